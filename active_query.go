@@ -0,0 +1,53 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ErrActiveQueryNotFound is the error msg for a missing active query.
+const ErrActiveQueryNotFound = "query not found"
+
+// ops for active query errors.
+const (
+	OpFindActiveQueryByID = "FindActiveQueryByID"
+	OpFindActiveQueries   = "FindActiveQueries"
+	OpCancelActiveQuery   = "CancelActiveQuery"
+)
+
+// ActiveQueryService represents a service for the queries that are run by a
+// server. Queries are no longer active once they finish.
+type ActiveQueryService interface {
+	// FindActiveQueryByID returns a single active query by ID.
+	FindActiveQueryByID(ctx context.Context, id ID) (*ActiveQuery, error)
+
+	// FindActiveQueries returns the active queries that match filter.
+	FindActiveQueries(ctx context.Context, filter ActiveQueryFilter) ([]*ActiveQuery, error)
+
+	// CancelActiveQuery cancels an active query by ID.
+	CancelActiveQuery(ctx context.Context, id ID) error
+}
+
+// ActiveQuery is a query that is run by a server.
+type ActiveQuery struct {
+	ID    ID `json:"id"`
+	OrgID ID `json:"orgID"`
+	// AuthorizationID is the ID of the authorization or session the query
+	// was run with and UserID the user it belongs to.
+	AuthorizationID ID `json:"authorizationID,omitempty"`
+	UserID          ID `json:"userID,omitempty"`
+	// CompilerType is the type of the compiler of the query, such as flux or influxql.
+	CompilerType string `json:"compilerType"`
+	// State is the state of the query, such as queueing or executing.
+	State     string        `json:"state"`
+	StartedAt time.Time     `json:"startedAt"`
+	Runtime   time.Duration `json:"runtime"`
+	// MemoryAllocated is the number of bytes the query currently has allocated.
+	MemoryAllocated int64  `json:"memoryAllocated"`
+	Query           string `json:"query"`
+}
+
+// ActiveQueryFilter represents a set of filter that restrict the returned active queries.
+type ActiveQueryFilter struct {
+	OrgID *ID
+}
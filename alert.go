@@ -0,0 +1,118 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ErrAlertAcknowledgementNotFound is the error msg for a missing alert acknowledgement.
+const ErrAlertAcknowledgementNotFound = "alert acknowledgement not found"
+
+// ops for alert acknowledgement error.
+const (
+	OpFindAlertAcknowledgementByID = "FindAlertAcknowledgementByID"
+	OpFindAlertAcknowledgements    = "FindAlertAcknowledgements"
+	OpCreateAlertAcknowledgement   = "CreateAlertAcknowledgement"
+	OpDeleteAlertAcknowledgement   = "DeleteAlertAcknowledgement"
+)
+
+// AlertAcknowledgementService represents a service for managing alert acknowledgements.
+type AlertAcknowledgementService interface {
+	// FindAlertAcknowledgementByID returns a single alert acknowledgement by ID.
+	FindAlertAcknowledgementByID(ctx context.Context, id ID) (*AlertAcknowledgement, error)
+
+	// FindAlertAcknowledgements returns a list of alert acknowledgements that match filter
+	// and the total count of matching alert acknowledgements.
+	FindAlertAcknowledgements(ctx context.Context, filter AlertAcknowledgementFilter) ([]*AlertAcknowledgement, int, error)
+
+	// CreateAlertAcknowledgement creates a new alert acknowledgement and sets a.ID with the new identifier.
+	// It replaces the previous acknowledgements of the same series.
+	CreateAlertAcknowledgement(ctx context.Context, a *AlertAcknowledgement) error
+
+	// DeleteAlertAcknowledgement removes an alert acknowledgement by ID.
+	DeleteAlertAcknowledgement(ctx context.Context, id ID) error
+}
+
+// AlertAcknowledgement acknowledges or resolves the alert of a series, that
+// is the statuses written by check CheckID with the Tags tag set. It mutes
+// the statuses of the series at Level from StartedAt on, until the series
+// changes level.
+type AlertAcknowledgement struct {
+	ID      ID    `json:"id,omitempty"`
+	OrgID   ID    `json:"orgID,omitempty"`
+	CheckID ID    `json:"checkID"`
+	Tags    []Tag `json:"tags,omitempty"`
+	// Level is the level of the alert, one of CRIT, WARN or INFO.
+	Level     string    `json:"level"`
+	StartedAt time.Time `json:"startedAt"`
+	// Resolved closes the alert instead of acknowledging it.
+	Resolved  bool   `json:"resolved"`
+	CreatedBy ID     `json:"createdBy,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+	CRUDLog
+}
+
+// Valid returns an error if the alert acknowledgement is invalid.
+func (a *AlertAcknowledgement) Valid() error {
+	if !a.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alert acknowledgement orgID is invalid",
+		}
+	}
+	if !a.CheckID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alert acknowledgement checkID is invalid",
+		}
+	}
+	for _, t := range a.Tags {
+		if err := t.Valid(); err != nil {
+			return err
+		}
+	}
+	switch a.Level {
+	case "CRIT", "WARN", "INFO":
+	default:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alert acknowledgement level must be one of CRIT, WARN or INFO",
+		}
+	}
+	if a.StartedAt.IsZero() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "alert acknowledgement startedAt is required",
+		}
+	}
+	return nil
+}
+
+// SameSeries returns true if a and b acknowledge the alerts of the same series.
+func (a *AlertAcknowledgement) SameSeries(b *AlertAcknowledgement) bool {
+	return a.OrgID == b.OrgID && a.CheckID == b.CheckID && sameTags(a.Tags, b.Tags)
+}
+
+// sameTags returns true if a and b hold the same tags in any order.
+func sameTags(a, b []Tag) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	values := make(map[string]string, len(a))
+	for _, t := range a {
+		values[t.Key] = t.Value
+	}
+	for _, t := range b {
+		if v, ok := values[t.Key]; !ok || v != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// AlertAcknowledgementFilter represents a set of filter that restrict the returned alert acknowledgements.
+type AlertAcknowledgementFilter struct {
+	OrgID        *ID
+	Organization *string
+	CheckID      *ID
+}
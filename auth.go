@@ -3,6 +3,7 @@ package influxdb
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // AuthorizationKind is returned by (*Authorization).Kind().
@@ -23,6 +24,21 @@ type Authorization struct {
 	OrgID       ID           `json:"orgID"`
 	UserID      ID           `json:"userID,omitempty"`
 	Permissions []Permission `json:"permissions"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
+
+	// RoleIDs are the roles whose permissions were added to the permissions
+	// of the authorization when it was created.
+	RoleIDs []ID `json:"roleIDs,omitempty"`
+
+	// LastUsedAt and LastUsedFrom record when and from which address the
+	// authorization was last used.
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
+	LastUsedFrom string     `json:"lastUsedFrom,omitempty"`
+
+	// PreviousToken is the token replaced by the last rotation. It is
+	// accepted until PreviousTokenExpiresAt.
+	PreviousToken          string     `json:"previousToken,omitempty"`
+	PreviousTokenExpiresAt *time.Time `json:"previousTokenExpiresAt,omitempty"`
 	CRUDLog
 }
 
@@ -46,6 +62,28 @@ func (a *Authorization) Valid() error {
 	return nil
 }
 
+// Expired returns an error if the authorization has expired.
+func (a *Authorization) Expired() error {
+	if a.ExpiresAt != nil && !time.Now().Before(*a.ExpiresAt) {
+		return &Error{
+			Code: EUnauthorized,
+			Msg:  "token has expired",
+		}
+	}
+
+	return nil
+}
+
+// AcceptsToken returns whether t is the token of the authorization, or its
+// previous token within the grace period of the last rotation.
+func (a *Authorization) AcceptsToken(t string) bool {
+	if t == a.Token {
+		return true
+	}
+	return a.PreviousToken != "" && t == a.PreviousToken &&
+		a.PreviousTokenExpiresAt != nil && time.Now().Before(*a.PreviousTokenExpiresAt)
+}
+
 // PermissionSet returns the set of permissions associated with the Authorization.
 func (a *Authorization) PermissionSet() (PermissionSet, error) {
 	if !a.IsActive() {
@@ -54,6 +92,9 @@ func (a *Authorization) PermissionSet() (PermissionSet, error) {
 			Msg:  "token is inactive",
 		}
 	}
+	if err := a.Expired(); err != nil {
+		return nil, err
+	}
 
 	return a.Permissions, nil
 }
@@ -87,6 +128,7 @@ const (
 	OpCreateAuthorization      = "CreateAuthorization"
 	OpUpdateAuthorization      = "UpdateAuthorization"
 	OpDeleteAuthorization      = "DeleteAuthorization"
+	OpRotateAuthorization      = "RotateAuthorization"
 )
 
 // AuthorizationService represents a service for managing authorization data.
@@ -109,6 +151,17 @@ type AuthorizationService interface {
 
 	// Removes a authorization by token.
 	DeleteAuthorization(ctx context.Context, id ID) error
+
+	// RotateAuthorization issues a new token for the authorization. The
+	// previous token remains valid for the grace period.
+	RotateAuthorization(ctx context.Context, id ID, grace time.Duration) (*Authorization, error)
+}
+
+// AuthorizationUsageService records the use of authorizations.
+type AuthorizationUsageService interface {
+	// SetAuthorizationLastUsed records that the authorization was used at
+	// the time from the address.
+	SetAuthorizationLastUsed(ctx context.Context, id ID, at time.Time, from string) error
 }
 
 // AuthorizationFilter represents a set of filter that restrict the returned results.
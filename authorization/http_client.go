@@ -3,6 +3,7 @@ package authorization
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/pkg/httpc"
@@ -104,3 +105,18 @@ func (s *AuthorizationClientService) DeleteAuthorization(ctx context.Context, id
 		Delete(prefixAuthorization, id.String()).
 		Do(ctx)
 }
+
+// RotateAuthorization issues a new token for the authorization. The previous
+// token remains valid for the grace period.
+func (s *AuthorizationClientService) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	var res authResponse
+	err := s.Client.
+		PostJSON(newRotateAuthorizationRequest(grace), prefixAuthorization, id.String(), "rotate").
+		DecodeJSON(&res).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.toInfluxdb(), nil
+}
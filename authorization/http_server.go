@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -30,6 +31,7 @@ type AuthHandler struct {
 	authSvc       influxdb.AuthorizationService
 	lookupService influxdb.LookupService
 	tenantService TenantService
+	roleService   RoleService
 }
 
 // NewHTTPAuthHandler constructs a new http server.
@@ -57,6 +59,7 @@ func NewHTTPAuthHandler(log *zap.Logger, authService influxdb.AuthorizationServi
 			r.Get("/", h.handleGetAuthorization)
 			r.Patch("/", h.handleUpdateAuthorization)
 			r.Delete("/", h.handleDeleteAuthorization)
+			r.Post("/rotate", h.handleRotateAuthorization)
 		})
 	})
 
@@ -64,6 +67,13 @@ func NewHTTPAuthHandler(log *zap.Logger, authService influxdb.AuthorizationServi
 	return h
 }
 
+// WithRoleService sets the role service used to look up the roles granted
+// to new authorizations. Without it, authorizations can not be created with
+// roles.
+func (h *AuthHandler) WithRoleService(rs RoleService) {
+	h.roleService = rs
+}
+
 const prefixAuthorization = "/api/v2/authorizations"
 
 func (h *AuthHandler) Prefix() string {
@@ -91,6 +101,10 @@ func (h *AuthHandler) handlePostAuthorization(w http.ResponseWriter, r *http.Req
 	}
 
 	auth := a.toInfluxdb(userID)
+	if err := ApplyRoles(ctx, h.roleService, auth); err != nil {
+		h.api.Err(w, r, err)
+		return
+	}
 
 	if err := h.authSvc.CreateAuthorization(ctx, auth); err != nil {
 		h.api.Err(w, r, err)
@@ -131,21 +145,27 @@ type postAuthorizationRequest struct {
 	UserID      *influxdb.ID          `json:"userID,omitempty"`
 	Description string                `json:"description"`
 	Permissions []influxdb.Permission `json:"permissions"`
+	RoleIDs     []influxdb.ID         `json:"roleIDs,omitempty"`
+	ExpiresAt   *time.Time            `json:"expiresAt,omitempty"`
 }
 
 type authResponse struct {
-	ID          influxdb.ID          `json:"id"`
-	Token       string               `json:"token"`
-	Status      influxdb.Status      `json:"status"`
-	Description string               `json:"description"`
-	OrgID       influxdb.ID          `json:"orgID"`
-	Org         string               `json:"org"`
-	UserID      influxdb.ID          `json:"userID"`
-	User        string               `json:"user"`
-	Permissions []permissionResponse `json:"permissions"`
-	Links       map[string]string    `json:"links"`
-	CreatedAt   time.Time            `json:"createdAt"`
-	UpdatedAt   time.Time            `json:"updatedAt"`
+	ID           influxdb.ID          `json:"id"`
+	Token        string               `json:"token"`
+	Status       influxdb.Status      `json:"status"`
+	Description  string               `json:"description"`
+	OrgID        influxdb.ID          `json:"orgID"`
+	Org          string               `json:"org"`
+	UserID       influxdb.ID          `json:"userID"`
+	User         string               `json:"user"`
+	Permissions  []permissionResponse `json:"permissions"`
+	RoleIDs      []influxdb.ID        `json:"roleIDs,omitempty"`
+	Links        map[string]string    `json:"links"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	UpdatedAt    time.Time            `json:"updatedAt"`
+	ExpiresAt    *time.Time           `json:"expiresAt,omitempty"`
+	LastUsedAt   *time.Time           `json:"lastUsedAt,omitempty"`
+	LastUsedFrom string               `json:"lastUsedFrom,omitempty"`
 }
 
 // In the future, we would like only the service layer to look up the user and org to see if they are valid
@@ -172,12 +192,16 @@ func (h *AuthHandler) newAuthResponse(ctx context.Context, a *influxdb.Authoriza
 		User:        user.Name,
 		Org:         org.Name,
 		Permissions: ps,
+		RoleIDs:     a.RoleIDs,
 		Links: map[string]string{
 			"self": fmt.Sprintf("/api/v2/authorizations/%s", a.ID),
 			"user": fmt.Sprintf("/api/v2/users/%s", a.UserID),
 		},
-		CreatedAt: a.CreatedAt,
-		UpdatedAt: a.UpdatedAt,
+		CreatedAt:    a.CreatedAt,
+		UpdatedAt:    a.UpdatedAt,
+		ExpiresAt:    a.ExpiresAt,
+		LastUsedAt:   a.LastUsedAt,
+		LastUsedFrom: a.LastUsedFrom,
 	}
 	return res, nil
 }
@@ -188,25 +212,31 @@ func (p *postAuthorizationRequest) toInfluxdb(userID influxdb.ID) *influxdb.Auth
 		Status:      p.Status,
 		Description: p.Description,
 		Permissions: p.Permissions,
+		RoleIDs:     p.RoleIDs,
 		UserID:      userID,
+		ExpiresAt:   p.ExpiresAt,
 	}
 }
 
 func (a *authResponse) toInfluxdb() *influxdb.Authorization {
 	res := &influxdb.Authorization{
-		ID:          a.ID,
-		Token:       a.Token,
-		Status:      a.Status,
-		Description: a.Description,
-		OrgID:       a.OrgID,
-		UserID:      a.UserID,
+		ID:           a.ID,
+		Token:        a.Token,
+		Status:       a.Status,
+		Description:  a.Description,
+		OrgID:        a.OrgID,
+		UserID:       a.UserID,
+		RoleIDs:      a.RoleIDs,
+		ExpiresAt:    a.ExpiresAt,
+		LastUsedAt:   a.LastUsedAt,
+		LastUsedFrom: a.LastUsedFrom,
 		CRUDLog: influxdb.CRUDLog{
 			CreatedAt: a.CreatedAt,
 			UpdatedAt: a.UpdatedAt,
 		},
 	}
 	for _, p := range a.Permissions {
-		res.Permissions = append(res.Permissions, influxdb.Permission{Action: p.Action, Resource: p.Resource.Resource})
+		res.Permissions = append(res.Permissions, influxdb.Permission{Action: p.Action, Resource: p.Resource.Resource, Scope: p.Scope})
 	}
 	return res
 }
@@ -231,7 +261,9 @@ func newPostAuthorizationRequest(a *influxdb.Authorization) (*postAuthorizationR
 		OrgID:       a.OrgID,
 		Description: a.Description,
 		Permissions: a.Permissions,
+		RoleIDs:     a.RoleIDs,
 		Status:      a.Status,
+		ExpiresAt:   a.ExpiresAt,
 	}
 
 	if a.UserID.Valid() {
@@ -250,7 +282,7 @@ func (p *postAuthorizationRequest) SetDefaults() {
 }
 
 func (p *postAuthorizationRequest) Validate() error {
-	if len(p.Permissions) == 0 {
+	if len(p.Permissions) == 0 && len(p.RoleIDs) == 0 {
 		return &influxdb.Error{
 			Code: influxdb.EInvalid,
 			Msg:  "authorization must include permissions",
@@ -282,12 +314,20 @@ func (p *postAuthorizationRequest) Validate() error {
 		return err
 	}
 
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "expiresAt must be in the future",
+		}
+	}
+
 	return nil
 }
 
 type permissionResponse struct {
-	Action   influxdb.Action  `json:"action"`
-	Resource resourceResponse `json:"resource"`
+	Action   influxdb.Action           `json:"action"`
+	Resource resourceResponse          `json:"resource"`
+	Scope    *influxdb.PermissionScope `json:"scope,omitempty"`
 }
 
 type resourceResponse struct {
@@ -304,6 +344,7 @@ func newPermissionsResponse(ctx context.Context, ps []influxdb.Permission, svc i
 			Resource: resourceResponse{
 				Resource: p.Resource,
 			},
+			Scope: p.Scope,
 		}
 
 		if p.Resource.ID != nil {
@@ -548,6 +589,74 @@ func decodeUpdateAuthorizationRequest(ctx context.Context, r *http.Request) (*up
 	}, nil
 }
 
+// handleRotateAuthorization is the HTTP handler for the POST /api/v2/authorizations/:id/rotate route that issues a new token for the authorization.
+func (h *AuthHandler) handleRotateAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, grace, err := decodeRotateAuthorizationRequest(ctx, r)
+	if err != nil {
+		h.log.Info("Failed to decode request", zap.String("handler", "rotateAuthorization"), zap.Error(err))
+		h.api.Err(w, r, err)
+		return
+	}
+
+	a, err := h.authSvc.RotateAuthorization(ctx, id, grace)
+	if err != nil {
+		h.api.Err(w, r, err)
+		return
+	}
+
+	ps, err := newPermissionsResponse(ctx, a.Permissions, h.lookupService)
+	if err != nil {
+		h.api.Err(w, r, err)
+		return
+	}
+	h.log.Debug("Auth rotated", zap.String("authID", fmt.Sprint(a.ID)))
+
+	resp, err := h.newAuthResponse(ctx, a, ps)
+	if err != nil {
+		h.api.Err(w, r, err)
+		return
+	}
+
+	h.api.Respond(w, r, http.StatusOK, resp)
+}
+
+type rotateAuthorizationRequest struct {
+	// GracePeriodSeconds is how long the previous token remains valid.
+	GracePeriodSeconds int64 `json:"gracePeriodSeconds"`
+}
+
+func newRotateAuthorizationRequest(grace time.Duration) *rotateAuthorizationRequest {
+	return &rotateAuthorizationRequest{
+		GracePeriodSeconds: int64(grace / time.Second),
+	}
+}
+
+func decodeRotateAuthorizationRequest(ctx context.Context, r *http.Request) (influxdb.ID, time.Duration, error) {
+	id, err := influxdb.IDFromString(chi.URLParam(r, "id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the request body is optional.
+	req := &rotateAuthorizationRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+	if req.GracePeriodSeconds < 0 {
+		return 0, 0, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "gracePeriodSeconds must not be negative",
+		}
+	}
+
+	return *id, time.Duration(req.GracePeriodSeconds) * time.Second, nil
+}
+
 // handleDeleteAuthorization is the HTTP handler for the DELETE /api/v2/authorizations/:id route.
 func (h *AuthHandler) handleDeleteAuthorization(w http.ResponseWriter, r *http.Request) {
 	id, err := influxdb.IDFromString(chi.URLParam(r, "id"))
@@ -2,7 +2,7 @@ package authorization
 
 import (
 	"context"
-	"fmt"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/authorizer"
@@ -102,16 +102,21 @@ func (s *AuthedAuthorizationService) DeleteAuthorization(ctx context.Context, id
 	return s.s.DeleteAuthorization(ctx, id)
 }
 
+func (s *AuthedAuthorizationService) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	a, err := s.s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := authorizer.AuthorizeWrite(ctx, influxdb.AuthorizationsResourceType, a.ID, a.OrgID); err != nil {
+		return nil, err
+	}
+	if _, _, err := authorizer.AuthorizeWriteResource(ctx, influxdb.UsersResourceType, a.UserID); err != nil {
+		return nil, err
+	}
+	return s.s.RotateAuthorization(ctx, id, grace)
+}
+
 // VerifyPermissions ensures that an authorization is allowed all of the appropriate permissions.
 func VerifyPermissions(ctx context.Context, ps []influxdb.Permission) error {
-	for _, p := range ps {
-		if err := authorizer.IsAllowed(ctx, p); err != nil {
-			return &influxdb.Error{
-				Err:  err,
-				Msg:  fmt.Sprintf("permission %s is not allowed", p),
-				Code: influxdb.EForbidden,
-			}
-		}
-	}
-	return nil
+	return authorizer.VerifyPermissions(ctx, ps)
 }
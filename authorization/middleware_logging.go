@@ -98,3 +98,16 @@ func (l *AuthLogger) DeleteAuthorization(ctx context.Context, id influxdb.ID) (e
 	}(time.Now())
 	return l.authService.DeleteAuthorization(ctx, id)
 }
+
+func (l *AuthLogger) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (a *influxdb.Authorization, err error) {
+	defer func(start time.Time) {
+		dur := zap.Duration("took", time.Since(start))
+		if err != nil {
+			msg := fmt.Sprintf("failed to rotate authorization with ID %v", id)
+			l.logger.Debug(msg, zap.Error(err), dur)
+			return
+		}
+		l.logger.Debug("authorization rotate", dur)
+	}(time.Now())
+	return l.authService.RotateAuthorization(ctx, id, grace)
+}
@@ -2,6 +2,7 @@ package authorization
 
 import (
 	"context"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/kit/metric"
@@ -58,3 +59,9 @@ func (m *AuthMetrics) DeleteAuthorization(ctx context.Context, id influxdb.ID) e
 	err := m.authService.DeleteAuthorization(ctx, id)
 	return rec(err)
 }
+
+func (m *AuthMetrics) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	rec := m.rec.Record("rotate_authorization")
+	a, err := m.authService.RotateAuthorization(ctx, id, grace)
+	return a, rec(err)
+}
@@ -0,0 +1,43 @@
+package authorization
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+// RoleService is used to look up the roles granted to an Authorization.
+type RoleService interface {
+	FindRoleByID(ctx context.Context, id influxdb.ID) (*influxdb.Role, error)
+}
+
+// ApplyRoles adds the permissions of the roles of the authorization to its
+// permissions. The roles must belong to the organization of the
+// authorization. Later changes to the roles do not change the authorization.
+func ApplyRoles(ctx context.Context, rs RoleService, a *influxdb.Authorization) error {
+	if len(a.RoleIDs) == 0 {
+		return nil
+	}
+	if rs == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "roles are not supported",
+		}
+	}
+
+	for _, id := range a.RoleIDs {
+		r, err := rs.FindRoleByID(ctx, id)
+		if err != nil {
+			return err
+		}
+		if r.OrgID != a.OrgID {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("role %s is not for org id %s", id, a.OrgID),
+			}
+		}
+		a.Permissions = append(a.Permissions, r.Permissions...)
+	}
+	return nil
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/influxdata/influxdb/v2/rand"
 )
 
-var _ influxdb.AuthorizationService = (*Service)(nil)
+var (
+	_ influxdb.AuthorizationService      = (*Service)(nil)
+	_ influxdb.AuthorizationUsageService = (*Service)(nil)
+)
 
 type Service struct {
 	store          *Store
@@ -214,3 +217,67 @@ func (s *Service) DeleteAuthorization(ctx context.Context, id influxdb.ID) error
 		return s.store.DeleteAuthorization(ctx, tx, id)
 	})
 }
+
+// RotateAuthorization issues a new token for the authorization. The previous
+// token remains valid for the grace period.
+func (s *Service) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	token, err := s.tokenGenerator.Token()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+
+	var auth *influxdb.Authorization
+	err = s.store.Update(ctx, func(tx kv.Tx) error {
+		a, err := s.store.GetAuthorizationByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.store.uniqueAuthToken(ctx, tx, &influxdb.Authorization{Token: token}); err != nil {
+			return err
+		}
+
+		// only the token replaced by this rotation remains valid.
+		if a.PreviousToken != "" {
+			if err := s.store.DeleteAuthorizationToken(ctx, tx, a.PreviousToken); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+		if grace > 0 {
+			expiresAt := now.Add(grace)
+			a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+		} else if err := s.store.DeleteAuthorizationToken(ctx, tx, a.Token); err != nil {
+			return err
+		}
+		a.Token = token
+		a.SetUpdatedAt(now)
+
+		auth, err = s.store.UpdateAuthorization(ctx, tx, id, a)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return auth, nil
+}
+
+// SetAuthorizationLastUsed records that the authorization was used at the time
+// from the address.
+func (s *Service) SetAuthorizationLastUsed(ctx context.Context, id influxdb.ID, at time.Time, from string) error {
+	return s.store.Update(ctx, func(tx kv.Tx) error {
+		a, err := s.store.GetAuthorizationByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		a.LastUsedAt, a.LastUsedFrom = &at, from
+		_, err = s.store.UpdateAuthorization(ctx, tx, id, a)
+		return err
+	})
+}
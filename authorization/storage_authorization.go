@@ -164,7 +164,19 @@ func (s *Store) GetAuthorizationByToken(ctx context.Context, tx kv.Tx, token str
 		}
 	}
 
-	return s.GetAuthorizationByID(ctx, tx, id)
+	a, err := s.GetAuthorizationByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index keeps the previous token of a rotated authorization.
+	if !a.AcceptsToken(token) {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "authorization not found",
+		}
+	}
+	return a, nil
 }
 
 // ListAuthorizations returns all the authorizations matching a set of FindOptions. This function is used for
@@ -265,6 +277,20 @@ func (s *Store) UpdateAuthorization(ctx context.Context, tx kv.Tx, id influxdb.I
 
 }
 
+// DeleteAuthorizationToken removes a token of an authorization from the index.
+func (s *Store) DeleteAuthorizationToken(ctx context.Context, tx kv.Tx, token string) error {
+	idx, err := authIndexBucket(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := idx.Delete(authIndexKey(token)); err != nil {
+		return ErrInternalServiceError(err)
+	}
+
+	return nil
+}
+
 // DeleteAuthorization removes an authorization from storage
 func (s *Store) DeleteAuthorization(ctx context.Context, tx kv.Tx, id influxdb.ID) error {
 	a, err := s.GetAuthorizationByID(ctx, tx, id)
@@ -291,6 +317,12 @@ func (s *Store) DeleteAuthorization(ctx context.Context, tx kv.Tx, id influxdb.I
 		return ErrInternalServiceError(err)
 	}
 
+	if a.PreviousToken != "" {
+		if err := idx.Delete([]byte(a.PreviousToken)); err != nil {
+			return ErrInternalServiceError(err)
+		}
+	}
+
 	if err := b.Delete(encodedID); err != nil {
 		return ErrInternalServiceError(err)
 	}
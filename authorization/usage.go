@@ -0,0 +1,79 @@
+package authorization
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"go.uber.org/zap"
+)
+
+// LastUsedInterval is the minimum time between two records of the use of an
+// authorization from the same address.
+const LastUsedInterval = time.Minute
+
+// UsageRecorder records the last use of authorizations in the background, so
+// that authenticating a request does not wait for a write.
+type UsageRecorder struct {
+	log *zap.Logger
+	svc influxdb.AuthorizationUsageService
+
+	mu      sync.Mutex
+	pending map[influxdb.ID]struct{}
+}
+
+// NewUsageRecorder constructs a UsageRecorder recording to svc.
+func NewUsageRecorder(log *zap.Logger, svc influxdb.AuthorizationUsageService) *UsageRecorder {
+	return &UsageRecorder{
+		log:     log,
+		svc:     svc,
+		pending: make(map[influxdb.ID]struct{}),
+	}
+}
+
+// Record records that the authorization was used by the request. The use is
+// not recorded if the last use was recorded from the same address less than
+// LastUsedInterval ago, or if a record of the authorization is in progress.
+func (u *UsageRecorder) Record(a *influxdb.Authorization, r *http.Request) {
+	if u == nil {
+		return
+	}
+
+	now := time.Now()
+	from := remoteHost(r)
+	if a.LastUsedAt != nil && a.LastUsedFrom == from && now.Sub(*a.LastUsedAt) < LastUsedInterval {
+		return
+	}
+
+	u.mu.Lock()
+	if _, ok := u.pending[a.ID]; ok {
+		u.mu.Unlock()
+		return
+	}
+	u.pending[a.ID] = struct{}{}
+	u.mu.Unlock()
+
+	go func(id influxdb.ID) {
+		defer func() {
+			u.mu.Lock()
+			delete(u.pending, id)
+			u.mu.Unlock()
+		}()
+
+		if err := u.svc.SetAuthorizationLastUsed(context.Background(), id, now, from); err != nil {
+			u.log.Info("Failed to record authorization use", zap.String("authID", id.String()), zap.Error(err))
+		}
+	}(a.ID)
+}
+
+// remoteHost returns the host of the remote address of the request.
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,56 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryService wraps a influxdb.ActiveQueryService and authorizes actions
+// against it appropriately. The queries of an org are authorized together, as
+// a permission on a single query cannot be given before the query runs.
+type ActiveQueryService struct {
+	s influxdb.ActiveQueryService
+}
+
+// NewActiveQueryService constructs an instance of an authorizing active query service.
+func NewActiveQueryService(s influxdb.ActiveQueryService) *ActiveQueryService {
+	return &ActiveQueryService{
+		s: s,
+	}
+}
+
+// FindActiveQueryByID checks to see if the authorizer on context has read access to the queries of the query's org.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+	q, err := s.s.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeOrgReadResource(ctx, influxdb.QueriesResourceType, q.OrgID); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// FindActiveQueries retrieves all active queries that match the provided filter and then filters the list down to only the queries that are authorized.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+	qs, err := s.s.FindActiveQueries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return AuthorizeFindActiveQueries(ctx, qs)
+}
+
+// CancelActiveQuery checks to see if the authorizer on context has write access to the queries of the query's org.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	q, err := s.s.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := AuthorizeOrgWriteResource(ctx, influxdb.QueriesResourceType, q.OrgID); err != nil {
+		return err
+	}
+	return s.s.CancelActiveQuery(ctx, id)
+}
@@ -0,0 +1,137 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+)
+
+func newActiveQueryService() *mock.ActiveQueryService {
+	queries := []*influxdb.ActiveQuery{
+		{ID: 10, OrgID: 1},
+		{ID: 20, OrgID: 2},
+	}
+	svc := mock.NewActiveQueryService()
+	svc.FindActiveQueryByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+		for _, q := range queries {
+			if q.ID == id {
+				return q, nil
+			}
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: influxdb.ErrActiveQueryNotFound}
+	}
+	svc.FindActiveQueriesFn = func(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+		return append([]*influxdb.ActiveQuery(nil), queries...), nil
+	}
+	svc.CancelActiveQueryFn = func(ctx context.Context, id influxdb.ID) error {
+		return nil
+	}
+	return svc
+}
+
+func TestActiveQueryService_FindActiveQueries(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		want        []influxdb.ID
+	}{
+		{
+			name: "authorized to read all queries",
+			permissions: []influxdb.Permission{{
+				Action:   influxdb.ReadAction,
+				Resource: influxdb.Resource{Type: influxdb.QueriesResourceType},
+			}},
+			want: []influxdb.ID{10, 20},
+		},
+		{
+			name: "authorized to read the queries of an org",
+			permissions: []influxdb.Permission{{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.QueriesResourceType,
+					OrgID: influxdbtesting.IDPtr(2),
+				},
+			}},
+			want: []influxdb.ID{20},
+		},
+		{
+			name: "unauthorized to read queries",
+			permissions: []influxdb.Permission{{
+				Action:   influxdb.ReadAction,
+				Resource: influxdb.Resource{Type: influxdb.BucketsResourceType},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewActiveQueryService(newActiveQueryService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, tt.permissions))
+
+			qs, err := s.FindActiveQueries(ctx, influxdb.ActiveQueryFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []influxdb.ID
+			for _, q := range qs {
+				got = append(got, q.ID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected queries -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestActiveQueryService_CancelActiveQuery(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission influxdb.Permission
+		err        error
+	}{
+		{
+			name: "authorized to cancel the queries of the org",
+			permission: influxdb.Permission{
+				Action: influxdb.WriteAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.QueriesResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+			},
+		},
+		{
+			name: "authorized to read the queries of the org",
+			permission: influxdb.Permission{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.QueriesResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+			},
+			err: &influxdb.Error{
+				Msg:  "write:orgs/0000000000000001/queries is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewActiveQueryService(newActiveQueryService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, []influxdb.Permission{tt.permission}))
+
+			err := s.CancelActiveQuery(ctx, 10)
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
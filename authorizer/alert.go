@@ -0,0 +1,89 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification/alert"
+)
+
+var _ influxdb.AlertAcknowledgementService = (*AlertAcknowledgementService)(nil)
+
+// AlertAcknowledgementService wraps a influxdb.AlertAcknowledgementService and authorizes actions
+// against it appropriately. An alert acknowledgement mutes the notification rules of its
+// organization, so it is authorized against the notification rules of the org.
+type AlertAcknowledgementService struct {
+	s influxdb.AlertAcknowledgementService
+}
+
+// NewAlertAcknowledgementService constructs an instance of an authorizing alert acknowledgement service.
+func NewAlertAcknowledgementService(s influxdb.AlertAcknowledgementService) *AlertAcknowledgementService {
+	return &AlertAcknowledgementService{
+		s: s,
+	}
+}
+
+// FindAlertAcknowledgementByID checks to see if the authorizer on context has read access to the notification rules of the acknowledgement's org.
+func (s *AlertAcknowledgementService) FindAlertAcknowledgementByID(ctx context.Context, id influxdb.ID) (*influxdb.AlertAcknowledgement, error) {
+	a, err := s.s.FindAlertAcknowledgementByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeOrgReadResource(ctx, influxdb.NotificationRuleResourceType, a.OrgID); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// FindAlertAcknowledgements retrieves all alert acknowledgements that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *AlertAcknowledgementService) FindAlertAcknowledgements(ctx context.Context, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, int, error) {
+	as, _, err := s.s.FindAlertAcknowledgements(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return AuthorizeFindAlertAcknowledgements(ctx, as)
+}
+
+// CreateAlertAcknowledgement checks to see if the authorizer on context has write access to the notification rules of the org.
+func (s *AlertAcknowledgementService) CreateAlertAcknowledgement(ctx context.Context, a *influxdb.AlertAcknowledgement) error {
+	if _, _, err := AuthorizeOrgWriteResource(ctx, influxdb.NotificationRuleResourceType, a.OrgID); err != nil {
+		return err
+	}
+	return s.s.CreateAlertAcknowledgement(ctx, a)
+}
+
+// DeleteAlertAcknowledgement checks to see if the authorizer on context has write access to the notification rules of the acknowledgement's org.
+func (s *AlertAcknowledgementService) DeleteAlertAcknowledgement(ctx context.Context, id influxdb.ID) error {
+	a, err := s.s.FindAlertAcknowledgementByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := AuthorizeOrgWriteResource(ctx, influxdb.NotificationRuleResourceType, a.OrgID); err != nil {
+		return err
+	}
+	return s.s.DeleteAlertAcknowledgement(ctx, id)
+}
+
+var _ alert.Service = (*AlertService)(nil)
+
+// AlertService wraps a alert.Service and authorizes actions against it appropriately.
+// Alerts are derived from the statuses of checks, so they are authorized against
+// the checks of the org.
+type AlertService struct {
+	s alert.Service
+}
+
+// NewAlertService constructs an instance of an authorizing alert service.
+func NewAlertService(s alert.Service) *AlertService {
+	return &AlertService{
+		s: s,
+	}
+}
+
+// FindAlerts checks to see if the authorizer on context has read access to the checks of the org.
+func (s *AlertService) FindAlerts(ctx context.Context, filter alert.Filter) ([]*alert.Alert, error) {
+	if _, _, err := AuthorizeOrgReadResource(ctx, influxdb.ChecksResourceType, filter.OrgID); err != nil {
+		return nil, err
+	}
+	return s.s.FindAlerts(ctx, filter)
+}
@@ -3,8 +3,10 @@ package authorizer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
+	icontext "github.com/influxdata/influxdb/v2/context"
 )
 
 var _ influxdb.AuthorizationService = (*AuthorizationService)(nil)
@@ -92,6 +94,21 @@ func (s *AuthorizationService) UpdateAuthorization(ctx context.Context, id influ
 	return s.s.UpdateAuthorization(ctx, id, upd)
 }
 
+// RotateAuthorization checks to see if the authorizer on context has write access to the authorization provided.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	a, err := s.s.FindAuthorizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeWrite(ctx, influxdb.AuthorizationsResourceType, a.ID, a.OrgID); err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeWriteResource(ctx, influxdb.UsersResourceType, a.UserID); err != nil {
+		return nil, err
+	}
+	return s.s.RotateAuthorization(ctx, id, grace)
+}
+
 // DeleteAuthorization checks to see if the authorizer on context has write access to the authorization provided.
 func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id influxdb.ID) error {
 	a, err := s.s.FindAuthorizationByID(ctx, id)
@@ -108,11 +125,20 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id influ
 }
 
 // VerifyPermissions ensures that an authorization is allowed all of the appropriate permissions.
+// A permission restricted to a scope must be within the scopes of the permissions
+// that allow it.
 func VerifyPermissions(ctx context.Context, ps []influxdb.Permission) error {
+	a, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+	pset, err := a.PermissionSet()
+	if err != nil {
+		return err
+	}
 	for _, p := range ps {
-		if err := IsAllowed(ctx, p); err != nil {
+		if !pset.Covers(p) {
 			return &influxdb.Error{
-				Err:  err,
 				Msg:  fmt.Sprintf("permission %s is not allowed", p),
 				Code: influxdb.EForbidden,
 			}
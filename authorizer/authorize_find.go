@@ -283,6 +283,78 @@ func AuthorizeFindChecks(ctx context.Context, rs []influxdb.Check) ([]influxdb.C
 	return rrs, len(rrs), nil
 }
 
+// AuthorizeFindSilences takes the given items and returns only the ones that the user is authorized to read.
+func AuthorizeFindSilences(ctx context.Context, rs []*influxdb.Silence) ([]*influxdb.Silence, int, error) {
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	rrs := rs[:0]
+	for _, r := range rs {
+		_, _, err := AuthorizeOrgReadResource(ctx, influxdb.NotificationRuleResourceType, r.OrgID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		rrs = append(rrs, r)
+	}
+	return rrs, len(rrs), nil
+}
+
+// AuthorizeFindAlertAcknowledgements takes the given items and returns only the ones that the user is authorized to read.
+func AuthorizeFindAlertAcknowledgements(ctx context.Context, rs []*influxdb.AlertAcknowledgement) ([]*influxdb.AlertAcknowledgement, int, error) {
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	rrs := rs[:0]
+	for _, r := range rs {
+		_, _, err := AuthorizeOrgReadResource(ctx, influxdb.NotificationRuleResourceType, r.OrgID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		rrs = append(rrs, r)
+	}
+	return rrs, len(rrs), nil
+}
+
+// AuthorizeFindActiveQueries takes the given items and returns only the ones that the user is authorized to read.
+func AuthorizeFindActiveQueries(ctx context.Context, rs []*influxdb.ActiveQuery) ([]*influxdb.ActiveQuery, error) {
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	rrs := rs[:0]
+	for _, r := range rs {
+		_, _, err := AuthorizeOrgReadResource(ctx, influxdb.QueriesResourceType, r.OrgID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, err
+		}
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		rrs = append(rrs, r)
+	}
+	return rrs, nil
+}
+
+// AuthorizeFindRoles takes the given items and returns only the ones that the user is authorized to read.
+func AuthorizeFindRoles(ctx context.Context, rs []*influxdb.Role) ([]*influxdb.Role, int, error) {
+	// This filters without allocating
+	// https://github.com/golang/go/wiki/SliceTricks#filtering-without-allocating
+	rrs := rs[:0]
+	for _, r := range rs {
+		_, _, err := AuthorizeRead(ctx, influxdb.RolesResourceType, r.ID, r.OrgID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		rrs = append(rrs, r)
+	}
+	return rrs, len(rrs), nil
+}
+
 // AuthorizeFindUserResourceMappings takes the given items and returns only the ones that the user is authorized to read.
 func AuthorizeFindUserResourceMappings(ctx context.Context, os OrganizationService, rs []*influxdb.UserResourceMapping) ([]*influxdb.UserResourceMapping, int, error) {
 	// This filters without allocating
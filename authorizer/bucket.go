@@ -93,25 +93,25 @@ func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) er
 	return s.s.CreateBucket(ctx, b)
 }
 
-// UpdateBucket checks to see if the authorizer on context has write access to the bucket provided.
+// UpdateBucket checks to see if the authorizer on context has write access to all of the bucket provided.
 func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
 	b, err := s.s.FindBucketByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
-	if _, _, err := AuthorizeWrite(ctx, influxdb.BucketsResourceType, id, b.OrgID); err != nil {
+	if _, _, err := AuthorizeWriteBucket(ctx, id, b.OrgID); err != nil {
 		return nil, err
 	}
 	return s.s.UpdateBucket(ctx, id, upd)
 }
 
-// DeleteBucket checks to see if the authorizer on context has write access to the bucket provided.
+// DeleteBucket checks to see if the authorizer on context has write access to all of the bucket provided.
 func (s *BucketService) DeleteBucket(ctx context.Context, id influxdb.ID) error {
 	b, err := s.s.FindBucketByID(ctx, id)
 	if err != nil {
 		return err
 	}
-	if _, _, err := AuthorizeWrite(ctx, influxdb.BucketsResourceType, id, b.OrgID); err != nil {
+	if _, _, err := AuthorizeWriteBucket(ctx, id, b.OrgID); err != nil {
 		return err
 	}
 	return s.s.DeleteBucket(ctx, id)
@@ -425,6 +425,46 @@ func TestBucketService_UpdateBucket(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unauthorized to update bucket with a scoped permission",
+			fields: fields{
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctc context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+						return &influxdb.Bucket{
+							ID:    1,
+							OrgID: 10,
+						}, nil
+					},
+					UpdateBucketFn: func(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+						return &influxdb.Bucket{
+							ID:    1,
+							OrgID: 10,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				permissions: []influxdb.Permission{
+					{
+						Action: "write",
+						Resource: influxdb.Resource{
+							Type: influxdb.BucketsResourceType,
+							ID:   influxdbtesting.IDPtr(1),
+						},
+						Scope: &influxdb.PermissionScope{
+							Measurements: []string{"cpu"},
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/buckets/0000000000000001 is unauthorized with permissions restricted to a scope",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -530,6 +570,43 @@ func TestBucketService_DeleteBucket(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "unauthorized to delete bucket with a scoped permission",
+			fields: fields{
+				BucketService: &mock.BucketService{
+					FindBucketByIDFn: func(ctc context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+						return &influxdb.Bucket{
+							ID:    1,
+							OrgID: 10,
+						}, nil
+					},
+					DeleteBucketFn: func(ctx context.Context, id influxdb.ID) error {
+						return nil
+					},
+				},
+			},
+			args: args{
+				id: 1,
+				permissions: []influxdb.Permission{
+					{
+						Action: "write",
+						Resource: influxdb.Resource{
+							Type: influxdb.BucketsResourceType,
+							ID:   influxdbtesting.IDPtr(1),
+						},
+						Scope: &influxdb.PermissionScope{
+							Measurements: []string{"cpu"},
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "write:orgs/000000000000000a/buckets/0000000000000001 is unauthorized with permissions restricted to a scope",
+					Code: influxdb.EUnauthorized,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,39 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.OrganizationSettingsService = (*OrgSettingsService)(nil)
+
+// OrgSettingsService wraps a influxdb.OrganizationSettingsService and authorizes
+// actions against it appropriately. The settings limit what the organization may
+// use of the server, so only those who can write to every organization may update them.
+type OrgSettingsService struct {
+	s influxdb.OrganizationSettingsService
+}
+
+// NewOrgSettingsService constructs an instance of an authorizing organization settings service.
+func NewOrgSettingsService(s influxdb.OrganizationSettingsService) *OrgSettingsService {
+	return &OrgSettingsService{
+		s: s,
+	}
+}
+
+// FindOrganizationSettings checks to see if the authorizer on context has read access to the org.
+func (s *OrgSettingsService) FindOrganizationSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+	if _, _, err := AuthorizeReadOrg(ctx, orgID); err != nil {
+		return nil, err
+	}
+	return s.s.FindOrganizationSettings(ctx, orgID)
+}
+
+// UpdateOrganizationSettings checks to see if the authorizer on context has write access to all orgs.
+func (s *OrgSettingsService) UpdateOrganizationSettings(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+	if _, _, err := AuthorizeWriteGlobal(ctx, influxdb.OrgsResourceType); err != nil {
+		return nil, err
+	}
+	return s.s.UpdateOrganizationSettings(ctx, orgID, upd)
+}
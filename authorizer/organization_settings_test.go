@@ -0,0 +1,115 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+)
+
+func newOrgSettingsService() *mock.OrganizationSettingsService {
+	svc := mock.NewOrganizationSettingsService()
+	svc.FindOrganizationSettingsFn = func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+		return &influxdb.OrganizationSettings{OrgID: orgID}, nil
+	}
+	svc.UpdateOrganizationSettingsFn = func(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+		s := &influxdb.OrganizationSettings{OrgID: orgID}
+		upd.Apply(s)
+		return s, nil
+	}
+	return svc
+}
+
+func TestOrgSettingsService_FindOrganizationSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission influxdb.Permission
+		err        error
+	}{
+		{
+			name: "authorized to read the org",
+			permission: influxdb.Permission{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type: influxdb.OrgsResourceType,
+					ID:   influxdbtesting.IDPtr(1),
+				},
+			},
+		},
+		{
+			name: "unauthorized to read the org",
+			permission: influxdb.Permission{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type: influxdb.OrgsResourceType,
+					ID:   influxdbtesting.IDPtr(2),
+				},
+			},
+			err: &influxdb.Error{
+				Msg:  "read:orgs/0000000000000001 is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewOrgSettingsService(newOrgSettingsService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, []influxdb.Permission{tt.permission}))
+
+			_, err := s.FindOrganizationSettings(ctx, 1)
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
+
+func TestOrgSettingsService_UpdateOrganizationSettings(t *testing.T) {
+	tests := []struct {
+		name       string
+		permission influxdb.Permission
+		err        error
+	}{
+		{
+			name: "authorized to write all orgs",
+			permission: influxdb.Permission{
+				Action: influxdb.WriteAction,
+				Resource: influxdb.Resource{
+					Type: influxdb.OrgsResourceType,
+				},
+			},
+		},
+		{
+			name: "unauthorized with write access to the org only",
+			permission: influxdb.Permission{
+				Action: influxdb.WriteAction,
+				Resource: influxdb.Resource{
+					Type: influxdb.OrgsResourceType,
+					ID:   influxdbtesting.IDPtr(1),
+				},
+			},
+			err: &influxdb.Error{
+				Msg:  "write:orgs is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewOrgSettingsService(newOrgSettingsService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, []influxdb.Permission{tt.permission}))
+
+			weight := 2
+			_, err := s.UpdateOrganizationSettings(ctx, 1, influxdb.OrganizationSettingsUpdate{QueryWeight: &weight})
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
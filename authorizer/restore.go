@@ -0,0 +1,34 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+)
+
+var _ influxdb.RestoreService = (*RestoreService)(nil)
+
+// RestoreService wraps a influxdb.RestoreService and authorizes actions
+// against it appropriately.
+type RestoreService struct {
+	s influxdb.RestoreService
+}
+
+// NewRestoreService constructs an instance of an authorizing restore service.
+func NewRestoreService(s influxdb.RestoreService) *RestoreService {
+	return &RestoreService{
+		s: s,
+	}
+}
+
+// RestoreBucketFile checks to see if the authorizer on context has write access to all of the target bucket.
+func (s *RestoreService) RestoreBucketFile(ctx context.Context, source, target influxdb.BackupBucket, path string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if _, _, err := AuthorizeWriteBucket(ctx, target.BucketID, target.OrgID); err != nil {
+		return err
+	}
+	return s.s.RestoreBucketFile(ctx, source, target, path)
+}
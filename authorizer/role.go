@@ -0,0 +1,85 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.RoleService = (*RoleService)(nil)
+
+// RoleService wraps a influxdb.RoleService and authorizes actions
+// against it appropriately. Creating or changing a role also requires the
+// authorizer on context to have the permissions the role grants, so that a
+// role can not be used to escalate privileges.
+type RoleService struct {
+	s influxdb.RoleService
+}
+
+// NewRoleService constructs an instance of an authorizing role service.
+func NewRoleService(s influxdb.RoleService) *RoleService {
+	return &RoleService{
+		s: s,
+	}
+}
+
+// FindRoleByID checks to see if the authorizer on context has read access to the id provided.
+func (s *RoleService) FindRoleByID(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+	r, err := s.s.FindRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeRead(ctx, influxdb.RolesResourceType, r.ID, r.OrgID); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// FindRoles retrieves all roles that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *RoleService) FindRoles(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+	rs, _, err := s.s.FindRoles(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return AuthorizeFindRoles(ctx, rs)
+}
+
+// CreateRole checks to see if the authorizer on context has write access to the roles of the org
+// and has the permissions of the role.
+func (s *RoleService) CreateRole(ctx context.Context, r *influxdb.Role) error {
+	if _, _, err := AuthorizeCreate(ctx, influxdb.RolesResourceType, r.OrgID); err != nil {
+		return err
+	}
+	if err := VerifyPermissions(ctx, r.Permissions); err != nil {
+		return err
+	}
+	return s.s.CreateRole(ctx, r)
+}
+
+// UpdateRole checks to see if the authorizer on context has write access to the role provided
+// and has the permissions it is updated with.
+func (s *RoleService) UpdateRole(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+	r, err := s.FindRoleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeWrite(ctx, influxdb.RolesResourceType, r.ID, r.OrgID); err != nil {
+		return nil, err
+	}
+	if err := VerifyPermissions(ctx, upd.Permissions); err != nil {
+		return nil, err
+	}
+	return s.s.UpdateRole(ctx, id, upd)
+}
+
+// DeleteRole checks to see if the authorizer on context has write access to the role provided.
+func (s *RoleService) DeleteRole(ctx context.Context, id influxdb.ID) error {
+	r, err := s.FindRoleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := AuthorizeWrite(ctx, influxdb.RolesResourceType, r.ID, r.OrgID); err != nil {
+		return err
+	}
+	return s.s.DeleteRole(ctx, id)
+}
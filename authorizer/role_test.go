@@ -0,0 +1,220 @@
+package authorizer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+)
+
+func newRoleService() *mock.RoleService {
+	roles := []*influxdb.Role{
+		{ID: 10, OrgID: 1, Name: "analyst"},
+		{ID: 20, OrgID: 2, Name: "analyst"},
+	}
+	svc := mock.NewRoleService()
+	svc.FindRoleByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+		for _, r := range roles {
+			if r.ID == id {
+				return r, nil
+			}
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: influxdb.ErrRoleNotFound}
+	}
+	svc.FindRolesFn = func(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+		return append([]*influxdb.Role(nil), roles...), len(roles), nil
+	}
+	svc.CreateRoleFn = func(ctx context.Context, r *influxdb.Role) error {
+		return nil
+	}
+	svc.UpdateRoleFn = func(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+		return &influxdb.Role{ID: id, OrgID: 1}, nil
+	}
+	return svc
+}
+
+func TestRoleService_FindRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		want        []influxdb.ID
+	}{
+		{
+			name: "authorized to read all roles",
+			permissions: []influxdb.Permission{{
+				Action:   influxdb.ReadAction,
+				Resource: influxdb.Resource{Type: influxdb.RolesResourceType},
+			}},
+			want: []influxdb.ID{10, 20},
+		},
+		{
+			name: "authorized to read the roles of an org",
+			permissions: []influxdb.Permission{{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.RolesResourceType,
+					OrgID: influxdbtesting.IDPtr(2),
+				},
+			}},
+			want: []influxdb.ID{20},
+		},
+		{
+			name: "unauthorized to read roles",
+			permissions: []influxdb.Permission{{
+				Action:   influxdb.ReadAction,
+				Resource: influxdb.Resource{Type: influxdb.BucketsResourceType},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewRoleService(newRoleService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, tt.permissions))
+
+			rs, _, err := s.FindRoles(ctx, influxdb.RoleFilter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []influxdb.ID
+			for _, r := range rs {
+				got = append(got, r.ID)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("unexpected roles -want/+got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRoleService_CreateRole(t *testing.T) {
+	writeRoles := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.RolesResourceType,
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+	readBuckets := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		err         error
+	}{
+		{
+			name:        "authorized to write roles with the permissions of the role",
+			permissions: []influxdb.Permission{writeRoles, readBuckets},
+		},
+		{
+			name:        "authorized to write roles without the permissions of the role",
+			permissions: []influxdb.Permission{writeRoles},
+			err: &influxdb.Error{
+				Msg:  "permission read:orgs/0000000000000001/buckets is not allowed",
+				Code: influxdb.EForbidden,
+			},
+		},
+		{
+			name:        "unauthorized to write roles",
+			permissions: []influxdb.Permission{readBuckets},
+			err: &influxdb.Error{
+				Msg:  "write:orgs/0000000000000001/roles is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewRoleService(newRoleService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, tt.permissions))
+
+			err := s.CreateRole(ctx, &influxdb.Role{
+				OrgID:       1,
+				Name:        "analyst",
+				Permissions: []influxdb.Permission{readBuckets},
+			})
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
+
+func TestRoleService_UpdateRole(t *testing.T) {
+	writeRole := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type: influxdb.RolesResourceType,
+			ID:   influxdbtesting.IDPtr(10),
+		},
+	}
+	readRole := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type: influxdb.RolesResourceType,
+			ID:   influxdbtesting.IDPtr(10),
+		},
+	}
+	writeBuckets := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		err         error
+	}{
+		{
+			name:        "authorized to update the role with its new permissions",
+			permissions: []influxdb.Permission{readRole, writeRole, writeBuckets},
+		},
+		{
+			name:        "authorized to update the role without its new permissions",
+			permissions: []influxdb.Permission{readRole, writeRole},
+			err: &influxdb.Error{
+				Msg:  "permission write:orgs/0000000000000001/buckets is not allowed",
+				Code: influxdb.EForbidden,
+			},
+		},
+		{
+			name:        "authorized to read the role",
+			permissions: []influxdb.Permission{readRole, writeBuckets},
+			err: &influxdb.Error{
+				Msg:  "write:orgs/0000000000000001/roles/000000000000000a is unauthorized",
+				Code: influxdb.EUnauthorized,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := authorizer.NewRoleService(newRoleService())
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, tt.permissions))
+
+			_, err := s.UpdateRole(ctx, 10, influxdb.RoleUpdate{
+				Permissions: []influxdb.Permission{writeBuckets},
+			})
+			influxdbtesting.ErrorsEqual(t, err, tt.err)
+		})
+	}
+}
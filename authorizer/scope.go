@@ -0,0 +1,123 @@
+package authorizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/tsdb"
+)
+
+// BucketScopes returns the scopes that the user in the context is restricted to
+// when performing the action on the data of the bucket. The scopes are nil if the
+// user is not restricted to scopes, and an EUnauthorized error is returned if the
+// user may not perform the action on the bucket at all.
+func BucketScopes(ctx context.Context, a influxdb.Action, orgID, bucketID influxdb.ID) ([]influxdb.PermissionScope, error) {
+	auth, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bucketScopes(auth, a, orgID, bucketID)
+}
+
+func bucketScopes(auth influxdb.Authorizer, a influxdb.Action, orgID, bucketID influxdb.ID) ([]influxdb.PermissionScope, error) {
+	p, err := influxdb.NewPermissionAtID(bucketID, a, influxdb.BucketsResourceType, orgID)
+	if err != nil {
+		return nil, err
+	}
+	pset, err := auth.PermissionSet()
+	if err != nil {
+		return nil, err
+	}
+	scopes, ok := pset.Scopes(*p)
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnauthorized,
+			Msg:  fmt.Sprintf("%s is unauthorized", p),
+		}
+	}
+	return scopes, nil
+}
+
+// AuthorizeWriteBucket authorizes the user in the context to write the bucket as
+// a whole, such as to update, delete or restore it. Permissions restricted to a
+// scope do not allow this, as it changes data outside of the scope.
+func AuthorizeWriteBucket(ctx context.Context, bucketID, orgID influxdb.ID) (influxdb.Authorizer, influxdb.Permission, error) {
+	auth, p, err := AuthorizeWrite(ctx, influxdb.BucketsResourceType, bucketID, orgID)
+	if err != nil {
+		return nil, influxdb.Permission{}, err
+	}
+	pset, err := auth.PermissionSet()
+	if err != nil {
+		return nil, influxdb.Permission{}, err
+	}
+	if !pset.Covers(p) {
+		return nil, influxdb.Permission{}, &influxdb.Error{
+			Code: influxdb.EUnauthorized,
+			Msg:  fmt.Sprintf("%s is unauthorized with permissions restricted to a scope", p),
+		}
+	}
+	return auth, p, nil
+}
+
+// AuthorizeWritePoints authorizes the user in the context to write the points.
+// Points written to buckets that the user may only write part of must be within
+// one of the scopes of the permissions of the user on the bucket, and points
+// written to buckets that the user may not write to are unauthorized.
+func AuthorizeWritePoints(ctx context.Context, points []models.Point) error {
+	auth, err := icontext.GetAuthorizer(ctx)
+	if err != nil {
+		return err
+	}
+
+	type bucket struct{ orgID, bucketID influxdb.ID }
+	scopes := make(map[bucket][]influxdb.PermissionScope)
+	for _, pt := range points {
+		var b bucket
+		b.orgID, b.bucketID = tsdb.DecodeNameSlice(pt.Name())
+		s, ok := scopes[b]
+		if !ok {
+			if s, err = bucketScopes(auth, influxdb.WriteAction, b.orgID, b.bucketID); err != nil {
+				return err
+			}
+			scopes[b] = s
+		}
+		if s != nil && !pointWithin(pt, s) {
+			return &influxdb.Error{
+				Code: influxdb.EForbidden,
+				Msg:  fmt.Sprintf("writing series %q is not within the permissions for bucket %s", seriesKey(pt), b.bucketID),
+			}
+		}
+	}
+	return nil
+}
+
+// pointWithin returns whether the series of the exploded point is within one
+// of the scopes.
+func pointWithin(pt models.Point, scopes []influxdb.PermissionScope) bool {
+	tags := pt.Tags()
+	measurement := tags.GetString(models.MeasurementTagKey)
+	for _, s := range scopes {
+		if s.MatchesSeries(measurement, tags.GetString) {
+			return true
+		}
+	}
+	return false
+}
+
+// seriesKey returns the series key of the exploded point in line protocol,
+// without its field.
+func seriesKey(pt models.Point) string {
+	tags := pt.Tags()
+	key := models.Tags{}
+	for _, t := range tags {
+		switch string(t.Key) {
+		case models.MeasurementTagKey, models.FieldKeyTagKey:
+		default:
+			key = append(key, t)
+		}
+	}
+	return string(models.MakeKey(tags.Get(models.MeasurementTagKeyBytes), key))
+}
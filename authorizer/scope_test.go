@@ -0,0 +1,178 @@
+package authorizer_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	influxdbcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/models"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+	"github.com/influxdata/influxdb/v2/tsdb"
+)
+
+func TestAuthorizeWritePoints(t *testing.T) {
+	scoped := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			ID:    influxdbtesting.IDPtr(2),
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+		Scope: &influxdb.PermissionScope{
+			Measurements: []string{"cpu"},
+			Tags:         []influxdb.Tag{{Key: "team", Value: "payments"}},
+		},
+	}
+	unscoped := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		bucketID    influxdb.ID
+		data        string
+		wantCode    string
+	}{
+		{
+			name:        "within scope",
+			permissions: []influxdb.Permission{scoped},
+			bucketID:    2,
+			data:        "cpu,team=payments,host=a value=1 1000\ncpu,host=b,team=payments value=2 1000",
+		},
+		{
+			name:        "other tag value",
+			permissions: []influxdb.Permission{scoped},
+			bucketID:    2,
+			data:        "cpu,team=payments value=1 1000\ncpu,team=search value=2 1000",
+			wantCode:    influxdb.EForbidden,
+		},
+		{
+			name:        "other measurement",
+			permissions: []influxdb.Permission{scoped},
+			bucketID:    2,
+			data:        "mem,team=payments value=1 1000",
+			wantCode:    influxdb.EForbidden,
+		},
+		{
+			name:        "unscoped permission",
+			permissions: []influxdb.Permission{scoped, unscoped},
+			bucketID:    2,
+			data:        "mem value=1 1000",
+		},
+		{
+			name:        "bucket without permission",
+			permissions: []influxdb.Permission{scoped},
+			bucketID:    3,
+			data:        "mem value=1 1000",
+			wantCode:    influxdb.EUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := tsdb.EncodeName(1, tt.bucketID)
+			points, err := models.ParsePointsString(tt.data, string(models.EscapeMeasurement(name[:])))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(false, tt.permissions))
+			err = authorizer.AuthorizeWritePoints(ctx, points)
+			if tt.wantCode != "" {
+				if influxdb.ErrorCode(err) != tt.wantCode {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestBucketScopes(t *testing.T) {
+	scope := influxdb.PermissionScope{Measurements: []string{"cpu"}}
+	scoped := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			ID:    influxdbtesting.IDPtr(2),
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+		Scope: &scope,
+	}
+	unscoped := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+	write := influxdb.Permission{
+		Action: influxdb.WriteAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			ID:    influxdbtesting.IDPtr(2),
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+
+	tests := []struct {
+		name        string
+		permissions []influxdb.Permission
+		bucketID    influxdb.ID
+		want        []influxdb.PermissionScope
+		wantCode    string
+	}{
+		{
+			name:        "scoped permission",
+			permissions: []influxdb.Permission{scoped},
+			bucketID:    2,
+			want:        []influxdb.PermissionScope{scope},
+		},
+		{
+			name:        "unscoped permission",
+			permissions: []influxdb.Permission{scoped, unscoped},
+			bucketID:    2,
+		},
+		{
+			name:        "bucket without permission",
+			permissions: []influxdb.Permission{scoped},
+			bucketID:    3,
+			wantCode:    influxdb.EUnauthorized,
+		},
+		{
+			name:        "write permission",
+			permissions: []influxdb.Permission{write},
+			bucketID:    2,
+			wantCode:    influxdb.EUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := influxdbcontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(false, tt.permissions))
+			scopes, err := authorizer.BucketScopes(ctx, influxdb.ReadAction, 1, tt.bucketID)
+			if tt.wantCode != "" {
+				if influxdb.ErrorCode(err) != tt.wantCode {
+					t.Fatalf("expected %s error, got %v", tt.wantCode, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(scopes, tt.want) {
+				t.Errorf("unexpected scopes, got %v, want %v", scopes, tt.want)
+			}
+		})
+	}
+}
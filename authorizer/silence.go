@@ -0,0 +1,76 @@
+package authorizer
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.SilenceService = (*SilenceService)(nil)
+
+// SilenceService wraps a influxdb.SilenceService and authorizes actions
+// against it appropriately. A silence mutes every notification rule of its
+// organization, so it is authorized against the notification rules of the org.
+type SilenceService struct {
+	s influxdb.SilenceService
+}
+
+// NewSilenceService constructs an instance of an authorizing silence service.
+func NewSilenceService(s influxdb.SilenceService) *SilenceService {
+	return &SilenceService{
+		s: s,
+	}
+}
+
+// FindSilenceByID checks to see if the authorizer on context has read access to the notification rules of the silence's org.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	sil, err := s.s.FindSilenceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeOrgReadResource(ctx, influxdb.NotificationRuleResourceType, sil.OrgID); err != nil {
+		return nil, err
+	}
+	return sil, nil
+}
+
+// FindSilences retrieves all silences that match the provided filter and then filters the list down to only the resources that are authorized.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+	sils, _, err := s.s.FindSilences(ctx, filter, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return AuthorizeFindSilences(ctx, sils)
+}
+
+// CreateSilence checks to see if the authorizer on context has write access to the notification rules of the org.
+func (s *SilenceService) CreateSilence(ctx context.Context, sil *influxdb.Silence) error {
+	if _, _, err := AuthorizeOrgWriteResource(ctx, influxdb.NotificationRuleResourceType, sil.OrgID); err != nil {
+		return err
+	}
+	return s.s.CreateSilence(ctx, sil)
+}
+
+// UpdateSilence checks to see if the authorizer on context has write access to the notification rules of the silence's org.
+func (s *SilenceService) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	sil, err := s.s.FindSilenceByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := AuthorizeOrgWriteResource(ctx, influxdb.NotificationRuleResourceType, sil.OrgID); err != nil {
+		return nil, err
+	}
+	return s.s.UpdateSilence(ctx, id, upd)
+}
+
+// DeleteSilence checks to see if the authorizer on context has write access to the notification rules of the silence's org.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	sil, err := s.s.FindSilenceByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if _, _, err := AuthorizeOrgWriteResource(ctx, influxdb.NotificationRuleResourceType, sil.OrgID); err != nil {
+		return err
+	}
+	return s.s.DeleteSilence(ctx, id)
+}
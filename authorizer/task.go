@@ -3,6 +3,7 @@ package authorizer
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/kit/tracing"
@@ -250,3 +251,43 @@ func (ts *taskServiceValidator) ForceRun(ctx context.Context, taskID influxdb.ID
 	}
 	return ts.TaskService.ForceRun(ctx, taskID, scheduledFor)
 }
+
+func (ts *taskServiceValidator) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Status != string(influxdb.TaskActive) {
+		return nil, ErrInactiveTask
+	}
+
+	a, p, err := AuthorizeWrite(ctx, influxdb.TasksResourceType, task.ID, task.OrganizationID)
+	loggerFields := []zap.Field{zap.String("method", "BackfillTask"), zap.Stringer("task_id", taskID)}
+	if err := ts.processPermissionError(a, p, err, loggerFields...); err != nil {
+		return nil, err
+	}
+	return ts.TaskService.BackfillTask(ctx, taskID, start, stop)
+}
+
+func (ts *taskServiceValidator) CancelBackfill(ctx context.Context, taskID influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	// Unauthenticated task lookup, to identify the task's organization.
+	task, err := ts.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return err
+	}
+
+	a, p, err := AuthorizeWrite(ctx, influxdb.TasksResourceType, task.ID, task.OrganizationID)
+	loggerFields := []zap.Field{zap.String("method", "CancelBackfill"), zap.Stringer("task_id", taskID)}
+	if err := ts.processPermissionError(a, p, err, loggerFields...); err != nil {
+		return err
+	}
+	return ts.TaskService.CancelBackfill(ctx, taskID)
+}
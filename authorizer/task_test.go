@@ -109,6 +109,12 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`,
 		ForceRunFn: func(context.Context, influxdb.ID, int64) (*influxdb.Run, error) {
 			return &run, nil
 		},
+		BackfillTaskFn: func(context.Context, influxdb.ID, time.Time, time.Time) ([]*influxdb.Run, error) {
+			return []*influxdb.Run{&run}, nil
+		},
+		CancelBackfillFn: func(context.Context, influxdb.ID) error {
+			return nil
+		},
 	}
 }
 
@@ -556,6 +562,51 @@ from(bucket:"holder") |> range(start:-5m) |> to(bucket:"holder", org:"thing")`
 				return err
 			},
 		},
+		{
+			name: "BackfillTask with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.BackfillTask(ctx, taskID, time.Unix(0, 0), time.Unix(3600, 0))
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "BackfillTask with org auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.BackfillTask(ctx, taskID, time.Unix(0, 0), time.Unix(3600, 0))
+				return err
+			},
+		},
+		{
+			name: "BackfillTask with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				_, err := svc.BackfillTask(ctx, taskID, time.Unix(0, 0), time.Unix(3600, 0))
+				return err
+			},
+		},
+		{
+			name: "CancelBackfill with bad auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: wrongOrgReadAllTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				err := svc.CancelBackfill(ctx, taskID)
+				if err == nil {
+					return errors.New("returned no error with a invalid auth")
+				}
+				return nil
+			},
+		},
+		{
+			name: "CancelBackfill with task auth",
+			auth: &influxdb.Authorization{Status: "active", Permissions: orgWriteTaskPermissions},
+			check: func(ctx context.Context, svc influxdb.TaskService) error {
+				return svc.CancelBackfill(ctx, taskID)
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -10,9 +10,15 @@ type OrganizationService interface {
 	FindResourceOrganizationID(ctx context.Context, rt influxdb.ResourceType, id influxdb.ID) (influxdb.ID, error)
 }
 
+// RoleFinder finds the roles that users are made members of.
+type RoleFinder interface {
+	FindRoleByID(ctx context.Context, id influxdb.ID) (*influxdb.Role, error)
+}
+
 type URMService struct {
-	s          influxdb.UserResourceMappingService
-	orgService OrganizationService
+	s           influxdb.UserResourceMappingService
+	orgService  OrganizationService
+	roleService RoleFinder
 }
 
 func NewURMService(orgSvc OrganizationService, s influxdb.UserResourceMappingService) *URMService {
@@ -22,6 +28,13 @@ func NewURMService(orgSvc OrganizationService, s influxdb.UserResourceMappingSer
 	}
 }
 
+// WithRoleService sets the role service used to look up the permissions of the
+// roles that users are made members of. Without it, users can not be made
+// members of roles.
+func (s *URMService) WithRoleService(rs RoleFinder) {
+	s.roleService = rs
+}
+
 func (s *URMService) FindUserResourceMappings(ctx context.Context, filter influxdb.UserResourceMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.UserResourceMapping, int, error) {
 	urms, _, err := s.s.FindUserResourceMappings(ctx, filter, opt...)
 	if err != nil {
@@ -38,9 +51,31 @@ func (s *URMService) CreateUserResourceMapping(ctx context.Context, m *influxdb.
 	if _, _, err := AuthorizeWrite(ctx, m.ResourceType, m.ResourceID, orgID); err != nil {
 		return err
 	}
+	if m.ResourceType == influxdb.RolesResourceType {
+		if err := s.verifyRolePermissions(ctx, m.ResourceID); err != nil {
+			return err
+		}
+	}
 	return s.s.CreateUserResourceMapping(ctx, m)
 }
 
+// verifyRolePermissions ensures that the authorizer on context has the
+// permissions of the role, so that making a user a member of it can not be
+// used to escalate privileges.
+func (s *URMService) verifyRolePermissions(ctx context.Context, id influxdb.ID) error {
+	if s.roleService == nil {
+		return &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "members can not be added to roles without a role service",
+		}
+	}
+	r, err := s.roleService.FindRoleByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	return VerifyPermissions(ctx, r.Permissions)
+}
+
 func (s *URMService) DeleteUserResourceMapping(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error {
 	f := influxdb.UserResourceMappingFilter{ResourceID: resourceID, UserID: userID}
 	urms, _, err := s.s.FindUserResourceMappings(ctx, f)
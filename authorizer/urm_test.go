@@ -256,3 +256,103 @@ func TestURMService_WriteUserResourceMapping(t *testing.T) {
 		})
 	}
 }
+
+func TestURMService_CreateRoleMember(t *testing.T) {
+	adminRole := &influxdb.Role{
+		ID:    1,
+		OrgID: 10,
+		Name:  "admin",
+		Permissions: []influxdb.Permission{
+			{
+				Action: "write",
+				Resource: influxdb.Resource{
+					Type:  influxdb.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(10),
+				},
+			},
+		},
+	}
+	writeRole := influxdb.Permission{
+		Action: "write",
+		Resource: influxdb.Resource{
+			Type:  influxdb.RolesResourceType,
+			ID:    influxdbtesting.IDPtr(1),
+			OrgID: influxdbtesting.IDPtr(10),
+		},
+	}
+
+	type args struct {
+		permissions []influxdb.Permission
+	}
+	type wants struct {
+		err error
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "admin adds member to admin role",
+			args: args{
+				permissions: append([]influxdb.Permission{writeRole}, adminRole.Permissions...),
+			},
+			wants: wants{
+				err: nil,
+			},
+		},
+		{
+			name: "non-admin joins admin role",
+			args: args{
+				permissions: []influxdb.Permission{
+					writeRole,
+					{
+						Action: "read",
+						Resource: influxdb.Resource{
+							Type:  influxdb.BucketsResourceType,
+							OrgID: influxdbtesting.IDPtr(10),
+						},
+					},
+				},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Msg:  "permission write:orgs/000000000000000a/buckets is not allowed",
+					Code: influxdb.EForbidden,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created bool
+			s := authorizer.NewURMService(&OrgService{OrgID: 10}, &mock.UserResourceMappingService{
+				CreateMappingFn: func(ctx context.Context, m *influxdb.UserResourceMapping) error {
+					created = true
+					return nil
+				},
+			})
+			roles := mock.NewRoleService()
+			roles.FindRoleByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+				return adminRole, nil
+			}
+			s.WithRoleService(roles)
+
+			ctx := context.Background()
+			ctx = influxdbcontext.SetAuthorizer(ctx, mock.NewMockAuthorizer(false, tt.args.permissions))
+
+			err := s.CreateUserResourceMapping(ctx, &influxdb.UserResourceMapping{
+				ResourceType: influxdb.RolesResourceType,
+				ResourceID:   1,
+				UserID:       100,
+				UserType:     influxdb.Member,
+			})
+			influxdbtesting.ErrorsEqual(t, err, tt.wants.err)
+			if created != (tt.wants.err == nil) {
+				t.Errorf("unexpected creation of the membership: %t", created)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"strings"
 )
 
 var (
@@ -131,6 +132,10 @@ const (
 	ChecksResourceType = ResourceType("checks") // 16
 	// DBRPType gives permission to one or more DBRPs.
 	DBRPResourceType = ResourceType("dbrp") // 17
+	// QueriesResourceType gives permission to see and cancel the active queries.
+	QueriesResourceType = ResourceType("queries") // 18
+	// RolesResourceType gives permission to one or more roles.
+	RolesResourceType = ResourceType("roles") // 19
 )
 
 // AllResourceTypes is the list of all known resource types.
@@ -153,6 +158,8 @@ var AllResourceTypes = []ResourceType{
 	NotificationEndpointResourceType, // 15
 	ChecksResourceType,               // 16
 	DBRPResourceType,                 // 17
+	QueriesResourceType,              // 18
+	RolesResourceType,                // 19
 	// NOTE: when modifying this list, please update the swagger for components.schemas.Permission resource enum.
 }
 
@@ -171,6 +178,7 @@ var OrgResourceTypes = []ResourceType{
 	NotificationEndpointResourceType, // 15
 	ChecksResourceType,               // 16
 	DBRPResourceType,                 // 17
+	RolesResourceType,                // 19
 }
 
 // Valid checks if the resource type is a member of the ResourceType enum.
@@ -199,6 +207,8 @@ func (t ResourceType) Valid() (err error) {
 	case NotificationEndpointResourceType: // 15
 	case ChecksResourceType: // 16
 	case DBRPResourceType: // 17
+	case QueriesResourceType: // 18
+	case RolesResourceType: // 19
 	default:
 		err = ErrInvalidResourceType
 	}
@@ -212,10 +222,147 @@ func (ps PermissionSet) Allowed(p Permission) bool {
 	return PermissionAllowed(p, ps)
 }
 
+// Scopes returns the scopes of the permissions of the set that match p. ok is
+// false if no permission of the set matches p, and scopes is nil if one of the
+// permissions that match p is not restricted to a scope.
+func (ps PermissionSet) Scopes(p Permission) (scopes []PermissionScope, ok bool) {
+	for _, perm := range ps {
+		if !perm.Matches(p) {
+			continue
+		}
+		if perm.Scope == nil {
+			return nil, true
+		}
+		scopes = append(scopes, *perm.Scope)
+	}
+	return scopes, len(scopes) > 0
+}
+
+// Covers returns whether the set allows p on all of the data p applies to.
+// A permission that is not restricted to a scope is only covered by
+// permissions that are not restricted either.
+func (ps PermissionSet) Covers(p Permission) bool {
+	scopes, ok := ps.Scopes(p)
+	if !ok {
+		return false
+	}
+	if scopes == nil {
+		return true
+	}
+	if p.Scope == nil {
+		return false
+	}
+	for _, s := range scopes {
+		if p.Scope.Within(s) {
+			return true
+		}
+	}
+	return false
+}
+
 // Permission defines an action and a resource.
 type Permission struct {
 	Action   Action   `json:"action"`
 	Resource Resource `json:"resource"`
+	// Scope restricts a bucket permission to part of the data of the buckets.
+	Scope *PermissionScope `json:"scope,omitempty"`
+}
+
+// PermissionScope restricts the data of a bucket that a permission allows to
+// read or write to the series of some measurements and/or with some tags.
+type PermissionScope struct {
+	// Measurements are the measurements of the series. Series of any
+	// measurement are allowed when empty.
+	Measurements []string `json:"measurements,omitempty"`
+	// Tags are the tags that the series must all have.
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+// Valid returns an error if the scope does not restrict anything.
+func (s PermissionScope) Valid() error {
+	if len(s.Measurements) == 0 && len(s.Tags) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "permission scope must contain measurements or tags",
+		}
+	}
+	for _, m := range s.Measurements {
+		if m == "" {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "permission scope must not contain empty measurements",
+			}
+		}
+	}
+	for _, t := range s.Tags {
+		if err := t.Valid(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MatchesSeries returns whether the series of the measurement is within the
+// scope. tag returns the value of the tag of the series with the key.
+func (s PermissionScope) MatchesSeries(measurement string, tag func(key string) string) bool {
+	if len(s.Measurements) > 0 && !containsString(s.Measurements, measurement) {
+		return false
+	}
+	for _, t := range s.Tags {
+		if tag(t.Key) != t.Value {
+			return false
+		}
+	}
+	return true
+}
+
+// Within returns whether all of the series within the scope are within
+// the other scope.
+func (s PermissionScope) Within(other PermissionScope) bool {
+	if len(other.Measurements) > 0 {
+		if len(s.Measurements) == 0 {
+			return false
+		}
+		for _, m := range s.Measurements {
+			if !containsString(other.Measurements, m) {
+				return false
+			}
+		}
+	}
+	for _, t := range other.Tags {
+		found := false
+		for _, st := range s.Tags {
+			if st == t {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the scope in the form _measurement=a|b,key=value.
+func (s PermissionScope) String() string {
+	var parts []string
+	if len(s.Measurements) > 0 {
+		parts = append(parts, "_measurement="+strings.Join(s.Measurements, "|"))
+	}
+	for _, t := range s.Tags {
+		parts = append(parts, t.Key+"="+t.Value)
+	}
+	return strings.Join(parts, ",")
+}
+
+func containsString(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
 }
 
 // Matches returns whether or not one permission matches the other.
@@ -256,6 +403,9 @@ func (p Permission) Matches(perm Permission) bool {
 }
 
 func (p Permission) String() string {
+	if p.Scope != nil {
+		return fmt.Sprintf("%s:%s[%s]", p.Action, p.Resource, p.Scope)
+	}
 	return fmt.Sprintf("%s:%s", p.Action, p.Resource)
 }
 
@@ -293,6 +443,18 @@ func (p *Permission) Valid() error {
 		}
 	}
 
+	if p.Scope != nil {
+		if p.Resource.Type != BucketsResourceType {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "only bucket permissions can be restricted to a scope",
+			}
+		}
+		if err := p.Scope.Valid(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -395,9 +557,14 @@ func MePermissions(userID ID) []Permission {
 }
 
 // MemberPermissions are the default permissions for those who can see a resource.
+// Members can not read the queries of the organization, their text may be
+// about data the member can not read.
 func MemberPermissions(orgID ID) []Permission {
 	ps := []Permission{}
 	for _, r := range AllResourceTypes {
+		if r == QueriesResourceType {
+			continue
+		}
 		if r == OrgsResourceType {
 			ps = append(ps, Permission{Action: ReadAction, Resource: Resource{Type: r, ID: &orgID}})
 			continue
@@ -245,6 +245,7 @@ func TestPermission_Valid(t *testing.T) {
 	type fields struct {
 		Action   platform.Action
 		Resource platform.Resource
+		Scope    *platform.PermissionScope
 	}
 	tests := []struct {
 		name    string
@@ -302,12 +303,68 @@ func TestPermission_Valid(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid bucket permission with a scope",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					ID:    validID(),
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Scope: &platform.PermissionScope{
+					Measurements: []string{"cpu"},
+					Tags:         []platform.Tag{{Key: "team", Value: "payments"}},
+				},
+			},
+		},
+		{
+			name: "invalid bucket permission with an empty scope",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Scope: &platform.PermissionScope{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid bucket permission with an empty scope tag",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Scope: &platform.PermissionScope{
+					Tags: []platform.Tag{{Key: "team"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid dashboard permission with a scope",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.DashboardsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+				},
+				Scope: &platform.PermissionScope{
+					Measurements: []string{"cpu"},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &platform.Permission{
 				Action:   tt.fields.Action,
 				Resource: tt.fields.Resource,
+				Scope:    tt.fields.Scope,
 			}
 			if err := p.Valid(); (err != nil) != tt.wantErr {
 				t.Errorf("Permission.Valid() error = %v, wantErr %v", err, tt.wantErr)
@@ -362,10 +419,31 @@ func TestPermissionAllActions(t *testing.T) {
 	}
 }
 
+func TestMemberPermissions(t *testing.T) {
+	orgID := platform.ID(1)
+	queries := platform.Permission{
+		Action:   platform.ReadAction,
+		Resource: platform.Resource{Type: platform.QueriesResourceType, OrgID: &orgID},
+	}
+	buckets := platform.Permission{
+		Action:   platform.ReadAction,
+		Resource: platform.Resource{Type: platform.BucketsResourceType, OrgID: &orgID},
+	}
+
+	ps := platform.MemberPermissions(orgID)
+	if !platform.PermissionAllowed(buckets, ps) {
+		t.Errorf("expected members to read the buckets of the organization")
+	}
+	if platform.PermissionAllowed(queries, ps) {
+		t.Errorf("expected members not to read the queries of the organization")
+	}
+}
+
 func TestPermission_String(t *testing.T) {
 	type fields struct {
 		Action   platform.Action
 		Resource platform.Resource
+		Scope    *platform.PermissionScope
 		Name     *string
 	}
 	tests := []struct {
@@ -417,12 +495,29 @@ func TestPermission_String(t *testing.T) {
 			},
 			want: `write:buckets/0000000000000001`,
 		},
+		{
+			name: "valid permission with a scope",
+			fields: fields{
+				Action: platform.ReadAction,
+				Resource: platform.Resource{
+					Type:  platform.BucketsResourceType,
+					OrgID: influxdbtesting.IDPtr(1),
+					ID:    validID(),
+				},
+				Scope: &platform.PermissionScope{
+					Measurements: []string{"cpu", "mem"},
+					Tags:         []platform.Tag{{Key: "team", Value: "payments"}},
+				},
+			},
+			want: `read:orgs/0000000000000001/buckets/0000000000000064[_measurement=cpu|mem,team=payments]`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := platform.Permission{
 				Action:   tt.fields.Action,
 				Resource: tt.fields.Resource,
+				Scope:    tt.fields.Scope,
 			}
 			if got := p.String(); got != tt.want {
 				t.Errorf("Permission.String() = %v, want %v", got, tt.want)
@@ -435,3 +530,102 @@ func validID() *platform.ID {
 	id := platform.ID(100)
 	return &id
 }
+
+func TestPermissionSet_Covers(t *testing.T) {
+	bucket := func(scope *platform.PermissionScope) platform.Permission {
+		return platform.Permission{
+			Action: platform.WriteAction,
+			Resource: platform.Resource{
+				Type:  platform.BucketsResourceType,
+				OrgID: influxdbtesting.IDPtr(1),
+				ID:    validID(),
+			},
+			Scope: scope,
+		}
+	}
+	payments := &platform.PermissionScope{
+		Tags: []platform.Tag{{Key: "team", Value: "payments"}},
+	}
+	paymentsCPU := &platform.PermissionScope{
+		Measurements: []string{"cpu"},
+		Tags:         []platform.Tag{{Key: "team", Value: "payments"}, {Key: "host", Value: "a"}},
+	}
+	billing := &platform.PermissionScope{
+		Tags: []platform.Tag{{Key: "team", Value: "billing"}},
+	}
+
+	tests := []struct {
+		name        string
+		permission  platform.Permission
+		permissions platform.PermissionSet
+		allowed     bool
+		covered     bool
+	}{
+		{
+			name:        "unscoped permission covers scoped permission",
+			permission:  bucket(payments),
+			permissions: platform.PermissionSet{bucket(nil)},
+			allowed:     true,
+			covered:     true,
+		},
+		{
+			name:        "scoped permission allows but does not cover unscoped permission",
+			permission:  bucket(nil),
+			permissions: platform.PermissionSet{bucket(payments)},
+			allowed:     true,
+			covered:     false,
+		},
+		{
+			name:        "scoped permission covers narrower scope",
+			permission:  bucket(paymentsCPU),
+			permissions: platform.PermissionSet{bucket(billing), bucket(payments)},
+			allowed:     true,
+			covered:     true,
+		},
+		{
+			name:        "scoped permission does not cover other scope",
+			permission:  bucket(billing),
+			permissions: platform.PermissionSet{bucket(payments)},
+			allowed:     true,
+			covered:     false,
+		},
+		{
+			name:        "no matching permission",
+			permission:  bucket(nil),
+			permissions: platform.PermissionSet{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.permissions.Allowed(tt.permission); got != tt.allowed {
+				t.Errorf("PermissionSet.Allowed() = %v, want %v", got, tt.allowed)
+			}
+			if got := tt.permissions.Covers(tt.permission); got != tt.covered {
+				t.Errorf("PermissionSet.Covers() = %v, want %v", got, tt.covered)
+			}
+		})
+	}
+}
+
+func TestPermissionScope_MatchesSeries(t *testing.T) {
+	scope := platform.PermissionScope{
+		Measurements: []string{"cpu", "mem"},
+		Tags:         []platform.Tag{{Key: "team", Value: "payments"}},
+	}
+	tags := func(m map[string]string) func(string) string {
+		return func(k string) string { return m[k] }
+	}
+
+	if !scope.MatchesSeries("cpu", tags(map[string]string{"team": "payments", "host": "a"})) {
+		t.Error("expected series of cpu with team=payments to match")
+	}
+	if scope.MatchesSeries("disk", tags(map[string]string{"team": "payments"})) {
+		t.Error("expected series of disk not to match")
+	}
+	if scope.MatchesSeries("cpu", tags(map[string]string{"team": "billing"})) {
+		t.Error("expected series of cpu with team=billing not to match")
+	}
+	if scope.MatchesSeries("cpu", tags(nil)) {
+		t.Error("expected series of cpu without team tag not to match")
+	}
+}
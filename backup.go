@@ -3,21 +3,88 @@ package influxdb
 import (
 	"context"
 	"io"
+	"time"
 )
 
+// BackupManifestFilename is the name of the manifest file written to a backup directory.
+const BackupManifestFilename = "manifest.json"
+
 // BackupService represents the data backup functions of InfluxDB.
 type BackupService interface {
-	// CreateBackup creates a local copy (hard links) of the TSM data for all orgs and buckets.
-	// The return values are used to download each backup file.
-	CreateBackup(context.Context) (backupID int, backupFiles []string, err error)
+	// CreateBackup creates a local copy (hard links) of the TSM data matching the filter.
+	// The returned manifest describes the backup files and is used to download them.
+	CreateBackup(ctx context.Context, filter BackupFilter) (*BackupManifest, error)
 	// FetchBackupFile downloads one backup file, data or metadata.
 	FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error
 	// InternalBackupPath is a utility to determine the on-disk location of a backup fileset.
 	InternalBackupPath(backupID int) string
 }
 
+// RestoreService represents the data restore functions of InfluxDB.
+type RestoreService interface {
+	// RestoreBucketFile imports the data of the source bucket in the backup TSM file
+	// at path into the target bucket. Tombstones next to the file are applied.
+	RestoreBucketFile(ctx context.Context, source, target BackupBucket, path string) error
+}
+
 // KVBackupService represents the meta data backup functions of InfluxDB.
 type KVBackupService interface {
 	// Backup creates a live backup copy of the metadata database.
 	Backup(ctx context.Context, w io.Writer) error
 }
+
+// BackupFilter restricts the TSM data included in a backup.
+type BackupFilter struct {
+	// OrgID restricts the backup to the buckets of an organization.
+	OrgID *ID `json:"orgID,omitempty"`
+	// BucketID restricts the backup to a single bucket.
+	BucketID *ID `json:"bucketID,omitempty"`
+	// Since holds the files of a base backup. Files that are unchanged
+	// since the base backup are not included again.
+	Since []BackupFile `json:"since,omitempty"`
+}
+
+// BackupManifest describes the files of a backup.
+type BackupManifest struct {
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	OrgID     *ID       `json:"orgID,omitempty"`
+	BucketID  *ID       `json:"bucketID,omitempty"`
+	// KV is the snapshot of the metadata database.
+	KV *BackupFile `json:"kv,omitempty"`
+	// Configs is the CLI configs file of the server host, if present.
+	Configs *BackupFile `json:"configs,omitempty"`
+	// Files are the TSM and tombstone files of the backup.
+	Files []BackupFile `json:"files"`
+}
+
+// BackupFile describes a single file of a backup.
+type BackupFile struct {
+	FileName string `json:"fileName"`
+	Size     int64  `json:"size"`
+	// Checksum is the hex encoded SHA-256 checksum of the file. The
+	// server only sets it for the files compared with a base backup.
+	Checksum string `json:"checksum"`
+	// Buckets are the buckets with data in a TSM file.
+	Buckets []BackupBucket `json:"buckets,omitempty"`
+	// Base is true if the file is unchanged since the base backup
+	// and is not transferred with this backup.
+	Base bool `json:"base,omitempty"`
+}
+
+// BackupBucket identifies a bucket with data in a backup file.
+type BackupBucket struct {
+	OrgID    ID `json:"orgID"`
+	BucketID ID `json:"bucketID"`
+}
+
+// Matches returns true if the bucket is selected by the filter.
+func (f BackupFilter) Matches(orgID, bucketID ID) bool {
+	if f.OrgID != nil && *f.OrgID != orgID {
+		return false
+	}
+	if f.BucketID != nil && *f.BucketID != bucketID {
+		return false
+	}
+	return true
+}
@@ -41,9 +41,94 @@ type Bucket struct {
 	Description         string        `json:"description"`
 	RetentionPolicyName string        `json:"rp,omitempty"` // This to support v1 sources
 	RetentionPeriod     time.Duration `json:"retentionPeriod"`
+	// DownsampleRules are materialized as tasks writing aggregates of
+	// the bucket's data into other buckets of the organization.
+	DownsampleRules []DownsampleRule `json:"downsampleRules,omitempty"`
 	CRUDLog
 }
 
+// DownsampleRule aggregates the data of a bucket once it is older than After
+// into windows of Every, and writes the aggregates to DestinationBucket.
+type DownsampleRule struct {
+	After     time.Duration `json:"after"`
+	Every     time.Duration `json:"every"`
+	Functions []string      `json:"functions"`
+	// DestinationBucket is the name of a bucket of the same organization.
+	DestinationBucket string `json:"destinationBucket"`
+	// TaskID is the task that materializes the rule.
+	TaskID ID `json:"taskID,omitempty"`
+}
+
+// DownsampleFunctions are the aggregate functions supported by downsample rules.
+var DownsampleFunctions = []string{"count", "first", "last", "max", "mean", "median", "min", "sum"}
+
+// Valid returns an error if the rule cannot be materialized.
+func (r DownsampleRule) Valid() error {
+	switch {
+	case r.Every < time.Second || r.Every%time.Second != 0:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample every must be a whole number of seconds",
+		}
+	case r.After < r.Every || r.After%r.Every != 0:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample after must be a multiple of every",
+		}
+	case len(r.Functions) == 0:
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample rule must have at least one function",
+		}
+	case r.DestinationBucket == "":
+		return &Error{
+			Code: EInvalid,
+			Msg:  "downsample destination bucket must be provided",
+		}
+	}
+	for _, fn := range r.Functions {
+		if !validDownsampleFunction(fn) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("unsupported downsample function %q", fn),
+			}
+		}
+	}
+	return nil
+}
+
+func validDownsampleFunction(fn string) bool {
+	for _, f := range DownsampleFunctions {
+		if f == fn {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidDownsampleRules returns an error if a rule is invalid, or if the
+// retention period of the bucket removes data before it is downsampled.
+func (b *Bucket) ValidDownsampleRules() error {
+	for _, r := range b.DownsampleRules {
+		if err := r.Valid(); err != nil {
+			return err
+		}
+		if r.DestinationBucket == b.Name {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "downsample destination bucket must not be the bucket itself",
+			}
+		}
+		if b.RetentionPeriod != InfiniteRetention && r.After+r.Every > b.RetentionPeriod {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "downsample rule must complete within the retention period of the bucket",
+			}
+		}
+	}
+	return nil
+}
+
 // BucketType differentiates system buckets from user buckets.
 type BucketType int
 
@@ -104,6 +189,8 @@ type BucketUpdate struct {
 	Name            *string        `json:"name,omitempty"`
 	Description     *string        `json:"description,omitempty"`
 	RetentionPeriod *time.Duration `json:"retentionPeriod,omitempty"`
+	// DownsampleRules replaces the downsample rules of the bucket if set.
+	DownsampleRules *[]DownsampleRule `json:"downsampleRules,omitempty"`
 }
 
 // BucketFilter represents a set of filter that restrict the returned results.
@@ -2,12 +2,16 @@ package oauth2
 
 import (
 	"context"
+	"crypto/rsa"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"math"
+	"math/big"
 	"net/http"
+	"strings"
 	"time"
 
 	gojwt "github.com/dgrijalva/jwt-go"
@@ -144,6 +148,10 @@ func (j *JWT) KeyFuncRS256(token *gojwt.Token) (interface{}, error) {
 	var certPkix []byte
 	for _, jwk := range jwks.Keys {
 		if token.Header["kid"] == jwk.Kid {
+			// keys without a certificate chain are given by their modulus and exponent
+			if len(jwk.X5c) == 0 {
+				return rsaPublicKey(jwk)
+			}
 			// FIXME: optionally walk the key chain, see rfc7517 section 4.7
 			certPkix, err = base64.StdEncoding.DecodeString(jwk.X5c[0])
 			if err != nil {
@@ -163,6 +171,30 @@ func (j *JWT) KeyFuncRS256(token *gojwt.Token) (interface{}, error) {
 	return cert.PublicKey, nil
 }
 
+// rsaPublicKey returns the RSA public key of a JWK with the base64url encoded
+// modulus and exponent of rfc7518 section 6.3.1.
+func rsaPublicKey(jwk JWK) (*rsa.PublicKey, error) {
+	if jwk.Kty != "RSA" || jwk.N == "" || jwk.E == "" {
+		return nil, fmt.Errorf("JWK kid %v is not an RSA key", jwk.Kid)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.N, "="))
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode error for modulus of JWK kid %v", jwk.Kid)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(jwk.E, "="))
+	if err != nil {
+		return nil, fmt.Errorf("base64 decode error for exponent of JWK kid %v", jwk.Kid)
+	}
+	exp := new(big.Int).SetBytes(e)
+	if !exp.IsInt64() || exp.Int64() > math.MaxInt32 {
+		return nil, fmt.Errorf("exponent of JWK kid %v is too large", jwk.Kid)
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(exp.Int64()),
+	}, nil
+}
+
 // ValidClaims validates a token with StandardClaims
 func (j *JWT) ValidClaims(jwtToken Token, lifespan time.Duration, alg gojwt.Keyfunc) (Principal, error) {
 	// 1. Checks for expired tokens
@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	platform "github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/cmd/influx/internal"
@@ -17,6 +21,29 @@ type token struct {
 	UserName    string      `json:"userName"`
 	UserID      platform.ID `json:"userID"`
 	Permissions []string    `json:"permissions"`
+
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	LastUsedAt   *time.Time `json:"lastUsedAt,omitempty"`
+	LastUsedFrom string     `json:"lastUsedFrom,omitempty"`
+}
+
+func newToken(a *platform.Authorization, userName string) token {
+	ps := make([]string, 0, len(a.Permissions))
+	for _, p := range a.Permissions {
+		ps = append(ps, p.String())
+	}
+
+	return token{
+		ID:           a.ID,
+		Token:        a.Token,
+		Status:       string(a.Status),
+		UserName:     userName,
+		UserID:       a.UserID,
+		Permissions:  ps,
+		ExpiresAt:    a.ExpiresAt,
+		LastUsedAt:   a.LastUsedAt,
+		LastUsedFrom: a.LastUsedFrom,
+	}
 }
 
 func cmdAuth(f *globalFlags, opt genericCLIOpts) *cobra.Command {
@@ -31,6 +58,7 @@ func cmdAuth(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 		authDeleteCmd(),
 		authFindCmd(),
 		authInactiveCmd(),
+		authRotateCmd(),
 	)
 
 	return cmd
@@ -43,8 +71,9 @@ var authCRUDFlags struct {
 }
 
 var authCreateFlags struct {
-	user string
-	org  organization
+	user      string
+	org       organization
+	expiresIn time.Duration
 
 	writeUserPermission bool
 	readUserPermission  bool
@@ -55,6 +84,9 @@ var authCreateFlags struct {
 	writeBucketPermissions []string
 	readBucketPermissions  []string
 
+	measurements []string
+	tags         []string
+
 	writeTasksPermission bool
 	readTasksPermission  bool
 
@@ -78,6 +110,14 @@ var authCreateFlags struct {
 
 	writeDBRPPermission bool
 	readDBRPPermission  bool
+
+	writeQueriesPermission bool
+	readQueriesPermission  bool
+
+	writeRolesPermission bool
+	readRolesPermission  bool
+
+	roles []string
 }
 
 func authCreateCmd() *cobra.Command {
@@ -89,6 +129,7 @@ func authCreateCmd() *cobra.Command {
 	authCreateFlags.org.register(cmd, false)
 
 	cmd.Flags().StringVarP(&authCreateFlags.user, "user", "u", "", "The user name")
+	cmd.Flags().DurationVar(&authCreateFlags.expiresIn, "expires-in", 0, "Duration after which the token expires. 0 never expires. Default is 0.")
 	registerPrintOptions(cmd, &authCRUDFlags.hideHeaders, &authCRUDFlags.json)
 
 	cmd.Flags().BoolVarP(&authCreateFlags.writeUserPermission, "write-user", "", false, "Grants the permission to perform mutative actions against organization users")
@@ -100,6 +141,9 @@ func authCreateCmd() *cobra.Command {
 	cmd.Flags().StringArrayVarP(&authCreateFlags.writeBucketPermissions, "write-bucket", "", []string{}, "The bucket id")
 	cmd.Flags().StringArrayVarP(&authCreateFlags.readBucketPermissions, "read-bucket", "", []string{}, "The bucket id")
 
+	cmd.Flags().StringArrayVar(&authCreateFlags.measurements, "measurement", nil, "Restricts the bucket permissions to the measurement; may be repeated")
+	cmd.Flags().StringArrayVar(&authCreateFlags.tags, "tag", nil, "Restricts the bucket permissions to series with the tag; format should be --tag=key=value")
+
 	cmd.Flags().BoolVarP(&authCreateFlags.writeTasksPermission, "write-tasks", "", false, "Grants the permission to create tasks")
 	cmd.Flags().BoolVarP(&authCreateFlags.readTasksPermission, "read-tasks", "", false, "Grants the permission to read tasks")
 
@@ -124,6 +168,14 @@ func authCreateCmd() *cobra.Command {
 	cmd.Flags().BoolVarP(&authCreateFlags.writeDBRPPermission, "write-dbrps", "", false, "Grants the permission to create database retention policy mappings")
 	cmd.Flags().BoolVarP(&authCreateFlags.readDBRPPermission, "read-dbrps", "", false, "Grants the permission to read database retention policy mappings")
 
+	cmd.Flags().BoolVarP(&authCreateFlags.writeQueriesPermission, "write-queries", "", false, "Grants the permission to cancel running queries")
+	cmd.Flags().BoolVarP(&authCreateFlags.readQueriesPermission, "read-queries", "", false, "Grants the permission to list running queries")
+
+	cmd.Flags().BoolVarP(&authCreateFlags.writeRolesPermission, "write-roles", "", false, "Grants the permission to create roles and assign them to users")
+	cmd.Flags().BoolVarP(&authCreateFlags.readRolesPermission, "read-roles", "", false, "Grants the permission to read roles")
+
+	cmd.Flags().StringArrayVar(&authCreateFlags.roles, "role", nil, "ID of a role whose permissions are granted; may be repeated")
+
 	return cmd
 }
 
@@ -147,6 +199,11 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	scope, err := parsePermissionScope(authCreateFlags.measurements, authCreateFlags.tags)
+	if err != nil {
+		return err
+	}
+
 	bucketPerms := []struct {
 		action platform.Action
 		perms  []string
@@ -167,6 +224,7 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
+			p.Scope = scope
 
 			permissions = append(permissions, *p)
 		}
@@ -227,6 +285,16 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 			writePerm:    authCreateFlags.writeDBRPPermission,
 			ResourceType: platform.DBRPResourceType,
 		},
+		{
+			readPerm:     authCreateFlags.readQueriesPermission,
+			writePerm:    authCreateFlags.writeQueriesPermission,
+			ResourceType: platform.QueriesResourceType,
+		},
+		{
+			readPerm:     authCreateFlags.readRolesPermission,
+			writePerm:    authCreateFlags.writeRolesPermission,
+			ResourceType: platform.RolesResourceType,
+		},
 	}
 
 	for _, provided := range providedPerm {
@@ -243,15 +311,40 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 			if err != nil {
 				return err
 			}
+			if provided.ResourceType == platform.BucketsResourceType {
+				p.Scope = scope
+			}
 			permissions = append(permissions, *p)
 		}
 	}
 
+	if scope != nil && !hasBucketPermission(permissions) {
+		return errors.New("--measurement and --tag require bucket permissions to restrict")
+	}
+
+	var roleIDs []platform.ID
+	for _, r := range authCreateFlags.roles {
+		id, err := platform.IDFromString(r)
+		if err != nil {
+			return fmt.Errorf("invalid role id %q: %v", r, err)
+		}
+		roleIDs = append(roleIDs, *id)
+	}
+
 	authorization := &platform.Authorization{
 		Permissions: permissions,
+		RoleIDs:     roleIDs,
 		OrgID:       orgID,
 	}
 
+	if authCreateFlags.expiresIn < 0 {
+		return errors.New("--expires-in must not be negative")
+	}
+	if authCreateFlags.expiresIn > 0 {
+		expiresAt := time.Now().Add(authCreateFlags.expiresIn).UTC()
+		authorization.ExpiresAt = &expiresAt
+	}
+
 	if userName := authCreateFlags.user; userName != "" {
 		user, err := userSvc.FindUser(context.Background(), platform.UserFilter{
 			Name: &userName,
@@ -276,25 +369,42 @@ func authorizationCreateF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ps := make([]string, 0, len(authorization.Permissions))
-	for _, p := range authorization.Permissions {
-		ps = append(ps, p.String())
-	}
-
 	return writeTokens(cmd.OutOrStdout(), tokenPrintOpt{
 		jsonOut:     authCRUDFlags.json,
 		hideHeaders: authCRUDFlags.hideHeaders,
-		token: token{
-			ID:          authorization.ID,
-			Token:       authorization.Token,
-			Status:      string(authorization.Status),
-			UserName:    user.Name,
-			UserID:      user.ID,
-			Permissions: ps,
-		},
+		token:       newToken(authorization, user.Name),
 	})
 }
 
+// parsePermissionScope returns the scope of the bucket permissions from the
+// measurements and key=value tags, or nil if there are neither.
+func parsePermissionScope(measurements, tags []string) (*platform.PermissionScope, error) {
+	if len(measurements) == 0 && len(tags) == 0 {
+		return nil, nil
+	}
+	scope := &platform.PermissionScope{Measurements: measurements}
+	for _, t := range tags {
+		kv := strings.SplitN(t, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid tag %q: format should be key=value", t)
+		}
+		scope.Tags = append(scope.Tags, platform.Tag{Key: kv[0], Value: kv[1]})
+	}
+	if err := scope.Valid(); err != nil {
+		return nil, err
+	}
+	return scope, nil
+}
+
+func hasBucketPermission(permissions []platform.Permission) bool {
+	for _, p := range permissions {
+		if p.Resource.Type == platform.BucketsResourceType {
+			return true
+		}
+	}
+	return false
+}
+
 var authorizationFindFlags struct {
 	org    organization
 	user   string
@@ -366,24 +476,12 @@ func authorizationFindF(cmd *cobra.Command, args []string) error {
 
 	var tokens []token
 	for _, a := range authorizations {
-		var permissions []string
-		for _, p := range a.Permissions {
-			permissions = append(permissions, p.String())
-		}
-
 		user, err := us.FindUserByID(context.Background(), a.UserID)
 		if err != nil {
 			return err
 		}
 
-		tokens = append(tokens, token{
-			ID:          a.ID,
-			Token:       a.Token,
-			Status:      string(a.Status),
-			UserName:    user.Name,
-			UserID:      a.UserID,
-			Permissions: permissions,
-		})
+		tokens = append(tokens, newToken(a, user.Name))
 	}
 
 	return writeTokens(cmd.OutOrStdout(), tokenPrintOpt{
@@ -438,23 +536,11 @@ func authorizationDeleteF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ps := make([]string, 0, len(a.Permissions))
-	for _, p := range a.Permissions {
-		ps = append(ps, p.String())
-	}
-
 	return writeTokens(cmd.OutOrStdout(), tokenPrintOpt{
 		jsonOut:     authCRUDFlags.json,
 		deleted:     true,
 		hideHeaders: authCRUDFlags.hideHeaders,
-		token: token{
-			ID:          a.ID,
-			Token:       a.Token,
-			Status:      string(a.Status),
-			UserName:    user.Name,
-			UserID:      user.ID,
-			Permissions: ps,
-		},
+		token:       newToken(a, user.Name),
 	})
 }
 
@@ -505,22 +591,10 @@ func authorizationActiveF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ps := make([]string, 0, len(a.Permissions))
-	for _, p := range a.Permissions {
-		ps = append(ps, p.String())
-	}
-
 	return writeTokens(cmd.OutOrStdout(), tokenPrintOpt{
 		jsonOut:     authCRUDFlags.json,
 		hideHeaders: authCRUDFlags.hideHeaders,
-		token: token{
-			ID:          a.ID,
-			Token:       a.Token,
-			Status:      string(a.Status),
-			UserName:    user.Name,
-			UserID:      user.ID,
-			Permissions: ps,
-		},
+		token:       newToken(a, user.Name),
 	})
 }
 
@@ -571,22 +645,71 @@ func authorizationInactiveF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	ps := make([]string, 0, len(a.Permissions))
-	for _, p := range a.Permissions {
-		ps = append(ps, p.String())
+	return writeTokens(cmd.OutOrStdout(), tokenPrintOpt{
+		jsonOut:     authCRUDFlags.json,
+		hideHeaders: authCRUDFlags.hideHeaders,
+		token:       newToken(a, user.Name),
+	})
+}
+
+var authRotateFlags struct {
+	gracePeriod time.Duration
+}
+
+func authRotateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Rotate authorization token",
+		Long: `Rotate authorization token.
+
+Issues a new token for the authorization, keeping its ID and permissions. The
+previous token remains valid for the grace period, so that clients can be
+updated to the new token without downtime.`,
+		RunE: checkSetupRunEMiddleware(&flags)(authorizationRotateF),
+	}
+
+	registerPrintOptions(cmd, &authCRUDFlags.hideHeaders, &authCRUDFlags.json)
+	cmd.Flags().StringVarP(&authCRUDFlags.id, "id", "i", "", "The authorization ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().DurationVar(&authRotateFlags.gracePeriod, "grace-period", 0, "Duration the previous token remains valid. Default is 0.")
+
+	return cmd
+}
+
+func authorizationRotateF(cmd *cobra.Command, args []string) error {
+	if authRotateFlags.gracePeriod < 0 {
+		return errors.New("--grace-period must not be negative")
+	}
+
+	s, err := newAuthorizationService()
+	if err != nil {
+		return err
+	}
+
+	us, err := newUserService()
+	if err != nil {
+		return err
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(authCRUDFlags.id); err != nil {
+		return err
+	}
+
+	a, err := s.RotateAuthorization(context.Background(), id, authRotateFlags.gracePeriod)
+	if err != nil {
+		return err
+	}
+
+	user, err := us.FindUserByID(context.Background(), a.UserID)
+	if err != nil {
+		return err
 	}
 
 	return writeTokens(cmd.OutOrStdout(), tokenPrintOpt{
 		jsonOut:     authCRUDFlags.json,
 		hideHeaders: authCRUDFlags.hideHeaders,
-		token: token{
-			ID:          a.ID,
-			Token:       a.Token,
-			Status:      string(a.Status),
-			UserName:    user.Name,
-			UserID:      user.ID,
-			Permissions: ps,
-		},
+		token:       newToken(a, user.Name),
 	})
 }
 
@@ -618,6 +741,9 @@ func writeTokens(w io.Writer, printOpts tokenPrintOpt) error {
 		"User Name",
 		"User ID",
 		"Permissions",
+		"Expires At",
+		"Last Used At",
+		"Last Used From",
 	}
 	if printOpts.deleted {
 		headers = append(headers, "Deleted")
@@ -630,11 +756,14 @@ func writeTokens(w io.Writer, printOpts tokenPrintOpt) error {
 
 	for _, t := range printOpts.tokens {
 		m := map[string]interface{}{
-			"ID":          t.ID.String(),
-			"Token":       t.Token,
-			"User Name":   t.UserName,
-			"User ID":     t.UserID.String(),
-			"Permissions": t.Permissions,
+			"ID":             t.ID.String(),
+			"Token":          t.Token,
+			"User Name":      t.UserName,
+			"User ID":        t.UserID.String(),
+			"Permissions":    t.Permissions,
+			"Expires At":     formatTime(t.ExpiresAt),
+			"Last Used At":   formatTime(t.LastUsedAt),
+			"Last Used From": t.LastUsedFrom,
 		}
 		if printOpts.deleted {
 			m["Deleted"] = true
@@ -645,6 +774,15 @@ func writeTokens(w io.Writer, printOpts tokenPrintOpt) error {
 	return nil
 }
 
+// formatTime formats the optional time for a table, or returns the empty
+// string if it is not set.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 func newAuthorizationService() (platform.AuthorizationService, error) {
 	if flags.local {
 		return newLocalKVService()
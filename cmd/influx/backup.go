@@ -2,13 +2,16 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/bolt"
 	"github.com/influxdata/influxdb/v2/http"
+	"github.com/influxdata/influxdb/v2/internal/fs"
 	"github.com/spf13/cobra"
 	"go.uber.org/multierr"
 )
@@ -20,9 +23,18 @@ func cmdBackup(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 		`Backs up data and meta data for the running InfluxDB instance.
 Downloaded files are written to the directory indicated by --path.
 The target directory, and any parent directories, are created automatically.
-Data file have extension .tsm; meta data is written to %s in the same directory.`,
-		bolt.DefaultFilename)
+Data file have extension .tsm; meta data is written to %s in the same directory.
+The files of the backup are described by %s in the same directory.
 
+The data can be restricted to the buckets of an organization with --org or
+--org-id, or to a single bucket with --bucket or --bucket-id.
+
+With --since, only the data files that changed since the backup in the given
+directory are downloaded. Unchanged files are linked or copied from that
+backup, so the new directory holds a complete backup.`,
+		bolt.DefaultFilename, influxdb.BackupManifestFilename)
+
+	backupFlags.org.register(cmd, false)
 	opts := flagOpts{
 		{
 			DestP:    &backupFlags.Path,
@@ -32,6 +44,23 @@ Data file have extension .tsm; meta data is written to %s in the same directory.
 			Desc:     "directory path to write backup files to",
 			Required: true,
 		},
+		{
+			DestP: &backupFlags.BucketID,
+			Flag:  "bucket-id",
+			Desc:  "The ID of the bucket to backup",
+		},
+		{
+			DestP:  &backupFlags.Bucket,
+			Flag:   "bucket",
+			Short:  'b',
+			EnvVar: "BUCKET_NAME",
+			Desc:   "The name of the bucket to backup",
+		},
+		{
+			DestP: &backupFlags.Since,
+			Flag:  "since",
+			Desc:  "directory path of a base backup; only files changed since that backup are downloaded",
+		},
 	}
 	opts.mustRegister(cmd)
 
@@ -39,7 +68,11 @@ Data file have extension .tsm; meta data is written to %s in the same directory.
 }
 
 var backupFlags struct {
-	Path string
+	org      organization
+	Path     string
+	BucketID string
+	Bucket   string
+	Since    string
 }
 
 func newBackupService() (influxdb.BackupService, error) {
@@ -60,7 +93,12 @@ func backupF(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("must specify path")
 	}
 
-	err := os.MkdirAll(backupFlags.Path, 0777)
+	filter, err := newBackupFilter(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(backupFlags.Path, 0777)
 	if err != nil && !os.IsExist(err) {
 		return err
 	}
@@ -70,29 +108,185 @@ func backupF(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	id, backupFilenames, err := backupService.CreateBackup(ctx)
+	manifest, err := backupService.CreateBackup(ctx, filter)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Backup ID %d contains %d files\n", id, len(backupFilenames))
+	files := []*influxdb.BackupFile{manifest.KV}
+	for i := range manifest.Files {
+		files = append(files, &manifest.Files[i])
+	}
+	if manifest.Configs != nil {
+		files = append(files, manifest.Configs)
+	}
 
-	for _, backupFilename := range backupFilenames {
-		dest := filepath.Join(backupFlags.Path, backupFilename)
-		w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
-		if err != nil {
-			return err
+	fmt.Printf("Backup ID %d contains %d files\n", manifest.ID, len(files))
+
+	for _, f := range files {
+		if f.Base {
+			err = copyBackupFile(f.FileName, backupFlags.Since, backupFlags.Path)
+		} else {
+			err = fetchBackupFile(ctx, backupService, manifest.ID, f.FileName)
 		}
-		err = backupService.FetchBackupFile(ctx, id, backupFilename, w)
 		if err != nil {
-			return multierr.Append(fmt.Errorf("error fetching file %s: %v", backupFilename, err), w.Close())
+			return err
 		}
-		if err = w.Close(); err != nil {
+		if err := verifyBackupFile(backupFlags.Path, f); err != nil {
 			return err
 		}
 	}
 
+	w, err := os.OpenFile(filepath.Join(backupFlags.Path, influxdb.BackupManifestFilename), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	if err := writeJSON(w, manifest); err != nil {
+		return multierr.Append(err, w.Close())
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+
 	fmt.Printf("Backup complete")
 
 	return nil
 }
+
+// newBackupFilter builds the filter of the backup from the bucket,
+// organization and base backup flags.
+func newBackupFilter(ctx context.Context) (influxdb.BackupFilter, error) {
+	var filter influxdb.BackupFilter
+
+	if backupFlags.Bucket != "" && backupFlags.BucketID != "" {
+		return filter, fmt.Errorf("please specify one of bucket or bucket-id")
+	}
+
+	if backupFlags.Bucket != "" || backupFlags.BucketID != "" {
+		bucketFilter := influxdb.BucketFilter{}
+		if backupFlags.BucketID != "" {
+			id, err := influxdb.IDFromString(backupFlags.BucketID)
+			if err != nil {
+				return filter, fmt.Errorf("failed to decode bucket-id: %v", err)
+			}
+			bucketFilter.ID = id
+		} else {
+			if err := backupFlags.org.validOrgFlags(&flags); err != nil {
+				return filter, err
+			}
+			bucketFilter.Name = &backupFlags.Bucket
+			if backupFlags.org.id != "" {
+				id, err := influxdb.IDFromString(backupFlags.org.id)
+				if err != nil {
+					return filter, fmt.Errorf("failed to decode org-id: %v", err)
+				}
+				bucketFilter.OrganizationID = id
+			} else {
+				bucketFilter.Org = &backupFlags.org.name
+			}
+		}
+
+		bucketSvc, err := newBucketService()
+		if err != nil {
+			return filter, err
+		}
+		bucket, err := bucketSvc.FindBucket(ctx, bucketFilter)
+		if err != nil {
+			return filter, fmt.Errorf("failed to find bucket: %v", err)
+		}
+		filter.OrgID = &bucket.OrgID
+		filter.BucketID = &bucket.ID
+	} else if backupFlags.org.id != "" || backupFlags.org.name != "" {
+		if err := backupFlags.org.validOrgFlags(nil); err != nil {
+			return filter, err
+		}
+		orgSvc, err := newOrganizationService()
+		if err != nil {
+			return filter, err
+		}
+		orgID, err := backupFlags.org.getID(orgSvc)
+		if err != nil {
+			return filter, fmt.Errorf("failed to find organization: %v", err)
+		}
+		filter.OrgID = &orgID
+	}
+
+	if backupFlags.Since != "" {
+		base, err := readBackupManifest(backupFlags.Since)
+		if err != nil {
+			return filter, err
+		}
+		filter.Since = base.Files
+	}
+
+	return filter, nil
+}
+
+func readBackupManifest(dir string) (*influxdb.BackupManifest, error) {
+	f, err := os.Open(filepath.Join(dir, influxdb.BackupManifestFilename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open base backup manifest: %v", err)
+	}
+	defer f.Close()
+
+	var manifest influxdb.BackupManifest
+	if err := json.NewDecoder(f).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to decode base backup manifest: %v", err)
+	}
+	return &manifest, nil
+}
+
+func fetchBackupFile(ctx context.Context, backupService influxdb.BackupService, backupID int, backupFilename string) error {
+	dest := filepath.Join(backupFlags.Path, backupFilename)
+	w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	err = backupService.FetchBackupFile(ctx, backupID, backupFilename, w)
+	if err != nil {
+		return multierr.Append(fmt.Errorf("error fetching file %s: %v", backupFilename, err), w.Close())
+	}
+	return w.Close()
+}
+
+// copyBackupFile links, or copies if linking fails, a file of the base backup
+// into the backup directory.
+func copyBackupFile(backupFilename, baseDir, dir string) error {
+	src := filepath.Join(baseDir, backupFilename)
+	dest := filepath.Join(dir, backupFilename)
+	if err := os.Link(src, dest); err == nil {
+		return nil
+	}
+
+	r, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("error opening base backup file %s: %v", backupFilename, err)
+	}
+	defer r.Close()
+
+	w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		return multierr.Append(fmt.Errorf("error copying base backup file %s: %v", backupFilename, err), w.Close())
+	}
+	return w.Close()
+}
+
+func verifyBackupFile(dir string, f *influxdb.BackupFile) error {
+	checksum, size, err := fs.Checksum(filepath.Join(dir, f.FileName))
+	if err != nil {
+		return err
+	}
+	// The server only checksums the files it compares with a base backup,
+	// the manifest records the checksums of the fetched files otherwise.
+	if f.Checksum == "" {
+		f.Checksum, f.Size = checksum, size
+		return nil
+	}
+	if checksum != f.Checksum || size != f.Size {
+		return fmt.Errorf("backup file %s does not match the manifest checksum", f.FileName)
+	}
+	return nil
+}
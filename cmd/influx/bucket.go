@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb/v2"
@@ -304,7 +305,7 @@ func (b *cmdBucketBuilder) printBuckets(printOpt bucketPrintOpt) error {
 
 	w.HideHeaders(b.hideHeaders)
 
-	headers := []string{"ID", "Name", "Retention", "Organization ID"}
+	headers := []string{"ID", "Name", "Retention", "Downsample", "Organization ID"}
 	if printOpt.deleted {
 		headers = append(headers, "Deleted")
 	}
@@ -319,6 +320,7 @@ func (b *cmdBucketBuilder) printBuckets(printOpt bucketPrintOpt) error {
 			"ID":              bkt.ID.String(),
 			"Name":            bkt.Name,
 			"Retention":       bkt.RetentionPeriod,
+			"Downsample":      formatDownsampleRules(bkt.DownsampleRules),
 			"Organization ID": bkt.OrgID.String(),
 		}
 		if printOpt.deleted {
@@ -330,6 +332,15 @@ func (b *cmdBucketBuilder) printBuckets(printOpt bucketPrintOpt) error {
 	return nil
 }
 
+// formatDownsampleRules formats rules as "after every functions->bucket" entries.
+func formatDownsampleRules(rules []influxdb.DownsampleRule) string {
+	out := make([]string, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, fmt.Sprintf("%s %s %s->%s", r.After, r.Every, strings.Join(r.Functions, ","), r.DestinationBucket))
+	}
+	return strings.Join(out, "; ")
+}
+
 func newBucketSVCs() (influxdb.BucketService, influxdb.OrganizationService, error) {
 	httpClient, err := newHTTPClient()
 	if err != nil {
@@ -266,7 +266,10 @@ func influxCmd(opts ...genericCLIOptFn) *cobra.Command {
 		cmdPing,
 		cmdQuery,
 		cmdREPL,
+		cmdRestore,
+		cmdRole,
 		cmdSecret,
+		cmdSilence,
 		cmdSetup,
 		cmdStack,
 		cmdTask,
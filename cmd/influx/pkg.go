@@ -80,6 +80,7 @@ type cmdPkgBuilder struct {
 		dashboards   string
 		endpoints    string
 		labels       string
+		roles        string
 		rules        string
 		tasks        string
 		telegrafs    string
@@ -299,6 +300,7 @@ func (b *cmdPkgBuilder) cmdPkgExport() *cobra.Command {
 	cmd.Flags().StringVar(&b.exportOpts.dashboards, "dashboards", "", "List of dashboard ids comma separated")
 	cmd.Flags().StringVar(&b.exportOpts.endpoints, "endpoints", "", "List of notification endpoint ids comma separated")
 	cmd.Flags().StringVar(&b.exportOpts.labels, "labels", "", "List of label ids comma separated")
+	cmd.Flags().StringVar(&b.exportOpts.roles, "roles", "", "List of role ids comma separated")
 	cmd.Flags().StringVar(&b.exportOpts.rules, "rules", "", "List of notification rule ids comma separated")
 	cmd.Flags().StringVar(&b.exportOpts.tasks, "tasks", "", "List of task ids comma separated")
 	cmd.Flags().StringVar(&b.exportOpts.telegrafs, "telegraf-configs", "", "List of telegraf config ids comma separated")
@@ -325,6 +327,7 @@ func (b *cmdPkgBuilder) pkgExportRunEFn(cmd *cobra.Command, args []string) error
 		{kind: pkger.KindLabel, idStrs: strings.Split(b.exportOpts.labels, ",")},
 		{kind: pkger.KindNotificationEndpoint, idStrs: strings.Split(b.exportOpts.endpoints, ",")},
 		{kind: pkger.KindNotificationRule, idStrs: strings.Split(b.exportOpts.rules, ",")},
+		{kind: pkger.KindRole, idStrs: strings.Split(b.exportOpts.roles, ",")},
 		{kind: pkger.KindTask, idStrs: strings.Split(b.exportOpts.tasks, ",")},
 		{kind: pkger.KindTelegraf, idStrs: strings.Split(b.exportOpts.telegrafs, ",")},
 		{kind: pkger.KindVariable, idStrs: strings.Split(b.exportOpts.variables, ",")},
@@ -1269,6 +1272,31 @@ func (b *cmdPkgBuilder) printPkgDiff(diff pkger.Diff) error {
 		printer.Render()
 	}
 
+	if roles := diff.Roles; len(roles) > 0 {
+		printer := diffPrinterGen("Roles", []string{"Description", "Permissions"})
+		appendValues := func(id pkger.SafeID, pkgName string, v pkger.DiffRoleValues) []string {
+			return []string{pkgName, id.String(), v.Name, v.Description, v.Permissions.String()}
+		}
+
+		for _, e := range roles {
+			var oldRow []string
+			if e.Old != nil {
+				oldRow = appendValues(e.ID, e.PkgName, *e.Old)
+			}
+
+			newRow := appendValues(e.ID, e.PkgName, e.New)
+			switch {
+			case e.IsNew():
+				printer.AppendDiff(nil, newRow)
+			case e.Remove:
+				printer.AppendDiff(oldRow, nil)
+			default:
+				printer.AppendDiff(oldRow, newRow)
+			}
+		}
+		printer.Render()
+	}
+
 	if teles := diff.Telegrafs; len(teles) > 0 {
 		printer := diffPrinterGen("Telegraf Configurations", []string{"Description"})
 		appendValues := func(id pkger.SafeID, pkgName string, v influxdb.TelegrafConfig) []string {
@@ -1483,6 +1511,20 @@ func (b *cmdPkgBuilder) printPkgSummary(stackID influxdb.ID, sum pkger.Summary)
 		})
 	}
 
+	if roles := sum.Roles; len(roles) > 0 {
+		headers := append(commonHeaders, "Description", "Permissions")
+		tablePrintFn("ROLES", headers, len(roles), func(i int) []string {
+			r := roles[i]
+			return []string{
+				r.PkgName,
+				r.ID.String(),
+				r.Name,
+				r.Description,
+				r.Permissions.String(),
+			}
+		})
+	}
+
 	if tasks := sum.Tasks; len(tasks) > 0 {
 		headers := append(commonHeaders, "Description", "Cycle")
 		tablePrintFn("TASKS", headers, len(tasks), func(i int) []string {
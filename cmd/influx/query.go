@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/runtime"
 	_ "github.com/influxdata/flux/stdlib"
 	"github.com/influxdata/flux/stdlib/influxdata/influxdb"
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
 	_ "github.com/influxdata/influxdb/v2/query/stdlib"
 	"github.com/spf13/cobra"
 )
@@ -28,6 +32,12 @@ func cmdQuery(f *globalFlags, opts genericCLIOpts) *cobra.Command {
 	queryFlags.org.register(cmd, true)
 	cmd.Flags().StringVarP(&queryFlags.file, "file", "f", "", "Path to Flux query file")
 
+	builder := newCmdActiveQueryBuilder(newActiveQuerySVCs, opts)
+	cmd.AddCommand(
+		builder.cmdKill(),
+		builder.cmdList(),
+	)
+
 	return cmd
 }
 
@@ -101,3 +111,172 @@ func fluxQueryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+type activeQuerySVCsFn func() (platform.ActiveQueryService, platform.OrganizationService, error)
+
+// cmdActiveQueryBuilder builds the commands that manage the queries running
+// on the server.
+type cmdActiveQueryBuilder struct {
+	genericCLIOpts
+
+	svcFn activeQuerySVCsFn
+
+	json        bool
+	hideHeaders bool
+	id          string
+	org         organization
+}
+
+func newCmdActiveQueryBuilder(svcFn activeQuerySVCsFn, opt genericCLIOpts) *cmdActiveQueryBuilder {
+	return &cmdActiveQueryBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcFn,
+	}
+}
+
+func (b *cmdActiveQueryBuilder) cmdList() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdListRunEFn, true)
+	cmd.Short = "List the queries running on the server"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The query ID")
+	b.org.register(cmd, false)
+	registerPrintOptions(cmd, &b.hideHeaders, &b.json)
+
+	return cmd
+}
+
+func (b *cmdActiveQueryBuilder) cmdListRunEFn(cmd *cobra.Command, args []string) error {
+	querySVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := platform.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode query id %q: %v", b.id, err)
+		}
+		q, err := querySVC.FindActiveQueryByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve query: %v", err)
+		}
+		return b.printActiveQueries(activeQueryPrintOpt{query: q})
+	}
+
+	var filter platform.ActiveQueryFilter
+	if b.org.id != "" || b.org.name != "" {
+		orgID, err := b.org.getID(orgSVC)
+		if err != nil {
+			return err
+		}
+		filter.OrgID = &orgID
+	}
+
+	queries, err := querySVC.FindActiveQueries(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve queries: %v", err)
+	}
+
+	return b.printActiveQueries(activeQueryPrintOpt{queries: queries})
+}
+
+func (b *cmdActiveQueryBuilder) cmdKill() *cobra.Command {
+	cmd := b.newCmd("kill", b.cmdKillRunEFn, true)
+	cmd.Short = "Cancel a query running on the server"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The query ID (required)")
+	cmd.MarkFlagRequired("id")
+	registerPrintOptions(cmd, &b.hideHeaders, &b.json)
+
+	return cmd
+}
+
+func (b *cmdActiveQueryBuilder) cmdKillRunEFn(cmd *cobra.Command, args []string) error {
+	querySVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id platform.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode query id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	q, err := querySVC.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find query with id %q: %v", id, err)
+	}
+	if err := querySVC.CancelActiveQuery(ctx, id); err != nil {
+		return fmt.Errorf("failed to cancel query with id %q: %v", id, err)
+	}
+
+	return b.printActiveQueries(activeQueryPrintOpt{
+		canceled: true,
+		query:    q,
+	})
+}
+
+func (b *cmdActiveQueryBuilder) printActiveQueries(opt activeQueryPrintOpt) error {
+	if b.json {
+		var v interface{} = opt.queries
+		if opt.queries == nil {
+			v = opt.query
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Organization ID", "User ID", "Authorization ID", "Type", "State", "Runtime", "Memory Allocated", "Query"}
+	if opt.canceled {
+		headers = append(headers, "Canceled")
+	}
+	w.WriteHeaders(headers...)
+
+	if opt.query != nil {
+		opt.queries = append(opt.queries, opt.query)
+	}
+
+	for _, q := range opt.queries {
+		m := map[string]interface{}{
+			"ID":               q.ID.String(),
+			"Organization ID":  q.OrgID.String(),
+			"User ID":          q.UserID.String(),
+			"Authorization ID": q.AuthorizationID.String(),
+			"Type":             q.CompilerType,
+			"State":            q.State,
+			"Runtime":          q.Runtime.Round(time.Millisecond).String(),
+			"Memory Allocated": q.MemoryAllocated,
+			// Keep each query on a single line of the table.
+			"Query": strings.Join(strings.Fields(q.Query), " "),
+		}
+		if opt.canceled {
+			m["Canceled"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+type activeQueryPrintOpt struct {
+	canceled bool
+	query    *platform.ActiveQuery
+	queries  []*platform.ActiveQuery
+}
+
+func newActiveQuerySVCs() (platform.ActiveQueryService, platform.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	orgSvc := &http.OrganizationService{Client: httpClient}
+
+	return &http.ActiveQueryService{Client: httpClient}, orgSvc, nil
+}
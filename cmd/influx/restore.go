@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/spf13/cobra"
+)
+
+func cmdRestore(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("restore", restoreF, false)
+	cmd.Short = "Restore a bucket from a backup into InfluxDB"
+	cmd.Long = fmt.Sprintf(
+		`Restores the data of a single bucket of a backup into the running InfluxDB
+instance. The backup directory indicated by --path must hold the %s
+written by the backup command.
+
+The data of the bucket given by --bucket-id is restored into a new bucket
+named --new-bucket, in the organization given by --org or --org-id. The
+other buckets of the server stay available during the restore.`,
+		influxdb.BackupManifestFilename)
+
+	restoreFlags.org.register(cmd, false)
+	opts := flagOpts{
+		{
+			DestP:    &restoreFlags.Path,
+			Flag:     "path",
+			Short:    'p',
+			EnvVar:   "PATH",
+			Desc:     "directory path of the backup to restore from",
+			Required: true,
+		},
+		{
+			DestP:    &restoreFlags.BucketID,
+			Flag:     "bucket-id",
+			Desc:     "The ID of the bucket in the backup to restore",
+			Required: true,
+		},
+		{
+			DestP:    &restoreFlags.NewBucket,
+			Flag:     "new-bucket",
+			Desc:     "The name of the bucket to create and restore the data into",
+			Required: true,
+		},
+	}
+	opts.mustRegister(cmd)
+
+	return cmd
+}
+
+var restoreFlags struct {
+	org       organization
+	Path      string
+	BucketID  string
+	NewBucket string
+}
+
+func newRestoreService() *http.RestoreService {
+	return &http.RestoreService{
+		Addr:               flags.Host,
+		Token:              flags.Token,
+		InsecureSkipVerify: flags.skipVerify,
+	}
+}
+
+func restoreF(cmd *cobra.Command, args []string) error {
+	ctx := context.Background()
+
+	if flags.local {
+		return fmt.Errorf("local flag not supported for restore command")
+	}
+
+	if restoreFlags.Path == "" {
+		return fmt.Errorf("must specify path")
+	}
+	if restoreFlags.NewBucket == "" {
+		return fmt.Errorf("must specify new-bucket")
+	}
+	sourceBucketID, err := influxdb.IDFromString(restoreFlags.BucketID)
+	if err != nil {
+		return fmt.Errorf("failed to decode bucket-id: %v", err)
+	}
+
+	if err := restoreFlags.org.validOrgFlags(&flags); err != nil {
+		return err
+	}
+	orgSvc, err := newOrganizationService()
+	if err != nil {
+		return err
+	}
+	orgID, err := restoreFlags.org.getID(orgSvc)
+	if err != nil {
+		return fmt.Errorf("failed to find organization: %v", err)
+	}
+
+	manifest, err := readBackupManifest(restoreFlags.Path)
+	if err != nil {
+		return err
+	}
+	source, files, err := restoreBucketFiles(manifest, *sourceBucketID, restoreFlags.Path)
+	if err != nil {
+		return err
+	}
+
+	bucket := &influxdb.Bucket{
+		OrgID: orgID,
+		Name:  restoreFlags.NewBucket,
+	}
+	if err := newRestoreService().RestoreBucket(ctx, source, bucket, files); err != nil {
+		return fmt.Errorf("failed to restore bucket: %v", err)
+	}
+
+	fmt.Printf("Restored bucket %s into bucket %s (%s)\n", source.BucketID, bucket.Name, bucket.ID)
+	return nil
+}
+
+// restoreBucketFiles returns the source bucket and the paths of the backup
+// files holding its data, with each tombstone file before its TSM file.
+func restoreBucketFiles(manifest *influxdb.BackupManifest, bucketID influxdb.ID, dir string) (influxdb.BackupBucket, []string, error) {
+	var (
+		source     influxdb.BackupBucket
+		tombstones = make(map[string]string)
+		files      []string
+	)
+	for _, f := range manifest.Files {
+		if ext := filepath.Ext(f.FileName); ext == ".tombstone" {
+			tombstones[strings.TrimSuffix(f.FileName, ext)+".tsm"] = filepath.Join(dir, f.FileName)
+		}
+	}
+	for _, f := range manifest.Files {
+		for _, b := range f.Buckets {
+			if b.BucketID != bucketID {
+				continue
+			}
+			source = b
+			if path, ok := tombstones[f.FileName]; ok {
+				files = append(files, path)
+			}
+			files = append(files, filepath.Join(dir, f.FileName))
+			break
+		}
+	}
+	if len(files) == 0 {
+		return source, nil, fmt.Errorf("backup in %s has no data for bucket %s", dir, bucketID)
+	}
+	for _, path := range files {
+		if _, err := os.Stat(path); err != nil {
+			return source, nil, fmt.Errorf("backup file missing: %v", err)
+		}
+	}
+	return source, files, nil
+}
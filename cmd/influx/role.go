@@ -0,0 +1,494 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/spf13/cobra"
+)
+
+type roleSVCsFn func() (influxdb.RoleService, influxdb.OrganizationService, influxdb.UserResourceMappingService, influxdb.UserService, error)
+
+func cmdRole(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdRoleBuilder(newRoleSVCs, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdRoleBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn roleSVCsFn
+
+	json        bool
+	hideHeaders bool
+	id          string
+	name        string
+	description string
+	permissions []string
+	memberID    string
+	org         organization
+}
+
+func newCmdRoleBuilder(svcsFn roleSVCsFn, opt genericCLIOpts) *cmdRoleBuilder {
+	return &cmdRoleBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdRoleBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("role", nil, false)
+	cmd.Short = "Role management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdFind(),
+		b.cmdMember(),
+		b.cmdUpdate(),
+	)
+	return cmd
+}
+
+func (b *cmdRoleBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn, true)
+	cmd.Short = "Create role"
+
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "The role name (required)")
+	cmd.MarkFlagRequired("name")
+	b.registerRoleFlags(cmd)
+	b.org.register(cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) cmdCreateRunEFn(cmd *cobra.Command, args []string) error {
+	roleSVC, orgSVC, _, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	role := &influxdb.Role{
+		OrgID:       orgID,
+		Name:        b.name,
+		Description: b.description,
+	}
+	if role.Permissions, err = parseRolePermissions(b.permissions, orgID); err != nil {
+		return err
+	}
+
+	if err := roleSVC.CreateRole(context.Background(), role); err != nil {
+		return fmt.Errorf("failed to create role: %v", err)
+	}
+
+	return b.printRoles(rolePrintOpt{role: role})
+}
+
+func (b *cmdRoleBuilder) cmdFind() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdFindRunEFn, true)
+	cmd.Short = "List roles"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The role ID")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "The role name")
+	b.org.register(cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) cmdFindRunEFn(cmd *cobra.Command, args []string) error {
+	roleSVC, orgSVC, _, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode role id %q: %v", b.id, err)
+		}
+		role, err := roleSVC.FindRoleByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve role: %v", err)
+		}
+		return b.printRoles(rolePrintOpt{role: role})
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	filter := influxdb.RoleFilter{OrgID: &orgID}
+	if b.name != "" {
+		filter.Name = &b.name
+	}
+
+	roles, _, err := roleSVC.FindRoles(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve roles: %v", err)
+	}
+
+	return b.printRoles(rolePrintOpt{roles: roles})
+}
+
+func (b *cmdRoleBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn, true)
+	cmd.Short = "Update role"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The role ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVarP(&b.name, "name", "n", "", "The new role name")
+	b.registerRoleFlags(cmd)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	roleSVC, _, _, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode role id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	var upd influxdb.RoleUpdate
+	if b.name != "" {
+		upd.Name = &b.name
+	}
+	if cmd.Flags().Changed("description") {
+		upd.Description = &b.description
+	}
+	if len(b.permissions) > 0 {
+		role, err := roleSVC.FindRoleByID(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to find role with id %q: %v", id, err)
+		}
+		if upd.Permissions, err = parseRolePermissions(b.permissions, role.OrgID); err != nil {
+			return err
+		}
+	}
+
+	role, err := roleSVC.UpdateRole(ctx, id, upd)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %v", err)
+	}
+
+	return b.printRoles(rolePrintOpt{role: role})
+}
+
+func (b *cmdRoleBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn, true)
+	cmd.Short = "Delete role"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The role ID (required)")
+	cmd.MarkFlagRequired("id")
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	roleSVC, _, _, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode role id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	role, err := roleSVC.FindRoleByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find role with id %q: %v", id, err)
+	}
+	if err := roleSVC.DeleteRole(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete role with id %q: %v", id, err)
+	}
+
+	return b.printRoles(rolePrintOpt{
+		deleted: true,
+		role:    role,
+	})
+}
+
+func (b *cmdRoleBuilder) cmdMember() *cobra.Command {
+	cmd := b.newCmd("members", nil, false)
+	cmd.Short = "Role membership commands"
+	cmd.Run = seeHelp
+
+	cmd.AddCommand(
+		b.cmdMemberAdd(),
+		b.cmdMemberList(),
+		b.cmdMemberRemove(),
+	)
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) cmdMemberAdd() *cobra.Command {
+	cmd := b.newCmd("add", b.memberAddRunEFn, true)
+	cmd.Short = "Grant the role to a user"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The role ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVarP(&b.memberID, "member", "m", "", "The member ID (required)")
+	cmd.MarkFlagRequired("member")
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) memberAddRunEFn(cmd *cobra.Command, args []string) error {
+	_, _, urmSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	roleID, memberID, err := b.memberIDs()
+	if err != nil {
+		return err
+	}
+
+	return addMember(context.Background(), b.w, urmSVC, influxdb.UserResourceMapping{
+		ResourceID:   roleID,
+		ResourceType: influxdb.RolesResourceType,
+		MappingType:  influxdb.UserMappingType,
+		UserID:       memberID,
+		UserType:     influxdb.Member,
+	})
+}
+
+func (b *cmdRoleBuilder) cmdMemberList() *cobra.Command {
+	cmd := b.newCmd("list", b.memberListRunEFn, true)
+	cmd.Short = "List the users the role is granted to"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The role ID (required)")
+	cmd.MarkFlagRequired("id")
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) memberListRunEFn(cmd *cobra.Command, args []string) error {
+	_, _, urmSVC, userSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode role id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	mappings, _, err := urmSVC.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+		ResourceType: influxdb.RolesResourceType,
+		ResourceID:   id,
+		UserType:     influxdb.Member,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to find members: %v", err)
+	}
+
+	users := make([]*influxdb.User, 0, len(mappings))
+	for _, m := range mappings {
+		u, err := userSVC.FindUserByID(ctx, m.UserID)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve user details: %v", err)
+		}
+		users = append(users, u)
+	}
+
+	if b.json {
+		return b.writeJSON(users)
+	}
+
+	tw := b.newTabWriter()
+	defer tw.Flush()
+
+	tw.HideHeaders(b.hideHeaders)
+
+	tw.WriteHeaders("ID", "Name", "Status")
+	for _, u := range users {
+		tw.Write(map[string]interface{}{
+			"ID":     u.ID.String(),
+			"Name":   u.Name,
+			"Status": string(u.Status),
+		})
+	}
+
+	return nil
+}
+
+func (b *cmdRoleBuilder) cmdMemberRemove() *cobra.Command {
+	cmd := b.newCmd("remove", b.memberRemoveRunEFn, true)
+	cmd.Short = "Revoke the role from a user"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The role ID (required)")
+	cmd.MarkFlagRequired("id")
+	cmd.Flags().StringVarP(&b.memberID, "member", "m", "", "The member ID (required)")
+	cmd.MarkFlagRequired("member")
+
+	return cmd
+}
+
+func (b *cmdRoleBuilder) memberRemoveRunEFn(cmd *cobra.Command, args []string) error {
+	_, _, urmSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	roleID, memberID, err := b.memberIDs()
+	if err != nil {
+		return err
+	}
+
+	return removeMember(context.Background(), b.w, urmSVC, roleID, memberID)
+}
+
+func (b *cmdRoleBuilder) memberIDs() (roleID, memberID influxdb.ID, err error) {
+	if err := roleID.DecodeFromString(b.id); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode role id %q: %v", b.id, err)
+	}
+	if err := memberID.DecodeFromString(b.memberID); err != nil {
+		return 0, 0, fmt.Errorf("failed to decode member id %q: %v", b.memberID, err)
+	}
+	return roleID, memberID, nil
+}
+
+func (b *cmdRoleBuilder) registerRoleFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&b.description, "description", "d", "", "Description of the role")
+	cmd.Flags().StringArrayVarP(&b.permissions, "permission", "p", nil, "Permission granted by the role within its organization; format should be --permission=read:buckets or --permission=write:buckets/<id>")
+}
+
+func (b *cmdRoleBuilder) registerPrintFlags(cmd *cobra.Command) {
+	registerPrintOptions(cmd, &b.hideHeaders, &b.json)
+}
+
+func (b *cmdRoleBuilder) printRoles(opt rolePrintOpt) error {
+	if b.json {
+		var v interface{} = opt.roles
+		if opt.roles == nil {
+			v = opt.role
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Name", "Organization ID", "Description", "Permissions"}
+	if opt.deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+
+	if opt.role != nil {
+		opt.roles = append(opt.roles, opt.role)
+	}
+
+	for _, r := range opt.roles {
+		ps := make([]string, 0, len(r.Permissions))
+		for _, p := range r.Permissions {
+			ps = append(ps, p.String())
+		}
+
+		m := map[string]interface{}{
+			"ID":              r.ID.String(),
+			"Name":            r.Name,
+			"Organization ID": r.OrgID.String(),
+			"Description":     r.Description,
+			"Permissions":     strings.Join(ps, ","),
+		}
+		if opt.deleted {
+			m["Deleted"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+type rolePrintOpt struct {
+	deleted bool
+	role    *influxdb.Role
+	roles   []*influxdb.Role
+}
+
+// parseRolePermissions parses permissions of the form action:type or
+// action:type/id into permissions on the resources of the organization.
+func parseRolePermissions(ss []string, orgID influxdb.ID) ([]influxdb.Permission, error) {
+	var ps []influxdb.Permission
+	for _, s := range ss {
+		parts := strings.SplitN(s, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid permission %q: must be of the form action:type[/id]", s)
+		}
+		action := influxdb.Action(parts[0])
+		rt, rawID := parts[1], ""
+		if i := strings.Index(rt, "/"); i >= 0 {
+			rt, rawID = rt[:i], rt[i+1:]
+		}
+
+		var (
+			p   *influxdb.Permission
+			err error
+		)
+		switch {
+		case rt == string(influxdb.OrgsResourceType):
+			// the only organization a role can grant access to is its own
+			p, err = influxdb.NewResourcePermission(action, influxdb.OrgsResourceType, orgID)
+		case rawID == "":
+			p, err = influxdb.NewPermission(action, influxdb.ResourceType(rt), orgID)
+		default:
+			var id *influxdb.ID
+			if id, err = influxdb.IDFromString(rawID); err != nil {
+				return nil, fmt.Errorf("invalid permission %q: %v", s, err)
+			}
+			p, err = influxdb.NewPermissionAtID(*id, action, influxdb.ResourceType(rt), orgID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid permission %q: %v", s, err)
+		}
+		ps = append(ps, *p)
+	}
+	return ps, nil
+}
+
+func newRoleSVCs() (influxdb.RoleService, influxdb.OrganizationService, influxdb.UserResourceMappingService, influxdb.UserService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	orgSvc := &http.OrganizationService{Client: httpClient}
+	urmSvc := &http.UserResourceMappingService{
+		Client:       httpClient,
+		ResourceType: influxdb.RolesResourceType,
+	}
+	userSvc := &http.UserService{Client: httpClient}
+
+	return &http.RoleService{Client: httpClient}, orgSvc, urmSvc, userSvc, nil
+}
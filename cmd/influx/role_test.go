@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdRole(t *testing.T) {
+	orgID := influxdb.ID(9000)
+
+	fakeSVCFn := func(svc influxdb.RoleService, urmSVC influxdb.UserResourceMappingService) roleSVCsFn {
+		return func() (influxdb.RoleService, influxdb.OrganizationService, influxdb.UserResourceMappingService, influxdb.UserService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, urmSVC, mock.NewUserService(), nil
+		}
+	}
+
+	newCmd := func(svcFn roleSVCsFn, args ...string) *cobra.Command {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdRoleBuilder(svcFn, opt).cmd()
+		})
+		cmd.SetArgs(append([]string{"role"}, args...))
+		return cmd
+	}
+
+	t.Run("create", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.Role
+		}{
+			{
+				name: "permissions on all resources of a type",
+				flags: []string{
+					"--org=influxdata",
+					"--name=analyst",
+					"--description=reads everything",
+					"--permission=read:buckets",
+					"--permission=read:dashboards",
+				},
+				expected: influxdb.Role{
+					OrgID:       orgID,
+					Name:        "analyst",
+					Description: "reads everything",
+					Permissions: []influxdb.Permission{
+						{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType, OrgID: &orgID}},
+						{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.DashboardsResourceType, OrgID: &orgID}},
+					},
+				},
+			},
+			{
+				name: "permissions on a resource and the org",
+				flags: []string{
+					"--org-id=" + orgID.String(),
+					"-n=alert manager",
+					"-p=write:checks/" + influxdb.ID(3).String(),
+					"-p=read:orgs",
+				},
+				expected: influxdb.Role{
+					OrgID: orgID,
+					Name:  "alert manager",
+					Permissions: []influxdb.Permission{
+						{Action: influxdb.WriteAction, Resource: influxdb.Resource{Type: influxdb.ChecksResourceType, OrgID: &orgID, ID: idPtr(3)}},
+						{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.OrgsResourceType, ID: &orgID}},
+					},
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				svc := mock.NewRoleService()
+				var got influxdb.Role
+				svc.CreateRoleFn = func(ctx context.Context, r *influxdb.Role) error {
+					got = *r
+					return nil
+				}
+
+				cmd := newCmd(fakeSVCFn(svc, nil), append([]string{"create"}, tt.flags...)...)
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, got)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("create with an invalid permission", func(t *testing.T) {
+		cmd := newCmd(fakeSVCFn(mock.NewRoleService(), nil), "create", "--org=influxdata", "--name=analyst", "--permission=read")
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		svc := mock.NewRoleService()
+		svc.FindRoleByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+			return &influxdb.Role{ID: id, OrgID: orgID}, nil
+		}
+		var got influxdb.RoleUpdate
+		svc.UpdateRoleFn = func(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+			got = upd
+			return &influxdb.Role{ID: id, OrgID: orgID}, nil
+		}
+
+		cmd := newCmd(fakeSVCFn(svc, nil), "update", "--id="+influxdb.ID(1).String(), "--description=", "--permission=write:tasks")
+		require.NoError(t, cmd.Execute())
+		description := ""
+		assert.Equal(t, influxdb.RoleUpdate{
+			Description: &description,
+			Permissions: []influxdb.Permission{
+				{Action: influxdb.WriteAction, Resource: influxdb.Resource{Type: influxdb.TasksResourceType, OrgID: &orgID}},
+			},
+		}, got)
+	})
+
+	t.Run("members add", func(t *testing.T) {
+		urmSVC := mock.NewUserResourceMappingService()
+		var got influxdb.UserResourceMapping
+		urmSVC.CreateMappingFn = func(ctx context.Context, m *influxdb.UserResourceMapping) error {
+			got = *m
+			return nil
+		}
+
+		cmd := newCmd(fakeSVCFn(mock.NewRoleService(), urmSVC), "members", "add", "--id="+influxdb.ID(1).String(), "--member="+influxdb.ID(2).String())
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, influxdb.UserResourceMapping{
+			ResourceID:   1,
+			ResourceType: influxdb.RolesResourceType,
+			MappingType:  influxdb.UserMappingType,
+			UserID:       2,
+			UserType:     influxdb.Member,
+		}, got)
+	})
+}
+
+func idPtr(id influxdb.ID) *influxdb.ID {
+	return &id
+}
@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http"
+	"github.com/spf13/cobra"
+)
+
+type silenceSVCsFn func() (influxdb.SilenceService, influxdb.OrganizationService, error)
+
+func cmdSilence(f *globalFlags, opt genericCLIOpts) *cobra.Command {
+	builder := newCmdSilenceBuilder(newSilenceSVCs, opt)
+	builder.globalFlags = f
+	return builder.cmd()
+}
+
+type cmdSilenceBuilder struct {
+	genericCLIOpts
+	*globalFlags
+
+	svcFn silenceSVCsFn
+
+	json        bool
+	hideHeaders bool
+	id          string
+	matchers    []string
+	checkIDs    []string
+	start       string
+	end         string
+	duration    time.Duration
+	comment     string
+	active      bool
+	org         organization
+}
+
+func newCmdSilenceBuilder(svcsFn silenceSVCsFn, opt genericCLIOpts) *cmdSilenceBuilder {
+	return &cmdSilenceBuilder{
+		genericCLIOpts: opt,
+		svcFn:          svcsFn,
+	}
+}
+
+func (b *cmdSilenceBuilder) cmd() *cobra.Command {
+	cmd := b.newCmd("silence", nil, false)
+	cmd.Short = "Notification rule silence management commands"
+	cmd.Run = seeHelp
+	cmd.AddCommand(
+		b.cmdCreate(),
+		b.cmdDelete(),
+		b.cmdFind(),
+		b.cmdUpdate(),
+	)
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdCreate() *cobra.Command {
+	cmd := b.newCmd("create", b.cmdCreateRunEFn, true)
+	cmd.Short = "Create silence"
+
+	b.registerSilenceFlags(cmd)
+	cmd.Flags().DurationVarP(&b.duration, "duration", "d", 0, "Duration of the silence from its start; alternative to --end")
+	b.org.register(cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdCreateRunEFn(cmd *cobra.Command, args []string) error {
+	silSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	sil := &influxdb.Silence{
+		OrgID:    orgID,
+		StartsAt: time.Now().UTC(),
+		Comment:  b.comment,
+	}
+	if sil.Matchers, err = parseSilenceMatchers(b.matchers); err != nil {
+		return err
+	}
+	if sil.CheckIDs, err = parseSilenceCheckIDs(b.checkIDs); err != nil {
+		return err
+	}
+	if b.start != "" {
+		if sil.StartsAt, err = time.Parse(time.RFC3339, b.start); err != nil {
+			return fmt.Errorf("invalid start time %q: %v", b.start, err)
+		}
+	}
+	switch {
+	case b.end != "" && b.duration != 0:
+		return fmt.Errorf("must specify end or duration, not both")
+	case b.end != "":
+		if sil.EndsAt, err = time.Parse(time.RFC3339, b.end); err != nil {
+			return fmt.Errorf("invalid end time %q: %v", b.end, err)
+		}
+	case b.duration != 0:
+		sil.EndsAt = sil.StartsAt.Add(b.duration)
+	default:
+		return fmt.Errorf("must specify end or duration")
+	}
+
+	if err := silSVC.CreateSilence(context.Background(), sil); err != nil {
+		return fmt.Errorf("failed to create silence: %v", err)
+	}
+
+	return b.printSilences(silencePrintOpt{silence: sil})
+}
+
+func (b *cmdSilenceBuilder) cmdFind() *cobra.Command {
+	cmd := b.newCmd("list", b.cmdFindRunEFn, true)
+	cmd.Short = "List silences"
+	cmd.Aliases = []string{"find", "ls"}
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The silence ID")
+	cmd.Flags().BoolVar(&b.active, "active", false, "Only list the silences that are currently active")
+	b.org.register(cmd, false)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdFindRunEFn(cmd *cobra.Command, args []string) error {
+	silSVC, orgSVC, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if b.id != "" {
+		id, err := influxdb.IDFromString(b.id)
+		if err != nil {
+			return fmt.Errorf("failed to decode silence id %q: %v", b.id, err)
+		}
+		sil, err := silSVC.FindSilenceByID(ctx, *id)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve silence: %v", err)
+		}
+		return b.printSilences(silencePrintOpt{silence: sil})
+	}
+
+	orgID, err := b.org.getID(orgSVC)
+	if err != nil {
+		return err
+	}
+
+	filter := influxdb.SilenceFilter{OrgID: &orgID}
+	if b.active {
+		now := time.Now()
+		filter.Active = &now
+	}
+
+	silences, _, err := silSVC.FindSilences(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve silences: %v", err)
+	}
+
+	return b.printSilences(silencePrintOpt{silences: silences})
+}
+
+func (b *cmdSilenceBuilder) cmdUpdate() *cobra.Command {
+	cmd := b.newCmd("update", b.cmdUpdateRunEFn, true)
+	cmd.Short = "Update silence"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The silence ID (required)")
+	cmd.MarkFlagRequired("id")
+	b.registerSilenceFlags(cmd)
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdUpdateRunEFn(cmd *cobra.Command, args []string) error {
+	silSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode silence id %q: %v", b.id, err)
+	}
+
+	var upd influxdb.SilenceUpdate
+	if len(b.matchers) > 0 {
+		if upd.Matchers, err = parseSilenceMatchers(b.matchers); err != nil {
+			return err
+		}
+	}
+	if len(b.checkIDs) > 0 {
+		if upd.CheckIDs, err = parseSilenceCheckIDs(b.checkIDs); err != nil {
+			return err
+		}
+	}
+	if b.start != "" {
+		start, err := time.Parse(time.RFC3339, b.start)
+		if err != nil {
+			return fmt.Errorf("invalid start time %q: %v", b.start, err)
+		}
+		upd.StartsAt = &start
+	}
+	if b.end != "" {
+		end, err := time.Parse(time.RFC3339, b.end)
+		if err != nil {
+			return fmt.Errorf("invalid end time %q: %v", b.end, err)
+		}
+		upd.EndsAt = &end
+	}
+	if cmd.Flags().Changed("comment") {
+		upd.Comment = &b.comment
+	}
+
+	sil, err := silSVC.UpdateSilence(context.Background(), id, upd)
+	if err != nil {
+		return fmt.Errorf("failed to update silence: %v", err)
+	}
+
+	return b.printSilences(silencePrintOpt{silence: sil})
+}
+
+func (b *cmdSilenceBuilder) cmdDelete() *cobra.Command {
+	cmd := b.newCmd("delete", b.cmdDeleteRunEFn, true)
+	cmd.Short = "Delete silence"
+
+	cmd.Flags().StringVarP(&b.id, "id", "i", "", "The silence ID (required)")
+	cmd.MarkFlagRequired("id")
+	b.registerPrintFlags(cmd)
+
+	return cmd
+}
+
+func (b *cmdSilenceBuilder) cmdDeleteRunEFn(cmd *cobra.Command, args []string) error {
+	silSVC, _, err := b.svcFn()
+	if err != nil {
+		return err
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(b.id); err != nil {
+		return fmt.Errorf("failed to decode silence id %q: %v", b.id, err)
+	}
+
+	ctx := context.Background()
+	sil, err := silSVC.FindSilenceByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to find silence with id %q: %v", id, err)
+	}
+	if err := silSVC.DeleteSilence(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete silence with id %q: %v", id, err)
+	}
+
+	return b.printSilences(silencePrintOpt{
+		deleted: true,
+		silence: sil,
+	})
+}
+
+func (b *cmdSilenceBuilder) registerSilenceFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&b.matchers, "matcher", "m", nil, "Tag the silenced statuses must have; format should be --matcher=key:value --matcher=key2:value2")
+	cmd.Flags().StringArrayVar(&b.checkIDs, "check-id", nil, "ID of a check whose statuses are silenced; may be repeated")
+	cmd.Flags().StringVar(&b.start, "start", "", "Start of the silence in RFC3339 format; defaults to now")
+	cmd.Flags().StringVar(&b.end, "end", "", "End of the silence in RFC3339 format")
+	cmd.Flags().StringVarP(&b.comment, "comment", "c", "", "Why the notifications are silenced")
+}
+
+func (b *cmdSilenceBuilder) registerPrintFlags(cmd *cobra.Command) {
+	registerPrintOptions(cmd, &b.hideHeaders, &b.json)
+}
+
+func (b *cmdSilenceBuilder) printSilences(opt silencePrintOpt) error {
+	if b.json {
+		var v interface{} = opt.silences
+		if opt.silences == nil {
+			v = opt.silence
+		}
+		return b.writeJSON(v)
+	}
+
+	w := b.newTabWriter()
+	defer w.Flush()
+
+	w.HideHeaders(b.hideHeaders)
+
+	headers := []string{"ID", "Organization ID", "Starts At", "Ends At", "Matchers", "Check IDs", "Comment"}
+	if opt.deleted {
+		headers = append(headers, "Deleted")
+	}
+	w.WriteHeaders(headers...)
+
+	if opt.silence != nil {
+		opt.silences = append(opt.silences, opt.silence)
+	}
+
+	for _, s := range opt.silences {
+		matchers := make([]string, 0, len(s.Matchers))
+		for _, m := range s.Matchers {
+			matchers = append(matchers, m.Key+":"+m.Value)
+		}
+		checkIDs := make([]string, 0, len(s.CheckIDs))
+		for _, id := range s.CheckIDs {
+			checkIDs = append(checkIDs, id.String())
+		}
+
+		m := map[string]interface{}{
+			"ID":              s.ID.String(),
+			"Organization ID": s.OrgID.String(),
+			"Starts At":       s.StartsAt.Format(time.RFC3339),
+			"Ends At":         s.EndsAt.Format(time.RFC3339),
+			"Matchers":        strings.Join(matchers, ","),
+			"Check IDs":       strings.Join(checkIDs, ","),
+			"Comment":         s.Comment,
+		}
+		if opt.deleted {
+			m["Deleted"] = true
+		}
+		w.Write(m)
+	}
+
+	return nil
+}
+
+type silencePrintOpt struct {
+	deleted  bool
+	silence  *influxdb.Silence
+	silences []*influxdb.Silence
+}
+
+func parseSilenceMatchers(ss []string) ([]influxdb.Tag, error) {
+	var matchers []influxdb.Tag
+	for _, s := range ss {
+		tag, err := influxdb.NewTag(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid matcher %q: %v", s, err)
+		}
+		matchers = append(matchers, tag)
+	}
+	return matchers, nil
+}
+
+func parseSilenceCheckIDs(ss []string) ([]influxdb.ID, error) {
+	var ids []influxdb.ID
+	for _, s := range ss {
+		id, err := influxdb.IDFromString(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid check id %q: %v", s, err)
+		}
+		ids = append(ids, *id)
+	}
+	return ids, nil
+}
+
+func newSilenceSVCs() (influxdb.SilenceService, influxdb.OrganizationService, error) {
+	httpClient, err := newHTTPClient()
+	if err != nil {
+		return nil, nil, err
+	}
+	orgSvc := &http.OrganizationService{Client: httpClient}
+
+	return &http.SilenceService{Client: httpClient}, orgSvc, nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCmdSilence(t *testing.T) {
+	orgID := influxdb.ID(9000)
+
+	fakeSVCFn := func(svc influxdb.SilenceService) silenceSVCsFn {
+		return func() (influxdb.SilenceService, influxdb.OrganizationService, error) {
+			return svc, &mock.OrganizationService{
+				FindOrganizationF: func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+					return &influxdb.Organization{ID: orgID, Name: "influxdata"}, nil
+				},
+			}, nil
+		}
+	}
+
+	start := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("create", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			flags    []string
+			expected influxdb.Silence
+		}{
+			{
+				name: "matchers and end",
+				flags: []string{
+					"--org=influxdata",
+					"--matcher=host:db01",
+					"--matcher=region:east",
+					"--start=2020-06-01T10:00:00Z",
+					"--end=2020-06-01T12:00:00Z",
+					"--comment=db maintenance",
+				},
+				expected: influxdb.Silence{
+					OrgID: orgID,
+					Matchers: []influxdb.Tag{
+						{Key: "host", Value: "db01"},
+						{Key: "region", Value: "east"},
+					},
+					StartsAt: start,
+					EndsAt:   start.Add(2 * time.Hour),
+					Comment:  "db maintenance",
+				},
+			},
+			{
+				name: "check ids and duration",
+				flags: []string{
+					"--org-id=" + orgID.String(),
+					"--check-id=" + influxdb.ID(3).String(),
+					"--start=2020-06-01T10:00:00Z",
+					"-d=30m",
+				},
+				expected: influxdb.Silence{
+					OrgID:    orgID,
+					CheckIDs: []influxdb.ID{3},
+					StartsAt: start,
+					EndsAt:   start.Add(30 * time.Minute),
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			fn := func(t *testing.T) {
+				svc := mock.NewSilenceService()
+				var got influxdb.Silence
+				svc.CreateSilenceFn = func(ctx context.Context, s *influxdb.Silence) error {
+					got = *s
+					return nil
+				}
+
+				builder := newInfluxCmdBuilder(
+					in(new(bytes.Buffer)),
+					out(ioutil.Discard),
+				)
+				cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+					return newCmdSilenceBuilder(fakeSVCFn(svc), opt).cmd()
+				})
+				cmd.SetArgs(append([]string{"silence", "create"}, tt.flags...))
+
+				require.NoError(t, cmd.Execute())
+				assert.Equal(t, tt.expected, got)
+			}
+
+			t.Run(tt.name, fn)
+		}
+	})
+
+	t.Run("create requires an end", func(t *testing.T) {
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdSilenceBuilder(fakeSVCFn(mock.NewSilenceService()), opt).cmd()
+		})
+		cmd.SetArgs([]string{"silence", "create", "--org=influxdata", "--matcher=host:db01"})
+
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("update", func(t *testing.T) {
+		svc := mock.NewSilenceService()
+		var got influxdb.SilenceUpdate
+		svc.UpdateSilenceFn = func(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+			if id != 1 {
+				t.Errorf("unexpected id: %s", id)
+			}
+			got = upd
+			return &influxdb.Silence{ID: id, OrgID: orgID}, nil
+		}
+
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdSilenceBuilder(fakeSVCFn(svc), opt).cmd()
+		})
+		cmd.SetArgs([]string{"silence", "update", "--id=" + influxdb.ID(1).String(), "--end=2020-06-01T12:00:00Z", "--comment="})
+
+		require.NoError(t, cmd.Execute())
+		end := start.Add(2 * time.Hour)
+		comment := ""
+		assert.Equal(t, influxdb.SilenceUpdate{EndsAt: &end, Comment: &comment}, got)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		svc := mock.NewSilenceService()
+		svc.FindSilenceByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+			return &influxdb.Silence{ID: id, OrgID: orgID}, nil
+		}
+		var deleted influxdb.ID
+		svc.DeleteSilenceFn = func(ctx context.Context, id influxdb.ID) error {
+			deleted = id
+			return nil
+		}
+
+		builder := newInfluxCmdBuilder(
+			in(new(bytes.Buffer)),
+			out(ioutil.Discard),
+		)
+		cmd := builder.cmd(func(g *globalFlags, opt genericCLIOpts) *cobra.Command {
+			return newCmdSilenceBuilder(fakeSVCFn(svc), opt).cmd()
+		})
+		cmd.SetArgs([]string{"silence", "delete", "--id=" + influxdb.ID(1).String()})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, influxdb.ID(1), deleted)
+	})
+}
@@ -29,6 +29,7 @@ func cmdTask(f *globalFlags, opt genericCLIOpts) *cobra.Command {
 	cmd.AddCommand(
 		taskLogCmd(opt),
 		taskRunCmd(opt),
+		taskBackfillCmd(opt),
 		taskCreateCmd(opt),
 		taskDeleteCmd(opt),
 		taskFindCmd(opt),
@@ -441,6 +442,12 @@ func taskLogFindF(cmd *cobra.Command, args []string) error {
 	tabW.HideHeaders(taskPrintFlags.hideHeaders)
 
 	tabW.WriteHeaders("RunID", "Time", "Message")
+	writeLogs(tabW, logs)
+
+	return nil
+}
+
+func writeLogs(tabW *internal.TabWriter, logs []*influxdb.Log) {
 	for _, log := range logs {
 		tabW.Write(map[string]interface{}{
 			"RunID":   log.RunID,
@@ -448,8 +455,6 @@ func taskLogFindF(cmd *cobra.Command, args []string) error {
 			"Message": log.Message,
 		})
 	}
-
-	return nil
 }
 
 func taskRunCmd(opt genericCLIOpts) *cobra.Command {
@@ -459,6 +464,7 @@ func taskRunCmd(opt genericCLIOpts) *cobra.Command {
 	cmd.AddCommand(
 		taskRunFindCmd(opt),
 		taskRunRetryCmd(opt),
+		taskRunLogsCmd(opt),
 	)
 
 	return cmd
@@ -528,7 +534,10 @@ func taskRunFindF(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	w := cmd.OutOrStdout()
+	return printRuns(cmd.OutOrStdout(), runs)
+}
+
+func printRuns(w io.Writer, runs []*influxdb.Run) error {
 	if taskPrintFlags.json {
 		if runs == nil {
 			// guarantee we never return a null value from CLI
@@ -616,3 +625,191 @@ func runRetryF(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// runLogsPollInterval is how often logs --follow checks for new logs of the run.
+const runLogsPollInterval = time.Second
+
+var taskRunLogsFlags struct {
+	taskID string
+	runID  string
+	follow bool
+}
+
+func taskRunLogsCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("logs", taskRunLogsF, true)
+	cmd.Short = "List logs for a run"
+	cmd.Long = `List the logs of a run. With --follow the logs are printed as they are added
+until the run finishes.`
+
+	registerPrintOptions(cmd, &taskPrintFlags.hideHeaders, &taskPrintFlags.json)
+	cmd.Flags().StringVarP(&taskRunLogsFlags.taskID, "task-id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskRunLogsFlags.runID, "run-id", "r", "", "run id (required)")
+	cmd.Flags().BoolVarP(&taskRunLogsFlags.follow, "follow", "f", false, "print new logs until the run finishes")
+	cmd.MarkFlagRequired("task-id")
+	cmd.MarkFlagRequired("run-id")
+
+	return cmd
+}
+
+func taskRunLogsF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client: client,
+	}
+
+	var taskID, runID influxdb.ID
+	if err := taskID.DecodeFromString(taskRunLogsFlags.taskID); err != nil {
+		return err
+	}
+	if err := runID.DecodeFromString(taskRunLogsFlags.runID); err != nil {
+		return err
+	}
+	filter := influxdb.LogFilter{Task: taskID, Run: &runID}
+
+	ctx := context.Background()
+	w := cmd.OutOrStdout()
+	if !taskRunLogsFlags.follow {
+		logs, _, err := s.FindLogs(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if taskPrintFlags.json {
+			return writeJSON(w, logs)
+		}
+
+		tabW := internal.NewTabWriter(w)
+		defer tabW.Flush()
+
+		tabW.HideHeaders(taskPrintFlags.hideHeaders)
+		tabW.WriteHeaders("RunID", "Time", "Message")
+		writeLogs(tabW, logs)
+		return nil
+	}
+
+	return followRunLogs(ctx, w, s, filter, runLogsPollInterval)
+}
+
+// followRunLogs prints the logs of the run of filter as they are added, until
+// the run has finished and its last logs are printed. As JSON, every log is
+// written as its own object.
+func followRunLogs(ctx context.Context, w io.Writer, s *http.TaskService, filter influxdb.LogFilter, interval time.Duration) error {
+	tabW := internal.NewTabWriter(w)
+	defer tabW.Flush()
+
+	tabW.HideHeaders(taskPrintFlags.hideHeaders)
+	if !taskPrintFlags.json {
+		tabW.WriteHeaders("RunID", "Time", "Message")
+	}
+
+	var printed int
+	for {
+		// look the run up before its logs, so the logs it added before it
+		// finished are printed before we stop
+		run, err := s.FindRunByID(ctx, filter.Task, *filter.Run)
+		if err != nil {
+			return err
+		}
+		logs, _, err := s.FindLogs(ctx, filter)
+		if err != nil {
+			return err
+		}
+
+		if len(logs) > printed {
+			if taskPrintFlags.json {
+				for _, log := range logs[printed:] {
+					if err := writeJSON(w, log); err != nil {
+						return err
+					}
+				}
+			} else {
+				writeLogs(tabW, logs[printed:])
+				tabW.Flush()
+			}
+			printed = len(logs)
+		}
+
+		switch run.Status {
+		case influxdb.RunScheduled.String(), influxdb.RunStarted.String():
+		default:
+			return nil
+		}
+
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+var taskBackfillFlags struct {
+	id     string
+	start  string
+	stop   string
+	cancel bool
+}
+
+func taskBackfillCmd(opt genericCLIOpts) *cobra.Command {
+	cmd := opt.newCmd("backfill", taskBackfillF, true)
+	cmd.Short = "Backfill a task"
+	cmd.Long = `Queue a run of the task for every time it is scheduled for from start up to stop.
+The runs are executed in the background up to the concurrency of the task, list them
+with "influx task run list" to follow the backfill, or cancel it with --cancel.`
+
+	registerPrintOptions(cmd, &taskPrintFlags.hideHeaders, &taskPrintFlags.json)
+	cmd.Flags().StringVarP(&taskBackfillFlags.id, "id", "i", "", "task id (required)")
+	cmd.Flags().StringVarP(&taskBackfillFlags.start, "start", "", "", "start of the time range, as RFC3339")
+	cmd.Flags().StringVarP(&taskBackfillFlags.stop, "stop", "", "", "stop of the time range, as RFC3339")
+	cmd.Flags().BoolVarP(&taskBackfillFlags.cancel, "cancel", "", false, "cancel the backfill of the task")
+	cmd.MarkFlagRequired("id")
+
+	return cmd
+}
+
+func taskBackfillF(cmd *cobra.Command, args []string) error {
+	client, err := newHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	s := &http.TaskService{
+		Client: client,
+	}
+
+	var id influxdb.ID
+	if err := id.DecodeFromString(taskBackfillFlags.id); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if taskBackfillFlags.cancel {
+		if err := s.CancelBackfill(ctx, id); err != nil {
+			return err
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Backfill of task %s canceled.\n", id)
+		return nil
+	}
+
+	if taskBackfillFlags.start == "" || taskBackfillFlags.stop == "" {
+		return fmt.Errorf("must provide --start and --stop")
+	}
+	start, err := time.Parse(time.RFC3339, taskBackfillFlags.start)
+	if err != nil {
+		return fmt.Errorf("invalid start: %v", err)
+	}
+	stop, err := time.Parse(time.RFC3339, taskBackfillFlags.stop)
+	if err != nil {
+		return fmt.Errorf("invalid stop: %v", err)
+	}
+
+	runs, err := s.BackfillTask(ctx, id, start, stop)
+	if err != nil {
+		return err
+	}
+
+	return printRuns(cmd.OutOrStdout(), runs)
+}
@@ -30,9 +30,15 @@ type Engine interface {
 	storage.BucketDeleter
 	prom.PrometheusCollector
 	influxdb.BackupService
+	influxdb.RestoreService
 
 	SeriesCardinality() int64
 
+	MeasurementNames(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64) (cursors.StringIterator, error)
+	MeasurementTagKeys(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	MeasurementTagValues(ctx context.Context, orgID, bucketID influxdb.ID, measurement, tagKey string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) (cursors.MeasurementFieldsIterator, error)
+
 	WithLogger(log *zap.Logger)
 	Open(context.Context) error
 	Close() error
@@ -156,6 +162,26 @@ func (t *TemporaryEngine) TagValues(ctx context.Context, orgID, bucketID influxd
 	return t.engine.TagValues(ctx, orgID, bucketID, tagKey, start, end, predicate)
 }
 
+// MeasurementNames calls into the underlying engines MeasurementNames.
+func (t *TemporaryEngine) MeasurementNames(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64) (cursors.StringIterator, error) {
+	return t.engine.MeasurementNames(ctx, orgID, bucketID, start, end)
+}
+
+// MeasurementTagKeys calls into the underlying engines MeasurementTagKeys.
+func (t *TemporaryEngine) MeasurementTagKeys(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error) {
+	return t.engine.MeasurementTagKeys(ctx, orgID, bucketID, measurement, start, end, predicate)
+}
+
+// MeasurementTagValues calls into the underlying engines MeasurementTagValues.
+func (t *TemporaryEngine) MeasurementTagValues(ctx context.Context, orgID, bucketID influxdb.ID, measurement, tagKey string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error) {
+	return t.engine.MeasurementTagValues(ctx, orgID, bucketID, measurement, tagKey, start, end, predicate)
+}
+
+// MeasurementFields calls into the underlying engines MeasurementFields.
+func (t *TemporaryEngine) MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) (cursors.MeasurementFieldsIterator, error) {
+	return t.engine.MeasurementFields(ctx, orgID, bucketID, measurement, start, end, predicate)
+}
+
 // Flush will remove the time-series files and re-open the engine.
 func (t *TemporaryEngine) Flush(ctx context.Context) {
 	if err := t.Close(); err != nil {
@@ -167,8 +193,8 @@ func (t *TemporaryEngine) Flush(ctx context.Context) {
 	}
 }
 
-func (t *TemporaryEngine) CreateBackup(ctx context.Context) (int, []string, error) {
-	return t.engine.CreateBackup(ctx)
+func (t *TemporaryEngine) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (*influxdb.BackupManifest, error) {
+	return t.engine.CreateBackup(ctx, filter)
 }
 
 func (t *TemporaryEngine) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
@@ -178,3 +204,7 @@ func (t *TemporaryEngine) FetchBackupFile(ctx context.Context, backupID int, bac
 func (t *TemporaryEngine) InternalBackupPath(backupID int) string {
 	return t.engine.InternalBackupPath(backupID)
 }
+
+func (t *TemporaryEngine) RestoreBucketFile(ctx context.Context, source, target influxdb.BackupBucket, path string) error {
+	return t.engine.RestoreBucketFile(ctx, source, target, path)
+}
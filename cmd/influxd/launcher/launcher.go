@@ -23,6 +23,7 @@ import (
 	"github.com/influxdata/influxdb/v2/chronograf/server"
 	"github.com/influxdata/influxdb/v2/cmd/influxd/inspect"
 	"github.com/influxdata/influxdb/v2/dbrp"
+	"github.com/influxdata/influxdb/v2/downsample"
 	"github.com/influxdata/influxdb/v2/endpoints"
 	"github.com/influxdata/influxdb/v2/gather"
 	"github.com/influxdata/influxdb/v2/http"
@@ -40,11 +41,15 @@ import (
 	"github.com/influxdata/influxdb/v2/label"
 	influxlogger "github.com/influxdata/influxdb/v2/logger"
 	"github.com/influxdata/influxdb/v2/nats"
+	"github.com/influxdata/influxdb/v2/notification/alert"
+	"github.com/influxdata/influxdb/v2/notification/smtp"
 	"github.com/influxdata/influxdb/v2/pkger"
 	infprom "github.com/influxdata/influxdb/v2/prometheus"
 	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/cache"
 	"github.com/influxdata/influxdb/v2/query/control"
 	"github.com/influxdata/influxdb/v2/query/fluxlang"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
 	"github.com/influxdata/influxdb/v2/query/stdlib/influxdata/influxdb"
 	"github.com/influxdata/influxdb/v2/session"
 	"github.com/influxdata/influxdb/v2/snowflake"
@@ -207,6 +212,152 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: false,
 			Desc:    "disables automatically extending session ttl on request",
 		},
+		{
+			DestP: &l.oauthConfig.ClientID,
+			Flag:  "oauth-client-id",
+			Desc:  "client id of influxd at the OAuth2 or OpenID Connect identity provider, enables single sign-on at /api/v2/oauth/login",
+		},
+		{
+			DestP: &l.oauthConfig.ClientSecret,
+			Flag:  "oauth-client-secret",
+			Desc:  "client secret of influxd at the identity provider, also verifies HS256 signed id tokens",
+		},
+		{
+			DestP: &l.oauthConfig.AuthURL,
+			Flag:  "oauth-auth-url",
+			Desc:  "authorization endpoint of the identity provider",
+		},
+		{
+			DestP: &l.oauthConfig.TokenURL,
+			Flag:  "oauth-token-url",
+			Desc:  "token endpoint of the identity provider",
+		},
+		{
+			DestP: &l.oauthConfig.UserInfoURL,
+			Flag:  "oauth-userinfo-url",
+			Desc:  "userinfo endpoint of the identity provider, required if the provider does not return id tokens",
+		},
+		{
+			DestP: &l.oauthConfig.JWKSURL,
+			Flag:  "oauth-jwks-url",
+			Desc:  "key set of the identity provider that RS256 signed id tokens are verified with",
+		},
+		{
+			DestP: &l.oauthConfig.Issuer,
+			Flag:  "oauth-issuer",
+			Desc:  "issuer that id tokens must be issued by, not checked if unset",
+		},
+		{
+			DestP: &l.oauthConfig.RedirectURL,
+			Flag:  "oauth-redirect-url",
+			Desc:  "URL of /api/v2/oauth/callback as registered at the identity provider",
+		},
+		{
+			DestP:   &l.oauthConfig.Scopes,
+			Flag:    "oauth-scopes",
+			Default: session.DefaultOAuthScopes,
+			Desc:    "scopes requested from the identity provider",
+		},
+		{
+			DestP:   &l.oauthConfig.UsernameClaim,
+			Flag:    "oauth-username-claim",
+			Default: "email",
+			Desc:    "claim whose value is the name of the user",
+		},
+		{
+			DestP:   &l.oauthConfig.GroupsClaim,
+			Flag:    "oauth-groups-claim",
+			Default: "groups",
+			Desc:    "claim that lists the groups of the user",
+		},
+		{
+			DestP: &l.oauthGroupMappings,
+			Flag:  "oauth-group-mappings",
+			Desc:  "grant the members of identity provider groups a role in organizations, as group=org[:member|owner]",
+		},
+		{
+			DestP:   &l.oauthConfig.AutoProvision,
+			Flag:    "oauth-auto-provision",
+			Default: true,
+			Desc:    "create users that log in with the identity provider for the first time",
+		},
+		{
+			DestP:   &l.oauthConfig.LinkExistingUsers,
+			Flag:    "oauth-link-existing-users",
+			Default: false,
+			Desc:    "let logins with the identity provider use existing users of the same name that were not created by such a login",
+		},
+		{
+			DestP: &l.oauthConfig.StateSecret,
+			Flag:  "oauth-state-secret",
+			Desc:  "secret that signs the state of logins, must be the same on all instances behind a load balancer; random if unset",
+		},
+		{
+			DestP: &l.ldapConfig.URL,
+			Flag:  "ldap-url",
+			Desc:  "ldap:// or ldaps:// URL of an LDAP directory to authenticate users against, users not in the directory use local passwords",
+		},
+		{
+			DestP:   &l.ldapConfig.StartTLS,
+			Flag:    "ldap-start-tls",
+			Default: false,
+			Desc:    "upgrade ldap:// connections to the directory to TLS",
+		},
+		{
+			DestP:   &l.ldapConfig.InsecureSkipVerify,
+			Flag:    "ldap-insecure-skip-verify",
+			Default: false,
+			Desc:    "do not verify the certificate of the directory",
+		},
+		{
+			DestP:   &l.ldapConfig.Timeout,
+			Flag:    "ldap-timeout",
+			Default: 10 * time.Second,
+			Desc:    "timeout of connections and requests to the directory",
+		},
+		{
+			DestP: &l.ldapConfig.BindDN,
+			Flag:  "ldap-bind-dn",
+			Desc:  "DN of the account that searches users and groups, the search is anonymous if unset",
+		},
+		{
+			DestP: &l.ldapConfig.BindPassword,
+			Flag:  "ldap-bind-password",
+			Desc:  "password of the account that searches users and groups",
+		},
+		{
+			DestP: &l.ldapConfig.UserBaseDN,
+			Flag:  "ldap-user-base-dn",
+			Desc:  "DN users are searched under",
+		},
+		{
+			DestP:   &l.ldapConfig.UserFilter,
+			Flag:    "ldap-user-filter",
+			Default: "(uid=%s)",
+			Desc:    "filter that finds a user, %s is replaced with the username",
+		},
+		{
+			DestP: &l.ldapConfig.GroupBaseDN,
+			Flag:  "ldap-group-base-dn",
+			Desc:  "DN groups are searched under, groups are not searched if unset",
+		},
+		{
+			DestP:   &l.ldapConfig.GroupFilter,
+			Flag:    "ldap-group-filter",
+			Default: "(member=%s)",
+			Desc:    "filter that finds the groups of a user, %s is replaced with the DN of the user",
+		},
+		{
+			DestP:   &l.ldapConfig.GroupAttribute,
+			Flag:    "ldap-group-attribute",
+			Default: "cn",
+			Desc:    "attribute with the name of a group",
+		},
+		{
+			DestP: &l.ldapGroupMappings,
+			Flag:  "ldap-group-mappings",
+			Desc:  "grant the members of LDAP groups a role in organizations, as group=org[:member|owner]",
+		},
 		{
 			DestP: &vaultConfig.Address,
 			Flag:  "vault-addr",
@@ -275,6 +426,12 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: false,
 			Desc:    "disables the task scheduler",
 		},
+		{
+			DestP:   &l.taskMaxBackfillRuns,
+			Flag:    "task-max-backfill-runs",
+			Default: platform.TaskDefaultMaxBackfillRuns,
+			Desc:    "the maximum number of runs a single task backfill may enqueue",
+		},
 		{
 			DestP:   &l.concurrencyQuota,
 			Flag:    "query-concurrency",
@@ -305,6 +462,24 @@ func buildLauncherCommand(l *Launcher, cmd *cobra.Command) {
 			Default: 10,
 			Desc:    "the number of queries that are allowed to be awaiting execution before new queries are rejected",
 		},
+		{
+			DestP:   &l.queryCacheMaxBytes,
+			Flag:    "query-cache-max-bytes",
+			Default: 0,
+			Desc:    "the maximum number of bytes of query results held in the query result cache. If this is unset, then query results are not cached",
+		},
+		{
+			DestP:   &l.queryCacheMaxEntryBytes,
+			Flag:    "query-cache-max-entry-bytes",
+			Default: 0,
+			Desc:    "the maximum number of bytes of a query result that is cached. If this is unset, then query-cache-max-bytes will be used",
+		},
+		{
+			DestP:   &l.queryCacheNowPrecision,
+			Flag:    "query-cache-now-precision",
+			Default: cache.DefaultNowPrecision,
+			Desc:    "the precision now() is truncated to for cached queries, queries relative to now share cached results for this long",
+		},
 		{
 			DestP: &l.featureFlags,
 			Flag:  "feature-flags",
@@ -327,6 +502,11 @@ type Launcher struct {
 	sessionLength        int // in minutes
 	sessionRenewDisabled bool
 
+	oauthConfig        session.OAuthConfig
+	oauthGroupMappings []string
+	ldapConfig         session.LDAPConfig
+	ldapGroupMappings  []string
+
 	logLevel          string
 	tracingType       string
 	reportingDisabled bool
@@ -344,6 +524,9 @@ type Launcher struct {
 	memoryBytesQuotaPerQuery        int
 	maxMemoryBytes                  int
 	queueSize                       int
+	queryCacheMaxBytes              int
+	queryCacheMaxEntryBytes         int
+	queryCacheNowPrecision          time.Duration
 
 	boltClient    *bolt.Client
 	kvStore       kv.Store
@@ -361,10 +544,11 @@ type Launcher struct {
 	natsServer *nats.Server
 	natsPort   int
 
-	noTasks            bool
-	scheduler          stoppingScheduler
-	executor           *executor.Executor
-	taskControlService taskbackend.TaskControlService
+	noTasks             bool
+	taskMaxBackfillRuns int
+	scheduler           stoppingScheduler
+	executor            *executor.Executor
+	taskControlService  taskbackend.TaskControlService
 
 	jaegerTracerCloser io.Closer
 	log                *zap.Logger
@@ -545,6 +729,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	serviceConfig := kv.ServiceConfig{
 		SessionLength:       time.Duration(m.sessionLength) * time.Minute,
 		FluxLanguageService: fluxlang.DefaultService,
+		TaskMaxBackfillRuns: m.taskMaxBackfillRuns,
 	}
 
 	flushers := flushers{}
@@ -594,6 +779,9 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		scraperTargetSvc          platform.ScraperTargetStoreService       = m.kvService
 		telegrafSvc               platform.TelegrafConfigStore             = m.kvService
 		secretSvc                 platform.SecretService                   = m.kvService
+		silenceSvc                platform.SilenceService                  = m.kvService
+		roleSvc                   platform.RoleService                     = m.kvService
+		alertAckSvc               platform.AlertAcknowledgementService     = m.kvService
 		lookupSvc                 platform.LookupService                   = m.kvService
 		notificationEndpointStore platform.NotificationEndpointService     = m.kvService
 	)
@@ -613,6 +801,15 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		passwdsSvc      platform.PasswordsService           = tenant.NewPasswordLogger(m.log.With(zap.String("store", "new")), tenant.NewPasswordMetrics(m.reg, ts, metric.WithSuffix("new")))
 	)
 
+	if m.ldapConfig.Enabled() {
+		ldapConfig := m.ldapConfig
+		if ldapConfig.GroupMappings, err = session.ParseGroupMappings(m.ldapGroupMappings); err != nil {
+			m.log.Error("Failed to parse LDAP group mappings", zap.Error(err))
+			return err
+		}
+		passwdsSvc = session.NewLDAPPasswordsService(m.log.With(zap.String("service", "ldap")), ldapConfig, passwdsSvc, userSvc, orgSvc, userResourceSvc)
+	}
+
 	switch m.secretStore {
 	case "bolt":
 		// If it is bolt, then we already set it above.
@@ -654,14 +851,30 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	m.reg.MustRegister(m.engine.PrometheusCollectors()...)
 
 	var (
-		deleteService platform.DeleteService = m.engine
-		pointsWriter  storage.PointsWriter   = m.engine
-		backupService platform.BackupService = m.engine
+		deleteService  platform.DeleteService  = m.engine
+		pointsWriter   storage.PointsWriter    = m.engine
+		backupService  platform.BackupService  = m.engine
+		restoreService platform.RestoreService = m.engine
 	)
 
+	var queryCache *cache.Cache
+	if m.queryCacheMaxBytes > 0 {
+		queryCache = cache.New(cache.Config{
+			MaxBytes:      int64(m.queryCacheMaxBytes),
+			MaxEntryBytes: int64(m.queryCacheMaxEntryBytes),
+			NowPrecision:  m.queryCacheNowPrecision,
+		})
+		m.reg.MustRegister(queryCache.PrometheusCollectors()...)
+
+		// Writes, deletes and restores invalidate the cached results of the buckets they change.
+		pointsWriter = cache.NewPointsWriter(queryCache, pointsWriter)
+		deleteService = cache.NewDeleteService(queryCache, deleteService)
+		restoreService = cache.NewRestoreService(queryCache, restoreService)
+	}
+
 	deps, err := influxdb.NewDependencies(
 		storageflux.NewReader(readservice.NewStore(m.engine)),
-		m.engine,
+		pointsWriter,
 		authorizer.NewBucketService(bucketSvc, userResourceSvc),
 		authorizer.NewOrgService(orgSvc),
 		authorizer.NewSecretService(secretSvc),
@@ -671,6 +884,22 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		m.log.Error("Failed to get query controller dependencies", zap.Error(err))
 		return err
 	}
+	// The notification rules of smtp endpoints post their mails to influxd
+	// through the HTTP client of flux.
+	if fdeps, ok := deps.FluxDeps.(flux.Deps); ok {
+		fdeps.Deps.HTTPClient = smtp.NewClient(m.log.With(zap.String("service", "smtp")), fdeps.Deps.HTTPClient, notificationEndpointStore, secretSvc)
+		deps.FluxDeps = fdeps
+	}
+
+	nativeDeps := native.Dependencies{
+		Store:         readservice.NewStore(m.engine),
+		Schema:        m.engine,
+		BucketService: authorizer.NewBucketService(bucketSvc, userResourceSvc),
+	}
+	if err := nativeDeps.Validate(); err != nil {
+		m.log.Error("Failed to get native InfluxQL dependencies", zap.Error(err))
+		return err
+	}
 
 	m.queryController, err = control.New(control.Config{
 		ConcurrencyQuota:                m.concurrencyQuota,
@@ -679,7 +908,8 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		MaxMemoryBytes:                  int64(m.maxMemoryBytes),
 		QueueSize:                       m.queueSize,
 		Logger:                          m.log.With(zap.String("service", "storage-reads")),
-		ExecutorDependencies:            []flux.Dependency{deps},
+		ExecutorDependencies:            []flux.Dependency{deps, nativeDeps},
+		OrganizationSettings:            m.kvService,
 	})
 	if err != nil {
 		m.log.Error("Failed to create query controller", zap.Error(err))
@@ -689,6 +919,10 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 	m.reg.MustRegister(m.queryController.PrometheusCollectors()...)
 
 	var storageQueryService = readservice.NewProxyQueryService(m.queryController)
+	var fluxQueryService query.ProxyQueryService = storageQueryService
+	if queryCache != nil {
+		fluxQueryService = cache.NewProxyQueryService(queryCache, storageQueryService, fluxlang.DefaultService, bucketSvc)
+	}
 	var taskSvc platform.TaskService
 	{
 		// create the task stack
@@ -700,9 +934,17 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 			authSvc,
 			combinedTaskService,
 			combinedTaskService,
+			executor.WithFluxLanguageService(fluxlang.DefaultService),
 		)
 		m.executor = executor
 		m.reg.MustRegister(executorMetrics.PrometheusCollectors()...)
+		// hold the runs of tasks with dependencies outside of the executor's workers
+		gate := coordinator.NewDependencyGate(
+			m.log.With(zap.String("service", "task-dependencies")),
+			executor,
+			combinedTaskService,
+			combinedTaskService,
+		)
 		schLogger := m.log.With(zap.String("service", "task-scheduler"))
 
 		var sch stoppingScheduler = &scheduler.NoopScheduler{}
@@ -712,7 +954,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 				err error
 			)
 			sch, sm, err = scheduler.NewScheduler(
-				executor,
+				gate,
 				taskbackend.NewSchedulableTaskService(m.kvService),
 				scheduler.WithOnErrorFn(func(ctx context.Context, taskID scheduler.ID, scheduledAt time.Time, err error) {
 					schLogger.Info(
@@ -734,7 +976,8 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		taskCoord := coordinator.NewCoordinator(
 			coordLogger,
 			sch,
-			executor)
+			gate,
+			coordinator.WithFluxLanguageService(fluxlang.DefaultService))
 
 		taskSvc = middleware.New(combinedTaskService, taskCoord)
 		m.taskControlService = combinedTaskService
@@ -744,20 +987,23 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 			combinedTaskService,
 			taskCoord,
 			func(ctx context.Context, taskID platform.ID, runID platform.ID) error {
-				_, err := executor.ResumeCurrentRun(ctx, taskID, runID)
+				_, err := gate.ResumeCurrentRun(ctx, taskID, runID)
 				return err
 			},
 			coordLogger); err != nil {
 			m.log.Error("Failed to resume existing tasks", zap.Error(err))
 		}
+		if err := taskbackend.NotifyBackfillerOfExisting(ctx, coordLogger, taskSvc, m.kvService, taskCoord); err != nil {
+			m.log.Error("Failed to resume task backfills", zap.Error(err))
+		}
 	}
 
-	dbrpSvc, err := dbrp.NewService(ctx, authorizer.NewBucketService(bucketSvc, userResourceSvc), m.kvStore)
+	legacyDBRPSvc, err := dbrp.NewService(ctx, authorizer.NewBucketService(bucketSvc, userResourceSvc), m.kvStore)
 	if err != nil {
 		return err
 	}
 
-	dbrpSvc = dbrp.NewAuthorizedService(dbrpSvc)
+	var dbrpSvc platform.DBRPMappingServiceV2 = dbrp.NewAuthorizedService(legacyDBRPSvc)
 
 	var checkSvc platform.CheckService
 	{
@@ -863,8 +1109,9 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 	var sessionSvc platform.SessionService
 	{
-		sessionSvc = session.NewService(session.NewStorage(inmem.NewSessionStore()), userSvc, userResourceSvc, authSvc, time.Duration(m.sessionLength)*time.Minute)
-		sessionSvc = session.NewSessionMetrics(m.reg, sessionSvc)
+		svc := session.NewService(session.NewStorage(inmem.NewSessionStore()), userSvc, userResourceSvc, authSvc, time.Duration(m.sessionLength)*time.Minute)
+		svc.WithRoleService(roleSvc)
+		sessionSvc = session.NewSessionMetrics(m.reg, svc)
 		sessionSvc = session.NewSessionLogger(m.log.With(zap.String("service", "session")), sessionSvc)
 		sessionSvc = session.NewServiceController(flagger, m.kvService, sessionSvc)
 	}
@@ -880,6 +1127,12 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		labelSvc = label.NewLabelController(flagger, m.kvService, ls)
 	}
 
+	var apiBucketSvc platform.BucketService = storage.NewBucketService(bucketSvc, m.engine)
+	if queryCache != nil {
+		apiBucketSvc = cache.NewBucketService(queryCache, apiBucketSvc)
+	}
+	apiBucketSvc = downsample.NewBucketService(apiBucketSvc, taskSvc)
+
 	m.apibackend = &http.APIBackend{
 		AssetsPath:           m.assetsPath,
 		HTTPErrorHandler:     kithttp.ErrorHandler(0),
@@ -888,16 +1141,21 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		NewBucketService:     source.NewBucketService,
 		NewQueryService:      source.NewQueryService,
 		PointsWriter:         pointsWriter,
+		ReadStore:            readservice.NewStore(m.engine),
 		DeleteService:        deleteService,
 		BackupService:        backupService,
 		KVBackupService:      m.kvService,
+		RestoreService:       restoreService,
 		AuthorizationService: authSvc,
 		AlgoWProxy:           &http.NoopProxyHandler{},
-		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine.
-		BucketService:                   storage.NewBucketService(bucketSvc, m.engine),
+		// Wrap the BucketService in a storage backed one that will ensure deleted buckets are removed from the storage engine,
+		// in one that invalidates the cached results of deleted buckets and of buckets whose retention changes,
+		// and in one that materializes the downsample rules of buckets as tasks.
+		BucketService:                   apiBucketSvc,
 		SessionService:                  sessionSvc,
 		UserService:                     userSvc,
 		DBRPService:                     dbrpSvc,
+		LegacyDBRPService:               legacyDBRPSvc,
 		OrganizationService:             orgSvc,
 		UserResourceMappingService:      userResourceSvc,
 		LabelService:                    labelSvc,
@@ -906,11 +1164,12 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		BucketOperationLogService:       bucketLogSvc,
 		UserOperationLogService:         userLogSvc,
 		OrganizationOperationLogService: orgLogSvc,
+		OrganizationSettingsService:     m.kvService,
 		SourceService:                   sourceSvc,
 		VariableService:                 variableSvc,
 		PasswordsService:                passwdsSvc,
 		InfluxQLService:                 storageQueryService,
-		FluxService:                     storageQueryService,
+		FluxService:                     fluxQueryService,
 		FluxLanguageService:             fluxlang.DefaultService,
 		TaskService:                     taskSvc,
 		TelegrafService:                 telegrafSvc,
@@ -920,6 +1179,11 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		ScraperTargetStoreService:       scraperTargetSvc,
 		ChronografService:               chronografSvc,
 		SecretService:                   secretSvc,
+		SilenceService:                  silenceSvc,
+		RoleService:                     roleSvc,
+		AlertService:                    alert.NewStatusService(m.log.With(zap.String("service", "alert")), bucketSvc, alertAckSvc, query.QueryServiceBridge{AsyncQueryService: m.queryController}),
+		AlertAcknowledgementService:     alertAckSvc,
+		ActiveQueryService:              m.queryController,
 		LookupService:                   lookupSvc,
 		DocumentService:                 m.kvService,
 		OrgLookupService:                m.kvService,
@@ -938,6 +1202,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		b := m.apibackend
 		authedOrgSVC := authorizer.NewOrgService(b.OrganizationService)
 		authedURMSVC := authorizer.NewURMService(b.OrgLookupService, b.UserResourceMappingService)
+		authedURMSVC.WithRoleService(b.RoleService)
 		pkgerLogger := m.log.With(zap.String("service", "pkger"))
 		pkgSVC = pkger.NewService(
 			pkger.WithLogger(pkgerLogger),
@@ -949,6 +1214,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 			pkger.WithNotificationEndpointSVC(authorizer.NewNotificationEndpointService(b.NotificationEndpointService, authedURMSVC, authedOrgSVC)),
 			pkger.WithNotificationRuleSVC(authorizer.NewNotificationRuleStore(b.NotificationRuleStore, authedURMSVC, authedOrgSVC)),
 			pkger.WithOrganizationService(authorizer.NewOrgService(b.OrganizationService)),
+			pkger.WithRoleSVC(authorizer.NewRoleService(b.RoleService)),
 			pkger.WithSecretSVC(authorizer.NewSecretService(b.SecretService)),
 			pkger.WithTaskSVC(authorizer.NewTaskService(pkgerLogger, b.TaskService)),
 			pkger.WithTelegrafSVC(authorizer.NewTelegrafConfigService(b.TelegrafService, b.UserResourceMappingService)),
@@ -1004,6 +1270,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 
 		oldBackend := http.NewAuthorizationBackend(authLogger, m.apibackend)
 		oldBackend.AuthorizationService = authorizer.NewAuthorizationService(authSvc)
+		oldBackend.RoleService = authorizer.NewRoleService(roleSvc)
 		oldHandler := http.NewAuthorizationHandler(authLogger, oldBackend)
 
 		authStore, err := authorization.NewStore(m.kvStore)
@@ -1017,6 +1284,7 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		authService = authorization.NewAuthLogger(authLogger, authService)
 
 		newHandler := authorization.NewHTTPAuthHandler(m.log, authService, ts, lookupSvc)
+		newHandler.WithRoleService(authorizer.NewRoleService(roleSvc))
 		authHTTPServer = kithttp.NewFeatureHandler(feature.NewAuthPackage(), flagger, oldHandler, newHandler, newHandler.Prefix())
 	}
 
@@ -1027,17 +1295,34 @@ func (m *Launcher) run(ctx context.Context) (err error) {
 		sessionHTTPServer = session.NewSessionHandler(m.log.With(zap.String("handler", "session")), sessionSvc, userSvc, passwdsSvc)
 	}
 
+	platformOpts := []http.APIHandlerOptFn{
+		http.WithResourceHandler(pkgHTTPServer),
+		http.WithResourceHandler(onboardHTTPServer),
+		http.WithResourceHandler(authHTTPServer),
+		http.WithResourceHandler(labelsHTTPServer),
+		http.WithResourceHandler(kithttp.NewFeatureHandler(feature.SessionService(), flagger, oldSessionHandler, sessionHTTPServer.SignInResourceHandler(), sessionHTTPServer.SignInResourceHandler().Prefix())),
+		http.WithResourceHandler(kithttp.NewFeatureHandler(feature.SessionService(), flagger, oldSessionHandler, sessionHTTPServer.SignOutResourceHandler(), sessionHTTPServer.SignOutResourceHandler().Prefix())),
+		http.WithResourceHandler(userHTTPServer.MeResourceHandler()),
+		http.WithResourceHandler(userHTTPServer.UserResourceHandler()),
+	}
+
+	if m.oauthConfig.Enabled() {
+		oauthConfig := m.oauthConfig
+		if oauthConfig.GroupMappings, err = session.ParseGroupMappings(m.oauthGroupMappings); err != nil {
+			m.log.Error("Failed to parse OAuth group mappings", zap.Error(err))
+			return err
+		}
+
+		oauthHTTPServer, err := session.NewOAuthHandler(m.log.With(zap.String("handler", "oauth")), oauthConfig, sessionSvc, userSvc, orgSvc, userResourceSvc)
+		if err != nil {
+			m.log.Error("Failed to create OAuth handler", zap.Error(err))
+			return err
+		}
+		platformOpts = append(platformOpts, http.WithResourceHandler(oauthHTTPServer))
+	}
+
 	{
-		platformHandler := http.NewPlatformHandler(m.apibackend,
-			http.WithResourceHandler(pkgHTTPServer),
-			http.WithResourceHandler(onboardHTTPServer),
-			http.WithResourceHandler(authHTTPServer),
-			http.WithResourceHandler(labelsHTTPServer),
-			http.WithResourceHandler(kithttp.NewFeatureHandler(feature.SessionService(), flagger, oldSessionHandler, sessionHTTPServer.SignInResourceHandler(), sessionHTTPServer.SignInResourceHandler().Prefix())),
-			http.WithResourceHandler(kithttp.NewFeatureHandler(feature.SessionService(), flagger, oldSessionHandler, sessionHTTPServer.SignOutResourceHandler(), sessionHTTPServer.SignOutResourceHandler().Prefix())),
-			http.WithResourceHandler(userHTTPServer.MeResourceHandler()),
-			http.WithResourceHandler(userHTTPServer.UserResourceHandler()),
-		)
+		platformHandler := http.NewPlatformHandler(m.apibackend, platformOpts...)
 
 		httpLogger := m.log.With(zap.String("service", "http"))
 		m.httpServer.Handler = http.NewHandlerFromRegistry(
@@ -0,0 +1,265 @@
+package launcher_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/cmd/influxd/launcher"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+)
+
+// TestLauncher_NotificationRuleDelivery runs the Flux of notification rules
+// against a stand-in of the endpoint, with statuses read from CSV rather than
+// the monitoring bucket.
+func TestLauncher_NotificationRuleDelivery(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx)
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	type request struct {
+		path   string
+		header nethttp.Header
+		body   map[string]interface{}
+	}
+	requests := make(chan request, 10)
+	srv := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		var body map[string]interface{}
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Errorf("notification body is not JSON: %s", b)
+		}
+		requests <- request{path: r.URL.Path, header: r.Header, body: body}
+		// OpsGenie processes alerts asynchronously.
+		if strings.HasPrefix(r.URL.Path, "/v2/alerts") {
+			w.WriteHeader(nethttp.StatusAccepted)
+		}
+	}))
+	defer srv.Close()
+
+	base := rule.Base{
+		ID:          1,
+		Name:        "cpu rule",
+		EndpointID:  2,
+		Every:       mustDuration(t, time.Hour),
+		StatusRules: []notification.StatusRule{{CurrentLevel: notification.Critical}},
+	}
+	tests := []struct {
+		name     string
+		rule     influxdb.NotificationRule
+		endpoint influxdb.NotificationEndpoint
+		secrets  map[string]string
+		check    func(t *testing.T, r request)
+	}{
+		{
+			name: "teams",
+			rule: &rule.Teams{Base: base, Title: "cpu", MessageTemplate: "cpu is high"},
+			endpoint: &endpoint.Teams{
+				Base: endpoint.Base{ID: idPtr(2), Name: "teams"},
+				URL:  influxdb.SecretField{Key: "teams-url"},
+			},
+			secrets: map[string]string{"teams-url": srv.URL + "/teams"},
+			check: func(t *testing.T, r request) {
+				if r.path != "/teams" {
+					t.Errorf("unexpected path: %s", r.path)
+				}
+				if r.body["@type"] != "MessageCard" || r.body["text"] != "cpu is high" || r.body["themeColor"] != "D0021B" {
+					t.Errorf("unexpected message card: %v", r.body)
+				}
+			},
+		},
+		{
+			name: "opsgenie",
+			rule: &rule.OpsGenie{Base: base, MessageTemplate: "cpu is high"},
+			endpoint: &endpoint.OpsGenie{
+				Base:   endpoint.Base{ID: idPtr(2), Name: "opsgenie"},
+				URL:    srv.URL + "/v2/alerts",
+				APIKey: influxdb.SecretField{Key: "opsgenie-api-key"},
+			},
+			secrets: map[string]string{"opsgenie-api-key": "genie"},
+			check: func(t *testing.T, r request) {
+				if r.path != "/v2/alerts" {
+					t.Errorf("unexpected path: %s", r.path)
+				}
+				if got := r.header.Get("Authorization"); got != "GenieKey genie" {
+					t.Errorf("unexpected authorization header: %s", got)
+				}
+				if r.body["message"] != "cpu is high" || r.body["alias"] != "000000000000000a" || r.body["priority"] != "P1" {
+					t.Errorf("unexpected alert: %v", r.body)
+				}
+			},
+		},
+		{
+			name: "telegram",
+			rule: &rule.Telegram{Base: base, Channel: "-12345", MessageTemplate: "cpu is high"},
+			endpoint: &endpoint.Telegram{
+				Base:  endpoint.Base{ID: idPtr(2), Name: "telegram"},
+				URL:   srv.URL,
+				Token: influxdb.SecretField{Key: "telegram-token"},
+			},
+			secrets: map[string]string{"telegram-token": "123:abc"},
+			check: func(t *testing.T, r request) {
+				if r.path != "/bot123:abc/sendMessage" {
+					t.Errorf("unexpected path: %s", r.path)
+				}
+				if r.body["chat_id"] != "-12345" || r.body["text"] != "cpu is high" {
+					t.Errorf("unexpected message: %v", r.body)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.secrets {
+				if err := l.SecretService().PutSecret(ctx, l.Org.ID, k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			script, err := tt.rule.GenerateFlux(tt.endpoint, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			l.FluxQueryOrFail(t, l.Org, l.Auth.Token, withCSVStatuses(t, script, time.Now()))
+
+			select {
+			case r := <-requests:
+				tt.check(t, r)
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for the notification")
+			}
+		})
+	}
+}
+
+// TestLauncher_SMTPNotificationRuleDelivery runs the Flux of an smtp
+// notification rule, whose mail is relayed by influxd to a stand-in of the
+// mail server.
+func TestLauncher_SMTPNotificationRuleDelivery(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx)
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	mails := make(chan string, 1)
+	go serveSMTP(t, ln, mails)
+
+	e := &endpoint.SMTP{
+		Base: endpoint.Base{Name: "mail", OrgID: &l.Org.ID, Status: influxdb.Active},
+		Host: ln.Addr().String(),
+		From: "influxdb@example.com",
+	}
+	if err := l.NotificationEndpointService(t).CreateNotificationEndpoint(ctx, e, l.User.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &rule.SMTP{
+		Base: rule.Base{
+			ID:          1,
+			Name:        "cpu rule",
+			EndpointID:  e.GetID(),
+			Every:       mustDuration(t, time.Hour),
+			StatusRules: []notification.StatusRule{{CurrentLevel: notification.Critical}},
+		},
+		SubjectTemplate: "cpu",
+		BodyTemplate:    "cpu is high",
+		To:              "oncall@example.com",
+	}
+	script, err := r.GenerateFlux(e, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.FluxQueryOrFail(t, l.Org, l.Auth.Token, withCSVStatuses(t, script, time.Now()))
+
+	select {
+	case m := <-mails:
+		if !strings.Contains(m, "To: <oncall@example.com>\r\n") || !strings.Contains(m, "Subject: cpu\r\n") || !strings.HasSuffix(m, "\r\n\r\ncpu is high\r\n") {
+			t.Errorf("unexpected mail:\n%s", m)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the mail")
+	}
+}
+
+// serveSMTP accepts a connection of ln, and sends the data of the mail sent
+// over it to mails.
+func serveSMTP(t *testing.T, ln net.Listener, mails chan<- string) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	c := textproto.NewConn(conn)
+	defer c.Close()
+
+	_ = c.PrintfLine("220 localhost ESMTP")
+	for {
+		line, err := c.ReadLine()
+		if err != nil {
+			return
+		}
+		switch cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); cmd {
+		case "DATA":
+			_ = c.PrintfLine("354 go ahead")
+			b, err := c.ReadDotBytes()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mails <- strings.ReplaceAll(string(b), "\n", "\r\n")
+			_ = c.PrintfLine("250 ok")
+		case "QUIT":
+			_ = c.PrintfLine("221 bye")
+			return
+		default:
+			_ = c.PrintfLine("250 ok")
+		}
+	}
+}
+
+var monitorFromRegexp = regexp.MustCompile(`statuses = monitor\["from"\]\(start: [^)]*\)`)
+
+// withCSVStatuses returns the notification rule script reading a critical
+// status at now from CSV, without logging the notification.
+func withCSVStatuses(t *testing.T, script string, now time.Time) string {
+	t.Helper()
+	statuses := "#datatype,string,long,dateTime:RFC3339,string,string,string,string\n" +
+		"#group,false,false,false,false,false,false,false\n" +
+		"#default,_result,,,,,,\n" +
+		",result,table,_time,_check_id,_check_name,_level,_message\n" +
+		",,0," + now.UTC().Format(time.RFC3339) + ",000000000000000a,cpu check,crit,cpu is high\n"
+
+	if !monitorFromRegexp.MatchString(script) {
+		t.Fatalf("script does not read statuses from the monitoring bucket:\n%s", script)
+	}
+	script = monitorFromRegexp.ReplaceAllLiteralString(script, "statuses = csv.from(csv: "+strconv.Quote(statuses)+")")
+	script = strings.Replace(script, "import ", "import \"csv\"\nimport ", 1)
+	return strings.Replace(script, "option task", "option monitor.log = (tables=<-) => tables\noption task", 1)
+}
+
+func mustDuration(t *testing.T, d time.Duration) *notification.Duration {
+	t.Helper()
+	dur, err := notification.FromTimeDuration(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &dur
+}
+
+func idPtr(id influxdb.ID) *influxdb.ID {
+	return &id
+}
@@ -146,7 +146,7 @@ func TestLauncher_Pkger(t *testing.T) {
 		every, err := notification.FromTimeDuration(time.Hour)
 		require.NoError(t, err)
 
-		obj := pkger.NotificationRuleToObject("", endpointPkgName, &rule.HTTP{
+		obj := pkger.NotificationRuleToObject("", endpointPkgName, nil, &rule.HTTP{
 			Base: rule.Base{
 				Name:        name,
 				Description: desc,
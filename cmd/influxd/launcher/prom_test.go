@@ -0,0 +1,130 @@
+package launcher_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	nethttp "net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2/cmd/influxd/launcher"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+)
+
+func TestLauncher_PromRead_EmptyLabelMatchers(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx)
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	l.WritePointsOrFail(t, `up,job=node value=1 946684800000000000
+up,job=db value=2 946684800000000000
+up value=3 946684800000000000`)
+
+	tests := []struct {
+		name    string
+		matcher *remote.LabelMatcher
+		want    []string
+	}{
+		{
+			name:    "equal empty matches series without the label",
+			matcher: &remote.LabelMatcher{Type: remote.LabelMatcher_EQ, Name: "job", Value: ""},
+			want:    []string{""},
+		},
+		{
+			name:    "not equal empty matches series with the label",
+			matcher: &remote.LabelMatcher{Type: remote.LabelMatcher_NEQ, Name: "job", Value: ""},
+			want:    []string{"db", "node"},
+		},
+		{
+			name:    "not equal matches series without the label",
+			matcher: &remote.LabelMatcher{Type: remote.LabelMatcher_NEQ, Name: "job", Value: "node"},
+			want:    []string{"", "db"},
+		},
+		{
+			name:    "regex matching empty matches series without the label",
+			matcher: &remote.LabelMatcher{Type: remote.LabelMatcher_RE, Name: "job", Value: "|node"},
+			want:    []string{"", "node"},
+		},
+		{
+			name:    "regex not matching empty excludes series without the label",
+			matcher: &remote.LabelMatcher{Type: remote.LabelMatcher_NRE, Name: "job", Value: "|node"},
+			want:    []string{"db"},
+		},
+		{
+			name:    "label missing from all series",
+			matcher: &remote.LabelMatcher{Type: remote.LabelMatcher_EQ, Name: "instance", Value: ""},
+			want:    []string{"", "db", "node"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &remote.ReadRequest{
+				Queries: []*remote.Query{{
+					StartTimestampMs: 946684800000,
+					EndTimestampMs:   946684800000,
+					Matchers: []*remote.LabelMatcher{
+						{Type: remote.LabelMatcher_EQ, Name: remote.MetricNameLabel, Value: "up"},
+						tt.matcher,
+					},
+				}},
+			}
+			resp := promReadOrFail(t, l, req)
+			if len(resp.Results) != 1 {
+				t.Fatalf("unexpected number of results: %d", len(resp.Results))
+			}
+
+			got := []string{}
+			for _, ts := range resp.Results[0].Timeseries {
+				var job string
+				for _, label := range ts.Labels {
+					if label.Name == "job" {
+						job = label.Value
+					}
+				}
+				got = append(got, job)
+			}
+			sort.Strings(got)
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("unexpected series -want/+got\n%s", cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func promReadOrFail(t *testing.T, l *launcher.TestLauncher, req *remote.ReadRequest) *remote.ReadResponse {
+	t.Helper()
+	data, err := proto.Marshal(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hreq := l.MustNewHTTPRequest("POST", fmt.Sprintf("/api/v2/prom/read?org=%s&bucket=%s", l.Org.ID, l.Bucket.ID), "")
+	hreq.Body = ioutil.NopCloser(strings.NewReader(string(snappy.Encode(nil, data))))
+	resp, err := nethttp.DefaultClient.Do(hreq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != nethttp.StatusOK {
+		t.Fatalf("unexpected status code: %d, body: %s", resp.StatusCode, body)
+	}
+
+	data, err = snappy.Decode(nil, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rresp remote.ReadResponse
+	if err := proto.Unmarshal(data, &rresp); err != nil {
+		t.Fatal(err)
+	}
+	return &rresp
+}
@@ -0,0 +1,92 @@
+package launcher_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/cmd/influxd/launcher"
+	"github.com/influxdata/influxdb/v2/http"
+)
+
+func TestLauncher_QueryCache_Restore(t *testing.T) {
+	l := launcher.RunTestLauncherOrFail(t, ctx, "--query-cache-max-bytes", "1048576")
+	l.SetupOrFail(t)
+	defer l.ShutdownOrFail(t, ctx)
+
+	l.WritePointsOrFail(t, `m,k=v f=1 946684800000000000`)
+
+	// Back up the bucket holding the point.
+	bs := &http.BackupService{Addr: l.URL(), Token: l.Auth.Token}
+	manifest, err := bs.CreateBackup(ctx, influxdb.BackupFilter{BucketID: &l.Bucket.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "query-cache-restore")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var files []string
+	for _, f := range manifest.Files {
+		path := filepath.Join(dir, f.FileName)
+		fw, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = bs.FetchBackupFile(ctx, manifest.ID, f.FileName, fw)
+		if cerr := fw.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if filepath.Ext(f.FileName) == ".tsm" {
+			files = append(files, path)
+		}
+	}
+	if len(files) == 0 {
+		t.Fatal("backup holds no TSM files")
+	}
+
+	// Cache the empty result of a bucket of the name the backup is restored to.
+	bucket := &influxdb.Bucket{OrgID: l.Org.ID, Name: "restored"}
+	if err := l.BucketService(t).CreateBucket(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+	query := `from(bucket: "restored") |> range(start: 2000-01-01T00:00:00Z, stop: 2000-01-02T00:00:00Z) |> keep(columns: ["_value"])`
+	if got := cachedQueryOrFail(t, l, query); strings.Contains(got, "_value") {
+		t.Fatalf("expected an empty result before the restore, got:\n%s", got)
+	}
+
+	// Replace the bucket with the restored one.
+	if err := l.BucketService(t).DeleteBucket(ctx, bucket.ID); err != nil {
+		t.Fatal(err)
+	}
+	rs := &http.RestoreService{Addr: l.URL(), Token: l.Auth.Token}
+	source := influxdb.BackupBucket{OrgID: l.Org.ID, BucketID: l.Bucket.ID}
+	restored := &influxdb.Bucket{OrgID: l.Org.ID, Name: "restored"}
+	if err := rs.RestoreBucket(ctx, source, restored, files); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := ",result,table,_value\r\n,_result,0,1\r\n\r\n"
+	if got := cachedQueryOrFail(t, l, query); got != exp {
+		t.Errorf("unexpected result after the restore -want/+got:\n\t- %q\n\t+ %q", exp, got)
+	}
+}
+
+// cachedQueryOrFail runs the query with results served from and stored in
+// the query cache.
+func cachedQueryOrFail(t *testing.T, l *launcher.TestLauncher, query string) string {
+	t.Helper()
+	b, err := http.SimpleQuery(l.URL(), query, l.Org.Name, l.Auth.Token, "Cache-Control", "max-age=3600")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
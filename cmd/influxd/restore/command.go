@@ -1,12 +1,14 @@
 package restore
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/bolt"
 	"github.com/influxdata/influxdb/v2/cmd/influxd/inspect"
 	"github.com/influxdata/influxdb/v2/http"
@@ -34,6 +36,9 @@ NOTES:
 
 * The influxd server should not be running when using the restore tool
   as it replaces all data and metadata.
+* Backups of a single bucket or organization cannot be restored with this
+  tool, since it would replace the data of every other bucket. Restore them
+  into a running server with "influx restore".
 `,
 	Args: cobra.ExactArgs(0),
 	RunE: restoreE,
@@ -99,6 +104,19 @@ func restoreE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no backup path given")
 	}
 
+	manifest, err := readManifest()
+	if err != nil {
+		return fmt.Errorf("failed to read backup manifest: %v", err)
+	}
+	if manifest != nil {
+		if err := checkFullBackup(manifest); err != nil {
+			return err
+		}
+		if err := verifyManifest(manifest); err != nil {
+			return fmt.Errorf("failed to verify backup: %v", err)
+		}
+	}
+
 	if err := moveBolt(); err != nil {
 		return fmt.Errorf("failed to move existing bolt file: %v", err)
 	}
@@ -119,7 +137,7 @@ func restoreE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to restore credentials file: %v", err)
 	}
 
-	if err := restoreEngine(); err != nil {
+	if err := restoreEngine(manifest); err != nil {
 		return fmt.Errorf("failed to restore all TSM files: %v", err)
 	}
 
@@ -129,7 +147,9 @@ func restoreE(cmd *cobra.Command, args []string) error {
 
 		rebuild := inspect.NewBuildTSICommand()
 		rebuild.SetArgs([]string{"--sfile-path", sFilePath, "--tsi-path", indexPath})
-		rebuild.Execute()
+		if err := rebuild.Execute(); err != nil {
+			return fmt.Errorf("failed to rebuild the TSI index and series file: %v", err)
+		}
 	}
 
 	if err := removeTmpBolt(); err != nil {
@@ -230,30 +250,26 @@ func restoreBolt() error {
 	return nil
 }
 
-func restoreEngine() error {
+func restoreEngine(manifest *influxdb.BackupManifest) error {
 	dataDir := filepath.Join(flags.enginePath, "/data")
 	if err := os.MkdirAll(dataDir, 0777); err != nil {
 		return err
 	}
 
-	count := 0
-	err := filepath.Walk(flags.backupPath, func(path string, info os.FileInfo, err error) error {
-		if strings.Contains(path, ".tsm") {
-			f, err := os.OpenFile(path, os.O_RDONLY, 0666)
-			if err != nil {
-				return fmt.Errorf("error opening TSM file: %v", err)
-			}
-			defer f.Close()
-
-			tsmPath := filepath.Join(dataDir, filepath.Base(path))
-			w, err := os.OpenFile(tsmPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
-			if err != nil {
+	if manifest != nil {
+		for _, f := range manifest.Files {
+			if err := copyFile(filepath.Join(flags.backupPath, f.FileName), filepath.Join(dataDir, f.FileName)); err != nil {
 				return err
 			}
-			defer w.Close()
+		}
+		fmt.Printf("Restored %d TSM and tombstone files to %v\n", len(manifest.Files), dataDir)
+		return nil
+	}
 
-			_, err = io.Copy(w, f)
-			if err != nil {
+	count := 0
+	err := filepath.Walk(flags.backupPath, func(path string, info os.FileInfo, err error) error {
+		if strings.Contains(path, ".tsm") {
+			if err := copyFile(path, filepath.Join(dataDir, filepath.Base(path))); err != nil {
 				return err
 			}
 			count++
@@ -265,6 +281,73 @@ func restoreEngine() error {
 	return err
 }
 
+func copyFile(src, dest string) error {
+	f, err := os.OpenFile(src, os.O_RDONLY, 0666)
+	if err != nil {
+		return fmt.Errorf("error opening backup file: %v", err)
+	}
+	defer f.Close()
+
+	w, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0666)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// readManifest reads the manifest of the backup. It returns nil if the backup
+// was created without a manifest.
+func readManifest() (*influxdb.BackupManifest, error) {
+	f, err := os.Open(filepath.Join(flags.backupPath, influxdb.BackupManifestFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var manifest influxdb.BackupManifest
+	if err := json.NewDecoder(f).Decode(&manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// checkFullBackup returns an error if the backup is restricted to a bucket or
+// an organization. The offline restore replaces all the data and metadata of
+// the server, so restoring a filtered backup would lose every other bucket.
+func checkFullBackup(manifest *influxdb.BackupManifest) error {
+	switch {
+	case manifest.BucketID != nil:
+		return fmt.Errorf("backup %d only contains bucket %s; restore it into a running server with \"influx restore\"", manifest.ID, manifest.BucketID)
+	case manifest.OrgID != nil:
+		return fmt.Errorf("backup %d only contains organization %s; restore its buckets into a running server with \"influx restore\"", manifest.ID, manifest.OrgID)
+	}
+	return nil
+}
+
+// verifyManifest checks the files of the backup against the checksums of the manifest.
+func verifyManifest(manifest *influxdb.BackupManifest) error {
+	files := manifest.Files
+	if manifest.KV != nil {
+		files = append([]influxdb.BackupFile{*manifest.KV}, files...)
+	}
+	for _, f := range files {
+		checksum, size, err := fs.Checksum(filepath.Join(flags.backupPath, f.FileName))
+		if err != nil {
+			return err
+		}
+		if checksum != f.Checksum || size != f.Size {
+			return fmt.Errorf("backup file %s does not match the manifest checksum", f.FileName)
+		}
+	}
+	fmt.Printf("Verified %d files of backup %d\n", len(files), manifest.ID)
+	return nil
+}
+
 func restoreFile(backup string, target string, filetype string) error {
 	f, err := os.Open(backup)
 	if err != nil {
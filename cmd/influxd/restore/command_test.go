@@ -0,0 +1,41 @@
+package restore
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+func TestCheckFullBackup(t *testing.T) {
+	id := influxdb.ID(1)
+
+	tests := []struct {
+		name     string
+		manifest influxdb.BackupManifest
+		wantErr  bool
+	}{
+		{
+			name:     "full backup",
+			manifest: influxdb.BackupManifest{ID: 1},
+		},
+		{
+			name:     "bucket backup",
+			manifest: influxdb.BackupManifest{ID: 2, BucketID: &id},
+			wantErr:  true,
+		},
+		{
+			name:     "organization backup",
+			manifest: influxdb.BackupManifest{ID: 3, OrgID: &id},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkFullBackup(&tt.manifest)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkFullBackup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package downsample
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+// TaskType is the type of the tasks materializing downsample rules.
+const TaskType = "downsample"
+
+// GenerateFlux returns the script of the task materializing rule r of bucket b.
+// Each run aggregates the window of data that became older than r.After since
+// the previous run, and writes one field per function to the destination,
+// suffixed with the function name.
+func GenerateFlux(b *influxdb.Bucket, r influxdb.DownsampleRule, dest *influxdb.Bucket) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "option task = {name: %q, every: %s}\n\n", taskName(b, r), fluxDuration(r.Every))
+	fmt.Fprintf(&sb, "data = from(bucketID: %q)\n", b.ID.String())
+	fmt.Fprintf(&sb, "\t|> range(start: -%s, stop: -%s)\n", fluxDuration(r.After+r.Every), fluxDuration(r.After))
+	for _, fn := range r.Functions {
+		fmt.Fprintf(&sb, "\ndata\n")
+		fmt.Fprintf(&sb, "\t|> aggregateWindow(every: %s, fn: %s)\n", fluxDuration(r.Every), fn)
+		fmt.Fprintf(&sb, "\t|> map(fn: (r) => ({r with _field: r._field + %q}))\n", "_"+fn)
+		fmt.Fprintf(&sb, "\t|> to(bucketID: %q, orgID: %q)\n", dest.ID.String(), dest.OrgID.String())
+	}
+	return sb.String()
+}
+
+func taskName(b *influxdb.Bucket, r influxdb.DownsampleRule) string {
+	return fmt.Sprintf("Downsample %s every %s into %s", b.Name, fluxDuration(r.Every), r.DestinationBucket)
+}
+
+// fluxDuration formats a duration of whole seconds as a Flux duration literal.
+func fluxDuration(d time.Duration) string {
+	var sb strings.Builder
+	for _, u := range []struct {
+		unit time.Duration
+		name string
+	}{{time.Hour, "h"}, {time.Minute, "m"}, {time.Second, "s"}} {
+		if n := d / u.unit; n > 0 {
+			fmt.Fprintf(&sb, "%d%s", n, u.name)
+			d -= n * u.unit
+		}
+	}
+	if sb.Len() == 0 {
+		return "0s"
+	}
+	return sb.String()
+}
@@ -0,0 +1,56 @@
+package downsample
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+func TestGenerateFlux(t *testing.T) {
+	b := &influxdb.Bucket{ID: 1, OrgID: 2, Name: "telegraf"}
+	dest := &influxdb.Bucket{ID: 3, OrgID: 2, Name: "telegraf_5m"}
+	r := influxdb.DownsampleRule{
+		After:             7 * 24 * time.Hour,
+		Every:             5 * time.Minute,
+		Functions:         []string{"mean", "max"},
+		DestinationBucket: "telegraf_5m",
+	}
+
+	exp := `option task = {name: "Downsample telegraf every 5m into telegraf_5m", every: 5m}
+
+data = from(bucketID: "0000000000000001")
+	|> range(start: -168h5m, stop: -168h)
+
+data
+	|> aggregateWindow(every: 5m, fn: mean)
+	|> map(fn: (r) => ({r with _field: r._field + "_mean"}))
+	|> to(bucketID: "0000000000000003", orgID: "0000000000000002")
+
+data
+	|> aggregateWindow(every: 5m, fn: max)
+	|> map(fn: (r) => ({r with _field: r._field + "_max"}))
+	|> to(bucketID: "0000000000000003", orgID: "0000000000000002")
+`
+	if got := GenerateFlux(b, r, dest); got != exp {
+		t.Fatalf("unexpected flux:\n%s\nexp:\n%s", got, exp)
+	}
+}
+
+func TestFluxDuration(t *testing.T) {
+	tests := []struct {
+		d   time.Duration
+		exp string
+	}{
+		{d: 10 * time.Second, exp: "10s"},
+		{d: 5 * time.Minute, exp: "5m"},
+		{d: 90 * time.Minute, exp: "1h30m"},
+		{d: 7*24*time.Hour + 10*time.Second, exp: "168h10s"},
+		{d: 0, exp: "0s"},
+	}
+	for _, tt := range tests {
+		if got := fluxDuration(tt.d); got != tt.exp {
+			t.Errorf("fluxDuration(%s) = %s, exp %s", tt.d, got, tt.exp)
+		}
+	}
+}
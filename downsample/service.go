@@ -0,0 +1,198 @@
+package downsample
+
+import (
+	"context"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"go.uber.org/multierr"
+)
+
+var _ influxdb.BucketService = (*BucketService)(nil)
+
+// BucketService wraps an existing influxdb.BucketService implementation.
+//
+// BucketService materializes the downsample rules of a bucket as tasks. The
+// tasks are owned by the user creating or updating the rules, and are replaced
+// whenever the rules change. Deleting a bucket deletes its tasks.
+//
+// As the tasks run with the permissions of their owner, creating them
+// requires the authorizer in the context to be allowed to create tasks, read
+// the bucket and write its destination buckets.
+type BucketService struct {
+	influxdb.BucketService
+	tasks influxdb.TaskService
+}
+
+// NewBucketService returns a new BucketService creating the tasks of
+// downsample rules with the provided TaskService.
+func NewBucketService(s influxdb.BucketService, tasks influxdb.TaskService) *BucketService {
+	return &BucketService{
+		BucketService: s,
+		tasks:         tasks,
+	}
+}
+
+// CreateBucket creates a new bucket and the tasks of its downsample rules.
+func (s *BucketService) CreateBucket(ctx context.Context, b *influxdb.Bucket) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	if len(b.DownsampleRules) == 0 {
+		return s.BucketService.CreateBucket(ctx, b)
+	}
+	if err := b.ValidDownsampleRules(); err != nil {
+		return err
+	}
+	// Task IDs are assigned once the bucket exists.
+	rules := clearTaskIDs(b.DownsampleRules)
+	b.DownsampleRules = nil
+	if err := s.BucketService.CreateBucket(ctx, b); err != nil {
+		return err
+	}
+
+	b.DownsampleRules = rules
+	if err := s.createTasks(ctx, b); err != nil {
+		return multierr.Append(err, s.BucketService.DeleteBucket(ctx, b.ID))
+	}
+	updated, err := s.BucketService.UpdateBucket(ctx, b.ID, influxdb.BucketUpdate{DownsampleRules: &rules})
+	if err != nil {
+		err = multierr.Append(err, s.deleteTasks(ctx, rules))
+		return multierr.Append(err, s.BucketService.DeleteBucket(ctx, b.ID))
+	}
+	*b = *updated
+	return nil
+}
+
+// UpdateBucket updates a single bucket with changeset. The tasks of the
+// downsample rules are replaced if the rules or the name of the bucket change.
+func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	current, err := s.BucketService.FindBucketByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	next := *current
+	if upd.Name != nil {
+		next.Name = *upd.Name
+	}
+	if upd.RetentionPeriod != nil {
+		next.RetentionPeriod = *upd.RetentionPeriod
+	}
+	if upd.DownsampleRules != nil {
+		next.DownsampleRules = clearTaskIDs(*upd.DownsampleRules)
+	}
+	if err := next.ValidDownsampleRules(); err != nil {
+		return nil, err
+	}
+
+	if upd.DownsampleRules == nil && next.Name == current.Name {
+		return s.BucketService.UpdateBucket(ctx, id, upd)
+	}
+	if upd.DownsampleRules == nil {
+		next.DownsampleRules = clearTaskIDs(current.DownsampleRules)
+	}
+
+	if err := s.createTasks(ctx, &next); err != nil {
+		return nil, err
+	}
+	upd.DownsampleRules = &next.DownsampleRules
+	updated, err := s.BucketService.UpdateBucket(ctx, id, upd)
+	if err != nil {
+		return nil, multierr.Append(err, s.deleteTasks(ctx, next.DownsampleRules))
+	}
+	if err := s.deleteTasks(ctx, current.DownsampleRules); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// DeleteBucket removes a bucket by ID and the tasks of its downsample rules.
+func (s *BucketService) DeleteBucket(ctx context.Context, id influxdb.ID) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	b, err := s.BucketService.FindBucketByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.BucketService.DeleteBucket(ctx, id); err != nil {
+		return err
+	}
+	return s.deleteTasks(ctx, b.DownsampleRules)
+}
+
+// createTasks creates a task for each downsample rule of b and sets its ID on
+// the rule. If a task cannot be created, the tasks created so far are deleted.
+func (s *BucketService) createTasks(ctx context.Context, b *influxdb.Bucket) error {
+	if len(b.DownsampleRules) == 0 {
+		return nil
+	}
+	ownerID, err := icontext.GetUserID(ctx)
+	if err != nil {
+		return err
+	}
+	if _, _, err := authorizer.AuthorizeCreate(ctx, influxdb.TasksResourceType, b.OrgID); err != nil {
+		return err
+	}
+	if _, _, err := authorizer.AuthorizeRead(ctx, influxdb.BucketsResourceType, b.ID, b.OrgID); err != nil {
+		return err
+	}
+
+	for i, r := range b.DownsampleRules {
+		dest, err := s.BucketService.FindBucketByName(ctx, b.OrgID, r.DestinationBucket)
+		if err != nil {
+			err = &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "downsample destination bucket " + r.DestinationBucket + " not found",
+				Err:  err,
+			}
+			return multierr.Append(err, s.deleteTasks(ctx, b.DownsampleRules[:i]))
+		}
+		if _, _, err := authorizer.AuthorizeWrite(ctx, influxdb.BucketsResourceType, dest.ID, dest.OrgID); err != nil {
+			return multierr.Append(err, s.deleteTasks(ctx, b.DownsampleRules[:i]))
+		}
+
+		t, err := s.tasks.CreateTask(ctx, influxdb.TaskCreate{
+			Type:           TaskType,
+			Flux:           GenerateFlux(b, r, dest),
+			Description:    "Downsample rule of bucket " + b.ID.String(),
+			Status:         influxdb.TaskStatusActive,
+			OrganizationID: b.OrgID,
+			OwnerID:        ownerID,
+		})
+		if err != nil {
+			return multierr.Append(err, s.deleteTasks(ctx, b.DownsampleRules[:i]))
+		}
+		b.DownsampleRules[i].TaskID = t.ID
+	}
+	return nil
+}
+
+// deleteTasks deletes the tasks of rules. Tasks that no longer exist are ignored.
+func (s *BucketService) deleteTasks(ctx context.Context, rules []influxdb.DownsampleRule) error {
+	var err error
+	for _, r := range rules {
+		if !r.TaskID.Valid() {
+			continue
+		}
+		if e := s.tasks.DeleteTask(ctx, r.TaskID); e != nil && influxdb.ErrorCode(e) != influxdb.ENotFound {
+			err = multierr.Append(err, e)
+		}
+	}
+	return err
+}
+
+func clearTaskIDs(rules []influxdb.DownsampleRule) []influxdb.DownsampleRule {
+	out := make([]influxdb.DownsampleRule, len(rules))
+	copy(out, rules)
+	for i := range out {
+		out[i].TaskID = 0
+	}
+	return out
+}
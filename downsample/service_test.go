@@ -0,0 +1,222 @@
+package downsample_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/downsample"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	orgID    = influxdb.ID(1)
+	srcID    = influxdb.ID(10)
+	destID   = influxdb.ID(11)
+	taskID   = influxdb.ID(20)
+	userID   = influxdb.ID(2) // the user of mock.Authorizer
+	testRule = influxdb.DownsampleRule{
+		After:             time.Hour,
+		Every:             5 * time.Minute,
+		Functions:         []string{"mean"},
+		DestinationBucket: "dest",
+	}
+)
+
+func newBucketService(buckets map[influxdb.ID]*influxdb.Bucket) *mock.BucketService {
+	svc := mock.NewBucketService()
+	svc.FindBucketByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+		if b, ok := buckets[id]; ok {
+			bb := *b
+			return &bb, nil
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound}
+	}
+	svc.FindBucketByNameFn = func(_ context.Context, _ influxdb.ID, name string) (*influxdb.Bucket, error) {
+		for _, b := range buckets {
+			if b.Name == name {
+				return b, nil
+			}
+		}
+		return nil, &influxdb.Error{Code: influxdb.ENotFound}
+	}
+	svc.CreateBucketFn = func(_ context.Context, b *influxdb.Bucket) error {
+		b.ID = srcID
+		bb := *b
+		buckets[b.ID] = &bb
+		return nil
+	}
+	svc.UpdateBucketFn = func(_ context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+		b := buckets[id]
+		if upd.DownsampleRules != nil {
+			b.DownsampleRules = *upd.DownsampleRules
+		}
+		bb := *b
+		return &bb, nil
+	}
+	svc.DeleteBucketFn = func(_ context.Context, id influxdb.ID) error {
+		delete(buckets, id)
+		return nil
+	}
+	return svc
+}
+
+func TestBucketService_CreateBucket(t *testing.T) {
+	ctx := icontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(true, nil))
+
+	t.Run("creates a task for each rule", func(t *testing.T) {
+		buckets := map[influxdb.ID]*influxdb.Bucket{destID: {ID: destID, OrgID: orgID, Name: "dest"}}
+		tasks := mock.NewTaskService()
+		tasks.CreateTaskFn = func(_ context.Context, tc influxdb.TaskCreate) (*influxdb.Task, error) {
+			assert.Equal(t, downsample.TaskType, tc.Type)
+			assert.Equal(t, orgID, tc.OrganizationID)
+			assert.Equal(t, userID, tc.OwnerID)
+			assert.Contains(t, tc.Flux, `to(bucketID: "`+destID.String()+`"`)
+			return &influxdb.Task{ID: taskID}, nil
+		}
+
+		svc := downsample.NewBucketService(newBucketService(buckets), tasks)
+		b := &influxdb.Bucket{OrgID: orgID, Name: "src", DownsampleRules: []influxdb.DownsampleRule{testRule}}
+		require.NoError(t, svc.CreateBucket(ctx, b))
+
+		require.Len(t, b.DownsampleRules, 1)
+		assert.Equal(t, taskID, b.DownsampleRules[0].TaskID)
+		assert.Equal(t, taskID, buckets[srcID].DownsampleRules[0].TaskID)
+	})
+
+	t.Run("removes the bucket if the destination does not exist", func(t *testing.T) {
+		buckets := map[influxdb.ID]*influxdb.Bucket{}
+		tasks := mock.NewTaskService()
+
+		svc := downsample.NewBucketService(newBucketService(buckets), tasks)
+		b := &influxdb.Bucket{OrgID: orgID, Name: "src", DownsampleRules: []influxdb.DownsampleRule{testRule}}
+		err := svc.CreateBucket(ctx, b)
+		require.Error(t, err)
+
+		assert.Equal(t, influxdb.EInvalid, influxdb.ErrorCode(err))
+		assert.Empty(t, buckets)
+		assert.Equal(t, 0, tasks.CreateTaskCalls.Count())
+	})
+
+	t.Run("rejects rules beyond the retention period", func(t *testing.T) {
+		svc := downsample.NewBucketService(newBucketService(map[influxdb.ID]*influxdb.Bucket{}), mock.NewTaskService())
+		b := &influxdb.Bucket{
+			OrgID:           orgID,
+			Name:            "src",
+			RetentionPeriod: time.Hour,
+			DownsampleRules: []influxdb.DownsampleRule{testRule},
+		}
+		err := svc.CreateBucket(ctx, b)
+		assert.Equal(t, influxdb.EInvalid, influxdb.ErrorCode(err))
+	})
+}
+
+func TestBucketService_UpdateBucket(t *testing.T) {
+	ctx := icontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(true, nil))
+
+	rule := testRule
+	rule.TaskID = taskID
+	buckets := map[influxdb.ID]*influxdb.Bucket{
+		srcID:  {ID: srcID, OrgID: orgID, Name: "src", DownsampleRules: []influxdb.DownsampleRule{rule}},
+		destID: {ID: destID, OrgID: orgID, Name: "dest"},
+	}
+	tasks := mock.NewTaskService()
+	tasks.CreateTaskFn = func(context.Context, influxdb.TaskCreate) (*influxdb.Task, error) {
+		return &influxdb.Task{ID: taskID + 1}, nil
+	}
+	var deleted []influxdb.ID
+	tasks.DeleteTaskFn = func(_ context.Context, id influxdb.ID) error {
+		deleted = append(deleted, id)
+		return nil
+	}
+
+	svc := downsample.NewBucketService(newBucketService(buckets), tasks)
+	rules := []influxdb.DownsampleRule{testRule}
+	b, err := svc.UpdateBucket(ctx, srcID, influxdb.BucketUpdate{DownsampleRules: &rules})
+	require.NoError(t, err)
+
+	assert.Equal(t, taskID+1, b.DownsampleRules[0].TaskID)
+	assert.Equal(t, []influxdb.ID{taskID}, deleted)
+}
+
+func TestBucketService_DeleteBucket(t *testing.T) {
+	rule := testRule
+	rule.TaskID = taskID
+	buckets := map[influxdb.ID]*influxdb.Bucket{
+		srcID: {ID: srcID, OrgID: orgID, Name: "src", DownsampleRules: []influxdb.DownsampleRule{rule}},
+	}
+	tasks := mock.NewTaskService()
+	var deleted []influxdb.ID
+	tasks.DeleteTaskFn = func(_ context.Context, id influxdb.ID) error {
+		deleted = append(deleted, id)
+		return nil
+	}
+
+	svc := downsample.NewBucketService(newBucketService(buckets), tasks)
+	require.NoError(t, svc.DeleteBucket(context.Background(), srcID))
+
+	assert.Empty(t, buckets)
+	assert.Equal(t, []influxdb.ID{taskID}, deleted)
+}
+
+func TestBucketService_UpdateBucket_Unauthorized(t *testing.T) {
+	permission := func(action influxdb.Action, rt influxdb.ResourceType, id influxdb.ID) influxdb.Permission {
+		p := influxdb.Permission{Action: action, Resource: influxdb.Resource{Type: rt, OrgID: &orgID}}
+		if id.Valid() {
+			p.Resource.ID = &id
+		}
+		return p
+	}
+
+	for _, tt := range []struct {
+		name        string
+		permissions []influxdb.Permission
+	}{
+		{
+			name: "without permission to create tasks",
+			permissions: []influxdb.Permission{
+				permission(influxdb.ReadAction, influxdb.BucketsResourceType, srcID),
+				permission(influxdb.WriteAction, influxdb.BucketsResourceType, srcID),
+				permission(influxdb.WriteAction, influxdb.BucketsResourceType, destID),
+			},
+		},
+		{
+			name: "without permission to read the bucket",
+			permissions: []influxdb.Permission{
+				permission(influxdb.WriteAction, influxdb.TasksResourceType, 0),
+				permission(influxdb.WriteAction, influxdb.BucketsResourceType, srcID),
+				permission(influxdb.WriteAction, influxdb.BucketsResourceType, destID),
+			},
+		},
+		{
+			name: "without permission to write the destination",
+			permissions: []influxdb.Permission{
+				permission(influxdb.WriteAction, influxdb.TasksResourceType, 0),
+				permission(influxdb.ReadAction, influxdb.BucketsResourceType, srcID),
+				permission(influxdb.WriteAction, influxdb.BucketsResourceType, srcID),
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := icontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(false, tt.permissions))
+
+			buckets := map[influxdb.ID]*influxdb.Bucket{
+				srcID:  {ID: srcID, OrgID: orgID, Name: "src"},
+				destID: {ID: destID, OrgID: orgID, Name: "dest"},
+			}
+			tasks := mock.NewTaskService()
+
+			svc := downsample.NewBucketService(newBucketService(buckets), tasks)
+			rules := []influxdb.DownsampleRule{testRule}
+			_, err := svc.UpdateBucket(ctx, srcID, influxdb.BucketUpdate{DownsampleRules: &rules})
+
+			assert.Equal(t, influxdb.EUnauthorized, influxdb.ErrorCode(err))
+			assert.Empty(t, buckets[srcID].DownsampleRules)
+			assert.Equal(t, 0, tasks.CreateTaskCalls.Count())
+		})
+	}
+}
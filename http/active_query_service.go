@@ -0,0 +1,264 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixActiveQueries = "/api/v2/queries"
+	activeQueriesIDPath = "/api/v2/queries/:id"
+)
+
+var _ influxdb.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryBackend is all services and associated parameters required to construct
+// the ActiveQueryHandler.
+type ActiveQueryBackend struct {
+	influxdb.HTTPErrorHandler
+	log                 *zap.Logger
+	ActiveQueryService  influxdb.ActiveQueryService
+	OrganizationService influxdb.OrganizationService
+}
+
+// NewActiveQueryBackend creates a backend used by the active query handler.
+func NewActiveQueryBackend(log *zap.Logger, b *APIBackend) *ActiveQueryBackend {
+	return &ActiveQueryBackend{
+		HTTPErrorHandler:    b.HTTPErrorHandler,
+		log:                 log,
+		ActiveQueryService:  b.ActiveQueryService,
+		OrganizationService: b.OrganizationService,
+	}
+}
+
+// ActiveQueryHandler is the handler for the active query service
+type ActiveQueryHandler struct {
+	*httprouter.Router
+
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	ActiveQueryService  influxdb.ActiveQueryService
+	OrganizationService influxdb.OrganizationService
+}
+
+// NewActiveQueryHandler creates a new ActiveQueryHandler
+func NewActiveQueryHandler(log *zap.Logger, b *ActiveQueryBackend) *ActiveQueryHandler {
+	h := &ActiveQueryHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		ActiveQueryService:  b.ActiveQueryService,
+		OrganizationService: b.OrganizationService,
+	}
+
+	h.HandlerFunc("GET", prefixActiveQueries, h.handleGetActiveQueries)
+	h.HandlerFunc("GET", activeQueriesIDPath, h.handleGetActiveQuery)
+	h.HandlerFunc("DELETE", activeQueriesIDPath, h.handleDeleteActiveQuery)
+
+	return h
+}
+
+type activeQueryLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type activeQueryResponse struct {
+	*influxdb.ActiveQuery
+	// Runtime replaces the runtime of the query with a duration string.
+	Runtime string           `json:"runtime"`
+	Links   activeQueryLinks `json:"links"`
+}
+
+func newActiveQueryResponse(q *influxdb.ActiveQuery) activeQueryResponse {
+	return activeQueryResponse{
+		ActiveQuery: q,
+		Runtime:     q.Runtime.String(),
+		Links: activeQueryLinks{
+			Self: fmt.Sprintf("%s/%s", prefixActiveQueries, q.ID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", q.OrgID),
+		},
+	}
+}
+
+func (r activeQueryResponse) toInfluxdb() (*influxdb.ActiveQuery, error) {
+	runtime, err := time.ParseDuration(r.Runtime)
+	if err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  fmt.Sprintf("invalid query runtime %q", r.Runtime),
+			Err:  err,
+		}
+	}
+	r.ActiveQuery.Runtime = runtime
+	return r.ActiveQuery, nil
+}
+
+type activeQueriesResponse struct {
+	Queries []activeQueryResponse `json:"queries"`
+}
+
+func newActiveQueriesResponse(qs []*influxdb.ActiveQuery) activeQueriesResponse {
+	resp := activeQueriesResponse{
+		Queries: make([]activeQueryResponse, 0, len(qs)),
+	}
+	for _, q := range qs {
+		resp.Queries = append(resp.Queries, newActiveQueryResponse(q))
+	}
+	return resp
+}
+
+func (h *ActiveQueryHandler) decodeGetActiveQueriesRequest(ctx context.Context, r *http.Request) (*influxdb.ActiveQueryFilter, error) {
+	var filter influxdb.ActiveQueryFilter
+	qp := r.URL.Query()
+	if orgID := qp.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return nil, err
+		}
+		filter.OrgID = id
+	} else if org := qp.Get("org"); org != "" {
+		o, err := h.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+		if err != nil {
+			return nil, err
+		}
+		filter.OrgID = &o.ID
+	}
+	return &filter, nil
+}
+
+func (h *ActiveQueryHandler) handleGetActiveQueries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := h.decodeGetActiveQueriesRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	qs, err := h.ActiveQueryService.FindActiveQueries(ctx, *filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Active queries retrieved", zap.Int("queries", len(qs)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newActiveQueriesResponse(qs)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func requestActiveQueryID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := influxdb.IDFromString(urlID)
+	if err != nil {
+		return influxdb.InvalidID(), err
+	}
+
+	return *id, nil
+}
+
+func (h *ActiveQueryHandler) handleGetActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestActiveQueryID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	q, err := h.ActiveQueryService.FindActiveQueryByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Active query retrieved", zap.Stringer("queryID", id))
+	if err := encodeResponse(ctx, w, http.StatusOK, newActiveQueryResponse(q)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *ActiveQueryHandler) handleDeleteActiveQuery(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestActiveQueryID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.ActiveQueryService.CancelActiveQuery(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Active query canceled", zap.Stringer("queryID", id))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ActiveQueryService is an active query service over HTTP to the influxdb server.
+type ActiveQueryService struct {
+	Client *httpc.Client
+}
+
+// FindActiveQueryByID returns a single active query by ID.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+	var resp activeQueryResponse
+	err := s.Client.
+		Get(prefixActiveQueries, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.toInfluxdb()
+}
+
+// FindActiveQueries returns the active queries that match filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+	var params [][2]string
+	if filter.OrgID != nil {
+		params = append(params, [2]string{"orgID", filter.OrgID.String()})
+	}
+
+	var resp activeQueriesResponse
+	err := s.Client.
+		Get(prefixActiveQueries).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	qs := make([]*influxdb.ActiveQuery, 0, len(resp.Queries))
+	for _, r := range resp.Queries {
+		q, err := r.toInfluxdb()
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+	return qs, nil
+}
+
+// CancelActiveQuery cancels an active query by ID.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixActiveQueries, id.String()).
+		Do(ctx)
+}
@@ -0,0 +1,443 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	pctx "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/alert"
+	"github.com/influxdata/influxdb/v2/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixAlerts                = "/api/v2/alerts"
+	prefixAlertAcknowledgements = "/api/v2/alerts/acknowledgements"
+	alertAcknowledgementsIDPath = "/api/v2/alerts/acknowledgements/:id"
+)
+
+var (
+	_ alert.Service                        = (*AlertService)(nil)
+	_ influxdb.AlertAcknowledgementService = (*AlertService)(nil)
+)
+
+// AlertBackend is all services and associated parameters required to construct
+// the AlertHandler.
+type AlertBackend struct {
+	influxdb.HTTPErrorHandler
+	log                         *zap.Logger
+	AlertService                alert.Service
+	AlertAcknowledgementService influxdb.AlertAcknowledgementService
+	OrganizationService         influxdb.OrganizationService
+}
+
+// NewAlertBackend creates a backend used by the alert handler.
+func NewAlertBackend(log *zap.Logger, b *APIBackend) *AlertBackend {
+	return &AlertBackend{
+		HTTPErrorHandler:            b.HTTPErrorHandler,
+		log:                         log,
+		AlertService:                b.AlertService,
+		AlertAcknowledgementService: b.AlertAcknowledgementService,
+		OrganizationService:         b.OrganizationService,
+	}
+}
+
+// AlertHandler is the handler for the alert service
+type AlertHandler struct {
+	*httprouter.Router
+
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	AlertService                alert.Service
+	AlertAcknowledgementService influxdb.AlertAcknowledgementService
+	OrganizationService         influxdb.OrganizationService
+}
+
+// NewAlertHandler creates a new AlertHandler
+func NewAlertHandler(log *zap.Logger, b *AlertBackend) *AlertHandler {
+	h := &AlertHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		AlertService:                b.AlertService,
+		AlertAcknowledgementService: b.AlertAcknowledgementService,
+		OrganizationService:         b.OrganizationService,
+	}
+
+	h.HandlerFunc("GET", prefixAlerts, h.handleGetAlerts)
+	h.HandlerFunc("GET", prefixAlertAcknowledgements, h.handleGetAlertAcknowledgements)
+	h.HandlerFunc("POST", prefixAlertAcknowledgements, h.handlePostAlertAcknowledgement)
+	h.HandlerFunc("GET", alertAcknowledgementsIDPath, h.handleGetAlertAcknowledgement)
+	h.HandlerFunc("DELETE", alertAcknowledgementsIDPath, h.handleDeleteAlertAcknowledgement)
+
+	return h
+}
+
+type alertsResponse struct {
+	Alerts []*alert.Alert `json:"alerts"`
+}
+
+type alertAcknowledgementLinks struct {
+	Self  string `json:"self"`
+	Org   string `json:"org"`
+	Check string `json:"check"`
+}
+
+type alertAcknowledgementResponse struct {
+	*influxdb.AlertAcknowledgement
+	Links alertAcknowledgementLinks `json:"links"`
+}
+
+func newAlertAcknowledgementResponse(a *influxdb.AlertAcknowledgement) alertAcknowledgementResponse {
+	return alertAcknowledgementResponse{
+		AlertAcknowledgement: a,
+		Links: alertAcknowledgementLinks{
+			Self:  fmt.Sprintf("%s/%s", prefixAlertAcknowledgements, a.ID),
+			Org:   fmt.Sprintf("/api/v2/orgs/%s", a.OrgID),
+			Check: fmt.Sprintf("/api/v2/checks/%s", a.CheckID),
+		},
+	}
+}
+
+type alertAcknowledgementsResponse struct {
+	Acknowledgements []alertAcknowledgementResponse `json:"acknowledgements"`
+}
+
+func newAlertAcknowledgementsResponse(acks []*influxdb.AlertAcknowledgement) alertAcknowledgementsResponse {
+	resp := alertAcknowledgementsResponse{
+		Acknowledgements: make([]alertAcknowledgementResponse, 0, len(acks)),
+	}
+	for _, a := range acks {
+		resp.Acknowledgements = append(resp.Acknowledgements, newAlertAcknowledgementResponse(a))
+	}
+	return resp
+}
+
+func (r alertAcknowledgementsResponse) toInfluxdb() []*influxdb.AlertAcknowledgement {
+	acks := make([]*influxdb.AlertAcknowledgement, len(r.Acknowledgements))
+	for i := range r.Acknowledgements {
+		acks[i] = r.Acknowledgements[i].AlertAcknowledgement
+	}
+	return acks
+}
+
+// decodeAlertOrgID returns the id of the org of the request, set by either
+// the orgID or the org query parameter.
+func (h *AlertHandler) decodeAlertOrgID(ctx context.Context, r *http.Request) (influxdb.ID, error) {
+	qp := r.URL.Query()
+	if orgID := qp.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return influxdb.InvalidID(), err
+		}
+		return *id, nil
+	}
+
+	if org := qp.Get("org"); org != "" {
+		o, err := h.OrganizationService.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+		if err != nil {
+			return influxdb.InvalidID(), err
+		}
+		return o.ID, nil
+	}
+
+	return influxdb.InvalidID(), &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "orgID or org is required",
+	}
+}
+
+func decodeAlertCheckID(r *http.Request) (*influxdb.ID, error) {
+	checkID := r.URL.Query().Get("checkID")
+	if checkID == "" {
+		return nil, nil
+	}
+	return influxdb.IDFromString(checkID)
+}
+
+func (h *AlertHandler) decodeGetAlertsRequest(ctx context.Context, r *http.Request) (*alert.Filter, error) {
+	orgID, err := h.decodeAlertOrgID(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	checkID, err := decodeAlertCheckID(r)
+	if err != nil {
+		return nil, err
+	}
+	filter := &alert.Filter{
+		OrgID:   orgID,
+		CheckID: checkID,
+	}
+
+	qp := r.URL.Query()
+	for _, l := range qp["level"] {
+		level := notification.ParseCheckLevel(strings.ToUpper(l))
+		switch level {
+		case notification.Critical, notification.Warn, notification.Info:
+		default:
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid alert level %q, must be one of CRIT, WARN or INFO", l),
+			}
+		}
+		filter.Levels = append(filter.Levels, level)
+	}
+
+	if s := qp.Get("status"); s != "" {
+		status := alert.Status(s)
+		if err := status.Valid(); err != nil {
+			return nil, err
+		}
+		filter.Status = &status
+	}
+
+	if since := qp.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "since must be an RFC3339 time",
+				Err:  err,
+			}
+		}
+		filter.Since = t
+	}
+
+	return filter, nil
+}
+
+func (h *AlertHandler) handleGetAlerts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	filter, err := h.decodeGetAlertsRequest(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	alerts, err := h.AlertService.FindAlerts(ctx, *filter)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Alerts retrieved", zap.Int("alerts", len(alerts)))
+	if err := encodeResponse(ctx, w, http.StatusOK, alertsResponse{Alerts: alerts}); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *AlertHandler) handleGetAlertAcknowledgements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	orgID, err := h.decodeAlertOrgID(ctx, r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	checkID, err := decodeAlertCheckID(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	acks, _, err := h.AlertAcknowledgementService.FindAlertAcknowledgements(ctx, influxdb.AlertAcknowledgementFilter{
+		OrgID:   &orgID,
+		CheckID: checkID,
+	})
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Alert acknowledgements retrieved", zap.String("acknowledgements", fmt.Sprint(acks)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertAcknowledgementsResponse(acks)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *AlertHandler) handlePostAlertAcknowledgement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var ack influxdb.AlertAcknowledgement
+	if err := json.NewDecoder(r.Body).Decode(&ack); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request body",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	ack.CreatedBy = auth.GetUserID()
+
+	if err := h.AlertAcknowledgementService.CreateAlertAcknowledgement(ctx, &ack); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Alert acknowledgement created", zap.String("acknowledgement", fmt.Sprint(ack)))
+	if err := encodeResponse(ctx, w, http.StatusCreated, newAlertAcknowledgementResponse(&ack)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func requestAlertAcknowledgementID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := influxdb.IDFromString(urlID)
+	if err != nil {
+		return influxdb.InvalidID(), err
+	}
+
+	return *id, nil
+}
+
+func (h *AlertHandler) handleGetAlertAcknowledgement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestAlertAcknowledgementID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	ack, err := h.AlertAcknowledgementService.FindAlertAcknowledgementByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Alert acknowledgement retrieved", zap.String("acknowledgement", fmt.Sprint(ack)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newAlertAcknowledgementResponse(ack)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *AlertHandler) handleDeleteAlertAcknowledgement(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestAlertAcknowledgementID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.AlertAcknowledgementService.DeleteAlertAcknowledgement(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Alert acknowledgement deleted", zap.String("acknowledgementID", fmt.Sprint(id)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AlertService is an alert and alert acknowledgement service over HTTP to the influxdb server.
+type AlertService struct {
+	Client *httpc.Client
+}
+
+// FindAlerts returns the alerts that match filter, the most recent first.
+func (s *AlertService) FindAlerts(ctx context.Context, filter alert.Filter) ([]*alert.Alert, error) {
+	params := [][2]string{{"orgID", filter.OrgID.String()}}
+	if filter.CheckID != nil {
+		params = append(params, [2]string{"checkID", filter.CheckID.String()})
+	}
+	for _, l := range filter.Levels {
+		params = append(params, [2]string{"level", l.String()})
+	}
+	if filter.Status != nil {
+		params = append(params, [2]string{"status", string(*filter.Status)})
+	}
+	if !filter.Since.IsZero() {
+		params = append(params, [2]string{"since", filter.Since.Format(time.RFC3339)})
+	}
+
+	var resp alertsResponse
+	err := s.Client.
+		Get(prefixAlerts).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Alerts, nil
+}
+
+// FindAlertAcknowledgementByID returns a single alert acknowledgement by ID.
+func (s *AlertService) FindAlertAcknowledgementByID(ctx context.Context, id influxdb.ID) (*influxdb.AlertAcknowledgement, error) {
+	var resp alertAcknowledgementResponse
+	err := s.Client.
+		Get(prefixAlertAcknowledgements, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.AlertAcknowledgement, nil
+}
+
+// FindAlertAcknowledgements returns a list of alert acknowledgements that match filter
+// and the total count of matching alert acknowledgements.
+func (s *AlertService) FindAlertAcknowledgements(ctx context.Context, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, int, error) {
+	var params [][2]string
+	if filter.OrgID != nil {
+		params = append(params, [2]string{"orgID", filter.OrgID.String()})
+	}
+	if filter.Organization != nil {
+		params = append(params, [2]string{"org", *filter.Organization})
+	}
+	if filter.CheckID != nil {
+		params = append(params, [2]string{"checkID", filter.CheckID.String()})
+	}
+
+	var resp alertAcknowledgementsResponse
+	err := s.Client.
+		Get(prefixAlertAcknowledgements).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	acks := resp.toInfluxdb()
+	return acks, len(acks), nil
+}
+
+// CreateAlertAcknowledgement creates a new alert acknowledgement and sets a.ID with the new identifier.
+func (s *AlertService) CreateAlertAcknowledgement(ctx context.Context, a *influxdb.AlertAcknowledgement) error {
+	var resp alertAcknowledgementResponse
+	err := s.Client.
+		PostJSON(a, prefixAlertAcknowledgements).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	*a = *resp.AlertAcknowledgement
+	return nil
+}
+
+// DeleteAlertAcknowledgement removes an alert acknowledgement by ID.
+func (s *AlertService) DeleteAlertAcknowledgement(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixAlertAcknowledgements, id.String()).
+		Do(ctx)
+}
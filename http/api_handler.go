@@ -12,8 +12,10 @@ import (
 	"github.com/influxdata/influxdb/v2/kit/feature"
 	"github.com/influxdata/influxdb/v2/kit/prom"
 	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"github.com/influxdata/influxdb/v2/notification/alert"
 	"github.com/influxdata/influxdb/v2/query"
 	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/storage/reads"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
 )
@@ -55,9 +57,11 @@ type APIBackend struct {
 	AlgoWProxy FeatureProxyHandler
 
 	PointsWriter                    storage.PointsWriter
+	ReadStore                       reads.Store
 	DeleteService                   influxdb.DeleteService
 	BackupService                   influxdb.BackupService
 	KVBackupService                 influxdb.KVBackupService
+	RestoreService                  influxdb.RestoreService
 	AuthorizationService            influxdb.AuthorizationService
 	DBRPService                     influxdb.DBRPMappingServiceV2
 	BucketService                   influxdb.BucketService
@@ -71,6 +75,7 @@ type APIBackend struct {
 	BucketOperationLogService       influxdb.BucketOperationLogService
 	UserOperationLogService         influxdb.UserOperationLogService
 	OrganizationOperationLogService influxdb.OrganizationOperationLogService
+	OrganizationSettingsService     influxdb.OrganizationSettingsService
 	SourceService                   influxdb.SourceService
 	VariableService                 influxdb.VariableService
 	PasswordsService                influxdb.PasswordsService
@@ -82,6 +87,11 @@ type APIBackend struct {
 	TelegrafService                 influxdb.TelegrafConfigStore
 	ScraperTargetStoreService       influxdb.ScraperTargetStoreService
 	SecretService                   influxdb.SecretService
+	SilenceService                  influxdb.SilenceService
+	RoleService                     influxdb.RoleService
+	AlertService                    alert.Service
+	AlertAcknowledgementService     influxdb.AlertAcknowledgementService
+	ActiveQueryService              influxdb.ActiveQueryService
 	LookupService                   influxdb.LookupService
 	ChronografService               *server.Service
 	OrgLookupService                authorizer.OrganizationService
@@ -90,6 +100,10 @@ type APIBackend struct {
 	NotificationEndpointService     influxdb.NotificationEndpointService
 	Flagger                         feature.Flagger
 	FlagsHandler                    http.Handler
+
+	// LegacyDBRPService resolves db/rp pairs for the 1.x compatible API. It should
+	// not perform authorization, access is checked against the mapped bucket instead.
+	LegacyDBRPService influxdb.DBRPMappingServiceV2
 }
 
 // PrometheusCollectors exposes the prometheus collectors associated with an APIBackend.
@@ -125,10 +139,17 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	}
 
 	noAuthUserResourceMappingService := b.UserResourceMappingService
-	b.UserResourceMappingService = authorizer.NewURMService(b.OrgLookupService, b.UserResourceMappingService)
+	urmService := authorizer.NewURMService(b.OrgLookupService, b.UserResourceMappingService)
+	urmService.WithRoleService(b.RoleService)
+	b.UserResourceMappingService = urmService
 
 	h.Mount("/api/v2", serveLinksHandler(b.HTTPErrorHandler))
 
+	alertBackend := NewAlertBackend(b.Logger.With(zap.String("handler", "alert")), b)
+	alertBackend.AlertService = authorizer.NewAlertService(b.AlertService)
+	alertBackend.AlertAcknowledgementService = authorizer.NewAlertAcknowledgementService(b.AlertAcknowledgementService)
+	h.Mount(prefixAlerts, NewAlertHandler(b.Logger, alertBackend))
+
 	bucketBackend := NewBucketBackend(b.Logger.With(zap.String("handler", "bucket")), b)
 	bucketBackend.BucketService = authorizer.NewBucketService(b.BucketService, noAuthUserResourceMappingService)
 	h.Mount(prefixBuckets, NewBucketHandler(b.Logger, bucketBackend))
@@ -154,6 +175,10 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	fluxBackend := NewFluxBackend(b.Logger.With(zap.String("handler", "query")), b)
 	h.Mount(prefixQuery, NewFluxHandler(b.Logger, fluxBackend))
 
+	activeQueryBackend := NewActiveQueryBackend(b.Logger.With(zap.String("handler", "active_query")), b)
+	activeQueryBackend.ActiveQueryService = authorizer.NewActiveQueryService(b.ActiveQueryService)
+	h.Mount(prefixActiveQueries, NewActiveQueryHandler(b.Logger, activeQueryBackend))
+
 	notificationEndpointBackend := NewNotificationEndpointBackend(b.Logger.With(zap.String("handler", "notificationEndpoint")), b)
 	notificationEndpointBackend.NotificationEndpointService = authorizer.NewNotificationEndpointService(b.NotificationEndpointService,
 		b.UserResourceMappingService, b.OrganizationService)
@@ -167,14 +192,23 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	orgBackend := NewOrgBackend(b.Logger.With(zap.String("handler", "org")), b)
 	orgBackend.OrganizationService = authorizer.NewOrgService(b.OrganizationService)
 	orgBackend.SecretService = authorizer.NewSecretService(b.SecretService)
+	orgBackend.OrganizationSettingsService = authorizer.NewOrgSettingsService(b.OrganizationSettingsService)
 	h.Mount(prefixOrganizations, NewOrgHandler(b.Logger, orgBackend))
 
+	roleBackend := NewRoleBackend(b.Logger.With(zap.String("handler", "role")), b)
+	roleBackend.RoleService = authorizer.NewRoleService(b.RoleService)
+	h.Mount(prefixRoles, NewRoleHandler(b.Logger, roleBackend))
+
 	scraperBackend := NewScraperBackend(b.Logger.With(zap.String("handler", "scraper")), b)
 	scraperBackend.ScraperStorageService = authorizer.NewScraperTargetStoreService(b.ScraperTargetStoreService,
 		b.UserResourceMappingService,
 		b.OrganizationService)
 	h.Mount(prefixTargets, NewScraperHandler(b.Logger, scraperBackend))
 
+	silenceBackend := NewSilenceBackend(b.Logger.With(zap.String("handler", "silence")), b)
+	silenceBackend.SilenceService = authorizer.NewSilenceService(b.SilenceService)
+	h.Mount(prefixSilences, NewSilenceHandler(b.Logger, silenceBackend))
+
 	sourceBackend := NewSourceBackend(b.Logger.With(zap.String("handler", "source")), b)
 	sourceBackend.SourceService = authorizer.NewSourceService(b.SourceService)
 	sourceBackend.BucketService = authorizer.NewBucketService(b.BucketService, noAuthUserResourceMappingService)
@@ -203,6 +237,11 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 	backupBackend.BackupService = authorizer.NewBackupService(backupBackend.BackupService)
 	h.Mount(prefixBackup, NewBackupHandler(backupBackend))
 
+	restoreBackend := NewRestoreBackend(b)
+	restoreBackend.RestoreService = authorizer.NewRestoreService(b.RestoreService)
+	restoreBackend.BucketService = authorizer.NewBucketService(b.BucketService, noAuthUserResourceMappingService)
+	h.Mount(prefixRestore, NewRestoreHandler(restoreBackend))
+
 	h.Mount(dbrp.PrefixDBRP, dbrp.NewHTTPHandler(b.Logger, b.DBRPService, b.OrganizationService))
 
 	writeBackend := NewWriteBackend(b.Logger.With(zap.String("handler", "write")), b)
@@ -213,6 +252,9 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 		WithParserMaxValues(b.WriteParserMaxValues),
 	))
 
+	promBackend := NewPromBackend(b.Logger.With(zap.String("handler", "prom")), b)
+	h.Mount(prefixProm, NewPromHandler(b.Logger, promBackend))
+
 	for _, o := range opts {
 		o(h)
 	}
@@ -222,6 +264,10 @@ func NewAPIHandler(b *APIBackend, opts ...APIHandlerOptFn) *APIHandler {
 var apiLinks = map[string]interface{}{
 	// when adding new links, please take care to keep this list alphabetical
 	// as this makes it easier to verify values against the swagger document.
+	"alerts": map[string]string{
+		"self":             "/api/v2/alerts",
+		"acknowledgements": "/api/v2/alerts/acknowledgements",
+	},
 	"authorizations": "/api/v2/authorizations",
 	"backup":         "/api/v2/backup",
 	"buckets":        "/api/v2/buckets",
@@ -236,15 +282,24 @@ var apiLinks = map[string]interface{}{
 	"notificationRules":     "/api/v2/notificationRules",
 	"notificationEndpoints": "/api/v2/notificationEndpoints",
 	"orgs":                  "/api/v2/orgs",
+	"prom": map[string]string{
+		"write":      "/api/v2/prom/write",
+		"read":       "/api/v2/prom/read",
+		"queryRange": "/api/v2/prom/api/v1/query_range",
+	},
+	"queries": "/api/v2/queries",
 	"query": map[string]string{
 		"self":        "/api/v2/query",
 		"ast":         "/api/v2/query/ast",
 		"analyze":     "/api/v2/query/analyze",
 		"suggestions": "/api/v2/query/suggestions",
 	},
+	"restore":  "/api/v2/restore",
+	"roles":    "/api/v2/roles",
 	"setup":    "/api/v2/setup",
 	"signin":   "/api/v2/signin",
 	"signout":  "/api/v2/signout",
+	"silences": "/api/v2/silences",
 	"sources":  "/api/v2/sources",
 	"scrapers": "/api/v2/scrapers",
 	"swagger":  "/api/v2/swagger.json",
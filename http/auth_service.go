@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb/v2"
 	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorization"
 	platcontext "github.com/influxdata/influxdb/v2/context"
 	"github.com/influxdata/influxdb/v2/pkg/httpc"
 	"go.uber.org/zap"
@@ -28,6 +30,7 @@ type AuthorizationBackend struct {
 	OrganizationService  platform.OrganizationService
 	UserService          platform.UserService
 	LookupService        platform.LookupService
+	RoleService          authorization.RoleService
 }
 
 // NewAuthorizationBackend returns a new instance of AuthorizationBackend.
@@ -40,6 +43,7 @@ func NewAuthorizationBackend(log *zap.Logger, b *APIBackend) *AuthorizationBacke
 		OrganizationService:  b.OrganizationService,
 		UserService:          b.UserService,
 		LookupService:        b.LookupService,
+		RoleService:          b.RoleService,
 	}
 }
 
@@ -53,6 +57,7 @@ type AuthorizationHandler struct {
 	UserService          platform.UserService
 	AuthorizationService platform.AuthorizationService
 	LookupService        platform.LookupService
+	RoleService          authorization.RoleService
 }
 
 // NewAuthorizationHandler returns a new instance of AuthorizationHandler.
@@ -66,6 +71,7 @@ func NewAuthorizationHandler(log *zap.Logger, b *AuthorizationBackend) *Authoriz
 		OrganizationService:  b.OrganizationService,
 		UserService:          b.UserService,
 		LookupService:        b.LookupService,
+		RoleService:          b.RoleService,
 	}
 
 	h.HandlerFunc("POST", "/api/v2/authorizations", h.handlePostAuthorization)
@@ -73,22 +79,27 @@ func NewAuthorizationHandler(log *zap.Logger, b *AuthorizationBackend) *Authoriz
 	h.HandlerFunc("GET", "/api/v2/authorizations/:id", h.handleGetAuthorization)
 	h.HandlerFunc("PATCH", "/api/v2/authorizations/:id", h.handleUpdateAuthorization)
 	h.HandlerFunc("DELETE", "/api/v2/authorizations/:id", h.handleDeleteAuthorization)
+	h.HandlerFunc("POST", "/api/v2/authorizations/:id/rotate", h.handleRotateAuthorization)
 	return h
 }
 
 type authResponse struct {
-	ID          platform.ID          `json:"id"`
-	Token       string               `json:"token"`
-	Status      platform.Status      `json:"status"`
-	Description string               `json:"description"`
-	OrgID       platform.ID          `json:"orgID"`
-	Org         string               `json:"org"`
-	UserID      platform.ID          `json:"userID"`
-	User        string               `json:"user"`
-	Permissions []permissionResponse `json:"permissions"`
-	Links       map[string]string    `json:"links"`
-	CreatedAt   time.Time            `json:"createdAt"`
-	UpdatedAt   time.Time            `json:"updatedAt"`
+	ID           platform.ID          `json:"id"`
+	Token        string               `json:"token"`
+	Status       platform.Status      `json:"status"`
+	Description  string               `json:"description"`
+	OrgID        platform.ID          `json:"orgID"`
+	Org          string               `json:"org"`
+	UserID       platform.ID          `json:"userID"`
+	User         string               `json:"user"`
+	Permissions  []permissionResponse `json:"permissions"`
+	RoleIDs      []platform.ID        `json:"roleIDs,omitempty"`
+	Links        map[string]string    `json:"links"`
+	CreatedAt    time.Time            `json:"createdAt"`
+	UpdatedAt    time.Time            `json:"updatedAt"`
+	ExpiresAt    *time.Time           `json:"expiresAt,omitempty"`
+	LastUsedAt   *time.Time           `json:"lastUsedAt,omitempty"`
+	LastUsedFrom string               `json:"lastUsedFrom,omitempty"`
 }
 
 func newAuthResponse(a *platform.Authorization, org *platform.Organization, user *platform.User, ps []permissionResponse) *authResponse {
@@ -102,38 +113,47 @@ func newAuthResponse(a *platform.Authorization, org *platform.Organization, user
 		User:        user.Name,
 		Org:         org.Name,
 		Permissions: ps,
+		RoleIDs:     a.RoleIDs,
 		Links: map[string]string{
 			"self": fmt.Sprintf("/api/v2/authorizations/%s", a.ID),
 			"user": fmt.Sprintf("/api/v2/users/%s", a.UserID),
 		},
-		CreatedAt: a.CreatedAt,
-		UpdatedAt: a.UpdatedAt,
+		CreatedAt:    a.CreatedAt,
+		UpdatedAt:    a.UpdatedAt,
+		ExpiresAt:    a.ExpiresAt,
+		LastUsedAt:   a.LastUsedAt,
+		LastUsedFrom: a.LastUsedFrom,
 	}
 	return res
 }
 
 func (a *authResponse) toPlatform() *platform.Authorization {
 	res := &platform.Authorization{
-		ID:          a.ID,
-		Token:       a.Token,
-		Status:      a.Status,
-		Description: a.Description,
-		OrgID:       a.OrgID,
-		UserID:      a.UserID,
+		ID:           a.ID,
+		Token:        a.Token,
+		Status:       a.Status,
+		Description:  a.Description,
+		OrgID:        a.OrgID,
+		UserID:       a.UserID,
+		RoleIDs:      a.RoleIDs,
+		ExpiresAt:    a.ExpiresAt,
+		LastUsedAt:   a.LastUsedAt,
+		LastUsedFrom: a.LastUsedFrom,
 		CRUDLog: platform.CRUDLog{
 			CreatedAt: a.CreatedAt,
 			UpdatedAt: a.UpdatedAt,
 		},
 	}
 	for _, p := range a.Permissions {
-		res.Permissions = append(res.Permissions, platform.Permission{Action: p.Action, Resource: p.Resource.Resource})
+		res.Permissions = append(res.Permissions, platform.Permission{Action: p.Action, Resource: p.Resource.Resource, Scope: p.Scope})
 	}
 	return res
 }
 
 type permissionResponse struct {
-	Action   platform.Action  `json:"action"`
-	Resource resourceResponse `json:"resource"`
+	Action   platform.Action           `json:"action"`
+	Resource resourceResponse          `json:"resource"`
+	Scope    *platform.PermissionScope `json:"scope,omitempty"`
 }
 
 type resourceResponse struct {
@@ -150,6 +170,7 @@ func newPermissionsResponse(ctx context.Context, ps []platform.Permission, svc p
 			Resource: resourceResponse{
 				Resource: p.Resource,
 			},
+			Scope: p.Scope,
 		}
 
 		if p.Resource.ID != nil {
@@ -214,6 +235,10 @@ func (h *AuthorizationHandler) handlePostAuthorization(w http.ResponseWriter, r
 	}
 
 	auth := req.toPlatform(userID)
+	if err := authorization.ApplyRoles(ctx, h.RoleService, auth); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
 
 	org, err := h.OrganizationService.FindOrganizationByID(ctx, auth.OrgID)
 	if err != nil {
@@ -246,6 +271,8 @@ type postAuthorizationRequest struct {
 	UserID      *platform.ID          `json:"userID,omitempty"`
 	Description string                `json:"description"`
 	Permissions []platform.Permission `json:"permissions"`
+	RoleIDs     []platform.ID         `json:"roleIDs,omitempty"`
+	ExpiresAt   *time.Time            `json:"expiresAt,omitempty"`
 }
 
 func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Authorization {
@@ -254,7 +281,9 @@ func (p *postAuthorizationRequest) toPlatform(userID platform.ID) *platform.Auth
 		Status:      p.Status,
 		Description: p.Description,
 		Permissions: p.Permissions,
+		RoleIDs:     p.RoleIDs,
 		UserID:      userID,
+		ExpiresAt:   p.ExpiresAt,
 	}
 }
 
@@ -263,7 +292,9 @@ func newPostAuthorizationRequest(a *platform.Authorization) (*postAuthorizationR
 		OrgID:       a.OrgID,
 		Description: a.Description,
 		Permissions: a.Permissions,
+		RoleIDs:     a.RoleIDs,
 		Status:      a.Status,
+		ExpiresAt:   a.ExpiresAt,
 	}
 
 	if a.UserID.Valid() {
@@ -282,7 +313,7 @@ func (p *postAuthorizationRequest) SetDefaults() {
 }
 
 func (p *postAuthorizationRequest) Validate() error {
-	if len(p.Permissions) == 0 {
+	if len(p.Permissions) == 0 && len(p.RoleIDs) == 0 {
 		return &platform.Error{
 			Code: platform.EInvalid,
 			Msg:  "authorization must include permissions",
@@ -314,6 +345,13 @@ func (p *postAuthorizationRequest) Validate() error {
 		return err
 	}
 
+	if p.ExpiresAt != nil && !p.ExpiresAt.After(time.Now()) {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "expiresAt must be in the future",
+		}
+	}
+
 	return nil
 }
 
@@ -595,6 +633,86 @@ func (h *AuthorizationHandler) handleDeleteAuthorization(w http.ResponseWriter,
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// handleRotateAuthorization is the HTTP handler for the POST /api/v2/authorizations/:id/rotate route that issues a new token for the authorization.
+func (h *AuthorizationHandler) handleRotateAuthorization(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeRotateAuthorizationRequest(ctx, r)
+	if err != nil {
+		h.log.Info("Failed to decode request", zap.String("handler", "rotateAuthorization"), zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	a, err := h.AuthorizationService.RotateAuthorization(ctx, req.ID, time.Duration(req.GracePeriodSeconds)*time.Second)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	o, err := h.OrganizationService.FindOrganizationByID(ctx, a.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	u, err := h.UserService.FindUserByID(ctx, a.UserID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	ps, err := newPermissionsResponse(ctx, a.Permissions, h.LookupService)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Auth rotated", zap.String("authID", fmt.Sprint(a.ID)))
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newAuthResponse(a, o, u, ps)); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+}
+
+type rotateAuthorizationRequest struct {
+	ID platform.ID `json:"-"`
+	// GracePeriodSeconds is how long the previous token remains valid.
+	GracePeriodSeconds int64 `json:"gracePeriodSeconds"`
+}
+
+func decodeRotateAuthorizationRequest(ctx context.Context, r *http.Request) (*rotateAuthorizationRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	id := params.ByName("id")
+	if id == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	req := &rotateAuthorizationRequest{}
+	if err := req.ID.DecodeFromString(id); err != nil {
+		return nil, err
+	}
+
+	// the request body is optional.
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && err != io.EOF {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "invalid json structure",
+			Err:  err,
+		}
+	}
+	if req.GracePeriodSeconds < 0 {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "gracePeriodSeconds must not be negative",
+		}
+	}
+
+	return req, nil
+}
+
 type deleteAuthorizationRequest struct {
 	ID platform.ID
 }
@@ -726,3 +844,22 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 		Delete(prefixAuthorization, id.String()).
 		Do(ctx)
 }
+
+// RotateAuthorization issues a new token for the authorization. The previous
+// token remains valid for the grace period.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (*platform.Authorization, error) {
+	req := rotateAuthorizationRequest{
+		GracePeriodSeconds: int64(grace / time.Second),
+	}
+
+	var res authResponse
+	err := s.Client.
+		PostJSON(req, prefixAuthorization, id.String(), "rotate").
+		DecodeJSON(&res).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.toPlatform(), nil
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/influxdata/httprouter"
 	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorization"
 	platcontext "github.com/influxdata/influxdb/v2/context"
 	"github.com/influxdata/influxdb/v2/jsonweb"
 	"github.com/opentracing/opentracing-go"
@@ -26,6 +27,9 @@ type AuthenticationHandler struct {
 	TokenParser          *jsonweb.TokenParser
 	SessionRenewDisabled bool
 
+	// UsageRecorder records the use of token authorizations when set.
+	UsageRecorder *authorization.UsageRecorder
+
 	// This is only really used for it's lookup method the specific http
 	// handler used to register routes does not matter.
 	noAuthRouter *httprouter.Router
@@ -116,6 +120,10 @@ func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	if a, ok := auth.(*platform.Authorization); ok {
+		h.UsageRecorder.Record(a, r)
+	}
+
 	ctx = platcontext.SetAuthorizer(ctx, auth)
 
 	if span := opentracing.SpanFromContext(ctx); span != nil {
@@ -157,7 +165,16 @@ func (h *AuthenticationHandler) extractAuthorization(ctx context.Context, r *htt
 		return nil, err
 	}
 
-	return h.AuthorizationService.FindAuthorizationByToken(ctx, t)
+	a, err := h.AuthorizationService.FindAuthorizationByToken(ctx, t)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.Expired(); err != nil {
+		return nil, err
+	}
+
+	return a, nil
 }
 
 func (h *AuthenticationHandler) extractSession(ctx context.Context, r *http.Request) (*platform.Session, error) {
@@ -114,6 +114,24 @@ func TestAuthenticationHandler(t *testing.T) {
 				code: http.StatusUnauthorized,
 			},
 		},
+		{
+			name: "token has expired",
+			fields: fields{
+				AuthorizationService: &mock.AuthorizationService{
+					FindAuthorizationByTokenFn: func(ctx context.Context, token string) (*platform.Authorization, error) {
+						expiresAt := time.Now().Add(-time.Minute)
+						return &platform.Authorization{ExpiresAt: &expiresAt}, nil
+					},
+				},
+				SessionService: mock.NewSessionService(),
+			},
+			args: args{
+				token: "abc123",
+			},
+			wants: wants{
+				code: http.StatusUnauthorized,
+			},
+		},
 		{
 			name: "associated user is inactive",
 			fields: fields{
@@ -1,6 +1,7 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -88,8 +89,9 @@ func NewBackupHandler(b *BackupBackend) *BackupHandler {
 }
 
 type backup struct {
-	ID    int      `json:"id,omitempty"`
-	Files []string `json:"files,omitempty"`
+	ID       int                      `json:"id,omitempty"`
+	Files    []string                 `json:"files,omitempty"`
+	Manifest *influxdb.BackupManifest `json:"manifest,omitempty"`
 }
 
 func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
@@ -98,13 +100,25 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 
-	id, files, err := h.BackupService.CreateBackup(ctx)
+	var filter influxdb.BackupFilter
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil && err != io.EOF {
+			h.HandleHTTPError(ctx, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "invalid backup filter",
+				Err:  err,
+			}, w)
+			return
+		}
+	}
+
+	manifest, err := h.BackupService.CreateBackup(ctx, filter)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
 
-	internalBackupPath := h.BackupService.InternalBackupPath(id)
+	internalBackupPath := h.BackupService.InternalBackupPath(manifest.ID)
 
 	boltPath := filepath.Join(internalBackupPath, bolt.DefaultFilename)
 	boltFile, err := os.OpenFile(boltPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0660)
@@ -115,11 +129,30 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err = h.KVBackupService.Backup(ctx, boltFile); err != nil {
+		err = multierr.Append(err, boltFile.Close())
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
 		h.HandleHTTPError(ctx, err, w)
 		return
 	}
 
+	if err = boltFile.Close(); err != nil {
+		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if manifest.KV, err = newBackupFile(internalBackupPath, bolt.DefaultFilename); err != nil {
+		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var files []string
+	for _, f := range manifest.Files {
+		if !f.Base {
+			files = append(files, f.FileName)
+		}
+	}
 	files = append(files, bolt.DefaultFilename)
 
 	credsExist, err := h.backupCredentials(internalBackupPath)
@@ -130,12 +163,17 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if credsExist {
+		if manifest.Configs, err = newBackupFile(internalBackupPath, DefaultConfigsFile); err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
 		files = append(files, DefaultConfigsFile)
 	}
 
 	b := backup{
-		ID:    id,
-		Files: files,
+		ID:       manifest.ID,
+		Files:    files,
+		Manifest: manifest,
 	}
 	if err = json.NewEncoder(w).Encode(&b); err != nil {
 		err = multierr.Append(err, os.RemoveAll(internalBackupPath))
@@ -144,6 +182,18 @@ func (h *BackupHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func newBackupFile(dir, name string) (*influxdb.BackupFile, error) {
+	checksum, size, err := fs.Checksum(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	return &influxdb.BackupFile{
+		FileName: name,
+		Size:     size,
+		Checksum: checksum,
+	}, nil
+}
+
 func (h *BackupHandler) backupCredentials(internalBackupPath string) (bool, error) {
 	credBackupPath := filepath.Join(internalBackupPath, DefaultConfigsFile)
 
@@ -191,19 +241,25 @@ type BackupService struct {
 	InsecureSkipVerify bool
 }
 
-func (s *BackupService) CreateBackup(ctx context.Context) (int, []string, error) {
+func (s *BackupService) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (*influxdb.BackupManifest, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
 	u, err := NewURL(s.Addr, prefixBackup)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	req, err := http.NewRequest(http.MethodPost, u.String(), nil)
+	body, err := json.Marshal(filter)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
 	SetToken(s.Token, req)
 	req = req.WithContext(ctx)
 
@@ -211,20 +267,26 @@ func (s *BackupService) CreateBackup(ctx context.Context) (int, []string, error)
 	hc.Timeout = httpClientTimeout
 	resp, err := hc.Do(req)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 	defer resp.Body.Close()
 
 	if err := CheckError(resp); err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
 	var b backup
 	if err = json.NewDecoder(resp.Body).Decode(&b); err != nil {
-		return 0, nil, err
+		return nil, err
+	}
+	if b.Manifest == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "backup response is missing the manifest",
+		}
 	}
 
-	return b.ID, b.Files, nil
+	return b.Manifest, nil
 }
 
 func (s *BackupService) FetchBackupFile(ctx context.Context, backupID int, backupFile string, w io.Writer) error {
@@ -132,13 +132,14 @@ func NewBucketHandler(log *zap.Logger, b *BucketBackend) *BucketHandler {
 
 // bucket is used for serialization/deserialization with duration string syntax.
 type bucket struct {
-	ID                  influxdb.ID     `json:"id,omitempty"`
-	OrgID               influxdb.ID     `json:"orgID,omitempty"`
-	Type                string          `json:"type"`
-	Description         string          `json:"description,omitempty"`
-	Name                string          `json:"name"`
-	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
-	RetentionRules      []retentionRule `json:"retentionRules"`
+	ID                  influxdb.ID      `json:"id,omitempty"`
+	OrgID               influxdb.ID      `json:"orgID,omitempty"`
+	Type                string           `json:"type"`
+	Description         string           `json:"description,omitempty"`
+	Name                string           `json:"name"`
+	RetentionPolicyName string           `json:"rp,omitempty"` // This to support v1 sources
+	RetentionRules      []retentionRule  `json:"retentionRules"`
+	DownsampleRules     []downsampleRule `json:"downsampleRules,omitempty"`
 	influxdb.CRUDLog
 }
 
@@ -160,6 +161,49 @@ func (rr *retentionRule) RetentionPeriod() (time.Duration, error) {
 	return t, nil
 }
 
+// downsampleRule is a downsample rule of a bucket with durations in seconds.
+type downsampleRule struct {
+	AfterSeconds      int64       `json:"afterSeconds"`
+	EverySeconds      int64       `json:"everySeconds"`
+	Functions         []string    `json:"functions"`
+	DestinationBucket string      `json:"destinationBucket"`
+	TaskID            influxdb.ID `json:"taskID,omitempty"`
+}
+
+func toDownsampleRules(rules []downsampleRule) []influxdb.DownsampleRule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]influxdb.DownsampleRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, influxdb.DownsampleRule{
+			After:             time.Duration(r.AfterSeconds) * time.Second,
+			Every:             time.Duration(r.EverySeconds) * time.Second,
+			Functions:         r.Functions,
+			DestinationBucket: r.DestinationBucket,
+			TaskID:            r.TaskID,
+		})
+	}
+	return out
+}
+
+func newDownsampleRules(rules []influxdb.DownsampleRule) []downsampleRule {
+	if rules == nil {
+		return nil
+	}
+	out := make([]downsampleRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, downsampleRule{
+			AfterSeconds:      int64(r.After / time.Second),
+			EverySeconds:      int64(r.Every / time.Second),
+			Functions:         r.Functions,
+			DestinationBucket: r.DestinationBucket,
+			TaskID:            r.TaskID,
+		})
+	}
+	return out
+}
+
 func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 	if b == nil {
 		return nil, nil
@@ -186,6 +230,7 @@ func (b *bucket) toInfluxDB() (*influxdb.Bucket, error) {
 		Name:                b.Name,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     d,
+		DownsampleRules:     toDownsampleRules(b.DownsampleRules),
 		CRUDLog:             b.CRUDLog,
 	}, nil
 }
@@ -212,6 +257,7 @@ func newBucket(pb *influxdb.Bucket) *bucket {
 		Description:         pb.Description,
 		RetentionPolicyName: pb.RetentionPolicyName,
 		RetentionRules:      rules,
+		DownsampleRules:     newDownsampleRules(pb.DownsampleRules),
 		CRUDLog:             pb.CRUDLog,
 	}
 }
@@ -221,6 +267,8 @@ type bucketUpdate struct {
 	Name           *string         `json:"name,omitempty"`
 	Description    *string         `json:"description,omitempty"`
 	RetentionRules []retentionRule `json:"retentionRules,omitempty"`
+	// DownsampleRules replaces the downsample rules of the bucket if set.
+	DownsampleRules *[]downsampleRule `json:"downsampleRules,omitempty"`
 }
 
 func (b *bucketUpdate) OK() error {
@@ -244,11 +292,19 @@ func (b *bucketUpdate) toInfluxDB() *influxdb.BucketUpdate {
 		d, _ = b.RetentionRules[0].RetentionPeriod()
 	}
 
-	return &influxdb.BucketUpdate{
+	upd := &influxdb.BucketUpdate{
 		Name:            b.Name,
 		Description:     b.Description,
 		RetentionPeriod: &d,
 	}
+	if b.DownsampleRules != nil {
+		rules := toDownsampleRules(*b.DownsampleRules)
+		if rules == nil {
+			rules = []influxdb.DownsampleRule{}
+		}
+		upd.DownsampleRules = &rules
+	}
+	return upd
 }
 
 func newBucketUpdate(pb *influxdb.BucketUpdate) *bucketUpdate {
@@ -269,6 +325,14 @@ func newBucketUpdate(pb *influxdb.BucketUpdate) *bucketUpdate {
 			EverySeconds: d,
 		})
 	}
+
+	if pb.DownsampleRules != nil {
+		rules := newDownsampleRules(*pb.DownsampleRules)
+		if rules == nil {
+			rules = []downsampleRule{}
+		}
+		up.DownsampleRules = &rules
+	}
 	return up
 }
 
@@ -336,11 +400,12 @@ func (h *BucketHandler) handlePostBucket(w http.ResponseWriter, r *http.Request)
 }
 
 type postBucketRequest struct {
-	OrgID               influxdb.ID     `json:"orgID,omitempty"`
-	Name                string          `json:"name"`
-	Description         string          `json:"description"`
-	RetentionPolicyName string          `json:"rp,omitempty"` // This to support v1 sources
-	RetentionRules      []retentionRule `json:"retentionRules"`
+	OrgID               influxdb.ID      `json:"orgID,omitempty"`
+	Name                string           `json:"name"`
+	Description         string           `json:"description"`
+	RetentionPolicyName string           `json:"rp,omitempty"` // This to support v1 sources
+	RetentionRules      []retentionRule  `json:"retentionRules"`
+	DownsampleRules     []downsampleRule `json:"downsampleRules,omitempty"`
 }
 
 func (b *postBucketRequest) OK() error {
@@ -386,6 +451,7 @@ func (b postBucketRequest) toInfluxDB() *influxdb.Bucket {
 		Type:                influxdb.BucketTypeUser,
 		RetentionPolicyName: b.RetentionPolicyName,
 		RetentionPeriod:     dur,
+		DownsampleRules:     toDownsampleRules(b.DownsampleRules),
 	}
 }
 
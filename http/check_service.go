@@ -867,6 +867,11 @@ type Check struct {
 	Tags                  []*influxdb.Tag   `json:"tags"`
 	StatusMessageTemplate string            `json:"statusMessageTemplate"`
 	Thresholds            []*CheckThreshold `json:"thresholds"`
+	Method                string            `json:"method,omitempty"`
+	Window                string            `json:"window,omitempty"`
+	Season                string            `json:"season,omitempty"`
+	Seasons               int               `json:"seasons,omitempty"`
+	Levels                []*AnomalyLevel   `json:"levels,omitempty"`
 }
 
 type CheckQuery struct {
@@ -899,6 +904,11 @@ type CheckLinks struct {
 	Query   string `json:"query"`
 }
 
+type AnomalyLevel struct {
+	Level       string  `json:"level"`
+	Sensitivity float64 `json:"sensitivity"`
+}
+
 type CheckThreshold struct {
 	check.ThresholdConfigBase
 	Type   string  `json:"type"`
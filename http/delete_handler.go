@@ -105,7 +105,7 @@ func (h *DeleteHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if pset, err := a.PermissionSet(); err != nil || !pset.Allowed(*p) {
+	if pset, err := a.PermissionSet(); err != nil || !pset.Covers(*p) {
 		h.HandleHTTPError(ctx, &influxdb.Error{
 			Code: influxdb.EForbidden,
 			Op:   "http/handleDelete",
@@ -0,0 +1,148 @@
+package legacy
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorization"
+	platcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/opentracing/opentracing-go"
+	"go.uber.org/zap"
+)
+
+const tokenScheme = "Token "
+
+var (
+	// ErrCredentialsMissing is returned when a request does not carry any 1.x credentials.
+	ErrCredentialsMissing = errors.New("unable to parse authentication credentials")
+)
+
+// AuthenticationHandler authenticates requests made with InfluxDB 1.x
+// credentials. A token may be supplied either as the password of the
+// u/p query parameters, as the password of HTTP basic authentication,
+// or in the Authorization header using the Token scheme.
+type AuthenticationHandler struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	AuthorizationService influxdb.AuthorizationService
+	UserService          influxdb.UserService
+
+	// UsageRecorder records the use of authorizations when set.
+	UsageRecorder *authorization.UsageRecorder
+
+	next http.Handler
+}
+
+// NewAuthenticationHandler creates an authentication handler wrapping next.
+func NewAuthenticationHandler(log *zap.Logger, next http.Handler, auth influxdb.AuthorizationService, users influxdb.UserService, h influxdb.HTTPErrorHandler) *AuthenticationHandler {
+	return &AuthenticationHandler{
+		HTTPErrorHandler:     h,
+		log:                  log,
+		AuthorizationService: auth,
+		UserService:          users,
+		next:                 next,
+	}
+}
+
+// ServeHTTP extracts the 1.x credentials from the request and places the
+// resulting authorization on the request context.
+func (h *AuthenticationHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	token, err := parseCredentials(r)
+	if err != nil {
+		h.unauthorized(ctx, w, err)
+		return
+	}
+
+	auth, err := h.AuthorizationService.FindAuthorizationByToken(ctx, token)
+	if err != nil {
+		h.unauthorized(ctx, w, err)
+		return
+	}
+
+	if !auth.IsActive() {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "authorization is inactive",
+		}, w)
+		return
+	}
+
+	if err := auth.Expired(); err != nil {
+		h.unauthorized(ctx, w, err)
+		return
+	}
+
+	if auth.GetUserID().Valid() {
+		u, err := h.UserService.FindUserByID(ctx, auth.GetUserID())
+		if err != nil {
+			h.unauthorized(ctx, w, err)
+			return
+		}
+		if u.Status == influxdb.Inactive {
+			h.HandleHTTPError(ctx, &influxdb.Error{
+				Code: influxdb.EForbidden,
+				Msg:  "User is inactive",
+			}, w)
+			return
+		}
+	}
+
+	h.UsageRecorder.Record(auth, r)
+
+	ctx = platcontext.SetAuthorizer(ctx, auth)
+	if span := opentracing.SpanFromContext(ctx); span != nil {
+		span.SetTag("user_id", auth.GetUserID().String())
+	}
+
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+
+func (h *AuthenticationHandler) unauthorized(ctx context.Context, w http.ResponseWriter, err error) {
+	h.log.Info("Unauthorized", zap.Error(err))
+	h.HandleHTTPError(ctx, &influxdb.Error{
+		Code: influxdb.EUnauthorized,
+		Msg:  "unauthorized access",
+	}, w)
+}
+
+// parseCredentials returns the token supplied with the request.
+func parseCredentials(r *http.Request) (string, error) {
+	if p := r.URL.Query().Get("p"); p != "" {
+		return p, nil
+	}
+
+	if _, p, ok := r.BasicAuth(); ok && p != "" {
+		return p, nil
+	}
+
+	if header := r.Header.Get("Authorization"); strings.HasPrefix(header, tokenScheme) {
+		if t := header[len(tokenScheme):]; t != "" {
+			return t, nil
+		}
+	}
+
+	return "", ErrCredentialsMissing
+}
+
+// authorizationFromContext returns the authorization placed on the context
+// by the AuthenticationHandler.
+func authorizationFromContext(ctx context.Context) (*influxdb.Authorization, error) {
+	a, err := platcontext.GetAuthorizer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	auth, ok := a.(*influxdb.Authorization)
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.EUnauthorized,
+			Msg:  "a token authorization is required",
+		}
+	}
+	return auth, nil
+}
@@ -0,0 +1,93 @@
+// Package legacy implements the InfluxDB 1.x compatible HTTP API.
+//
+// The /write, /query and /ping endpoints are served at the root of the
+// server. Database and retention policy parameters are resolved to a
+// bucket through the DBRP mapping service.
+package legacy
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorization"
+	"github.com/influxdata/influxdb/v2/http/metric"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	// PrefixWrite is the 1.x write endpoint.
+	PrefixWrite = "/write"
+	// PrefixQuery is the 1.x query endpoint.
+	PrefixQuery = "/query"
+	// PrefixPing is the 1.x ping endpoint.
+	PrefixPing = "/ping"
+)
+
+// IsLegacyPath reports whether path is served by the 1.x compatible API.
+func IsLegacyPath(path string) bool {
+	switch path {
+	case PrefixWrite, PrefixQuery, PrefixPing:
+		return true
+	}
+	return false
+}
+
+// Backend is all services and associated parameters required to construct
+// the legacy Handler.
+type Backend struct {
+	influxdb.HTTPErrorHandler
+	Logger             *zap.Logger
+	WriteEventRecorder metric.EventRecorder
+	QueryEventRecorder metric.EventRecorder
+
+	// MaxBatchSizeBytes is the maximum number of bytes which can be written
+	// in a single points batch.
+	MaxBatchSizeBytes int64
+
+	AuthorizationService influxdb.AuthorizationService
+	UserService          influxdb.UserService
+	BucketService        influxdb.BucketService
+	PointsWriter         storage.PointsWriter
+	ProxyQueryService    query.ProxyQueryService
+
+	// DBRPMappingService resolves database and retention policy pairs.
+	// Write and read access to the mapped buckets is verified by the
+	// handlers and by the mapping lookups of queries, so this service is
+	// expected not to perform authorization itself.
+	DBRPMappingService influxdb.DBRPMappingServiceV2
+}
+
+// Handler serves the InfluxDB 1.x compatible API.
+type Handler struct {
+	chi.Router
+}
+
+// NewHandler constructs the 1.x compatible handler. The /write and /query
+// endpoints require 1.x credentials; /ping is always accessible.
+func NewHandler(b *Backend) *Handler {
+	var usage *authorization.UsageRecorder
+	if svc, ok := b.AuthorizationService.(influxdb.AuthorizationUsageService); ok {
+		usage = authorization.NewUsageRecorder(b.Logger, svc)
+	}
+	authed := func(h http.Handler) http.Handler {
+		a := NewAuthenticationHandler(b.Logger, h, b.AuthorizationService, b.UserService, b.HTTPErrorHandler)
+		a.UsageRecorder = usage
+		return a
+	}
+
+	write := authed(NewWriteHandler(b.Logger.With(zap.String("handler", "legacy_write")), b))
+	influxql := authed(NewInfluxQLHandler(b.Logger.With(zap.String("handler", "legacy_query")), b))
+	ping := NewPingHandler()
+
+	r := chi.NewRouter()
+	r.Method(http.MethodPost, PrefixWrite, write)
+	r.Method(http.MethodGet, PrefixQuery, influxql)
+	r.Method(http.MethodPost, PrefixQuery, influxql)
+	r.Method(http.MethodGet, PrefixPing, ping)
+	r.Method(http.MethodHead, PrefixPing, ping)
+
+	return &Handler{Router: r}
+}
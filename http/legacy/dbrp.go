@@ -0,0 +1,148 @@
+package legacy
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+)
+
+// findMapping resolves the database and retention policy to a DBRP mapping
+// within the organization. An empty retention policy resolves to the
+// default mapping for the database.
+func findMapping(ctx context.Context, svc influxdb.DBRPMappingServiceV2, orgID influxdb.ID, db, rp string) (*influxdb.DBRPMappingV2, error) {
+	if db == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "database is required",
+		}
+	}
+
+	filter := influxdb.DBRPMappingFilterV2{
+		OrgID:    &orgID,
+		Database: &db,
+	}
+	if rp == "" {
+		isDefault := true
+		filter.Default = &isDefault
+	} else {
+		filter.RetentionPolicy = &rp
+	}
+
+	mappings, _, err := svc.FindMany(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(mappings) == 0 {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  fmt.Sprintf("no dbrp mapping found for database %q and retention policy %q", db, rp),
+		}
+	}
+	return mappings[0], nil
+}
+
+// dbrpMappingService adapts the organization scoped DBRP mapping service to
+// the lookup interface consumed by the InfluxQL transpiler. Only mappings to
+// buckets the authorizer in the context can read are resolved, as the
+// compiled queries read the mapped buckets by ID without further checks.
+type dbrpMappingService struct {
+	svc   influxdb.DBRPMappingServiceV2
+	orgID influxdb.ID
+}
+
+var _ influxdb.DBRPMappingService = (*dbrpMappingService)(nil)
+
+// NewDBRPMappingService returns the lookup of the DBRP mappings of the
+// organization orgID for the InfluxQL compilers. Only mappings to buckets the
+// authorizer in the context can read are resolved.
+func NewDBRPMappingService(svc influxdb.DBRPMappingServiceV2, orgID influxdb.ID) influxdb.DBRPMappingService {
+	return &dbrpMappingService{
+		svc:   svc,
+		orgID: orgID,
+	}
+}
+
+func (s *dbrpMappingService) FindBy(ctx context.Context, cluster, db, rp string) (*influxdb.DBRPMapping, error) {
+	m, err := findMapping(ctx, s.svc, s.orgID, db, rp)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := authorizer.AuthorizeRead(ctx, influxdb.BucketsResourceType, m.BucketID, m.OrganizationID); err != nil {
+		return nil, err
+	}
+	return &influxdb.DBRPMapping{
+		Cluster:         cluster,
+		Database:        m.Database,
+		RetentionPolicy: m.RetentionPolicy,
+		Default:         m.Default,
+		OrganizationID:  m.OrganizationID,
+		BucketID:        m.BucketID,
+	}, nil
+}
+
+func (s *dbrpMappingService) Find(ctx context.Context, filter influxdb.DBRPMappingFilter) (*influxdb.DBRPMapping, error) {
+	var cluster, db, rp string
+	if filter.Cluster != nil {
+		cluster = *filter.Cluster
+	}
+	if filter.Database != nil {
+		db = *filter.Database
+	}
+	if filter.RetentionPolicy != nil {
+		rp = *filter.RetentionPolicy
+	}
+	return s.FindBy(ctx, cluster, db, rp)
+}
+
+func (s *dbrpMappingService) FindMany(ctx context.Context, filter influxdb.DBRPMappingFilter, opt ...influxdb.FindOptions) ([]*influxdb.DBRPMapping, int, error) {
+	orgID := s.orgID
+	ms, _, err := s.svc.FindMany(ctx, influxdb.DBRPMappingFilterV2{
+		OrgID:           &orgID,
+		Database:        filter.Database,
+		RetentionPolicy: filter.RetentionPolicy,
+		Default:         filter.Default,
+	}, opt...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var cluster string
+	if filter.Cluster != nil {
+		cluster = *filter.Cluster
+	}
+	mappings := make([]*influxdb.DBRPMapping, 0, len(ms))
+	for _, m := range ms {
+		_, _, err := authorizer.AuthorizeRead(ctx, influxdb.BucketsResourceType, m.BucketID, m.OrganizationID)
+		if err != nil && influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			return nil, 0, err
+		}
+		if influxdb.ErrorCode(err) == influxdb.EUnauthorized {
+			continue
+		}
+		mappings = append(mappings, &influxdb.DBRPMapping{
+			Cluster:         cluster,
+			Database:        m.Database,
+			RetentionPolicy: m.RetentionPolicy,
+			Default:         m.Default,
+			OrganizationID:  m.OrganizationID,
+			BucketID:        m.BucketID,
+		})
+	}
+	return mappings, len(mappings), nil
+}
+
+func (s *dbrpMappingService) Create(ctx context.Context, dbrpMap *influxdb.DBRPMapping) error {
+	return &influxdb.Error{
+		Code: influxdb.EMethodNotAllowed,
+		Msg:  "creating dbrp mappings is not supported through the 1.x API",
+	}
+}
+
+func (s *dbrpMappingService) Delete(ctx context.Context, cluster, db, rp string) error {
+	return &influxdb.Error{
+		Code: influxdb.EMethodNotAllowed,
+		Msg:  "deleting dbrp mappings is not supported through the 1.x API",
+	}
+}
@@ -0,0 +1,223 @@
+package legacy
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http/metric"
+	"github.com/influxdata/influxdb/v2/kit/feature"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"github.com/influxdata/influxdb/v2/logger"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
+	"go.uber.org/zap"
+)
+
+// InfluxQLHandler executes InfluxQL queries received on the 1.x /query endpoint.
+type InfluxQLHandler struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	Now                func() time.Time
+	DBRPMappingService influxdb.DBRPMappingServiceV2
+	ProxyQueryService  query.ProxyQueryService
+	EventRecorder      metric.EventRecorder
+}
+
+// NewInfluxQLHandler returns a new handler for the 1.x /query endpoint.
+func NewInfluxQLHandler(log *zap.Logger, b *Backend) *InfluxQLHandler {
+	return &InfluxQLHandler{
+		HTTPErrorHandler:   b.HTTPErrorHandler,
+		log:                log,
+		Now:                time.Now,
+		DBRPMappingService: b.DBRPMappingService,
+		ProxyQueryService:  b.ProxyQueryService,
+		EventRecorder:      b.QueryEventRecorder,
+	}
+}
+
+func (h *InfluxQLHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	const op = "http/legacy/handleInfluxQL"
+	span, r := tracing.ExtractFromHTTPRequest(r, "LegacyInfluxQLHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	log := h.log.With(logger.TraceFields(ctx)...)
+
+	var orgID influxdb.ID
+	sw := kithttp.NewStatusResponseWriter(w)
+	w = sw
+	defer func() {
+		if h.EventRecorder == nil {
+			return
+		}
+		h.EventRecorder.Record(ctx, metric.Event{
+			OrgID:         orgID,
+			Endpoint:      r.URL.Path,
+			ResponseBytes: sw.ResponseBytes(),
+			Status:        sw.Code(),
+		})
+	}()
+
+	a, err := authorizationFromContext(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	orgID = a.OrgID
+
+	req, err := h.decodeQueryRequest(r, a)
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+			Op:   op,
+			Err:  err,
+		}, w)
+		return
+	}
+	req.Request.Source = r.Header.Get("User-Agent")
+
+	if err := h.authorizeDatabase(r, a); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	dialect := req.Dialect.(*influxql.Dialect)
+	dialect.SetHeaders(w)
+
+	cw := iocounter.Writer{Writer: w}
+	if _, err := h.ProxyQueryService.Query(ctx, &cw, req); err != nil {
+		if cw.Count() == 0 {
+			// Only record the error headers IFF nothing has been written to w.
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		_ = tracing.LogError(span, err)
+		log.Info("Error writing response to client",
+			zap.String("handler", "influxql"),
+			zap.Error(err),
+		)
+	}
+}
+
+// authorizeDatabase verifies that the bucket mapped to the db and rp
+// parameters of the request can be read. Databases without a mapping are
+// left for the query to report, as are databases referenced by the query
+// itself; those are authorized when the query resolves them.
+func (h *InfluxQLHandler) authorizeDatabase(r *http.Request, a *influxdb.Authorization) error {
+	db := r.FormValue("db")
+	if db == "" {
+		return nil
+	}
+	dbrpSvc := &dbrpMappingService{
+		svc:   h.DBRPMappingService,
+		orgID: a.OrgID,
+	}
+	_, err := dbrpSvc.FindBy(r.Context(), "", db, r.FormValue("rp"))
+	switch influxdb.ErrorCode(err) {
+	case "", influxdb.ENotFound:
+		return nil
+	case influxdb.EUnauthorized:
+		return &influxdb.Error{
+			Code: influxdb.EForbidden,
+			Msg:  "insufficient permissions for query",
+			Err:  err,
+		}
+	}
+	return err
+}
+
+// decodeQueryRequest translates the 1.x query parameters into a proxy request
+// for the InfluxQL transpiler.
+func (h *InfluxQLHandler) decodeQueryRequest(r *http.Request, a *influxdb.Authorization) (*query.ProxyRequest, error) {
+	q := r.FormValue("q")
+	if strings.TrimSpace(q) == "" {
+		return nil, fmt.Errorf(`missing required parameter "q"`)
+	}
+
+	timeFormat, err := parseEpoch(r.FormValue("epoch"))
+	if err != nil {
+		return nil, err
+	}
+
+	encoding := influxql.JSON
+	if accept := r.Header.Get("Accept"); accept != "" {
+		for _, t := range strings.Split(accept, ",") {
+			mt, _, err := mime.ParseMediaType(strings.TrimSpace(t))
+			if err != nil {
+				continue
+			}
+			if mt == "application/csv" || mt == "text/csv" {
+				encoding = influxql.CSV
+				break
+			}
+		}
+	}
+	if encoding == influxql.JSON && r.FormValue("pretty") == "true" {
+		encoding = influxql.JSONPretty
+	}
+
+	now := h.Now()
+	dbrpSvc := &dbrpMappingService{
+		svc:   h.DBRPMappingService,
+		orgID: a.OrgID,
+	}
+
+	var compiler flux.Compiler
+	if feature.NativeInfluxqlEngine().Enabled(r.Context()) {
+		c := native.NewCompiler(dbrpSvc)
+		c.DB = r.FormValue("db")
+		c.RP = r.FormValue("rp")
+		c.Query = q
+		c.Now = &now
+		compiler = c
+	} else {
+		c := influxql.NewCompiler(dbrpSvc)
+		c.DB = r.FormValue("db")
+		c.RP = r.FormValue("rp")
+		c.Query = q
+		c.Now = &now
+		compiler = c
+	}
+
+	return &query.ProxyRequest{
+		Request: query.Request{
+			Authorization:  a,
+			OrganizationID: a.OrgID,
+			Compiler:       compiler,
+		},
+		Dialect: &influxql.Dialect{
+			TimeFormat: timeFormat,
+			Encoding:   encoding,
+		},
+	}, nil
+}
+
+func parseEpoch(epoch string) (influxql.TimeFormat, error) {
+	switch epoch {
+	case "":
+		return influxql.RFC3339Nano, nil
+	case "h":
+		return influxql.Hour, nil
+	case "m":
+		return influxql.Minute, nil
+	case "s":
+		return influxql.Second, nil
+	case "ms":
+		return influxql.Millisecond, nil
+	case "u", "µ":
+		return influxql.Microsecond, nil
+	case "n", "ns":
+		return influxql.Nanosecond, nil
+	}
+	return 0, fmt.Errorf("invalid epoch %q", epoch)
+}
@@ -0,0 +1,248 @@
+package legacy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb/v2"
+	platcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/kit/feature"
+	"github.com/influxdata/influxdb/v2/kit/feature/override"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
+	querymock "github.com/influxdata/influxdb/v2/query/mock"
+)
+
+func TestInfluxQLHandler(t *testing.T) {
+	tests := []struct {
+		name        string
+		auth        *influxdb.Authorization
+		url         string
+		accept      string
+		code        int
+		contentType string
+		db, rp      string
+		encoding    influxql.EncodingFormat
+		timeFormat  influxql.TimeFormat
+	}{
+		{
+			name:        "json with epoch",
+			auth:        bucketReadAuthorization(testOrgID, testBucketID),
+			url:         "/query?db=telegraf&epoch=s&q=SELECT+*+FROM+cpu&p=" + testToken,
+			code:        http.StatusOK,
+			contentType: "application/json",
+			db:          "telegraf",
+			encoding:    influxql.JSON,
+			timeFormat:  influxql.Second,
+		},
+		{
+			name:        "csv",
+			auth:        bucketReadAuthorization(testOrgID, testBucketID),
+			url:         "/query?db=telegraf&rp=autogen&q=SELECT+*+FROM+cpu&p=" + testToken,
+			accept:      "application/csv",
+			code:        http.StatusOK,
+			contentType: "text/csv",
+			db:          "telegraf",
+			rp:          "autogen",
+			encoding:    influxql.CSV,
+		},
+		{
+			name: "missing query",
+			auth: bucketReadAuthorization(testOrgID, testBucketID),
+			url:  "/query?db=telegraf&p=" + testToken,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "invalid epoch",
+			auth: bucketReadAuthorization(testOrgID, testBucketID),
+			url:  "/query?db=telegraf&epoch=d&q=SELECT+*+FROM+cpu&p=" + testToken,
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "write only token",
+			auth: bucketWriteAuthorization(testOrgID, testBucketID),
+			url:  "/query?db=telegraf&q=SELECT+*+FROM+cpu&p=" + testToken,
+			code: http.StatusForbidden,
+		},
+		{
+			name: "token for another bucket",
+			auth: bucketReadAuthorization(testOrgID, testBucketID+1),
+			url:  "/query?db=telegraf&q=SELECT+*+FROM+cpu&p=" + testToken,
+			code: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *query.ProxyRequest
+			b := newTestBackend(t, tt.auth, &mock.PointsWriter{})
+			b.ProxyQueryService = &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+					got = req
+					_, err := io.WriteString(w, `{"results":[]}`)
+					return flux.Statistics{}, err
+				},
+			}
+			h := NewHandler(b)
+
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.accept != "" {
+				r.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.code {
+				t.Fatalf("unexpected status code: got %d want %d, body: %s", w.Code, tt.code, w.Body.String())
+			}
+			if tt.code != http.StatusOK {
+				return
+			}
+
+			if ct := w.Header().Get("Content-Type"); ct != tt.contentType {
+				t.Errorf("unexpected content type: got %q want %q", ct, tt.contentType)
+			}
+			if got.Request.OrganizationID != testOrgID {
+				t.Errorf("unexpected organization: got %s want %s", got.Request.OrganizationID, testOrgID)
+			}
+
+			c := got.Request.Compiler.(*influxql.Compiler)
+			if c.DB != tt.db || c.RP != tt.rp {
+				t.Errorf("unexpected db/rp: got %s/%s want %s/%s", c.DB, c.RP, tt.db, tt.rp)
+			}
+
+			d := got.Dialect.(*influxql.Dialect)
+			if d.Encoding != tt.encoding || d.TimeFormat != tt.timeFormat {
+				t.Errorf("unexpected dialect: got %+v", d)
+			}
+		})
+	}
+}
+
+func TestInfluxQLHandler_NativeEngine(t *testing.T) {
+	var got *query.ProxyRequest
+	b := newTestBackend(t, bucketReadAuthorization(testOrgID, testBucketID), &mock.PointsWriter{})
+	b.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+			got = req
+			_, err := io.WriteString(w, `{"results":[]}`)
+			return flux.Statistics{}, err
+		},
+	}
+	h := NewHandler(b)
+
+	flagger, err := override.Make(map[string]string{
+		feature.NativeInfluxqlEngine().Key(): "true",
+	}, feature.ByKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := feature.Annotate(context.Background(), flagger, feature.NativeInfluxqlEngine())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/query?db=telegraf&q=SELECT+*+FROM+cpu&p="+testToken, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r.WithContext(ctx))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("unexpected status code: got %d want %d, body: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	c, ok := got.Request.Compiler.(*native.Compiler)
+	if !ok {
+		t.Fatalf("unexpected compiler type: got %T", got.Request.Compiler)
+	}
+	if c.DB != "telegraf" || c.Query != "SELECT * FROM cpu" {
+		t.Errorf("unexpected compiler: got %+v", c)
+	}
+}
+
+func TestInfluxQLHandler_NativeEngineWriteOnlyToken(t *testing.T) {
+	b := newTestBackend(t, bucketWriteAuthorization(testOrgID, testBucketID), &mock.PointsWriter{})
+	b.ProxyQueryService = &querymock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+			t.Fatal("query of a write only token was executed")
+			return flux.Statistics{}, nil
+		},
+	}
+	h := NewHandler(b)
+
+	flagger, err := override.Make(map[string]string{
+		feature.NativeInfluxqlEngine().Key(): "true",
+	}, feature.ByKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := feature.Annotate(context.Background(), flagger, feature.NativeInfluxqlEngine())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/query?db=telegraf&q=SELECT+*+FROM+cpu&p="+testToken, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r.WithContext(ctx))
+
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("unexpected status code: got %d want %d, body: %s", w.Code, http.StatusForbidden, w.Body.String())
+	}
+}
+
+func TestDBRPMappingService_FindBy(t *testing.T) {
+	b := newTestBackend(t, nil, &mock.PointsWriter{})
+	svc := &dbrpMappingService{svc: b.DBRPMappingService, orgID: testOrgID}
+	ctx := platcontext.SetAuthorizer(context.Background(), bucketReadAuthorization(testOrgID, testBucketID))
+
+	m, err := svc.FindBy(ctx, "", "telegraf", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.BucketID != testBucketID {
+		t.Errorf("unexpected bucket: got %s want %s", m.BucketID, testBucketID)
+	}
+
+	if _, err := svc.FindBy(ctx, "", "telegraf", "other"); influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Errorf("expected not found error, got %v", err)
+	}
+}
+
+func TestDBRPMappingService_Unreadable(t *testing.T) {
+	b := newTestBackend(t, nil, &mock.PointsWriter{})
+	svc := &dbrpMappingService{svc: b.DBRPMappingService, orgID: testOrgID}
+
+	for _, auth := range []*influxdb.Authorization{
+		bucketWriteAuthorization(testOrgID, testBucketID),
+		bucketReadAuthorization(testOrgID, testBucketID+1),
+	} {
+		ctx := platcontext.SetAuthorizer(context.Background(), auth)
+
+		if _, err := svc.FindBy(ctx, "", "telegraf", ""); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+			t.Errorf("expected unauthorized error, got %v", err)
+		}
+
+		db := "telegraf"
+		ms, _, err := svc.FindMany(ctx, influxdb.DBRPMappingFilter{Database: &db})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(ms) != 0 {
+			t.Errorf("expected no readable mappings, got %d", len(ms))
+		}
+	}
+}
+
+func bucketReadAuthorization(orgID, bucketID influxdb.ID) *influxdb.Authorization {
+	p, _ := influxdb.NewPermissionAtID(bucketID, influxdb.ReadAction, influxdb.BucketsResourceType, orgID)
+	return &influxdb.Authorization{
+		ID:          1,
+		OrgID:       orgID,
+		Status:      influxdb.Active,
+		Permissions: []influxdb.Permission{*p},
+	}
+}
@@ -0,0 +1,35 @@
+package legacy
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+// PingHandler answers 1.x ping requests.
+type PingHandler struct{}
+
+// NewPingHandler returns a handler for /ping.
+func NewPingHandler() *PingHandler {
+	return &PingHandler{}
+}
+
+// ServeHTTP responds with the version headers expected by 1.x clients.
+func (h *PingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	info := influxdb.GetBuildInfo()
+	w.Header().Set("X-Influxdb-Build", "OSS")
+	w.Header().Set("X-Influxdb-Version", info.Version)
+
+	if r.URL.Query().Get("verbose") == "true" && r.Method == http.MethodGet {
+		writeJSON(w, http.StatusOK, map[string]string{"version": info.Version})
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
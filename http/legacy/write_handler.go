@@ -0,0 +1,237 @@
+package legacy
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	"github.com/influxdata/influxdb/v2/http/metric"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/tsdb"
+	"go.uber.org/zap"
+)
+
+// ErrMaxBatchSizeExceeded is returned when a points batch exceeds
+// the defined upper limit in bytes.
+var ErrMaxBatchSizeExceeded = errors.New("points batch is too large")
+
+// WriteHandler receives line protocol on the 1.x /write endpoint.
+type WriteHandler struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	BucketService      influxdb.BucketService
+	DBRPMappingService influxdb.DBRPMappingServiceV2
+	PointsWriter       storage.PointsWriter
+	EventRecorder      metric.EventRecorder
+
+	maxBatchSizeBytes int64
+}
+
+// NewWriteHandler creates a new handler for the 1.x /write endpoint.
+func NewWriteHandler(log *zap.Logger, b *Backend) *WriteHandler {
+	return &WriteHandler{
+		HTTPErrorHandler:   b.HTTPErrorHandler,
+		log:                log,
+		BucketService:      b.BucketService,
+		DBRPMappingService: b.DBRPMappingService,
+		PointsWriter:       b.PointsWriter,
+		EventRecorder:      b.WriteEventRecorder,
+		maxBatchSizeBytes:  b.MaxBatchSizeBytes,
+	}
+}
+
+func (h *WriteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "LegacyWriteHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	defer r.Body.Close()
+
+	var (
+		orgID        influxdb.ID
+		requestBytes int
+		sw           = kithttp.NewStatusResponseWriter(w)
+		handleError  = func(err error, code, message string) {
+			h.HandleHTTPError(ctx, &influxdb.Error{
+				Code: code,
+				Op:   "http/legacy/handleWrite",
+				Msg:  message,
+				Err:  err,
+			}, w)
+		}
+	)
+	w = sw
+	defer func() {
+		if h.EventRecorder == nil {
+			return
+		}
+		h.EventRecorder.Record(ctx, metric.Event{
+			OrgID:         orgID,
+			Endpoint:      r.URL.Path,
+			RequestBytes:  requestBytes,
+			ResponseBytes: sw.ResponseBytes(),
+			Status:        sw.Code(),
+		})
+	}()
+
+	a, err := authorizationFromContext(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	orgID = a.OrgID
+
+	req, err := decodeWriteRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	log := h.log.With(zap.String("db", req.Database), zap.String("rp", req.RetentionPolicy))
+
+	mapping, err := findMapping(ctx, h.DBRPMappingService, orgID, req.Database, req.RetentionPolicy)
+	if err != nil {
+		log.Info("Failed to find dbrp mapping", zap.Error(err))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	bucket, err := h.BucketService.FindBucketByID(ctx, mapping.BucketID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	span.LogKV("org_id", orgID, "bucket_id", bucket.ID)
+
+	p, err := influxdb.NewPermissionAtID(bucket.ID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)
+	if err != nil {
+		handleError(err, influxdb.EInternal, fmt.Sprintf("unable to create permission for bucket: %v", err))
+		return
+	}
+	if pset, err := a.PermissionSet(); err != nil || !pset.Allowed(*p) {
+		handleError(nil, influxdb.EForbidden, "insufficient permissions for write")
+		return
+	}
+
+	data, err := readWriteRequest(ctx, r.Body, r.Header.Get("Content-Encoding"), h.maxBatchSizeBytes)
+	if err != nil {
+		log.Error("Error reading body", zap.Error(err))
+
+		code := influxdb.EInternal
+		if errors.Is(err, ErrMaxBatchSizeExceeded) {
+			code = influxdb.ETooLarge
+		} else if errors.Is(err, gzip.ErrHeader) || errors.Is(err, gzip.ErrChecksum) {
+			code = influxdb.EInvalid
+		}
+
+		handleError(err, code, "unable to read data")
+		return
+	}
+
+	requestBytes = len(data)
+	if requestBytes == 0 {
+		handleError(nil, influxdb.EInvalid, "writing requires points")
+		return
+	}
+
+	encoded := tsdb.EncodeName(orgID, bucket.ID)
+	mm := models.EscapeMeasurement(encoded[:])
+
+	var options []models.ParserOption
+	if req.Precision != "" {
+		options = append(options, models.WithParserPrecision(req.Precision))
+	}
+
+	points, err := models.ParsePointsWithOptions(data, mm, options...)
+	if err != nil {
+		log.Error("Error parsing points", zap.Error(err))
+		handleError(err, influxdb.EInvalid, "")
+		return
+	}
+
+	if err := authorizer.AuthorizeWritePoints(ctx, points); err != nil {
+		handleError(err, influxdb.EForbidden, "insufficient permissions for write")
+		return
+	}
+
+	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
+		log.Error("Error writing points", zap.Error(err))
+		handleError(err, influxdb.EInternal, "unexpected error writing points to database")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+type writeRequest struct {
+	Database        string
+	RetentionPolicy string
+	Precision       string
+}
+
+func decodeWriteRequest(r *http.Request) (*writeRequest, error) {
+	qp := r.URL.Query()
+
+	// 1.x clients may use the single letter n and u precisions.
+	precision := qp.Get("precision")
+	switch precision {
+	case "", "n", "ns":
+		precision = ""
+	case "u":
+		precision = "us"
+	}
+	if precision != "" && !models.ValidPrecision(precision) {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Op:   "http/legacy/decodeWriteRequest",
+			Msg:  "invalid precision; valid precision units are n, ns, u, us, ms, and s",
+		}
+	}
+
+	return &writeRequest{
+		Database:        qp.Get("db"),
+		RetentionPolicy: qp.Get("rp"),
+		Precision:       precision,
+	}, nil
+}
+
+func readWriteRequest(ctx context.Context, rc io.ReadCloser, encoding string, maxBatchSizeBytes int64) ([]byte, error) {
+	span, _ := tracing.StartSpanFromContextWithOperationName(ctx, "read request body")
+	defer span.Finish()
+
+	var r io.Reader = rc
+	switch encoding {
+	case "gzip", "x-gzip":
+		gr, err := gzip.NewReader(rc)
+		if err != nil {
+			return nil, err
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	if maxBatchSizeBytes <= 0 {
+		return ioutil.ReadAll(r)
+	}
+
+	// read up to max + 1 so we know when the limit has been exceeded.
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxBatchSizeBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBatchSizeBytes {
+		return nil, ErrMaxBatchSizeExceeded
+	}
+	span.LogKV("request_bytes", len(data))
+	return data, nil
+}
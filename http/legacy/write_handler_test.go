@@ -0,0 +1,171 @@
+package legacy
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"github.com/influxdata/influxdb/v2/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	testOrgID    = influxdb.ID(0x043e0780ee2b1000)
+	testBucketID = influxdb.ID(0x04504b356e23b000)
+	testToken    = "secret-token"
+)
+
+func newTestBackend(t *testing.T, auth *influxdb.Authorization, pw *mock.PointsWriter) *Backend {
+	t.Helper()
+
+	authSvc := mock.NewAuthorizationService()
+	authSvc.FindAuthorizationByTokenFn = func(ctx context.Context, token string) (*influxdb.Authorization, error) {
+		if token != testToken {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "authorization not found"}
+		}
+		return auth, nil
+	}
+
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketByIDFn = func(ctx context.Context, id influxdb.ID) (*influxdb.Bucket, error) {
+		return &influxdb.Bucket{ID: id, OrgID: testOrgID, Name: "telegraf"}, nil
+	}
+
+	dbrpSvc := &mock.DBRPMappingServiceV2{
+		FindManyFn: func(ctx context.Context, f influxdb.DBRPMappingFilterV2, opts ...influxdb.FindOptions) ([]*influxdb.DBRPMappingV2, int, error) {
+			if *f.OrgID != testOrgID || *f.Database != "telegraf" {
+				return nil, 0, nil
+			}
+			if f.RetentionPolicy != nil && *f.RetentionPolicy != "autogen" {
+				return nil, 0, nil
+			}
+			return []*influxdb.DBRPMappingV2{{
+				ID:              1,
+				Database:        "telegraf",
+				RetentionPolicy: "autogen",
+				Default:         true,
+				OrganizationID:  testOrgID,
+				BucketID:        testBucketID,
+			}}, 1, nil
+		},
+	}
+
+	return &Backend{
+		HTTPErrorHandler:     kithttp.ErrorHandler(0),
+		Logger:               zaptest.NewLogger(t),
+		AuthorizationService: authSvc,
+		UserService:          mock.NewUserService(),
+		BucketService:        bucketSvc,
+		PointsWriter:         pw,
+		DBRPMappingService:   dbrpSvc,
+	}
+}
+
+func bucketWriteAuthorization(orgID, bucketID influxdb.ID) *influxdb.Authorization {
+	p, _ := influxdb.NewPermissionAtID(bucketID, influxdb.WriteAction, influxdb.BucketsResourceType, orgID)
+	return &influxdb.Authorization{
+		ID:          1,
+		OrgID:       orgID,
+		Status:      influxdb.Active,
+		Permissions: []influxdb.Permission{*p},
+	}
+}
+
+func TestWriteHandler(t *testing.T) {
+	tests := []struct {
+		name   string
+		auth   *influxdb.Authorization
+		url    string
+		header http.Header
+		body   string
+		code   int
+		points int
+	}{
+		{
+			name:   "token in query params",
+			auth:   bucketWriteAuthorization(testOrgID, testBucketID),
+			url:    "/write?db=telegraf&u=me&p=" + testToken,
+			body:   "m1,t1=v1 f1=1",
+			code:   http.StatusNoContent,
+			points: 1,
+		},
+		{
+			name:   "token in header with rp and precision",
+			auth:   bucketWriteAuthorization(testOrgID, testBucketID),
+			url:    "/write?db=telegraf&rp=autogen&precision=s",
+			header: http.Header{"Authorization": []string{"Token " + testToken}},
+			body:   "m1,t1=v1 f1=1 1577836800",
+			code:   http.StatusNoContent,
+			points: 1,
+		},
+		{
+			name: "missing credentials",
+			auth: bucketWriteAuthorization(testOrgID, testBucketID),
+			url:  "/write?db=telegraf",
+			body: "m1,t1=v1 f1=1",
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "unknown database",
+			auth: bucketWriteAuthorization(testOrgID, testBucketID),
+			url:  "/write?db=unknown&p=" + testToken,
+			body: "m1,t1=v1 f1=1",
+			code: http.StatusNotFound,
+		},
+		{
+			name: "insufficient permissions",
+			auth: bucketWriteAuthorization(testOrgID, influxdb.ID(0x04504b356e23b001)),
+			url:  "/write?db=telegraf&p=" + testToken,
+			body: "m1,t1=v1 f1=1",
+			code: http.StatusForbidden,
+		},
+		{
+			name: "invalid precision",
+			auth: bucketWriteAuthorization(testOrgID, testBucketID),
+			url:  "/write?db=telegraf&precision=h&p=" + testToken,
+			body: "m1,t1=v1 f1=1",
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{}
+			h := NewHandler(newTestBackend(t, tt.auth, pw))
+
+			r := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				r.Header[k] = v
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.code; got != want {
+				t.Fatalf("unexpected status code: got %d want %d, body: %s", got, want, w.Body.String())
+			}
+			if got, want := len(pw.Points), tt.points; got != want {
+				t.Errorf("unexpected number of points written: got %d want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestPingHandler(t *testing.T) {
+	influxdb.SetBuildInfo("2.0.0", "abc", "now")
+	h := NewHandler(newTestBackend(t, nil, &mock.PointsWriter{}))
+
+	r := httptest.NewRequest(http.MethodGet, "/ping", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	if got, want := w.Code, http.StatusNoContent; got != want {
+		t.Fatalf("unexpected status code: got %d want %d", got, want)
+	}
+	if got, want := w.Header().Get("X-Influxdb-Version"), "2.0.0"; got != want {
+		t.Errorf("unexpected version header: got %q want %q", got, want)
+	}
+}
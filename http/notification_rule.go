@@ -37,6 +37,8 @@ type NotificationRuleBackend struct {
 	UserService                 influxdb.UserService
 	OrganizationService         influxdb.OrganizationService
 	TaskService                 influxdb.TaskService
+	SilenceService              influxdb.SilenceService
+	AlertAcknowledgementService influxdb.AlertAcknowledgementService
 }
 
 // NewNotificationRuleBackend returns a new instance of NotificationRuleBackend.
@@ -53,6 +55,8 @@ func NewNotificationRuleBackend(log *zap.Logger, b *APIBackend) *NotificationRul
 		UserService:                 b.UserService,
 		OrganizationService:         b.OrganizationService,
 		TaskService:                 b.TaskService,
+		SilenceService:              b.SilenceService,
+		AlertAcknowledgementService: b.AlertAcknowledgementService,
 	}
 }
 
@@ -69,6 +73,8 @@ type NotificationRuleHandler struct {
 	UserService                 influxdb.UserService
 	OrganizationService         influxdb.OrganizationService
 	TaskService                 influxdb.TaskService
+	SilenceService              influxdb.SilenceService
+	AlertAcknowledgementService influxdb.AlertAcknowledgementService
 }
 
 const (
@@ -97,6 +103,8 @@ func NewNotificationRuleHandler(log *zap.Logger, b *NotificationRuleBackend) *No
 		UserService:                 b.UserService,
 		OrganizationService:         b.OrganizationService,
 		TaskService:                 b.TaskService,
+		SilenceService:              b.SilenceService,
+		AlertAcknowledgementService: b.AlertAcknowledgementService,
 	}
 
 	h.Handler("POST", prefixNotificationRules, withFeatureProxy(b.AlgoWProxy, http.HandlerFunc(h.handlePostNotificationRule)))
@@ -310,7 +318,22 @@ func (h *NotificationRuleHandler) handleGetNotificationRuleQuery(w http.Response
 		}, w)
 		return
 	}
-	flux, err := nr.GenerateFlux(edp)
+	silences, err := h.findUnexpiredSilences(ctx, nr.GetOrgID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	acks, err := h.findAlertAcknowledgements(ctx, nr.GetOrgID())
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	escalations, err := h.findEscalationEndpoints(ctx, nr)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	flux, err := nr.GenerateFlux(edp, escalations, silences, acks)
 	if err != nil {
 		h.HandleHTTPError(ctx, err, w)
 		return
@@ -322,6 +345,53 @@ func (h *NotificationRuleHandler) handleGetNotificationRuleQuery(w http.Response
 	}
 }
 
+// findUnexpiredSilences returns the silences of the org that the notification
+// rule query has to consult, that is those active now or in the future.
+func (h *NotificationRuleHandler) findUnexpiredSilences(ctx context.Context, orgID influxdb.ID) ([]*influxdb.Silence, error) {
+	if h.SilenceService == nil {
+		return nil, nil
+	}
+	silences, _, err := h.SilenceService.FindSilences(ctx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var unexpired []*influxdb.Silence
+	for _, sil := range silences {
+		if !sil.Expired(now) {
+			unexpired = append(unexpired, sil)
+		}
+	}
+	return unexpired, nil
+}
+
+// findAlertAcknowledgements returns the alert acknowledgements of the org.
+func (h *NotificationRuleHandler) findAlertAcknowledgements(ctx context.Context, orgID influxdb.ID) ([]*influxdb.AlertAcknowledgement, error) {
+	if h.AlertAcknowledgementService == nil {
+		return nil, nil
+	}
+	acks, _, err := h.AlertAcknowledgementService.FindAlertAcknowledgements(ctx, influxdb.AlertAcknowledgementFilter{OrgID: &orgID})
+	return acks, err
+}
+
+func (h *NotificationRuleHandler) findEscalationEndpoints(ctx context.Context, nr influxdb.NotificationRule) ([]influxdb.NotificationEndpoint, error) {
+	ids := nr.GetEscalationEndpointIDs()
+	escalations := make([]influxdb.NotificationEndpoint, 0, len(ids))
+	for _, id := range ids {
+		edp, err := h.NotificationEndpointService.FindNotificationEndpointByID(ctx, id)
+		if err != nil {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInternal,
+				Op:   "http/handleGetNotificationRuleQuery",
+				Err:  err,
+			}
+		}
+		escalations = append(escalations, edp)
+	}
+	return escalations, nil
+}
+
 func (h *NotificationRuleHandler) handleGetNotificationRule(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := decodeGetNotificationRuleRequest(ctx, r)
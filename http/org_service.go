@@ -27,6 +27,7 @@ type OrgBackend struct {
 	SecretService                   influxdb.SecretService
 	LabelService                    influxdb.LabelService
 	UserService                     influxdb.UserService
+	OrganizationSettingsService     influxdb.OrganizationSettingsService
 }
 
 // NewOrgBackend is a datasource used by the org handler.
@@ -41,6 +42,7 @@ func NewOrgBackend(log *zap.Logger, b *APIBackend) *OrgBackend {
 		SecretService:                   b.SecretService,
 		LabelService:                    b.LabelService,
 		UserService:                     b.UserService,
+		OrganizationSettingsService:     b.OrganizationSettingsService,
 	}
 }
 
@@ -56,6 +58,7 @@ type OrgHandler struct {
 	SecretService                   influxdb.SecretService
 	LabelService                    influxdb.LabelService
 	UserService                     influxdb.UserService
+	OrganizationSettingsService     influxdb.OrganizationSettingsService
 }
 
 const (
@@ -71,6 +74,7 @@ const (
 	organizationsIDSecretsDeletePath = "/api/v2/orgs/:id/secrets/delete"
 	organizationsIDLabelsPath        = "/api/v2/orgs/:id/labels"
 	organizationsIDLabelsIDPath      = "/api/v2/orgs/:id/labels/:lid"
+	organizationsIDSettingsPath      = "/api/v2/orgs/:id/settings"
 )
 
 func checkOrganizationExists(orgHandler *OrgHandler) kithttp.Middleware {
@@ -107,6 +111,7 @@ func NewOrgHandler(log *zap.Logger, b *OrgBackend) *OrgHandler {
 		SecretService:                   b.SecretService,
 		LabelService:                    b.LabelService,
 		UserService:                     b.UserService,
+		OrganizationSettingsService:     b.OrganizationSettingsService,
 	}
 
 	h.HandlerFunc("POST", prefixOrganizations, h.handlePostOrg)
@@ -145,6 +150,9 @@ func NewOrgHandler(log *zap.Logger, b *OrgBackend) *OrgHandler {
 	// TODO(desa): need a way to specify which secrets to delete. this should work for now
 	h.HandlerFunc("POST", organizationsIDSecretsDeletePath, h.handleDeleteSecrets)
 
+	h.Handler("GET", organizationsIDSettingsPath, applyMW(http.HandlerFunc(h.handleGetOrgSettings), checkOrganizationExists(h)))
+	h.HandlerFunc("PATCH", organizationsIDSettingsPath, h.handlePatchOrgSettings)
+
 	labelBackend := &LabelBackend{
 		HTTPErrorHandler: b.HTTPErrorHandler,
 		log:              b.log.With(zap.String("handler", "label")),
@@ -402,6 +410,63 @@ func (h *OrgHandler) handleDeleteSecrets(w http.ResponseWriter, r *http.Request)
 	h.API.Respond(w, r, http.StatusNoContent, nil)
 }
 
+type orgSettingsResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.OrganizationSettings
+}
+
+func newOrgSettingsResponse(s influxdb.OrganizationSettings) *orgSettingsResponse {
+	return &orgSettingsResponse{
+		Links: map[string]string{
+			"org":  fmt.Sprintf("/api/v2/orgs/%s", s.OrgID),
+			"self": fmt.Sprintf("/api/v2/orgs/%s/settings", s.OrgID),
+		},
+		OrganizationSettings: s,
+	}
+}
+
+// handleGetOrgSettings is the HTTP handler for the GET /api/v2/orgs/:id/settings route.
+func (h *OrgHandler) handleGetOrgSettings(w http.ResponseWriter, r *http.Request) {
+	orgID, err := decodeIDFromCtx(r.Context(), "id")
+	if err != nil {
+		h.API.Err(w, r, err)
+		return
+	}
+
+	settings, err := h.OrganizationSettingsService.FindOrganizationSettings(r.Context(), orgID)
+	if err != nil {
+		h.API.Err(w, r, err)
+		return
+	}
+	h.log.Debug("Org settings retrieved", zap.String("settings", fmt.Sprint(settings)))
+
+	h.API.Respond(w, r, http.StatusOK, newOrgSettingsResponse(*settings))
+}
+
+// handlePatchOrgSettings is the HTTP handler for the PATCH /api/v2/orgs/:id/settings route.
+func (h *OrgHandler) handlePatchOrgSettings(w http.ResponseWriter, r *http.Request) {
+	orgID, err := decodeIDFromCtx(r.Context(), "id")
+	if err != nil {
+		h.API.Err(w, r, err)
+		return
+	}
+
+	var upd influxdb.OrganizationSettingsUpdate
+	if err := h.API.DecodeJSON(r.Body, &upd); err != nil {
+		h.API.Err(w, r, err)
+		return
+	}
+
+	settings, err := h.OrganizationSettingsService.UpdateOrganizationSettings(r.Context(), orgID, upd)
+	if err != nil {
+		h.API.Err(w, r, err)
+		return
+	}
+	h.log.Debug("Org settings updated", zap.String("settings", fmt.Sprint(settings)))
+
+	h.API.Respond(w, r, http.StatusOK, newOrgSettingsResponse(*settings))
+}
+
 // hanldeGetOrganizationLog retrieves a organization log by the organizations ID.
 func (h *OrgHandler) handleGetOrgLog(w http.ResponseWriter, r *http.Request) {
 	orgID, err := decodeIDFromCtx(r.Context(), "id")
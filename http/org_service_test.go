@@ -30,6 +30,7 @@ func NewMockOrgBackend(t *testing.T) *OrgBackend {
 		SecretService:                   mock.NewSecretService(),
 		LabelService:                    mock.NewLabelService(),
 		UserService:                     mock.NewUserService(),
+		OrganizationSettingsService:     mock.NewOrganizationSettingsService(),
 	}
 }
 
@@ -416,3 +417,227 @@ func TestSecretService_handleDeleteSecrets(t *testing.T) {
 		})
 	}
 }
+
+func TestOrgHandler_handleGetOrgSettings(t *testing.T) {
+	type fields struct {
+		OrganizationService         influxdb.OrganizationService
+		OrganizationSettingsService influxdb.OrganizationSettingsService
+	}
+	type args struct {
+		orgID influxdb.ID
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "get settings",
+			fields: fields{
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+						return &influxdb.Organization{ID: id, Name: "org"}, nil
+					},
+				},
+				OrganizationSettingsService: &mock.OrganizationSettingsService{
+					FindOrganizationSettingsFn: func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+						return &influxdb.OrganizationSettings{
+							OrgID:                 orgID,
+							QueryConcurrencyQuota: 2,
+							QueryWeight:           3,
+						}, nil
+					},
+				},
+			},
+			args: args{
+				orgID: 1,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "org": "/api/v2/orgs/0000000000000001",
+    "self": "/api/v2/orgs/0000000000000001/settings"
+  },
+  "orgID": "0000000000000001",
+  "queryConcurrencyQuota": 2,
+  "queryQueueSize": 0,
+  "queryMemoryBytesQuota": 0,
+  "queryWeight": 3
+}
+`,
+			},
+		},
+		{
+			name: "get settings of missing organization",
+			fields: fields{
+				OrganizationService: &mock.OrganizationService{
+					FindOrganizationByIDF: func(ctx context.Context, id influxdb.ID) (*influxdb.Organization, error) {
+						return nil, &influxdb.Error{
+							Code: influxdb.ENotFound,
+							Msg:  "organization not found",
+						}
+					},
+				},
+				OrganizationSettingsService: mock.NewOrganizationSettingsService(),
+			},
+			args: args{
+				orgID: 1,
+			},
+			wants: wants{
+				statusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgBackend := NewMockOrgBackend(t)
+			orgBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			orgBackend.OrganizationService = tt.fields.OrganizationService
+			orgBackend.OrganizationSettingsService = tt.fields.OrganizationSettingsService
+			h := NewOrgHandler(zaptest.NewLogger(t), orgBackend)
+
+			u := fmt.Sprintf("http://any.url/api/v2/orgs/%s/settings", tt.args.orgID)
+			r := httptest.NewRequest("GET", u, nil)
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("handleGetOrgSettings() = %v, want %v", res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("handleGetOrgSettings() = %v, want %v", content, tt.wants.contentType)
+			}
+			if tt.wants.body != "" {
+				if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+					t.Errorf("%q, handleGetOrgSettings(). error unmarshaling json %v", tt.name, err)
+				} else if !eq {
+					t.Errorf("%q. handleGetOrgSettings() = ***%s***", tt.name, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestOrgHandler_handlePatchOrgSettings(t *testing.T) {
+	type fields struct {
+		OrganizationSettingsService influxdb.OrganizationSettingsService
+	}
+	type args struct {
+		orgID influxdb.ID
+		body  string
+	}
+	type wants struct {
+		statusCode  int
+		contentType string
+		body        string
+	}
+
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		wants  wants
+	}{
+		{
+			name: "update settings",
+			fields: fields{
+				&mock.OrganizationSettingsService{
+					UpdateOrganizationSettingsFn: func(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+						settings := &influxdb.OrganizationSettings{OrgID: orgID, QueryWeight: 3}
+						upd.Apply(settings)
+						return settings, nil
+					},
+				},
+			},
+			args: args{
+				orgID: 1,
+				body:  `{"queryMemoryBytesQuota": 1024}`,
+			},
+			wants: wants{
+				statusCode:  http.StatusOK,
+				contentType: "application/json; charset=utf-8",
+				body: `
+{
+  "links": {
+    "org": "/api/v2/orgs/0000000000000001",
+    "self": "/api/v2/orgs/0000000000000001/settings"
+  },
+  "orgID": "0000000000000001",
+  "queryConcurrencyQuota": 0,
+  "queryQueueSize": 0,
+  "queryMemoryBytesQuota": 1024,
+  "queryWeight": 3
+}
+`,
+			},
+		},
+		{
+			name: "update with invalid settings",
+			fields: fields{
+				&mock.OrganizationSettingsService{
+					UpdateOrganizationSettingsFn: func(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+						settings := &influxdb.OrganizationSettings{OrgID: orgID}
+						upd.Apply(settings)
+						return nil, settings.Valid()
+					},
+				},
+			},
+			args: args{
+				orgID: 1,
+				body:  `{"queryWeight": -1}`,
+			},
+			wants: wants{
+				statusCode: http.StatusBadRequest,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orgBackend := NewMockOrgBackend(t)
+			orgBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+			orgBackend.OrganizationSettingsService = tt.fields.OrganizationSettingsService
+			h := NewOrgHandler(zaptest.NewLogger(t), orgBackend)
+
+			u := fmt.Sprintf("http://any.url/api/v2/orgs/%s/settings", tt.args.orgID)
+			r := httptest.NewRequest("PATCH", u, bytes.NewBufferString(tt.args.body))
+			w := httptest.NewRecorder()
+
+			h.ServeHTTP(w, r)
+
+			res := w.Result()
+			content := res.Header.Get("Content-Type")
+			body, _ := ioutil.ReadAll(res.Body)
+
+			if res.StatusCode != tt.wants.statusCode {
+				t.Errorf("handlePatchOrgSettings() = %v, want %v", res.StatusCode, tt.wants.statusCode)
+			}
+			if tt.wants.contentType != "" && content != tt.wants.contentType {
+				t.Errorf("handlePatchOrgSettings() = %v, want %v", content, tt.wants.contentType)
+			}
+			if tt.wants.body != "" {
+				if eq, diff, err := jsonEqual(string(body), tt.wants.body); err != nil {
+					t.Errorf("%q, handlePatchOrgSettings(). error unmarshaling json %v", tt.name, err)
+				} else if !eq {
+					t.Errorf("%q. handlePatchOrgSettings() = ***%s***", tt.name, diff)
+				}
+			}
+		})
+	}
+}
@@ -4,15 +4,20 @@ import (
 	"net/http"
 	"strings"
 
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorization"
+	"github.com/influxdata/influxdb/v2/http/legacy"
 	"github.com/influxdata/influxdb/v2/kit/feature"
 	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"go.uber.org/zap"
 )
 
 // PlatformHandler is a collection of all the service handlers.
 type PlatformHandler struct {
-	AssetHandler *AssetHandler
-	DocsHandler  http.HandlerFunc
-	APIHandler   http.Handler
+	AssetHandler  *AssetHandler
+	DocsHandler   http.HandlerFunc
+	APIHandler    http.Handler
+	LegacyHandler http.Handler
 }
 
 // NewPlatformHandler returns a platform handler that serves the API and associated assets.
@@ -23,10 +28,15 @@ func NewPlatformHandler(b *APIBackend, opts ...APIHandlerOptFn) *PlatformHandler
 	h.SessionService = b.SessionService
 	h.SessionRenewDisabled = b.SessionRenewDisabled
 	h.UserService = b.UserService
+	if svc, ok := b.AuthorizationService.(platform.AuthorizationUsageService); ok {
+		h.UsageRecorder = authorization.NewUsageRecorder(b.Logger, svc)
+	}
 
 	h.RegisterNoAuthRoute("GET", "/api/v2")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signin")
 	h.RegisterNoAuthRoute("POST", "/api/v2/signout")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/login")
+	h.RegisterNoAuthRoute("GET", "/api/v2/oauth/callback")
 	h.RegisterNoAuthRoute("POST", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/setup")
 	h.RegisterNoAuthRoute("GET", "/api/v2/swagger.json")
@@ -38,9 +48,31 @@ func NewPlatformHandler(b *APIBackend, opts ...APIHandlerOptFn) *PlatformHandler
 	wrappedHandler = kithttp.SkipOptions(wrappedHandler)
 
 	return &PlatformHandler{
-		AssetHandler: assetHandler,
-		DocsHandler:  Redoc("/api/v2/swagger.json"),
-		APIHandler:   wrappedHandler,
+		AssetHandler:  assetHandler,
+		DocsHandler:   Redoc("/api/v2/swagger.json"),
+		APIHandler:    wrappedHandler,
+		LegacyHandler: feature.NewHandler(b.Logger, b.Flagger, feature.Flags(), legacy.NewHandler(newLegacyBackend(b))),
+	}
+}
+
+// newLegacyBackend returns the services used by the 1.x compatible API.
+func newLegacyBackend(b *APIBackend) *legacy.Backend {
+	dbrpSvc := b.LegacyDBRPService
+	if dbrpSvc == nil {
+		dbrpSvc = b.DBRPService
+	}
+	return &legacy.Backend{
+		HTTPErrorHandler:     b.HTTPErrorHandler,
+		Logger:               b.Logger.With(zap.String("handler", "legacy")),
+		WriteEventRecorder:   b.WriteEventRecorder,
+		QueryEventRecorder:   b.QueryEventRecorder,
+		MaxBatchSizeBytes:    b.MaxBatchSizeBytes,
+		AuthorizationService: b.AuthorizationService,
+		UserService:          b.UserService,
+		BucketService:        b.BucketService,
+		PointsWriter:         b.PointsWriter,
+		ProxyQueryService:    b.InfluxQLService,
+		DBRPMappingService:   dbrpSvc,
 	}
 }
 
@@ -51,6 +83,12 @@ func (h *PlatformHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Serve the InfluxDB 1.x compatible endpoints.
+	if legacy.IsLegacyPath(r.URL.Path) {
+		h.LegacyHandler.ServeHTTP(w, r)
+		return
+	}
+
 	// Serve the chronograf assets for any basepath that does not start with addressable parts
 	// of the platform API.
 	if !strings.HasPrefix(r.URL.Path, "/v1") &&
@@ -0,0 +1,409 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/golang/snappy"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	pcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/promql"
+	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixProm    = "/api/v2/prom"
+	promWritePath = prefixProm + "/write"
+	promReadPath  = prefixProm + "/read"
+
+	// promQueryRangePath is the range query endpoint of the Prometheus HTTP
+	// API, relative to a datasource URL of prefixProm.
+	promQueryRangePath = prefixProm + "/api/v1/query_range"
+)
+
+// PromBackend is all services and associated parameters required to construct
+// the PromHandler.
+type PromBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	PointsWriter        storage.PointsWriter
+	ReadStore           reads.Store
+	ProxyQueryService   query.ProxyQueryService
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+
+	// MaxBatchSizeBytes is the maximum size of the body of a remote storage
+	// request, both compressed and decompressed. Zero means no limit.
+	MaxBatchSizeBytes int64
+}
+
+// NewPromBackend returns a new instance of PromBackend.
+func NewPromBackend(log *zap.Logger, b *APIBackend) *PromBackend {
+	return &PromBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		PointsWriter:        b.PointsWriter,
+		ReadStore:           b.ReadStore,
+		ProxyQueryService:   b.FluxService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+		MaxBatchSizeBytes:   b.MaxBatchSizeBytes,
+	}
+}
+
+// PromHandler implements the Prometheus remote_write and remote_read
+// endpoints for a bucket, and the range query API of PromQL queries of the
+// bucket.
+type PromHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	PointsWriter        storage.PointsWriter
+	ReadStore           reads.Store
+	ProxyQueryService   query.ProxyQueryService
+	BucketService       influxdb.BucketService
+	OrganizationService influxdb.OrganizationService
+
+	maxBatchSizeBytes int64
+}
+
+// NewPromHandler creates a new handler at /api/v2/prom.
+func NewPromHandler(log *zap.Logger, b *PromBackend) *PromHandler {
+	h := &PromHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		PointsWriter:        b.PointsWriter,
+		ReadStore:           b.ReadStore,
+		ProxyQueryService:   b.ProxyQueryService,
+		BucketService:       b.BucketService,
+		OrganizationService: b.OrganizationService,
+
+		maxBatchSizeBytes: b.MaxBatchSizeBytes,
+	}
+
+	h.HandlerFunc("POST", promWritePath, h.handleWrite)
+	h.HandlerFunc("POST", promReadPath, h.handleRead)
+	h.HandlerFunc("GET", promQueryRangePath, h.handleQueryRange)
+	h.HandlerFunc("POST", promQueryRangePath, h.handleQueryRange)
+	return h
+}
+
+// Prefix provides the route prefix.
+func (*PromHandler) Prefix() string {
+	return prefixProm
+}
+
+func (h *PromHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, err := h.findBucket(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if _, _, err := authorizer.AuthorizeWrite(ctx, influxdb.BucketsResourceType, bucket.ID, bucket.OrgID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var req remote.WriteRequest
+	if err := decodePromRequest(w, r, &req, h.maxBatchSizeBytes); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	points, err := remote.Points(&req, bucket.OrgID, bucket.ID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	span.LogKV("values_total", len(points))
+
+	if err := authorizer.AuthorizeWritePoints(ctx, points); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
+		h.log.Error("Error writing points", zap.Error(err))
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Op:   "http/handlePromWrite",
+			Msg:  "unexpected error writing points to database",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *PromHandler) handleRead(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, err := h.findBucket(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if _, _, err := authorizer.AuthorizeReadBucket(ctx, bucket.Type, bucket.ID, bucket.OrgID); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var req remote.ReadRequest
+	if err := decodePromRequest(w, r, &req, h.maxBatchSizeBytes); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	source, err := types.MarshalAny(h.ReadStore.GetSource(uint64(bucket.OrgID), uint64(bucket.ID)))
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	scopes, err := authorizer.BucketScopes(ctx, influxdb.ReadAction, bucket.OrgID, bucket.ID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	resp := &remote.ReadResponse{Results: make([]*remote.QueryResult, 0, len(req.Queries))}
+	for _, q := range req.Queries {
+		rreq, err := remote.ReadFilterRequest(q, source)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		rreq.Predicate = reads.RestrictPredicate(rreq.Predicate, scopes)
+		rs, err := h.ReadStore.ReadFilter(ctx, rreq)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		res, err := remote.NewQueryResult(rs)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+		resp.Results = append(resp.Results, res)
+	}
+
+	data, err := proto.Marshal(resp)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(snappy.Encode(nil, data)); err != nil {
+		h.log.Info("Failed to write Prometheus read response", zap.Error(err))
+	}
+}
+
+func (h *PromHandler) handleQueryRange(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "PromHandler")
+	defer span.Finish()
+
+	ctx := r.Context()
+	bucket, err := h.findBucket(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	a, _, err := authorizer.AuthorizeReadBucket(ctx, bucket.Type, bucket.ID, bucket.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	token, err := queryAuthorization(a, bucket.OrgID)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	req, err := decodePromQueryRangeRequest(r, bucket)
+	if err != nil {
+		writePromError(w, http.StatusBadRequest, "bad_data", err)
+		return
+	}
+	pr, err := req.ProxyRequest()
+	if err != nil {
+		writePromError(w, http.StatusBadRequest, "bad_data", err)
+		return
+	}
+	pr.Request.Authorization = token
+	pr.Request.Source = r.Header.Get("User-Agent")
+
+	// Transform the context into one with the request's authorization.
+	ctx = pcontext.SetAuthorizer(ctx, token)
+
+	pr.Dialect.(HTTPDialect).SetHeaders(w)
+	cw := iocounter.Writer{Writer: w}
+	if _, err := h.ProxyQueryService.Query(ctx, &cw, pr); err != nil {
+		if cw.Count() == 0 {
+			// Only record the error IFF nothing has been written to w.
+			code, typ := http.StatusUnprocessableEntity, "execution"
+			if flux.ErrorCode(err) == codes.Invalid {
+				code, typ = http.StatusBadRequest, "bad_data"
+			}
+			writePromError(w, code, typ, err)
+			return
+		}
+		_ = tracing.LogError(span, err)
+		h.log.Info("Error writing response to client",
+			zap.String("handler", "prom"),
+			zap.Error(err),
+		)
+	}
+}
+
+// findBucket returns the bucket identified by the org and bucket query
+// parameters of the request.
+func (h *PromHandler) findBucket(r *http.Request) (*influxdb.Bucket, error) {
+	ctx := r.Context()
+	org, err := queryOrganization(ctx, r, h.OrganizationService)
+	if err != nil {
+		return nil, err
+	}
+	return queryBucket(ctx, org.ID, r, h.BucketService)
+}
+
+// decodePromRequest decodes the snappy-compressed protobuf body of a
+// Prometheus remote storage request into m. A positive maxBytes limits the
+// size of the body, both compressed and decompressed.
+func decodePromRequest(w http.ResponseWriter, r *http.Request, m proto.Message, maxBytes int64) error {
+	body := r.Body
+	if maxBytes > 0 {
+		body = http.MaxBytesReader(w, body, maxBytes)
+	}
+	compressed, err := ioutil.ReadAll(body)
+	if err != nil {
+		if maxBytes > 0 && int64(len(compressed)) >= maxBytes {
+			return &influxdb.Error{
+				Code: influxdb.ETooLarge,
+				Msg:  "request body is too large",
+				Err:  ErrMaxBatchSizeExceeded,
+			}
+		}
+		return &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "unable to read request body",
+			Err:  err,
+		}
+	}
+	n, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "request body is not snappy compressed",
+			Err:  err,
+		}
+	}
+	if maxBytes > 0 && int64(n) > maxBytes {
+		return &influxdb.Error{
+			Code: influxdb.ETooLarge,
+			Msg:  "decompressed request body is too large",
+			Err:  ErrMaxBatchSizeExceeded,
+		}
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "request body is not snappy compressed",
+			Err:  err,
+		}
+	}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("unable to decode %T", m),
+			Err:  err,
+		}
+	}
+	return nil
+}
+
+// decodePromQueryRangeRequest returns the PromQL query of the bucket of a
+// range query, decoded from the parameters of the Prometheus HTTP API.
+func decodePromQueryRangeRequest(r *http.Request, bucket *influxdb.Bucket) (*QueryRequest, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	start, err := parsePromTime(r.FormValue("start"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter 'start': %v", err)
+	}
+	end, err := parsePromTime(r.FormValue("end"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter 'end': %v", err)
+	}
+
+	req := QueryRequest{
+		Type:   "promql",
+		Query:  r.FormValue("query"),
+		Bucket: bucket.Name,
+		Start:  start,
+		End:    end,
+		Step:   r.FormValue("step"),
+		Org:    &influxdb.Organization{ID: bucket.OrgID},
+	}.WithDefaults()
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// parsePromTime parses a timestamp of the Prometheus HTTP API, either a
+// unix timestamp in seconds or an RFC3339 time.
+func parsePromTime(s string) (time.Time, error) {
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		s, ns := math.Modf(secs)
+		return time.Unix(int64(s), int64(math.Round(ns*1e3))*int64(time.Millisecond)).UTC(), nil
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q to a valid timestamp", s)
+}
+
+// writePromError writes an error response of the Prometheus HTTP API.
+func writePromError(w http.ResponseWriter, code int, typ string, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(promql.Response{
+		Status:    "error",
+		ErrorType: typ,
+		Error:     err.Error(),
+	})
+}
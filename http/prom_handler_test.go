@@ -0,0 +1,349 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/golang/snappy"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb/v2"
+	httpmock "github.com/influxdata/influxdb/v2/http/mock"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"github.com/influxdata/influxdb/v2/query"
+	querymock "github.com/influxdata/influxdb/v2/query/mock"
+	"github.com/influxdata/influxdb/v2/query/promql"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	influxtesting "github.com/influxdata/influxdb/v2/testing"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestPromHandler_handleWrite(t *testing.T) {
+	const (
+		org    = "043e0780ee2b1000"
+		bucket = "04504b356e23b000"
+	)
+	samples := &remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			{
+				Labels:  []*remote.Label{{Name: remote.MetricNameLabel, Value: "up"}, {Name: "job", Value: "node"}},
+				Samples: []*remote.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		auth     influxdb.Authorizer
+		body     []byte
+		maxBytes int64
+		code     int
+		points   int
+	}{
+		{
+			name:   "samples are written",
+			auth:   bucketWritePermission(org, bucket),
+			body:   mustEncodePromRequest(t, samples),
+			code:   http.StatusNoContent,
+			points: 1,
+		},
+		{
+			name: "read permission is forbidden",
+			auth: bucketReadPermission(org, bucket),
+			body: mustEncodePromRequest(t, samples),
+			code: http.StatusUnauthorized,
+		},
+		{
+			name: "uncompressed body is invalid",
+			auth: bucketWritePermission(org, bucket),
+			body: []byte("up{job=\"node\"} 1"),
+			code: http.StatusBadRequest,
+		},
+		{
+			name:     "body over the limit is too large",
+			auth:     bucketWritePermission(org, bucket),
+			body:     mustEncodePromRequest(t, samples),
+			maxBytes: 8,
+			code:     http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:     "decompressed body over the limit is too large",
+			auth:     bucketWritePermission(org, bucket),
+			body:     snappy.Encode(nil, make([]byte, 4096)),
+			maxBytes: 1024,
+			code:     http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pw := &mock.PointsWriter{}
+			h := newTestPromHandler(t, org, bucket, pw, nil)
+			h.maxBatchSizeBytes = tt.maxBytes
+			w := servePromRequest(h, tt.auth, promWritePath, org, bucket, tt.body)
+
+			if got, want := w.Code, tt.code; got != want {
+				t.Errorf("unexpected status code: got %d want %d, body %s", got, want, w.Body.String())
+			}
+			if got, want := len(pw.Points), tt.points; got != want {
+				t.Errorf("unexpected number of points: got %d want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestPromHandler_handleRead(t *testing.T) {
+	const (
+		org    = "043e0780ee2b1000"
+		bucket = "04504b356e23b000"
+	)
+	req := &remote.ReadRequest{
+		Queries: []*remote.Query{
+			{
+				StartTimestampMs: 1000,
+				EndTimestampMs:   2000,
+				Matchers:         []*remote.LabelMatcher{{Type: remote.LabelMatcher_EQ, Name: remote.MetricNameLabel, Value: "up"}},
+			},
+		},
+	}
+
+	t.Run("queries are answered", func(t *testing.T) {
+		store := &promTestStore{}
+		h := newTestPromHandler(t, org, bucket, &mock.PointsWriter{}, store)
+		w := servePromRequest(h, bucketReadPermission(org, bucket), promReadPath, org, bucket, mustEncodePromRequest(t, req))
+
+		if got, want := w.Code, http.StatusOK; got != want {
+			t.Fatalf("unexpected status code: got %d want %d, body %s", got, want, w.Body.String())
+		}
+		if got, want := w.Header().Get("Content-Encoding"), "snappy"; got != want {
+			t.Errorf("unexpected content encoding: got %s want %s", got, want)
+		}
+		data, err := snappy.Decode(nil, w.Body.Bytes())
+		if err != nil {
+			t.Fatal(err)
+		}
+		var resp remote.ReadResponse
+		if err := proto.Unmarshal(data, &resp); err != nil {
+			t.Fatal(err)
+		}
+		if got, want := len(resp.Results), 1; got != want {
+			t.Errorf("unexpected number of results: got %d want %d", got, want)
+		}
+		if got, want := store.req.Range.End, int64(2e9+1); got != want {
+			t.Errorf("unexpected range end: got %d want %d", got, want)
+		}
+	})
+
+	t.Run("write permission is forbidden", func(t *testing.T) {
+		h := newTestPromHandler(t, org, bucket, &mock.PointsWriter{}, &promTestStore{})
+		w := servePromRequest(h, bucketWritePermission(org, bucket), promReadPath, org, bucket, mustEncodePromRequest(t, req))
+
+		if got, want := w.Code, http.StatusUnauthorized; got != want {
+			t.Errorf("unexpected status code: got %d want %d", got, want)
+		}
+	})
+}
+
+func TestPromHandler_handleQueryRange(t *testing.T) {
+	const (
+		org    = "043e0780ee2b1000"
+		bucket = "04504b356e23b000"
+	)
+
+	tests := []struct {
+		name   string
+		auth   influxdb.Authorizer
+		params url.Values
+		code   int
+		query  bool
+	}{
+		{
+			name: "query is run",
+			auth: bucketReadPermission(org, bucket),
+			params: url.Values{
+				"query": {"rate(http_requests_total[5m])"},
+				"start": {"1577836800"},
+				"end":   {"2020-01-01T01:00:00Z"},
+				"step":  {"15"},
+			},
+			code:  http.StatusOK,
+			query: true,
+		},
+		{
+			name: "invalid step is bad data",
+			auth: bucketReadPermission(org, bucket),
+			params: url.Values{
+				"query": {"up"},
+				"start": {"1577836800"},
+				"end":   {"1577840400"},
+				"step":  {"fast"},
+			},
+			code: http.StatusBadRequest,
+		},
+		{
+			name: "write permission is forbidden",
+			auth: bucketWritePermission(org, bucket),
+			params: url.Values{
+				"query": {"up"},
+				"start": {"1577836800"},
+				"end":   {"1577840400"},
+				"step":  {"15"},
+			},
+			code: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *query.ProxyRequest
+			h := newTestPromHandler(t, org, bucket, &mock.PointsWriter{}, nil)
+			buckets := mock.NewBucketService()
+			buckets.FindBucketFn = func(context.Context, influxdb.BucketFilter) (*influxdb.Bucket, error) {
+				b := testBucket(org, bucket)
+				b.Name = "prometheus"
+				return b, nil
+			}
+			h.BucketService = buckets
+			h.ProxyQueryService = &querymock.ProxyQueryService{
+				QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+					got = req
+					_, err := io.WriteString(w, `{"status":"success"}`)
+					return flux.Statistics{}, err
+				},
+			}
+
+			params := url.Values{"org": {org}, "bucket": {bucket}}
+			for k, v := range tt.params {
+				params[k] = v
+			}
+			r := httptest.NewRequest("GET", "http://localhost:9999"+promQueryRangePath+"?"+params.Encode(), nil)
+			w := httptest.NewRecorder()
+			httpmock.NewAuthMiddlewareHandler(h, tt.auth).ServeHTTP(w, r)
+
+			if got, want := w.Code, tt.code; got != want {
+				t.Fatalf("unexpected status code: got %d want %d, body %s", got, want, w.Body.String())
+			}
+			if got, want := w.Header().Get("Content-Type"), "application/json"; tt.code != http.StatusUnauthorized && got != want {
+				t.Errorf("unexpected content type: got %s want %s", got, want)
+			}
+			if !tt.query {
+				if got != nil {
+					t.Error("unexpected query")
+				}
+				return
+			}
+
+			c, ok := got.Request.Compiler.(*promql.Compiler)
+			if !ok {
+				t.Fatalf("unexpected compiler %T", got.Request.Compiler)
+			}
+			start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+			if !c.Start.Equal(start) || !c.End.Equal(start.Add(time.Hour)) || c.Step != 15*time.Second {
+				t.Errorf("unexpected range: start %v end %v step %v", c.Start, c.End, c.Step)
+			}
+			if got, want := c.Bucket, "prometheus"; got != want {
+				t.Errorf("unexpected bucket: got %s want %s", got, want)
+			}
+			if got.Request.Authorization == nil {
+				t.Error("expected the query to be authorized")
+			}
+		})
+	}
+}
+
+func newTestPromHandler(t *testing.T, org, bucket string, pw *mock.PointsWriter, store reads.Store) *PromHandler {
+	orgs := mock.NewOrganizationService()
+	orgs.FindOrganizationF = func(ctx context.Context, filter influxdb.OrganizationFilter) (*influxdb.Organization, error) {
+		return testOrg(org), nil
+	}
+	buckets := mock.NewBucketService()
+	buckets.FindBucketFn = func(context.Context, influxdb.BucketFilter) (*influxdb.Bucket, error) {
+		return testBucket(org, bucket), nil
+	}
+
+	b := &APIBackend{
+		HTTPErrorHandler:    DefaultErrorHandler,
+		Logger:              zaptest.NewLogger(t),
+		OrganizationService: orgs,
+		BucketService:       buckets,
+		PointsWriter:        pw,
+		ReadStore:           store,
+	}
+	return NewPromHandler(zaptest.NewLogger(t), NewPromBackend(zaptest.NewLogger(t), b))
+}
+
+func servePromRequest(h http.Handler, auth influxdb.Authorizer, path, org, bucket string, body []byte) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "http://localhost:9999"+path, bytes.NewReader(body))
+	params := r.URL.Query()
+	params.Set("org", org)
+	params.Set("bucket", bucket)
+	r.URL.RawQuery = params.Encode()
+
+	w := httptest.NewRecorder()
+	httpmock.NewAuthMiddlewareHandler(h, auth).ServeHTTP(w, r)
+	return w
+}
+
+func mustEncodePromRequest(t *testing.T, m proto.Message) []byte {
+	t.Helper()
+	data, err := proto.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return snappy.Encode(nil, data)
+}
+
+func bucketReadPermission(org, bucket string) *influxdb.Authorization {
+	oid := influxtesting.MustIDBase16(org)
+	bid := influxtesting.MustIDBase16(bucket)
+	return &influxdb.Authorization{
+		OrgID:  oid,
+		Status: influxdb.Active,
+		Permissions: []influxdb.Permission{
+			{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.BucketsResourceType,
+					OrgID: &oid,
+					ID:    &bid,
+				},
+			},
+		},
+	}
+}
+
+// promTestStore is a reads.Store recording the filter request and returning
+// no series.
+type promTestStore struct {
+	req *datatypes.ReadFilterRequest
+}
+
+func (s *promTestStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	s.req = req
+	return nil, nil
+}
+
+func (s *promTestStore) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *promTestStore) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *promTestStore) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *promTestStore) GetSource(orgID, bucketID uint64) proto.Message {
+	return &types.Empty{}
+}
@@ -19,9 +19,12 @@ import (
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/lang"
 	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/http/legacy"
 	"github.com/influxdata/influxdb/v2/jsonweb"
 	"github.com/influxdata/influxdb/v2/query"
 	transpiler "github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
+	"github.com/influxdata/influxdb/v2/query/promql"
 	"github.com/influxdata/influxql"
 )
 
@@ -36,9 +39,14 @@ type QueryRequest struct {
 	Dialect QueryDialect    `json:"dialect"`
 	Now     time.Time       `json:"now"`
 
-	// InfluxQL fields
+	// InfluxQL and PromQL fields
 	Bucket string `json:"bucket,omitempty"`
 
+	// PromQL fields
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Step  string    `json:"step,omitempty"`
+
 	Org *influxdb.Organization `json:"-"`
 
 	// PreferNoContent specifies if the Response to this request should
@@ -92,14 +100,22 @@ func (r QueryRequest) Validate() error {
 		return errors.New(`request body requires either query or AST`)
 	}
 
-	if r.Type != "flux" && r.Type != "influxql" {
+	switch r.Type {
+	case "flux", "influxql", native.CompilerType, "promql":
+	default:
 		return fmt.Errorf(`unknown query type: %s`, r.Type)
 	}
 
-	if r.Type == "influxql" && r.Bucket == "" {
+	if (r.Type == "influxql" || r.Type == native.CompilerType) && r.Bucket == "" {
 		return fmt.Errorf("bucket parameter is required for influxql queries")
 	}
 
+	if r.Type == "promql" {
+		if err := r.validatePromQL(); err != nil {
+			return err
+		}
+	}
+
 	if len(r.Dialect.CommentPrefix) > 1 {
 		return fmt.Errorf("invalid dialect comment prefix: must be length 0 or 1")
 	}
@@ -130,6 +146,32 @@ func (r QueryRequest) Validate() error {
 	return nil
 }
 
+func (r QueryRequest) validatePromQL() error {
+	if r.Query == "" {
+		return fmt.Errorf("query parameter is required for promql queries")
+	}
+	if r.Bucket == "" {
+		return fmt.Errorf("bucket parameter is required for promql queries")
+	}
+	if r.Start.IsZero() || r.End.IsZero() {
+		return fmt.Errorf("start and end parameters are required for promql queries")
+	}
+	if r.End.Before(r.Start) {
+		return fmt.Errorf("end parameter must not be before start parameter")
+	}
+	step, err := promql.ParseDuration(r.Step)
+	if err != nil {
+		return fmt.Errorf("invalid step parameter: %v", err)
+	}
+	if step <= 0 {
+		return fmt.Errorf("step parameter must be positive")
+	}
+	if r.End.Sub(r.Start)/step >= promql.MaxPoints {
+		return fmt.Errorf("exceeded maximum resolution of %d points per timeseries, try increasing the step", promql.MaxPoints)
+	}
+	return nil
+}
+
 // QueryAnalysis is a structured response of errors.
 type QueryAnalysis struct {
 	Errors []queryParseError `json:"errors"`
@@ -148,8 +190,10 @@ func (r QueryRequest) Analyze(l influxdb.FluxLanguageService) (*QueryAnalysis, e
 	switch r.Type {
 	case "flux":
 		return r.analyzeFluxQuery(l)
-	case "influxql":
+	case "influxql", native.CompilerType:
 		return r.analyzeInfluxQLQuery()
+	case "promql":
+		return r.analyzePromQLQuery()
 	}
 
 	return nil, fmt.Errorf("unknown query request type %s", r.Type)
@@ -217,6 +261,20 @@ func (r QueryRequest) analyzeInfluxQLQuery() (*QueryAnalysis, error) {
 	return a, nil
 }
 
+func (r QueryRequest) analyzePromQLQuery() (*QueryAnalysis, error) {
+	errs := promql.ParseErrors(r.Query)
+	a := &QueryAnalysis{Errors: make([]queryParseError, 0, len(errs))}
+	for _, err := range errs {
+		a.Errors = append(a.Errors, queryParseError{
+			Line:      err.Line,
+			Column:    err.Column,
+			Character: err.Offset,
+			Message:   err.Msg,
+		})
+	}
+	return a, nil
+}
+
 func columnFromCharacter(q string, char int) int {
 	col := 0
 	for i, c := range q {
@@ -260,6 +318,23 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 				Query:  r.Query,
 				Bucket: r.Bucket,
 			}
+		case native.CompilerType:
+			compiler = &native.Compiler{
+				Now:    &n,
+				Query:  r.Query,
+				Bucket: r.Bucket,
+			}
+		case "promql":
+			// The step has been validated.
+			step, _ := promql.ParseDuration(r.Step)
+			compiler = &promql.Compiler{
+				Now:    &n,
+				Query:  r.Query,
+				Bucket: r.Bucket,
+				Start:  r.Start,
+				End:    r.End,
+				Step:   step,
+			}
 		case "flux":
 			fallthrough
 		default:
@@ -289,9 +364,11 @@ func (r QueryRequest) proxyRequest(now func() time.Time) (*query.ProxyRequest, e
 	if r.PreferNoContent {
 		dialect = &query.NoContentDialect{}
 	} else {
-		if r.Type == "influxql" {
+		if r.Type == "influxql" || r.Type == native.CompilerType {
 			// Use default transpiler dialect
 			dialect = &transpiler.Dialect{}
+		} else if r.Type == "promql" {
+			dialect = &promql.Dialect{}
 		} else {
 			// TODO(nathanielc): Use commentPrefix and dateTimeFormat
 			// once they are supported.
@@ -334,6 +411,23 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 		qr.Type = "flux"
 		qr.AST = c.AST
 		qr.Now = c.Now
+	case *native.Compiler:
+		qr.Type = native.CompilerType
+		qr.Query = c.Query
+		qr.Bucket = c.Bucket
+		if c.Now != nil {
+			qr.Now = *c.Now
+		}
+	case *promql.Compiler:
+		qr.Type = "promql"
+		qr.Query = c.Query
+		qr.Bucket = c.Bucket
+		qr.Start = c.Start
+		qr.End = c.End
+		qr.Step = strconv.FormatFloat(c.Step.Seconds(), 'f', -1, 64)
+		if c.Now != nil {
+			qr.Now = *c.Now
+		}
 	default:
 		return nil, fmt.Errorf("unsupported compiler %T", c)
 	}
@@ -345,6 +439,8 @@ func QueryRequestFromProxyRequest(req *query.ProxyRequest) (*QueryRequest, error
 		qr.Dialect.CommentPrefix = "#"
 		qr.Dialect.DateTimeFormat = "RFC3339"
 		qr.Dialect.Annotations = d.ResultEncoderConfig.Annotations
+	case *transpiler.Dialect:
+	case *promql.Dialect:
 	case *query.NoContentDialect:
 		qr.PreferNoContent = true
 	case *query.NoContentWithErrorDialect:
@@ -409,7 +505,7 @@ func (r *countReader) Read(p []byte) (n int, err error) {
 	return n, err
 }
 
-func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth influxdb.Authorizer, svc influxdb.OrganizationService) (*query.ProxyRequest, int, error) {
+func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth influxdb.Authorizer, svc influxdb.OrganizationService, dbrpSvc influxdb.DBRPMappingServiceV2) (*query.ProxyRequest, int, error) {
 	req, n, err := decodeQueryRequest(ctx, r, svc)
 	if err != nil {
 		return nil, n, err
@@ -419,19 +515,57 @@ func decodeProxyQueryRequest(ctx context.Context, r *http.Request, auth influxdb
 	if err != nil {
 		return nil, n, err
 	}
+	if dbrpSvc != nil {
+		pr.Request.Compiler = withDBRPMappings(pr.Request.Compiler, legacy.NewDBRPMappingService(dbrpSvc, req.Org.ID))
+	}
+
+	token, err := queryAuthorization(auth, req.Org.ID)
+	if err != nil {
+		return pr, n, err
+	}
+
+	pr.Request.Authorization = token
+	return pr, n, nil
+}
 
-	var token *influxdb.Authorization
+// withDBRPMappings returns the compiler resolving the databases and retention
+// policies of InfluxQL queries, such as the db.rp.measurement sources, with svc.
+// Other compilers are returned as is.
+func withDBRPMappings(c flux.Compiler, svc influxdb.DBRPMappingService) flux.Compiler {
+	switch c := c.(type) {
+	case *transpiler.Compiler:
+		nc := transpiler.NewCompiler(svc)
+		nc.Cluster = c.Cluster
+		nc.DB = c.DB
+		nc.RP = c.RP
+		nc.Bucket = c.Bucket
+		nc.Query = c.Query
+		nc.Now = c.Now
+		return nc
+	case *native.Compiler:
+		nc := native.NewCompiler(svc)
+		nc.Cluster = c.Cluster
+		nc.DB = c.DB
+		nc.RP = c.RP
+		nc.Bucket = c.Bucket
+		nc.Query = c.Query
+		nc.Now = c.Now
+		return nc
+	}
+	return c
+}
+
+// queryAuthorization returns the authorization of the queries the authorizer
+// runs in the organization.
+func queryAuthorization(auth influxdb.Authorizer, orgID influxdb.ID) (*influxdb.Authorization, error) {
 	switch a := auth.(type) {
 	case *influxdb.Authorization:
-		token = a
+		return a, nil
 	case *influxdb.Session:
-		token = a.EphemeralAuth(req.Org.ID)
+		return a.EphemeralAuth(orgID), nil
 	case *jsonweb.Token:
-		token = a.EphemeralAuth(req.Org.ID)
+		return a.EphemeralAuth(orgID), nil
 	default:
-		return pr, n, influxdb.ErrAuthorizerNotSupported
+		return nil, influxdb.ErrAuthorizerNotSupported
 	}
-
-	pr.Request.Authorization = token
-	return pr, n, nil
 }
@@ -26,7 +26,9 @@ import (
 	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
 	"github.com/influxdata/influxdb/v2/logger"
 	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/cache"
 	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
 	"github.com/pkg/errors"
 	prom "github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/zap"
@@ -48,6 +50,7 @@ type FluxBackend struct {
 	OrganizationService influxdb.OrganizationService
 	ProxyQueryService   query.ProxyQueryService
 	FluxLanguageService influxdb.FluxLanguageService
+	DBRPService         influxdb.DBRPMappingServiceV2
 }
 
 // NewFluxBackend returns a new instance of FluxBackend.
@@ -63,6 +66,7 @@ func NewFluxBackend(log *zap.Logger, b *APIBackend) *FluxBackend {
 		},
 		OrganizationService: b.OrganizationService,
 		FluxLanguageService: b.FluxLanguageService,
+		DBRPService:         b.DBRPService,
 	}
 }
 
@@ -81,6 +85,9 @@ type FluxHandler struct {
 	OrganizationService influxdb.OrganizationService
 	ProxyQueryService   query.ProxyQueryService
 	FluxLanguageService influxdb.FluxLanguageService
+	// DBRPService resolves the databases and retention policies of InfluxQL
+	// queries. It is expected to enforce authorization of the caller.
+	DBRPService influxdb.DBRPMappingServiceV2
 
 	EventRecorder metric.EventRecorder
 }
@@ -102,6 +109,7 @@ func NewFluxHandler(log *zap.Logger, b *FluxBackend) *FluxHandler {
 		OrganizationService: b.OrganizationService,
 		EventRecorder:       b.QueryEventRecorder,
 		FluxLanguageService: b.FluxLanguageService,
+		DBRPService:         b.DBRPService,
 	}
 
 	// query reponses can optionally be gzip encoded
@@ -153,7 +161,7 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	req, n, err := decodeProxyQueryRequest(ctx, r, a, h.OrganizationService)
+	req, n, err := decodeProxyQueryRequest(ctx, r, a, h.OrganizationService, h.DBRPService)
 	if err != nil && err != influxdb.ErrAuthorizerNotSupported {
 		err := &influxdb.Error{
 			Code: influxdb.EInvalid,
@@ -170,6 +178,9 @@ func (h *FluxHandler) handleQuery(w http.ResponseWriter, r *http.Request) {
 
 	// Transform the context into one with the request's authorization.
 	ctx = pcontext.SetAuthorizer(ctx, req.Request.Authorization)
+	// The Cache-Control header chooses whether the result may be served from
+	// or stored in the query result cache, if there is one.
+	ctx = cache.ContextWithControl(ctx, cache.ParseControl(r.Header.Get("Cache-Control")))
 
 	hd, ok := req.Dialect.(HTTPDialect)
 	if !ok {
@@ -645,7 +656,8 @@ func (s routingQueryService) Check(ctx context.Context) check.Response {
 }
 
 func (s routingQueryService) Query(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
-	if req.Request.Compiler.CompilerType() == influxql.CompilerType {
+	switch req.Request.Compiler.CompilerType() {
+	case influxql.CompilerType, native.CompilerType:
 		return s.InfluxQLService.Query(ctx, w, req)
 	}
 	return s.DefaultService.Query(ctx, w, req)
@@ -17,10 +17,15 @@ import (
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/csv"
 	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/flux/memory"
 	platform "github.com/influxdata/influxdb/v2"
+	pcontext "github.com/influxdata/influxdb/v2/context"
 	"github.com/influxdata/influxdb/v2/mock"
 	"github.com/influxdata/influxdb/v2/query"
 	_ "github.com/influxdata/influxdb/v2/query/builtin"
+	transpiler "github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
+	"github.com/influxdata/influxdb/v2/query/promql"
 )
 
 var cmpOptions = cmp.Options{
@@ -28,6 +33,7 @@ var cmpOptions = cmp.Options{
 	cmpopts.IgnoreUnexported(query.ProxyRequest{}),
 	cmpopts.IgnoreUnexported(query.Request{}),
 	cmpopts.IgnoreUnexported(flux.Spec{}),
+	cmpopts.IgnoreUnexported(native.Compiler{}),
 	cmpopts.EquateEmpty(),
 }
 
@@ -366,6 +372,129 @@ func TestQueryRequest_proxyRequest(t *testing.T) {
 	}
 }
 
+func TestQueryRequest_NativeInfluxQL(t *testing.T) {
+	valid := func() QueryRequest {
+		return QueryRequest{
+			Type:   native.CompilerType,
+			Query:  `SELECT mean(value) FROM cpu GROUP BY time(1m) fill(previous)`,
+			Bucket: "telegraf",
+			Org:    &platform.Organization{},
+		}.WithDefaults()
+	}
+
+	t.Run("proxy request", func(t *testing.T) {
+		got, err := valid().proxyRequest(func() time.Time { return time.Unix(1, 1) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		now := time.Unix(1, 1)
+		want := &query.ProxyRequest{
+			Request: query.Request{
+				Compiler: &native.Compiler{
+					Bucket: "telegraf",
+					Query:  `SELECT mean(value) FROM cpu GROUP BY time(1m) fill(previous)`,
+					Now:    &now,
+				},
+			},
+			Dialect: &transpiler.Dialect{},
+		}
+		if !cmp.Equal(got, want, cmpOptions...) {
+			t.Errorf("QueryRequest.ProxyRequest() -want/+got\n%s", cmp.Diff(want, got, cmpOptions...))
+		}
+
+		qr, err := QueryRequestFromProxyRequest(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if qr.Type != native.CompilerType || qr.Bucket != "telegraf" || qr.Query != valid().Query {
+			t.Errorf("unexpected query request from proxy request: %+v", qr)
+		}
+	})
+
+	t.Run("requires bucket", func(t *testing.T) {
+		r := valid()
+		r.Bucket = ""
+		if err := r.Validate(); err == nil {
+			t.Error("QueryRequest.Validate() expected error")
+		}
+	})
+}
+
+func TestQueryRequest_PromQL(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() QueryRequest {
+		return QueryRequest{
+			Type:   "promql",
+			Query:  `rate(http_requests_total[5m])`,
+			Bucket: "prometheus",
+			Start:  start,
+			End:    start.Add(time.Hour),
+			Step:   "15s",
+			Org:    &platform.Organization{},
+		}.WithDefaults()
+	}
+
+	t.Run("proxy request", func(t *testing.T) {
+		got, err := valid().proxyRequest(func() time.Time { return time.Unix(1, 1) })
+		if err != nil {
+			t.Fatal(err)
+		}
+		now := time.Unix(1, 1)
+		want := &query.ProxyRequest{
+			Request: query.Request{
+				Compiler: &promql.Compiler{
+					Bucket: "prometheus",
+					Query:  `rate(http_requests_total[5m])`,
+					Start:  start,
+					End:    start.Add(time.Hour),
+					Step:   15 * time.Second,
+					Now:    &now,
+				},
+			},
+			Dialect: &promql.Dialect{},
+		}
+		if !cmp.Equal(got, want, cmpOptions...) {
+			t.Errorf("QueryRequest.ProxyRequest() -want/+got\n%s", cmp.Diff(want, got, cmpOptions...))
+		}
+	})
+
+	for _, tt := range []struct {
+		name   string
+		modify func(r *QueryRequest)
+	}{
+		{name: "requires bucket", modify: func(r *QueryRequest) { r.Bucket = "" }},
+		{name: "requires query", modify: func(r *QueryRequest) { r.Query = "" }},
+		{name: "requires start", modify: func(r *QueryRequest) { r.Start = time.Time{} }},
+		{name: "end before start", modify: func(r *QueryRequest) { r.End = start.Add(-time.Hour) }},
+		{name: "invalid step", modify: func(r *QueryRequest) { r.Step = "fast" }},
+		{name: "zero step", modify: func(r *QueryRequest) { r.Step = "0" }},
+		{name: "too many points", modify: func(r *QueryRequest) { r.Step = "1ms" }},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			tt.modify(&r)
+			if err := r.Validate(); err == nil {
+				t.Error("QueryRequest.Validate() expected error")
+			}
+		})
+	}
+
+	t.Run("analyze", func(t *testing.T) {
+		r := valid()
+		r.Query = `rate(http_requests_total[5m]`
+		a, err := r.Analyze(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(a.Errors) == 0 {
+			t.Fatal("expected parse errors")
+		}
+		if got, want := a.Errors[0].Line, 1; got != want {
+			t.Errorf("unexpected error line: got %d want %d", got, want)
+		}
+	})
+}
+
 func mustMarshal(p ast.Node) []byte {
 	bs, err := json.Marshal(p)
 	if err != nil {
@@ -599,7 +728,7 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 	)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, _, err := decodeProxyQueryRequest(tt.args.ctx, tt.args.r, tt.args.auth, tt.args.svc)
+			got, _, err := decodeProxyQueryRequest(tt.args.ctx, tt.args.r, tt.args.auth, tt.args.svc, nil)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("decodeProxyQueryRequest() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -610,3 +739,74 @@ func Test_decodeProxyQueryRequest(t *testing.T) {
 		})
 	}
 }
+
+func Test_decodeProxyQueryRequest_DBRPMappings(t *testing.T) {
+	orgID := platform.ID(1)
+	bucketID := platform.ID(2)
+	dbrpSvc := &mock.DBRPMappingServiceV2{
+		FindManyFn: func(ctx context.Context, filter platform.DBRPMappingFilterV2, opts ...platform.FindOptions) ([]*platform.DBRPMappingV2, int, error) {
+			if *filter.OrgID != orgID || *filter.Database != "db0" || filter.RetentionPolicy == nil || *filter.RetentionPolicy != "rp0" {
+				return nil, 0, nil
+			}
+			return []*platform.DBRPMappingV2{{
+				ID:              3,
+				Database:        "db0",
+				RetentionPolicy: "rp0",
+				OrganizationID:  orgID,
+				BucketID:        bucketID,
+			}}, 1, nil
+		},
+	}
+	orgSvc := &mock.OrganizationService{
+		FindOrganizationF: func(ctx context.Context, filter platform.OrganizationFilter) (*platform.Organization, error) {
+			return &platform.Organization{ID: orgID}, nil
+		},
+	}
+	auth := &platform.Authorization{
+		OrgID:       orgID,
+		Status:      platform.Active,
+		Permissions: []platform.Permission{{Action: platform.ReadAction, Resource: platform.Resource{Type: platform.BucketsResourceType, OrgID: &orgID}}},
+	}
+
+	r := httptest.NewRequest("POST", "/api/v2/query?org=myorg", strings.NewReader(`{"type": "influxql_native", "query": "SELECT value FROM db0.rp0.cpu", "bucket": "mybucket"}`))
+	r.Header.Set("Content-Type", "application/json")
+	pr, _, err := decodeProxyQueryRequest(context.Background(), r, auth, orgSvc, dbrpSvc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the query stops once the bucket mapped to db0.rp0 has been looked up
+	var found []platform.ID
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		found = append(found, id)
+		return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+	}
+	ctx := pcontext.SetAuthorizer(context.Background(), pr.Request.Authorization)
+	ctx = query.ContextWithRequest(ctx, &pr.Request)
+	ctx = native.Dependencies{BucketService: bucketSvc}.Inject(ctx)
+
+	prog, err := pr.Request.Compiler.Compile(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fq, err := prog.Start(ctx, &memory.Allocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for res := range fq.Results() {
+		_ = res.Tables().Do(func(flux.Table) error { return nil })
+	}
+	fq.Done()
+	if platform.ErrorCode(fq.Err()) != platform.ENotFound {
+		t.Errorf("expected the bucket lookup to fail, got %v", fq.Err())
+	}
+	if len(found) == 0 {
+		t.Fatal("expected the bucket mapped to db0.rp0 to be looked up")
+	}
+	for _, id := range found {
+		if id != bucketID {
+			t.Errorf("unexpected bucket looked up, got %s, want %s", id, bucketID)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// RestoreBackend is all services and associated parameters required to construct the RestoreHandler.
+type RestoreBackend struct {
+	Logger *zap.Logger
+	influxdb.HTTPErrorHandler
+
+	RestoreService influxdb.RestoreService
+	BucketService  influxdb.BucketService
+}
+
+// NewRestoreBackend returns a new instance of RestoreBackend.
+func NewRestoreBackend(b *APIBackend) *RestoreBackend {
+	return &RestoreBackend{
+		Logger: b.Logger.With(zap.String("handler", "restore")),
+
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		RestoreService:   b.RestoreService,
+		BucketService:    b.BucketService,
+	}
+}
+
+// RestoreHandler is http handler for restore service.
+type RestoreHandler struct {
+	*httprouter.Router
+	influxdb.HTTPErrorHandler
+	Logger *zap.Logger
+
+	RestoreService influxdb.RestoreService
+	BucketService  influxdb.BucketService
+}
+
+const (
+	prefixRestore     = "/api/v2/restore"
+	restoreBucketPath = prefixRestore + "/bucket"
+
+	// restoreRequestPart is the form name of the first part of a bucket
+	// restore request, holding the JSON encoded restoreBucketRequest.
+	restoreRequestPart = "request"
+	// restoreFilePart is the form name of the parts holding the backup files.
+	// A tombstone file must be sent before the TSM file it belongs to.
+	restoreFilePart = "file"
+
+	restoreTSMExt       = ".tsm"
+	restoreTombstoneExt = ".tombstone"
+)
+
+// NewRestoreHandler creates a new handler at /api/v2/restore to receive restore requests.
+func NewRestoreHandler(b *RestoreBackend) *RestoreHandler {
+	h := &RestoreHandler{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		Router:           NewRouter(b.HTTPErrorHandler),
+		Logger:           b.Logger,
+		RestoreService:   b.RestoreService,
+		BucketService:    b.BucketService,
+	}
+
+	h.HandlerFunc(http.MethodPost, restoreBucketPath, h.handleRestoreBucket)
+
+	return h
+}
+
+type restoreBucketRequest struct {
+	SourceOrgID    influxdb.ID       `json:"sourceOrgID"`
+	SourceBucketID influxdb.ID       `json:"sourceBucketID"`
+	Bucket         postBucketRequest `json:"bucket"`
+}
+
+func (r *restoreBucketRequest) OK() error {
+	if !r.SourceOrgID.Valid() || !r.SourceBucketID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "source organization and bucket id must be provided",
+		}
+	}
+	return r.Bucket.OK()
+}
+
+// handleRestoreBucket creates a new bucket and restores the data of a bucket
+// of a backup into it. The request is a multipart form with the request as
+// the first part, followed by the backup files.
+func (h *RestoreHandler) handleRestoreBucket(w http.ResponseWriter, r *http.Request) {
+	span, r := tracing.ExtractFromHTTPRequest(r, "RestoreHandler.handleRestoreBucket")
+	defer span.Finish()
+
+	ctx := r.Context()
+
+	mr, err := r.MultipartReader()
+	if err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "restore request must be a multipart form",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	req, err := decodeRestoreBucketRequest(mr)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	dir, err := ioutil.TempDir("", "influxdb-restore")
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	bucket := req.Bucket.toInfluxDB()
+	if err := h.BucketService.CreateBucket(ctx, bucket); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	source := influxdb.BackupBucket{OrgID: req.SourceOrgID, BucketID: req.SourceBucketID}
+	target := influxdb.BackupBucket{OrgID: bucket.OrgID, BucketID: bucket.ID}
+	if err := h.restoreBucketFiles(ctx, mr, dir, source, target); err != nil {
+		// Deleting the bucket also deletes the data restored so far.
+		err = multierr.Append(err, h.BucketService.DeleteBucket(ctx, bucket.ID))
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.Logger.Info("Bucket restored",
+		zap.String("source_bucket_id", source.BucketID.String()),
+		zap.String("bucket_id", bucket.ID.String()))
+
+	if err := encodeResponse(ctx, w, http.StatusCreated, NewBucketResponse(bucket, []*influxdb.Label{})); err != nil {
+		logEncodingError(h.Logger, r, err)
+		return
+	}
+}
+
+func decodeRestoreBucketRequest(mr *multipart.Reader) (*restoreBucketRequest, error) {
+	part, err := mr.NextPart()
+	if err != nil || part.FormName() != restoreRequestPart {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "restore request must start with the request part",
+			Err:  err,
+		}
+	}
+	defer part.Close()
+
+	var req restoreBucketRequest
+	if err := json.NewDecoder(part).Decode(&req); err != nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid restore request",
+			Err:  err,
+		}
+	}
+	if err := req.OK(); err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// restoreBucketFiles saves the backup files of the request to dir and
+// restores each TSM file once it is received.
+func (h *RestoreHandler) restoreBucketFiles(ctx context.Context, mr *multipart.Reader, dir string, source, target influxdb.BackupBucket) error {
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "failed to read restore request",
+				Err:  err,
+			}
+		}
+
+		name := filepath.Base(part.FileName())
+		ext := filepath.Ext(name)
+		if part.FormName() != restoreFilePart || (ext != restoreTSMExt && ext != restoreTombstoneExt) {
+			part.Close()
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "unexpected restore file " + name,
+			}
+		}
+
+		path := filepath.Join(dir, name)
+		err = saveRestoreFile(path, part)
+		part.Close()
+		if err != nil {
+			return err
+		}
+
+		if ext == restoreTSMExt {
+			if err := h.RestoreService.RestoreBucketFile(ctx, source, target, path); err != nil {
+				return err
+			}
+			if err := os.Remove(path); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func saveRestoreFile(path string, r io.Reader) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		return multierr.Append(err, f.Close())
+	}
+	return f.Close()
+}
+
+// RestoreService is the client implementation of the restore API.
+type RestoreService struct {
+	Addr               string
+	Token              string
+	InsecureSkipVerify bool
+}
+
+// RestoreBucket creates bucket and restores the data of the source bucket
+// from the backup files into it. The files are TSM files and their
+// tombstones, with each tombstone file listed before its TSM file.
+func (s *RestoreService) RestoreBucket(ctx context.Context, source influxdb.BackupBucket, bucket *influxdb.Bucket, files []string) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	u, err := NewURL(s.Addr, restoreBucketPath)
+	if err != nil {
+		return err
+	}
+
+	b := newBucket(bucket)
+	req := restoreBucketRequest{
+		SourceOrgID:    source.OrgID,
+		SourceBucketID: source.BucketID,
+		Bucket: postBucketRequest{
+			OrgID:           b.OrgID,
+			Name:            b.Name,
+			Description:     b.Description,
+			RetentionRules:  b.RetentionRules,
+			DownsampleRules: b.DownsampleRules,
+		},
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeRestoreBucketRequest(mw, &req, files))
+	}()
+	defer pr.Close()
+
+	hreq, err := http.NewRequest(http.MethodPost, u.String(), pr)
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set("Content-Type", mw.FormDataContentType())
+	SetToken(s.Token, hreq)
+	hreq = hreq.WithContext(ctx)
+
+	hc := NewClient(u.Scheme, s.InsecureSkipVerify)
+	hc.Timeout = httpClientTimeout
+	resp, err := hc.Do(hreq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := CheckError(resp); err != nil {
+		return err
+	}
+
+	var br bucketResponse
+	if err := json.NewDecoder(resp.Body).Decode(&br); err != nil {
+		return err
+	}
+	pb, err := br.toInfluxDB()
+	if err != nil {
+		return err
+	}
+	*bucket = *pb
+	return nil
+}
+
+func writeRestoreBucketRequest(mw *multipart.Writer, req *restoreBucketRequest, files []string) error {
+	w, err := mw.CreateFormField(restoreRequestPart)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(w).Encode(req); err != nil {
+		return err
+	}
+
+	for _, path := range files {
+		if err := writeRestoreFile(mw, path); err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
+func writeRestoreFile(mw *multipart.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w, err := mw.CreateFormFile(restoreFilePart, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,400 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixRoles          = "/api/v2/roles"
+	rolesIDPath          = "/api/v2/roles/:id"
+	rolesIDMembersPath   = "/api/v2/roles/:id/members"
+	rolesIDMembersIDPath = "/api/v2/roles/:id/members/:userID"
+)
+
+var _ influxdb.RoleService = (*RoleService)(nil)
+
+// RoleBackend is all services and associated parameters required to construct
+// the RoleHandler.
+type RoleBackend struct {
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	RoleService                influxdb.RoleService
+	UserResourceMappingService influxdb.UserResourceMappingService
+	UserService                influxdb.UserService
+}
+
+// NewRoleBackend creates a backend used by the role handler.
+func NewRoleBackend(log *zap.Logger, b *APIBackend) *RoleBackend {
+	return &RoleBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		RoleService:                b.RoleService,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+}
+
+// RoleHandler is the handler for the role service
+type RoleHandler struct {
+	*httprouter.Router
+
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	RoleService influxdb.RoleService
+}
+
+// NewRoleHandler creates a new RoleHandler. The members of a role are the
+// users it is granted to.
+func NewRoleHandler(log *zap.Logger, b *RoleBackend) *RoleHandler {
+	h := &RoleHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		RoleService: b.RoleService,
+	}
+
+	h.HandlerFunc("GET", prefixRoles, h.handleGetRoles)
+	h.HandlerFunc("POST", prefixRoles, h.handlePostRole)
+	h.HandlerFunc("GET", rolesIDPath, h.handleGetRole)
+	h.HandlerFunc("PATCH", rolesIDPath, h.handlePatchRole)
+	h.HandlerFunc("DELETE", rolesIDPath, h.handleDeleteRole)
+
+	memberBackend := MemberBackend{
+		HTTPErrorHandler:           b.HTTPErrorHandler,
+		log:                        b.log.With(zap.String("handler", "member")),
+		ResourceType:               influxdb.RolesResourceType,
+		UserType:                   influxdb.Member,
+		UserResourceMappingService: b.UserResourceMappingService,
+		UserService:                b.UserService,
+	}
+	h.HandlerFunc("POST", rolesIDMembersPath, newPostMemberHandler(memberBackend))
+	h.HandlerFunc("GET", rolesIDMembersPath, newGetMembersHandler(memberBackend))
+	h.HandlerFunc("DELETE", rolesIDMembersIDPath, newDeleteMemberHandler(memberBackend))
+
+	return h
+}
+
+type roleLinks struct {
+	Self    string `json:"self"`
+	Members string `json:"members"`
+	Org     string `json:"org"`
+}
+
+type roleResponse struct {
+	*influxdb.Role
+	Links roleLinks `json:"links"`
+}
+
+func newRoleResponse(r *influxdb.Role) roleResponse {
+	return roleResponse{
+		Role: r,
+		Links: roleLinks{
+			Self:    fmt.Sprintf("%s/%s", prefixRoles, r.ID),
+			Members: fmt.Sprintf("%s/%s/members", prefixRoles, r.ID),
+			Org:     fmt.Sprintf("/api/v2/orgs/%s", r.OrgID),
+		},
+	}
+}
+
+type rolesResponse struct {
+	Roles []roleResponse        `json:"roles"`
+	Links *influxdb.PagingLinks `json:"links"`
+}
+
+func (r rolesResponse) toInfluxdb() []*influxdb.Role {
+	roles := make([]*influxdb.Role, len(r.Roles))
+	for i := range r.Roles {
+		roles[i] = r.Roles[i].Role
+	}
+	return roles
+}
+
+func newRolesResponse(roles []*influxdb.Role, f influxdb.RoleFilter, opts influxdb.FindOptions) rolesResponse {
+	resp := rolesResponse{
+		Roles: make([]roleResponse, 0, len(roles)),
+		Links: influxdb.NewPagingLinks(prefixRoles, opts, roleFilterParams(f), len(roles)),
+	}
+	for _, r := range roles {
+		resp.Roles = append(resp.Roles, newRoleResponse(r))
+	}
+	return resp
+}
+
+// roleFilterParams implements influxdb.PagingFilter for a role filter.
+type roleFilterParams influxdb.RoleFilter
+
+func (f roleFilterParams) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if f.ID != nil {
+		qp["id"] = []string{f.ID.String()}
+	}
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+	if f.Organization != nil {
+		qp["org"] = []string{*f.Organization}
+	}
+	if f.Name != nil {
+		qp["name"] = []string{*f.Name}
+	}
+	return qp
+}
+
+type getRolesRequest struct {
+	filter influxdb.RoleFilter
+	opts   influxdb.FindOptions
+}
+
+func decodeGetRolesRequest(r *http.Request) (*getRolesRequest, error) {
+	opts, err := influxdb.DecodeFindOptions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &getRolesRequest{
+		opts: *opts,
+	}
+	qp := r.URL.Query()
+	if id := qp.Get("id"); id != "" {
+		req.filter.ID, err = influxdb.IDFromString(id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if orgID := qp.Get("orgID"); orgID != "" {
+		req.filter.OrgID, err = influxdb.IDFromString(orgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if org := qp.Get("org"); org != "" {
+		req.filter.Organization = &org
+	}
+
+	if name := qp.Get("name"); name != "" {
+		req.filter.Name = &name
+	}
+
+	if req.filter.OrgID == nil && req.filter.Organization == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID or org is required",
+		}
+	}
+
+	return req, nil
+}
+
+func (h *RoleHandler) handleGetRoles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeGetRolesRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	roles, _, err := h.RoleService.FindRoles(ctx, req.filter, req.opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Roles retrieved", zap.String("roles", fmt.Sprint(roles)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newRolesResponse(roles, req.filter, req.opts)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func requestRoleID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := influxdb.IDFromString(urlID)
+	if err != nil {
+		return influxdb.InvalidID(), err
+	}
+
+	return *id, nil
+}
+
+func (h *RoleHandler) handleGetRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestRoleID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	role, err := h.RoleService.FindRoleByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Role retrieved", zap.String("role", fmt.Sprint(role)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newRoleResponse(role)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *RoleHandler) handlePostRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var role influxdb.Role
+	if err := json.NewDecoder(r.Body).Decode(&role); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request body",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	if err := h.RoleService.CreateRole(ctx, &role); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Role created", zap.String("role", fmt.Sprint(role)))
+	if err := encodeResponse(ctx, w, http.StatusCreated, newRoleResponse(&role)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *RoleHandler) handlePatchRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestRoleID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var upd influxdb.RoleUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request body",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	role, err := h.RoleService.UpdateRole(ctx, id, upd)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Role updated", zap.String("role", fmt.Sprint(role)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newRoleResponse(role)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *RoleHandler) handleDeleteRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestRoleID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.RoleService.DeleteRole(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Role deleted", zap.String("roleID", fmt.Sprint(id)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RoleService is a role service over HTTP to the influxdb server.
+type RoleService struct {
+	Client *httpc.Client
+}
+
+// FindRoleByID returns a single role by ID.
+func (s *RoleService) FindRoleByID(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+	var resp roleResponse
+	err := s.Client.
+		Get(prefixRoles, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Role, nil
+}
+
+// FindRoles returns a list of roles that match filter and the total count of matching roles.
+func (s *RoleService) FindRoles(ctx context.Context, filter influxdb.RoleFilter, opts ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+	params := influxdb.FindOptionParams(opts...)
+	for k, v := range roleFilterParams(filter).QueryParams() {
+		params = append(params, [2]string{k, v[0]})
+	}
+
+	var resp rolesResponse
+	err := s.Client.
+		Get(prefixRoles).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	roles := resp.toInfluxdb()
+	return roles, len(roles), nil
+}
+
+// CreateRole creates a new role and sets r.ID with the new identifier.
+func (s *RoleService) CreateRole(ctx context.Context, r *influxdb.Role) error {
+	var resp roleResponse
+	err := s.Client.
+		PostJSON(r, prefixRoles).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	*r = *resp.Role
+	return nil
+}
+
+// UpdateRole updates a single role with a changeset.
+func (s *RoleService) UpdateRole(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+	var resp roleResponse
+	err := s.Client.
+		PatchJSON(upd, prefixRoles, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Role, nil
+}
+
+// DeleteRole removes a role by ID.
+func (s *RoleService) DeleteRole(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixRoles, id.String()).
+		Do(ctx)
+}
@@ -78,12 +78,14 @@ func (h *SessionHandler) handleSignin(w http.ResponseWriter, r *http.Request) {
 	u, err := h.UserService.FindUser(ctx, platform.UserFilter{
 		Name: &req.Username,
 	})
-	if err != nil {
-		UnauthorizedError(ctx, h, w)
-		return
+	if err == nil {
+		err = h.PasswordsService.ComparePassword(ctx, u.ID, req.Password)
+	} else if p, ok := h.PasswordsService.(platform.UserProvisioner); ok && platform.ErrorCode(err) == platform.ENotFound {
+		// The user may exist in the directory the passwords service
+		// authenticates against, without having signed in before.
+		_, err = p.ProvisionUser(ctx, req.Username, req.Password)
 	}
-
-	if err := h.PasswordsService.ComparePassword(ctx, u.ID, req.Password); err != nil {
+	if err != nil {
 		// Don't log here, it should already be handled by the service
 		UnauthorizedError(ctx, h, w)
 		return
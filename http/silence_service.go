@@ -0,0 +1,385 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/influxdata/httprouter"
+	"github.com/influxdata/influxdb/v2"
+	pctx "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/pkg/httpc"
+	"go.uber.org/zap"
+)
+
+const (
+	prefixSilences = "/api/v2/silences"
+)
+
+var _ influxdb.SilenceService = (*SilenceService)(nil)
+
+// SilenceBackend is all services and associated parameters required to construct
+// the SilenceHandler.
+type SilenceBackend struct {
+	influxdb.HTTPErrorHandler
+	log            *zap.Logger
+	SilenceService influxdb.SilenceService
+}
+
+// NewSilenceBackend creates a backend used by the silence handler.
+func NewSilenceBackend(log *zap.Logger, b *APIBackend) *SilenceBackend {
+	return &SilenceBackend{
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+		SilenceService:   b.SilenceService,
+	}
+}
+
+// SilenceHandler is the handler for the silence service
+type SilenceHandler struct {
+	*httprouter.Router
+
+	influxdb.HTTPErrorHandler
+	log *zap.Logger
+
+	SilenceService influxdb.SilenceService
+}
+
+// NewSilenceHandler creates a new SilenceHandler
+func NewSilenceHandler(log *zap.Logger, b *SilenceBackend) *SilenceHandler {
+	h := &SilenceHandler{
+		Router:           NewRouter(b.HTTPErrorHandler),
+		HTTPErrorHandler: b.HTTPErrorHandler,
+		log:              log,
+
+		SilenceService: b.SilenceService,
+	}
+
+	entityPath := fmt.Sprintf("%s/:id", prefixSilences)
+
+	h.HandlerFunc("GET", prefixSilences, h.handleGetSilences)
+	h.HandlerFunc("POST", prefixSilences, h.handlePostSilence)
+	h.HandlerFunc("GET", entityPath, h.handleGetSilence)
+	h.HandlerFunc("PATCH", entityPath, h.handlePatchSilence)
+	h.HandlerFunc("DELETE", entityPath, h.handleDeleteSilence)
+
+	return h
+}
+
+type silenceLinks struct {
+	Self string `json:"self"`
+	Org  string `json:"org"`
+}
+
+type silenceResponse struct {
+	*influxdb.Silence
+	Links silenceLinks `json:"links"`
+}
+
+func newSilenceResponse(s *influxdb.Silence) silenceResponse {
+	return silenceResponse{
+		Silence: s,
+		Links: silenceLinks{
+			Self: fmt.Sprintf("%s/%s", prefixSilences, s.ID),
+			Org:  fmt.Sprintf("/api/v2/orgs/%s", s.OrgID),
+		},
+	}
+}
+
+type silencesResponse struct {
+	Silences []silenceResponse     `json:"silences"`
+	Links    *influxdb.PagingLinks `json:"links"`
+}
+
+func (r silencesResponse) toInfluxdb() []*influxdb.Silence {
+	silences := make([]*influxdb.Silence, len(r.Silences))
+	for i := range r.Silences {
+		silences[i] = r.Silences[i].Silence
+	}
+	return silences
+}
+
+func newSilencesResponse(silences []*influxdb.Silence, f influxdb.SilenceFilter, opts influxdb.FindOptions) silencesResponse {
+	resp := silencesResponse{
+		Silences: make([]silenceResponse, 0, len(silences)),
+		Links:    influxdb.NewPagingLinks(prefixSilences, opts, silenceFilterParams(f), len(silences)),
+	}
+	for _, s := range silences {
+		resp.Silences = append(resp.Silences, newSilenceResponse(s))
+	}
+	return resp
+}
+
+// silenceFilterParams implements influxdb.PagingFilter for a silence filter.
+type silenceFilterParams influxdb.SilenceFilter
+
+func (f silenceFilterParams) QueryParams() map[string][]string {
+	qp := map[string][]string{}
+	if f.OrgID != nil {
+		qp["orgID"] = []string{f.OrgID.String()}
+	}
+	if f.Organization != nil {
+		qp["org"] = []string{*f.Organization}
+	}
+	if f.Active != nil {
+		qp["active"] = []string{"true"}
+	}
+	return qp
+}
+
+type getSilencesRequest struct {
+	filter influxdb.SilenceFilter
+	opts   influxdb.FindOptions
+}
+
+func decodeGetSilencesRequest(r *http.Request) (*getSilencesRequest, error) {
+	opts, err := influxdb.DecodeFindOptions(r)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &getSilencesRequest{
+		opts: *opts,
+	}
+	qp := r.URL.Query()
+	if orgID := qp.Get("orgID"); orgID != "" {
+		id, err := influxdb.IDFromString(orgID)
+		if err != nil {
+			return nil, err
+		}
+		req.filter.OrgID = id
+	}
+
+	if org := qp.Get("org"); org != "" {
+		req.filter.Organization = &org
+	}
+
+	if req.filter.OrgID == nil && req.filter.Organization == nil {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "orgID or org is required",
+		}
+	}
+
+	if active := qp.Get("active"); active == "true" {
+		now := time.Now()
+		req.filter.Active = &now
+	}
+
+	return req, nil
+}
+
+func (h *SilenceHandler) handleGetSilences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeGetSilencesRequest(r)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	silences, _, err := h.SilenceService.FindSilences(ctx, req.filter, req.opts)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Silences retrieved", zap.String("silences", fmt.Sprint(silences)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newSilencesResponse(silences, req.filter, req.opts)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func requestSilenceID(ctx context.Context) (influxdb.ID, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	urlID := params.ByName("id")
+	if urlID == "" {
+		return influxdb.InvalidID(), &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "url missing id",
+		}
+	}
+
+	id, err := influxdb.IDFromString(urlID)
+	if err != nil {
+		return influxdb.InvalidID(), err
+	}
+
+	return *id, nil
+}
+
+func (h *SilenceHandler) handleGetSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestSilenceID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	silence, err := h.SilenceService.FindSilenceByID(ctx, id)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Silence retrieved", zap.String("silence", fmt.Sprint(silence)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newSilenceResponse(silence)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *SilenceHandler) handlePostSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var silence influxdb.Silence
+	if err := json.NewDecoder(r.Body).Decode(&silence); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request body",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	auth, err := pctx.GetAuthorizer(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	silence.CreatedBy = auth.GetUserID()
+
+	if err := h.SilenceService.CreateSilence(ctx, &silence); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Silence created", zap.String("silence", fmt.Sprint(silence)))
+	if err := encodeResponse(ctx, w, http.StatusCreated, newSilenceResponse(&silence)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *SilenceHandler) handlePatchSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestSilenceID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	var upd influxdb.SilenceUpdate
+	if err := json.NewDecoder(r.Body).Decode(&upd); err != nil {
+		h.HandleHTTPError(ctx, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request body",
+			Err:  err,
+		}, w)
+		return
+	}
+
+	silence, err := h.SilenceService.UpdateSilence(ctx, id, upd)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Silence updated", zap.String("silence", fmt.Sprint(silence)))
+	if err := encodeResponse(ctx, w, http.StatusOK, newSilenceResponse(silence)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+func (h *SilenceHandler) handleDeleteSilence(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := requestSilenceID(ctx)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.SilenceService.DeleteSilence(ctx, id); err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	h.log.Debug("Silence deleted", zap.String("silenceID", fmt.Sprint(id)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SilenceService is a silence service over HTTP to the influxdb server.
+type SilenceService struct {
+	Client *httpc.Client
+}
+
+// FindSilenceByID returns a single silence by ID.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	var resp silenceResponse
+	err := s.Client.
+		Get(prefixSilences, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Silence, nil
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter, opts ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+	params := influxdb.FindOptionParams(opts...)
+	if filter.OrgID != nil {
+		params = append(params, [2]string{"orgID", filter.OrgID.String()})
+	}
+	if filter.Organization != nil {
+		params = append(params, [2]string{"org", *filter.Organization})
+	}
+	if filter.Active != nil {
+		params = append(params, [2]string{"active", "true"})
+	}
+
+	var resp silencesResponse
+	err := s.Client.
+		Get(prefixSilences).
+		QueryParams(params...).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	silences := resp.toInfluxdb()
+	return silences, len(silences), nil
+}
+
+// CreateSilence creates a new silence and sets sil.ID with the new identifier.
+func (s *SilenceService) CreateSilence(ctx context.Context, sil *influxdb.Silence) error {
+	var resp silenceResponse
+	err := s.Client.
+		PostJSON(sil, prefixSilences).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return err
+	}
+	*sil = *resp.Silence
+	return nil
+}
+
+// UpdateSilence updates a single silence with a changeset.
+func (s *SilenceService) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	var resp silenceResponse
+	err := s.Client.
+		PatchJSON(upd, prefixSilences, id.String()).
+		DecodeJSON(&resp).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Silence, nil
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	return s.Client.
+		Delete(prefixSilences, id.String()).
+		Do(ctx)
+}
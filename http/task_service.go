@@ -68,19 +68,22 @@ type TaskHandler struct {
 }
 
 const (
-	prefixTasks            = "/api/v2/tasks"
-	tasksIDPath            = "/api/v2/tasks/:id"
-	tasksIDLogsPath        = "/api/v2/tasks/:id/logs"
-	tasksIDMembersPath     = "/api/v2/tasks/:id/members"
-	tasksIDMembersIDPath   = "/api/v2/tasks/:id/members/:userID"
-	tasksIDOwnersPath      = "/api/v2/tasks/:id/owners"
-	tasksIDOwnersIDPath    = "/api/v2/tasks/:id/owners/:userID"
-	tasksIDRunsPath        = "/api/v2/tasks/:id/runs"
-	tasksIDRunsIDPath      = "/api/v2/tasks/:id/runs/:rid"
-	tasksIDRunsIDLogsPath  = "/api/v2/tasks/:id/runs/:rid/logs"
-	tasksIDRunsIDRetryPath = "/api/v2/tasks/:id/runs/:rid/retry"
-	tasksIDLabelsPath      = "/api/v2/tasks/:id/labels"
-	tasksIDLabelsIDPath    = "/api/v2/tasks/:id/labels/:lid"
+	prefixTasks              = "/api/v2/tasks"
+	tasksIDPath              = "/api/v2/tasks/:id"
+	tasksIDLogsPath          = "/api/v2/tasks/:id/logs"
+	tasksIDGraphPath         = "/api/v2/tasks/:id/graph"
+	tasksIDBackfillPath      = "/api/v2/tasks/:id/backfill"
+	tasksIDMembersPath       = "/api/v2/tasks/:id/members"
+	tasksIDMembersIDPath     = "/api/v2/tasks/:id/members/:userID"
+	tasksIDOwnersPath        = "/api/v2/tasks/:id/owners"
+	tasksIDOwnersIDPath      = "/api/v2/tasks/:id/owners/:userID"
+	tasksIDRunsPath          = "/api/v2/tasks/:id/runs"
+	tasksIDRunsIDPath        = "/api/v2/tasks/:id/runs/:rid"
+	tasksIDRunsIDLogsPath    = "/api/v2/tasks/:id/runs/:rid/logs"
+	tasksIDRunsIDRetryPath   = "/api/v2/tasks/:id/runs/:rid/retry"
+	tasksIDRunsIDResultsPath = "/api/v2/tasks/:id/runs/:rid/results"
+	tasksIDLabelsPath        = "/api/v2/tasks/:id/labels"
+	tasksIDLabelsIDPath      = "/api/v2/tasks/:id/labels/:lid"
 )
 
 // NewTaskHandler returns a new instance of TaskHandler.
@@ -109,6 +112,11 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("GET", tasksIDLogsPath, h.handleGetLogs)
 	h.HandlerFunc("GET", tasksIDRunsIDLogsPath, h.handleGetLogs)
 
+	h.HandlerFunc("GET", tasksIDGraphPath, h.handleGetTaskGraph)
+
+	h.HandlerFunc("POST", tasksIDBackfillPath, h.handleBackfillTask)
+	h.HandlerFunc("DELETE", tasksIDBackfillPath, h.handleCancelBackfill)
+
 	memberBackend := MemberBackend{
 		HTTPErrorHandler:           b.HTTPErrorHandler,
 		log:                        b.log.With(zap.String("handler", "member")),
@@ -137,6 +145,7 @@ func NewTaskHandler(log *zap.Logger, b *TaskBackend) *TaskHandler {
 	h.HandlerFunc("POST", tasksIDRunsPath, h.handleForceRun)
 	h.HandlerFunc("GET", tasksIDRunsIDPath, h.handleGetRun)
 	h.HandlerFunc("POST", tasksIDRunsIDRetryPath, h.handleRetryRun)
+	h.HandlerFunc("GET", tasksIDRunsIDResultsPath, h.handleGetRunResults)
 	h.HandlerFunc("DELETE", tasksIDRunsIDPath, h.handleCancelRun)
 
 	labelBackend := &LabelBackend{
@@ -166,6 +175,7 @@ type Task struct {
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
 	Offset          string                 `json:"offset,omitempty"`
+	DependsOn       []influxdb.ID          `json:"dependsOn,omitempty"`
 	LatestCompleted string                 `json:"latestCompleted,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
@@ -211,6 +221,7 @@ func NewFrontEndTask(t influxdb.Task) Task {
 		Every:           t.Every,
 		Cron:            t.Cron,
 		Offset:          offset,
+		DependsOn:       t.DependsOn,
 		LatestCompleted: latestCompleted,
 		LastRunStatus:   t.LastRunStatus,
 		LastRunError:    t.LastRunError,
@@ -684,6 +695,86 @@ func decodeGetTaskRequest(ctx context.Context, r *http.Request) (*getTaskRequest
 	return req, nil
 }
 
+type taskGraphResponse struct {
+	Links map[string]string `json:"links"`
+	influxdb.TaskGraph
+}
+
+func newTaskGraphResponse(id influxdb.ID, g *influxdb.TaskGraph) taskGraphResponse {
+	return taskGraphResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/graph", id),
+			"task": fmt.Sprintf("/api/v2/tasks/%s", id),
+		},
+		TaskGraph: *g,
+	}
+}
+
+func (h *TaskHandler) handleGetTaskGraph(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req, err := decodeGetTaskRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	task, err := h.TaskService.FindTaskByID(ctx, req.TaskID)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.ENotFound,
+			Msg:  "failed to find task",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	tasks, err := h.findOrganizationTasks(ctx, task.OrganizationID)
+	if err != nil {
+		err = &influxdb.Error{
+			Err: err,
+			Msg: "failed to find tasks of the organization",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	g, err := influxdb.NewTaskGraph(task.ID, tasks)
+	if err != nil {
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusOK, newTaskGraphResponse(task.ID, g)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+// findOrganizationTasks pages through all the tasks of the organization.
+func (h *TaskHandler) findOrganizationTasks(ctx context.Context, orgID influxdb.ID) ([]*influxdb.Task, error) {
+	var tasks []*influxdb.Task
+	filter := influxdb.TaskFilter{
+		OrganizationID: &orgID,
+		Limit:          influxdb.TaskMaxPageSize,
+	}
+	for {
+		ts, _, err := h.TaskService.FindTasks(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, ts...)
+		if len(ts) < filter.Limit {
+			return tasks, nil
+		}
+		filter.After = &ts[len(ts)-1].ID
+	}
+}
+
 func (h *TaskHandler) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	req, err := decodeUpdateTaskRequest(ctx, r)
@@ -1110,6 +1201,111 @@ func decodeForceRunRequest(ctx context.Context, r *http.Request) (forceRunReques
 	}, nil
 }
 
+func (h *TaskHandler) handleBackfillTask(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeBackfillTaskRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	runs, err := h.TaskService.BackfillTask(ctx, req.TaskID, req.Start, req.Stop)
+	if err != nil {
+		err := &influxdb.Error{
+			Err: err,
+			Msg: "failed to backfill task",
+		}
+		if err.Err == influxdb.ErrTaskNotFound {
+			err.Code = influxdb.ENotFound
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+	if err := encodeResponse(ctx, w, http.StatusCreated, newRunsResponse(runs, req.TaskID)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
+type backfillTaskRequest struct {
+	TaskID      influxdb.ID
+	Start, Stop time.Time
+}
+
+func decodeBackfillTaskRequest(ctx context.Context, r *http.Request) (*backfillTaskRequest, error) {
+	params := httprouter.ParamsFromContext(ctx)
+	tid := params.ByName("id")
+	if tid == "" {
+		return nil, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "you must provide a task ID",
+		}
+	}
+
+	var ti influxdb.ID
+	if err := ti.DecodeFromString(tid); err != nil {
+		return nil, err
+	}
+
+	var req struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(time.RFC3339, req.Start)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := time.Parse(time.RFC3339, req.Stop)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backfillTaskRequest{
+		TaskID: ti,
+		Start:  start,
+		Stop:   stop,
+	}, nil
+}
+
+func (h *TaskHandler) handleCancelBackfill(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetTaskRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := h.TaskService.CancelBackfill(ctx, req.TaskID); err != nil {
+		err := &influxdb.Error{
+			Err: err,
+			Msg: "failed to cancel backfill",
+		}
+		if err.Err == influxdb.ErrTaskNotFound {
+			err.Code = influxdb.ENotFound
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *TaskHandler) handleGetRun(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -1166,6 +1362,82 @@ func (h *TaskHandler) handleGetRun(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+type runResultsResponse struct {
+	Links   map[string]string    `json:"links"`
+	Results []influxdb.RunResult `json:"results"`
+}
+
+func newRunResultsResponse(r influxdb.Run) runResultsResponse {
+	results := r.Results
+	if results == nil {
+		results = []influxdb.RunResult{}
+	}
+	return runResultsResponse{
+		Links: map[string]string{
+			"self": fmt.Sprintf("/api/v2/tasks/%s/runs/%s/results", r.TaskID, r.ID),
+			"run":  fmt.Sprintf("/api/v2/tasks/%s/runs/%s", r.TaskID, r.ID),
+		},
+		Results: results,
+	}
+}
+
+// handleGetRunResults returns the rows captured from the results of a run.
+func (h *TaskHandler) handleGetRunResults(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	req, err := decodeGetRunRequest(ctx, r)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EInvalid,
+			Msg:  "failed to decode request",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	auth, err := pcontext.GetAuthorizer(ctx)
+	if err != nil {
+		err = &influxdb.Error{
+			Err:  err,
+			Code: influxdb.EUnauthorized,
+			Msg:  "failed to get authorizer",
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if k := auth.Kind(); k != influxdb.AuthorizationKind {
+		// Get the authorization for the task, if allowed.
+		authz, err := h.getAuthorizationForTask(ctx, auth, req.TaskID)
+		if err != nil {
+			h.HandleHTTPError(ctx, err, w)
+			return
+		}
+
+		// We were able to access the authorizer for the task, so reassign that on the context for the rest of this call.
+		ctx = pcontext.SetAuthorizer(ctx, authz)
+	}
+
+	run, err := h.TaskService.FindRunByID(ctx, req.TaskID, req.RunID)
+	if err != nil {
+		err := &influxdb.Error{
+			Err: err,
+			Msg: "failed to find run",
+		}
+		if err.Err == influxdb.ErrTaskNotFound || err.Err == influxdb.ErrRunNotFound {
+			err.Code = influxdb.ENotFound
+		}
+		h.HandleHTTPError(ctx, err, w)
+		return
+	}
+
+	if err := encodeResponse(ctx, w, http.StatusOK, newRunResultsResponse(*run)); err != nil {
+		logEncodingError(h.log, r, err)
+		return
+	}
+}
+
 type getRunRequest struct {
 	TaskID influxdb.ID
 	RunID  influxdb.ID
@@ -1676,6 +1948,45 @@ func (t TaskService) ForceRun(ctx context.Context, taskID influxdb.ID, scheduled
 	return convertRun(rs.httpRun), nil
 }
 
+// BackfillTask enqueues a run for every time the task is scheduled for within [start, stop).
+func (t TaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	b := struct {
+		Start string `json:"start"`
+		Stop  string `json:"stop"`
+	}{
+		Start: start.UTC().Format(time.RFC3339),
+		Stop:  stop.UTC().Format(time.RFC3339),
+	}
+
+	var rs runsResponse
+	err := t.Client.
+		PostJSON(b, taskIDBackfillPath(taskID)).
+		DecodeJSON(&rs).
+		Do(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]*influxdb.Run, len(rs.Runs))
+	for i := range rs.Runs {
+		runs[i] = convertRun(rs.Runs[i].httpRun)
+	}
+	return runs, nil
+}
+
+// CancelBackfill cancels the runs of the backfill of the task that have not finished.
+func (t TaskService) CancelBackfill(ctx context.Context, taskID influxdb.ID) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	return t.Client.
+		Delete(taskIDBackfillPath(taskID)).
+		Do(ctx)
+}
+
 func cancelPath(taskID, runID influxdb.ID) string {
 	return path.Join(taskID.String(), runID.String())
 }
@@ -1700,6 +2011,10 @@ func taskIDPath(id influxdb.ID) string {
 	return path.Join(prefixTasks, id.String())
 }
 
+func taskIDBackfillPath(id influxdb.ID) string {
+	return path.Join(prefixTasks, id.String(), "backfill")
+}
+
 func taskIDRunsPath(id influxdb.ID) string {
 	return path.Join(prefixTasks, id.String(), "runs")
 }
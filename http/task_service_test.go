@@ -700,6 +700,247 @@ func TestTaskHandler_handleGetRun(t *testing.T) {
 	}
 }
 
+func TestTaskHandler_handleGetTaskGraph(t *testing.T) {
+	tasks := []*influxdb.Task{
+		{ID: 1, OrganizationID: 10, Name: "raw", Status: "active", LastRunStatus: "success"},
+		{ID: 2, OrganizationID: 10, Name: "downsample", Status: "active", DependsOn: []influxdb.ID{1}},
+		{ID: 3, OrganizationID: 10, Name: "unrelated", Status: "active"},
+	}
+	taskService := &mock.TaskService{
+		FindTaskByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Task, error) {
+			for _, task := range tasks {
+				if task.ID == id {
+					return task, nil
+				}
+			}
+			return nil, influxdb.ErrTaskNotFound
+		},
+		FindTasksFn: func(ctx context.Context, f influxdb.TaskFilter) ([]*influxdb.Task, int, error) {
+			if f.OrganizationID == nil || *f.OrganizationID != 10 {
+				return nil, 0, errors.New("unexpected filter")
+			}
+			return tasks, len(tasks), nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url", nil)
+	r = r.WithContext(context.WithValue(
+		context.Background(),
+		httprouter.ParamsKey,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: influxdb.ID(2).String(),
+			},
+		}))
+	w := httptest.NewRecorder()
+	taskBackend := NewMockTaskBackend(t)
+	taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	taskBackend.TaskService = taskService
+	h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+	h.handleGetTaskGraph(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("handleGetTaskGraph() = %v, want %v: %s", res.StatusCode, http.StatusOK, body)
+	}
+
+	want := `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000002/graph",
+    "task": "/api/v2/tasks/0000000000000002"
+  },
+  "tasks": [
+    {
+      "id": "0000000000000001",
+      "name": "raw",
+      "status": "active",
+      "lastRunStatus": "success"
+    },
+    {
+      "id": "0000000000000002",
+      "name": "downsample",
+      "status": "active"
+    }
+  ],
+  "edges": [
+    {
+      "from": "0000000000000001",
+      "to": "0000000000000002"
+    }
+  ]
+}`
+	if eq, diff, err := jsonEqual(string(body), want); err != nil {
+		t.Errorf("handleGetTaskGraph() error unmarshaling json %v", err)
+	} else if !eq {
+		t.Errorf("handleGetTaskGraph() = ***%s***", diff)
+	}
+}
+
+func TestTaskHandler_handleBackfillTask(t *testing.T) {
+	taskService := &mock.TaskService{
+		BackfillTaskFn: func(ctx context.Context, id influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+			if id != 1 {
+				return nil, influxdb.ErrTaskNotFound
+			}
+			var runs []*influxdb.Run
+			for ts := start; ts.Before(stop); ts = ts.Add(time.Hour) {
+				runs = append(runs, &influxdb.Run{
+					ID:           influxdb.ID(len(runs) + 2),
+					TaskID:       id,
+					Status:       "scheduled",
+					ScheduledFor: ts,
+				})
+			}
+			return runs, nil
+		},
+	}
+
+	r := httptest.NewRequest("POST", "http://any.url", strings.NewReader(`{"start": "2020-06-01T00:00:00Z", "stop": "2020-06-01T02:00:00Z"}`))
+	r = r.WithContext(context.WithValue(
+		context.Background(),
+		httprouter.ParamsKey,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: influxdb.ID(1).String(),
+			},
+		}))
+	w := httptest.NewRecorder()
+	taskBackend := NewMockTaskBackend(t)
+	taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	taskBackend.TaskService = taskService
+	h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+	h.handleBackfillTask(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusCreated {
+		t.Fatalf("handleBackfillTask() = %v, want %v: %s", res.StatusCode, http.StatusCreated, body)
+	}
+
+	want := `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/runs",
+    "task": "/api/v2/tasks/0000000000000001"
+  },
+  "runs": [
+    {
+      "links": {
+        "self": "/api/v2/tasks/0000000000000001/runs/0000000000000002",
+        "task": "/api/v2/tasks/0000000000000001",
+        "retry": "/api/v2/tasks/0000000000000001/runs/0000000000000002/retry",
+        "logs": "/api/v2/tasks/0000000000000001/runs/0000000000000002/logs"
+      },
+      "id": "0000000000000002",
+      "taskID": "0000000000000001",
+      "status": "scheduled",
+      "scheduledFor": "2020-06-01T00:00:00Z"
+    },
+    {
+      "links": {
+        "self": "/api/v2/tasks/0000000000000001/runs/0000000000000003",
+        "task": "/api/v2/tasks/0000000000000001",
+        "retry": "/api/v2/tasks/0000000000000001/runs/0000000000000003/retry",
+        "logs": "/api/v2/tasks/0000000000000001/runs/0000000000000003/logs"
+      },
+      "id": "0000000000000003",
+      "taskID": "0000000000000001",
+      "status": "scheduled",
+      "scheduledFor": "2020-06-01T01:00:00Z"
+    }
+  ]
+}`
+	if eq, diff, err := jsonEqual(string(body), want); err != nil {
+		t.Errorf("handleBackfillTask() error unmarshaling json %v", err)
+	} else if !eq {
+		t.Errorf("handleBackfillTask() = ***%s***", diff)
+	}
+}
+
+func TestTaskHandler_handleGetRunResults(t *testing.T) {
+	taskService := &mock.TaskService{
+		FindRunByIDFn: func(ctx context.Context, taskID influxdb.ID, runID influxdb.ID) (*influxdb.Run, error) {
+			if taskID != 1 || runID != 2 {
+				return nil, influxdb.ErrRunNotFound
+			}
+			return &influxdb.Run{
+				ID:     runID,
+				TaskID: taskID,
+				Status: "success",
+				Results: []influxdb.RunResult{
+					{
+						Name: "_result",
+						Tables: []influxdb.RunResultTable{
+							{
+								Columns: []string{"_time", "_value"},
+								Rows:    [][]interface{}{{"2020-06-01T00:00:00Z", 1.5}},
+							},
+						},
+						Truncated: true,
+					},
+				},
+			}, nil
+		},
+	}
+
+	r := httptest.NewRequest("GET", "http://any.url", nil)
+	r = r.WithContext(context.WithValue(
+		context.Background(),
+		httprouter.ParamsKey,
+		httprouter.Params{
+			{
+				Key:   "id",
+				Value: influxdb.ID(1).String(),
+			},
+			{
+				Key:   "rid",
+				Value: influxdb.ID(2).String(),
+			},
+		}))
+	r = r.WithContext(pcontext.SetAuthorizer(r.Context(), &influxdb.Authorization{Permissions: influxdb.OperPermissions()}))
+	w := httptest.NewRecorder()
+	taskBackend := NewMockTaskBackend(t)
+	taskBackend.HTTPErrorHandler = kithttp.ErrorHandler(0)
+	taskBackend.TaskService = taskService
+	h := NewTaskHandler(zaptest.NewLogger(t), taskBackend)
+	h.handleGetRunResults(w, r)
+
+	res := w.Result()
+	body, _ := ioutil.ReadAll(res.Body)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("handleGetRunResults() = %v, want %v: %s", res.StatusCode, http.StatusOK, body)
+	}
+
+	want := `
+{
+  "links": {
+    "self": "/api/v2/tasks/0000000000000001/runs/0000000000000002/results",
+    "run": "/api/v2/tasks/0000000000000001/runs/0000000000000002"
+  },
+  "results": [
+    {
+      "name": "_result",
+      "tables": [
+        {
+          "columns": ["_time", "_value"],
+          "rows": [["2020-06-01T00:00:00Z", 1.5]]
+        }
+      ],
+      "truncated": true
+    }
+  ]
+}`
+	if eq, diff, err := jsonEqual(string(body), want); err != nil {
+		t.Errorf("handleGetRunResults() error unmarshaling json %v", err)
+	} else if !eq {
+		t.Errorf("handleGetRunResults() = ***%s***", diff)
+	}
+}
+
 func TestTaskHandler_handleGetRuns(t *testing.T) {
 	type fields struct {
 		taskService influxdb.TaskService
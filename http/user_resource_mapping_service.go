@@ -265,6 +265,10 @@ func decodeDeleteMemberRequest(ctx context.Context, r *http.Request) (*deleteMem
 // UserResourceMappingService is the struct of urm service
 type UserResourceMappingService struct {
 	Client *httpc.Client
+
+	// ResourceType is the type of resource mappings are deleted from.
+	// It defaults to organizations.
+	ResourceType influxdb.ResourceType
 }
 
 // FindUserResourceMappings returns the user resource mappings
@@ -305,7 +309,11 @@ func (s *UserResourceMappingService) CreateUserResourceMapping(ctx context.Conte
 
 // DeleteUserResourceMapping will delete user resource mapping based in criteria.
 func (s *UserResourceMappingService) DeleteUserResourceMapping(ctx context.Context, resourceID influxdb.ID, userID influxdb.ID) error {
-	urlPath := resourceIDUserPath(influxdb.OrgsResourceType, resourceID, influxdb.Member, userID)
+	rt := s.ResourceType
+	if rt == "" {
+		rt = influxdb.OrgsResourceType
+	}
+	urlPath := resourceIDUserPath(rt, resourceID, influxdb.Member, userID)
 	return s.Client.
 		Delete(urlPath).
 		Do(ctx)
@@ -11,6 +11,7 @@ import (
 
 	"github.com/influxdata/httprouter"
 	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
 	pcontext "github.com/influxdata/influxdb/v2/context"
 	"github.com/influxdata/influxdb/v2/http/metric"
 	"github.com/influxdata/influxdb/v2/kit/tracing"
@@ -310,6 +311,11 @@ func (h *WriteHandler) handleWrite(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := authorizer.AuthorizeWritePoints(ctx, points); err != nil {
+		handleError(err, influxdb.EForbidden, "insufficient permissions for write")
+		return
+	}
+
 	if err := h.PointsWriter.WritePoints(ctx, points); err != nil {
 		log.Error("Error writing points", zap.Error(err))
 		handleError(err, influxdb.EInternal, "unexpected error writing points to database")
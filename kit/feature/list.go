@@ -198,6 +198,20 @@ func NewHydrateVarsFunctionality() BoolFlag {
 	return hydratevars
 }
 
+var nativeInfluxQL = MakeBoolFlag(
+	"Native InfluxQL Engine",
+	"nativeInfluxQL",
+	"Query Team",
+	false,
+	Temporary,
+	false,
+)
+
+// NativeInfluxqlEngine - Execute InfluxQL queries sent to the 1.x compatible /query endpoint with the native engine instead of transpiling them to Flux
+func NativeInfluxqlEngine() BoolFlag {
+	return nativeInfluxQL
+}
+
 var all = []Flag{
 	appMetrics,
 	backendExample,
@@ -213,6 +227,7 @@ var all = []Flag{
 	pushDownGroupAggregateLast,
 	newLabels,
 	hydratevars,
+	nativeInfluxQL,
 }
 
 var byKey = map[string]Flag{
@@ -230,4 +245,5 @@ var byKey = map[string]Flag{
 	"pushDownGroupAggregateLast":   pushDownGroupAggregateLast,
 	"newLabels":                    newLabels,
 	"hydratevars":                  hydratevars,
+	"nativeInfluxQL":               nativeInfluxQL,
 }
@@ -0,0 +1,178 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.AlertAcknowledgementService = (*Service)(nil)
+
+func newAlertAcknowledgementStore() *StoreBase {
+	const resource = "alert acknowledgement"
+
+	var decodeAlertAckEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var a influxdb.AlertAcknowledgement
+		return key, &a, json.Unmarshal(val, &a)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, i interface{}) (Entity, error) {
+		a, ok := i.(*influxdb.AlertAcknowledgement)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(a.ID),
+			Body: a,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("alertacknowledgementsv1"), EncIDKey, EncBodyJSON, decodeAlertAckEntFn, decValToEntFn)
+}
+
+func (s *Service) initializeAlertAcknowledgements(ctx context.Context, store Store) error {
+	return store.Update(ctx, func(tx Tx) error {
+		return s.alertAckStore.Init(ctx, tx)
+	})
+}
+
+// FindAlertAcknowledgementByID returns a single alert acknowledgement by ID.
+func (s *Service) FindAlertAcknowledgementByID(ctx context.Context, id influxdb.ID) (*influxdb.AlertAcknowledgement, error) {
+	var ack *influxdb.AlertAcknowledgement
+	err := s.kv.View(ctx, func(tx Tx) error {
+		a, err := s.findAlertAcknowledgementByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		ack = a
+		return nil
+	})
+	return ack, err
+}
+
+func (s *Service) findAlertAcknowledgementByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.AlertAcknowledgement, error) {
+	body, err := s.alertAckStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return nil, &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  influxdb.ErrAlertAcknowledgementNotFound,
+			}
+		}
+		return nil, err
+	}
+
+	ack, ok := body.(*influxdb.AlertAcknowledgement)
+	return ack, IsErrUnexpectedDecodeVal(ok)
+}
+
+// FindAlertAcknowledgements returns a list of alert acknowledgements that match filter
+// and the total count of matching alert acknowledgements.
+func (s *Service) FindAlertAcknowledgements(ctx context.Context, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, int, error) {
+	var acks []*influxdb.AlertAcknowledgement
+	err := s.kv.View(ctx, func(tx Tx) error {
+		as, err := s.findAlertAcknowledgements(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		acks = as
+		return nil
+	})
+	return acks, len(acks), err
+}
+
+func (s *Service) findAlertAcknowledgements(ctx context.Context, tx Tx, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, error) {
+	if filter.Organization != nil {
+		o, err := s.findOrganizationByName(ctx, tx, *filter.Organization)
+		if err != nil {
+			return nil, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	acks := make([]*influxdb.AlertAcknowledgement, 0)
+	err := s.alertAckStore.Find(ctx, tx, FindOpts{
+		FilterEntFn: filterAlertAcknowledgementsFn(filter),
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			acks = append(acks, decodedVal.(*influxdb.AlertAcknowledgement))
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acks, nil
+}
+
+func filterAlertAcknowledgementsFn(filter influxdb.AlertAcknowledgementFilter) func([]byte, interface{}) bool {
+	return func(key []byte, val interface{}) bool {
+		ack, ok := val.(*influxdb.AlertAcknowledgement)
+		if !ok {
+			return false
+		}
+
+		if filter.OrgID != nil && ack.OrgID != *filter.OrgID {
+			return false
+		}
+
+		if filter.CheckID != nil && ack.CheckID != *filter.CheckID {
+			return false
+		}
+
+		return true
+	}
+}
+
+// CreateAlertAcknowledgement creates a new alert acknowledgement and sets a.ID with the
+// new identifier. The previous acknowledgements of the same series are deleted.
+func (s *Service) CreateAlertAcknowledgement(ctx context.Context, a *influxdb.AlertAcknowledgement) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := a.Valid(); err != nil {
+			return err
+		}
+
+		acks, err := s.findAlertAcknowledgements(ctx, tx, influxdb.AlertAcknowledgementFilter{OrgID: &a.OrgID, CheckID: &a.CheckID})
+		if err != nil {
+			return err
+		}
+		for _, prev := range acks {
+			if !prev.SameSeries(a) {
+				continue
+			}
+			if err := s.alertAckStore.DeleteEnt(ctx, tx, Entity{PK: EncID(prev.ID)}); err != nil {
+				return err
+			}
+		}
+
+		a.ID = s.IDGenerator.ID()
+		now := s.TimeGenerator.Now()
+		a.CreatedAt = now
+		a.UpdatedAt = now
+		ent := Entity{
+			PK:   EncID(a.ID),
+			Body: a,
+		}
+		if err := s.alertAckStore.Put(ctx, tx, ent, PutNew()); err != nil {
+			return err
+		}
+
+		return s.updateMatchingNotificationTasks(ctx, tx, a.OrgID, a.Tags)
+	})
+}
+
+// DeleteAlertAcknowledgement removes an alert acknowledgement by ID.
+func (s *Service) DeleteAlertAcknowledgement(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		ack, err := s.findAlertAcknowledgementByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.alertAckStore.DeleteEnt(ctx, tx, Entity{PK: EncID(id)}); err != nil {
+			return err
+		}
+
+		return s.updateMatchingNotificationTasks(ctx, tx, ack.OrgID, ack.Tags)
+	})
+}
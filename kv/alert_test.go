@@ -0,0 +1,58 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kv"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBoltAlertAcknowledgementService(t *testing.T) {
+	influxdbtesting.AlertAcknowledgementService(initBoltAlertAcknowledgementService, t)
+}
+
+func initBoltAlertAcknowledgementService(f influxdbtesting.AlertAcknowledgementFields, t *testing.T) (influxdb.AlertAcknowledgementService, func()) {
+	s, closeBolt, err := NewTestBoltStore(t)
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initAlertAcknowledgementService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initAlertAcknowledgementService(s kv.Store, f influxdbtesting.AlertAcknowledgementFields, t *testing.T) (influxdb.AlertAcknowledgementService, func()) {
+	svc := kv.NewService(zaptest.NewLogger(t), s)
+	svc.TimeGenerator = f.TimeGenerator
+	if svc.TimeGenerator == nil {
+		svc.TimeGenerator = influxdb.RealTimeGenerator{}
+	}
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing alert acknowledgement service: %v", err)
+	}
+	for _, a := range f.AlertAcknowledgements {
+		svc.IDGenerator = mock.NewIDGenerator(a.ID.String(), t)
+		if err := svc.CreateAlertAcknowledgement(ctx, a); err != nil {
+			t.Fatalf("failed to populate alert acknowledgements: %v", err)
+		}
+	}
+	svc.IDGenerator = f.IDGenerator
+
+	done := func() {
+		for _, a := range f.AlertAcknowledgements {
+			if err := svc.DeleteAlertAcknowledgement(ctx, a.ID); err != nil {
+				t.Logf("failed to clean up alert acknowledgements bolt test: %v", err)
+			}
+		}
+	}
+	return svc, done
+}
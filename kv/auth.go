@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/buger/jsonparser"
 	influxdb "github.com/influxdata/influxdb/v2"
@@ -15,7 +16,10 @@ var (
 	authIndex  = []byte("authorizationindexv1")
 )
 
-var _ influxdb.AuthorizationService = (*Service)(nil)
+var (
+	_ influxdb.AuthorizationService      = (*Service)(nil)
+	_ influxdb.AuthorizationUsageService = (*Service)(nil)
+)
 
 func (s *Service) initializeAuths(ctx context.Context, tx Tx) error {
 	if _, err := tx.Bucket(authBucket); err != nil {
@@ -126,7 +130,19 @@ func (s *Service) findAuthorizationByToken(ctx context.Context, tx Tx, n string)
 			Err:  err,
 		}
 	}
-	return s.findAuthorizationByID(ctx, tx, id)
+	auth, err := s.findAuthorizationByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the index keeps the previous token of a rotated authorization.
+	if !auth.AcceptsToken(n) {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  "authorization not found",
+		}
+	}
+	return auth, nil
 }
 
 func authorizationsPredicateFn(f influxdb.AuthorizationFilter) CursorPredicateFunc {
@@ -486,6 +502,13 @@ func (s *Service) deleteAuthorization(ctx context.Context, tx Tx, id influxdb.ID
 			Err: err,
 		}
 	}
+	if a.PreviousToken != "" {
+		if err := idx.Delete(authIndexKey(a.PreviousToken)); err != nil {
+			return &influxdb.Error{
+				Err: err,
+			}
+		}
+	}
 	encodedID, err := id.Encode()
 	if err != nil {
 		return &influxdb.Error{
@@ -540,6 +563,82 @@ func (s *Service) updateAuthorization(ctx context.Context, tx Tx, id influxdb.ID
 	return a, nil
 }
 
+// RotateAuthorization issues a new token for the authorization. The previous
+// token remains valid for the grace period.
+func (s *Service) RotateAuthorization(ctx context.Context, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	var a *influxdb.Authorization
+	var err error
+	err = s.kv.Update(ctx, func(tx Tx) error {
+		a, err = s.rotateAuthorization(ctx, tx, id, grace)
+		return err
+	})
+	return a, err
+}
+
+func (s *Service) rotateAuthorization(ctx context.Context, tx Tx, id influxdb.ID, grace time.Duration) (*influxdb.Authorization, error) {
+	a, err := s.findAuthorizationByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.TokenGenerator.Token()
+	if err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	if err := s.unique(ctx, tx, authIndex, authIndexKey(token)); err != nil {
+		return nil, influxdb.ErrUnableToCreateToken
+	}
+
+	idx, err := authIndexBucket(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// only the token replaced by this rotation remains valid.
+	if a.PreviousToken != "" {
+		if err := idx.Delete(authIndexKey(a.PreviousToken)); err != nil {
+			return nil, &influxdb.Error{
+				Err: err,
+			}
+		}
+	}
+
+	now := s.TimeGenerator.Now()
+	a.PreviousToken, a.PreviousTokenExpiresAt = "", nil
+	if grace > 0 {
+		expiresAt := now.Add(grace)
+		a.PreviousToken, a.PreviousTokenExpiresAt = a.Token, &expiresAt
+	} else if err := idx.Delete(authIndexKey(a.Token)); err != nil {
+		return nil, &influxdb.Error{
+			Err: err,
+		}
+	}
+	a.Token = token
+	a.SetUpdatedAt(now)
+
+	if err := s.putAuthorization(ctx, tx, a); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// SetAuthorizationLastUsed records that the authorization was used at the time
+// from the address.
+func (s *Service) SetAuthorizationLastUsed(ctx context.Context, id influxdb.ID, at time.Time, from string) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		a, err := s.findAuthorizationByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		a.LastUsedAt, a.LastUsedFrom = &at, from
+		return s.putAuthorization(ctx, tx, a)
+	})
+}
+
 func authIndexBucket(tx Tx) (Bucket, error) {
 	b, err := tx.Bucket([]byte(authIndex))
 	if err != nil {
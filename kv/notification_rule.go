@@ -114,7 +114,35 @@ func (s *Service) createNotificationTask(ctx context.Context, tx Tx, r influxdb.
 		return nil, err
 	}
 
-	script, err := r.GenerateFlux(ep)
+	orgSilences, err := s.findUnexpiredSilences(ctx, tx, r.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	var silences []*influxdb.Silence
+	for _, sil := range orgSilences {
+		if r.MayMatchTags(sil.Matchers) {
+			silences = append(silences, sil)
+		}
+	}
+
+	orgID := r.GetOrgID()
+	orgAcks, err := s.findAlertAcknowledgements(ctx, tx, influxdb.AlertAcknowledgementFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	var acks []*influxdb.AlertAcknowledgement
+	for _, a := range orgAcks {
+		if r.MayMatchTags(a.Tags) {
+			acks = append(acks, a)
+		}
+	}
+
+	escalations, err := s.findEscalationEndpoints(ctx, tx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := r.GenerateFlux(ep, escalations, silences, acks)
 	if err != nil {
 		return nil, err
 	}
@@ -137,13 +165,55 @@ func (s *Service) createNotificationTask(ctx context.Context, tx Tx, r influxdb.
 	return t, nil
 }
 
+// findEscalationEndpoints returns the endpoints of the escalation steps of r.
+func (s *Service) findEscalationEndpoints(ctx context.Context, tx Tx, r influxdb.NotificationRule) ([]influxdb.NotificationEndpoint, error) {
+	ids := r.GetEscalationEndpointIDs()
+	escalations := make([]influxdb.NotificationEndpoint, 0, len(ids))
+	for _, id := range ids {
+		ep, err := s.findNotificationEndpointByID(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		escalations = append(escalations, ep)
+	}
+	return escalations, nil
+}
+
 func (s *Service) updateNotificationTask(ctx context.Context, tx Tx, r influxdb.NotificationRule, status *string) (*influxdb.Task, error) {
 	ep, err := s.findNotificationEndpointByID(ctx, tx, r.GetEndpointID())
 	if err != nil {
 		return nil, err
 	}
 
-	script, err := r.GenerateFlux(ep)
+	orgSilences, err := s.findUnexpiredSilences(ctx, tx, r.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+	var silences []*influxdb.Silence
+	for _, sil := range orgSilences {
+		if r.MayMatchTags(sil.Matchers) {
+			silences = append(silences, sil)
+		}
+	}
+
+	orgID := r.GetOrgID()
+	orgAcks, err := s.findAlertAcknowledgements(ctx, tx, influxdb.AlertAcknowledgementFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+	var acks []*influxdb.AlertAcknowledgement
+	for _, a := range orgAcks {
+		if r.MayMatchTags(a.Tags) {
+			acks = append(acks, a)
+		}
+	}
+
+	escalations, err := s.findEscalationEndpoints(ctx, tx, r)
+	if err != nil {
+		return nil, err
+	}
+
+	script, err := r.GenerateFlux(ep, escalations, silences, acks)
 	if err != nil {
 		return nil, err
 	}
@@ -554,6 +554,9 @@ func (s *Service) DeleteOrganization(ctx context.Context, id influxdb.ID) error
 		if pe := s.deleteOrganization(ctx, tx, id); pe != nil {
 			return pe
 		}
+		if err := s.deleteOrganizationSettings(ctx, tx, id); err != nil {
+			return err
+		}
 
 		uid, _ := icontext.GetUserID(ctx)
 		return s.audit.Log(resource.Change{
@@ -775,6 +778,12 @@ func (s *Service) FindResourceOrganizationID(ctx context.Context, rt influxdb.Re
 			return influxdb.InvalidID(), err
 		}
 		return r.GetOrgID(), nil
+	case influxdb.RolesResourceType:
+		r, err := s.FindRoleByID(ctx, id)
+		if err != nil {
+			return influxdb.InvalidID(), err
+		}
+		return r.OrgID, nil
 	}
 
 	return influxdb.InvalidID(), &influxdb.Error{
@@ -0,0 +1,100 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.OrganizationSettingsService = (*Service)(nil)
+
+func newOrgSettingsStore() *StoreBase {
+	const resource = "organization settings"
+
+	var decodeOrgSettingsEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var s influxdb.OrganizationSettings
+		return key, &s, json.Unmarshal(val, &s)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, i interface{}) (Entity, error) {
+		s, ok := i.(*influxdb.OrganizationSettings)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(s.OrgID),
+			Body: s,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("orgsettingsv1"), EncIDKey, EncBodyJSON, decodeOrgSettingsEntFn, decValToEntFn)
+}
+
+func (s *Service) initializeOrgSettings(ctx context.Context, store Store) error {
+	return store.Update(ctx, func(tx Tx) error {
+		return s.orgSettingsStore.Init(ctx, tx)
+	})
+}
+
+// FindOrganizationSettings returns the settings of the organization.
+func (s *Service) FindOrganizationSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+	var settings *influxdb.OrganizationSettings
+	err := s.kv.View(ctx, func(tx Tx) error {
+		set, err := s.findOrganizationSettings(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+		settings = set
+		return nil
+	})
+	return settings, err
+}
+
+func (s *Service) findOrganizationSettings(ctx context.Context, tx Tx, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+	body, err := s.orgSettingsStore.FindEnt(ctx, tx, Entity{PK: EncID(orgID)})
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return &influxdb.OrganizationSettings{OrgID: orgID}, nil
+		}
+		return nil, err
+	}
+
+	settings, ok := body.(*influxdb.OrganizationSettings)
+	return settings, IsErrUnexpectedDecodeVal(ok)
+}
+
+// UpdateOrganizationSettings updates the settings of the organization with a changeset.
+func (s *Service) UpdateOrganizationSettings(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+	var settings *influxdb.OrganizationSettings
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findOrganizationByID(ctx, tx, orgID); err != nil {
+			return err
+		}
+
+		set, err := s.findOrganizationSettings(ctx, tx, orgID)
+		if err != nil {
+			return err
+		}
+
+		upd.Apply(set)
+		if err := set.Valid(); err != nil {
+			return err
+		}
+
+		ent := Entity{
+			PK:   EncID(orgID),
+			Body: set,
+		}
+		if err := s.orgSettingsStore.Put(ctx, tx, ent); err != nil {
+			return err
+		}
+		settings = set
+		return nil
+	})
+	return settings, err
+}
+
+func (s *Service) deleteOrganizationSettings(ctx context.Context, tx Tx, orgID influxdb.ID) error {
+	return s.orgSettingsStore.DeleteEnt(ctx, tx, Entity{PK: EncID(orgID)})
+}
@@ -0,0 +1,76 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/inmem"
+	"github.com/influxdata/influxdb/v2/kv"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestService_OrganizationSettings(t *testing.T) {
+	ctx := context.Background()
+	svc := kv.NewService(zaptest.NewLogger(t), inmem.NewKVStore())
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing kv service: %v", err)
+	}
+
+	org := &influxdb.Organization{Name: "org"}
+	if err := svc.CreateOrganization(ctx, org); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := svc.FindOrganizationSettings(ctx, org.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&influxdb.OrganizationSettings{OrgID: org.ID}, settings); diff != "" {
+		t.Fatalf("unexpected default settings: %s", diff)
+	}
+
+	concurrency, weight := 2, 5
+	exp := &influxdb.OrganizationSettings{OrgID: org.ID, QueryConcurrencyQuota: 2, QueryWeight: 5}
+	settings, err = svc.UpdateOrganizationSettings(ctx, org.ID, influxdb.OrganizationSettingsUpdate{
+		QueryConcurrencyQuota: &concurrency,
+		QueryWeight:           &weight,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(exp, settings); diff != "" {
+		t.Fatalf("unexpected updated settings: %s", diff)
+	}
+
+	settings, err = svc.FindOrganizationSettings(ctx, org.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(exp, settings); diff != "" {
+		t.Fatalf("unexpected settings: %s", diff)
+	}
+
+	weight = influxdb.MaxQueryWeight + 1
+	_, err = svc.UpdateOrganizationSettings(ctx, org.ID, influxdb.OrganizationSettingsUpdate{QueryWeight: &weight})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid weight error, got %v", err)
+	}
+
+	_, err = svc.UpdateOrganizationSettings(ctx, org.ID+1, influxdb.OrganizationSettingsUpdate{QueryWeight: &concurrency})
+	if influxdb.ErrorCode(err) != influxdb.ENotFound {
+		t.Fatalf("expected org not found error, got %v", err)
+	}
+
+	if err := svc.DeleteOrganization(ctx, org.ID); err != nil {
+		t.Fatal(err)
+	}
+	settings, err = svc.FindOrganizationSettings(ctx, org.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(&influxdb.OrganizationSettings{OrgID: org.ID}, settings); diff != "" {
+		t.Fatalf("expected settings of deleted org to be removed: %s", diff)
+	}
+}
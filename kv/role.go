@@ -0,0 +1,258 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.RoleService = (*Service)(nil)
+
+func newRoleStore() *StoreBase {
+	const resource = "role"
+
+	var decodeRoleEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var r influxdb.Role
+		return key, &r, json.Unmarshal(val, &r)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, i interface{}) (Entity, error) {
+		r, ok := i.(*influxdb.Role)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(r.ID),
+			Body: r,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("rolesv1"), EncIDKey, EncBodyJSON, decodeRoleEntFn, decValToEntFn)
+}
+
+func (s *Service) initializeRoles(ctx context.Context, store Store) error {
+	return store.Update(ctx, func(tx Tx) error {
+		return s.roleStore.Init(ctx, tx)
+	})
+}
+
+// FindRoleByID returns a single role by ID.
+func (s *Service) FindRoleByID(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+	var role *influxdb.Role
+	err := s.kv.View(ctx, func(tx Tx) error {
+		r, err := s.findRoleByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		role = r
+		return nil
+	})
+	return role, err
+}
+
+func (s *Service) findRoleByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Role, error) {
+	body, err := s.roleStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return nil, &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  influxdb.ErrRoleNotFound,
+			}
+		}
+		return nil, err
+	}
+
+	role, ok := body.(*influxdb.Role)
+	return role, IsErrUnexpectedDecodeVal(ok)
+}
+
+// FindRoles returns a list of roles that match filter and the total count of matching roles.
+func (s *Service) FindRoles(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+	var roles []*influxdb.Role
+	err := s.kv.View(ctx, func(tx Tx) error {
+		rs, err := s.findRoles(ctx, tx, filter, opt...)
+		if err != nil {
+			return err
+		}
+		roles = rs
+		return nil
+	})
+	return roles, len(roles), err
+}
+
+func (s *Service) findRoles(ctx context.Context, tx Tx, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, error) {
+	if filter.ID != nil {
+		r, err := s.findRoleByID(ctx, tx, *filter.ID)
+		if err != nil {
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				return []*influxdb.Role{}, nil
+			}
+			return nil, err
+		}
+		if !filterRolesFn(filter)(nil, r) {
+			return []*influxdb.Role{}, nil
+		}
+		return []*influxdb.Role{r}, nil
+	}
+
+	if filter.Organization != nil {
+		o, err := s.findOrganizationByName(ctx, tx, *filter.Organization)
+		if err != nil {
+			return nil, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	var o influxdb.FindOptions
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+
+	roles := make([]*influxdb.Role, 0)
+	err := s.roleStore.Find(ctx, tx, FindOpts{
+		Descending:  o.Descending,
+		Limit:       o.Limit,
+		Offset:      o.Offset,
+		FilterEntFn: filterRolesFn(filter),
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			roles = append(roles, decodedVal.(*influxdb.Role))
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func filterRolesFn(filter influxdb.RoleFilter) func([]byte, interface{}) bool {
+	return func(key []byte, val interface{}) bool {
+		role, ok := val.(*influxdb.Role)
+		if !ok {
+			return false
+		}
+
+		if filter.OrgID != nil && role.OrgID != *filter.OrgID {
+			return false
+		}
+
+		if filter.Name != nil && role.Name != *filter.Name {
+			return false
+		}
+
+		return true
+	}
+}
+
+// CreateRole creates a new role and sets r.ID with the new identifier.
+func (s *Service) CreateRole(ctx context.Context, r *influxdb.Role) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := r.Valid(); err != nil {
+			return err
+		}
+		if err := s.uniqueRoleName(ctx, tx, r); err != nil {
+			return err
+		}
+
+		r.ID = s.IDGenerator.ID()
+		now := s.TimeGenerator.Now()
+		r.CreatedAt = now
+		r.UpdatedAt = now
+		return s.putRole(ctx, tx, r, PutNew())
+	})
+}
+
+// UpdateRole updates a single role with a changeset.
+func (s *Service) UpdateRole(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+	var role *influxdb.Role
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		r, err := s.findRoleByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		upd.Apply(r)
+		if err := r.Valid(); err != nil {
+			return err
+		}
+		if upd.Name != nil {
+			if err := s.uniqueRoleName(ctx, tx, r); err != nil {
+				return err
+			}
+		}
+
+		r.UpdatedAt = s.TimeGenerator.Now()
+		if err := s.putRole(ctx, tx, r, PutUpdate()); err != nil {
+			return err
+		}
+		role = r
+		return nil
+	})
+	return role, err
+}
+
+// uniqueRoleName returns a conflict error if another role of the
+// organization of r has its name.
+func (s *Service) uniqueRoleName(ctx context.Context, tx Tx, r *influxdb.Role) error {
+	roles, err := s.findRoles(ctx, tx, influxdb.RoleFilter{OrgID: &r.OrgID, Name: &r.Name})
+	if err != nil {
+		return err
+	}
+	for _, other := range roles {
+		if other.ID != r.ID {
+			return &influxdb.Error{
+				Code: influxdb.EConflict,
+				Msg:  fmt.Sprintf("role with name %s already exists", r.Name),
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Service) putRole(ctx context.Context, tx Tx, r *influxdb.Role, putOpts ...PutOptionFn) error {
+	ent := Entity{
+		PK:   EncID(r.ID),
+		Body: r,
+	}
+	return s.roleStore.Put(ctx, tx, ent, putOpts...)
+}
+
+// DeleteRole removes a role by ID, and unassigns it from its members.
+func (s *Service) DeleteRole(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findRoleByID(ctx, tx, id); err != nil {
+			return err
+		}
+
+		if err := s.roleStore.DeleteEnt(ctx, tx, Entity{PK: EncID(id)}); err != nil {
+			return err
+		}
+
+		return s.deleteUserResourceMappings(ctx, tx, influxdb.UserResourceMappingFilter{
+			ResourceID:   id,
+			ResourceType: influxdb.RolesResourceType,
+		})
+	})
+}
+
+// rolePermissions returns the permissions of the roles the mappings make the
+// user a member of. Mappings to roles that no longer exist are ignored.
+func (s *Service) rolePermissions(ctx context.Context, tx Tx, mappings []*influxdb.UserResourceMapping) ([]influxdb.Permission, error) {
+	var ps []influxdb.Permission
+	for _, m := range mappings {
+		if m.ResourceType != influxdb.RolesResourceType {
+			continue
+		}
+		r, err := s.findRoleByID(ctx, tx, m.ResourceID)
+		if err != nil {
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				continue
+			}
+			return nil, err
+		}
+		ps = append(ps, r.Permissions...)
+	}
+	return ps, nil
+}
@@ -0,0 +1,58 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kv"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBoltRoleService(t *testing.T) {
+	influxdbtesting.RoleService(initBoltRoleService, t)
+}
+
+func initBoltRoleService(f influxdbtesting.RoleFields, t *testing.T) (influxdb.RoleService, func()) {
+	s, closeBolt, err := NewTestBoltStore(t)
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initRoleService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initRoleService(s kv.Store, f influxdbtesting.RoleFields, t *testing.T) (influxdb.RoleService, func()) {
+	svc := kv.NewService(zaptest.NewLogger(t), s)
+	svc.TimeGenerator = f.TimeGenerator
+	if svc.TimeGenerator == nil {
+		svc.TimeGenerator = influxdb.RealTimeGenerator{}
+	}
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing role service: %v", err)
+	}
+	for _, r := range f.Roles {
+		svc.IDGenerator = mock.NewIDGenerator(r.ID.String(), t)
+		if err := svc.CreateRole(ctx, r); err != nil {
+			t.Fatalf("failed to populate roles: %v", err)
+		}
+	}
+	svc.IDGenerator = f.IDGenerator
+
+	done := func() {
+		for _, r := range f.Roles {
+			if err := svc.DeleteRole(ctx, r.ID); err != nil {
+				t.Logf("failed to clean up roles bolt test: %v", err)
+			}
+		}
+	}
+	return svc, done
+}
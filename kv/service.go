@@ -45,9 +45,13 @@ type Service struct {
 	influxdb.TimeGenerator
 	Hash Crypt
 
-	checkStore    *IndexStore
-	endpointStore *IndexStore
-	variableStore *IndexStore
+	checkStore       *IndexStore
+	endpointStore    *IndexStore
+	variableStore    *IndexStore
+	silenceStore     *StoreBase
+	roleStore        *StoreBase
+	alertAckStore    *StoreBase
+	orgSettingsStore *StoreBase
 
 	Migrator *Migrator
 
@@ -62,16 +66,20 @@ func NewService(log *zap.Logger, kv Store, configs ...ServiceConfig) *Service {
 		log:         log,
 		IDGenerator: snowflake.NewIDGenerator(),
 		// Seed the random number generator with the current time
-		OrgBucketIDs:   rand.NewOrgBucketID(time.Now().UnixNano()),
-		TokenGenerator: rand.NewTokenGenerator(64),
-		Hash:           &Bcrypt{},
-		kv:             kv,
-		audit:          noop.ResourceLogger{},
-		TimeGenerator:  influxdb.RealTimeGenerator{},
-		checkStore:     newCheckStore(),
-		endpointStore:  newEndpointStore(),
-		variableStore:  newVariableStore(),
-		Migrator:       NewMigrator(log),
+		OrgBucketIDs:     rand.NewOrgBucketID(time.Now().UnixNano()),
+		TokenGenerator:   rand.NewTokenGenerator(64),
+		Hash:             &Bcrypt{},
+		kv:               kv,
+		audit:            noop.ResourceLogger{},
+		TimeGenerator:    influxdb.RealTimeGenerator{},
+		checkStore:       newCheckStore(),
+		endpointStore:    newEndpointStore(),
+		variableStore:    newVariableStore(),
+		silenceStore:     newSilenceStore(),
+		roleStore:        newRoleStore(),
+		alertAckStore:    newAlertAcknowledgementStore(),
+		orgSettingsStore: newOrgSettingsStore(),
+		Migrator:         NewMigrator(log),
 		urmByUserIndex: NewIndex(NewIndexMapping(
 			urmBucket,
 			urmByUserIndexBucket,
@@ -104,6 +112,42 @@ func NewService(log *zap.Logger, kv Store, configs ...ServiceConfig) *Service {
 		),
 		// add index user resource mappings by user id
 		s.urmByUserIndex.Migration(),
+		// add silences bucket
+		NewAnonymousMigration(
+			"create silences bucket",
+			s.initializeSilences,
+			// down is a noop
+			func(context.Context, Store) error {
+				return nil
+			},
+		),
+		// add alert acknowledgements bucket
+		NewAnonymousMigration(
+			"create alert acknowledgements bucket",
+			s.initializeAlertAcknowledgements,
+			// down is a noop
+			func(context.Context, Store) error {
+				return nil
+			},
+		),
+		// add organization settings bucket
+		NewAnonymousMigration(
+			"create organization settings bucket",
+			s.initializeOrgSettings,
+			// down is a noop
+			func(context.Context, Store) error {
+				return nil
+			},
+		),
+		// add roles bucket
+		NewAnonymousMigration(
+			"create roles bucket",
+			s.initializeRoles,
+			// down is a noop
+			func(context.Context, Store) error {
+				return nil
+			},
+		),
 		// and new migrations below here (and move this comment down):
 	)
 
@@ -115,6 +159,10 @@ func NewService(log *zap.Logger, kv Store, configs ...ServiceConfig) *Service {
 		s.Config.SessionLength = influxdb.DefaultSessionLength
 	}
 
+	if s.Config.TaskMaxBackfillRuns == 0 {
+		s.Config.TaskMaxBackfillRuns = influxdb.TaskDefaultMaxBackfillRuns
+	}
+
 	s.clock = s.Config.Clock
 	if s.clock == nil {
 		s.clock = clock.New()
@@ -130,6 +178,9 @@ type ServiceConfig struct {
 	Clock                         clock.Clock
 	URMByUserIndexReadPathEnabled bool
 	FluxLanguageService           influxdb.FluxLanguageService
+	// TaskMaxBackfillRuns is the maximum number of runs a single backfill may
+	// enqueue, influxdb.TaskDefaultMaxBackfillRuns if zero.
+	TaskMaxBackfillRuns int
 }
 
 // AutoMigrationStore is a Store which also describes whether or not
@@ -34,7 +34,8 @@ func TestNewService(t *testing.T) {
 	}
 
 	config := kv.ServiceConfig{
-		SessionLength: time.Duration(time.Hour * 4),
+		SessionLength:       time.Duration(time.Hour * 4),
+		TaskMaxBackfillRuns: 100,
 	}
 
 	s = kv.NewService(zaptest.NewLogger(t), mockStore{}, config)
@@ -141,6 +141,12 @@ func (s *Service) maxPermissions(ctx context.Context, tx Tx, userID influxdb.ID)
 
 		ps = append(ps, p...)
 	}
+
+	rps, err := s.rolePermissions(ctx, tx, mappings)
+	if err != nil {
+		return nil, err
+	}
+	ps = append(ps, rps...)
 	ps = append(ps, influxdb.MePermissions(userID)...)
 
 	if !s.disableAuthorizationsForMaxPermissions(ctx) {
@@ -152,6 +158,9 @@ func (s *Service) maxPermissions(ctx context.Context, tx Tx, userID influxdb.ID)
 			return nil, err
 		}
 		for _, a := range as {
+			if a.Expired() != nil {
+				continue
+			}
 			ps = append(ps, a.Permissions...)
 		}
 	}
@@ -0,0 +1,248 @@
+package kv
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.SilenceService = (*Service)(nil)
+
+func newSilenceStore() *StoreBase {
+	const resource = "silence"
+
+	var decodeSilenceEntFn DecodeBucketValFn = func(key, val []byte) ([]byte, interface{}, error) {
+		var s influxdb.Silence
+		return key, &s, json.Unmarshal(val, &s)
+	}
+
+	var decValToEntFn ConvertValToEntFn = func(_ []byte, i interface{}) (Entity, error) {
+		s, ok := i.(*influxdb.Silence)
+		if err := IsErrUnexpectedDecodeVal(ok); err != nil {
+			return Entity{}, err
+		}
+		return Entity{
+			PK:   EncID(s.ID),
+			Body: s,
+		}, nil
+	}
+
+	return NewStoreBase(resource, []byte("silencesv1"), EncIDKey, EncBodyJSON, decodeSilenceEntFn, decValToEntFn)
+}
+
+func (s *Service) initializeSilences(ctx context.Context, store Store) error {
+	return store.Update(ctx, func(tx Tx) error {
+		return s.silenceStore.Init(ctx, tx)
+	})
+}
+
+// FindSilenceByID returns a single silence by ID.
+func (s *Service) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	var silence *influxdb.Silence
+	err := s.kv.View(ctx, func(tx Tx) error {
+		sil, err := s.findSilenceByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		silence = sil
+		return nil
+	})
+	return silence, err
+}
+
+func (s *Service) findSilenceByID(ctx context.Context, tx Tx, id influxdb.ID) (*influxdb.Silence, error) {
+	body, err := s.silenceStore.FindEnt(ctx, tx, Entity{PK: EncID(id)})
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return nil, &influxdb.Error{
+				Code: influxdb.ENotFound,
+				Msg:  influxdb.ErrSilenceNotFound,
+			}
+		}
+		return nil, err
+	}
+
+	silence, ok := body.(*influxdb.Silence)
+	return silence, IsErrUnexpectedDecodeVal(ok)
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+func (s *Service) FindSilences(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+	var silences []*influxdb.Silence
+	err := s.kv.View(ctx, func(tx Tx) error {
+		sils, err := s.findSilences(ctx, tx, filter, opt...)
+		if err != nil {
+			return err
+		}
+		silences = sils
+		return nil
+	})
+	return silences, len(silences), err
+}
+
+func (s *Service) findSilences(ctx context.Context, tx Tx, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, error) {
+	if filter.Organization != nil {
+		o, err := s.findOrganizationByName(ctx, tx, *filter.Organization)
+		if err != nil {
+			return nil, err
+		}
+		filter.OrgID = &o.ID
+	}
+
+	var o influxdb.FindOptions
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+
+	silences := make([]*influxdb.Silence, 0)
+	err := s.silenceStore.Find(ctx, tx, FindOpts{
+		Descending:  o.Descending,
+		Limit:       o.Limit,
+		Offset:      o.Offset,
+		FilterEntFn: filterSilencesFn(filter),
+		CaptureFn: func(key []byte, decodedVal interface{}) error {
+			silences = append(silences, decodedVal.(*influxdb.Silence))
+			return nil
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return silences, nil
+}
+
+func filterSilencesFn(filter influxdb.SilenceFilter) func([]byte, interface{}) bool {
+	return func(key []byte, val interface{}) bool {
+		silence, ok := val.(*influxdb.Silence)
+		if !ok {
+			return false
+		}
+
+		if filter.OrgID != nil && silence.OrgID != *filter.OrgID {
+			return false
+		}
+
+		if filter.Active != nil && !silence.Active(*filter.Active) {
+			return false
+		}
+
+		return true
+	}
+}
+
+// findUnexpiredSilences returns the silences of the org that are active
+// now or will be in the future.
+func (s *Service) findUnexpiredSilences(ctx context.Context, tx Tx, orgID influxdb.ID) ([]*influxdb.Silence, error) {
+	silences, err := s.findSilences(ctx, tx, influxdb.SilenceFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.TimeGenerator.Now()
+	var unexpired []*influxdb.Silence
+	for _, sil := range silences {
+		if !sil.Expired(now) {
+			unexpired = append(unexpired, sil)
+		}
+	}
+	return unexpired, nil
+}
+
+// CreateSilence creates a new silence and sets sil.ID with the new identifier.
+func (s *Service) CreateSilence(ctx context.Context, sil *influxdb.Silence) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if err := sil.Valid(); err != nil {
+			return err
+		}
+
+		sil.ID = s.IDGenerator.ID()
+		now := s.TimeGenerator.Now()
+		sil.CreatedAt = now
+		sil.UpdatedAt = now
+		if err := s.putSilence(ctx, tx, sil, PutNew()); err != nil {
+			return err
+		}
+
+		return s.updateMatchingNotificationTasks(ctx, tx, sil.OrgID, sil.Matchers)
+	})
+}
+
+// UpdateSilence updates a single silence with a changeset.
+func (s *Service) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	var silence *influxdb.Silence
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		sil, err := s.findSilenceByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		prevMatchers := sil.Matchers
+		upd.Apply(sil)
+		if err := sil.Valid(); err != nil {
+			return err
+		}
+
+		sil.UpdatedAt = s.TimeGenerator.Now()
+		if err := s.putSilence(ctx, tx, sil, PutUpdate()); err != nil {
+			return err
+		}
+		silence = sil
+
+		return s.updateMatchingNotificationTasks(ctx, tx, sil.OrgID, prevMatchers, sil.Matchers)
+	})
+	return silence, err
+}
+
+func (s *Service) putSilence(ctx context.Context, tx Tx, sil *influxdb.Silence, putOpts ...PutOptionFn) error {
+	ent := Entity{
+		PK:   EncID(sil.ID),
+		Body: sil,
+	}
+	return s.silenceStore.Put(ctx, tx, ent, putOpts...)
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *Service) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		sil, err := s.findSilenceByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.silenceStore.DeleteEnt(ctx, tx, Entity{PK: EncID(id)}); err != nil {
+			return err
+		}
+
+		return s.updateMatchingNotificationTasks(ctx, tx, sil.OrgID, sil.Matchers)
+	})
+}
+
+// updateMatchingNotificationTasks regenerates the tasks of the notification
+// rules of the org that may read statuses with all the tags of one of the tag
+// sets, so that they consult its current silences and alert acknowledgements.
+func (s *Service) updateMatchingNotificationTasks(ctx context.Context, tx Tx, orgID influxdb.ID, tagSets ...[]influxdb.Tag) error {
+	var rules []influxdb.NotificationRule
+	err := s.forEachNotificationRule(ctx, tx, false, func(nr influxdb.NotificationRule) bool {
+		if nr.GetOrgID() != orgID {
+			return true
+		}
+		for _, tags := range tagSets {
+			if nr.MayMatchTags(tags) {
+				rules = append(rules, nr)
+				break
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, nr := range rules {
+		if _, err := s.updateNotificationTask(ctx, tx, nr, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package kv_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kv"
+	"github.com/influxdata/influxdb/v2/mock"
+	influxdbtesting "github.com/influxdata/influxdb/v2/testing"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestBoltSilenceService(t *testing.T) {
+	influxdbtesting.SilenceService(initBoltSilenceService, t)
+}
+
+func initBoltSilenceService(f influxdbtesting.SilenceFields, t *testing.T) (influxdb.SilenceService, func()) {
+	s, closeBolt, err := NewTestBoltStore(t)
+	if err != nil {
+		t.Fatalf("failed to create new kv store: %v", err)
+	}
+
+	svc, closeSvc := initSilenceService(s, f, t)
+	return svc, func() {
+		closeSvc()
+		closeBolt()
+	}
+}
+
+func initSilenceService(s kv.Store, f influxdbtesting.SilenceFields, t *testing.T) (influxdb.SilenceService, func()) {
+	svc := kv.NewService(zaptest.NewLogger(t), s)
+	svc.TimeGenerator = f.TimeGenerator
+	if svc.TimeGenerator == nil {
+		svc.TimeGenerator = influxdb.RealTimeGenerator{}
+	}
+
+	ctx := context.Background()
+	if err := svc.Initialize(ctx); err != nil {
+		t.Fatalf("error initializing silence service: %v", err)
+	}
+	for _, sil := range f.Silences {
+		svc.IDGenerator = mock.NewIDGenerator(sil.ID.String(), t)
+		if err := svc.CreateSilence(ctx, sil); err != nil {
+			t.Fatalf("failed to populate silences: %v", err)
+		}
+	}
+	svc.IDGenerator = f.IDGenerator
+
+	done := func() {
+		for _, sil := range f.Silences {
+			if err := svc.DeleteSilence(ctx, sil.ID); err != nil {
+				t.Logf("failed to clean up silences bolt test: %v", err)
+			}
+		}
+	}
+	return svc, done
+}
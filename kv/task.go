@@ -3,12 +3,15 @@ package kv
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"strings"
 	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	icontext "github.com/influxdata/influxdb/v2/context"
 	"github.com/influxdata/influxdb/v2/resource"
+	"github.com/influxdata/influxdb/v2/task/backend/scheduler"
 	"github.com/influxdata/influxdb/v2/task/options"
 	"go.uber.org/zap"
 )
@@ -19,6 +22,7 @@ import (
 // taskRunBucket:
 //   <taskID>/<runID>: run data storage
 //   <taskID>/manualRuns: list of runs to run manually
+//   <taskID>/backfill: IDs of the manual runs enqueued by backfills
 //   <taskID>/latestCompleted: run data for the latest completed run of a task
 // taskIndexBucket
 //   <orgID>/<taskID>: index for tasks by org
@@ -48,6 +52,7 @@ type kvTask struct {
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
 	LastRunError    string                 `json:"lastRunError,omitempty"`
 	Offset          influxdb.Duration      `json:"offset,omitempty"`
+	DependsOn       []influxdb.ID          `json:"dependsOn,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	CreatedAt       time.Time              `json:"createdAt,omitempty"`
@@ -71,6 +76,7 @@ func kvToInfluxTask(k *kvTask) *influxdb.Task {
 		LastRunStatus:   k.LastRunStatus,
 		LastRunError:    k.LastRunError,
 		Offset:          k.Offset.Duration,
+		DependsOn:       k.DependsOn,
 		LatestCompleted: k.LatestCompleted,
 		LatestScheduled: k.LatestScheduled,
 		CreatedAt:       k.CreatedAt,
@@ -630,6 +636,11 @@ func (s *Service) createTask(ctx context.Context, tx Tx, tc influxdb.TaskCreate)
 
 	}
 
+	task.DependsOn, err = s.taskDependencies(ctx, tx, task, opt.DependsOn)
+	if err != nil {
+		return nil, err
+	}
+
 	taskBucket, err := tx.Bucket(taskBucket)
 	if err != nil {
 		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
@@ -713,6 +724,115 @@ func (s *Service) createTaskURM(ctx context.Context, tx Tx, t *influxdb.Task) er
 	})
 }
 
+// taskDependencies resolves the IDs of the tasks a task depends on. These tasks
+// must exist in the organization of the task, share its schedule and must not
+// depend on it themselves.
+func (s *Service) taskDependencies(ctx context.Context, tx Tx, t *influxdb.Task, dependsOn []string) ([]influxdb.ID, error) {
+	if len(dependsOn) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]influxdb.ID, 0, len(dependsOn))
+	for _, dep := range dependsOn {
+		id, err := influxdb.IDFromString(dep)
+		if err != nil {
+			return nil, influxdb.ErrTaskDependencyInvalid(fmt.Errorf("%q is not a valid task ID", dep))
+		}
+		if *id == t.ID {
+			return nil, influxdb.ErrTaskDependencyInvalid(errors.New("a task cannot depend on itself"))
+		}
+
+		upstream, err := s.findTaskByID(ctx, tx, *id)
+		if err == influxdb.ErrTaskNotFound {
+			return nil, influxdb.ErrTaskDependencyInvalid(fmt.Errorf("task %s does not exist", id))
+		}
+		if err != nil {
+			return nil, err
+		}
+		if upstream.OrganizationID != t.OrganizationID {
+			return nil, influxdb.ErrTaskDependencyInvalid(fmt.Errorf("task %s belongs to another organization", id))
+		}
+		// runs wait on the runs of their upstream tasks scheduled for the same time
+		if !sameSchedule(upstream, t) {
+			return nil, influxdb.ErrTaskDependencyInvalid(fmt.Errorf("task %s does not have the schedule of the task", id))
+		}
+		ids = append(ids, *id)
+	}
+
+	// walk up the dependencies to make sure they don't lead back to the task
+	visited := make(map[influxdb.ID]bool)
+	queue := append([]influxdb.ID(nil), ids...)
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if id == t.ID {
+			return nil, influxdb.ErrTaskDependencyInvalid(errors.New("dependencies of the task form a cycle"))
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		upstream, err := s.findTaskByID(ctx, tx, id)
+		if err == influxdb.ErrTaskNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, upstream.DependsOn...)
+	}
+
+	return ids, nil
+}
+
+// checkDependentSchedules makes sure the tasks of the organization depending
+// on the task share its schedule.
+func (s *Service) checkDependentSchedules(ctx context.Context, tx Tx, t *influxdb.Task) error {
+	indexBucket, err := tx.Bucket(taskIndexBucket)
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	prefix, err := t.OrganizationID.Encode()
+	if err != nil {
+		return influxdb.ErrInvalidTaskID
+	}
+
+	c, err := indexBucket.ForwardCursor(prefix, WithCursorPrefix(prefix))
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	defer c.Close()
+
+	for k, v := c.Next(); k != nil; k, v = c.Next() {
+		id, err := influxdb.IDFromString(string(v))
+		if err != nil {
+			return influxdb.ErrInvalidTaskID
+		}
+
+		downstream, err := s.findTaskByID(ctx, tx, *id)
+		if err == influxdb.ErrTaskNotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, upID := range downstream.DependsOn {
+			if upID == t.ID && !sameSchedule(downstream, t) {
+				return influxdb.ErrTaskDependencyInvalid(fmt.Errorf("task %s depends on the task and does not have its schedule", downstream.ID))
+			}
+		}
+	}
+
+	return c.Err()
+}
+
+// sameSchedule returns whether the tasks are scheduled for the same times.
+func sameSchedule(a, b *influxdb.Task) bool {
+	return a.Cron == b.Cron && a.Every == b.Every && a.Offset == b.Offset
+}
+
 // UpdateTask updates a single task with changeset.
 func (s *Service) UpdateTask(ctx context.Context, id influxdb.ID, upd influxdb.TaskUpdate) (*influxdb.Task, error) {
 	var t *influxdb.Task
@@ -763,6 +883,14 @@ func (s *Service) updateTask(ctx context.Context, tx Tx, id influxdb.ID, upd inf
 			}
 		}
 		task.Offset = off
+
+		task.DependsOn, err = s.taskDependencies(ctx, tx, task, options.DependsOn)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.checkDependentSchedules(ctx, tx, task); err != nil {
+			return nil, err
+		}
 		task.UpdatedAt = updatedAt
 	}
 
@@ -908,6 +1036,16 @@ func (s *Service) deleteTask(ctx context.Context, tx Tx, id influxdb.ID) error {
 		return influxdb.ErrUnexpectedTaskBucketErr(err)
 	}
 
+	// remove the backfill
+	backfillKey, err := taskBackfillKey(task.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := runBucket.Delete(backfillKey); err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
 	// remove the runs
 	runs, _, err := s.findRuns(ctx, tx, influxdb.RunFilter{Task: task.ID})
 	if err != nil {
@@ -1023,6 +1161,11 @@ func (s *Service) findRuns(ctx context.Context, tx Tx, filter influxdb.RunFilter
 		return nil, 0, influxdb.ErrOutOfBoundsLimit
 	}
 
+	inRange, err := runTimeRange(filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
 	var runs []*influxdb.Run
 	// manual runs
 	manualRuns, err := s.manualRuns(ctx, tx, filter.Task)
@@ -1030,6 +1173,9 @@ func (s *Service) findRuns(ctx context.Context, tx Tx, filter influxdb.RunFilter
 		return nil, 0, err
 	}
 	for _, run := range manualRuns {
+		if !inRange(run) {
+			continue
+		}
 		runs = append(runs, run)
 		if len(runs) >= filter.Limit {
 			return runs, len(runs), nil
@@ -1042,6 +1188,9 @@ func (s *Service) findRuns(ctx context.Context, tx Tx, filter influxdb.RunFilter
 		return nil, 0, err
 	}
 	for _, run := range currentlyRunning {
+		if !inRange(run) {
+			continue
+		}
 		runs = append(runs, run)
 		if len(runs) >= filter.Limit {
 			return runs, len(runs), nil
@@ -1051,6 +1200,35 @@ func (s *Service) findRuns(ctx context.Context, tx Tx, filter influxdb.RunFilter
 	return runs, len(runs), nil
 }
 
+// runTimeRange returns whether a run is scheduled strictly between the
+// AfterTime and BeforeTime of the filter.
+func runTimeRange(filter influxdb.RunFilter) (func(*influxdb.Run) bool, error) {
+	var after, before time.Time
+	if filter.AfterTime != "" {
+		t, err := time.Parse(time.RFC3339, filter.AfterTime)
+		if err != nil {
+			return nil, &influxdb.Error{Code: influxdb.EInvalid, Msg: "afterTime is not an RFC3339 time", Err: err}
+		}
+		after = t
+	}
+	if filter.BeforeTime != "" {
+		t, err := time.Parse(time.RFC3339, filter.BeforeTime)
+		if err != nil {
+			return nil, &influxdb.Error{Code: influxdb.EInvalid, Msg: "beforeTime is not an RFC3339 time", Err: err}
+		}
+		before = t
+	}
+	return func(run *influxdb.Run) bool {
+		if !after.IsZero() && !run.ScheduledFor.After(after) {
+			return false
+		}
+		if !before.IsZero() && !run.ScheduledFor.Before(before) {
+			return false
+		}
+		return true
+	}, nil
+}
+
 // FindRunByID returns a single run.
 func (s *Service) FindRunByID(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error) {
 	var run *influxdb.Run
@@ -1273,6 +1451,238 @@ func (s *Service) forceRun(ctx context.Context, tx Tx, taskID influxdb.ID, sched
 	return r, nil
 }
 
+// BackfillTask enqueues a run for every time the task is scheduled for within [start, stop).
+// Times that already have a run enqueued are skipped.
+func (s *Service) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+	var runs []*influxdb.Run
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		rs, err := s.backfillTask(ctx, tx, taskID, start, stop)
+		if err != nil {
+			return err
+		}
+		runs = rs
+		return nil
+	})
+	return runs, err
+}
+
+func (s *Service) backfillTask(ctx context.Context, tx Tx, taskID influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+	start, stop = start.UTC().Truncate(time.Second), stop.UTC().Truncate(time.Second)
+	if !start.Before(stop) {
+		return nil, influxdb.ErrTaskBackfillInvalid("start must be before stop")
+	}
+	if stop.After(s.clock.Now().UTC()) {
+		return nil, influxdb.ErrTaskBackfillInvalid("stop must not be in the future")
+	}
+
+	task, err := s.findTaskByID(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	sch, ts, err := scheduler.NewSchedule(task.EffectiveCron(), start.Add(-time.Second))
+	if err != nil {
+		return nil, influxdb.ErrTaskTimeParse(err)
+	}
+
+	runs, err := s.manualRuns(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	queued := make(map[int64]bool, len(runs))
+	for _, run := range runs {
+		queued[run.ScheduledFor.Unix()] = true
+	}
+
+	var backfilled []*influxdb.Run
+	for {
+		ts, err = sch.Next(ts)
+		if err != nil {
+			return nil, influxdb.ErrTaskTimeParse(err)
+		}
+		if !ts.Before(stop) {
+			break
+		}
+		if ts.Before(start) || queued[ts.Unix()] {
+			continue
+		}
+		if len(backfilled) == s.Config.TaskMaxBackfillRuns {
+			return nil, influxdb.ErrTaskBackfillInvalid(fmt.Sprintf("range has more than %d runs", s.Config.TaskMaxBackfillRuns))
+		}
+
+		backfilled = append(backfilled, &influxdb.Run{
+			ID:           s.IDGenerator.ID(),
+			TaskID:       taskID,
+			Status:       influxdb.RunScheduled.String(),
+			RequestedAt:  time.Now().UTC(),
+			ScheduledFor: ts,
+			Log:          []influxdb.Log{},
+		})
+	}
+	if len(backfilled) == 0 {
+		return backfilled, nil
+	}
+
+	if err := s.putManualRuns(ctx, tx, taskID, append(runs, backfilled...)); err != nil {
+		return nil, err
+	}
+
+	enqueued, err := s.backfillRuns(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.putBackfillRuns(ctx, tx, taskID, append(enqueued, backfilled...)); err != nil {
+		return nil, err
+	}
+	return backfilled, nil
+}
+
+// CancelBackfill removes the enqueued runs of the backfills of the task that have
+// not started yet.
+func (s *Service) CancelBackfill(ctx context.Context, taskID influxdb.ID) error {
+	return s.kv.Update(ctx, func(tx Tx) error {
+		if _, err := s.findTaskByID(ctx, tx, taskID); err != nil {
+			return err
+		}
+
+		backfilled, err := s.backfillRuns(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		ids := make(map[influxdb.ID]bool, len(backfilled))
+		for _, run := range backfilled {
+			ids[run.ID] = true
+		}
+
+		runs, err := s.manualRuns(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		remaining := []*influxdb.Run{}
+		for _, run := range runs {
+			if !ids[run.ID] {
+				remaining = append(remaining, run)
+			}
+		}
+		if err := s.putManualRuns(ctx, tx, taskID, remaining); err != nil {
+			return err
+		}
+		return s.putBackfillRuns(ctx, tx, taskID, nil)
+	})
+}
+
+// FindBackfillRuns returns the runs of the backfills of the task that are still
+// enqueued, in the order they were enqueued in.
+func (s *Service) FindBackfillRuns(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error) {
+	var runs []*influxdb.Run
+	err := s.kv.View(ctx, func(tx Tx) error {
+		rs, err := s.backfillRuns(ctx, tx, taskID)
+		if err != nil {
+			return err
+		}
+		runs = rs
+		return nil
+	})
+	return runs, err
+}
+
+// backfillRuns returns the manual runs of the task that were enqueued by its
+// backfills. Runs that have been started since are no longer manual runs.
+func (s *Service) backfillRuns(ctx context.Context, tx Tx, taskID influxdb.ID) ([]*influxdb.Run, error) {
+	bucket, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	key, err := taskBackfillKey(taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []influxdb.ID
+	val, err := bucket.Get(key)
+	if err != nil {
+		if err == ErrKeyNotFound {
+			return []*influxdb.Run{}, nil
+		}
+		return nil, influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	if err := json.Unmarshal(val, &ids); err != nil {
+		return nil, influxdb.ErrInternalTaskServiceError(err)
+	}
+
+	runs, err := s.manualRuns(ctx, tx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	manual := make(map[influxdb.ID]*influxdb.Run, len(runs))
+	for _, run := range runs {
+		manual[run.ID] = run
+	}
+
+	backfilled := []*influxdb.Run{}
+	for _, id := range ids {
+		if run, ok := manual[id]; ok {
+			backfilled = append(backfilled, run)
+		}
+	}
+	return backfilled, nil
+}
+
+func (s *Service) putBackfillRuns(ctx context.Context, tx Tx, taskID influxdb.ID, runs []*influxdb.Run) error {
+	bucket, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	key, err := taskBackfillKey(taskID)
+	if err != nil {
+		return err
+	}
+
+	if len(runs) == 0 {
+		if err := bucket.Delete(key); err != nil {
+			return influxdb.ErrUnexpectedTaskBucketErr(err)
+		}
+		return nil
+	}
+
+	ids := make([]influxdb.ID, 0, len(runs))
+	for _, run := range runs {
+		ids = append(ids, run.ID)
+	}
+	idsBytes, err := json.Marshal(ids)
+	if err != nil {
+		return influxdb.ErrInternalTaskServiceError(err)
+	}
+
+	if err := bucket.Put(key, idsBytes); err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
+func (s *Service) putManualRuns(ctx context.Context, tx Tx, taskID influxdb.ID, runs []*influxdb.Run) error {
+	bucket, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	runsBytes, err := json.Marshal(runs)
+	if err != nil {
+		return influxdb.ErrInternalTaskServiceError(err)
+	}
+
+	key, err := taskManualRunKey(taskID)
+	if err != nil {
+		return err
+	}
+
+	if err := bucket.Put(key, runsBytes); err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+	return nil
+}
+
 // CreateRun creates a run with a scheduledFor time as now.
 func (s *Service) CreateRun(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error) {
 	var r *influxdb.Run
@@ -1575,7 +1985,7 @@ func (s *Service) updateRunState(ctx context.Context, tx Tx, taskID, runID influ
 	switch state {
 	case influxdb.RunStarted:
 		run.StartedAt = when
-	case influxdb.RunSuccess, influxdb.RunFail, influxdb.RunCanceled:
+	case influxdb.RunSuccess, influxdb.RunFail, influxdb.RunCanceled, influxdb.RunSkipped:
 		run.FinishedAt = when
 	}
 
@@ -1645,6 +2055,48 @@ func (s *Service) addRunLog(ctx context.Context, tx Tx, taskID, runID influxdb.I
 	return nil
 }
 
+// SetRunResults sets the rows captured from the results of the run.
+func (s *Service) SetRunResults(ctx context.Context, taskID, runID influxdb.ID, results []influxdb.RunResult) error {
+	err := s.kv.Update(ctx, func(tx Tx) error {
+		err := s.setRunResults(ctx, tx, taskID, runID, results)
+		if err != nil {
+			return err
+		}
+		return nil
+	})
+	return err
+}
+
+func (s *Service) setRunResults(ctx context.Context, tx Tx, taskID, runID influxdb.ID, results []influxdb.RunResult) error {
+	// find run
+	run, err := s.findRunByID(ctx, tx, taskID, runID)
+	if err != nil {
+		return err
+	}
+	run.Results = results
+	// save run
+	b, err := tx.Bucket(taskRunBucket)
+	if err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	runBytes, err := json.Marshal(run)
+	if err != nil {
+		return influxdb.ErrInternalTaskServiceError(err)
+	}
+
+	runKey, err := taskRunKey(taskID, run.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := b.Put(runKey, runBytes); err != nil {
+		return influxdb.ErrUnexpectedTaskBucketErr(err)
+	}
+
+	return nil
+}
+
 func taskKey(taskID influxdb.ID) ([]byte, error) {
 	encodedID, err := taskID.Encode()
 	if err != nil {
@@ -1669,6 +2121,14 @@ func taskManualRunKey(taskID influxdb.ID) ([]byte, error) {
 	return []byte(string(encodedID) + "/manualRuns"), nil
 }
 
+func taskBackfillKey(taskID influxdb.ID) ([]byte, error) {
+	encodedID, err := taskID.Encode()
+	if err != nil {
+		return nil, influxdb.ErrInvalidTaskID
+	}
+	return []byte(string(encodedID) + "/backfill"), nil
+}
+
 func taskOrgKey(orgID, taskID influxdb.ID) ([]byte, error) {
 	encodedOrgID, err := orgID.Encode()
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -244,6 +246,196 @@ func TestService_UpdateTask_InactiveToActive(t *testing.T) {
 	}
 }
 
+func TestService_TaskDependencies(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	ts := newService(t, ctx, nil)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	createTask := func(name string, dependsOn ...influxdb.ID) (*influxdb.Task, error) {
+		opts := fmt.Sprintf("name: %q, every: 1h", name)
+		if len(dependsOn) > 0 {
+			deps := make([]string, 0, len(dependsOn))
+			for _, id := range dependsOn {
+				deps = append(deps, fmt.Sprintf("%q", id))
+			}
+			opts += fmt.Sprintf(", dependsOn: [%s]", strings.Join(deps, ", "))
+		}
+		return ts.Service.CreateTask(ctx, influxdb.TaskCreate{
+			Flux:           fmt.Sprintf(`option task = {%s} from(bucket:"test") |> range(start:-1h)`, opts),
+			OrganizationID: ts.Org.ID,
+			OwnerID:        ts.User.ID,
+		})
+	}
+
+	upstream, err := createTask("upstream")
+	if err != nil {
+		t.Fatal("CreateTask", err)
+	}
+	downstream, err := createTask("downstream", upstream.ID)
+	if err != nil {
+		t.Fatal("CreateTask", err)
+	}
+	if exp := []influxdb.ID{upstream.ID}; !cmp.Equal(downstream.DependsOn, exp) {
+		t.Fatalf("unexpected dependencies -got/+exp\n%s", cmp.Diff(downstream.DependsOn, exp))
+	}
+
+	found, err := ts.Service.FindTaskByID(ctx, downstream.ID)
+	if err != nil {
+		t.Fatal("FindTaskByID", err)
+	}
+	if exp := []influxdb.ID{upstream.ID}; !cmp.Equal(found.DependsOn, exp) {
+		t.Fatalf("unexpected dependencies -got/+exp\n%s", cmp.Diff(found.DependsOn, exp))
+	}
+
+	if _, err := createTask("unknown", influxdb.ID(1)); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for unknown dependency, got %v", err)
+	}
+
+	flux := fmt.Sprintf(`option task = {name: "upstream", every: 1h, dependsOn: [%q]} from(bucket:"test") |> range(start:-1h)`, downstream.ID)
+	if _, err := ts.Service.UpdateTask(ctx, upstream.ID, influxdb.TaskUpdate{Flux: &flux}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for dependency cycle, got %v", err)
+	}
+
+	flux = fmt.Sprintf(`option task = {name: "upstream", every: 1h, dependsOn: [%q]} from(bucket:"test") |> range(start:-1h)`, upstream.ID)
+	if _, err := ts.Service.UpdateTask(ctx, upstream.ID, influxdb.TaskUpdate{Flux: &flux}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for self dependency, got %v", err)
+	}
+
+	_, err = ts.Service.CreateTask(ctx, influxdb.TaskCreate{
+		Flux:           fmt.Sprintf(`option task = {name: "daily", every: 1d, dependsOn: [%q]} from(bucket:"test") |> range(start:-1h)`, upstream.ID),
+		OrganizationID: ts.Org.ID,
+		OwnerID:        ts.User.ID,
+	})
+	if influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for dependency with another schedule, got %v", err)
+	}
+
+	flux = `option task = {name: "upstream", every: 1h, offset: 10m} from(bucket:"test") |> range(start:-1h)`
+	if _, err := ts.Service.UpdateTask(ctx, upstream.ID, influxdb.TaskUpdate{Flux: &flux}); influxdb.ErrorCode(err) != influxdb.EInvalid {
+		t.Fatalf("expected invalid error for schedule change of a dependency, got %v", err)
+	}
+}
+
+func TestService_BackfillTask(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	c := clock.NewMock()
+	c.Set(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	ts := newService(t, ctx, c)
+	defer ts.Close()
+
+	ctx = icontext.SetAuthorizer(ctx, &ts.Auth)
+
+	task, err := ts.Service.CreateTask(ctx, influxdb.TaskCreate{
+		Flux:           `option task = {name: "backfill", every: 1h} from(bucket:"test") |> range(start:-1h)`,
+		OrganizationID: ts.Org.ID,
+		OwnerID:        ts.User.ID,
+	})
+	if err != nil {
+		t.Fatal("CreateTask", err)
+	}
+
+	start := time.Date(2020, 6, 1, 0, 30, 0, 0, time.UTC)
+	runs, err := ts.Service.BackfillTask(ctx, task.ID, start, start.Add(3*time.Hour))
+	if err != nil {
+		t.Fatal("BackfillTask", err)
+	}
+	var scheduledFor []time.Time
+	for _, r := range runs {
+		if r.Status != influxdb.RunScheduled.String() {
+			t.Fatalf("expected run to be scheduled, got %q", r.Status)
+		}
+		scheduledFor = append(scheduledFor, r.ScheduledFor)
+	}
+	exp := []time.Time{
+		time.Date(2020, 6, 1, 1, 0, 0, 0, time.UTC),
+		time.Date(2020, 6, 1, 2, 0, 0, 0, time.UTC),
+		time.Date(2020, 6, 1, 3, 0, 0, 0, time.UTC),
+	}
+	if !cmp.Equal(scheduledFor, exp) {
+		t.Fatalf("unexpected scheduled for times -got/+exp\n%s", cmp.Diff(scheduledFor, exp))
+	}
+
+	// the times that are already enqueued are skipped
+	runs, err = ts.Service.BackfillTask(ctx, task.ID, start, start.Add(4*time.Hour))
+	if err != nil {
+		t.Fatal("BackfillTask", err)
+	}
+	if len(runs) != 1 || !runs[0].ScheduledFor.Equal(time.Date(2020, 6, 1, 4, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected a single run scheduled for 04:00, got %v", runs)
+	}
+
+	manual, err := ts.Service.ManualRuns(ctx, task.ID)
+	if err != nil {
+		t.Fatal("ManualRuns", err)
+	}
+	if len(manual) != 4 {
+		t.Fatalf("expected 4 manual runs, got %d", len(manual))
+	}
+
+	// the backfill is persisted, without the runs that have started since
+	if _, err := ts.Service.StartManualRun(ctx, task.ID, runs[0].ID); err != nil {
+		t.Fatal("StartManualRun", err)
+	}
+	backfilled, err := ts.Service.FindBackfillRuns(ctx, task.ID)
+	if err != nil {
+		t.Fatal("FindBackfillRuns", err)
+	}
+	if len(backfilled) != 3 {
+		t.Fatalf("expected 3 backfilled runs, got %d", len(backfilled))
+	}
+
+	forced, err := ts.Service.ForceRun(ctx, task.ID, time.Date(2020, 6, 1, 6, 0, 0, 0, time.UTC).Unix())
+	if err != nil {
+		t.Fatal("ForceRun", err)
+	}
+	if err := ts.Service.CancelBackfill(ctx, task.ID); err != nil {
+		t.Fatal("CancelBackfill", err)
+	}
+	manual, err = ts.Service.ManualRuns(ctx, task.ID)
+	if err != nil {
+		t.Fatal("ManualRuns", err)
+	}
+	if len(manual) != 1 || manual[0].ID != forced.ID {
+		t.Fatalf("expected only the forced run after cancelling the backfill, got %v", manual)
+	}
+	backfilled, err = ts.Service.FindBackfillRuns(ctx, task.ID)
+	if err != nil {
+		t.Fatal("FindBackfillRuns", err)
+	}
+	if len(backfilled) != 0 {
+		t.Fatalf("expected no backfilled runs after cancelling the backfill, got %d", len(backfilled))
+	}
+
+	// 90 days of hourly runs fit in a single backfill
+	runs, err = ts.Service.BackfillTask(ctx, task.ID, start.Add(-90*24*time.Hour), start)
+	if err != nil {
+		t.Fatal("BackfillTask", err)
+	}
+	if len(runs) != 90*24 {
+		t.Fatalf("expected %d runs, got %d", 90*24, len(runs))
+	}
+
+	for _, tt := range []struct {
+		name        string
+		start, stop time.Time
+	}{
+		{name: "stop before start", start: start, stop: start.Add(-time.Hour)},
+		{name: "stop in the future", start: start, stop: c.Now().Add(time.Hour)},
+		{name: "too many runs", start: start.Add(-time.Duration(influxdb.TaskDefaultMaxBackfillRuns+1) * time.Hour), stop: start},
+	} {
+		if _, err := ts.Service.BackfillTask(ctx, task.ID, tt.start, tt.stop); influxdb.ErrorCode(err) != influxdb.EInvalid {
+			t.Errorf("%s: expected invalid error, got %v", tt.name, err)
+		}
+	}
+}
+
 func TestTaskRunCancellation(t *testing.T) {
 	store, close, err := NewTestBoltStore(t)
 	if err != nil {
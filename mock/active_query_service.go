@@ -0,0 +1,48 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.ActiveQueryService = (*ActiveQueryService)(nil)
+
+// ActiveQueryService is a mock implementation of influxdb.ActiveQueryService.
+type ActiveQueryService struct {
+	FindActiveQueryByIDFn func(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error)
+	FindActiveQueriesFn   func(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error)
+	CancelActiveQueryFn   func(ctx context.Context, id influxdb.ID) error
+}
+
+// NewActiveQueryService returns a mock ActiveQueryService where its methods will return
+// zero values.
+func NewActiveQueryService() *ActiveQueryService {
+	return &ActiveQueryService{
+		FindActiveQueryByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		FindActiveQueriesFn: func(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		CancelActiveQueryFn: func(ctx context.Context, id influxdb.ID) error {
+			return fmt.Errorf("not implemented")
+		},
+	}
+}
+
+// FindActiveQueryByID returns a single active query by ID.
+func (s *ActiveQueryService) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+	return s.FindActiveQueryByIDFn(ctx, id)
+}
+
+// FindActiveQueries returns the active queries that match filter.
+func (s *ActiveQueryService) FindActiveQueries(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+	return s.FindActiveQueriesFn(ctx, filter)
+}
+
+// CancelActiveQuery cancels an active query by ID.
+func (s *ActiveQueryService) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	return s.CancelActiveQueryFn(ctx, id)
+}
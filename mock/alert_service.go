@@ -0,0 +1,82 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification/alert"
+)
+
+var (
+	_ alert.Service                        = (*AlertService)(nil)
+	_ influxdb.AlertAcknowledgementService = (*AlertAcknowledgementService)(nil)
+)
+
+// AlertService is a mock implementation of alert.Service.
+type AlertService struct {
+	FindAlertsFn func(ctx context.Context, filter alert.Filter) ([]*alert.Alert, error)
+}
+
+// NewAlertService returns a mock AlertService where its methods will return
+// zero values.
+func NewAlertService() *AlertService {
+	return &AlertService{
+		FindAlertsFn: func(ctx context.Context, filter alert.Filter) ([]*alert.Alert, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+	}
+}
+
+// FindAlerts returns the alerts that match filter.
+func (s *AlertService) FindAlerts(ctx context.Context, filter alert.Filter) ([]*alert.Alert, error) {
+	return s.FindAlertsFn(ctx, filter)
+}
+
+// AlertAcknowledgementService is a mock implementation of influxdb.AlertAcknowledgementService.
+type AlertAcknowledgementService struct {
+	FindAlertAcknowledgementByIDFn func(ctx context.Context, id influxdb.ID) (*influxdb.AlertAcknowledgement, error)
+	FindAlertAcknowledgementsFn    func(ctx context.Context, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, int, error)
+	CreateAlertAcknowledgementFn   func(ctx context.Context, a *influxdb.AlertAcknowledgement) error
+	DeleteAlertAcknowledgementFn   func(ctx context.Context, id influxdb.ID) error
+}
+
+// NewAlertAcknowledgementService returns a mock AlertAcknowledgementService where its methods will return
+// zero values.
+func NewAlertAcknowledgementService() *AlertAcknowledgementService {
+	return &AlertAcknowledgementService{
+		FindAlertAcknowledgementByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.AlertAcknowledgement, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		FindAlertAcknowledgementsFn: func(ctx context.Context, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, int, error) {
+			return nil, 0, fmt.Errorf("not implemented")
+		},
+		CreateAlertAcknowledgementFn: func(ctx context.Context, a *influxdb.AlertAcknowledgement) error {
+			return fmt.Errorf("not implemented")
+		},
+		DeleteAlertAcknowledgementFn: func(ctx context.Context, id influxdb.ID) error {
+			return fmt.Errorf("not implemented")
+		},
+	}
+}
+
+// FindAlertAcknowledgementByID returns a single alert acknowledgement by ID.
+func (s *AlertAcknowledgementService) FindAlertAcknowledgementByID(ctx context.Context, id influxdb.ID) (*influxdb.AlertAcknowledgement, error) {
+	return s.FindAlertAcknowledgementByIDFn(ctx, id)
+}
+
+// FindAlertAcknowledgements returns a list of alert acknowledgements that match filter
+// and the total count of matching alert acknowledgements.
+func (s *AlertAcknowledgementService) FindAlertAcknowledgements(ctx context.Context, filter influxdb.AlertAcknowledgementFilter) ([]*influxdb.AlertAcknowledgement, int, error) {
+	return s.FindAlertAcknowledgementsFn(ctx, filter)
+}
+
+// CreateAlertAcknowledgement creates a new alert acknowledgement and sets a.ID with the new identifier.
+func (s *AlertAcknowledgementService) CreateAlertAcknowledgement(ctx context.Context, a *influxdb.AlertAcknowledgement) error {
+	return s.CreateAlertAcknowledgementFn(ctx, a)
+}
+
+// DeleteAlertAcknowledgement removes an alert acknowledgement by ID.
+func (s *AlertAcknowledgementService) DeleteAlertAcknowledgement(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteAlertAcknowledgementFn(ctx, id)
+}
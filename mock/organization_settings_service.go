@@ -0,0 +1,39 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.OrganizationSettingsService = (*OrganizationSettingsService)(nil)
+
+// OrganizationSettingsService is a mock implementation of influxdb.OrganizationSettingsService.
+type OrganizationSettingsService struct {
+	FindOrganizationSettingsFn   func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error)
+	UpdateOrganizationSettingsFn func(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error)
+}
+
+// NewOrganizationSettingsService returns a mock OrganizationSettingsService where its methods will return
+// zero values.
+func NewOrganizationSettingsService() *OrganizationSettingsService {
+	return &OrganizationSettingsService{
+		FindOrganizationSettingsFn: func(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		UpdateOrganizationSettingsFn: func(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+	}
+}
+
+// FindOrganizationSettings returns the settings of the organization.
+func (s *OrganizationSettingsService) FindOrganizationSettings(ctx context.Context, orgID influxdb.ID) (*influxdb.OrganizationSettings, error) {
+	return s.FindOrganizationSettingsFn(ctx, orgID)
+}
+
+// UpdateOrganizationSettings updates the settings of the organization with a changeset.
+func (s *OrganizationSettingsService) UpdateOrganizationSettings(ctx context.Context, orgID influxdb.ID, upd influxdb.OrganizationSettingsUpdate) (*influxdb.OrganizationSettings, error) {
+	return s.UpdateOrganizationSettingsFn(ctx, orgID, upd)
+}
@@ -0,0 +1,66 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.RoleService = (*RoleService)(nil)
+
+// RoleService is a mock implementation of influxdb.RoleService.
+type RoleService struct {
+	FindRoleByIDFn func(ctx context.Context, id influxdb.ID) (*influxdb.Role, error)
+	FindRolesFn    func(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error)
+	CreateRoleFn   func(ctx context.Context, r *influxdb.Role) error
+	UpdateRoleFn   func(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error)
+	DeleteRoleFn   func(ctx context.Context, id influxdb.ID) error
+}
+
+// NewRoleService returns a mock RoleService where its methods will return
+// zero values.
+func NewRoleService() *RoleService {
+	return &RoleService{
+		FindRoleByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		FindRolesFn: func(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+			return nil, 0, fmt.Errorf("not implemented")
+		},
+		CreateRoleFn: func(ctx context.Context, r *influxdb.Role) error {
+			return fmt.Errorf("not implemented")
+		},
+		UpdateRoleFn: func(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		DeleteRoleFn: func(ctx context.Context, id influxdb.ID) error {
+			return fmt.Errorf("not implemented")
+		},
+	}
+}
+
+// FindRoleByID returns a single role by ID.
+func (s *RoleService) FindRoleByID(ctx context.Context, id influxdb.ID) (*influxdb.Role, error) {
+	return s.FindRoleByIDFn(ctx, id)
+}
+
+// FindRoles returns a list of roles that match filter and the total count of matching roles.
+func (s *RoleService) FindRoles(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+	return s.FindRolesFn(ctx, filter, opt...)
+}
+
+// CreateRole creates a new role and sets r.ID with the new identifier.
+func (s *RoleService) CreateRole(ctx context.Context, r *influxdb.Role) error {
+	return s.CreateRoleFn(ctx, r)
+}
+
+// UpdateRole updates a single role with a changeset.
+func (s *RoleService) UpdateRole(ctx context.Context, id influxdb.ID, upd influxdb.RoleUpdate) (*influxdb.Role, error) {
+	return s.UpdateRoleFn(ctx, id, upd)
+}
+
+// DeleteRole removes a role by ID.
+func (s *RoleService) DeleteRole(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteRoleFn(ctx, id)
+}
@@ -0,0 +1,66 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.SilenceService = (*SilenceService)(nil)
+
+// SilenceService is a mock implementation of influxdb.SilenceService.
+type SilenceService struct {
+	FindSilenceByIDFn func(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error)
+	FindSilencesFn    func(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error)
+	CreateSilenceFn   func(ctx context.Context, s *influxdb.Silence) error
+	UpdateSilenceFn   func(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error)
+	DeleteSilenceFn   func(ctx context.Context, id influxdb.ID) error
+}
+
+// NewSilenceService returns a mock SilenceService where its methods will return
+// zero values.
+func NewSilenceService() *SilenceService {
+	return &SilenceService{
+		FindSilenceByIDFn: func(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		FindSilencesFn: func(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+			return nil, 0, fmt.Errorf("not implemented")
+		},
+		CreateSilenceFn: func(ctx context.Context, s *influxdb.Silence) error {
+			return fmt.Errorf("not implemented")
+		},
+		UpdateSilenceFn: func(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+			return nil, fmt.Errorf("not implemented")
+		},
+		DeleteSilenceFn: func(ctx context.Context, id influxdb.ID) error {
+			return fmt.Errorf("not implemented")
+		},
+	}
+}
+
+// FindSilenceByID returns a single silence by ID.
+func (s *SilenceService) FindSilenceByID(ctx context.Context, id influxdb.ID) (*influxdb.Silence, error) {
+	return s.FindSilenceByIDFn(ctx, id)
+}
+
+// FindSilences returns a list of silences that match filter and the total count of matching silences.
+func (s *SilenceService) FindSilences(ctx context.Context, filter influxdb.SilenceFilter, opt ...influxdb.FindOptions) ([]*influxdb.Silence, int, error) {
+	return s.FindSilencesFn(ctx, filter, opt...)
+}
+
+// CreateSilence creates a new silence and sets s.ID with the new identifier.
+func (s *SilenceService) CreateSilence(ctx context.Context, sil *influxdb.Silence) error {
+	return s.CreateSilenceFn(ctx, sil)
+}
+
+// UpdateSilence updates a single silence with a changeset.
+func (s *SilenceService) UpdateSilence(ctx context.Context, id influxdb.ID, upd influxdb.SilenceUpdate) (*influxdb.Silence, error) {
+	return s.UpdateSilenceFn(ctx, id, upd)
+}
+
+// DeleteSilence removes a silence by ID.
+func (s *SilenceService) DeleteSilence(ctx context.Context, id influxdb.ID) error {
+	return s.DeleteSilenceFn(ctx, id)
+}
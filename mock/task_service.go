@@ -12,28 +12,32 @@ var _ influxdb.TaskService = (*TaskService)(nil)
 var _ backend.TaskControlService = (*TaskControlService)(nil)
 
 type TaskService struct {
-	FindTaskByIDFn    func(context.Context, influxdb.ID) (*influxdb.Task, error)
-	FindTaskByIDCalls SafeCount
-	FindTasksFn       func(context.Context, influxdb.TaskFilter) ([]*influxdb.Task, int, error)
-	FindTasksCalls    SafeCount
-	CreateTaskFn      func(context.Context, influxdb.TaskCreate) (*influxdb.Task, error)
-	CreateTaskCalls   SafeCount
-	UpdateTaskFn      func(context.Context, influxdb.ID, influxdb.TaskUpdate) (*influxdb.Task, error)
-	UpdateTaskCalls   SafeCount
-	DeleteTaskFn      func(context.Context, influxdb.ID) error
-	DeleteTaskCalls   SafeCount
-	FindLogsFn        func(context.Context, influxdb.LogFilter) ([]*influxdb.Log, int, error)
-	FindLogsCalls     SafeCount
-	FindRunsFn        func(context.Context, influxdb.RunFilter) ([]*influxdb.Run, int, error)
-	FindRunsCalls     SafeCount
-	FindRunByIDFn     func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Run, error)
-	FindRunByIDCalls  SafeCount
-	CancelRunFn       func(context.Context, influxdb.ID, influxdb.ID) error
-	CancelRunCalls    SafeCount
-	RetryRunFn        func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Run, error)
-	RetryRunCalls     SafeCount
-	ForceRunFn        func(context.Context, influxdb.ID, int64) (*influxdb.Run, error)
-	ForceRunCalls     SafeCount
+	FindTaskByIDFn      func(context.Context, influxdb.ID) (*influxdb.Task, error)
+	FindTaskByIDCalls   SafeCount
+	FindTasksFn         func(context.Context, influxdb.TaskFilter) ([]*influxdb.Task, int, error)
+	FindTasksCalls      SafeCount
+	CreateTaskFn        func(context.Context, influxdb.TaskCreate) (*influxdb.Task, error)
+	CreateTaskCalls     SafeCount
+	UpdateTaskFn        func(context.Context, influxdb.ID, influxdb.TaskUpdate) (*influxdb.Task, error)
+	UpdateTaskCalls     SafeCount
+	DeleteTaskFn        func(context.Context, influxdb.ID) error
+	DeleteTaskCalls     SafeCount
+	FindLogsFn          func(context.Context, influxdb.LogFilter) ([]*influxdb.Log, int, error)
+	FindLogsCalls       SafeCount
+	FindRunsFn          func(context.Context, influxdb.RunFilter) ([]*influxdb.Run, int, error)
+	FindRunsCalls       SafeCount
+	FindRunByIDFn       func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Run, error)
+	FindRunByIDCalls    SafeCount
+	CancelRunFn         func(context.Context, influxdb.ID, influxdb.ID) error
+	CancelRunCalls      SafeCount
+	RetryRunFn          func(context.Context, influxdb.ID, influxdb.ID) (*influxdb.Run, error)
+	RetryRunCalls       SafeCount
+	ForceRunFn          func(context.Context, influxdb.ID, int64) (*influxdb.Run, error)
+	ForceRunCalls       SafeCount
+	BackfillTaskFn      func(context.Context, influxdb.ID, time.Time, time.Time) ([]*influxdb.Run, error)
+	BackfillTaskCalls   SafeCount
+	CancelBackfillFn    func(context.Context, influxdb.ID) error
+	CancelBackfillCalls SafeCount
 }
 
 func NewTaskService() *TaskService {
@@ -71,6 +75,12 @@ func NewTaskService() *TaskService {
 		ForceRunFn: func(ctx context.Context, id influxdb.ID, i int64) (*influxdb.Run, error) {
 			return nil, nil
 		},
+		BackfillTaskFn: func(ctx context.Context, id influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+			return nil, nil
+		},
+		CancelBackfillFn: func(ctx context.Context, id influxdb.ID) error {
+			return nil
+		},
 	}
 }
 
@@ -129,6 +139,16 @@ func (s *TaskService) ForceRun(ctx context.Context, taskID influxdb.ID, schedule
 	return s.ForceRunFn(ctx, taskID, scheduledFor)
 }
 
+func (s *TaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+	defer s.BackfillTaskCalls.IncrFn()()
+	return s.BackfillTaskFn(ctx, taskID, start, stop)
+}
+
+func (s *TaskService) CancelBackfill(ctx context.Context, taskID influxdb.ID) error {
+	defer s.CancelBackfillCalls.IncrFn()()
+	return s.CancelBackfillFn(ctx, taskID)
+}
+
 type TaskControlService struct {
 	CreateRunFn        func(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error)
 	CurrentlyRunningFn func(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
@@ -137,6 +157,7 @@ type TaskControlService struct {
 	FinishRunFn        func(ctx context.Context, taskID, runID influxdb.ID) (*influxdb.Run, error)
 	UpdateRunStateFn   func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, state influxdb.RunStatus) error
 	AddRunLogFn        func(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+	SetRunResultsFn    func(ctx context.Context, taskID, runID influxdb.ID, results []influxdb.RunResult) error
 }
 
 func (tcs *TaskControlService) CreateRun(ctx context.Context, taskID influxdb.ID, scheduledFor time.Time, runAt time.Time) (*influxdb.Run, error) {
@@ -160,3 +181,6 @@ func (tcs *TaskControlService) UpdateRunState(ctx context.Context, taskID, runID
 func (tcs *TaskControlService) AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error {
 	return tcs.AddRunLogFn(ctx, taskID, runID, when, log)
 }
+func (tcs *TaskControlService) SetRunResults(ctx context.Context, taskID, runID influxdb.ID, results []influxdb.RunResult) error {
+	return tcs.SetRunResultsFn(ctx, taskID, runID, results)
+}
@@ -28,9 +28,19 @@ type NotificationRule interface {
 	SetTaskID(id ID)
 	GetTaskID() ID
 	GetEndpointID() ID
+	// GetEscalationEndpointIDs returns the endpoint IDs of the escalation
+	// steps of the rule, in order.
+	GetEscalationEndpointIDs() []ID
 	GetLimit() *Limit
-	GenerateFlux(NotificationEndpoint) (string, error)
+	// GenerateFlux generates the flux script of the rule's task, notifying
+	// e and, for its escalation steps, escalations, and muting the statuses
+	// matched by silences and acknowledged alerts.
+	GenerateFlux(e NotificationEndpoint, escalations []NotificationEndpoint, silences []*Silence, acks []*AlertAcknowledgement) (string, error)
 	MatchesTags(tags []Tag) bool
+	// MayMatchTags returns false if the statuses with all the tags are never
+	// read by the rule, so that silences and acknowledged alerts of these
+	// tags do not concern it.
+	MayMatchTags(tags []Tag) bool
 }
 
 // NotificationRuleStore represents a service for managing notification rule.
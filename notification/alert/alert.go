@@ -0,0 +1,195 @@
+// Package alert derives the alerts of checks from the statuses they write
+// to the monitoring system bucket.
+package alert
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+)
+
+// DefaultLookback is how far back statuses are read when the filter does not
+// set Since.
+const DefaultLookback = 7 * 24 * time.Hour
+
+// Status is the state of an alert.
+type Status string
+
+// consts of alert statuses
+const (
+	Open         Status = "open"
+	Acknowledged Status = "acknowledged"
+	Resolved     Status = "resolved"
+)
+
+// Valid returns an error if the status is unknown.
+func (s Status) Valid() error {
+	switch s {
+	case Open, Acknowledged, Resolved:
+		return nil
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "alert status must be one of open, acknowledged or resolved",
+		}
+	}
+}
+
+// Alert is an incident of a series, that is the consecutive statuses written
+// by a check for a tag set at the same, non ok, level.
+type Alert struct {
+	OrgID      influxdb.ID             `json:"orgID"`
+	CheckID    influxdb.ID             `json:"checkID"`
+	CheckName  string                  `json:"checkName"`
+	Tags       []influxdb.Tag          `json:"tags"`
+	Level      notification.CheckLevel `json:"level"`
+	Message    string                  `json:"message"`
+	StartedAt  time.Time               `json:"startedAt"`
+	LastSeenAt time.Time               `json:"lastSeenAt"`
+	// ResolvedAt is the time the series left the level of the alert, if it did.
+	ResolvedAt      *time.Time                     `json:"resolvedAt,omitempty"`
+	Status          Status                         `json:"status"`
+	Acknowledgement *influxdb.AlertAcknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// Filter represents a set of filter that restrict the returned alerts.
+type Filter struct {
+	OrgID   influxdb.ID
+	CheckID *influxdb.ID
+	Levels  []notification.CheckLevel
+	Status  *Status
+	// Since is the earliest status read, it defaults to DefaultLookback ago.
+	Since time.Time
+}
+
+// Service represents a service for finding alerts.
+type Service interface {
+	// FindAlerts returns the alerts that match filter, the most recent first.
+	FindAlerts(ctx context.Context, filter Filter) ([]*Alert, error)
+}
+
+// CheckStatus is a single status written by a check.
+type CheckStatus struct {
+	CheckID   influxdb.ID
+	CheckName string
+	Tags      []influxdb.Tag
+	Level     notification.CheckLevel
+	Message   string
+	Time      time.Time
+}
+
+// Derive returns the alerts of the statuses of the org orgID, with the
+// acknowledgements acks applied. Statuses may be in any order.
+func Derive(orgID influxdb.ID, statuses []CheckStatus, acks []*influxdb.AlertAcknowledgement) []*Alert {
+	series := make(map[string][]CheckStatus)
+	var keys []string
+	for _, st := range statuses {
+		k := seriesKey(st.CheckID, st.Tags)
+		if _, ok := series[k]; !ok {
+			keys = append(keys, k)
+		}
+		series[k] = append(series[k], st)
+	}
+
+	alerts := make([]*Alert, 0)
+	for _, k := range keys {
+		sts := series[k]
+		sort.SliceStable(sts, func(i, j int) bool {
+			return sts[i].Time.Before(sts[j].Time)
+		})
+
+		var cur *Alert
+		for _, st := range sts {
+			if cur != nil && cur.Level == st.Level {
+				cur.LastSeenAt = st.Time
+				cur.Message = st.Message
+				continue
+			}
+			if cur != nil {
+				resolvedAt := st.Time
+				cur.ResolvedAt = &resolvedAt
+				cur = nil
+			}
+			if st.Level == notification.Ok || st.Level == notification.Unknown {
+				continue
+			}
+			cur = &Alert{
+				OrgID:      orgID,
+				CheckID:    st.CheckID,
+				CheckName:  st.CheckName,
+				Tags:       st.Tags,
+				Level:      st.Level,
+				Message:    st.Message,
+				StartedAt:  st.Time,
+				LastSeenAt: st.Time,
+			}
+			alerts = append(alerts, cur)
+		}
+	}
+
+	for _, a := range alerts {
+		a.Acknowledgement = findAcknowledgement(a, acks)
+		switch {
+		case a.ResolvedAt != nil:
+			a.Status = Resolved
+		case a.Acknowledgement == nil:
+			a.Status = Open
+		case a.Acknowledgement.Resolved:
+			a.Status = Resolved
+		default:
+			a.Status = Acknowledged
+		}
+	}
+
+	sort.SliceStable(alerts, func(i, j int) bool {
+		return alerts[i].StartedAt.After(alerts[j].StartedAt)
+	})
+	return alerts
+}
+
+// findAcknowledgement returns the acknowledgement of the alert a, if any.
+func findAcknowledgement(a *Alert, acks []*influxdb.AlertAcknowledgement) *influxdb.AlertAcknowledgement {
+	series := &influxdb.AlertAcknowledgement{OrgID: a.OrgID, CheckID: a.CheckID, Tags: a.Tags}
+	for _, ack := range acks {
+		if !ack.SameSeries(series) || ack.Level != a.Level.String() {
+			continue
+		}
+		if ack.StartedAt.Before(a.StartedAt) || ack.StartedAt.After(a.LastSeenAt) {
+			continue
+		}
+		return ack
+	}
+	return nil
+}
+
+// Match returns true if the alert a matches the filter f.
+func (f Filter) Match(a *Alert) bool {
+	if f.CheckID != nil && a.CheckID != *f.CheckID {
+		return false
+	}
+	if f.Status != nil && a.Status != *f.Status {
+		return false
+	}
+	if len(f.Levels) == 0 {
+		return true
+	}
+	for _, l := range f.Levels {
+		if a.Level == l {
+			return true
+		}
+	}
+	return false
+}
+
+func seriesKey(checkID influxdb.ID, tags []influxdb.Tag) string {
+	pairs := make([]string, 0, len(tags))
+	for _, t := range tags {
+		pairs = append(pairs, t.Key+"="+t.Value)
+	}
+	sort.Strings(pairs)
+	return checkID.String() + "," + strings.Join(pairs, ",")
+}
@@ -0,0 +1,251 @@
+package alert_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/alert"
+)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestDerive(t *testing.T) {
+	t0 := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time {
+		return t0.Add(time.Duration(m) * time.Minute)
+	}
+	db01 := []influxdb.Tag{{Key: "host", Value: "db01"}}
+	db02 := []influxdb.Tag{{Key: "host", Value: "db02"}}
+	status := func(tags []influxdb.Tag, level notification.CheckLevel, m int) alert.CheckStatus {
+		return alert.CheckStatus{
+			CheckID:   4,
+			CheckName: "cpu",
+			Tags:      tags,
+			Level:     level,
+			Message:   level.String() + " on " + tags[0].Value,
+			Time:      at(m),
+		}
+	}
+
+	tests := []struct {
+		name     string
+		statuses []alert.CheckStatus
+		acks     []*influxdb.AlertAcknowledgement
+		want     []*alert.Alert
+	}{
+		{
+			name: "no alerts when ok",
+			statuses: []alert.CheckStatus{
+				status(db01, notification.Ok, 0),
+				status(db01, notification.Ok, 1),
+			},
+			want: []*alert.Alert{},
+		},
+		{
+			name: "open and resolved alerts",
+			statuses: []alert.CheckStatus{
+				status(db01, notification.Ok, 0),
+				status(db01, notification.Critical, 1),
+				status(db01, notification.Critical, 2),
+				status(db01, notification.Ok, 3),
+				status(db02, notification.Warn, 2),
+			},
+			want: []*alert.Alert{
+				{
+					OrgID:      3,
+					CheckID:    4,
+					CheckName:  "cpu",
+					Tags:       db02,
+					Level:      notification.Warn,
+					Message:    "WARN on db02",
+					StartedAt:  at(2),
+					LastSeenAt: at(2),
+					Status:     alert.Open,
+				},
+				{
+					OrgID:      3,
+					CheckID:    4,
+					CheckName:  "cpu",
+					Tags:       db01,
+					Level:      notification.Critical,
+					Message:    "CRIT on db01",
+					StartedAt:  at(1),
+					LastSeenAt: at(2),
+					ResolvedAt: timePtr(at(3)),
+					Status:     alert.Resolved,
+				},
+			},
+		},
+		{
+			name: "level change opens a new alert",
+			statuses: []alert.CheckStatus{
+				status(db01, notification.Critical, 2),
+				status(db01, notification.Warn, 1),
+			},
+			want: []*alert.Alert{
+				{
+					OrgID:      3,
+					CheckID:    4,
+					CheckName:  "cpu",
+					Tags:       db01,
+					Level:      notification.Critical,
+					Message:    "CRIT on db01",
+					StartedAt:  at(2),
+					LastSeenAt: at(2),
+					Status:     alert.Open,
+				},
+				{
+					OrgID:      3,
+					CheckID:    4,
+					CheckName:  "cpu",
+					Tags:       db01,
+					Level:      notification.Warn,
+					Message:    "WARN on db01",
+					StartedAt:  at(1),
+					LastSeenAt: at(1),
+					ResolvedAt: timePtr(at(2)),
+					Status:     alert.Resolved,
+				},
+			},
+		},
+		{
+			name: "acknowledged and resolved by acknowledgement",
+			statuses: []alert.CheckStatus{
+				status(db01, notification.Critical, 1),
+				status(db01, notification.Critical, 2),
+				status(db02, notification.Critical, 1),
+			},
+			acks: []*influxdb.AlertAcknowledgement{
+				{ID: 10, OrgID: 3, CheckID: 4, Tags: db01, Level: "CRIT", StartedAt: at(1)},
+				{ID: 11, OrgID: 3, CheckID: 4, Tags: db02, Level: "CRIT", StartedAt: at(1), Resolved: true},
+				{ID: 12, OrgID: 3, CheckID: 4, Tags: db02, Level: "WARN", StartedAt: at(1)},
+			},
+			want: []*alert.Alert{
+				{
+					OrgID:           3,
+					CheckID:         4,
+					CheckName:       "cpu",
+					Tags:            db01,
+					Level:           notification.Critical,
+					Message:         "CRIT on db01",
+					StartedAt:       at(1),
+					LastSeenAt:      at(2),
+					Status:          alert.Acknowledged,
+					Acknowledgement: &influxdb.AlertAcknowledgement{ID: 10, OrgID: 3, CheckID: 4, Tags: db01, Level: "CRIT", StartedAt: at(1)},
+				},
+				{
+					OrgID:           3,
+					CheckID:         4,
+					CheckName:       "cpu",
+					Tags:            db02,
+					Level:           notification.Critical,
+					Message:         "CRIT on db02",
+					StartedAt:       at(1),
+					LastSeenAt:      at(1),
+					Status:          alert.Resolved,
+					Acknowledgement: &influxdb.AlertAcknowledgement{ID: 11, OrgID: 3, CheckID: 4, Tags: db02, Level: "CRIT", StartedAt: at(1), Resolved: true},
+				},
+			},
+		},
+		{
+			name: "acknowledgement of a previous alert",
+			statuses: []alert.CheckStatus{
+				status(db01, notification.Critical, 1),
+				status(db01, notification.Ok, 2),
+				status(db01, notification.Critical, 3),
+			},
+			acks: []*influxdb.AlertAcknowledgement{
+				{ID: 10, OrgID: 3, CheckID: 4, Tags: db01, Level: "CRIT", StartedAt: at(1)},
+			},
+			want: []*alert.Alert{
+				{
+					OrgID:      3,
+					CheckID:    4,
+					CheckName:  "cpu",
+					Tags:       db01,
+					Level:      notification.Critical,
+					Message:    "CRIT on db01",
+					StartedAt:  at(3),
+					LastSeenAt: at(3),
+					Status:     alert.Open,
+				},
+				{
+					OrgID:           3,
+					CheckID:         4,
+					CheckName:       "cpu",
+					Tags:            db01,
+					Level:           notification.Critical,
+					Message:         "CRIT on db01",
+					StartedAt:       at(1),
+					LastSeenAt:      at(1),
+					ResolvedAt:      timePtr(at(2)),
+					Status:          alert.Resolved,
+					Acknowledgement: &influxdb.AlertAcknowledgement{ID: 10, OrgID: 3, CheckID: 4, Tags: db01, Level: "CRIT", StartedAt: at(1)},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := alert.Derive(3, tt.statuses, tt.acks)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("alerts are different -want/+got\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	checkID := influxdb.ID(4)
+	otherCheckID := influxdb.ID(5)
+	acknowledged := alert.Acknowledged
+	a := &alert.Alert{
+		CheckID: 4,
+		Level:   notification.Warn,
+		Status:  alert.Acknowledged,
+	}
+
+	tests := []struct {
+		name   string
+		filter alert.Filter
+		want   bool
+	}{
+		{
+			name: "empty filter",
+			want: true,
+		},
+		{
+			name: "matching filter",
+			filter: alert.Filter{
+				CheckID: &checkID,
+				Levels:  []notification.CheckLevel{notification.Critical, notification.Warn},
+				Status:  &acknowledged,
+			},
+			want: true,
+		},
+		{
+			name: "other levels",
+			filter: alert.Filter{
+				Levels: []notification.CheckLevel{notification.Critical},
+			},
+		},
+		{
+			name: "other check",
+			filter: alert.Filter{
+				CheckID: &otherCheckID,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Match(a); got != tt.want {
+				t.Errorf("Match() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
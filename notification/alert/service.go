@@ -0,0 +1,196 @@
+package alert
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/query"
+	"go.uber.org/zap"
+)
+
+// columns of the statuses that are not tags of the series.
+var nonTagColumns = map[string]bool{
+	"_start":              true,
+	"_stop":               true,
+	"_time":               true,
+	"_value":              true,
+	"_field":              true,
+	"_measurement":        true,
+	"_check_id":           true,
+	"_check_name":         true,
+	"_level":              true,
+	"_source_measurement": true,
+	"_type":               true,
+}
+
+var _ Service = (*StatusService)(nil)
+
+// StatusService finds alerts by reading the statuses of the monitoring
+// system bucket.
+type StatusService struct {
+	log           *zap.Logger
+	bucketService influxdb.BucketService
+	ackService    influxdb.AlertAcknowledgementService
+	qs            query.QueryService
+	now           func() time.Time
+}
+
+// NewStatusService constructs a new StatusService.
+func NewStatusService(log *zap.Logger, bs influxdb.BucketService, as influxdb.AlertAcknowledgementService, qs query.QueryService) *StatusService {
+	return &StatusService{
+		log:           log,
+		bucketService: bs,
+		ackService:    as,
+		qs:            qs,
+		now:           time.Now,
+	}
+}
+
+// FindAlerts returns the alerts that match filter, the most recent first.
+func (s *StatusService) FindAlerts(ctx context.Context, filter Filter) ([]*Alert, error) {
+	if filter.Status != nil {
+		if err := filter.Status.Valid(); err != nil {
+			return nil, err
+		}
+	}
+	if filter.Since.IsZero() {
+		filter.Since = s.now().Add(-DefaultLookback)
+	}
+
+	sb, err := s.bucketService.FindBucketByName(ctx, filter.OrgID, influxdb.MonitoringSystemBucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := s.findStatuses(ctx, sb, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	acks, _, err := s.ackService.FindAlertAcknowledgements(ctx, influxdb.AlertAcknowledgementFilter{
+		OrgID:   &filter.OrgID,
+		CheckID: filter.CheckID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*Alert, 0)
+	for _, a := range Derive(filter.OrgID, statuses, acks) {
+		if filter.Match(a) {
+			alerts = append(alerts, a)
+		}
+	}
+	return alerts, nil
+}
+
+func (s *StatusService) findStatuses(ctx context.Context, sb *influxdb.Bucket, filter Filter) ([]CheckStatus, error) {
+	filterPart := ""
+	if filter.CheckID != nil {
+		filterPart = fmt.Sprintf(`|> filter(fn: (r) => r._check_id == %q)`, filter.CheckID.String())
+	}
+
+	statusesScript := fmt.Sprintf(`from(bucketID: %q)
+	  |> range(start: %s)
+	  |> filter(fn: (r) => r._measurement == "statuses" and r._field == "_message")
+	  %s
+	  `, sb.ID.String(), filter.Since.UTC().Format(time.RFC3339Nano), filterPart)
+
+	// At this point we are behind authorization
+	// so we are faking a read only permission to the org's monitoring bucket
+	monitoringBucketID := sb.ID
+	auth := &influxdb.Authorization{
+		Status: influxdb.Active,
+		ID:     sb.ID,
+		OrgID:  filter.OrgID,
+		Permissions: []influxdb.Permission{
+			{
+				Action: influxdb.ReadAction,
+				Resource: influxdb.Resource{
+					Type:  influxdb.BucketsResourceType,
+					OrgID: &filter.OrgID,
+					ID:    &monitoringBucketID,
+				},
+			},
+		},
+	}
+	request := &query.Request{Authorization: auth, OrganizationID: filter.OrgID, Compiler: lang.FluxCompiler{Query: statusesScript}}
+
+	ittr, err := s.qs.Query(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	defer ittr.Release()
+
+	sr := &statusReader{log: s.log.With(zap.String("component", "status-reader"))}
+	for ittr.More() {
+		if err := ittr.Next().Tables().Do(sr.readTable); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ittr.Err(); err != nil {
+		return nil, fmt.Errorf("unexpected internal error while decoding statuses response: %v", err)
+	}
+	return sr.statuses, nil
+}
+
+type statusReader struct {
+	statuses []CheckStatus
+	log      *zap.Logger
+}
+
+func (sr *statusReader) readTable(tbl flux.Table) error {
+	return tbl.Do(sr.readStatuses)
+}
+
+func (sr *statusReader) readStatuses(cr flux.ColReader) error {
+	for i := 0; i < cr.Len(); i++ {
+		var st CheckStatus
+		for j, col := range cr.Cols() {
+			switch col.Label {
+			case "_check_id":
+				id, err := influxdb.IDFromString(cr.Strings(j).ValueString(i))
+				if err != nil {
+					sr.log.Info("Failed to parse check ID", zap.Error(err))
+					continue
+				}
+				st.CheckID = *id
+			case "_check_name":
+				st.CheckName = cr.Strings(j).ValueString(i)
+			case "_level":
+				st.Level = notification.ParseCheckLevel(strings.ToUpper(cr.Strings(j).ValueString(i)))
+			case "_value":
+				st.Message = cr.Strings(j).ValueString(i)
+			case "_time":
+				st.Time = time.Unix(0, cr.Times(j).Value(i)).UTC()
+			default:
+				if nonTagColumns[col.Label] || col.Type != flux.TString || cr.Strings(j).IsNull(i) {
+					continue
+				}
+				st.Tags = append(st.Tags, influxdb.Tag{
+					Key:   col.Label,
+					Value: cr.Strings(j).ValueString(i),
+				})
+			}
+		}
+
+		// statuses that are not of a check can't be grouped into alerts.
+		if !st.CheckID.Valid() {
+			continue
+		}
+		sort.Slice(st.Tags, func(a, b int) bool {
+			return st.Tags[a].Key < st.Tags[b].Key
+		})
+		sr.statuses = append(sr.statuses, st)
+	}
+
+	return nil
+}
@@ -0,0 +1,465 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/flux"
+	"github.com/influxdata/influxdb/v2/query"
+)
+
+// Anomaly detection methods.
+const (
+	// AnomalyStdDev scores the latest value by its distance from the mean
+	// of the window, in standard deviations.
+	AnomalyStdDev = "stddev"
+	// AnomalyMAD scores the latest value by its distance from the median
+	// of the window, in median absolute deviations scaled to match the
+	// standard deviation of normally distributed data.
+	AnomalyMAD = "mad"
+	// AnomalySeasonal scores the latest value by its distance from the mean
+	// of the values at the same point of the previous seasons, in standard
+	// deviations.
+	AnomalySeasonal = "seasonal"
+)
+
+// madScale makes the median absolute deviation a consistent estimator of
+// the standard deviation of normally distributed data.
+const madScale = 1.4826
+
+var _ influxdb.Check = (*Anomaly)(nil)
+
+// Anomaly is the anomaly detection check. It scores the latest value of
+// every series of its query against a baseline computed from the history
+// of the series.
+type Anomaly struct {
+	Base
+	Method string `json:"method"`
+	// Window is the history the stddev and mad baselines are computed from.
+	Window *notification.Duration `json:"window,omitempty"`
+	// Season is the period of the seasonal baseline, which is computed
+	// from the values at the same point of the previous Seasons periods.
+	Season  *notification.Duration `json:"season,omitempty"`
+	Seasons int                    `json:"seasons,omitempty"`
+	Levels  []AnomalyLevel         `json:"levels"`
+}
+
+// AnomalyLevel sets Level when the anomaly score is greater than
+// Sensitivity. The ok level is set when the score is lesser or equal.
+type AnomalyLevel struct {
+	Level       notification.CheckLevel `json:"level"`
+	Sensitivity float64                 `json:"sensitivity"`
+}
+
+// Type returns the type of the check.
+func (a Anomaly) Type() string {
+	return "anomaly"
+}
+
+// Valid returns error if something is invalid.
+func (a Anomaly) Valid(lang influxdb.FluxLanguageService) error {
+	if err := a.Base.Valid(lang); err != nil {
+		return err
+	}
+
+	switch a.Method {
+	case AnomalyStdDev, AnomalyMAD:
+		if a.Window == nil || len(a.Window.Values) == 0 {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check window is required",
+			}
+		}
+		if a.Window.TimeDuration() <= a.Every.TimeDuration() {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check window must be greater than every",
+			}
+		}
+	case AnomalySeasonal:
+		if a.Season == nil || len(a.Season.Values) == 0 {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check season is required",
+			}
+		}
+		if a.Season.TimeDuration() <= a.Every.TimeDuration() {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check season must be greater than every",
+			}
+		}
+		if a.Seasons < 1 {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check seasons must be at least 1",
+			}
+		}
+	default:
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid anomaly check method %q", a.Method),
+		}
+	}
+
+	if len(a.Levels) == 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "anomaly check must have at least one level",
+		}
+	}
+	seen := make(map[notification.CheckLevel]bool, len(a.Levels))
+	for _, l := range a.Levels {
+		switch l.Level {
+		case notification.Ok, notification.Info, notification.Warn, notification.Critical:
+		default:
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid anomaly check level %s", l.Level),
+			}
+		}
+		if seen[l.Level] {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("anomaly check level %s is duplicated", l.Level),
+			}
+		}
+		seen[l.Level] = true
+		if l.Sensitivity <= 0 {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check sensitivity must be greater than 0",
+			}
+		}
+	}
+	return nil
+}
+
+// GenerateFlux returns a flux script for the anomaly check provided. If there
+// are any errors in the flux that the user provided the function will return
+// an error for each error found when the script is parsed.
+func (a Anomaly) GenerateFlux(lang influxdb.FluxLanguageService) (string, error) {
+	p, err := a.GenerateFluxAST(lang)
+	if err != nil {
+		return "", err
+	}
+
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST returns a flux AST for the anomaly check provided. If there
+// are any errors in the flux that the user provided the function will return
+// an error for each error found when the script is parsed.
+func (a Anomaly) GenerateFluxAST(lang influxdb.FluxLanguageService) (*ast.Package, error) {
+	history, err := a.history()
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := query.Parse(lang, a.Query.Text)
+	if p == nil {
+		return nil, err
+	}
+	replaceDurationsWithEvery(p, a.Every)
+	replaceRangeStart(p, history)
+	removeStopFromRange(p)
+	addCreateEmptyFalseToAggregateWindow(p)
+
+	if errs := ast.GetErrors(p); len(errs) != 0 {
+		return nil, multiError(errs)
+	}
+
+	// the baseline and score statements are appended to the file of the query
+	// so that they can refer to its data pipeline.
+	if len(p.Files) != 1 {
+		return nil, fmt.Errorf("expect a single file to be returned from query parsing got %d", len(p.Files))
+	}
+
+	fields := getFields(p)
+	if len(fields) != 1 {
+		return nil, fmt.Errorf("expected a single field but got: %s", fields)
+	}
+
+	f := p.Files[0]
+	assignPipelineToData(f)
+
+	f.Imports = append(f.Imports, flux.Imports("influxdata/influxdb/monitor", "math")...)
+	f.Body = append(f.Body, a.generateFluxASTBody()...)
+
+	return p, nil
+}
+
+// history returns how far back the query reads to compute the baseline.
+func (a Anomaly) history() (*notification.Duration, error) {
+	if a.Method != AnomalySeasonal {
+		return a.Window, nil
+	}
+	// One more interval so that the oldest season is not cut by the range.
+	d := time.Duration(a.Seasons)*a.Season.TimeDuration() + a.Every.TimeDuration()
+	history, err := notification.FromTimeDuration(d)
+	if err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// replaceRangeStart makes the query read the history the baseline is computed from.
+func replaceRangeStart(pkg *ast.Package, start *notification.Duration) {
+	ast.Visit(pkg, func(n ast.Node) {
+		if p, ok := n.(*ast.Property); ok && p.Key.Key() == "start" {
+			d := (ast.DurationLiteral)(*start)
+			p.Value = flux.Negative(&d)
+		}
+	})
+}
+
+func (a Anomaly) generateFluxASTBody() []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, a.generateTaskOption())
+	statements = append(statements, a.generateFluxASTCheckDefinition("anomaly"))
+	statements = append(statements, a.generateFluxASTLevelFunctions()...)
+	statements = append(statements, a.generateFluxASTMessageFunction())
+	switch a.Method {
+	case AnomalyMAD:
+		statements = append(statements, a.generateFluxASTDeviations()...)
+	case AnomalySeasonal:
+		statements = append(statements, a.generateFluxASTInBaselineFunction())
+	}
+	return append(statements, a.generateFluxASTChecksFunction())
+}
+
+func (a Anomaly) generateFluxASTLevelFunctions() []ast.Statement {
+	statements := make([]ast.Statement, len(a.Levels))
+	for i, l := range a.Levels {
+		var fnBody ast.Expression = flux.GreaterThan(flux.Member("r", "_score"), flux.Float(l.Sensitivity))
+		if l.Level == notification.Ok {
+			fnBody = flux.LessThanEqual(flux.Member("r", "_score"), flux.Float(l.Sensitivity))
+		}
+		lvl := strings.ToLower(l.Level.String())
+		statements[i] = flux.DefineVariable(lvl, flux.Function(flux.FunctionParams("r"), fnBody))
+	}
+	return statements
+}
+
+// generateFluxASTInBaselineFunction defines the function that tells whether
+// a value is at the same point of one of the previous seasons.
+func (a Anomaly) generateFluxASTInBaselineFunction() ast.Statement {
+	age := func() ast.Expression {
+		return flux.Subtract(
+			flux.Call(flux.Identifier("int"), flux.Object(flux.Property("v", flux.Call(flux.Identifier("now"), flux.Object())))),
+			flux.Call(flux.Identifier("int"), flux.Object(flux.Property("v", flux.Member("r", "_time")))),
+		)
+	}
+	season := flux.Integer(a.Season.TimeDuration().Nanoseconds())
+	every := flux.Integer(a.Every.TimeDuration().Nanoseconds())
+
+	fnBody := flux.And(
+		flux.GreaterThanEqual(age(), season),
+		flux.LessThan(flux.Modulo(age(), season), every),
+	)
+	return flux.DefineVariable("inBaseline", flux.Function(flux.FunctionParams("r"), fnBody))
+}
+
+// generateFluxASTDeviations defines the absolute deviations of the values
+// from the median of their series, the median of those deviations and the
+// latest value of the series.
+// Flux can't join the tables of a series with its aggregates without naming
+// the tag columns, so the aggregates are unioned into the series as their
+// first row and filled forward.
+func (a Anomaly) generateFluxASTDeviations() []ast.Statement {
+	medians := flux.Pipe(
+		flux.Identifier("data"),
+		flux.Call(flux.Identifier("median"), flux.Object()),
+		flux.Call(flux.Identifier("rename"), flux.Object(flux.Property("columns", flux.Object(flux.Property("_value", flux.String("_baseline")))))),
+		duplicateStartAsTime(),
+	)
+
+	deviations := flux.Pipe(
+		union(flux.Identifier("medians"), flux.Identifier("data")),
+		sortByTime(),
+		fillForward("_baseline"),
+		filterValues(),
+		flux.Call(flux.Identifier("map"), flux.Object(flux.Property("fn", flux.Function(
+			flux.FunctionParams("r"),
+			flux.ObjectWith("r", flux.Property("_deviation", mathAbs(flux.Subtract(flux.Member("r", "_value"), flux.Member("r", "_baseline"))))),
+		)))),
+	)
+
+	mads := flux.Pipe(
+		flux.Identifier("deviations"),
+		flux.Call(flux.Identifier("median"), flux.Object(flux.Property("column", flux.String("_deviation")))),
+		flux.Call(flux.Identifier("rename"), flux.Object(flux.Property("columns", flux.Object(flux.Property("_deviation", flux.String("_mad")))))),
+		duplicateStartAsTime(),
+	)
+
+	latest := flux.Pipe(
+		flux.Identifier("deviations"),
+		flux.Call(flux.Identifier("last"), flux.Object()),
+	)
+
+	return []ast.Statement{
+		flux.DefineVariable("medians", medians),
+		flux.DefineVariable("deviations", deviations),
+		flux.DefineVariable("mads", mads),
+		flux.DefineVariable("latest", latest),
+	}
+}
+
+func (a Anomaly) generateFluxASTChecksFunction() ast.Statement {
+	var pipe *ast.PipeExpression
+	switch a.Method {
+	case AnomalyMAD:
+		pipe = flux.Pipe(
+			union(flux.Identifier("mads"), flux.Identifier("latest")),
+			sortByTime(),
+			fillForward("_mad"),
+			filterValues(),
+			mapWith("_deviation", flux.Multiply(flux.Float(madScale), flux.Member("r", "_mad"))),
+		)
+	case AnomalySeasonal:
+		pipe = flux.Pipe(
+			flux.Identifier("data"),
+			reduceStats(flux.Call(flux.Identifier("inBaseline"), flux.Object(flux.Property("r", flux.Identifier("r"))))),
+		)
+		pipe = appendMeanAndStdDev(pipe)
+	default:
+		pipe = flux.Pipe(flux.Identifier("data"), reduceStats(nil))
+		pipe = appendMeanAndStdDev(pipe)
+	}
+
+	score := flux.If(
+		flux.GreaterThan(flux.Member("r", "_deviation"), flux.Float(0)),
+		flux.Divide(
+			mathAbs(flux.Subtract(flux.Member("r", "_value"), flux.Member("r", "_baseline"))),
+			flux.Member("r", "_deviation"),
+		),
+		flux.Float(0),
+	)
+
+	return flux.ExpressionStatement(flux.Pipe(
+		pipe,
+		mapWith("_score", score),
+		flux.Call(flux.Identifier("pivot"), flux.Object(
+			flux.Property("rowKey", flux.Array(flux.String("_time"), flux.String("_baseline"), flux.String("_deviation"), flux.String("_score"))),
+			flux.Property("columnKey", flux.Array(flux.String("_field"))),
+			flux.Property("valueColumn", flux.String("_value")),
+		)),
+		a.generateFluxASTChecksCall(),
+	))
+}
+
+func (a Anomaly) generateFluxASTChecksCall() *ast.CallExpression {
+	objectProps := append(([]*ast.Property)(nil), flux.Property("data", flux.Identifier("check")))
+	objectProps = append(objectProps, flux.Property("messageFn", flux.Identifier("messageFn")))
+
+	// This assumes that the AnomalyLevels we've been provided do not have duplicates.
+	for _, l := range a.Levels {
+		lvl := strings.ToLower(l.Level.String())
+		objectProps = append(objectProps, flux.Property(lvl, flux.Identifier(lvl)))
+	}
+
+	return flux.Call(flux.Member("monitor", "check"), flux.Object(objectProps...))
+}
+
+// reduceStats reduces every series to its latest value and the count, sum
+// and sum of squares of its values for which cond is true, or of all of
+// them when cond is nil.
+func reduceStats(cond ast.Expression) *ast.CallExpression {
+	acc := func(name string, v ast.Expression) *ast.Property {
+		e := ast.Expression(flux.Add(flux.Member("accumulator", name), v))
+		if cond != nil {
+			e = flux.If(cond, e, flux.Member("accumulator", name))
+		}
+		return flux.Property(name, e)
+	}
+
+	fn := flux.Function(flux.FunctionParams("r", "accumulator"), flux.Object(
+		acc("count", flux.Float(1)),
+		acc("sum", flux.Member("r", "_value")),
+		acc("sumSquares", flux.Multiply(flux.Member("r", "_value"), flux.Member("r", "_value"))),
+		flux.Property("_value", flux.Member("r", "_value")),
+		flux.Property("_time", flux.Member("r", "_time")),
+	))
+	identity := flux.Object(
+		flux.Property("count", flux.Float(0)),
+		flux.Property("sum", flux.Float(0)),
+		flux.Property("sumSquares", flux.Float(0)),
+		flux.Property("_value", flux.Float(0)),
+		flux.Property("_time", flux.DateTime(time.Unix(0, 0).UTC())),
+	)
+
+	return flux.Call(flux.Identifier("reduce"), flux.Object(
+		flux.Property("identity", identity),
+		flux.Property("fn", fn),
+	))
+}
+
+// appendMeanAndStdDev sets the baseline of the reduced series to the mean of
+// the values and their deviation to the standard deviation.
+func appendMeanAndStdDev(pipe *ast.PipeExpression) *ast.PipeExpression {
+	hasCount := flux.GreaterThan(flux.Member("r", "count"), flux.Float(0))
+	mean := flux.If(hasCount, flux.Divide(flux.Member("r", "sum"), flux.Member("r", "count")), flux.Member("r", "_value"))
+	variance := flux.Subtract(
+		flux.Divide(flux.Member("r", "sumSquares"), flux.Member("r", "count")),
+		flux.Multiply(flux.Member("r", "_baseline"), flux.Member("r", "_baseline")),
+	)
+	stddev := flux.If(hasCount, flux.Call(flux.Member("math", "sqrt"), flux.Object(flux.Property("x", variance))), flux.Float(0))
+
+	return flux.Pipe(pipe, mapWith("_baseline", mean), mapWith("_deviation", stddev))
+}
+
+func mapWith(column string, e ast.Expression) *ast.CallExpression {
+	fn := flux.Function(flux.FunctionParams("r"), flux.ObjectWith("r", flux.Property(column, e)))
+	return flux.Call(flux.Identifier("map"), flux.Object(flux.Property("fn", fn)))
+}
+
+func mathAbs(e ast.Expression) *ast.CallExpression {
+	return flux.Call(flux.Member("math", "abs"), flux.Object(flux.Property("x", e)))
+}
+
+func union(tables ...ast.Expression) *ast.CallExpression {
+	return flux.Call(flux.Identifier("union"), flux.Object(flux.Property("tables", flux.Array(tables...))))
+}
+
+func duplicateStartAsTime() *ast.CallExpression {
+	return flux.Call(flux.Identifier("duplicate"), flux.Object(
+		flux.Property("column", flux.String("_start")),
+		flux.Property("as", flux.String("_time")),
+	))
+}
+
+func sortByTime() *ast.CallExpression {
+	return flux.Call(flux.Identifier("sort"), flux.Object(flux.Property("columns", flux.Array(flux.String("_time")))))
+}
+
+func fillForward(column string) *ast.CallExpression {
+	return flux.Call(flux.Identifier("fill"), flux.Object(
+		flux.Property("column", flux.String(column)),
+		flux.Property("usePrevious", flux.Bool(true)),
+	))
+}
+
+// filterValues drops the aggregate rows unioned into the series.
+func filterValues() *ast.CallExpression {
+	fn := flux.Function(flux.FunctionParams("r"), flux.Exists(flux.Member("r", "_value")))
+	return flux.Call(flux.Identifier("filter"), flux.Object(flux.Property("fn", fn)))
+}
+
+type anomalyAlias Anomaly
+
+// MarshalJSON implement json.Marshaler interface.
+func (a Anomaly) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			anomalyAlias
+			Type string `json:"type"`
+		}{
+			anomalyAlias: anomalyAlias(a),
+			Type:         a.Type(),
+		})
+}
@@ -0,0 +1,271 @@
+package check_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/check"
+	"github.com/influxdata/influxdb/v2/query/fluxlang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnomaly_GenerateFlux(t *testing.T) {
+	type args struct {
+		anomaly check.Anomaly
+	}
+	type wants struct {
+		script string
+	}
+
+	base := check.Base{
+		ID:   10,
+		Name: "moo",
+		Tags: []influxdb.Tag{
+			{Key: "aaa", Value: "vaaa"},
+		},
+		Every:                 mustDuration("1m"),
+		StatusMessageTemplate: "score {r._score}",
+		Query: influxdb.DashboardQuery{
+			Text: `from(bucket: "foo") |> range(start: -1d, stop: now()) |> filter(fn: (r) => r._field == "usage_user") |> aggregateWindow(every: 1m, fn: mean) |> yield()`,
+		},
+	}
+	levels := []check.AnomalyLevel{
+		{Level: notification.Ok, Sensitivity: 2},
+		{Level: notification.Warn, Sensitivity: 2},
+		{Level: notification.Critical, Sensitivity: 3},
+	}
+
+	tests := []struct {
+		name  string
+		args  args
+		wants wants
+	}{
+		{
+			name: "standard deviation",
+			args: args{
+				anomaly: check.Anomaly{
+					Base:   base,
+					Method: check.AnomalyStdDev,
+					Window: mustDuration("1h"),
+					Levels: levels,
+				},
+			},
+			wants: wants{
+				script: `package main
+import "influxdata/influxdb/monitor"
+import "math"
+
+data = from(bucket: "foo")
+	|> range(start: -1h)
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> aggregateWindow(every: 1m, fn: mean, createEmpty: false)
+
+option task = {name: "moo", every: 1m}
+
+check = {
+	_check_id: "000000000000000a",
+	_check_name: "moo",
+	_type: "anomaly",
+	tags: {aaa: "vaaa"},
+}
+ok = (r) =>
+	(r["_score"] <= 2.0)
+warn = (r) =>
+	(r["_score"] > 2.0)
+crit = (r) =>
+	(r["_score"] > 3.0)
+messageFn = (r) =>
+	("score {r._score}")
+
+data
+	|> reduce(identity: {
+		count: 0.0,
+		sum: 0.0,
+		sumSquares: 0.0,
+		_value: 0.0,
+		_time: 1970-01-01T00:00:00Z,
+	}, fn: (r, accumulator) =>
+		({
+			count: accumulator["count"] + 1.0,
+			sum: accumulator["sum"] + r["_value"],
+			sumSquares: accumulator["sumSquares"] + r["_value"] * r["_value"],
+			_value: r["_value"],
+			_time: r["_time"],
+		}))
+	|> map(fn: (r) =>
+		({r with _baseline: if r["count"] > 0.0 then r["sum"] / r["count"] else r["_value"]}))
+	|> map(fn: (r) =>
+		({r with _deviation: if r["count"] > 0.0 then math["sqrt"](x: r["sumSquares"] / r["count"] - r["_baseline"] * r["_baseline"]) else 0.0}))
+	|> map(fn: (r) =>
+		({r with _score: if r["_deviation"] > 0.0 then math["abs"](x: r["_value"] - r["_baseline"]) / r["_deviation"] else 0.0}))
+	|> pivot(rowKey: ["_time", "_baseline", "_deviation", "_score"], columnKey: ["_field"], valueColumn: "_value")
+	|> monitor["check"](
+		data: check,
+		messageFn: messageFn,
+		ok: ok,
+		warn: warn,
+		crit: crit,
+	)`,
+			},
+		},
+		{
+			name: "median absolute deviation",
+			args: args{
+				anomaly: check.Anomaly{
+					Base:   base,
+					Method: check.AnomalyMAD,
+					Window: mustDuration("1h"),
+					Levels: levels,
+				},
+			},
+			wants: wants{
+				script: `package main
+import "influxdata/influxdb/monitor"
+import "math"
+
+data = from(bucket: "foo")
+	|> range(start: -1h)
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> aggregateWindow(every: 1m, fn: mean, createEmpty: false)
+
+option task = {name: "moo", every: 1m}
+
+check = {
+	_check_id: "000000000000000a",
+	_check_name: "moo",
+	_type: "anomaly",
+	tags: {aaa: "vaaa"},
+}
+ok = (r) =>
+	(r["_score"] <= 2.0)
+warn = (r) =>
+	(r["_score"] > 2.0)
+crit = (r) =>
+	(r["_score"] > 3.0)
+messageFn = (r) =>
+	("score {r._score}")
+medians = data
+	|> median()
+	|> rename(columns: {_value: "_baseline"})
+	|> duplicate(column: "_start", as: "_time")
+deviations = union(tables: [medians, data])
+	|> sort(columns: ["_time"])
+	|> fill(column: "_baseline", usePrevious: true)
+	|> filter(fn: (r) =>
+		(exists r["_value"]))
+	|> map(fn: (r) =>
+		({r with _deviation: math["abs"](x: r["_value"] - r["_baseline"])}))
+mads = deviations
+	|> median(column: "_deviation")
+	|> rename(columns: {_deviation: "_mad"})
+	|> duplicate(column: "_start", as: "_time")
+latest = deviations
+	|> last()
+
+union(tables: [mads, latest])
+	|> sort(columns: ["_time"])
+	|> fill(column: "_mad", usePrevious: true)
+	|> filter(fn: (r) =>
+		(exists r["_value"]))
+	|> map(fn: (r) =>
+		({r with _deviation: 1.4826 * r["_mad"]}))
+	|> map(fn: (r) =>
+		({r with _score: if r["_deviation"] > 0.0 then math["abs"](x: r["_value"] - r["_baseline"]) / r["_deviation"] else 0.0}))
+	|> pivot(rowKey: ["_time", "_baseline", "_deviation", "_score"], columnKey: ["_field"], valueColumn: "_value")
+	|> monitor["check"](
+		data: check,
+		messageFn: messageFn,
+		ok: ok,
+		warn: warn,
+		crit: crit,
+	)`,
+			},
+		},
+		{
+			name: "seasonal",
+			args: args{
+				anomaly: check.Anomaly{
+					Base:    base,
+					Method:  check.AnomalySeasonal,
+					Season:  mustDuration("1d"),
+					Seasons: 7,
+					Levels:  levels,
+				},
+			},
+			wants: wants{
+				script: `package main
+import "influxdata/influxdb/monitor"
+import "math"
+
+data = from(bucket: "foo")
+	|> range(start: -168h1m0s)
+	|> filter(fn: (r) =>
+		(r._field == "usage_user"))
+	|> aggregateWindow(every: 1m, fn: mean, createEmpty: false)
+
+option task = {name: "moo", every: 1m}
+
+check = {
+	_check_id: "000000000000000a",
+	_check_name: "moo",
+	_type: "anomaly",
+	tags: {aaa: "vaaa"},
+}
+ok = (r) =>
+	(r["_score"] <= 2.0)
+warn = (r) =>
+	(r["_score"] > 2.0)
+crit = (r) =>
+	(r["_score"] > 3.0)
+messageFn = (r) =>
+	("score {r._score}")
+inBaseline = (r) =>
+	(int(v: now()) - int(v: r["_time"]) >= 86400000000000 and (int(v: now()) - int(v: r["_time"])) % 86400000000000 < 60000000000)
+
+data
+	|> reduce(identity: {
+		count: 0.0,
+		sum: 0.0,
+		sumSquares: 0.0,
+		_value: 0.0,
+		_time: 1970-01-01T00:00:00Z,
+	}, fn: (r, accumulator) =>
+		({
+			count: if inBaseline(r: r) then accumulator["count"] + 1.0 else accumulator["count"],
+			sum: if inBaseline(r: r) then accumulator["sum"] + r["_value"] else accumulator["sum"],
+			sumSquares: if inBaseline(r: r) then accumulator["sumSquares"] + r["_value"] * r["_value"] else accumulator["sumSquares"],
+			_value: r["_value"],
+			_time: r["_time"],
+		}))
+	|> map(fn: (r) =>
+		({r with _baseline: if r["count"] > 0.0 then r["sum"] / r["count"] else r["_value"]}))
+	|> map(fn: (r) =>
+		({r with _deviation: if r["count"] > 0.0 then math["sqrt"](x: r["sumSquares"] / r["count"] - r["_baseline"] * r["_baseline"]) else 0.0}))
+	|> map(fn: (r) =>
+		({r with _score: if r["_deviation"] > 0.0 then math["abs"](x: r["_value"] - r["_baseline"]) / r["_deviation"] else 0.0}))
+	|> pivot(rowKey: ["_time", "_baseline", "_deviation", "_score"], columnKey: ["_field"], valueColumn: "_value")
+	|> monitor["check"](
+		data: check,
+		messageFn: messageFn,
+		ok: ok,
+		warn: warn,
+		crit: crit,
+	)`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := tt.args.anomaly.GenerateFluxAST(fluxlang.DefaultService)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, tt.wants.script, ast.Format(p))
+		})
+	}
+}
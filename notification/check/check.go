@@ -202,6 +202,7 @@ func (b *Base) SetDescription(description string) {
 }
 
 var typeToCheck = map[string](func() influxdb.Check){
+	"anomaly":   func() influxdb.Check { return &Anomaly{} },
 	"deadman":   func() influxdb.Check { return &Deadman{} },
 	"threshold": func() influxdb.Check { return &Threshold{} },
 	"custom":    func() influxdb.Check { return &Custom{} },
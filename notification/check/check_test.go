@@ -153,6 +153,74 @@ func TestValidCheck(t *testing.T) {
 				Msg:  "range threshold min can't be larger than max",
 			},
 		},
+		{
+			name: "bad anomaly method",
+			src: &check.Anomaly{
+				Base:   goodBase,
+				Method: "zscore",
+				Window: mustDuration("1h"),
+				Levels: []check.AnomalyLevel{{Level: notification.Critical, Sensitivity: 3}},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  `invalid anomaly check method "zscore"`,
+			},
+		},
+		{
+			name: "anomaly window not greater than every",
+			src: &check.Anomaly{
+				Base:   goodBase,
+				Method: check.AnomalyStdDev,
+				Window: mustDuration("1m"),
+				Levels: []check.AnomalyLevel{{Level: notification.Critical, Sensitivity: 3}},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check window must be greater than every",
+			},
+		},
+		{
+			name: "anomaly seasonal without seasons",
+			src: &check.Anomaly{
+				Base:   goodBase,
+				Method: check.AnomalySeasonal,
+				Season: mustDuration("1d"),
+				Levels: []check.AnomalyLevel{{Level: notification.Critical, Sensitivity: 3}},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check seasons must be at least 1",
+			},
+		},
+		{
+			name: "anomaly level duplicated",
+			src: &check.Anomaly{
+				Base:   goodBase,
+				Method: check.AnomalyMAD,
+				Window: mustDuration("1h"),
+				Levels: []check.AnomalyLevel{
+					{Level: notification.Critical, Sensitivity: 3},
+					{Level: notification.Critical, Sensitivity: 4},
+				},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check level CRIT is duplicated",
+			},
+		},
+		{
+			name: "anomaly sensitivity not positive",
+			src: &check.Anomaly{
+				Base:   goodBase,
+				Method: check.AnomalyMAD,
+				Window: mustDuration("1h"),
+				Levels: []check.AnomalyLevel{{Level: notification.Warn}},
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "anomaly check sensitivity must be greater than 0",
+			},
+		},
 	}
 	for _, c := range cases {
 		got := c.src.Valid(fluxlang.DefaultService)
@@ -263,6 +331,43 @@ func TestJSON(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "simple anomaly",
+			src: &check.Anomaly{
+				Base: check.Base{
+					ID:      influxTesting.MustIDBase16(id1),
+					Name:    "name1",
+					OwnerID: influxTesting.MustIDBase16(id2),
+					OrgID:   influxTesting.MustIDBase16(id3),
+					Every:   mustDuration("1h"),
+					Query: influxdb.DashboardQuery{
+						BuilderConfig: influxdb.BuilderConfig{
+							Buckets: []string{},
+							Tags: []struct {
+								Key                   string   `json:"key"`
+								Values                []string `json:"values"`
+								AggregateFunctionType string   `json:"aggregateFunctionType"`
+							}{},
+							Functions: []struct {
+								Name string `json:"name"`
+							}{},
+						},
+					},
+					Tags: []influxdb.Tag{},
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Method:  check.AnomalySeasonal,
+				Season:  mustDuration("1d"),
+				Seasons: 4,
+				Levels: []check.AnomalyLevel{
+					{Level: notification.Ok, Sensitivity: 1.5},
+					{Level: notification.Critical, Sensitivity: 3},
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		fn := func(t *testing.T) {
@@ -12,12 +12,20 @@ const (
 	SlackType     = "slack"
 	PagerDutyType = "pagerduty"
 	HTTPType      = "http"
+	TeamsType     = "teams"
+	OpsGenieType  = "opsgenie"
+	TelegramType  = "telegram"
+	SMTPType      = "smtp"
 )
 
 var typeToEndpoint = map[string]func() influxdb.NotificationEndpoint{
 	SlackType:     func() influxdb.NotificationEndpoint { return &Slack{} },
 	PagerDutyType: func() influxdb.NotificationEndpoint { return &PagerDuty{} },
 	HTTPType:      func() influxdb.NotificationEndpoint { return &HTTP{} },
+	TeamsType:     func() influxdb.NotificationEndpoint { return &Teams{} },
+	OpsGenieType:  func() influxdb.NotificationEndpoint { return &OpsGenie{} },
+	TelegramType:  func() influxdb.NotificationEndpoint { return &Telegram{} },
+	SMTPType:      func() influxdb.NotificationEndpoint { return &SMTP{} },
 }
 
 // UnmarshalJSON will convert the bytes to notification endpoint.
@@ -136,6 +136,74 @@ func TestValidEndpoint(t *testing.T) {
 				Msg:  "invalid http username/password for basic auth",
 			},
 		},
+		{
+			name: "empty teams url",
+			src: &endpoint.Teams{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "teams endpoint URL must be provided",
+			},
+		},
+		{
+			name: "empty opsgenie api key",
+			src: &endpoint.OpsGenie{
+				Base: goodBase,
+				URL:  "https://api.eu.opsgenie.com/v2/alerts",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "opsgenie api key is invalid",
+			},
+		},
+		{
+			name: "empty telegram token",
+			src: &endpoint.Telegram{
+				Base: goodBase,
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "empty telegram bot token",
+			},
+		},
+		{
+			name: "invalid smtp host",
+			src: &endpoint.SMTP{
+				Base: goodBase,
+				Host: "smtp.example.com",
+				From: "influxdb@example.com",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "smtp endpoint host is invalid: address smtp.example.com: missing port in address",
+			},
+		},
+		{
+			name: "empty smtp password",
+			src: &endpoint.SMTP{
+				Base:     goodBase,
+				Host:     "smtp.example.com:587",
+				Username: "influxdb",
+				From:     "influxdb@example.com",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "smtp endpoint password is empty",
+			},
+		},
+		{
+			name: "invalid smtp from",
+			src: &endpoint.SMTP{
+				Base: goodBase,
+				Host: "smtp.example.com:587",
+				From: "influxdb",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "smtp endpoint from address is invalid: mail: missing '@' or angle-addr",
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -226,6 +294,75 @@ func TestJSON(t *testing.T) {
 				Password:   influxdb.SecretField{Key: "password-key"},
 			},
 		},
+		{
+			name: "simple teams",
+			src: &endpoint.Teams{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: influxdb.SecretField{Key: "teams-url"},
+			},
+		},
+		{
+			name: "simple opsgenie",
+			src: &endpoint.OpsGenie{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL:    "https://api.eu.opsgenie.com/v2/alerts",
+				APIKey: influxdb.SecretField{Key: "opsgenie-api-key"},
+			},
+		},
+		{
+			name: "simple telegram",
+			src: &endpoint.Telegram{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL:   "http://localhost:8081",
+				Token: influxdb.SecretField{Key: "telegram-token"},
+			},
+		},
+		{
+			name: "simple smtp",
+			src: &endpoint.SMTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Host:     "smtp.example.com:587",
+				Username: "influxdb",
+				Password: influxdb.SecretField{Key: "smtp-password"},
+				From:     "influxdb@example.com",
+			},
+		},
 	}
 	for _, c := range cases {
 		b, err := json.Marshal(c.src)
@@ -320,6 +457,144 @@ func TestBackFill(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "simple teams",
+			src: &endpoint.Teams{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: influxdb.SecretField{
+					Value: strPtr("https://outlook.office.com/webhook/x/y/z"),
+				},
+			},
+			target: &endpoint.Teams{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				URL: influxdb.SecretField{
+					Key:   id1 + "-url",
+					Value: strPtr("https://outlook.office.com/webhook/x/y/z"),
+				},
+			},
+		},
+		{
+			name: "simple opsgenie",
+			src: &endpoint.OpsGenie{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				APIKey: influxdb.SecretField{
+					Value: strPtr("api-key-value"),
+				},
+			},
+			target: &endpoint.OpsGenie{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				APIKey: influxdb.SecretField{
+					Key:   id1 + "-api-key",
+					Value: strPtr("api-key-value"),
+				},
+			},
+		},
+		{
+			name: "simple telegram",
+			src: &endpoint.Telegram{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Token: influxdb.SecretField{
+					Value: strPtr("token-value"),
+				},
+			},
+			target: &endpoint.Telegram{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Token: influxdb.SecretField{
+					Key:   id1 + "-token",
+					Value: strPtr("token-value"),
+				},
+			},
+		},
+		{
+			name: "simple smtp",
+			src: &endpoint.SMTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Username: "influxdb",
+				Password: influxdb.SecretField{
+					Value: strPtr("password-value"),
+				},
+			},
+			target: &endpoint.SMTP{
+				Base: endpoint.Base{
+					ID:     influxTesting.MustIDBase16Ptr(id1),
+					Name:   "name1",
+					OrgID:  influxTesting.MustIDBase16Ptr(id3),
+					Status: influxdb.Active,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Username: "influxdb",
+				Password: influxdb.SecretField{
+					Key:   id1 + "-password",
+					Value: strPtr("password-value"),
+				},
+			},
+		},
 		{
 			name: "http with token",
 			src: &endpoint.HTTP{
@@ -0,0 +1,92 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.NotificationEndpoint = &OpsGenie{}
+
+const (
+	opsGenieAPIKeySuffix = "-api-key"
+
+	// OpsGenieDefaultURL is the alert API of the US instance of opsgenie.
+	OpsGenieDefaultURL = "https://api.opsgenie.com/v2/alerts"
+)
+
+// OpsGenie is the notification endpoint config of opsgenie.
+type OpsGenie struct {
+	Base
+	// URL is the alert API URL, it defaults to OpsGenieDefaultURL.
+	// example: https://api.eu.opsgenie.com/v2/alerts
+	URL string `json:"url,omitempty"`
+	// APIKey is the key of an API integration of opsgenie.
+	APIKey influxdb.SecretField `json:"apiKey"`
+}
+
+// AlertURL returns the URL alerts are created with.
+func (s OpsGenie) AlertURL() string {
+	if s.URL == "" {
+		return OpsGenieDefaultURL
+	}
+	return s.URL
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (s *OpsGenie) BackfillSecretKeys() {
+	if s.APIKey.Key == "" && s.APIKey.Value != nil {
+		s.APIKey.Key = s.idStr() + opsGenieAPIKeySuffix
+	}
+}
+
+// SecretFields return available secret fields.
+func (s OpsGenie) SecretFields() []influxdb.SecretField {
+	return []influxdb.SecretField{
+		s.APIKey,
+	}
+}
+
+// Valid returns error if some configuration is invalid
+func (s OpsGenie) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.URL != "" {
+		if _, err := url.Parse(s.URL); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("opsgenie endpoint URL is invalid: %s", err.Error()),
+			}
+		}
+	}
+	if s.APIKey.Key == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "opsgenie api key is invalid",
+		}
+	}
+	return nil
+}
+
+type opsGenieAlias OpsGenie
+
+// MarshalJSON implement json.Marshaler interface.
+func (s OpsGenie) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			opsGenieAlias
+			Type string `json:"type"`
+		}{
+			opsGenieAlias: opsGenieAlias(s),
+			Type:          s.Type(),
+		})
+}
+
+// Type returns the type.
+func (s OpsGenie) Type() string {
+	return OpsGenieType
+}
@@ -0,0 +1,102 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/mail"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.NotificationEndpoint = &SMTP{}
+
+const (
+	smtpPasswordSuffix = "-password"
+
+	// SMTPRelayHost is the host of the URLs the notification rules of smtp
+	// endpoints post their mails to. Flux can not send mails, influxd relays
+	// the posts to these URLs to the mail server of the endpoint instead.
+	SMTPRelayHost = "smtp.influxdb.invalid"
+)
+
+// SMTP is the notification endpoint config of an smtp mail server.
+type SMTP struct {
+	Base
+	// Host is the host:port of the mail server.
+	// example: smtp.example.com:587
+	Host string `json:"host"`
+	// Username is the user of the PLAIN authentication, no authentication
+	// is done if it is empty.
+	Username string               `json:"username,omitempty"`
+	Password influxdb.SecretField `json:"password,omitempty"`
+	// From is the address the mails are sent from.
+	From string `json:"from"`
+}
+
+// RelayURL returns the URL the mails of the endpoint are posted to.
+func (s SMTP) RelayURL() string {
+	return "http://" + SMTPRelayHost + "/" + s.idStr()
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (s *SMTP) BackfillSecretKeys() {
+	if s.Password.Key == "" && s.Password.Value != nil {
+		s.Password.Key = s.idStr() + smtpPasswordSuffix
+	}
+}
+
+// SecretFields return available secret fields.
+func (s SMTP) SecretFields() []influxdb.SecretField {
+	arr := make([]influxdb.SecretField, 0)
+	if s.Password.Key != "" {
+		arr = append(arr, s.Password)
+	}
+	return arr
+}
+
+// Valid returns error if some configuration is invalid
+func (s SMTP) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if _, _, err := net.SplitHostPort(s.Host); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("smtp endpoint host is invalid: %s", err.Error()),
+		}
+	}
+	if s.Username != "" && s.Password.Key == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "smtp endpoint password is empty",
+		}
+	}
+	if _, err := mail.ParseAddress(s.From); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("smtp endpoint from address is invalid: %s", err.Error()),
+		}
+	}
+	return nil
+}
+
+type smtpAlias SMTP
+
+// MarshalJSON implement json.Marshaler interface.
+func (s SMTP) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			smtpAlias
+			Type string `json:"type"`
+		}{
+			smtpAlias: smtpAlias(s),
+			Type:      s.Type(),
+		})
+}
+
+// Type returns the type.
+func (s SMTP) Type() string {
+	return SMTPType
+}
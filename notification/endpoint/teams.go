@@ -0,0 +1,67 @@
+package endpoint
+
+import (
+	"encoding/json"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.NotificationEndpoint = &Teams{}
+
+const teamsURLSuffix = "-url"
+
+// Teams is the notification endpoint config of microsoft teams.
+type Teams struct {
+	Base
+	// URL is the incoming webhook URL of the teams channel.
+	// The URL embeds the credentials of the webhook, so it is kept as a secret.
+	URL influxdb.SecretField `json:"url"`
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (s *Teams) BackfillSecretKeys() {
+	if s.URL.Key == "" && s.URL.Value != nil {
+		s.URL.Key = s.idStr() + teamsURLSuffix
+	}
+}
+
+// SecretFields return available secret fields.
+func (s Teams) SecretFields() []influxdb.SecretField {
+	return []influxdb.SecretField{
+		s.URL,
+	}
+}
+
+// Valid returns error if some configuration is invalid
+func (s Teams) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.URL.Key == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "teams endpoint URL must be provided",
+		}
+	}
+	return nil
+}
+
+type teamsAlias Teams
+
+// MarshalJSON implement json.Marshaler interface.
+func (s Teams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			teamsAlias
+			Type string `json:"type"`
+		}{
+			teamsAlias: teamsAlias(s),
+			Type:       s.Type(),
+		})
+}
+
+// Type returns the type.
+func (s Teams) Type() string {
+	return TeamsType
+}
@@ -0,0 +1,92 @@
+package endpoint
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+var _ influxdb.NotificationEndpoint = &Telegram{}
+
+const (
+	telegramTokenSuffix = "-token"
+
+	// TelegramDefaultURL is the telegram bot API.
+	TelegramDefaultURL = "https://api.telegram.org"
+)
+
+// Telegram is the notification endpoint config of telegram.
+type Telegram struct {
+	Base
+	// URL is the bot API URL, it defaults to TelegramDefaultURL.
+	URL string `json:"url,omitempty"`
+	// Token is the telegram bot token.
+	// example: 123456789:AAxSBr0jp3JnSl6W_Z7eGc2mI6-k5b7gB2s
+	Token influxdb.SecretField `json:"token"`
+}
+
+// APIURL returns the URL of the bot API.
+func (s Telegram) APIURL() string {
+	if s.URL == "" {
+		return TelegramDefaultURL
+	}
+	return s.URL
+}
+
+// BackfillSecretKeys fill back fill the secret field key during the unmarshalling
+// if value of that secret field is not nil.
+func (s *Telegram) BackfillSecretKeys() {
+	if s.Token.Key == "" && s.Token.Value != nil {
+		s.Token.Key = s.idStr() + telegramTokenSuffix
+	}
+}
+
+// SecretFields return available secret fields.
+func (s Telegram) SecretFields() []influxdb.SecretField {
+	return []influxdb.SecretField{
+		s.Token,
+	}
+}
+
+// Valid returns error if some configuration is invalid
+func (s Telegram) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.URL != "" {
+		if _, err := url.Parse(s.URL); err != nil {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("telegram endpoint URL is invalid: %s", err.Error()),
+			}
+		}
+	}
+	if s.Token.Key == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "empty telegram bot token",
+		}
+	}
+	return nil
+}
+
+type telegramAlias Telegram
+
+// MarshalJSON implement json.Marshaler interface.
+func (s Telegram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			telegramAlias
+			Type string `json:"type"`
+		}{
+			telegramAlias: telegramAlias(s),
+			Type:          s.Type(),
+		})
+}
+
+// Type returns the type.
+func (s Telegram) Type() string {
+	return TelegramType
+}
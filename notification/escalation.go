@@ -0,0 +1,41 @@
+package notification
+
+import (
+	"github.com/influxdata/influxdb/v2"
+)
+
+// EscalationStep notifies an additional endpoint about the statuses of a
+// notification rule once their series have stayed at the levels of the rule
+// for Delay, and again every RepeatEvery while they stay there.
+type EscalationStep struct {
+	EndpointID influxdb.ID `json:"endpointID"`
+	// Delay is how long a series stays at the levels of the rule before it
+	// is escalated, it defaults to escalating immediately.
+	Delay *Duration `json:"delay,omitempty"`
+	// RepeatEvery is the interval at which the escalation is repeated while
+	// the series stays at the levels of the rule, it is sent once if unset.
+	RepeatEvery *Duration `json:"repeatEvery,omitempty"`
+}
+
+// Valid returns an error if the escalation step is invalid.
+func (s EscalationStep) Valid() error {
+	if !s.EndpointID.Valid() {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "escalation step endpointID is invalid",
+		}
+	}
+	if s.Delay != nil && s.Delay.TimeDuration() < 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "escalation step delay must not be negative",
+		}
+	}
+	if s.RepeatEvery != nil && s.RepeatEvery.TimeDuration() <= 0 {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "escalation step repeatEvery must be larger than 0",
+		}
+	}
+	return nil
+}
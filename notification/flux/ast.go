@@ -1,6 +1,10 @@
 package flux
 
-import "github.com/influxdata/flux/ast"
+import (
+	"time"
+
+	"github.com/influxdata/flux/ast"
+)
 
 // File creates a new *ast.File.
 func File(name string, imports []*ast.ImportDeclaration, body []ast.Statement) *ast.File {
@@ -20,6 +24,15 @@ func GreaterThan(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	}
 }
 
+// GreaterThanEqual returns a greater than or equal to *ast.BinaryExpression.
+func GreaterThanEqual(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.GreaterThanEqualOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
 // LessThan returns a less than *ast.BinaryExpression.
 func LessThan(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	return &ast.BinaryExpression{
@@ -29,6 +42,15 @@ func LessThan(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	}
 }
 
+// LessThanEqual returns a less than or equal to *ast.BinaryExpression.
+func LessThanEqual(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.LessThanEqualOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
 // Equal returns an equal to *ast.BinaryExpression.
 func Equal(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	return &ast.BinaryExpression{
@@ -38,6 +60,15 @@ func Equal(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	}
 }
 
+// NotEqual returns a not equal to *ast.BinaryExpression.
+func NotEqual(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.NotEqualOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
 // Subtract returns a subtraction *ast.BinaryExpression.
 func Subtract(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	return &ast.BinaryExpression{
@@ -56,6 +87,33 @@ func Add(lhs, rhs ast.Expression) *ast.BinaryExpression {
 	}
 }
 
+// Multiply returns a multiplication *ast.BinaryExpression.
+func Multiply(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.MultiplicationOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
+// Divide returns a division *ast.BinaryExpression.
+func Divide(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.DivisionOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
+// Modulo returns a modulo *ast.BinaryExpression.
+func Modulo(lhs, rhs ast.Expression) *ast.BinaryExpression {
+	return &ast.BinaryExpression{
+		Operator: ast.ModuloOperator,
+		Left:     lhs,
+		Right:    rhs,
+	}
+}
+
 // Member returns an *ast.MemberExpression where the key is p and the values is c.
 func Member(p, c string) *ast.MemberExpression {
 	return &ast.MemberExpression{
@@ -191,6 +249,29 @@ func Integer(i int64) *ast.IntegerLiteral {
 	}
 }
 
+// DateTime returns an *ast.DateTimeLiteral of t.
+func DateTime(t time.Time) *ast.DateTimeLiteral {
+	return &ast.DateTimeLiteral{
+		Value: t,
+	}
+}
+
+// Not returns *ast.UnaryExpression for not (e).
+func Not(e ast.Expression) *ast.UnaryExpression {
+	return &ast.UnaryExpression{
+		Operator: ast.NotOperator,
+		Argument: e,
+	}
+}
+
+// Exists returns *ast.UnaryExpression for exists (e).
+func Exists(e ast.Expression) *ast.UnaryExpression {
+	return &ast.UnaryExpression{
+		Operator: ast.ExistsOperator,
+		Argument: e,
+	}
+}
+
 // Negative returns *ast.UnaryExpression for -(e).
 func Negative(e ast.Expression) *ast.UnaryExpression {
 	return &ast.UnaryExpression{
@@ -0,0 +1,495 @@
+package rule
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/flux"
+)
+
+// escalationSuffix returns the suffix of the variables of the escalation
+// step i, the variables of the rule's endpoint have none.
+func escalationSuffix(i int) string {
+	return fmt.Sprintf("_escalation_%d", i)
+}
+
+// checkEscalationEndpoints returns an error if there is not an endpoint for
+// each escalation step.
+func (b *Base) checkEscalationEndpoints(escalations []influxdb.NotificationEndpoint) error {
+	if len(escalations) != len(b.Escalations) {
+		return fmt.Errorf("%d escalation endpoints provided for %d escalation steps", len(escalations), len(b.Escalations))
+	}
+	return nil
+}
+
+// escalationNotifier is a rule notifying the endpoints of its type.
+type escalationNotifier interface {
+	Type() string
+	// generateFluxASTEscalationNotify returns the packages imported by and
+	// the statements notifying e of all_statuses with the suffix.
+	generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement)
+}
+
+// escalationNotifierOf returns the rule notifying the escalation endpoint e
+// of the statuses of b, with the message template msg.
+//
+// The rules of telegram and smtp are not supported, as the chat a telegram
+// bot sends to and the addresses of the mails are set on the rule rather than
+// on the endpoint.
+func (b *Base) escalationNotifierOf(e influxdb.NotificationEndpoint, msg string) (escalationNotifier, error) {
+	switch e.(type) {
+	case *endpoint.Slack:
+		return &Slack{Base: *b, MessageTemplate: msg}, nil
+	case *endpoint.PagerDuty:
+		return &PagerDuty{Base: *b, MessageTemplate: msg}, nil
+	case *endpoint.HTTP:
+		return &HTTP{Base: *b}, nil
+	case *endpoint.Teams:
+		return &Teams{Base: *b, MessageTemplate: msg}, nil
+	case *endpoint.OpsGenie:
+		return &OpsGenie{Base: *b, MessageTemplate: msg}, nil
+	}
+	return nil, fmt.Errorf("escalation endpoint provided is a %s, which only a %s notification rule can escalate to", e.Type(), e.Type())
+}
+
+// generateFluxASTEscalations returns the packages imported by and the
+// statements of the escalation steps of the rule r.
+//
+// Escalation endpoints of the type of r are notified the way r notifies its
+// own endpoint. Endpoints of other types are notified by a rule of their type
+// with the message template msg, as the settings of r do not apply to them.
+func (b *Base) generateFluxASTEscalations(r escalationNotifier, msg string, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) ([]string, []ast.Statement, error) {
+	var (
+		imports    []string
+		statements []ast.Statement
+	)
+	for i, e := range escalations {
+		n := r
+		if e.Type() != r.Type() {
+			var err error
+			if n, err = b.escalationNotifierOf(e, msg); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		suffix := escalationSuffix(i)
+		statements = append(statements, b.generateFluxASTEscalation(i, e, silences, acks)...)
+		packages, notify := n.generateFluxASTEscalationNotify(e, suffix)
+		imports = append(imports, packages...)
+		statements = append(statements, notify...)
+	}
+	return imports, statements, nil
+}
+
+// mergeImports returns the packages, followed by the other packages in order,
+// without duplicates.
+func mergeImports(packages []string, other []string) []string {
+	seen := make(map[string]bool, len(packages)+len(other))
+	merged := make([]string, 0, len(packages)+len(other))
+	for _, list := range [][]string{packages, other} {
+		for _, p := range list {
+			if !seen[p] {
+				seen[p] = true
+				merged = append(merged, p)
+			}
+		}
+	}
+	return merged
+}
+
+// generateFluxASTEscalation defines notification_escalation_i, the data of
+// the notifications of the escalation step i to the endpoint e, and
+// all_statuses_escalation_i, the statuses to escalate.
+//
+// A status is escalated once its series has stayed at the levels of the
+// status rules for the delay of the step. If the step repeats, it is
+// escalated again on every run after the repeat interval, unless the series
+// was already notified to e within it according to the notifications logged
+// to the monitoring bucket.
+func (b *Base) generateFluxASTEscalation(i int, e influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
+	step := b.Escalations[i]
+	suffix := escalationSuffix(i)
+
+	var delay, every time.Duration
+	if step.Delay != nil {
+		delay = step.Delay.TimeDuration()
+	}
+	if b.Every != nil {
+		every = b.Every.TimeDuration()
+	}
+	// Read far enough back for a series to reach the delay within the
+	// interval of the last run.
+	start := flux.Negative(durationLiteral(delay + 2*every))
+
+	tagRules := b.generateTagRules()
+	var statuses ast.Expression
+	if len(acks) == 0 {
+		statuses = monitorFrom(start, tagRules)
+	} else {
+		statuses = unionAcknowledgements(monitorFrom(start, generateUnacknowledged(tagRules)), acks)
+	}
+
+	stmts := []ast.Statement{
+		flux.DefineVariable("notification"+suffix, b.generateFluxASTNotificationData(step.EndpointID, e)),
+		flux.DefineVariable("statuses"+suffix, statuses),
+	}
+
+	var escalated *ast.PipeExpression
+	if step.RepeatEvery == nil {
+		escalated = b.generateEscalationOnce(flux.Identifier("statuses"+suffix), delay)
+	} else {
+		stmts = append(stmts,
+			flux.DefineVariable("due_statuses"+suffix, b.generateEscalationDue(flux.Identifier("statuses"+suffix), delay)),
+			flux.DefineVariable("notifications"+suffix, b.generateEscalationNotifications(step)),
+		)
+		escalated = generateEscalationRepeat(flux.Identifier("due_statuses"+suffix), flux.Identifier("notifications"+suffix))
+	}
+	if len(silences) > 0 {
+		silenced := flux.Call(flux.Identifier("silenced"), flux.Object(flux.Property("r", flux.Identifier("r"))))
+		escalated = flux.Pipe(escalated, flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"), flux.Not(silenced))),
+			),
+		))
+	}
+	stmts = append(stmts, flux.DefineVariable("all_statuses"+suffix, escalated))
+
+	return stmts
+}
+
+// generateEscalationOnce returns the statuses at which their series reached
+// the delay in the interval of the last run.
+func (b *Base) generateEscalationOnce(statuses ast.Expression, delay time.Duration) *ast.PipeExpression {
+	calls := b.generateEscalationDurations("_level")
+	calls = append(calls,
+		flux.Call(
+			flux.Identifier("stateCount"),
+			flux.Object(
+				flux.Property("fn", flux.Function(
+					flux.FunctionParams("r"),
+					flux.GreaterThanEqual(flux.Member("r", "_level_duration"), flux.Integer(int64(delay/time.Second))),
+				)),
+				flux.Property("column", flux.String("_escalation_count")),
+			),
+		),
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(
+					flux.FunctionParams("r"),
+					flux.And(
+						flux.Equal(flux.Member("r", "_escalation_count"), flux.Integer(1)),
+						b.generateLastRun(),
+					),
+				)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_level_duration"), flux.String("_escalation_count"))),
+			),
+		),
+		regroup("_level"),
+	)
+	return flux.Pipe(statuses, calls...)
+}
+
+// generateEscalationDue returns the last status of the series that were at
+// the levels for the delay in the interval of the last run.
+func (b *Base) generateEscalationDue(statuses ast.Expression, delay time.Duration) *ast.PipeExpression {
+	calls := b.generateEscalationDurations("_level", "_measurement")
+	calls = append(calls,
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(
+					flux.FunctionParams("r"),
+					flux.And(
+						flux.GreaterThanEqual(flux.Member("r", "_level_duration"), flux.Integer(int64(delay/time.Second))),
+						b.generateLastRun(),
+					),
+				)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_level_duration"))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("last"),
+			flux.Object(
+				flux.Property("column", flux.String("_time")),
+			),
+		),
+		setNotified(0),
+	)
+	return flux.Pipe(statuses, calls...)
+}
+
+// generateEscalationRepeat returns the due statuses of the series that are
+// not in notifications.
+func generateEscalationRepeat(due, notifications ast.Expression) *ast.PipeExpression {
+	// The notifications sort first in each series so that the cumulative
+	// sum of _notified is 0 only for the series that were not notified.
+	return flux.Pipe(
+		flux.Call(
+			flux.Identifier("union"),
+			flux.Object(
+				flux.Property("tables", flux.Array(due, notifications)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("sort"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_notified"))),
+				flux.Property("desc", flux.Bool(true)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("cumulativeSum"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_notified"))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(
+					flux.FunctionParams("r"),
+					flux.Equal(flux.Member("r", "_notified"), flux.Integer(0)),
+				)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_notified"))),
+			),
+		),
+		regroup("_level", "_measurement"),
+	)
+}
+
+// generateEscalationNotifications returns the notifications sent to the
+// endpoint of step within its repeat interval, grouped by series.
+func (b *Base) generateEscalationNotifications(step notification.EscalationStep) *ast.PipeExpression {
+	repeat := (*ast.DurationLiteral)(step.RepeatEvery)
+	fn := flux.And(
+		flux.And(
+			flux.Equal(flux.Member("r", "_notification_rule_id"), flux.String(b.ID.String())),
+			flux.Equal(flux.Member("r", "_notification_endpoint_id"), flux.String(step.EndpointID.String())),
+		),
+		flux.Equal(flux.Member("r", "_sent"), flux.String("true")),
+	)
+
+	now := flux.Call(flux.Identifier("now"), flux.Object())
+	return flux.Pipe(
+		flux.Call(
+			flux.Member("monitor", "logs"),
+			flux.Object(
+				flux.Property("start", flux.Negative(repeat)),
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"), fn)),
+			),
+		),
+		// The range includes its start, a notification sent exactly one
+		// repeat interval ago is due again.
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(
+					flux.FunctionParams("r"),
+					flux.GreaterThan(
+						flux.Member("r", "_time"),
+						flux.Call(
+							flux.Member("experimental", "subDuration"),
+							flux.Object(
+								flux.Property("from", now),
+								flux.Property("d", repeat),
+							),
+						),
+					),
+				)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(
+					flux.String("_start"),
+					flux.String("_stop"),
+					flux.String("_measurement"),
+					flux.String("_level"),
+					flux.String("_sent"),
+					flux.String("_notification_rule_id"),
+					flux.String("_notification_rule_name"),
+					flux.String("_notification_endpoint_id"),
+					flux.String("_notification_endpoint_name"),
+				)),
+			),
+		),
+		setNotified(1),
+	)
+}
+
+// generateEscalationDurations returns the calls that group the statuses by
+// series, removing columns from their group key, and set _level_duration to
+// the seconds the series has been at the levels of the status rules.
+func (b *Base) generateEscalationDurations(columns ...string) []*ast.CallExpression {
+	calls := []*ast.CallExpression{
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_start"), flux.String("_stop"))),
+			),
+		),
+	}
+	for _, c := range columns {
+		calls = append(calls, ungroup(c)...)
+	}
+	return append(calls,
+		flux.Call(
+			flux.Identifier("sort"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_time"))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("stateDuration"),
+			flux.Object(
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"), b.generateEscalationLevels())),
+				flux.Property("column", flux.String("_level_duration")),
+				flux.Property("unit", flux.Duration(1, "s")),
+			),
+		),
+	)
+}
+
+// generateEscalationLevels returns the predicate of the levels of the status
+// rules, any level but ok if a rule matches any level.
+func (b *Base) generateEscalationLevels() ast.Expression {
+	level := flux.Member("r", "_level")
+	var expr ast.Expression
+	seen := make(map[notification.CheckLevel]bool)
+	for _, r := range b.StatusRules {
+		if r.CurrentLevel == notification.Any {
+			return flux.NotEqual(level, flux.String("ok"))
+		}
+		if seen[r.CurrentLevel] {
+			continue
+		}
+		seen[r.CurrentLevel] = true
+
+		eq := flux.Equal(level, flux.String(strings.ToLower(r.CurrentLevel.String())))
+		if expr == nil {
+			expr = eq
+			continue
+		}
+		expr = flux.Or(expr, eq)
+	}
+	if expr == nil {
+		return flux.NotEqual(level, flux.String("ok"))
+	}
+	return expr
+}
+
+// generateLastRun returns the predicate of the statuses written within the
+// interval of the last run.
+func (b *Base) generateLastRun() ast.Expression {
+	now := flux.Call(flux.Identifier("now"), flux.Object())
+	return flux.GreaterThan(
+		flux.Member("r", "_time"),
+		flux.Call(
+			flux.Member("experimental", "subDuration"),
+			flux.Object(
+				flux.Property("from", now),
+				flux.Property("d", (*ast.DurationLiteral)(b.Every)),
+			),
+		),
+	)
+}
+
+// ungroup returns the calls that remove column from the group key, the way
+// monitor.stateChanges does with _level.
+func ungroup(column string) []*ast.CallExpression {
+	temp := "____temp" + column + "____"
+	return []*ast.CallExpression{
+		flux.Call(
+			flux.Identifier("duplicate"),
+			flux.Object(
+				flux.Property("column", flux.String(column)),
+				flux.Property("as", flux.String(temp)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String(column))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("rename"),
+			flux.Object(
+				flux.Property("columns", flux.Object(flux.Dictionary(temp, flux.String(column)))),
+			),
+		),
+	}
+}
+
+// regroup returns the call that adds columns back to the group key.
+func regroup(columns ...string) *ast.CallExpression {
+	cols := make([]ast.Expression, 0, len(columns))
+	for _, c := range columns {
+		cols = append(cols, flux.String(c))
+	}
+	return flux.Call(
+		flux.Member("experimental", "group"),
+		flux.Object(
+			flux.Property("mode", flux.String("extend")),
+			flux.Property("columns", flux.Array(cols...)),
+		),
+	)
+}
+
+func setNotified(v int64) *ast.CallExpression {
+	return flux.Call(
+		flux.Identifier("map"),
+		flux.Object(
+			flux.Property("fn", flux.Function(
+				flux.FunctionParams("r"),
+				flux.ObjectWith("r", flux.Property("_notified", flux.Integer(v))),
+			)),
+		),
+	)
+}
+
+// durationLiteral returns the duration literal of d in hours, minutes and
+// seconds.
+func durationLiteral(d time.Duration) *ast.DurationLiteral {
+	lit := &ast.DurationLiteral{}
+	for _, u := range []struct {
+		unit string
+		dur  time.Duration
+	}{
+		{"h", time.Hour},
+		{"m", time.Minute},
+		{"s", time.Second},
+	} {
+		if n := d / u.dur; n > 0 {
+			lit.Values = append(lit.Values, ast.Duration{Magnitude: int64(n), Unit: u.unit})
+			d -= n * u.dur
+		}
+	}
+	if len(lit.Values) == 0 {
+		lit.Values = append(lit.Values, ast.Duration{Magnitude: 0, Unit: "s"})
+	}
+	return lit
+}
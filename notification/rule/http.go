@@ -16,29 +16,40 @@ type HTTP struct {
 }
 
 // GenerateFlux generates a flux script for the http notification rule.
-func (s *HTTP) GenerateFlux(e influxdb.NotificationEndpoint) (string, error) {
+func (s *HTTP) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
 	httpEndpoint, ok := e.(*endpoint.HTTP)
 	if !ok {
 		return "", fmt.Errorf("endpoint provided is a %s, not an HTTP endpoint", e.Type())
 	}
-	p, err := s.GenerateFluxAST(httpEndpoint)
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(httpEndpoint, escalations, silences, acks)
 	if err != nil {
 		return "", err
 	}
 	return ast.Format(p), nil
 }
 
+// httpEscalationMessage is the message template of the escalations of http
+// rules to endpoints of other types, the message of the status.
+const httpEscalationMessage = "${r._message}"
+
 // GenerateFluxAST generates a flux AST for the http notification rule.
-func (s *HTTP) GenerateFluxAST(e *endpoint.HTTP) (*ast.Package, error) {
+func (s *HTTP) GenerateFluxAST(e *endpoint.HTTP, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, httpEscalationMessage, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
 	f := flux.File(
 		s.Name,
-		s.imports(e),
-		s.generateFluxASTBody(e),
+		flux.Imports(mergeImports(s.imports(e), imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
 	)
 	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
 }
 
-func (s *HTTP) imports(e *endpoint.HTTP) []*ast.ImportDeclaration {
+func (s *HTTP) imports(e *endpoint.HTTP) []string {
 	packages := []string{
 		"influxdata/influxdb/monitor",
 		"http",
@@ -50,23 +61,33 @@ func (s *HTTP) imports(e *endpoint.HTTP) []*ast.ImportDeclaration {
 		packages = append(packages, "influxdata/influxdb/secrets")
 	}
 
-	return flux.Imports(packages...)
+	return packages
 }
 
-func (s *HTTP) generateFluxASTBody(e *endpoint.HTTP) []ast.Statement {
+func (s *HTTP) generateFluxASTBody(e *endpoint.HTTP, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
 	var statements []ast.Statement
 	statements = append(statements, s.generateTaskOption())
-	statements = append(statements, s.generateHeaders(e))
-	statements = append(statements, s.generateFluxASTEndpoint(e))
-	statements = append(statements, s.generateFluxASTNotificationDefinition(e))
-	statements = append(statements, s.generateFluxASTStatuses())
-	statements = append(statements, s.generateLevelChecks()...)
-	statements = append(statements, s.generateFluxASTNotifyPipe())
+	statements = append(statements, s.generateHeaders(e, ""))
+	statements = append(statements, s.generateFluxASTEndpoint(e, ""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(""))
 
 	return statements
 }
 
-func (s *HTTP) generateHeaders(e *endpoint.HTTP) ast.Statement {
+func (s *HTTP) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.HTTP)
+	statements := []ast.Statement{
+		s.generateHeaders(esc, suffix),
+		s.generateFluxASTEndpoint(esc, suffix),
+		s.generateFluxASTNotifyPipe(suffix),
+	}
+	return s.imports(esc), statements
+}
+
+func (s *HTTP) generateHeaders(e *endpoint.HTTP, suffix string) ast.Statement {
 	props := []*ast.Property{
 		flux.Dictionary(
 			"Content-Type", flux.String("application/json"),
@@ -112,21 +133,21 @@ func (s *HTTP) generateHeaders(e *endpoint.HTTP) ast.Statement {
 		auth := flux.Dictionary("Authorization", basic)
 		props = append(props, auth)
 	}
-	return flux.DefineVariable("headers", flux.Object(props...))
+	return flux.DefineVariable("headers"+suffix, flux.Object(props...))
 }
 
-func (s *HTTP) generateFluxASTEndpoint(e *endpoint.HTTP) ast.Statement {
+func (s *HTTP) generateFluxASTEndpoint(e *endpoint.HTTP, suffix string) ast.Statement {
 	call := flux.Call(flux.Member("http", "endpoint"), flux.Object(flux.Property("url", flux.String(e.URL))))
 
-	return flux.DefineVariable("endpoint", call)
+	return flux.DefineVariable("endpoint"+suffix, call)
 }
 
-func (s *HTTP) generateFluxASTNotifyPipe() ast.Statement {
+func (s *HTTP) generateFluxASTNotifyPipe(suffix string) ast.Statement {
 	endpointBody := flux.Call(
 		flux.Member("json", "encode"),
 		flux.Object(flux.Property("v", flux.Identifier("body"))),
 	)
-	headers := flux.Property("headers", flux.Identifier("headers"))
+	headers := flux.Property("headers", flux.Identifier("headers"+suffix))
 
 	endpointProps := []*ast.Property{
 		headers,
@@ -140,13 +161,13 @@ func (s *HTTP) generateFluxASTNotifyPipe() ast.Statement {
 	)
 
 	props := []*ast.Property{}
-	props = append(props, flux.Property("data", flux.Identifier("notification")))
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
 	props = append(props, flux.Property("endpoint",
-		flux.Call(flux.Identifier("endpoint"), flux.Object(flux.Property("mapFn", endpointFn)))))
+		flux.Call(flux.Identifier("endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
 
 	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
 
-	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"), call))
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
 }
 
 func (s *HTTP) generateBody() ast.Statement {
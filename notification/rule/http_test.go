@@ -67,7 +67,7 @@ all_statuses
 		URL: "http://localhost:7777",
 	}
 
-	f, err := s.GenerateFlux(e)
+	f, err := s.GenerateFlux(e, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -142,7 +142,7 @@ all_statuses
 		},
 	}
 
-	f, err := s.GenerateFlux(e)
+	f, err := s.GenerateFlux(e, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -215,7 +215,7 @@ all_statuses
 		},
 	}
 
-	f, err := s.GenerateFlux(e)
+	f, err := s.GenerateFlux(e, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -288,7 +288,7 @@ all_statuses
 		},
 	}
 
-	f, err := s.GenerateFlux(e)
+	f, err := s.GenerateFlux(e, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -0,0 +1,223 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/flux"
+)
+
+// OpsGenie is the rule config of opsgenie notification.
+type OpsGenie struct {
+	Base
+	MessageTemplate string   `json:"messageTemplate"`
+	Tags            []string `json:"tags,omitempty"`
+}
+
+// GenerateFlux generates a flux script for the opsgenie notification rule.
+func (s *OpsGenie) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
+	opsGenieEndpoint, ok := e.(*endpoint.OpsGenie)
+	if !ok {
+		return "", fmt.Errorf("endpoint provided is a %s, not an OpsGenie endpoint", e.Type())
+	}
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(opsGenieEndpoint, escalations, silences, acks)
+	if err != nil {
+		return "", err
+	}
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST generates a flux AST for the opsgenie notification rule.
+func (s *OpsGenie) GenerateFluxAST(e *endpoint.OpsGenie, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, s.MessageTemplate, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
+	f := flux.File(
+		s.Name,
+		flux.Imports(mergeImports([]string{"influxdata/influxdb/monitor", "http", "json", "influxdata/influxdb/secrets", "experimental"}, imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
+	)
+	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
+}
+
+func (s *OpsGenie) generateFluxASTBody(e *endpoint.OpsGenie, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, s.generateTaskOption())
+	statements = append(statements, s.generateFluxASTSecrets(e, ""))
+	statements = append(statements, s.generateHeaders(""))
+	statements = append(statements, s.generateFluxASTEndpoint(e, ""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(""))
+
+	return statements
+}
+
+func (s *OpsGenie) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.OpsGenie)
+	statements := []ast.Statement{
+		s.generateFluxASTSecrets(esc, suffix),
+		s.generateHeaders(suffix),
+		s.generateFluxASTEndpoint(esc, suffix),
+		s.generateFluxASTNotifyPipe(suffix),
+	}
+	return []string{"http", "json", "influxdata/influxdb/secrets"}, statements
+}
+
+func (s *OpsGenie) generateFluxASTSecrets(e *endpoint.OpsGenie, suffix string) ast.Statement {
+	call := flux.Call(flux.Member("secrets", "get"), flux.Object(flux.Property("key", flux.String(e.APIKey.Key))))
+
+	return flux.DefineVariable("opsgenie_secret"+suffix, call)
+}
+
+func (s *OpsGenie) generateHeaders(suffix string) ast.Statement {
+	props := []*ast.Property{
+		flux.Dictionary("Content-Type", flux.String("application/json")),
+		flux.Dictionary("Authorization", flux.Add(flux.String("GenieKey "), flux.Identifier("opsgenie_secret"+suffix))),
+	}
+	return flux.DefineVariable("headers"+suffix, flux.Object(props...))
+}
+
+// generateFluxASTEndpoint defines the endpoint of the alert API. It is
+// http.endpoint except that opsgenie accepts alerts with a 202 status code,
+// as they are processed asynchronously.
+func (s *OpsGenie) generateFluxASTEndpoint(e *endpoint.OpsGenie, suffix string) ast.Statement {
+	post := flux.Call(
+		flux.Member("http", "post"),
+		flux.Object(
+			flux.Property("url", flux.String(e.AlertURL())),
+			flux.Property("headers", flux.Member("obj", "headers")),
+			flux.Property("data", flux.Member("obj", "data")),
+		),
+	)
+	sent := flux.Call(flux.Identifier("string"), flux.Object(flux.Property("v", flux.Equal(flux.Integer(202), post))))
+	mapFn := flux.FuncBlock(flux.FunctionParams("r"),
+		flux.DefineVariable("obj", flux.Call(flux.Identifier("mapFn"), flux.Object(flux.Property("r", flux.Identifier("r"))))),
+		&ast.ReturnStatement{
+			Argument: flux.ObjectWith("r", flux.Property("_sent", sent)),
+		},
+	)
+
+	tables := flux.Pipe(
+		flux.Identifier("tables"),
+		flux.Call(flux.Identifier("map"), flux.Object(flux.Property("fn", mapFn))),
+		flux.Call(
+			flux.Member("experimental", "group"),
+			flux.Object(
+				flux.Property("mode", flux.String("extend")),
+				flux.Property("columns", flux.Array(flux.String("_sent"))),
+			),
+		),
+	)
+	tablesParam := &ast.Property{Key: flux.Identifier("tables"), Value: &ast.PipeLiteral{}}
+	fn := flux.Function(flux.FunctionParams("mapFn"), flux.Function([]*ast.Property{tablesParam}, tables))
+
+	return flux.DefineVariable("opsgenie_endpoint"+suffix, fn)
+}
+
+func (s *OpsGenie) generateFluxASTNotifyPipe(suffix string) ast.Statement {
+	alertProps := []*ast.Property{}
+
+	// message:
+	// required
+	// the message of the alert, limited to 130 characters.
+	alertProps = append(alertProps, flux.Property("message", flux.String(s.MessageTemplate)))
+
+	// alias:
+	// optional
+	// the deduplication key of the alert, an open alert with the same alias is
+	// updated instead of a new alert being created.
+	alertProps = append(alertProps, flux.Property("alias", flux.Member("r", "_check_id")))
+
+	// description:
+	// optional
+	// the detailed description of the alert.
+	alertProps = append(alertProps, flux.Property("description", flux.Member("r", "_message")))
+
+	// priority:
+	// optional
+	// the priority of the alert, one of P1 to P5.
+	alertProps = append(alertProps, flux.Property("priority", priorityFromLevel()))
+
+	// source:
+	// optional
+	// the source of the alert.
+	alertProps = append(alertProps, flux.Property("source", flux.Member("notification"+suffix, "_notification_rule_name")))
+
+	if len(s.Tags) > 0 {
+		tags := make([]ast.Expression, 0, len(s.Tags))
+		for _, t := range s.Tags {
+			tags = append(tags, flux.String(t))
+		}
+		alertProps = append(alertProps, flux.Property("tags", flux.Array(tags...)))
+	}
+
+	endpointProps := []*ast.Property{
+		flux.Property("headers", flux.Identifier("headers"+suffix)),
+		flux.Property("data", flux.Call(flux.Member("json", "encode"), flux.Object(flux.Property("v", flux.Object(alertProps...))))),
+	}
+	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
+
+	props := []*ast.Property{}
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
+	props = append(props, flux.Property("endpoint",
+		flux.Call(flux.Identifier("opsgenie_endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
+
+	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
+
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
+}
+
+func priorityFromLevel() ast.Expression {
+	level := flux.Member("r", "_level")
+	return flux.If(
+		flux.Equal(level, flux.String("crit")),
+		flux.String("P1"),
+		flux.If(
+			flux.Equal(level, flux.String("warn")),
+			flux.String("P3"),
+			flux.String("P5"),
+		),
+	)
+}
+
+type opsGenieAlias OpsGenie
+
+// MarshalJSON implement json.Marshaler interface.
+func (s OpsGenie) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			opsGenieAlias
+			Type string `json:"type"`
+		}{
+			opsGenieAlias: opsGenieAlias(s),
+			Type:          s.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (s OpsGenie) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "opsgenie invalid message template",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (s OpsGenie) Type() string {
+	return "opsgenie"
+}
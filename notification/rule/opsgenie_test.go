@@ -0,0 +1,176 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+)
+
+func TestOpsGenie_GenerateFlux(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     string
+		rule     *rule.OpsGenie
+		endpoint *endpoint.OpsGenie
+	}{
+		{
+			name: "default url",
+			want: `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "http"
+import "json"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+opsgenie_secret = secrets["get"](key: "opsgenie-api-key")
+headers = {"Content-Type": "application/json", "Authorization": "GenieKey " + opsgenie_secret}
+opsgenie_endpoint = (mapFn) =>
+	((tables=<-) =>
+		(tables
+			|> map(fn: (r) => {
+				obj = mapFn(r: r)
+
+				return {r with _sent: string(v: 202 == http["post"](url: "https://api.opsgenie.com/v2/alerts", headers: obj["headers"], data: obj["data"]))}
+			})
+			|> experimental["group"](mode: "extend", columns: ["_sent"])))
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: opsgenie_endpoint(mapFn: (r) =>
+		({headers: headers, data: json["encode"](v: {
+			message: "blah",
+			alias: r["_check_id"],
+			description: r["_message"],
+			priority: if r["_level"] == "crit" then "P1" else if r["_level"] == "warn" then "P3" else "P5",
+			source: notification["_notification_rule_name"],
+		})})))`,
+			rule: &rule.OpsGenie{
+				MessageTemplate: "blah",
+				Base: rule.Base{
+					ID:         1,
+					EndpointID: 2,
+					Name:       "foo",
+					Every:      mustDuration("1h"),
+					StatusRules: []notification.StatusRule{
+						{
+							CurrentLevel: notification.Critical,
+						},
+					},
+				},
+			},
+			endpoint: &endpoint.OpsGenie{
+				Base: endpoint.Base{
+					ID:   idPtr(2),
+					Name: "foo",
+				},
+				APIKey: influxdb.SecretField{
+					Key: "opsgenie-api-key",
+				},
+			},
+		},
+		{
+			name: "eu url with tags",
+			want: `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "http"
+import "json"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+opsgenie_secret = secrets["get"](key: "opsgenie-api-key")
+headers = {"Content-Type": "application/json", "Authorization": "GenieKey " + opsgenie_secret}
+opsgenie_endpoint = (mapFn) =>
+	((tables=<-) =>
+		(tables
+			|> map(fn: (r) => {
+				obj = mapFn(r: r)
+
+				return {r with _sent: string(v: 202 == http["post"](url: "https://api.eu.opsgenie.com/v2/alerts", headers: obj["headers"], data: obj["data"]))}
+			})
+			|> experimental["group"](mode: "extend", columns: ["_sent"])))
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: opsgenie_endpoint(mapFn: (r) =>
+		({headers: headers, data: json["encode"](v: {
+			message: "blah",
+			alias: r["_check_id"],
+			description: r["_message"],
+			priority: if r["_level"] == "crit" then "P1" else if r["_level"] == "warn" then "P3" else "P5",
+			source: notification["_notification_rule_name"],
+			tags: ["influxdb", "production"],
+		})})))`,
+			rule: &rule.OpsGenie{
+				MessageTemplate: "blah",
+				Tags:            []string{"influxdb", "production"},
+				Base: rule.Base{
+					ID:         1,
+					EndpointID: 2,
+					Name:       "foo",
+					Every:      mustDuration("1h"),
+					StatusRules: []notification.StatusRule{
+						{
+							CurrentLevel: notification.Critical,
+						},
+					},
+				},
+			},
+			endpoint: &endpoint.OpsGenie{
+				Base: endpoint.Base{
+					ID:   idPtr(2),
+					Name: "foo",
+				},
+				URL: "https://api.eu.opsgenie.com/v2/alerts",
+				APIKey: influxdb.SecretField{
+					Key: "opsgenie-api-key",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := tt.rule.GenerateFlux(tt.endpoint, nil, nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if f != tt.want {
+				t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", tt.want, f)
+			}
+		})
+	}
+}
@@ -50,12 +50,15 @@ func (s PagerDuty) Type() string {
 }
 
 // GenerateFlux generates a flux script for the pagerduty notification rule.
-func (s *PagerDuty) GenerateFlux(e influxdb.NotificationEndpoint) (string, error) {
+func (s *PagerDuty) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
 	pagerdutyEndpoint, ok := e.(*endpoint.PagerDuty)
 	if !ok {
 		return "", fmt.Errorf("endpoint provided is a %s, not an PagerDuty endpoint", e.Type())
 	}
-	p, err := s.GenerateFluxAST(pagerdutyEndpoint)
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(pagerdutyEndpoint, escalations, silences, acks)
 	if err != nil {
 		return "", err
 	}
@@ -63,50 +66,64 @@ func (s *PagerDuty) GenerateFlux(e influxdb.NotificationEndpoint) (string, error
 }
 
 // GenerateFluxAST generates a flux AST for the pagerduty notification rule.
-func (s *PagerDuty) GenerateFluxAST(e *endpoint.PagerDuty) (*ast.Package, error) {
+func (s *PagerDuty) GenerateFluxAST(e *endpoint.PagerDuty, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, s.MessageTemplate, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
 	f := flux.File(
 		s.Name,
-		flux.Imports("influxdata/influxdb/monitor", "pagerduty", "influxdata/influxdb/secrets", "experimental"),
-		s.generateFluxASTBody(e),
+		flux.Imports(mergeImports([]string{"influxdata/influxdb/monitor", "pagerduty", "influxdata/influxdb/secrets", "experimental"}, imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
 	)
 	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
 }
 
-func (s *PagerDuty) generateFluxASTBody(e *endpoint.PagerDuty) []ast.Statement {
+func (s *PagerDuty) generateFluxASTBody(e *endpoint.PagerDuty, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
 	var statements []ast.Statement
 	statements = append(statements, s.generateTaskOption())
-	statements = append(statements, s.generateFluxASTSecrets(e))
-	statements = append(statements, s.generateFluxASTEndpoint(e))
-	statements = append(statements, s.generateFluxASTNotificationDefinition(e))
-	statements = append(statements, s.generateFluxASTStatuses())
-	statements = append(statements, s.generateLevelChecks()...)
-	statements = append(statements, s.generateFluxASTNotifyPipe(e.ClientURL))
+	statements = append(statements, s.generateFluxASTSecrets(e, ""))
+	statements = append(statements, s.generateFluxASTEndpoint(e, ""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(e.ClientURL, ""))
 
 	return statements
 }
 
-func (s *PagerDuty) generateFluxASTSecrets(e *endpoint.PagerDuty) ast.Statement {
+func (s *PagerDuty) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.PagerDuty)
+	statements := []ast.Statement{
+		s.generateFluxASTSecrets(esc, suffix),
+		s.generateFluxASTEndpoint(esc, suffix),
+		s.generateFluxASTNotifyPipe(esc.ClientURL, suffix),
+	}
+	return []string{"pagerduty", "influxdata/influxdb/secrets"}, statements
+}
+
+func (s *PagerDuty) generateFluxASTSecrets(e *endpoint.PagerDuty, suffix string) ast.Statement {
 	call := flux.Call(flux.Member("secrets", "get"), flux.Object(flux.Property("key", flux.String(e.RoutingKey.Key))))
 
-	return flux.DefineVariable("pagerduty_secret", call)
+	return flux.DefineVariable("pagerduty_secret"+suffix, call)
 }
 
-func (s *PagerDuty) generateFluxASTEndpoint(e *endpoint.PagerDuty) ast.Statement {
+func (s *PagerDuty) generateFluxASTEndpoint(e *endpoint.PagerDuty, suffix string) ast.Statement {
 	call := flux.Call(flux.Member("pagerduty", "endpoint"),
 		flux.Object(),
 	)
 
-	return flux.DefineVariable("pagerduty_endpoint", call)
+	return flux.DefineVariable("pagerduty_endpoint"+suffix, call)
 }
 
-func (s *PagerDuty) generateFluxASTNotifyPipe(url string) ast.Statement {
+func (s *PagerDuty) generateFluxASTNotifyPipe(url, suffix string) ast.Statement {
 	endpointProps := []*ast.Property{}
 
 	// routing_key:
 	// required
 	// string
 	// A version 4 UUID expressed as a 32-digit hexadecimal number. This is the Integration Key for an integration on any given service.
-	endpointProps = append(endpointProps, flux.Property("routingKey", flux.Identifier("pagerduty_secret")))
+	endpointProps = append(endpointProps, flux.Property("routingKey", flux.Identifier("pagerduty_secret"+suffix)))
 
 	// client:
 	// optional
@@ -148,7 +165,7 @@ func (s *PagerDuty) generateFluxASTNotifyPipe(url string) ast.Statement {
 	// required
 	// string
 	// The unique location of the affected system, preferably a hostname or FQDN
-	endpointProps = append(endpointProps, flux.Property("source", flux.Member("notification", "_notification_rule_name")))
+	endpointProps = append(endpointProps, flux.Property("source", flux.Member("notification"+suffix, "_notification_rule_name")))
 
 	// summary:
 	// required
@@ -165,13 +182,13 @@ func (s *PagerDuty) generateFluxASTNotifyPipe(url string) ast.Statement {
 	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
 
 	props := []*ast.Property{}
-	props = append(props, flux.Property("data", flux.Identifier("notification")))
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
 	props = append(props, flux.Property("endpoint",
-		flux.Call(flux.Identifier("pagerduty_endpoint"), flux.Object(flux.Property("mapFn", endpointFn)))))
+		flux.Call(flux.Identifier("pagerduty_endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
 
 	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
 
-	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"), call))
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
 }
 
 func severityFromLevel() *ast.CallExpression {
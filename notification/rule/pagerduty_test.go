@@ -277,7 +277,7 @@ all_statuses
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			script, err := tt.rule.GenerateFlux(tt.endpoint)
+			script, err := tt.rule.GenerateFlux(tt.endpoint, nil, nil, nil)
 			if err != nil {
 				panic(err)
 			}
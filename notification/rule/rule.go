@@ -16,6 +16,10 @@ var typeToRule = map[string](func() influxdb.NotificationRule){
 	"slack":     func() influxdb.NotificationRule { return &Slack{} },
 	"pagerduty": func() influxdb.NotificationRule { return &PagerDuty{} },
 	"http":      func() influxdb.NotificationRule { return &HTTP{} },
+	"teams":     func() influxdb.NotificationRule { return &Teams{} },
+	"opsgenie":  func() influxdb.NotificationRule { return &OpsGenie{} },
+	"telegram":  func() influxdb.NotificationRule { return &Telegram{} },
+	"smtp":      func() influxdb.NotificationRule { return &SMTP{} },
 }
 
 // UnmarshalJSON will convert
@@ -57,6 +61,9 @@ type Base struct {
 	RunbookLink string                    `json:"runbookLink"`
 	TagRules    []notification.TagRule    `json:"tagRules,omitempty"`
 	StatusRules []notification.StatusRule `json:"statusRules,omitempty"`
+	// Escalations notify additional endpoints of the statuses that stay at
+	// the levels of the status rules.
+	Escalations []notification.EscalationStep `json:"escalations,omitempty"`
 	*influxdb.Limit
 	influxdb.CRUDLog
 }
@@ -103,6 +110,17 @@ func (b Base) valid() error {
 			return err
 		}
 	}
+	for _, step := range b.Escalations {
+		if err := step.Valid(); err != nil {
+			return err
+		}
+		if step.RepeatEvery != nil && b.Every != nil && step.RepeatEvery.TimeDuration() < b.Every.TimeDuration() {
+			return &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "escalation step repeatEvery should not be less than the interval",
+			}
+		}
+	}
 	if b.Limit != nil {
 		if b.Limit.Every <= 0 || b.Limit.Rate <= 0 {
 			return &influxdb.Error{
@@ -114,16 +132,75 @@ func (b Base) valid() error {
 
 	return nil
 }
-func (b *Base) generateFluxASTNotificationDefinition(e influxdb.NotificationEndpoint) ast.Statement {
+
+// generateFluxASTNotificationDefinition defines the notification data and,
+// when there are silences, the silenced predicate matching the statuses
+// they mute.
+func (b *Base) generateFluxASTNotificationDefinition(e influxdb.NotificationEndpoint, silences []*influxdb.Silence) []ast.Statement {
+	stmts := []ast.Statement{
+		flux.DefineVariable("notification", b.generateFluxASTNotificationData(b.EndpointID, e)),
+	}
+	if len(silences) > 0 {
+		stmts = append(stmts, generateSilenced(silences))
+	}
+	return stmts
+}
+
+// generateFluxASTNotificationData returns the data of the notifications
+// sent to the endpoint e of ID endpointID.
+func (b *Base) generateFluxASTNotificationData(endpointID influxdb.ID, e influxdb.NotificationEndpoint) ast.Expression {
 	ruleID := flux.Property("_notification_rule_id", flux.String(b.ID.String()))
 	ruleName := flux.Property("_notification_rule_name", flux.String(b.Name))
-	endpointID := flux.Property("_notification_endpoint_id", flux.String(b.EndpointID.String()))
+	endpointIDProp := flux.Property("_notification_endpoint_id", flux.String(endpointID.String()))
 	endpointName := flux.Property("_notification_endpoint_name", flux.String(e.GetName()))
 
-	return flux.DefineVariable("notification", flux.Object(ruleID, ruleName, endpointID, endpointName))
+	return flux.Object(ruleID, ruleName, endpointIDProp, endpointName)
+}
+
+func generateSilenced(silences []*influxdb.Silence) ast.Statement {
+	var body ast.Expression
+	for _, s := range silences {
+		expr := generateSilenceMatch(s)
+		if body == nil {
+			body = expr
+			continue
+		}
+		body = flux.Or(body, expr)
+	}
+	return flux.DefineVariable("silenced", flux.Function(flux.FunctionParams("r"), body))
 }
 
-func (b *Base) generateLevelChecks() []ast.Statement {
+func generateSilenceMatch(s *influxdb.Silence) ast.Expression {
+	t := flux.Member("r", "_time")
+	var expr ast.Expression = flux.And(
+		flux.GreaterThanEqual(t, flux.DateTime(s.StartsAt.UTC())),
+		flux.LessThan(t, flux.DateTime(s.EndsAt.UTC())),
+	)
+
+	var checks ast.Expression
+	for _, id := range s.CheckIDs {
+		check := flux.Equal(flux.Member("r", "_check_id"), flux.String(id.String()))
+		if checks == nil {
+			checks = check
+			continue
+		}
+		checks = flux.Or(checks, check)
+	}
+	if checks != nil {
+		expr = flux.And(expr, checks)
+	}
+
+	for _, m := range s.Matchers {
+		k := flux.Member("r", m.Key)
+		expr = flux.And(expr, flux.And(flux.Exists(k), flux.Equal(k, flux.String(m.Value))))
+	}
+	return expr
+}
+
+// generateLevelChecks defines all_statuses, the statuses to notify. When
+// there are silences, the silenced statuses are removed from all_statuses
+// and logged with a silenced status instead of being sent to the endpoint.
+func (b *Base) generateLevelChecks(silences []*influxdb.Silence) []ast.Statement {
 	stmts := []ast.Statement{}
 	tables := []ast.Expression{}
 	for _, r := range b.StatusRules {
@@ -147,43 +224,90 @@ func (b *Base) generateLevelChecks() []ast.Statement {
 		),
 	)
 
-	var pipe *ast.PipeExpression
+	var calls []*ast.CallExpression
+	var base ast.Expression
 	if len(tables) == 1 {
-		pipe = flux.Pipe(
-			tables[0],
-			flux.Call(
-				flux.Identifier("filter"),
-				flux.Object(
-					flux.Property("fn", timeFilter),
-				),
+		base = tables[0]
+	} else {
+		base = flux.Call(
+			flux.Identifier("union"),
+			flux.Object(
+				flux.Property("tables", flux.Array(tables...)),
 			),
 		)
-	} else {
-		pipe = flux.Pipe(
-			flux.Call(
-				flux.Identifier("union"),
-				flux.Object(
-					flux.Property("tables", flux.Array(tables...)),
-				),
+		calls = append(calls, flux.Call(
+			flux.Identifier("sort"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_time"))),
 			),
-			flux.Call(
-				flux.Identifier("sort"),
-				flux.Object(
-					flux.Property("columns", flux.Array(flux.String("_time"))),
-				),
+		))
+	}
+	calls = append(calls, flux.Call(
+		flux.Identifier("filter"),
+		flux.Object(
+			flux.Property("fn", timeFilter),
+		),
+	))
+
+	if len(silences) == 0 {
+		stmts = append(stmts, flux.DefineVariable("all_statuses", flux.Pipe(base, calls...)))
+		return stmts
+	}
+
+	silenced := flux.Call(flux.Identifier("silenced"), flux.Object(flux.Property("r", flux.Identifier("r"))))
+	stmts = append(stmts, flux.DefineVariable("matched_statuses", flux.Pipe(base, calls...)))
+	stmts = append(stmts, flux.DefineVariable("all_statuses", flux.Pipe(
+		flux.Identifier("matched_statuses"),
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"), flux.Not(silenced))),
 			),
+		),
+	)))
+	stmts = append(stmts, b.generateSilencedNotifyPipe(silenced))
+
+	return stmts
+}
+
+// generateSilencedNotifyPipe logs the silenced statuses to the notifications
+// measurement with _sent set to false and _status set to silenced.
+func (b *Base) generateSilencedNotifyPipe(silenced ast.Expression) ast.Statement {
+	endpoint := flux.Function(
+		[]*ast.Property{{Key: flux.Identifier("tables"), Value: &ast.PipeLiteral{}}},
+		flux.Pipe(
+			flux.Identifier("tables"),
 			flux.Call(
-				flux.Identifier("filter"),
+				flux.Identifier("map"),
 				flux.Object(
-					flux.Property("fn", timeFilter),
+					flux.Property("fn", flux.Function(
+						flux.FunctionParams("r"),
+						flux.ObjectWith("r",
+							flux.Property("_sent", flux.String("false")),
+							flux.Property("_status", flux.String("silenced")),
+						),
+					)),
 				),
 			),
-		)
-	}
-
-	stmts = append(stmts, flux.DefineVariable("all_statuses", pipe))
+		),
+	)
 
-	return stmts
+	return flux.ExpressionStatement(flux.Pipe(
+		flux.Identifier("matched_statuses"),
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"), silenced)),
+			),
+		),
+		flux.Call(
+			flux.Member("monitor", "notify"),
+			flux.Object(
+				flux.Property("data", flux.Identifier("notification")),
+				flux.Property("endpoint", endpoint),
+			),
+		),
+	))
 }
 
 func (b *Base) generateLevelCheck(r notification.StatusRule) (ast.Statement, *ast.Identifier) {
@@ -285,24 +409,148 @@ func (b *Base) generateTaskOption() ast.Statement {
 	return flux.DefineTaskOption(flux.Object(props...))
 }
 
-func (b *Base) generateFluxASTStatuses() ast.Statement {
-	props := []*ast.Property{}
-
+// generateFluxASTStatuses defines statuses, the statuses the rule reads.
+// When there are alert acknowledgements, the statuses of the acknowledged
+// series are read from the start of their acknowledgement, and only those
+// that follow the first change of level are kept.
+func (b *Base) generateFluxASTStatuses(acks []*influxdb.AlertAcknowledgement) []ast.Statement {
 	dur := (*ast.DurationLiteral)(b.Every)
-	props = append(props, flux.Property("start", flux.Negative(increaseDur(dur))))
+	start := flux.Negative(increaseDur(dur))
 
-	if len(b.TagRules) > 0 {
-		r := b.TagRules[0]
-		var body ast.Expression = r.GenerateFluxAST()
-		for _, r := range b.TagRules[1:] {
-			body = flux.And(body, r.GenerateFluxAST())
+	tagRules := b.generateTagRules()
+	if len(acks) == 0 {
+		return []ast.Statement{flux.DefineVariable("statuses", monitorFrom(start, tagRules))}
+	}
+
+	var acknowledged ast.Expression
+	for _, a := range acks {
+		expr := generateAcknowledgementMatch(a)
+		if acknowledged == nil {
+			acknowledged = expr
+			continue
 		}
-		props = append(props, flux.Property("fn", flux.Function(flux.FunctionParams("r"), body)))
+		acknowledged = flux.Or(acknowledged, expr)
+	}
+
+	stmts := []ast.Statement{
+		flux.DefineVariable("acknowledged", flux.Function(flux.FunctionParams("r"), acknowledged)),
+		flux.DefineVariable("unacknowledged_statuses", monitorFrom(start, generateUnacknowledged(tagRules))),
+	}
+	for i, a := range acks {
+		stmts = append(stmts, flux.DefineVariable(acknowledgementName(i), b.generateAcknowledgementStatuses(a, tagRules)))
+	}
+	stmts = append(stmts, flux.DefineVariable("statuses", unionAcknowledgements(flux.Identifier("unacknowledged_statuses"), acks)))
+	return stmts
+}
+
+// generateUnacknowledged returns the predicate of the statuses matched by
+// tagRules that are not of an acknowledged series.
+func generateUnacknowledged(tagRules ast.Expression) ast.Expression {
+	var unacknowledged ast.Expression = flux.Not(flux.Call(flux.Identifier("acknowledged"), flux.Object(flux.Property("r", flux.Identifier("r")))))
+	if tagRules != nil {
+		unacknowledged = flux.And(tagRules, unacknowledged)
+	}
+	return unacknowledged
+}
+
+func acknowledgementName(i int) string {
+	return fmt.Sprintf("acknowledgement_%d", i)
+}
+
+// unionAcknowledgements returns the union of the unacknowledged statuses and
+// the statuses of the acknowledged series.
+func unionAcknowledgements(unacknowledged ast.Expression, acks []*influxdb.AlertAcknowledgement) ast.Expression {
+	tables := []ast.Expression{unacknowledged}
+	for i := range acks {
+		tables = append(tables, flux.Identifier(acknowledgementName(i)))
+	}
+	return flux.Call(
+		flux.Identifier("union"),
+		flux.Object(
+			flux.Property("tables", flux.Array(tables...)),
+		),
+	)
+}
+
+// generateTagRules returns the predicate of the tag rules, or nil if there
+// are none.
+func (b *Base) generateTagRules() ast.Expression {
+	if len(b.TagRules) == 0 {
+		return nil
+	}
+	var body ast.Expression = b.TagRules[0].GenerateFluxAST()
+	for _, r := range b.TagRules[1:] {
+		body = flux.And(body, r.GenerateFluxAST())
+	}
+	return body
+}
+
+func monitorFrom(start ast.Expression, fn ast.Expression) ast.Expression {
+	props := []*ast.Property{flux.Property("start", start)}
+	if fn != nil {
+		props = append(props, flux.Property("fn", flux.Function(flux.FunctionParams("r"), fn)))
+	}
+	return flux.Call(flux.Member("monitor", "from"), flux.Object(props...))
+}
+
+func generateAcknowledgementMatch(a *influxdb.AlertAcknowledgement) ast.Expression {
+	var expr ast.Expression = flux.Equal(flux.Member("r", "_check_id"), flux.String(a.CheckID.String()))
+	for _, t := range a.Tags {
+		k := flux.Member("r", t.Key)
+		expr = flux.And(expr, flux.And(flux.Exists(k), flux.Equal(k, flux.String(t.Value))))
 	}
+	return expr
+}
 
-	base := flux.Call(flux.Member("monitor", "from"), flux.Object(props...))
+// generateAcknowledgementStatuses returns the statuses of the series of a
+// that were written after the series left the acknowledged level.
+func (b *Base) generateAcknowledgementStatuses(a *influxdb.AlertAcknowledgement, tagRules ast.Expression) ast.Expression {
+	match := generateAcknowledgementMatch(a)
+	if tagRules != nil {
+		match = flux.And(tagRules, match)
+	}
 
-	return flux.DefineVariable("statuses", base)
+	changed := flux.Member("r", "_level_changed")
+	return flux.Pipe(
+		monitorFrom(flux.DateTime(a.StartedAt.UTC()), match),
+		flux.Call(
+			flux.Identifier("sort"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_time"))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("map"),
+			flux.Object(
+				flux.Property("fn", flux.Function(
+					flux.FunctionParams("r"),
+					flux.ObjectWith("r", flux.Property("_level_changed", flux.If(
+						flux.Equal(flux.Member("r", "_level"), flux.String(strings.ToLower(a.Level))),
+						flux.Integer(0),
+						flux.Integer(1),
+					))),
+				)),
+			),
+		),
+		flux.Call(
+			flux.Identifier("cumulativeSum"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_level_changed"))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("filter"),
+			flux.Object(
+				flux.Property("fn", flux.Function(flux.FunctionParams("r"), flux.GreaterThan(changed, flux.Integer(0)))),
+			),
+		),
+		flux.Call(
+			flux.Identifier("drop"),
+			flux.Object(
+				flux.Property("columns", flux.Array(flux.String("_level_changed"))),
+			),
+		),
+	)
 }
 
 // GetID implements influxdb.Getter interface.
@@ -315,6 +563,15 @@ func (b Base) GetEndpointID() influxdb.ID {
 	return b.EndpointID
 }
 
+// GetEscalationEndpointIDs returns the endpoint IDs of the escalation steps.
+func (b Base) GetEscalationEndpointIDs() []influxdb.ID {
+	ids := make([]influxdb.ID, 0, len(b.Escalations))
+	for _, step := range b.Escalations {
+		ids = append(ids, step.EndpointID)
+	}
+	return ids
+}
+
 // GetOrgID implements influxdb.Getter interface.
 func (b Base) GetOrgID() influxdb.ID {
 	return b.OrgID
@@ -367,6 +624,25 @@ func (b *Base) MatchesTags(tags []influxdb.Tag) bool {
 	return true
 }
 
+// MayMatchTags returns false if a tag rule of the Rule excludes the statuses
+// with all of the tags.
+func (b *Base) MayMatchTags(tags []influxdb.Tag) bool {
+	for _, tr := range b.TagRules {
+		for _, t := range tags {
+			if tr.Key != t.Key {
+				continue
+			}
+			if tr.Operator == influxdb.Equal && tr.Value != t.Value {
+				return false
+			}
+			if tr.Operator == influxdb.NotEqual && tr.Value == t.Value {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // GetOwnerID returns the owner id.
 func (b Base) GetOwnerID() influxdb.ID {
 	return b.OwnerID
@@ -195,6 +195,52 @@ func TestValidRule(t *testing.T) {
 				Msg:  `if limit is set, limit and limitEvery must be larger than 0`,
 			},
 		},
+		{
+			name: "bad escalation endpoint",
+			src: &rule.Slack{
+				Base: rule.Base{
+					ID:         influxTesting.MustIDBase16(id1),
+					OwnerID:    influxTesting.MustIDBase16(id2),
+					OrgID:      influxTesting.MustIDBase16(id3),
+					EndpointID: 1,
+					Name:       "name1",
+					Escalations: []notification.EscalationStep{
+						{
+							Delay: mustDuration("15m"),
+						},
+					},
+				},
+				MessageTemplate: "body {var2}",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "escalation step endpointID is invalid",
+			},
+		},
+		{
+			name: "escalation repeated more often than the interval",
+			src: &rule.Slack{
+				Base: rule.Base{
+					ID:         influxTesting.MustIDBase16(id1),
+					OwnerID:    influxTesting.MustIDBase16(id2),
+					OrgID:      influxTesting.MustIDBase16(id3),
+					EndpointID: 1,
+					Name:       "name1",
+					Every:      mustDuration("1h"),
+					Escalations: []notification.EscalationStep{
+						{
+							EndpointID:  2,
+							RepeatEvery: mustDuration("10m"),
+						},
+					},
+				},
+				MessageTemplate: "body {var2}",
+			},
+			err: &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "escalation step repeatEvery should not be less than the interval",
+			},
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.name, func(t *testing.T) {
@@ -321,6 +367,41 @@ func TestJSON(t *testing.T) {
 				MessageTemplate: "msg1",
 			},
 		},
+		{
+			name: "slack with escalations",
+			src: &rule.Slack{
+				Base: rule.Base{
+					ID:          influxTesting.MustIDBase16(id1),
+					Name:        "name1",
+					OwnerID:     influxTesting.MustIDBase16(id2),
+					OrgID:       influxTesting.MustIDBase16(id3),
+					RunbookLink: "runbooklink1",
+					Every:       mustDuration("10m"),
+					StatusRules: []notification.StatusRule{
+						{
+							CurrentLevel: notification.Critical,
+						},
+					},
+					Escalations: []notification.EscalationStep{
+						{
+							EndpointID: influxTesting.MustIDBase16(id2),
+							Delay:      mustDuration("15m"),
+						},
+						{
+							EndpointID:  influxTesting.MustIDBase16(id3),
+							Delay:       mustDuration("1h"),
+							RepeatEvery: mustDuration("1h"),
+						},
+					},
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: timeGen1.Now(),
+						UpdatedAt: timeGen2.Now(),
+					},
+				},
+				Channel:         "channel1",
+				MessageTemplate: "msg1",
+			},
+		},
 	}
 	for _, c := range cases {
 		b, err := json.Marshal(c.src)
@@ -503,3 +584,53 @@ func TestMatchingRules(t *testing.T) {
 		})
 	}
 }
+
+func TestMayMatchTags(t *testing.T) {
+	tagRules := []notification.TagRule{
+		{
+			Tag:      influxdb.Tag{Key: "a", Value: "b"},
+			Operator: influxdb.Equal,
+		},
+		{
+			Tag:      influxdb.Tag{Key: "c", Value: "d"},
+			Operator: influxdb.NotEqual,
+		},
+	}
+	cases := []struct {
+		name string
+		tags []influxdb.Tag
+		exp  bool
+	}{
+		{
+			name: "tags of the tag rules",
+			tags: []influxdb.Tag{{Key: "a", Value: "b"}, {Key: "c", Value: "X"}},
+			exp:  true,
+		},
+		{
+			name: "tags without the keys of the tag rules",
+			tags: []influxdb.Tag{{Key: "host", Value: "X"}},
+			exp:  true,
+		},
+		{
+			name: "no tags",
+			exp:  true,
+		},
+		{
+			name: "value excluded by an equal tag rule",
+			tags: []influxdb.Tag{{Key: "a", Value: "X"}},
+			exp:  false,
+		},
+		{
+			name: "value excluded by a notEqual tag rule",
+			tags: []influxdb.Tag{{Key: "host", Value: "X"}, {Key: "c", Value: "d"}},
+			exp:  false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			r := rule.Base{TagRules: tagRules}
+
+			assert.Equal(t, c.exp, r.MayMatchTags(c.tags))
+		})
+	}
+}
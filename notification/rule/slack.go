@@ -18,12 +18,15 @@ type Slack struct {
 }
 
 // GenerateFlux generates a flux script for the slack notification rule.
-func (s *Slack) GenerateFlux(e influxdb.NotificationEndpoint) (string, error) {
+func (s *Slack) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
 	slackEndpoint, ok := e.(*endpoint.Slack)
 	if !ok {
 		return "", fmt.Errorf("endpoint provided is a %s, not an Slack endpoint", e.Type())
 	}
-	p, err := s.GenerateFluxAST(slackEndpoint)
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(slackEndpoint, escalations, silences, acks)
 	if err != nil {
 		return "", err
 	}
@@ -31,50 +34,65 @@ func (s *Slack) GenerateFlux(e influxdb.NotificationEndpoint) (string, error) {
 }
 
 // GenerateFluxAST generates a flux AST for the slack notification rule.
-func (s *Slack) GenerateFluxAST(e *endpoint.Slack) (*ast.Package, error) {
+func (s *Slack) GenerateFluxAST(e *endpoint.Slack, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, s.MessageTemplate, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
 	f := flux.File(
 		s.Name,
-		flux.Imports("influxdata/influxdb/monitor", "slack", "influxdata/influxdb/secrets", "experimental"),
-		s.generateFluxASTBody(e),
+		flux.Imports(mergeImports([]string{"influxdata/influxdb/monitor", "slack", "influxdata/influxdb/secrets", "experimental"}, imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
 	)
 	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
 }
 
-func (s *Slack) generateFluxASTBody(e *endpoint.Slack) []ast.Statement {
+func (s *Slack) generateFluxASTBody(e *endpoint.Slack, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
 	var statements []ast.Statement
 	statements = append(statements, s.generateTaskOption())
 	if e.Token.Key != "" {
-		statements = append(statements, s.generateFluxASTSecrets(e))
+		statements = append(statements, s.generateFluxASTSecrets(e, ""))
 	}
-	statements = append(statements, s.generateFluxASTEndpoint(e))
-	statements = append(statements, s.generateFluxASTNotificationDefinition(e))
-	statements = append(statements, s.generateFluxASTStatuses())
-	statements = append(statements, s.generateLevelChecks()...)
-	statements = append(statements, s.generateFluxASTNotifyPipe())
+	statements = append(statements, s.generateFluxASTEndpoint(e, ""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(""))
 
 	return statements
 }
 
-func (s *Slack) generateFluxASTSecrets(e *endpoint.Slack) ast.Statement {
+func (s *Slack) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.Slack)
+	var statements []ast.Statement
+	if esc.Token.Key != "" {
+		statements = append(statements, s.generateFluxASTSecrets(esc, suffix))
+	}
+	statements = append(statements, s.generateFluxASTEndpoint(esc, suffix))
+	statements = append(statements, s.generateFluxASTNotifyPipe(suffix))
+	return []string{"slack", "influxdata/influxdb/secrets"}, statements
+}
+
+func (s *Slack) generateFluxASTSecrets(e *endpoint.Slack, suffix string) ast.Statement {
 	call := flux.Call(flux.Member("secrets", "get"), flux.Object(flux.Property("key", flux.String(e.Token.Key))))
 
-	return flux.DefineVariable("slack_secret", call)
+	return flux.DefineVariable("slack_secret"+suffix, call)
 }
 
-func (s *Slack) generateFluxASTEndpoint(e *endpoint.Slack) ast.Statement {
+func (s *Slack) generateFluxASTEndpoint(e *endpoint.Slack, suffix string) ast.Statement {
 	props := []*ast.Property{}
 	if e.Token.Key != "" {
-		props = append(props, flux.Property("token", flux.Identifier("slack_secret")))
+		props = append(props, flux.Property("token", flux.Identifier("slack_secret"+suffix)))
 	}
 	if e.URL != "" {
 		props = append(props, flux.Property("url", flux.String(e.URL)))
 	}
 	call := flux.Call(flux.Member("slack", "endpoint"), flux.Object(props...))
 
-	return flux.DefineVariable("slack_endpoint", call)
+	return flux.DefineVariable("slack_endpoint"+suffix, call)
 }
 
-func (s *Slack) generateFluxASTNotifyPipe() ast.Statement {
+func (s *Slack) generateFluxASTNotifyPipe(suffix string) ast.Statement {
 	endpointProps := []*ast.Property{}
 	endpointProps = append(endpointProps, flux.Property("channel", flux.String(s.Channel)))
 	// TODO(desa): are these values correct?
@@ -83,13 +101,13 @@ func (s *Slack) generateFluxASTNotifyPipe() ast.Statement {
 	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
 
 	props := []*ast.Property{}
-	props = append(props, flux.Property("data", flux.Identifier("notification")))
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
 	props = append(props, flux.Property("endpoint",
-		flux.Call(flux.Identifier("slack_endpoint"), flux.Object(flux.Property("mapFn", endpointFn)))))
+		flux.Call(flux.Identifier("slack_endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
 
 	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
 
-	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"), call))
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
 }
 
 func (s *Slack) generateSlackColors() ast.Expression {
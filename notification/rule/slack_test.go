@@ -2,6 +2,7 @@ package rule_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/influxdata/flux/ast"
 	"github.com/influxdata/flux/parser"
@@ -344,7 +345,7 @@ all_statuses
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			f, err := tt.rule.GenerateFlux(tt.endpoint)
+			f, err := tt.rule.GenerateFlux(tt.endpoint, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -355,3 +356,493 @@ all_statuses
 		})
 	}
 }
+
+func TestSlack_GenerateFluxWithSilences(t *testing.T) {
+	start := time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	silences := []*influxdb.Silence{
+		{
+			ID:       10,
+			OrgID:    3,
+			CheckIDs: []influxdb.ID{4, 5},
+			StartsAt: start,
+			EndsAt:   start.Add(time.Hour),
+		},
+		{
+			ID:    11,
+			OrgID: 3,
+			Matchers: []influxdb.Tag{
+				{Key: "host", Value: "db01"},
+			},
+			StartsAt: start,
+			EndsAt:   start.Add(2 * time.Hour),
+		},
+	}
+
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "slack"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+slack_endpoint = slack["endpoint"](url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+silenced = (r) =>
+	(r["_time"] >= 2020-06-01T10:00:00Z and r["_time"] < 2020-06-01T11:00:00Z and (r["_check_id"] == "0000000000000004" or r["_check_id"] == "0000000000000005") or r["_time"] >= 2020-06-01T10:00:00Z and r["_time"] < 2020-06-01T12:00:00Z and (exists r["host"] and r["host"] == "db01"))
+statuses = monitor["from"](start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+matched_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+all_statuses = matched_statuses
+	|> filter(fn: (r) =>
+		(not silenced(r: r)))
+
+matched_statuses
+	|> filter(fn: (r) =>
+		(silenced(r: r)))
+	|> monitor["notify"](data: notification, endpoint: (tables=<-) =>
+		(tables
+			|> map(fn: (r) =>
+				({r with _sent: "false", _status: "silenced"}))))
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: slack_endpoint(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r["_level"] == "crit" then "danger" else if r["_level"] == "warn" then "warning" else "good"})))`
+
+	s := &rule.Slack{
+		Channel:         "bar",
+		MessageTemplate: "blah",
+		Base: rule.Base{
+			ID:         1,
+			EndpointID: 2,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+	e := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		URL: "http://localhost:7777",
+	}
+
+	f, err := s.GenerateFlux(e, nil, silences, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
+
+func TestSlack_GenerateFluxWithAcknowledgements(t *testing.T) {
+	acks := []*influxdb.AlertAcknowledgement{
+		{
+			ID:      10,
+			OrgID:   3,
+			CheckID: 4,
+			Tags: []influxdb.Tag{
+				{Key: "host", Value: "db01"},
+			},
+			Level:     "CRIT",
+			StartedAt: time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "slack"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+slack_endpoint = slack["endpoint"](url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+acknowledged = (r) =>
+	(r["_check_id"] == "0000000000000004" and (exists r["host"] and r["host"] == "db01"))
+unacknowledged_statuses = monitor["from"](start: -2h, fn: (r) =>
+	(r["region"] == "eu" and not acknowledged(r: r)))
+acknowledgement_0 = monitor["from"](start: 2020-06-01T10:00:00Z, fn: (r) =>
+	(r["region"] == "eu" and (r["_check_id"] == "0000000000000004" and (exists r["host"] and r["host"] == "db01"))))
+	|> sort(columns: ["_time"])
+	|> map(fn: (r) =>
+		({r with _level_changed: if r["_level"] == "crit" then 0 else 1}))
+	|> cumulativeSum(columns: ["_level_changed"])
+	|> filter(fn: (r) =>
+		(r["_level_changed"] > 0))
+	|> drop(columns: ["_level_changed"])
+statuses = union(tables: [unacknowledged_statuses, acknowledgement_0])
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: slack_endpoint(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r["_level"] == "crit" then "danger" else if r["_level"] == "warn" then "warning" else "good"})))`
+
+	s := &rule.Slack{
+		Channel:         "bar",
+		MessageTemplate: "blah",
+		Base: rule.Base{
+			ID:         1,
+			EndpointID: 2,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			TagRules: []notification.TagRule{
+				{
+					Tag: influxdb.Tag{
+						Key:   "region",
+						Value: "eu",
+					},
+					Operator: influxdb.Equal,
+				},
+			},
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+	e := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		URL: "http://localhost:7777",
+	}
+
+	f, err := s.GenerateFlux(e, nil, nil, acks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
+
+func TestSlack_GenerateFluxWithEscalations(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "slack"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 10m}
+
+slack_endpoint = slack["endpoint"](url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -11m)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+warn = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "warn"))
+all_statuses = union(tables: [crit, warn])
+	|> sort(columns: ["_time"])
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 10m)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: slack_endpoint(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r["_level"] == "crit" then "danger" else if r["_level"] == "warn" then "warning" else "good"})))
+
+notification_escalation_0 = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000003",
+	_notification_endpoint_name: "oncall",
+}
+statuses_escalation_0 = monitor["from"](start: -35m)
+all_statuses_escalation_0 = statuses_escalation_0
+	|> drop(columns: ["_start", "_stop"])
+	|> duplicate(column: "_level", as: "____temp_level____")
+	|> drop(columns: ["_level"])
+	|> rename(columns: {"____temp_level____": "_level"})
+	|> sort(columns: ["_time"])
+	|> stateDuration(fn: (r) =>
+		(r["_level"] == "crit" or r["_level"] == "warn"), column: "_level_duration", unit: 1s)
+	|> stateCount(fn: (r) =>
+		(r["_level_duration"] >= 900), column: "_escalation_count")
+	|> filter(fn: (r) =>
+		(r["_escalation_count"] == 1 and r["_time"] > experimental["subDuration"](from: now(), d: 10m)))
+	|> drop(columns: ["_level_duration", "_escalation_count"])
+	|> experimental["group"](mode: "extend", columns: ["_level"])
+slack_endpoint_escalation_0 = slack["endpoint"](url: "http://localhost:7778")
+
+all_statuses_escalation_0
+	|> monitor["notify"](data: notification_escalation_0, endpoint: slack_endpoint_escalation_0(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r["_level"] == "crit" then "danger" else if r["_level"] == "warn" then "warning" else "good"})))
+
+notification_escalation_1 = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000004",
+	_notification_endpoint_name: "managers",
+}
+statuses_escalation_1 = monitor["from"](start: -1h20m)
+due_statuses_escalation_1 = statuses_escalation_1
+	|> drop(columns: ["_start", "_stop"])
+	|> duplicate(column: "_level", as: "____temp_level____")
+	|> drop(columns: ["_level"])
+	|> rename(columns: {"____temp_level____": "_level"})
+	|> duplicate(column: "_measurement", as: "____temp_measurement____")
+	|> drop(columns: ["_measurement"])
+	|> rename(columns: {"____temp_measurement____": "_measurement"})
+	|> sort(columns: ["_time"])
+	|> stateDuration(fn: (r) =>
+		(r["_level"] == "crit" or r["_level"] == "warn"), column: "_level_duration", unit: 1s)
+	|> filter(fn: (r) =>
+		(r["_level_duration"] >= 3600 and r["_time"] > experimental["subDuration"](from: now(), d: 10m)))
+	|> drop(columns: ["_level_duration"])
+	|> last(column: "_time")
+	|> map(fn: (r) =>
+		({r with _notified: 0}))
+notifications_escalation_1 = monitor["logs"](start: -1h, fn: (r) =>
+	(r["_notification_rule_id"] == "0000000000000001" and r["_notification_endpoint_id"] == "0000000000000004" and r["_sent"] == "true"))
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+	|> drop(columns: ["_start", "_stop", "_measurement", "_level", "_sent", "_notification_rule_id", "_notification_rule_name", "_notification_endpoint_id", "_notification_endpoint_name"])
+	|> map(fn: (r) =>
+		({r with _notified: 1}))
+all_statuses_escalation_1 = union(tables: [due_statuses_escalation_1, notifications_escalation_1])
+	|> sort(columns: ["_notified"], desc: true)
+	|> cumulativeSum(columns: ["_notified"])
+	|> filter(fn: (r) =>
+		(r["_notified"] == 0))
+	|> drop(columns: ["_notified"])
+	|> experimental["group"](mode: "extend", columns: ["_level", "_measurement"])
+slack_secret_escalation_1 = secrets["get"](key: "slack_token")
+slack_endpoint_escalation_1 = slack["endpoint"](token: slack_secret_escalation_1)
+
+all_statuses_escalation_1
+	|> monitor["notify"](data: notification_escalation_1, endpoint: slack_endpoint_escalation_1(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r["_level"] == "crit" then "danger" else if r["_level"] == "warn" then "warning" else "good"})))`
+
+	s := &rule.Slack{
+		Channel:         "bar",
+		MessageTemplate: "blah",
+		Base: rule.Base{
+			ID:         1,
+			EndpointID: 2,
+			Name:       "foo",
+			Every:      mustDuration("10m"),
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+				{
+					CurrentLevel: notification.Warn,
+				},
+			},
+			Escalations: []notification.EscalationStep{
+				{
+					EndpointID: 3,
+					Delay:      mustDuration("15m"),
+				},
+				{
+					EndpointID:  4,
+					Delay:       mustDuration("1h"),
+					RepeatEvery: mustDuration("1h"),
+				},
+			},
+		},
+	}
+	e := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		URL: "http://localhost:7777",
+	}
+	escalations := []influxdb.NotificationEndpoint{
+		&endpoint.Slack{
+			Base: endpoint.Base{
+				ID:   idPtr(3),
+				Name: "oncall",
+			},
+			URL: "http://localhost:7778",
+		},
+		&endpoint.Slack{
+			Base: endpoint.Base{
+				ID:   idPtr(4),
+				Name: "managers",
+			},
+			Token: influxdb.SecretField{Key: "slack_token"},
+		},
+	}
+
+	f, err := s.GenerateFlux(e, escalations, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
+
+func TestSlack_GenerateFluxWithEscalationToOtherEndpointType(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "slack"
+import "influxdata/influxdb/secrets"
+import "experimental"
+import "pagerduty"
+
+option task = {name: "foo", every: 10m}
+
+slack_endpoint = slack["endpoint"](url: "http://localhost:7777")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -11m)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 10m)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: slack_endpoint(mapFn: (r) =>
+		({channel: "bar", text: "blah", color: if r["_level"] == "crit" then "danger" else if r["_level"] == "warn" then "warning" else "good"})))
+
+notification_escalation_0 = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000003",
+	_notification_endpoint_name: "oncall",
+}
+statuses_escalation_0 = monitor["from"](start: -35m)
+all_statuses_escalation_0 = statuses_escalation_0
+	|> drop(columns: ["_start", "_stop"])
+	|> duplicate(column: "_level", as: "____temp_level____")
+	|> drop(columns: ["_level"])
+	|> rename(columns: {"____temp_level____": "_level"})
+	|> sort(columns: ["_time"])
+	|> stateDuration(fn: (r) =>
+		(r["_level"] == "crit"), column: "_level_duration", unit: 1s)
+	|> stateCount(fn: (r) =>
+		(r["_level_duration"] >= 900), column: "_escalation_count")
+	|> filter(fn: (r) =>
+		(r["_escalation_count"] == 1 and r["_time"] > experimental["subDuration"](from: now(), d: 10m)))
+	|> drop(columns: ["_level_duration", "_escalation_count"])
+	|> experimental["group"](mode: "extend", columns: ["_level"])
+pagerduty_secret_escalation_0 = secrets["get"](key: "pagerduty_token")
+pagerduty_endpoint_escalation_0 = pagerduty["endpoint"]()
+
+all_statuses_escalation_0
+	|> monitor["notify"](data: notification_escalation_0, endpoint: pagerduty_endpoint_escalation_0(mapFn: (r) =>
+		({
+			routingKey: pagerduty_secret_escalation_0,
+			client: "influxdata",
+			clientURL: "http://localhost:7777/host/${r.host}",
+			class: r._check_name,
+			group: r["_source_measurement"],
+			severity: pagerduty["severityFromLevel"](level: r["_level"]),
+			eventAction: pagerduty["actionFromLevel"](level: r["_level"]),
+			source: notification_escalation_0["_notification_rule_name"],
+			summary: r["_message"],
+			timestamp: time(v: r["_source_timestamp"]),
+		})))`
+
+	s := &rule.Slack{
+		Channel:         "bar",
+		MessageTemplate: "blah",
+		Base: rule.Base{
+			ID:         1,
+			EndpointID: 2,
+			Name:       "foo",
+			Every:      mustDuration("10m"),
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+			Escalations: []notification.EscalationStep{
+				{
+					EndpointID: 3,
+					Delay:      mustDuration("15m"),
+				},
+			},
+		},
+	}
+	e := &endpoint.Slack{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		URL: "http://localhost:7777",
+	}
+	escalations := []influxdb.NotificationEndpoint{
+		&endpoint.PagerDuty{
+			Base: endpoint.Base{
+				ID:   idPtr(3),
+				Name: "oncall",
+			},
+			ClientURL:  "http://localhost:7777/host/${r.host}",
+			RoutingKey: influxdb.SecretField{Key: "pagerduty_token"},
+		},
+	}
+
+	f, err := s.GenerateFlux(e, escalations, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+
+	escalations = []influxdb.NotificationEndpoint{
+		&endpoint.Telegram{
+			Base: endpoint.Base{
+				ID:   idPtr(3),
+				Name: "oncall",
+			},
+			Token: influxdb.SecretField{Key: "telegram_token"},
+		},
+	}
+	if _, err := s.GenerateFlux(e, escalations, nil, nil); err == nil {
+		t.Error("expected an error escalating to a telegram endpoint")
+	}
+}
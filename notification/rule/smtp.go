@@ -0,0 +1,149 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/mail"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/flux"
+)
+
+// SMTP is the notification rule config of smtp.
+type SMTP struct {
+	Base
+	SubjectTemplate string `json:"subjectTemplate"`
+	BodyTemplate    string `json:"bodyTemplate,omitempty"`
+	// To is the comma separated list of the addresses the mails are sent to.
+	// example: oncall@example.com, Ops <ops@example.com>
+	To string `json:"to"`
+}
+
+// GenerateFlux generates a flux script for the smtp notification rule.
+func (s *SMTP) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
+	smtpEndpoint, ok := e.(*endpoint.SMTP)
+	if !ok {
+		return "", fmt.Errorf("endpoint provided is a %s, not an SMTP endpoint", e.Type())
+	}
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(smtpEndpoint, escalations, silences, acks)
+	if err != nil {
+		return "", err
+	}
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST generates a flux AST for the smtp notification rule.
+func (s *SMTP) GenerateFluxAST(e *endpoint.SMTP, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, s.SubjectTemplate, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
+	f := flux.File(
+		s.Name,
+		flux.Imports(mergeImports([]string{"influxdata/influxdb/monitor", "http", "json", "experimental"}, imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
+	)
+	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
+}
+
+func (s *SMTP) generateFluxASTBody(e *endpoint.SMTP, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, s.generateTaskOption())
+	statements = append(statements, s.generateFluxASTEndpoint(e, ""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(""))
+
+	return statements
+}
+
+func (s *SMTP) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.SMTP)
+	statements := []ast.Statement{
+		s.generateFluxASTEndpoint(esc, suffix),
+		s.generateFluxASTNotifyPipe(suffix),
+	}
+	return []string{"http", "json"}, statements
+}
+
+// generateFluxASTEndpoint defines the endpoint the mails are posted to. Flux
+// can not send mails, influxd relays the posts to the relay URL of the
+// endpoint to its mail server.
+func (s *SMTP) generateFluxASTEndpoint(e *endpoint.SMTP, suffix string) ast.Statement {
+	call := flux.Call(flux.Member("http", "endpoint"), flux.Object(flux.Property("url", flux.String(e.RelayURL()))))
+
+	return flux.DefineVariable("smtp_endpoint"+suffix, call)
+}
+
+func (s *SMTP) generateFluxASTNotifyPipe(suffix string) ast.Statement {
+	mailProps := []*ast.Property{}
+	mailProps = append(mailProps, flux.Property("to", flux.String(s.To)))
+	mailProps = append(mailProps, flux.Property("subject", flux.String(s.SubjectTemplate)))
+	mailProps = append(mailProps, flux.Property("body", flux.String(s.BodyTemplate)))
+
+	endpointProps := []*ast.Property{
+		flux.Property("headers", flux.Object(flux.Dictionary("Content-Type", flux.String("application/json")))),
+		flux.Property("data", flux.Call(flux.Member("json", "encode"), flux.Object(flux.Property("v", flux.Object(mailProps...))))),
+	}
+	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
+
+	props := []*ast.Property{}
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
+	props = append(props, flux.Property("endpoint",
+		flux.Call(flux.Identifier("smtp_endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
+
+	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
+
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
+}
+
+type smtpAlias SMTP
+
+// MarshalJSON implement json.Marshaler interface.
+func (s SMTP) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			smtpAlias
+			Type string `json:"type"`
+		}{
+			smtpAlias: smtpAlias(s),
+			Type:      s.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (s SMTP) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.SubjectTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "smtp subject template is empty",
+		}
+	}
+	if s.To == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "smtp to is empty",
+		}
+	}
+	if _, err := mail.ParseAddressList(s.To); err != nil {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("smtp to is invalid: %s", err.Error()),
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (s SMTP) Type() string {
+	return "smtp"
+}
@@ -0,0 +1,74 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+)
+
+func TestSMTP_GenerateFlux(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "http"
+import "json"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+smtp_endpoint = http["endpoint"](url: "http://smtp.influxdb.invalid/0000000000000002")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: smtp_endpoint(mapFn: (r) =>
+		({headers: {"Content-Type": "application/json"}, data: json["encode"](v: {to: "oncall@example.com", subject: "crit", body: "blah"})})))`
+
+	s := &rule.SMTP{
+		SubjectTemplate: "crit",
+		BodyTemplate:    "blah",
+		To:              "oncall@example.com",
+		Base: rule.Base{
+			ID:         1,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			EndpointID: 2,
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+
+	e := &endpoint.SMTP{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		Host: "smtp.example.com:587",
+		From: "influxdb@example.com",
+	}
+
+	f, err := s.GenerateFlux(e, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
@@ -0,0 +1,158 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/flux"
+)
+
+// Teams is the notification rule config of microsoft teams.
+type Teams struct {
+	Base
+	Title           string `json:"title,omitempty"`
+	MessageTemplate string `json:"messageTemplate"`
+}
+
+// GenerateFlux generates a flux script for the teams notification rule.
+func (s *Teams) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
+	teamsEndpoint, ok := e.(*endpoint.Teams)
+	if !ok {
+		return "", fmt.Errorf("endpoint provided is a %s, not a Teams endpoint", e.Type())
+	}
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(teamsEndpoint, escalations, silences, acks)
+	if err != nil {
+		return "", err
+	}
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST generates a flux AST for the teams notification rule.
+func (s *Teams) GenerateFluxAST(e *endpoint.Teams, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, s.MessageTemplate, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
+	f := flux.File(
+		s.Name,
+		flux.Imports(mergeImports([]string{"influxdata/influxdb/monitor", "http", "json", "influxdata/influxdb/secrets", "experimental"}, imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
+	)
+	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
+}
+
+func (s *Teams) generateFluxASTBody(e *endpoint.Teams, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, s.generateTaskOption())
+	statements = append(statements, s.generateFluxASTSecrets(e, ""))
+	statements = append(statements, s.generateFluxASTEndpoint(""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(""))
+
+	return statements
+}
+
+func (s *Teams) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.Teams)
+	statements := []ast.Statement{
+		s.generateFluxASTSecrets(esc, suffix),
+		s.generateFluxASTEndpoint(suffix),
+		s.generateFluxASTNotifyPipe(suffix),
+	}
+	return []string{"http", "json", "influxdata/influxdb/secrets"}, statements
+}
+
+func (s *Teams) generateFluxASTSecrets(e *endpoint.Teams, suffix string) ast.Statement {
+	call := flux.Call(flux.Member("secrets", "get"), flux.Object(flux.Property("key", flux.String(e.URL.Key))))
+
+	return flux.DefineVariable("teams_url"+suffix, call)
+}
+
+func (s *Teams) generateFluxASTEndpoint(suffix string) ast.Statement {
+	call := flux.Call(flux.Member("http", "endpoint"), flux.Object(flux.Property("url", flux.Identifier("teams_url"+suffix))))
+
+	return flux.DefineVariable("teams_endpoint"+suffix, call)
+}
+
+func (s *Teams) generateFluxASTNotifyPipe(suffix string) ast.Statement {
+	// The body is a legacy actionable message card, the format accepted by
+	// incoming webhooks of teams channels.
+	cardProps := []*ast.Property{
+		flux.Dictionary("@type", flux.String("MessageCard")),
+		flux.Dictionary("@context", flux.String("https://schema.org/extensions")),
+	}
+	if s.Title != "" {
+		cardProps = append(cardProps, flux.Property("title", flux.String(s.Title)))
+	}
+	cardProps = append(cardProps, flux.Property("text", flux.String(s.MessageTemplate)))
+	cardProps = append(cardProps, flux.Property("themeColor", s.generateTeamsColors()))
+
+	endpointProps := []*ast.Property{
+		flux.Property("headers", flux.Object(flux.Dictionary("Content-Type", flux.String("application/json")))),
+		flux.Property("data", flux.Call(flux.Member("json", "encode"), flux.Object(flux.Property("v", flux.Object(cardProps...))))),
+	}
+	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
+
+	props := []*ast.Property{}
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
+	props = append(props, flux.Property("endpoint",
+		flux.Call(flux.Identifier("teams_endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
+
+	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
+
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
+}
+
+func (s *Teams) generateTeamsColors() ast.Expression {
+	level := flux.Member("r", "_level")
+	return flux.If(
+		flux.Equal(level, flux.String("crit")),
+		flux.String("D0021B"),
+		flux.If(
+			flux.Equal(level, flux.String("warn")),
+			flux.String("F5A623"),
+			flux.String("7ED321"),
+		),
+	)
+}
+
+type teamsAlias Teams
+
+// MarshalJSON implement json.Marshaler interface.
+func (s Teams) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			teamsAlias
+			Type string `json:"type"`
+		}{
+			teamsAlias: teamsAlias(s),
+			Type:       s.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (s Teams) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "teams msg template is empty",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (s Teams) Type() string {
+	return "teams"
+}
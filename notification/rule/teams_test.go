@@ -0,0 +1,83 @@
+package rule_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+)
+
+func TestTeams_GenerateFlux(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "http"
+import "json"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+teams_url = secrets["get"](key: "teams-url")
+teams_endpoint = http["endpoint"](url: teams_url)
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: teams_endpoint(mapFn: (r) =>
+		({headers: {"Content-Type": "application/json"}, data: json["encode"](v: {
+			"@type": "MessageCard",
+			"@context": "https://schema.org/extensions",
+			title: "bar",
+			text: "blah",
+			themeColor: if r["_level"] == "crit" then "D0021B" else if r["_level"] == "warn" then "F5A623" else "7ED321",
+		})})))`
+
+	s := &rule.Teams{
+		Title:           "bar",
+		MessageTemplate: "blah",
+		Base: rule.Base{
+			ID:         1,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			EndpointID: 2,
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+
+	e := &endpoint.Teams{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		URL: influxdb.SecretField{
+			Key: "teams-url",
+		},
+	}
+
+	f, err := s.GenerateFlux(e, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
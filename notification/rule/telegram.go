@@ -0,0 +1,166 @@
+package rule
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/flux"
+)
+
+var goodTelegramParseMode = map[string]bool{
+	"":           true,
+	"MarkdownV2": true,
+	"HTML":       true,
+	"Markdown":   true,
+}
+
+// Telegram is the notification rule config of telegram.
+type Telegram struct {
+	Base
+	// Channel is the ID of the chat, or the @username of the channel.
+	Channel         string `json:"channel"`
+	MessageTemplate string `json:"messageTemplate"`
+	// ParseMode is the formatting of the message, one of MarkdownV2, HTML or Markdown.
+	ParseMode             string `json:"parseMode,omitempty"`
+	DisableWebPagePreview bool   `json:"disableWebPagePreview,omitempty"`
+}
+
+// GenerateFlux generates a flux script for the telegram notification rule.
+func (s *Telegram) GenerateFlux(e influxdb.NotificationEndpoint, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (string, error) {
+	telegramEndpoint, ok := e.(*endpoint.Telegram)
+	if !ok {
+		return "", fmt.Errorf("endpoint provided is a %s, not a Telegram endpoint", e.Type())
+	}
+	if err := s.checkEscalationEndpoints(escalations); err != nil {
+		return "", err
+	}
+	p, err := s.GenerateFluxAST(telegramEndpoint, escalations, silences, acks)
+	if err != nil {
+		return "", err
+	}
+	return ast.Format(p), nil
+}
+
+// GenerateFluxAST generates a flux AST for the telegram notification rule.
+func (s *Telegram) GenerateFluxAST(e *endpoint.Telegram, escalations []influxdb.NotificationEndpoint, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) (*ast.Package, error) {
+	imports, escalationStatements, err := s.generateFluxASTEscalations(s, s.MessageTemplate, escalations, silences, acks)
+	if err != nil {
+		return nil, err
+	}
+	f := flux.File(
+		s.Name,
+		flux.Imports(mergeImports([]string{"influxdata/influxdb/monitor", "http", "json", "influxdata/influxdb/secrets", "experimental"}, imports)...),
+		append(s.generateFluxASTBody(e, silences, acks), escalationStatements...),
+	)
+	return &ast.Package{Package: "main", Files: []*ast.File{f}}, nil
+}
+
+func (s *Telegram) generateFluxASTBody(e *endpoint.Telegram, silences []*influxdb.Silence, acks []*influxdb.AlertAcknowledgement) []ast.Statement {
+	var statements []ast.Statement
+	statements = append(statements, s.generateTaskOption())
+	statements = append(statements, s.generateFluxASTSecrets(e, ""))
+	statements = append(statements, s.generateFluxASTEndpoint(e, ""))
+	statements = append(statements, s.generateFluxASTNotificationDefinition(e, silences)...)
+	statements = append(statements, s.generateFluxASTStatuses(acks)...)
+	statements = append(statements, s.generateLevelChecks(silences)...)
+	statements = append(statements, s.generateFluxASTNotifyPipe(""))
+
+	return statements
+}
+
+func (s *Telegram) generateFluxASTEscalationNotify(e influxdb.NotificationEndpoint, suffix string) ([]string, []ast.Statement) {
+	esc := e.(*endpoint.Telegram)
+	statements := []ast.Statement{
+		s.generateFluxASTSecrets(esc, suffix),
+		s.generateFluxASTEndpoint(esc, suffix),
+		s.generateFluxASTNotifyPipe(suffix),
+	}
+	return []string{"http", "json", "influxdata/influxdb/secrets"}, statements
+}
+
+func (s *Telegram) generateFluxASTSecrets(e *endpoint.Telegram, suffix string) ast.Statement {
+	call := flux.Call(flux.Member("secrets", "get"), flux.Object(flux.Property("key", flux.String(e.Token.Key))))
+
+	return flux.DefineVariable("telegram_secret"+suffix, call)
+}
+
+func (s *Telegram) generateFluxASTEndpoint(e *endpoint.Telegram, suffix string) ast.Statement {
+	// The bot token is a part of the URL of the bot API methods.
+	url := flux.Add(flux.Add(flux.String(e.APIURL()+"/bot"), flux.Identifier("telegram_secret"+suffix)), flux.String("/sendMessage"))
+	call := flux.Call(flux.Member("http", "endpoint"), flux.Object(flux.Property("url", url)))
+
+	return flux.DefineVariable("telegram_endpoint"+suffix, call)
+}
+
+func (s *Telegram) generateFluxASTNotifyPipe(suffix string) ast.Statement {
+	messageProps := []*ast.Property{}
+	messageProps = append(messageProps, flux.Property("chat_id", flux.String(s.Channel)))
+	messageProps = append(messageProps, flux.Property("text", flux.String(s.MessageTemplate)))
+	if s.ParseMode != "" {
+		messageProps = append(messageProps, flux.Property("parse_mode", flux.String(s.ParseMode)))
+	}
+	messageProps = append(messageProps, flux.Property("disable_web_page_preview", flux.Bool(s.DisableWebPagePreview)))
+
+	endpointProps := []*ast.Property{
+		flux.Property("headers", flux.Object(flux.Dictionary("Content-Type", flux.String("application/json")))),
+		flux.Property("data", flux.Call(flux.Member("json", "encode"), flux.Object(flux.Property("v", flux.Object(messageProps...))))),
+	}
+	endpointFn := flux.Function(flux.FunctionParams("r"), flux.Object(endpointProps...))
+
+	props := []*ast.Property{}
+	props = append(props, flux.Property("data", flux.Identifier("notification"+suffix)))
+	props = append(props, flux.Property("endpoint",
+		flux.Call(flux.Identifier("telegram_endpoint"+suffix), flux.Object(flux.Property("mapFn", endpointFn)))))
+
+	call := flux.Call(flux.Member("monitor", "notify"), flux.Object(props...))
+
+	return flux.ExpressionStatement(flux.Pipe(flux.Identifier("all_statuses"+suffix), call))
+}
+
+type telegramAlias Telegram
+
+// MarshalJSON implement json.Marshaler interface.
+func (s Telegram) MarshalJSON() ([]byte, error) {
+	return json.Marshal(
+		struct {
+			telegramAlias
+			Type string `json:"type"`
+		}{
+			telegramAlias: telegramAlias(s),
+			Type:          s.Type(),
+		})
+}
+
+// Valid returns where the config is valid.
+func (s Telegram) Valid() error {
+	if err := s.Base.valid(); err != nil {
+		return err
+	}
+	if s.Channel == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "telegram channel is empty",
+		}
+	}
+	if s.MessageTemplate == "" {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "telegram msg template is empty",
+		}
+	}
+	if !goodTelegramParseMode[s.ParseMode] {
+		return &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  "invalid telegram parse mode",
+		}
+	}
+	return nil
+}
+
+// Type returns the type of the rule config.
+func (s Telegram) Type() string {
+	return "telegram"
+}
@@ -0,0 +1,124 @@
+package rule_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"github.com/influxdata/influxdb/v2/notification/rule"
+)
+
+func TestTelegram_GenerateFlux(t *testing.T) {
+	want := `package main
+// foo
+import "influxdata/influxdb/monitor"
+import "http"
+import "json"
+import "influxdata/influxdb/secrets"
+import "experimental"
+
+option task = {name: "foo", every: 1h}
+
+telegram_secret = secrets["get"](key: "telegram-token")
+telegram_endpoint = http["endpoint"](url: "https://api.telegram.org/bot" + telegram_secret + "/sendMessage")
+notification = {
+	_notification_rule_id: "0000000000000001",
+	_notification_rule_name: "foo",
+	_notification_endpoint_id: "0000000000000002",
+	_notification_endpoint_name: "foo",
+}
+statuses = monitor["from"](start: -2h)
+crit = statuses
+	|> filter(fn: (r) =>
+		(r["_level"] == "crit"))
+all_statuses = crit
+	|> filter(fn: (r) =>
+		(r["_time"] > experimental["subDuration"](from: now(), d: 1h)))
+
+all_statuses
+	|> monitor["notify"](data: notification, endpoint: telegram_endpoint(mapFn: (r) =>
+		({headers: {"Content-Type": "application/json"}, data: json["encode"](v: {
+			chat_id: "-12345",
+			text: "blah",
+			parse_mode: "HTML",
+			disable_web_page_preview: true,
+		})})))`
+
+	s := &rule.Telegram{
+		Channel:               "-12345",
+		MessageTemplate:       "blah",
+		ParseMode:             "HTML",
+		DisableWebPagePreview: true,
+		Base: rule.Base{
+			ID:         1,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			EndpointID: 2,
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+
+	e := &endpoint.Telegram{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		Token: influxdb.SecretField{
+			Key: "telegram-token",
+		},
+	}
+
+	f, err := s.GenerateFlux(e, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f != want {
+		t.Errorf("scripts did not match. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
+
+func TestTelegram_GenerateFlux_URL(t *testing.T) {
+	s := &rule.Telegram{
+		Channel:         "-12345",
+		MessageTemplate: "blah",
+		Base: rule.Base{
+			ID:         1,
+			Name:       "foo",
+			Every:      mustDuration("1h"),
+			EndpointID: 2,
+			StatusRules: []notification.StatusRule{
+				{
+					CurrentLevel: notification.Critical,
+				},
+			},
+		},
+	}
+
+	e := &endpoint.Telegram{
+		Base: endpoint.Base{
+			ID:   idPtr(2),
+			Name: "foo",
+		},
+		URL: "http://localhost:8081",
+		Token: influxdb.SecretField{
+			Key: "telegram-token",
+		},
+	}
+
+	f, err := s.GenerateFlux(e, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `telegram_endpoint = http["endpoint"](url: "http://localhost:8081/bot" + telegram_secret + "/sendMessage")`
+	if !strings.Contains(f, want) {
+		t.Errorf("script does not post to the bot API at the endpoint URL. want:\n%v\n\ngot:\n%v", want, f)
+	}
+}
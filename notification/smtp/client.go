@@ -0,0 +1,182 @@
+// Package smtp relays the mails of the notification rules of smtp endpoints
+// to the mail servers of the endpoints.
+//
+// Notification rules run as flux tasks, and flux can only notify endpoints
+// over HTTP. The rules of smtp endpoints post their mails to the relay URL of
+// the endpoint instead, and the HTTP client of flux is wrapped by a Client
+// that sends the posts to relay URLs as mails.
+package smtp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	fluxhttp "github.com/influxdata/flux/dependencies/http"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"go.uber.org/zap"
+)
+
+// maxNotificationSize is the max size of the posted notifications.
+const maxNotificationSize = 1 << 20
+
+// Notification is the mail posted by the notification rules of smtp endpoints.
+type Notification struct {
+	// To is the comma separated list of the addresses the mail is sent to.
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Client is the HTTP client of flux. It sends the posts to the relay URLs of
+// smtp endpoints as mails, and passes the other requests to the next client.
+type Client struct {
+	next        fluxhttp.Client
+	endpointSvc influxdb.NotificationEndpointService
+	secretSvc   influxdb.SecretService
+	log         *zap.Logger
+	now         func() time.Time
+	sendMail    func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewClient returns a Client passing the requests that are not posts to relay
+// URLs to next.
+func NewClient(log *zap.Logger, next fluxhttp.Client, endpointSvc influxdb.NotificationEndpointService, secretSvc influxdb.SecretService) *Client {
+	return &Client{
+		next:        next,
+		endpointSvc: endpointSvc,
+		secretSvc:   secretSvc,
+		log:         log,
+		now:         time.Now,
+		sendMail:    smtp.SendMail,
+	}
+}
+
+// Do sends the request. The response to a post to a relay URL has the status
+// of the relay and an empty body.
+func (c *Client) Do(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != endpoint.SMTPRelayHost {
+		return c.next.Do(req)
+	}
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+
+	code := c.relay(req)
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		StatusCode: code,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		Request:    req,
+	}, nil
+}
+
+// relay sends the notification posted by req to the mail server of the
+// endpoint of its URL, and returns the status of the response to the post.
+//
+// The endpoint must be readable by the authorizer of the request, so that a
+// query can only send mails through the endpoints of its authorization.
+func (c *Client) relay(req *http.Request) int {
+	if req.Method != http.MethodPost {
+		return http.StatusMethodNotAllowed
+	}
+	ctx := req.Context()
+
+	id, err := influxdb.IDFromString(strings.TrimPrefix(req.URL.Path, "/"))
+	if err != nil {
+		return http.StatusNotFound
+	}
+	e, err := c.endpointSvc.FindNotificationEndpointByID(ctx, *id)
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return http.StatusNotFound
+		}
+		c.log.Error("Failed to find smtp notification endpoint", zap.Stringer("id", id), zap.Error(err))
+		return http.StatusInternalServerError
+	}
+	se, ok := e.(*endpoint.SMTP)
+	if !ok {
+		return http.StatusNotFound
+	}
+	if _, _, err := authorizer.AuthorizeRead(ctx, influxdb.NotificationEndpointResourceType, se.GetID(), se.GetOrgID()); err != nil {
+		return http.StatusForbidden
+	}
+
+	var n Notification
+	if req.Body == nil {
+		return http.StatusBadRequest
+	}
+	if err := json.NewDecoder(io.LimitReader(req.Body, maxNotificationSize)).Decode(&n); err != nil {
+		return http.StatusBadRequest
+	}
+
+	from, err := mail.ParseAddress(se.From)
+	if err != nil {
+		return http.StatusInternalServerError
+	}
+	to, err := mail.ParseAddressList(n.To)
+	if err != nil {
+		return http.StatusBadRequest
+	}
+	rcpts := make([]string, 0, len(to))
+	for _, a := range to {
+		rcpts = append(rcpts, a.Address)
+	}
+
+	var auth smtp.Auth
+	if se.Username != "" {
+		password, err := c.secretSvc.LoadSecret(ctx, se.GetOrgID(), se.Password.Key)
+		if err != nil {
+			c.log.Error("Failed to load smtp notification endpoint password", zap.Stringer("id", id), zap.Error(err))
+			return http.StatusInternalServerError
+		}
+		host, _, _ := net.SplitHostPort(se.Host)
+		auth = smtp.PlainAuth("", se.Username, password, host)
+	}
+
+	if err := c.sendMail(se.Host, auth, from.Address, rcpts, c.message(from, to, n)); err != nil {
+		c.log.Info("Failed to send notification mail", zap.Stringer("id", id), zap.String("host", se.Host), zap.Error(err))
+		return http.StatusBadGateway
+	}
+	return http.StatusOK
+}
+
+// message returns the mail of the notification n sent from from to to.
+func (c *Client) message(from *mail.Address, to []*mail.Address, n Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	addrs := make([]string, 0, len(to))
+	for _, a := range to {
+		addrs = append(addrs, a.String())
+	}
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(addrs, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(n.Subject)))
+	fmt.Fprintf(&b, "Date: %s\r\n", c.now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("\r\n")
+	body := strings.ReplaceAll(n.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// headerValue returns v without line breaks, so that it can not add headers.
+func headerValue(v string) string {
+	return strings.Join(strings.Fields(v), " ")
+}
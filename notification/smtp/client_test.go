@@ -0,0 +1,205 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/notification/endpoint"
+	"go.uber.org/zap/zaptest"
+)
+
+type clientFunc func(*http.Request) (*http.Response, error)
+
+func (f clientFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type sentMail struct {
+	addr string
+	from string
+	to   []string
+	msg  string
+}
+
+func TestClient_Do(t *testing.T) {
+	endpointID := influxdb.ID(2)
+	orgID := influxdb.ID(3)
+	smtpEndpoint := &endpoint.SMTP{
+		Base: endpoint.Base{
+			ID:     &endpointID,
+			Name:   "mail",
+			OrgID:  &orgID,
+			Status: influxdb.Active,
+		},
+		Host:     "smtp.example.com:587",
+		Username: "influxdb",
+		Password: influxdb.SecretField{Key: "0000000000000002-password"},
+		From:     "InfluxDB <influxdb@example.com>",
+	}
+	endpointRead := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.NotificationEndpointResourceType,
+			ID:    &endpointID,
+			OrgID: &orgID,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		url      string
+		body     string
+		perms    []influxdb.Permission
+		sendErr  error
+		wantCode int
+		wantMail *sentMail
+	}{
+		{
+			name:     "sends the notification",
+			method:   http.MethodPost,
+			url:      smtpEndpoint.RelayURL(),
+			body:     `{"to":"oncall@example.com, Ops <ops@example.com>","subject":"crit\r\nBcc: evil@example.com","body":"cpu is high\nat host a"}`,
+			perms:    []influxdb.Permission{endpointRead},
+			wantCode: http.StatusOK,
+			wantMail: &sentMail{
+				addr: "smtp.example.com:587",
+				from: "influxdb@example.com",
+				to:   []string{"oncall@example.com", "ops@example.com"},
+				msg: "From: \"InfluxDB\" <influxdb@example.com>\r\n" +
+					"To: <oncall@example.com>, \"Ops\" <ops@example.com>\r\n" +
+					"Subject: crit Bcc: evil@example.com\r\n" +
+					"Date: Thu, 13 Jul 2006 04:19:10 +0000\r\n" +
+					"MIME-Version: 1.0\r\n" +
+					"Content-Type: text/plain; charset=utf-8\r\n" +
+					"\r\n" +
+					"cpu is high\r\nat host a\r\n",
+			},
+		},
+		{
+			name:     "endpoint is not readable",
+			method:   http.MethodPost,
+			url:      smtpEndpoint.RelayURL(),
+			body:     `{"to":"oncall@example.com","subject":"crit","body":"cpu is high"}`,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "endpoint is not found",
+			method:   http.MethodPost,
+			url:      "http://" + endpoint.SMTPRelayHost + "/0000000000000004",
+			body:     `{"to":"oncall@example.com","subject":"crit","body":"cpu is high"}`,
+			perms:    []influxdb.Permission{endpointRead},
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:     "invalid notification",
+			method:   http.MethodPost,
+			url:      smtpEndpoint.RelayURL(),
+			body:     `crit`,
+			perms:    []influxdb.Permission{endpointRead},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "invalid to",
+			method:   http.MethodPost,
+			url:      smtpEndpoint.RelayURL(),
+			body:     `{"to":"oncall","subject":"crit","body":"cpu is high"}`,
+			perms:    []influxdb.Permission{endpointRead},
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "mail server fails",
+			method:   http.MethodPost,
+			url:      smtpEndpoint.RelayURL(),
+			body:     `{"to":"oncall@example.com","subject":"crit","body":"cpu is high"}`,
+			perms:    []influxdb.Permission{endpointRead},
+			sendErr:  errors.New("connection refused"),
+			wantCode: http.StatusBadGateway,
+		},
+		{
+			name:     "not a post",
+			method:   http.MethodGet,
+			url:      smtpEndpoint.RelayURL(),
+			perms:    []influxdb.Permission{endpointRead},
+			wantCode: http.StatusMethodNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpointSvc := mock.NewNotificationEndpointService()
+			endpointSvc.FindNotificationEndpointByIDF = func(ctx context.Context, id influxdb.ID) (influxdb.NotificationEndpoint, error) {
+				if id != endpointID {
+					return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: "notification endpoint not found"}
+				}
+				return smtpEndpoint, nil
+			}
+			secretSvc := mock.NewSecretService()
+			secretSvc.LoadSecretFn = func(ctx context.Context, orgID influxdb.ID, k string) (string, error) {
+				return "secret", nil
+			}
+
+			c := NewClient(zaptest.NewLogger(t), nil, endpointSvc, secretSvc)
+			c.now = func() time.Time {
+				return time.Date(2006, time.July, 13, 4, 19, 10, 0, time.UTC)
+			}
+			var sent *sentMail
+			c.sendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+				if a == nil {
+					t.Error("expected the mail to be sent with authentication")
+				}
+				sent = &sentMail{addr: addr, from: from, to: to, msg: string(msg)}
+				return tt.sendErr
+			}
+
+			req, err := http.NewRequest(tt.method, tt.url, strings.NewReader(tt.body))
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := icontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(false, tt.perms))
+			resp, err := c.Do(req.WithContext(ctx))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.wantCode {
+				t.Errorf("unexpected status code, got %d, want %d", resp.StatusCode, tt.wantCode)
+			}
+			if tt.wantMail != nil {
+				if diff := cmp.Diff(tt.wantMail, sent, cmp.AllowUnexported(sentMail{})); diff != "" {
+					t.Errorf("unexpected mail -want/+got:\n%s", diff)
+				}
+			} else if tt.sendErr == nil && sent != nil {
+				t.Errorf("unexpected mail sent: %v", sent)
+			}
+		})
+	}
+}
+
+func TestClient_Do_Next(t *testing.T) {
+	var called bool
+	next := clientFunc(func(req *http.Request) (*http.Response, error) {
+		called = true
+		return &http.Response{StatusCode: http.StatusAccepted}, nil
+	})
+	c := NewClient(zaptest.NewLogger(t), next, mock.NewNotificationEndpointService(), mock.NewSecretService())
+
+	req, err := http.NewRequest(http.MethodPost, "https://hooks.example.com/notify", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !called || resp.StatusCode != http.StatusAccepted {
+		t.Errorf("expected the request to be sent by the next client, got status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,104 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+)
+
+// MaxQueryWeight is the largest query weight an organization may have.
+const MaxQueryWeight = 100
+
+// ops for organization settings errors.
+const (
+	OpFindOrganizationSettings   = "FindOrganizationSettings"
+	OpUpdateOrganizationSettings = "UpdateOrganizationSettings"
+)
+
+// OrganizationSettingsService represents a service for managing the settings of organizations.
+type OrganizationSettingsService interface {
+	// FindOrganizationSettings returns the settings of the organization, the
+	// settings of an organization that has none set are all zero.
+	FindOrganizationSettings(ctx context.Context, orgID ID) (*OrganizationSettings, error)
+
+	// UpdateOrganizationSettings updates the settings of the organization with a changeset.
+	// Returns the new settings after update.
+	UpdateOrganizationSettings(ctx context.Context, orgID ID, upd OrganizationSettingsUpdate) (*OrganizationSettings, error)
+}
+
+// OrganizationSettings are the settings of an organization.
+//
+// The query settings limit the queries of the organization within the limits
+// of the query controller of each server, a zero value leaves it to the
+// controller.
+type OrganizationSettings struct {
+	OrgID ID `json:"orgID"`
+	// QueryConcurrencyQuota is the number of queries of the organization that may execute at once.
+	QueryConcurrencyQuota int `json:"queryConcurrencyQuota"`
+	// QueryQueueSize is the number of queries of the organization that may wait to be executed.
+	QueryQueueSize int `json:"queryQueueSize"`
+	// QueryMemoryBytesQuota is the memory the executing queries of the organization may use together.
+	QueryMemoryBytesQuota int64 `json:"queryMemoryBytesQuota"`
+	// QueryWeight is the share of the query concurrency the organization gets
+	// relative to the other organizations with queued queries. Zero is a weight of 1.
+	QueryWeight int `json:"queryWeight"`
+}
+
+// Valid returns an error if the settings are invalid.
+func (s *OrganizationSettings) Valid() error {
+	if !s.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "organization settings orgID is invalid",
+		}
+	}
+	if s.QueryConcurrencyQuota < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "queryConcurrencyQuota must not be negative",
+		}
+	}
+	if s.QueryQueueSize < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "queryQueueSize must not be negative",
+		}
+	}
+	if s.QueryMemoryBytesQuota < 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "queryMemoryBytesQuota must not be negative",
+		}
+	}
+	if s.QueryWeight < 0 || s.QueryWeight > MaxQueryWeight {
+		return &Error{
+			Code: EInvalid,
+			Msg:  fmt.Sprintf("queryWeight must be between 0 and %d", MaxQueryWeight),
+		}
+	}
+	return nil
+}
+
+// OrganizationSettingsUpdate is the changeset of the settings of an organization.
+// Nil fields are left unchanged.
+type OrganizationSettingsUpdate struct {
+	QueryConcurrencyQuota *int   `json:"queryConcurrencyQuota,omitempty"`
+	QueryQueueSize        *int   `json:"queryQueueSize,omitempty"`
+	QueryMemoryBytesQuota *int64 `json:"queryMemoryBytesQuota,omitempty"`
+	QueryWeight           *int   `json:"queryWeight,omitempty"`
+}
+
+// Apply applies the changeset to the settings.
+func (u OrganizationSettingsUpdate) Apply(s *OrganizationSettings) {
+	if u.QueryConcurrencyQuota != nil {
+		s.QueryConcurrencyQuota = *u.QueryConcurrencyQuota
+	}
+	if u.QueryQueueSize != nil {
+		s.QueryQueueSize = *u.QueryQueueSize
+	}
+	if u.QueryMemoryBytesQuota != nil {
+		s.QueryMemoryBytesQuota = *u.QueryMemoryBytesQuota
+	}
+	if u.QueryWeight != nil {
+		s.QueryWeight = *u.QueryWeight
+	}
+}
@@ -13,3 +13,12 @@ type PasswordsService interface {
 	// updates to the new password.
 	CompareAndSetPassword(ctx context.Context, userID ID, old, new string) error
 }
+
+// UserProvisioner is implemented by PasswordsServices that authenticate users
+// against an external directory, to create the users of the directory when
+// they sign in for the first time.
+type UserProvisioner interface {
+	// ProvisionUser authenticates the user with the name and password
+	// against the directory and creates it.
+	ProvisionUser(ctx context.Context, name, password string) (*User, error)
+}
@@ -46,17 +46,23 @@ var kindPriorities = map[Kind]int{
 	KindLabel:                         1,
 	KindBucket:                        2,
 	KindCheck:                         3,
-	KindCheckDeadman:                  4,
-	KindCheckThreshold:                5,
-	KindNotificationEndpoint:          6,
-	KindNotificationEndpointHTTP:      7,
-	KindNotificationEndpointPagerDuty: 8,
-	KindNotificationEndpointSlack:     9,
-	KindNotificationRule:              10,
-	KindTask:                          11,
-	KindVariable:                      12,
-	KindDashboard:                     13,
-	KindTelegraf:                      14,
+	KindCheckAnomaly:                  4,
+	KindCheckDeadman:                  5,
+	KindCheckThreshold:                6,
+	KindNotificationEndpoint:          7,
+	KindNotificationEndpointHTTP:      8,
+	KindNotificationEndpointOpsGenie:  9,
+	KindNotificationEndpointPagerDuty: 10,
+	KindNotificationEndpointSlack:     11,
+	KindNotificationEndpointSMTP:      12,
+	KindNotificationEndpointTeams:     13,
+	KindNotificationEndpointTelegram:  14,
+	KindNotificationRule:              15,
+	KindRole:                          16,
+	KindTask:                          17,
+	KindVariable:                      18,
+	KindDashboard:                     19,
+	KindTelegraf:                      20,
 }
 
 type exportKey struct {
@@ -83,6 +89,7 @@ type resourceExporter struct {
 	dashSVC     influxdb.DashboardService
 	labelSVC    influxdb.LabelService
 	endpointSVC influxdb.NotificationEndpointService
+	roleSVC     influxdb.RoleService
 	ruleSVC     influxdb.NotificationRuleStore
 	taskSVC     influxdb.TaskService
 	teleSVC     influxdb.TelegrafConfigStore
@@ -100,6 +107,7 @@ func newResourceExporter(svc *Service) *resourceExporter {
 		dashSVC:     svc.dashSVC,
 		labelSVC:    svc.labelSVC,
 		endpointSVC: svc.endpointSVC,
+		roleSVC:     svc.roleSVC,
 		ruleSVC:     svc.ruleSVC,
 		taskSVC:     svc.taskSVC,
 		teleSVC:     svc.teleSVC,
@@ -195,6 +203,7 @@ func (ex *resourceExporter) resourceCloneToKind(ctx context.Context, r ResourceT
 		}
 		mapResource(bkt.OrgID, uniqByNameResID, KindBucket, BucketToObject(r.Name, *bkt))
 	case r.Kind.is(KindCheck),
+		r.Kind.is(KindCheckAnomaly),
 		r.Kind.is(KindCheckDeadman),
 		r.Kind.is(KindCheckThreshold):
 		ch, err := ex.checkSVC.FindCheckByID(ctx, r.ID)
@@ -216,8 +225,12 @@ func (ex *resourceExporter) resourceCloneToKind(ctx context.Context, r ResourceT
 		mapResource(l.OrgID, uniqByNameResID, KindLabel, LabelToObject(r.Name, *l))
 	case r.Kind.is(KindNotificationEndpoint),
 		r.Kind.is(KindNotificationEndpointHTTP),
+		r.Kind.is(KindNotificationEndpointOpsGenie),
 		r.Kind.is(KindNotificationEndpointPagerDuty),
-		r.Kind.is(KindNotificationEndpointSlack):
+		r.Kind.is(KindNotificationEndpointSlack),
+		r.Kind.is(KindNotificationEndpointSMTP),
+		r.Kind.is(KindNotificationEndpointTeams),
+		r.Kind.is(KindNotificationEndpointTelegram):
 		e, err := ex.endpointSVC.FindNotificationEndpointByID(ctx, r.ID)
 		if err != nil {
 			return err
@@ -229,15 +242,32 @@ func (ex *resourceExporter) resourceCloneToKind(ctx context.Context, r ResourceT
 			return err
 		}
 
-		endpointKey := newExportKey(ruleEndpoint.GetOrgID(), uniqByNameResID, KindNotificationEndpoint, ruleEndpoint.GetName())
-		object, ok := ex.mObjects[endpointKey]
-		if !ok {
-			mapResource(ruleEndpoint.GetOrgID(), uniqByNameResID, KindNotificationEndpoint, NotificationEndpointToObject("", ruleEndpoint))
-			object = ex.mObjects[endpointKey]
+		endpointObjectName := func(e influxdb.NotificationEndpoint) string {
+			endpointKey := newExportKey(e.GetOrgID(), uniqByNameResID, KindNotificationEndpoint, e.GetName())
+			object, ok := ex.mObjects[endpointKey]
+			if !ok {
+				mapResource(e.GetOrgID(), uniqByNameResID, KindNotificationEndpoint, NotificationEndpointToObject("", e))
+				object = ex.mObjects[endpointKey]
+			}
+			return object.Name()
+		}
+
+		var escalationObjectNames []string
+		for _, id := range rule.GetEscalationEndpointIDs() {
+			e, err := ex.endpointSVC.FindNotificationEndpointByID(ctx, id)
+			if err != nil {
+				return err
+			}
+			escalationObjectNames = append(escalationObjectNames, endpointObjectName(e))
 		}
-		endpointObjectName := object.Name()
 
-		mapResource(rule.GetOrgID(), rule.GetID(), KindNotificationRule, NotificationRuleToObject(r.Name, endpointObjectName, rule))
+		mapResource(rule.GetOrgID(), rule.GetID(), KindNotificationRule, NotificationRuleToObject(r.Name, endpointObjectName(ruleEndpoint), escalationObjectNames, rule))
+	case r.Kind.is(KindRole):
+		role, err := ex.roleSVC.FindRoleByID(ctx, r.ID)
+		if err != nil {
+			return err
+		}
+		mapResource(role.OrgID, uniqByNameResID, KindRole, RoleToObject(r.Name, *role))
 	case r.Kind.is(KindTask):
 		t, err := ex.taskSVC.FindTaskByID(ctx, r.ID)
 		if err != nil {
@@ -415,6 +445,9 @@ func BucketToObject(name string, bkt influxdb.Bucket) Object {
 	if bkt.RetentionPeriod != 0 {
 		o.Spec[fieldBucketRetentionRules] = retentionRules{newRetentionRule(bkt.RetentionPeriod)}
 	}
+	if len(bkt.DownsampleRules) > 0 {
+		o.Spec[fieldBucketDownsampleRules] = newDownsampleRules(bkt.DownsampleRules)
+	}
 	return o
 }
 
@@ -469,6 +502,23 @@ func CheckToObject(name string, ch influxdb.Check) Object {
 			thresholds = append(thresholds, convertThreshold(th))
 		}
 		o.Spec[fieldCheckThresholds] = thresholds
+	case *icheck.Anomaly:
+		o.Kind = KindCheckAnomaly
+		assignBase(cT.Base)
+		o.Spec[fieldCheckMethod] = cT.Method
+		assignNonZeroFluxDurs(o.Spec, map[string]*notification.Duration{
+			fieldCheckWindow: cT.Window,
+			fieldCheckSeason: cT.Season,
+		})
+		assignNonZeroInts(o.Spec, map[string]int{fieldCheckSeasons: cT.Seasons})
+		var levels []Resource
+		for _, l := range cT.Levels {
+			levels = append(levels, Resource{
+				fieldLevel:            l.Level.String(),
+				fieldCheckSensitivity: l.Sensitivity,
+			})
+		}
+		o.Spec[fieldCheckLevels] = levels
 	}
 	return o
 }
@@ -835,13 +885,40 @@ func NotificationEndpointToObject(name string, e influxdb.NotificationEndpoint)
 		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
 			fieldNotificationEndpointToken: actual.Token,
 		})
+	case *endpoint.OpsGenie:
+		o.Kind = KindNotificationEndpointOpsGenie
+		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationEndpointURL: actual.URL})
+		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
+			fieldNotificationEndpointAPIKey: actual.APIKey,
+		})
+	case *endpoint.Teams:
+		o.Kind = KindNotificationEndpointTeams
+		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
+			fieldNotificationEndpointURL: actual.URL,
+		})
+	case *endpoint.Telegram:
+		o.Kind = KindNotificationEndpointTelegram
+		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationEndpointURL: actual.URL})
+		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
+			fieldNotificationEndpointToken: actual.Token,
+		})
+	case *endpoint.SMTP:
+		o.Kind = KindNotificationEndpointSMTP
+		o.Spec[fieldNotificationEndpointHost] = actual.Host
+		o.Spec[fieldNotificationEndpointFrom] = actual.From
+		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationEndpointUsername: actual.Username})
+		assignNonZeroSecrets(o.Spec, map[string]influxdb.SecretField{
+			fieldNotificationEndpointPassword: actual.Password,
+		})
 	}
 
 	return o
 }
 
 // NotificationRuleToObject converts an notification rule into a pkger Object.
-func NotificationRuleToObject(name, endpointPkgName string, iRule influxdb.NotificationRule) Object {
+// The escalationPkgNames are the names of the endpoints of its escalation
+// steps, in order.
+func NotificationRuleToObject(name, endpointPkgName string, escalationPkgNames []string, iRule influxdb.NotificationRule) Object {
 	if name == "" {
 		name = iRule.GetName()
 	}
@@ -883,6 +960,21 @@ func NotificationRuleToObject(name, endpointPkgName string, iRule influxdb.Notif
 		if len(statusRuleRes) > 0 {
 			o.Spec[fieldNotificationRuleStatusRules] = statusRuleRes
 		}
+
+		var escalationRes []Resource
+		for i, step := range base.Escalations {
+			eRes := Resource{
+				fieldNotificationRuleEndpointName: escalationPkgNames[i],
+			}
+			assignNonZeroFluxDurs(eRes, map[string]*notification.Duration{
+				fieldNotificationRuleDelay:       step.Delay,
+				fieldNotificationRuleRepeatEvery: step.RepeatEvery,
+			})
+			escalationRes = append(escalationRes, eRes)
+		}
+		if len(escalationRes) > 0 {
+			o.Spec[fieldNotificationRuleEscalations] = escalationRes
+		}
 	}
 
 	switch t := iRule.(type) {
@@ -895,6 +987,31 @@ func NotificationRuleToObject(name, endpointPkgName string, iRule influxdb.Notif
 		assignBase(t.Base)
 		o.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
 		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationRuleChannel: t.Channel})
+	case *rule.OpsGenie:
+		assignBase(t.Base)
+		o.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
+		if len(t.Tags) > 0 {
+			o.Spec[fieldNotificationRuleTags] = t.Tags
+		}
+	case *rule.Teams:
+		assignBase(t.Base)
+		o.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
+		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationRuleTitle: t.Title})
+	case *rule.Telegram:
+		assignBase(t.Base)
+		o.Spec[fieldNotificationRuleMessageTemplate] = t.MessageTemplate
+		assignNonZeroStrings(o.Spec, map[string]string{
+			fieldNotificationRuleChannel:   t.Channel,
+			fieldNotificationRuleParseMode: t.ParseMode,
+		})
+		assignNonZeroBools(o.Spec, map[string]bool{
+			fieldNotificationRuleDisableWebPagePreview: t.DisableWebPagePreview,
+		})
+	case *rule.SMTP:
+		assignBase(t.Base)
+		o.Spec[fieldNotificationRuleSubjectTemplate] = t.SubjectTemplate
+		o.Spec[fieldNotificationRuleTo] = t.To
+		assignNonZeroStrings(o.Spec, map[string]string{fieldNotificationRuleMessageTemplate: t.BodyTemplate})
 	}
 
 	return o
@@ -903,6 +1020,20 @@ func NotificationRuleToObject(name, endpointPkgName string, iRule influxdb.Notif
 // regex used to rip out the hard coded task option stuffs
 var taskFluxRegex = regexp.MustCompile(`option task = {(.|\n)*?}`)
 
+// RoleToObject converts an influxdb.Role into a pkger.Object.
+func RoleToObject(name string, r influxdb.Role) Object {
+	if name == "" {
+		name = r.Name
+	}
+
+	o := newObject(KindRole, name)
+	assignNonZeroStrings(o.Spec, map[string]string{fieldDescription: r.Description})
+	if len(r.Permissions) > 0 {
+		o.Spec[fieldRolePermissions] = newRolePermissions(r.Permissions)
+	}
+	return o
+}
+
 // TaskToObject converts an influxdb.Task into a pkger.Object.
 func TaskToObject(name string, t influxdb.Task) Object {
 	if name == "" {
@@ -16,16 +16,22 @@ const (
 	KindUnknown                       Kind = ""
 	KindBucket                        Kind = "Bucket"
 	KindCheck                         Kind = "Check"
+	KindCheckAnomaly                  Kind = "CheckAnomaly"
 	KindCheckDeadman                  Kind = "CheckDeadman"
 	KindCheckThreshold                Kind = "CheckThreshold"
 	KindDashboard                     Kind = "Dashboard"
 	KindLabel                         Kind = "Label"
 	KindNotificationEndpoint          Kind = "NotificationEndpoint"
 	KindNotificationEndpointHTTP      Kind = "NotificationEndpointHTTP"
+	KindNotificationEndpointOpsGenie  Kind = "NotificationEndpointOpsGenie"
 	KindNotificationEndpointPagerDuty Kind = "NotificationEndpointPagerDuty"
 	KindNotificationEndpointSlack     Kind = "NotificationEndpointSlack"
+	KindNotificationEndpointSMTP      Kind = "NotificationEndpointSMTP"
+	KindNotificationEndpointTeams     Kind = "NotificationEndpointTeams"
+	KindNotificationEndpointTelegram  Kind = "NotificationEndpointTelegram"
 	KindNotificationRule              Kind = "NotificationRule"
 	KindPackage                       Kind = "Package"
+	KindRole                          Kind = "Role"
 	KindTask                          Kind = "Task"
 	KindTelegraf                      Kind = "Telegraf"
 	KindVariable                      Kind = "Variable"
@@ -34,15 +40,21 @@ const (
 var kinds = map[Kind]bool{
 	KindBucket:                        true,
 	KindCheck:                         true,
+	KindCheckAnomaly:                  true,
 	KindCheckDeadman:                  true,
 	KindCheckThreshold:                true,
 	KindDashboard:                     true,
 	KindLabel:                         true,
 	KindNotificationEndpoint:          true,
 	KindNotificationEndpointHTTP:      true,
+	KindNotificationEndpointOpsGenie:  true,
 	KindNotificationEndpointPagerDuty: true,
 	KindNotificationEndpointSlack:     true,
+	KindNotificationEndpointSMTP:      true,
+	KindNotificationEndpointTeams:     true,
+	KindNotificationEndpointTelegram:  true,
 	KindNotificationRule:              true,
+	KindRole:                          true,
 	KindTask:                          true,
 	KindTelegraf:                      true,
 	KindVariable:                      true,
@@ -78,7 +90,7 @@ func (k Kind) ResourceType() influxdb.ResourceType {
 	switch k {
 	case KindBucket:
 		return influxdb.BucketsResourceType
-	case KindCheck, KindCheckDeadman, KindCheckThreshold:
+	case KindCheck, KindCheckAnomaly, KindCheckDeadman, KindCheckThreshold:
 		return influxdb.ChecksResourceType
 	case KindDashboard:
 		return influxdb.DashboardsResourceType
@@ -86,11 +98,17 @@ func (k Kind) ResourceType() influxdb.ResourceType {
 		return influxdb.LabelsResourceType
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
+		KindNotificationEndpointOpsGenie,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointSMTP,
+		KindNotificationEndpointTeams,
+		KindNotificationEndpointTelegram:
 		return influxdb.NotificationEndpointResourceType
 	case KindNotificationRule:
 		return influxdb.NotificationRuleResourceType
+	case KindRole:
+		return influxdb.RolesResourceType
 	case KindTask:
 		return influxdb.TasksResourceType
 	case KindTelegraf:
@@ -151,6 +169,7 @@ type Diff struct {
 	LabelMappings         []DiffLabelMapping         `json:"labelMappings"`
 	NotificationEndpoints []DiffNotificationEndpoint `json:"notificationEndpoints"`
 	NotificationRules     []DiffNotificationRule     `json:"notificationRules"`
+	Roles                 []DiffRole                 `json:"roles"`
 	Tasks                 []DiffTask                 `json:"tasks"`
 	Telegrafs             []DiffTelegraf             `json:"telegrafConfigs"`
 	Variables             []DiffVariable             `json:"variables"`
@@ -171,6 +190,12 @@ func (d Diff) HasConflicts() bool {
 		}
 	}
 
+	for _, r := range d.Roles {
+		if r.hasConflict() {
+			return true
+		}
+	}
+
 	for _, v := range d.Variables {
 		if v.hasConflict() {
 			return true
@@ -191,9 +216,10 @@ type (
 
 	// DiffBucketValues are the varying values for a bucket.
 	DiffBucketValues struct {
-		Name           string         `json:"name"`
-		Description    string         `json:"description"`
-		RetentionRules retentionRules `json:"retentionRules"`
+		Name            string          `json:"name"`
+		Description     string          `json:"description"`
+		RetentionRules  retentionRules  `json:"retentionRules"`
+		DownsampleRules downsampleRules `json:"downsampleRules,omitempty"`
 	}
 )
 
@@ -356,9 +382,32 @@ type (
 		MessageTemplate string              `json:"messageTemplate"`
 		StatusRules     []SummaryStatusRule `json:"statusRules"`
 		TagRules        []SummaryTagRule    `json:"tagRules"`
+
+		Escalations []SummaryEscalationStep `json:"escalations,omitempty"`
+	}
+)
+
+type (
+	// DiffRole is a diff of an individual role.
+	DiffRole struct {
+		DiffIdentifier
+
+		New DiffRoleValues  `json:"new"`
+		Old *DiffRoleValues `json:"old"`
+	}
+
+	// DiffRoleValues are the varying values for a role.
+	DiffRoleValues struct {
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		Permissions rolePermissions `json:"permissions"`
 	}
 )
 
+func (d DiffRole) hasConflict() bool {
+	return !d.IsNew() && d.Old != nil && !reflect.DeepEqual(*d.Old, d.New)
+}
+
 type (
 	// DiffTask is a diff of an individual task.
 	DiffTask struct {
@@ -421,6 +470,7 @@ type Summary struct {
 	LabelMappings         []SummaryLabelMapping         `json:"labelMappings"`
 	MissingEnvs           []string                      `json:"missingEnvRefs"`
 	MissingSecrets        []string                      `json:"missingSecrets"`
+	Roles                 []SummaryRole                 `json:"roles"`
 	Tasks                 []SummaryTask                 `json:"summaryTask"`
 	TelegrafConfigs       []SummaryTelegraf             `json:"telegrafConfigs"`
 	Variables             []SummaryVariable             `json:"variables"`
@@ -434,8 +484,9 @@ type SummaryBucket struct {
 	PkgName     string `json:"pkgName"`
 	Description string `json:"description"`
 	// TODO: return retention rules?
-	RetentionPeriod   time.Duration  `json:"retentionPeriod"`
-	LabelAssociations []SummaryLabel `json:"labelAssociations"`
+	RetentionPeriod   time.Duration   `json:"retentionPeriod"`
+	DownsampleRules   downsampleRules `json:"downsampleRules,omitempty"`
+	LabelAssociations []SummaryLabel  `json:"labelAssociations"`
 }
 
 // SummaryCheck provides a summary of a pkg check.
@@ -572,6 +623,16 @@ type (
 		Status            influxdb.Status     `json:"status"`
 		StatusRules       []SummaryStatusRule `json:"statusRules"`
 		TagRules          []SummaryTagRule    `json:"tagRules"`
+
+		Escalations []SummaryEscalationStep `json:"escalations,omitempty"`
+	}
+
+	// SummaryEscalationStep is an escalation step of a notification rule.
+	SummaryEscalationStep struct {
+		EndpointID      SafeID `json:"endpointID"`
+		EndpointPkgName string `json:"endpointPkgName"`
+		Delay           string `json:"delay"`
+		RepeatEvery     string `json:"repeatEvery"`
 	}
 
 	SummaryStatusRule struct {
@@ -611,6 +672,16 @@ type SummaryLabelMapping struct {
 	LabelID         SafeID                `json:"labelID"`
 }
 
+// SummaryRole provides a summary of a pkg role.
+type SummaryRole struct {
+	ID          SafeID          `json:"id,omitempty"`
+	OrgID       SafeID          `json:"orgID,omitempty"`
+	PkgName     string          `json:"pkgName"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Permissions rolePermissions `json:"permissions"`
+}
+
 // SummaryTask provides a summary of a task.
 type SummaryTask struct {
 	ID          SafeID          `json:"id"`
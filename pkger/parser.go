@@ -281,6 +281,7 @@ type Pkg struct {
 	mDashboards            map[string]*dashboard
 	mNotificationEndpoints map[string]*notificationEndpoint
 	mNotificationRules     map[string]*notificationRule
+	mRoles                 map[string]*role
 	mTasks                 map[string]*task
 	mTelegrafs             map[string]*telegraf
 	mVariables             map[string]*variable
@@ -338,6 +339,7 @@ func (p *Pkg) Summary() Summary {
 		Labels:                []SummaryLabel{},
 		MissingEnvs:           p.missingEnvRefs(),
 		MissingSecrets:        p.missingSecrets(),
+		Roles:                 []SummaryRole{},
 		Tasks:                 []SummaryTask{},
 		TelegrafConfigs:       []SummaryTelegraf{},
 		Variables:             []SummaryVariable{},
@@ -378,6 +380,10 @@ func (p *Pkg) Summary() Summary {
 		sum.NotificationRules = append(sum.NotificationRules, r.summarize())
 	}
 
+	for _, r := range p.roles() {
+		sum.Roles = append(sum.Roles, r.summarize())
+	}
+
 	for _, t := range p.tasks() {
 		sum.Tasks = append(sum.Tasks, t.summarize())
 	}
@@ -425,7 +431,7 @@ func (p *Pkg) Contains(k Kind, pkgName string) bool {
 	case KindBucket:
 		_, ok := p.mBuckets[pkgName]
 		return ok
-	case KindCheck, KindCheckDeadman, KindCheckThreshold:
+	case KindCheck, KindCheckAnomaly, KindCheckDeadman, KindCheckThreshold:
 		_, ok := p.mChecks[pkgName]
 		return ok
 	case KindLabel:
@@ -433,13 +439,20 @@ func (p *Pkg) Contains(k Kind, pkgName string) bool {
 		return ok
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
+		KindNotificationEndpointOpsGenie,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointSMTP,
+		KindNotificationEndpointTeams,
+		KindNotificationEndpointTelegram:
 		_, ok := p.mNotificationEndpoints[pkgName]
 		return ok
 	case KindNotificationRule:
 		_, ok := p.mNotificationRules[pkgName]
 		return ok
+	case KindRole:
+		_, ok := p.mRoles[pkgName]
+		return ok
 	case KindTask:
 		_, ok := p.mTasks[pkgName]
 		return ok
@@ -590,6 +603,15 @@ func (p *Pkg) notificationRules() []*notificationRule {
 	return rules
 }
 
+func (p *Pkg) roles() []*role {
+	roles := make([]*role, 0, len(p.mRoles))
+	for _, r := range p.mRoles {
+		roles = append(roles, r)
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].PkgName() < roles[j].PkgName() })
+	return roles
+}
+
 func (p *Pkg) missingEnvRefs() []string {
 	envRefs := make([]string, 0)
 	for envRef, matching := range p.mEnv {
@@ -708,6 +730,7 @@ func (p *Pkg) graphResources() error {
 		p.graphDashboards,
 		p.graphNotificationEndpoints,
 		p.graphNotificationRules,
+		p.graphRoles,
 		p.graphTasks,
 		p.graphTelegrafs,
 	}
@@ -753,6 +776,18 @@ func (p *Pkg) graphBuckets() *parseErr {
 				})
 			}
 		}
+		if rules, ok := o.Spec[fieldBucketDownsampleRules].(downsampleRules); ok {
+			bkt.DownsampleRules = rules
+		} else {
+			for _, r := range o.Spec.slcResource(fieldBucketDownsampleRules) {
+				bkt.DownsampleRules = append(bkt.DownsampleRules, downsampleRule{
+					AfterSeconds:      r.intShort(fieldDownsampleRulesAfterSeconds),
+					EverySeconds:      r.intShort(fieldDownsampleRulesEverySeconds),
+					Functions:         r.slcStr(fieldDownsampleRulesFunctions),
+					DestinationBucket: r.stringShort(fieldDownsampleRulesDestinationBucket),
+				})
+			}
+		}
 		p.setRefs(bkt.name, bkt.displayName)
 
 		failures := p.parseNestedLabels(o.Spec, func(l *label) error {
@@ -799,6 +834,7 @@ func (p *Pkg) graphChecks() *parseErr {
 	}{
 		{kind: KindCheckThreshold, checkKind: checkKindThreshold},
 		{kind: KindCheckDeadman, checkKind: checkKindDeadman},
+		{kind: KindCheckAnomaly, checkKind: checkKindAnomaly},
 	}
 	var pErr parseErr
 	for _, checkKind := range checkKinds {
@@ -821,6 +857,10 @@ func (p *Pkg) graphChecks() *parseErr {
 				status:        normStr(o.Spec.stringShort(fieldStatus)),
 				statusMessage: o.Spec.stringShort(fieldCheckStatusMessageTemplate),
 				timeSince:     o.Spec.durationShort(fieldCheckTimeSince),
+				method:        normStr(o.Spec.stringShort(fieldCheckMethod)),
+				window:        o.Spec.durationShort(fieldCheckWindow),
+				season:        o.Spec.durationShort(fieldCheckSeason),
+				seasons:       o.Spec.intShort(fieldCheckSeasons),
 			}
 			for _, tagRes := range o.Spec.slcResource(fieldCheckTags) {
 				ch.tags = append(ch.tags, struct{ k, v string }{
@@ -838,6 +878,12 @@ func (p *Pkg) graphChecks() *parseErr {
 					val:        th.float64Short(fieldValue),
 				})
 			}
+			for _, l := range o.Spec.slcResource(fieldCheckLevels) {
+				ch.anomalyLevels = append(ch.anomalyLevels, anomalyLevel{
+					level:       strings.TrimSpace(strings.ToUpper(l.stringShort(fieldLevel))),
+					sensitivity: l.float64Short(fieldCheckSensitivity),
+				})
+			}
 
 			failures := p.parseNestedLabels(o.Spec, func(l *label) error {
 				ch.labels = append(ch.labels, l)
@@ -923,6 +969,22 @@ func (p *Pkg) graphNotificationEndpoints() *parseErr {
 			kind:             KindNotificationEndpointSlack,
 			notificationKind: notificationKindSlack,
 		},
+		{
+			kind:             KindNotificationEndpointOpsGenie,
+			notificationKind: notificationKindOpsGenie,
+		},
+		{
+			kind:             KindNotificationEndpointTeams,
+			notificationKind: notificationKindTeams,
+		},
+		{
+			kind:             KindNotificationEndpointTelegram,
+			notificationKind: notificationKindTelegram,
+		},
+		{
+			kind:             KindNotificationEndpointSMTP,
+			notificationKind: notificationKindSMTP,
+		},
 	}
 
 	var pErr parseErr
@@ -936,11 +998,15 @@ func (p *Pkg) graphNotificationEndpoints() *parseErr {
 			endpoint := &notificationEndpoint{
 				kind:        nk.notificationKind,
 				identity:    ident,
+				apiKey:      o.Spec.references(fieldNotificationEndpointAPIKey),
 				description: o.Spec.stringShort(fieldDescription),
+				from:        o.Spec.stringShort(fieldNotificationEndpointFrom),
+				host:        o.Spec.stringShort(fieldNotificationEndpointHost),
 				method:      strings.TrimSpace(strings.ToUpper(o.Spec.stringShort(fieldNotificationEndpointHTTPMethod))),
 				httpType:    normStr(o.Spec.stringShort(fieldType)),
 				password:    o.Spec.references(fieldNotificationEndpointPassword),
 				routingKey:  o.Spec.references(fieldNotificationEndpointRoutingKey),
+				secretURL:   o.Spec.references(fieldNotificationEndpointURL),
 				status:      normStr(o.Spec.stringShort(fieldStatus)),
 				token:       o.Spec.references(fieldNotificationEndpointToken),
 				url:         o.Spec.stringShort(fieldNotificationEndpointURL),
@@ -956,11 +1022,15 @@ func (p *Pkg) graphNotificationEndpoints() *parseErr {
 			p.setRefs(
 				endpoint.name,
 				endpoint.displayName,
+				endpoint.apiKey,
 				endpoint.password,
 				endpoint.routingKey,
 				endpoint.token,
 				endpoint.username,
 			)
+			if endpoint.kind == notificationKindTeams {
+				p.setRefs(endpoint.secretURL)
+			}
 
 			p.mNotificationEndpoints[endpoint.PkgName()] = endpoint
 			return append(failures, endpoint.valid()...)
@@ -985,14 +1055,20 @@ func (p *Pkg) graphNotificationRules() *parseErr {
 		}
 
 		rule := &notificationRule{
-			identity:     ident,
-			endpointName: p.getRefWithKnownEnvs(o.Spec, fieldNotificationRuleEndpointName),
-			description:  o.Spec.stringShort(fieldDescription),
-			channel:      o.Spec.stringShort(fieldNotificationRuleChannel),
-			every:        o.Spec.durationShort(fieldEvery),
-			msgTemplate:  o.Spec.stringShort(fieldNotificationRuleMessageTemplate),
-			offset:       o.Spec.durationShort(fieldOffset),
-			status:       normStr(o.Spec.stringShort(fieldStatus)),
+			identity:       ident,
+			endpointName:   p.getRefWithKnownEnvs(o.Spec, fieldNotificationRuleEndpointName),
+			description:    o.Spec.stringShort(fieldDescription),
+			channel:        o.Spec.stringShort(fieldNotificationRuleChannel),
+			disablePreview: o.Spec.boolShort(fieldNotificationRuleDisableWebPagePreview),
+			every:          o.Spec.durationShort(fieldEvery),
+			msgTemplate:    o.Spec.stringShort(fieldNotificationRuleMessageTemplate),
+			offset:         o.Spec.durationShort(fieldOffset),
+			parseMode:      o.Spec.stringShort(fieldNotificationRuleParseMode),
+			status:         normStr(o.Spec.stringShort(fieldStatus)),
+			subject:        o.Spec.stringShort(fieldNotificationRuleSubjectTemplate),
+			tags:           o.Spec.slcStr(fieldNotificationRuleTags),
+			title:          o.Spec.stringShort(fieldNotificationRuleTitle),
+			to:             o.Spec.stringShort(fieldNotificationRuleTo),
 		}
 
 		for _, sRule := range o.Spec.slcResource(fieldNotificationRuleStatusRules) {
@@ -1012,6 +1088,17 @@ func (p *Pkg) graphNotificationRules() *parseErr {
 
 		rule.associatedEndpoint = p.mNotificationEndpoints[rule.endpointName.String()]
 
+		for _, eRule := range o.Spec.slcResource(fieldNotificationRuleEscalations) {
+			escalation := &notificationRuleEscalation{
+				endpointName: p.getRefWithKnownEnvs(eRule, fieldNotificationRuleEndpointName),
+				delay:        eRule.durationShort(fieldNotificationRuleDelay),
+				repeatEvery:  eRule.durationShort(fieldNotificationRuleRepeatEvery),
+			}
+			escalation.associatedEndpoint = p.mNotificationEndpoints[escalation.endpointName.String()]
+			rule.escalations = append(rule.escalations, escalation)
+			p.setRefs(escalation.endpointName)
+		}
+
 		failures := p.parseNestedLabels(o.Spec, func(l *label) error {
 			rule.labels = append(rule.labels, l)
 			p.mLabels[l.PkgName()].setMapping(rule, false)
@@ -1025,6 +1112,38 @@ func (p *Pkg) graphNotificationRules() *parseErr {
 	})
 }
 
+func (p *Pkg) graphRoles() *parseErr {
+	p.mRoles = make(map[string]*role)
+	tracker := p.trackNames(true)
+	return p.eachResource(KindRole, func(o Object) []validationErr {
+		ident, errs := tracker(o)
+		if len(errs) > 0 {
+			return errs
+		}
+
+		r := &role{
+			identity:    ident,
+			description: o.Spec.stringShort(fieldDescription),
+		}
+		if perms, ok := o.Spec[fieldRolePermissions].(rolePermissions); ok {
+			r.permissions = perms
+		} else {
+			for _, rp := range o.Spec.slcResource(fieldRolePermissions) {
+				r.permissions = append(r.permissions, rolePermission{
+					Action:       rp.stringShort(fieldRolePermissionAction),
+					ResourceType: rp.stringShort(fieldRolePermissionResourceType),
+					ResourceID:   rp.stringShort(fieldRolePermissionResourceID),
+				})
+			}
+		}
+
+		p.mRoles[r.PkgName()] = r
+		p.setRefs(r.name, r.displayName)
+
+		return r.valid()
+	})
+}
+
 func (p *Pkg) graphTasks() *parseErr {
 	p.mTasks = make(map[string]*task)
 	tracker := p.trackNames(false)
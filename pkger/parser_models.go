@@ -2,6 +2,8 @@ package pkger
 
 import (
 	"fmt"
+	"net"
+	"net/mail"
 	"net/url"
 	"regexp"
 	"sort"
@@ -59,7 +61,8 @@ const (
 )
 
 const (
-	fieldBucketRetentionRules = "retentionRules"
+	fieldBucketDownsampleRules = "downsampleRules"
+	fieldBucketRetentionRules  = "retentionRules"
 )
 
 const bucketNameMinLength = 2
@@ -67,9 +70,10 @@ const bucketNameMinLength = 2
 type bucket struct {
 	identity
 
-	Description    string
-	RetentionRules retentionRules
-	labels         sortedLabels
+	Description     string
+	RetentionRules  retentionRules
+	DownsampleRules downsampleRules
+	labels          sortedLabels
 }
 
 func (b *bucket) summarize() SummaryBucket {
@@ -78,6 +82,7 @@ func (b *bucket) summarize() SummaryBucket {
 		PkgName:           b.PkgName(),
 		Description:       b.Description,
 		RetentionPeriod:   b.RetentionRules.RP(),
+		DownsampleRules:   b.DownsampleRules,
 		LabelAssociations: toSummaryLabels(b.labels...),
 	}
 }
@@ -92,6 +97,7 @@ func (b *bucket) valid() []validationErr {
 		vErrs = append(vErrs, err)
 	}
 	vErrs = append(vErrs, b.RetentionRules.valid()...)
+	vErrs = append(vErrs, b.DownsampleRules.valid(b.Name(), b.RetentionRules.RP())...)
 	if len(vErrs) == 0 {
 		return nil
 	}
@@ -163,21 +169,94 @@ func (r retentionRules) valid() []validationErr {
 	return failures
 }
 
+const (
+	fieldDownsampleRulesAfterSeconds      = "afterSeconds"
+	fieldDownsampleRulesDestinationBucket = "destinationBucket"
+	fieldDownsampleRulesEverySeconds      = "everySeconds"
+	fieldDownsampleRulesFunctions         = "functions"
+)
+
+type downsampleRule struct {
+	AfterSeconds      int      `json:"afterSeconds" yaml:"afterSeconds"`
+	EverySeconds      int      `json:"everySeconds" yaml:"everySeconds"`
+	Functions         []string `json:"functions" yaml:"functions"`
+	DestinationBucket string   `json:"destinationBucket" yaml:"destinationBucket"`
+}
+
+func newDownsampleRule(r influxdb.DownsampleRule) downsampleRule {
+	return downsampleRule{
+		AfterSeconds:      int(r.After / time.Second),
+		EverySeconds:      int(r.Every / time.Second),
+		Functions:         r.Functions,
+		DestinationBucket: r.DestinationBucket,
+	}
+}
+
+func (r downsampleRule) toInfluxRule() influxdb.DownsampleRule {
+	return influxdb.DownsampleRule{
+		After:             time.Duration(r.AfterSeconds) * time.Second,
+		Every:             time.Duration(r.EverySeconds) * time.Second,
+		Functions:         r.Functions,
+		DestinationBucket: r.DestinationBucket,
+	}
+}
+
+type downsampleRules []downsampleRule
+
+func newDownsampleRules(rules []influxdb.DownsampleRule) downsampleRules {
+	var out downsampleRules
+	for _, r := range rules {
+		out = append(out, newDownsampleRule(r))
+	}
+	return out
+}
+
+func (r downsampleRules) toInfluxRules() []influxdb.DownsampleRule {
+	out := make([]influxdb.DownsampleRule, 0, len(r))
+	for _, rule := range r {
+		out = append(out, rule.toInfluxRule())
+	}
+	return out
+}
+
+func (r downsampleRules) valid(bucketName string, rp time.Duration) []validationErr {
+	bkt := influxdb.Bucket{Name: bucketName, RetentionPeriod: rp}
+	var failures []validationErr
+	for i, rule := range r {
+		bkt.DownsampleRules = []influxdb.DownsampleRule{rule.toInfluxRule()}
+		if err := bkt.ValidDownsampleRules(); err != nil {
+			failures = append(failures, validationErr{
+				Field: fieldBucketDownsampleRules,
+				Index: intPtr(i),
+				Msg:   influxdb.ErrorMessage(err),
+			})
+		}
+	}
+	return failures
+}
+
 type checkKind int
 
 const (
 	checkKindDeadman checkKind = iota + 1
 	checkKindThreshold
+	checkKindAnomaly
 )
 
 const (
 	fieldCheckAllValues             = "allValues"
+	fieldCheckLevels                = "levels"
+	fieldCheckMethod                = "method"
 	fieldCheckReportZero            = "reportZero"
+	fieldCheckSeason                = "season"
+	fieldCheckSeasons               = "seasons"
+	fieldCheckSensitivity           = "sensitivity"
 	fieldCheckStaleTime             = "staleTime"
 	fieldCheckStatusMessageTemplate = "statusMessageTemplate"
 	fieldCheckTags                  = "tags"
 	fieldCheckThresholds            = "thresholds"
 	fieldCheckTimeSince             = "timeSince"
+	fieldCheckWindow                = "window"
 )
 
 const checkNameMinLength = 1
@@ -198,6 +277,11 @@ type check struct {
 	tags          []struct{ k, v string }
 	timeSince     time.Duration
 	thresholds    []threshold
+	method        string
+	window        time.Duration
+	season        time.Duration
+	seasons       int
+	anomalyLevels []anomalyLevel
 
 	labels sortedLabels
 }
@@ -250,6 +334,20 @@ func (c *check) summarize() SummaryCheck {
 			StaleTime:  toNotificationDuration(c.staleTime),
 			TimeSince:  toNotificationDuration(c.timeSince),
 		}
+	case checkKindAnomaly:
+		anomaly := &icheck.Anomaly{
+			Base:    base,
+			Method:  c.method,
+			Seasons: c.seasons,
+			Levels:  toInfluxAnomalyLevels(c.anomalyLevels...),
+		}
+		if c.window > 0 {
+			anomaly.Window = toNotificationDuration(c.window)
+		}
+		if c.season > 0 {
+			anomaly.Season = toNotificationDuration(c.season)
+		}
+		sum.Check = anomaly
 	}
 	return sum
 }
@@ -298,6 +396,46 @@ func (c *check) valid() []validationErr {
 				vErrs = append(vErrs, fail)
 			}
 		}
+	case checkKindAnomaly:
+		switch c.method {
+		case icheck.AnomalyStdDev, icheck.AnomalyMAD:
+			if c.window <= c.every {
+				vErrs = append(vErrs, validationErr{
+					Field: fieldCheckWindow,
+					Msg:   "duration value must be provided that is > every",
+				})
+			}
+		case icheck.AnomalySeasonal:
+			if c.season <= c.every {
+				vErrs = append(vErrs, validationErr{
+					Field: fieldCheckSeason,
+					Msg:   "duration value must be provided that is > every",
+				})
+			}
+			if c.seasons < 1 {
+				vErrs = append(vErrs, validationErr{
+					Field: fieldCheckSeasons,
+					Msg:   "must be >= 1",
+				})
+			}
+		default:
+			vErrs = append(vErrs, validationErr{
+				Field: fieldCheckMethod,
+				Msg:   fmt.Sprintf("must be 1 in [stddev, mad, seasonal]; got=%q", c.method),
+			})
+		}
+		if len(c.anomalyLevels) == 0 {
+			vErrs = append(vErrs, validationErr{
+				Field: fieldCheckLevels,
+				Msg:   "must provide at least 1 level entry",
+			})
+		}
+		for i, l := range c.anomalyLevels {
+			for _, fail := range l.valid() {
+				fail.Index = intPtr(i)
+				vErrs = append(vErrs, fail)
+			}
+		}
 	}
 
 	if len(vErrs) > 0 {
@@ -386,6 +524,39 @@ func toInfluxThresholds(thresholds ...threshold) []icheck.ThresholdConfig {
 	return iThresh
 }
 
+type anomalyLevel struct {
+	level       string
+	sensitivity float64
+}
+
+func (a anomalyLevel) valid() []validationErr {
+	var vErrs []validationErr
+	if notification.ParseCheckLevel(a.level) == notification.Unknown {
+		vErrs = append(vErrs, validationErr{
+			Field: fieldLevel,
+			Msg:   fmt.Sprintf("must be 1 in [CRIT, WARN, INFO, OK]; got=%q", a.level),
+		})
+	}
+	if a.sensitivity <= 0 {
+		vErrs = append(vErrs, validationErr{
+			Field: fieldCheckSensitivity,
+			Msg:   "must be > 0",
+		})
+	}
+	return vErrs
+}
+
+func toInfluxAnomalyLevels(levels ...anomalyLevel) []icheck.AnomalyLevel {
+	var iLevels []icheck.AnomalyLevel
+	for _, l := range levels {
+		iLevels = append(iLevels, icheck.AnomalyLevel{
+			Level:       notification.ParseCheckLevel(l.level),
+			Sensitivity: l.sensitivity,
+		})
+	}
+	return iLevels
+}
+
 // chartKind identifies what kind of chart is eluded too. Each
 // chart kind has their own requirements for what constitutes
 // a chart.
@@ -862,8 +1033,8 @@ type color struct {
 }
 
 // TODO:
-//  - verify templates are desired
-//  - template colors so references can be shared
+//   - verify templates are desired
+//   - template colors so references can be shared
 type colors []*color
 
 func (c colors) influxViewColors() []influxdb.ViewColor {
@@ -897,8 +1068,9 @@ func (c colors) strings() []string {
 }
 
 // TODO: looks like much of these are actually getting defaults in
-//  the UI. looking at sytem charts, seeign lots of failures for missing
-//  color types or no colors at all.
+//
+//	the UI. looking at sytem charts, seeign lots of failures for missing
+//	color types or no colors at all.
 func (c colors) hasTypes(types ...string) []validationErr {
 	tMap := make(map[string]bool)
 	for _, cc := range c {
@@ -1183,14 +1355,22 @@ const (
 	notificationKindHTTP notificationEndpointKind = iota + 1
 	notificationKindPagerDuty
 	notificationKindSlack
+	notificationKindOpsGenie
+	notificationKindTeams
+	notificationKindTelegram
+	notificationKindSMTP
 )
 
 func (n notificationEndpointKind) String() string {
-	if n > 0 && n < 4 {
+	if n > 0 && n < 8 {
 		return [...]string{
 			endpoint.HTTPType,
 			endpoint.PagerDutyType,
 			endpoint.SlackType,
+			endpoint.OpsGenieType,
+			endpoint.TeamsType,
+			endpoint.TelegramType,
+			endpoint.SMTPType,
 		}[n-1]
 	}
 	return ""
@@ -1203,6 +1383,9 @@ const (
 )
 
 const (
+	fieldNotificationEndpointAPIKey     = "apiKey"
+	fieldNotificationEndpointFrom       = "from"
+	fieldNotificationEndpointHost       = "host"
 	fieldNotificationEndpointHTTPMethod = "method"
 	fieldNotificationEndpointPassword   = "password"
 	fieldNotificationEndpointRoutingKey = "routingKey"
@@ -1215,10 +1398,14 @@ type notificationEndpoint struct {
 	identity
 
 	kind        notificationEndpointKind
+	apiKey      *references
 	description string
+	from        string
+	host        string
 	method      string
 	password    *references
 	routingKey  *references
+	secretURL   *references
 	status      string
 	token       *references
 	httpType    string
@@ -1282,6 +1469,31 @@ func (n *notificationEndpoint) summarize() SummaryNotificationEndpoint {
 			URL:   n.url,
 			Token: n.token.SecretField(),
 		}
+	case notificationKindOpsGenie:
+		sum.NotificationEndpoint = &endpoint.OpsGenie{
+			Base:   base,
+			URL:    n.url,
+			APIKey: n.apiKey.SecretField(),
+		}
+	case notificationKindTeams:
+		sum.NotificationEndpoint = &endpoint.Teams{
+			Base: base,
+			URL:  n.secretURL.SecretField(),
+		}
+	case notificationKindTelegram:
+		sum.NotificationEndpoint = &endpoint.Telegram{
+			Base:  base,
+			URL:   n.url,
+			Token: n.token.SecretField(),
+		}
+	case notificationKindSMTP:
+		sum.NotificationEndpoint = &endpoint.SMTP{
+			Base:     base,
+			Host:     n.host,
+			Username: n.username.String(),
+			Password: n.password.SecretField(),
+			From:     n.from,
+		}
 	}
 	return sum
 }
@@ -1310,11 +1522,23 @@ func (n *notificationEndpoint) valid() []validationErr {
 		failures = append(failures, err)
 	}
 
-	if _, err := url.Parse(n.url); err != nil || n.url == "" {
-		failures = append(failures, validationErr{
-			Field: fieldNotificationEndpointURL,
-			Msg:   "must be valid url",
-		})
+	switch n.kind {
+	case notificationKindTeams, notificationKindSMTP:
+		// the teams webhook url is a secret, validated below, and smtp has no url.
+	case notificationKindOpsGenie, notificationKindTelegram:
+		if _, err := url.Parse(n.url); err != nil {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointURL,
+				Msg:   "must be valid url",
+			})
+		}
+	default:
+		if _, err := url.Parse(n.url); err != nil || n.url == "" {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointURL,
+				Msg:   "must be valid url",
+			})
+		}
 	}
 
 	status := influxdb.Status(n.status)
@@ -1333,6 +1557,46 @@ func (n *notificationEndpoint) valid() []validationErr {
 				Msg:   "must be provide",
 			})
 		}
+	case notificationKindOpsGenie:
+		if !n.apiKey.hasValue() {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointAPIKey,
+				Msg:   "must be provided",
+			})
+		}
+	case notificationKindTeams:
+		if !n.secretURL.hasValue() {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointURL,
+				Msg:   "must be provided",
+			})
+		}
+	case notificationKindTelegram:
+		if !n.token.hasValue() {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointToken,
+				Msg:   "must be provided",
+			})
+		}
+	case notificationKindSMTP:
+		if _, _, err := net.SplitHostPort(n.host); err != nil {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointHost,
+				Msg:   "must be a valid host:port",
+			})
+		}
+		if _, err := mail.ParseAddress(n.from); err != nil {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointFrom,
+				Msg:   "must be a valid address",
+			})
+		}
+		if n.username.hasValue() && !n.password.hasValue() {
+			failures = append(failures, validationErr{
+				Field: fieldNotificationEndpointPassword,
+				Msg:   "must be provided with a username",
+			})
+		}
 	case notificationKindHTTP:
 		if !validEndpointHTTPMethods[n.method] {
 			failures = append(failures, validationErr{
@@ -1387,33 +1651,67 @@ func (n *notificationEndpoint) valid() []validationErr {
 }
 
 const (
-	fieldNotificationRuleChannel         = "channel"
-	fieldNotificationRuleCurrentLevel    = "currentLevel"
-	fieldNotificationRuleEndpointName    = "endpointName"
-	fieldNotificationRuleMessageTemplate = "messageTemplate"
-	fieldNotificationRulePreviousLevel   = "previousLevel"
-	fieldNotificationRuleStatusRules     = "statusRules"
-	fieldNotificationRuleTagRules        = "tagRules"
+	fieldNotificationRuleChannel               = "channel"
+	fieldNotificationRuleCurrentLevel          = "currentLevel"
+	fieldNotificationRuleDelay                 = "delay"
+	fieldNotificationRuleDisableWebPagePreview = "disableWebPagePreview"
+	fieldNotificationRuleEndpointName          = "endpointName"
+	fieldNotificationRuleEscalations           = "escalations"
+	fieldNotificationRuleMessageTemplate       = "messageTemplate"
+	fieldNotificationRuleParseMode             = "parseMode"
+	fieldNotificationRulePreviousLevel         = "previousLevel"
+	fieldNotificationRuleRepeatEvery           = "repeatEvery"
+	fieldNotificationRuleStatusRules           = "statusRules"
+	fieldNotificationRuleSubjectTemplate       = "subjectTemplate"
+	fieldNotificationRuleTagRules              = "tagRules"
+	fieldNotificationRuleTags                  = "tags"
+	fieldNotificationRuleTitle                 = "title"
+	fieldNotificationRuleTo                    = "to"
 )
 
 type notificationRule struct {
 	identity
 
-	channel     string
-	description string
-	every       time.Duration
-	msgTemplate string
-	offset      time.Duration
-	status      string
-	statusRules []struct{ curLvl, prevLvl string }
-	tagRules    []struct{ k, v, op string }
+	channel        string
+	description    string
+	disablePreview bool
+	every          time.Duration
+	msgTemplate    string
+	offset         time.Duration
+	parseMode      string
+	status         string
+	statusRules    []struct{ curLvl, prevLvl string }
+	subject        string
+	tagRules       []struct{ k, v, op string }
+	tags           []string
+	title          string
+	to             string
 
 	associatedEndpoint *notificationEndpoint
 	endpointName       *references
 
+	escalations []*notificationRuleEscalation
+
 	labels sortedLabels
 }
 
+// notificationRuleEscalation is an escalation step of a notification rule,
+// its endpoint must be of the same kind as the endpoint of the rule.
+type notificationRuleEscalation struct {
+	associatedEndpoint *notificationEndpoint
+	endpointName       *references
+
+	delay       time.Duration
+	repeatEvery time.Duration
+}
+
+func (e *notificationRuleEscalation) endpointPkgName() string {
+	if e.associatedEndpoint != nil {
+		return e.associatedEndpoint.PkgName()
+	}
+	return ""
+}
+
 func (r *notificationRule) Labels() []*label {
 	return r.labels
 }
@@ -1456,9 +1754,33 @@ func (r *notificationRule) summarize() SummaryNotificationRule {
 		Status:            r.Status(),
 		StatusRules:       toSummaryStatusRules(r.statusRules),
 		TagRules:          toSummaryTagRules(r.tagRules),
+		Escalations:       r.summarizeEscalations(nil),
 	}
 }
 
+// summarizeEscalations summarizes the escalation steps, with the IDs of their
+// endpoints if endpointID is provided.
+func (r *notificationRule) summarizeEscalations(endpointID func(i int) influxdb.ID) []SummaryEscalationStep {
+	if len(r.escalations) == 0 {
+		return nil
+	}
+	out := make([]SummaryEscalationStep, 0, len(r.escalations))
+	for i, e := range r.escalations {
+		sum := SummaryEscalationStep{
+			EndpointPkgName: e.endpointPkgName(),
+			Delay:           e.delay.String(),
+		}
+		if e.repeatEvery > 0 {
+			sum.RepeatEvery = e.repeatEvery.String()
+		}
+		if endpointID != nil {
+			sum.EndpointID = SafeID(endpointID(i))
+		}
+		out = append(out, sum)
+	}
+	return out
+}
+
 func (r *notificationRule) toInfluxRule() influxdb.NotificationRule {
 	base := rule.Base{
 		Name:        r.Name(),
@@ -1486,6 +1808,16 @@ func (r *notificationRule) toInfluxRule() influxdb.NotificationRule {
 			Operator: op,
 		})
 	}
+	// the endpoint IDs of the escalation steps are set once the endpoints exist.
+	for _, e := range r.escalations {
+		step := notification.EscalationStep{
+			Delay: toNotificationDuration(e.delay),
+		}
+		if e.repeatEvery > 0 {
+			step.RepeatEvery = toNotificationDuration(e.repeatEvery)
+		}
+		base.Escalations = append(base.Escalations, step)
+	}
 
 	switch r.associatedEndpoint.kind {
 	case notificationKindHTTP:
@@ -1501,6 +1833,33 @@ func (r *notificationRule) toInfluxRule() influxdb.NotificationRule {
 			Channel:         r.channel,
 			MessageTemplate: r.msgTemplate,
 		}
+	case notificationKindOpsGenie:
+		return &rule.OpsGenie{
+			Base:            base,
+			MessageTemplate: r.msgTemplate,
+			Tags:            r.tags,
+		}
+	case notificationKindTeams:
+		return &rule.Teams{
+			Base:            base,
+			Title:           r.title,
+			MessageTemplate: r.msgTemplate,
+		}
+	case notificationKindTelegram:
+		return &rule.Telegram{
+			Base:                  base,
+			Channel:               r.channel,
+			MessageTemplate:       r.msgTemplate,
+			ParseMode:             r.parseMode,
+			DisableWebPagePreview: r.disablePreview,
+		}
+	case notificationKindSMTP:
+		return &rule.SMTP{
+			Base:            base,
+			SubjectTemplate: r.subject,
+			BodyTemplate:    r.msgTemplate,
+			To:              r.to,
+		}
 	}
 	return nil
 }
@@ -1583,6 +1942,50 @@ func (r *notificationRule) valid() []validationErr {
 		})
 	}
 
+	var escalationErrs []validationErr
+	for i, e := range r.escalations {
+		switch {
+		case !e.endpointName.hasValue():
+			escalationErrs = append(escalationErrs, validationErr{
+				Field: fieldNotificationRuleEndpointName,
+				Msg:   "must be provided",
+				Index: intPtr(i),
+			})
+		case e.associatedEndpoint == nil:
+			escalationErrs = append(escalationErrs, validationErr{
+				Field: fieldNotificationRuleEndpointName,
+				Msg:   fmt.Sprintf("notification endpoint %q does not exist in pkg", e.endpointName.String()),
+				Index: intPtr(i),
+			})
+		case r.associatedEndpoint != nil && e.associatedEndpoint.kind != r.associatedEndpoint.kind:
+			escalationErrs = append(escalationErrs, validationErr{
+				Field: fieldNotificationRuleEndpointName,
+				Msg:   fmt.Sprintf("notification endpoint %q must be of the same kind as the endpoint of the rule", e.endpointName.String()),
+				Index: intPtr(i),
+			})
+		}
+		if e.delay < 0 {
+			escalationErrs = append(escalationErrs, validationErr{
+				Field: fieldNotificationRuleDelay,
+				Msg:   "must not be negative",
+				Index: intPtr(i),
+			})
+		}
+		if e.repeatEvery < 0 || (e.repeatEvery > 0 && e.repeatEvery < r.every) {
+			escalationErrs = append(escalationErrs, validationErr{
+				Field: fieldNotificationRuleRepeatEvery,
+				Msg:   "must not be less than the every of the rule",
+				Index: intPtr(i),
+			})
+		}
+	}
+	if len(escalationErrs) > 0 {
+		vErrs = append(vErrs, validationErr{
+			Field:  fieldNotificationRuleEscalations,
+			Nested: escalationErrs,
+		})
+	}
+
 	if len(vErrs) > 0 {
 		return []validationErr{
 			objectValidationErr(fieldSpec, vErrs...),
@@ -1632,6 +2035,159 @@ func toSummaryTagRules(tagRules []struct{ k, v, op string }) []SummaryTagRule {
 	return out
 }
 
+const (
+	fieldRolePermissions = "permissions"
+
+	fieldRolePermissionAction       = "action"
+	fieldRolePermissionResourceID   = "resourceID"
+	fieldRolePermissionResourceType = "resourceType"
+)
+
+type role struct {
+	identity
+
+	description string
+	permissions rolePermissions
+}
+
+func (r *role) ResourceType() influxdb.ResourceType {
+	return KindRole.ResourceType()
+}
+
+func (r *role) summarize() SummaryRole {
+	return SummaryRole{
+		PkgName:     r.PkgName(),
+		Name:        r.Name(),
+		Description: r.description,
+		Permissions: r.permissions,
+	}
+}
+
+func (r *role) valid() []validationErr {
+	var failures []validationErr
+	if err, ok := isValidName(r.Name(), 1); !ok {
+		failures = append(failures, err)
+	}
+	if len(r.permissions) == 0 {
+		failures = append(failures, validationErr{
+			Field: fieldRolePermissions,
+			Msg:   "must provide at least 1 permission",
+		})
+	}
+	failures = append(failures, r.permissions.valid()...)
+
+	if len(failures) > 0 {
+		return []validationErr{
+			objectValidationErr(fieldSpec, failures...),
+		}
+	}
+
+	return nil
+}
+
+// rolePermission is a permission granted by a role. It applies to all the
+// resources of its type within the organization the role is applied to,
+// unless it identifies a single resource.
+type rolePermission struct {
+	Action       string `json:"action" yaml:"action"`
+	ResourceType string `json:"resourceType" yaml:"resourceType"`
+	ResourceID   string `json:"resourceID,omitempty" yaml:"resourceID,omitempty"`
+}
+
+func newRolePermission(p influxdb.Permission) rolePermission {
+	rp := rolePermission{
+		Action:       string(p.Action),
+		ResourceType: string(p.Resource.Type),
+	}
+	// the org of a role is the org the pkg is applied to, so it is never exported
+	if p.Resource.ID != nil && p.Resource.Type != influxdb.OrgsResourceType {
+		rp.ResourceID = p.Resource.ID.String()
+	}
+	return rp
+}
+
+func (p rolePermission) toInfluxPermission(orgID influxdb.ID) influxdb.Permission {
+	perm := influxdb.Permission{
+		Action:   influxdb.Action(p.Action),
+		Resource: influxdb.Resource{Type: influxdb.ResourceType(p.ResourceType)},
+	}
+	if perm.Resource.Type == influxdb.OrgsResourceType {
+		perm.Resource.ID = &orgID
+		return perm
+	}
+	perm.Resource.OrgID = &orgID
+	if id, err := influxdb.IDFromString(p.ResourceID); err == nil {
+		perm.Resource.ID = id
+	}
+	return perm
+}
+
+type rolePermissions []rolePermission
+
+func newRolePermissions(ps []influxdb.Permission) rolePermissions {
+	var out rolePermissions
+	for _, p := range ps {
+		out = append(out, newRolePermission(p))
+	}
+	return out
+}
+
+func (r rolePermissions) toInfluxPermissions(orgID influxdb.ID) []influxdb.Permission {
+	out := make([]influxdb.Permission, 0, len(r))
+	for _, p := range r {
+		out = append(out, p.toInfluxPermission(orgID))
+	}
+	return out
+}
+
+// String returns the permissions in the form action:resourceType[/resourceID].
+func (r rolePermissions) String() string {
+	out := make([]string, 0, len(r))
+	for _, p := range r {
+		s := p.Action + ":" + p.ResourceType
+		if p.ResourceID != "" {
+			s += "/" + p.ResourceID
+		}
+		out = append(out, s)
+	}
+	return strings.Join(out, ",")
+}
+
+func (r rolePermissions) valid() []validationErr {
+	var failures []validationErr
+	for i, p := range r {
+		var ff []validationErr
+		if err := influxdb.Action(p.Action).Valid(); err != nil {
+			ff = append(ff, validationErr{
+				Field: fieldRolePermissionAction,
+				Msg:   `must be one of ["read", "write"]`,
+			})
+		}
+		if err := influxdb.ResourceType(p.ResourceType).Valid(); err != nil {
+			ff = append(ff, validationErr{
+				Field: fieldRolePermissionResourceType,
+				Msg:   fmt.Sprintf("unknown resource type %q", p.ResourceType),
+			})
+		}
+		if p.ResourceID != "" {
+			if _, err := influxdb.IDFromString(p.ResourceID); err != nil {
+				ff = append(ff, validationErr{
+					Field: fieldRolePermissionResourceID,
+					Msg:   "must be a valid id",
+				})
+			}
+		}
+		if len(ff) > 0 {
+			failures = append(failures, validationErr{
+				Field:  fieldRolePermissions,
+				Index:  intPtr(i),
+				Nested: ff,
+			})
+		}
+	}
+	return failures
+}
+
 const (
 	fieldTaskCron = "cron"
 )
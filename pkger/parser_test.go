@@ -46,8 +46,72 @@ func TestParse(t *testing.T) {
 			})
 		})
 
+		t.Run("with downsample rules", func(t *testing.T) {
+			pkgStr := `apiVersion: influxdata.com/v2alpha1
+kind: Bucket
+metadata:
+  name:  rucket-11
+spec:
+  retentionRules:
+    - type: expire
+      everySeconds: 1209600
+  downsampleRules:
+    - afterSeconds: 604800
+      everySeconds: 300
+      functions: [mean, max]
+      destinationBucket: rucket-22
+`
+			pkg := newParsedPkg(t, FromString(pkgStr), EncodingYAML)
+
+			buckets := pkg.Summary().Buckets
+			require.Len(t, buckets, 1)
+
+			expected := downsampleRules{{
+				AfterSeconds:      604800,
+				EverySeconds:      300,
+				Functions:         []string{"mean", "max"},
+				DestinationBucket: "rucket-22",
+			}}
+			assert.Equal(t, expected, buckets[0].DownsampleRules)
+		})
+
 		t.Run("handles bad config", func(t *testing.T) {
 			tests := []testPkgResourceError{
+				{
+					name:           "invalid downsample function",
+					validationErrs: 1,
+					valFields:      []string{fieldSpec, fieldBucketDownsampleRules},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Bucket
+metadata:
+  name:  rucket-11
+spec:
+  downsampleRules:
+    - afterSeconds: 600
+      everySeconds: 300
+      functions: [mode]
+      destinationBucket: rucket-22
+`,
+				},
+				{
+					name:           "downsample rule beyond retention",
+					validationErrs: 1,
+					valFields:      []string{fieldSpec, fieldBucketDownsampleRules},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Bucket
+metadata:
+  name:  rucket-11
+spec:
+  retentionRules:
+    - type: expire
+      everySeconds: 3600
+  downsampleRules:
+    - afterSeconds: 3600
+      everySeconds: 300
+      functions: [mean]
+      destinationBucket: rucket-22
+`,
+				},
 				{
 					name:           "missing name",
 					validationErrs: 1,
@@ -457,7 +521,7 @@ spec:
 		t.Run("happy path", func(t *testing.T) {
 			testfileRunner(t, "testdata/checks", func(t *testing.T, pkg *Pkg) {
 				sum := pkg.Summary()
-				require.Len(t, sum.Checks, 2)
+				require.Len(t, sum.Checks, 3)
 
 				check1 := sum.Checks[0]
 				thresholdCheck, ok := check1.Check.(*icheck.Threshold)
@@ -528,6 +592,32 @@ spec:
 				assert.True(t, deadmanCheck.ReportZero)
 				assert.Len(t, check2.LabelAssociations, 1)
 
+				check3 := sum.Checks[2]
+				anomalyCheck, ok := check3.Check.(*icheck.Anomaly)
+				require.Truef(t, ok, "got: %#v", check3)
+
+				expectedBase = icheck.Base{
+					Name:                  "check-2",
+					Description:           "desc_2",
+					Every:                 mustDuration(t, time.Minute),
+					Offset:                mustDuration(t, 0),
+					StatusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }",
+				}
+				expectedBase.Query.Text = "from(bucket: \"rucket_1\")\n  |> range(start: v.timeRangeStart, stop: v.timeRangeStop)\n  |> filter(fn: (r) => r._measurement == \"cpu\")\n  |> filter(fn: (r) => r._field == \"usage_idle\")\n  |> aggregateWindow(every: 1m, fn: mean)\n  |> yield(name: \"mean\")"
+				assert.Equal(t, expectedBase, anomalyCheck.Base)
+				assert.Equal(t, icheck.AnomalySeasonal, anomalyCheck.Method)
+				assert.Nil(t, anomalyCheck.Window)
+				assert.Equal(t, mustDuration(t, 24*time.Hour), anomalyCheck.Season)
+				assert.Equal(t, 7, anomalyCheck.Seasons)
+				expectedLevels := []icheck.AnomalyLevel{
+					{Level: notification.Ok, Sensitivity: 2},
+					{Level: notification.Warn, Sensitivity: 2},
+					{Level: notification.Critical, Sensitivity: 3.5},
+				}
+				assert.Equal(t, expectedLevels, anomalyCheck.Levels)
+				assert.Equal(t, influxdb.Active, check3.Status)
+				assert.Len(t, check3.LabelAssociations, 1)
+
 				expectedMappings := []SummaryLabelMapping{
 					{
 						LabelPkgName:    "label-1",
@@ -541,6 +631,12 @@ spec:
 						ResourcePkgName: "check-1",
 						ResourceName:    "display name",
 					},
+					{
+						LabelPkgName:    "label-1",
+						LabelName:       "label-1",
+						ResourcePkgName: "check-2",
+						ResourceName:    "check-2",
+					},
 				}
 				for _, expected := range expectedMappings {
 					expected.Status = StateStatusNew
@@ -692,6 +788,102 @@ spec:
     from(bucket: "rucket_1") |> yield(name: "mean")
   statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
   thresholds:
+`,
+					},
+				},
+				{
+					kind: KindCheckAnomaly,
+					resErr: testPkgResourceError{
+						name:           "invalid anomaly method provided",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldCheckMethod},
+						pkgStr: `---
+apiVersion: influxdata.com/v2alpha1
+kind: CheckAnomaly
+metadata:
+  name: check-0
+spec:
+  every: 1m
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  method: zscore
+  window: 1h
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  levels:
+    - level: CRIT
+      sensitivity: 3.0
+`,
+					},
+				},
+				{
+					kind: KindCheckAnomaly,
+					resErr: testPkgResourceError{
+						name:           "anomaly window not greater than every",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldCheckWindow},
+						pkgStr: `---
+apiVersion: influxdata.com/v2alpha1
+kind: CheckAnomaly
+metadata:
+  name: check-0
+spec:
+  every: 1m
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  method: stddev
+  window: 1m
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  levels:
+    - level: CRIT
+      sensitivity: 3.0
+`,
+					},
+				},
+				{
+					kind: KindCheckAnomaly,
+					resErr: testPkgResourceError{
+						name:           "no anomaly levels provided",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldCheckLevels},
+						pkgStr: `---
+apiVersion: influxdata.com/v2alpha1
+kind: CheckAnomaly
+metadata:
+  name: check-0
+spec:
+  every: 1m
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  method: mad
+  window: 1h
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  levels:
+`,
+					},
+				},
+				{
+					kind: KindCheckAnomaly,
+					resErr: testPkgResourceError{
+						name:           "invalid anomaly sensitivity provided",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldCheckLevels},
+						pkgStr: `---
+apiVersion: influxdata.com/v2alpha1
+kind: CheckAnomaly
+metadata:
+  name: check-0
+spec:
+  every: 1m
+  query:  >
+    from(bucket: "rucket_1") |> yield(name: "mean")
+  method: mad
+  window: 1h
+  statusMessageTemplate: "Check: ${ r._check_name } is: ${ r._level }"
+  levels:
+    - level: WARN
+      sensitivity: 2.0
+    - level: CRIT
+      sensitivity: -1.0
 `,
 					},
 				},
@@ -2417,6 +2609,55 @@ spec:
 							Token: influxdb.SecretField{Value: strPtr("tokenval")},
 						},
 					},
+					{
+						PkgName: "opsgenie-notification-endpoint",
+						NotificationEndpoint: &endpoint.OpsGenie{
+							Base: endpoint.Base{
+								Name:        "opsgenie name",
+								Description: "opsgenie desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							URL:    "https://api.eu.opsgenie.com/v2/alerts",
+							APIKey: influxdb.SecretField{Value: strPtr("secret api-key")},
+						},
+					},
+					{
+						PkgName: "teams-notification-endpoint",
+						NotificationEndpoint: &endpoint.Teams{
+							Base: endpoint.Base{
+								Name:        "teams name",
+								Description: "teams desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							URL: influxdb.SecretField{Value: strPtr("https://outlook.office.com/webhook/bip/IncomingWebhook/piddy/boppidy")},
+						},
+					},
+					{
+						PkgName: "telegram-notification-endpoint",
+						NotificationEndpoint: &endpoint.Telegram{
+							Base: endpoint.Base{
+								Name:        "telegram name",
+								Description: "telegram desc",
+								Status:      influxdb.TaskStatusInactive,
+							},
+							URL:   "https://telegram.example.com",
+							Token: influxdb.SecretField{Value: strPtr("secret bot-token")},
+						},
+					},
+					{
+						PkgName: "smtp-notification-endpoint",
+						NotificationEndpoint: &endpoint.SMTP{
+							Base: endpoint.Base{
+								Name:        "smtp name",
+								Description: "smtp desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							Host:     "smtp.example.com:587",
+							Username: "influxdb",
+							Password: influxdb.SecretField{Value: strPtr("secret password")},
+							From:     "influxdb@example.com",
+						},
+					},
 				}
 
 				sum := pkg.Summary()
@@ -2472,6 +2713,79 @@ kind: NotificationEndpointPagerDuty
 metadata:
   name: pager-duty-notification-endpoint
 spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointOpsGenie,
+					resErr: testPkgResourceError{
+						name:           "missing opsgenie api key",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointAPIKey},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointOpsGenie
+metadata:
+  name: opsgenie-notification-endpoint
+spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointTeams,
+					resErr: testPkgResourceError{
+						name:           "missing teams url",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointURL},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointTeams
+metadata:
+  name: teams-notification-endpoint
+spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointTelegram,
+					resErr: testPkgResourceError{
+						name:           "missing telegram token",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointToken},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointTelegram
+metadata:
+  name: telegram-notification-endpoint
+spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointSMTP,
+					resErr: testPkgResourceError{
+						name:           "missing smtp host and from",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointHost, fieldNotificationEndpointFrom},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointSMTP
+metadata:
+  name: smtp-notification-endpoint
+spec:
+`,
+					},
+				},
+				{
+					kind: KindNotificationEndpointSMTP,
+					resErr: testPkgResourceError{
+						name:           "missing smtp password",
+						validationErrs: 1,
+						valFields:      []string{fieldSpec, fieldNotificationEndpointPassword},
+						pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointSMTP
+metadata:
+  name: smtp-notification-endpoint
+spec:
+  host: smtp.example.com:587
+  username: influxdb
+  from: influxdb@example.com
 `,
 					},
 				},
@@ -2740,6 +3054,19 @@ spec:
 			})
 		})
 
+		t.Run("with escalations", func(t *testing.T) {
+			testfileRunner(t, "testdata/notification_rule_escalations.yml", func(t *testing.T, pkg *Pkg) {
+				rules := pkg.Summary().NotificationRules
+				require.Len(t, rules, 1)
+
+				expected := []SummaryEscalationStep{
+					{EndpointPkgName: "endpoint-1", Delay: (15 * time.Minute).String()},
+					{EndpointPkgName: "endpoint-2", Delay: time.Hour.String(), RepeatEvery: time.Hour.String()},
+				}
+				assert.Equal(t, expected, rules[0].Escalations)
+			})
+		})
+
 		t.Run("handles bad config", func(t *testing.T) {
 			pkgWithValidEndpint := func(resource string) string {
 				return fmt.Sprintf(`
@@ -3001,6 +3328,77 @@ spec:
 `,
 					},
 				},
+				{
+					kind: KindNotificationRule,
+					resErr: testPkgResourceError{
+						name:      "missing escalation endpoint association in pkg",
+						valFields: []string{fieldSpec, fieldNotificationRuleEscalations},
+						pkgStr: pkgWithValidEndpint(`apiVersion: influxdata.com/v2alpha1
+kind: NotificationRule
+metadata:
+  name: rule-0
+spec:
+  endpointName: endpoint-0
+  every: 10m
+  messageTemplate: "Notification Rule: ${ r._notification_rule_name } triggered by check: ${ r._check_name }: ${ r._message }"
+  statusRules:
+    - currentLevel: WARN
+  escalations:
+    - endpointName: RANDO_ENDPOINT_NAME
+      delay: 15m
+`),
+					},
+				},
+				{
+					kind: KindNotificationRule,
+					resErr: testPkgResourceError{
+						name:      "escalation endpoint of another kind",
+						valFields: []string{fieldSpec, fieldNotificationRuleEscalations},
+						pkgStr: pkgWithValidEndpint(`apiVersion: influxdata.com/v2alpha1
+kind: NotificationEndpointHTTP
+metadata:
+  name: endpoint-1
+spec:
+  type: none
+  method: POST
+  url: https://www.example.com/endpoint/noneauth
+---
+apiVersion: influxdata.com/v2alpha1
+kind: NotificationRule
+metadata:
+  name: rule-0
+spec:
+  endpointName: endpoint-0
+  every: 10m
+  messageTemplate: "Notification Rule: ${ r._notification_rule_name } triggered by check: ${ r._check_name }: ${ r._message }"
+  statusRules:
+    - currentLevel: WARN
+  escalations:
+    - endpointName: endpoint-1
+`),
+					},
+				},
+				{
+					kind: KindNotificationRule,
+					resErr: testPkgResourceError{
+						name:      "escalation repeated more often than every",
+						valFields: []string{fieldSpec, fieldNotificationRuleEscalations},
+						pkgStr: pkgWithValidEndpint(`apiVersion: influxdata.com/v2alpha1
+kind: NotificationRule
+metadata:
+  name: rule-0
+spec:
+  endpointName: endpoint-0
+  every: 10m
+  messageTemplate: "Notification Rule: ${ r._notification_rule_name } triggered by check: ${ r._check_name }: ${ r._message }"
+  statusRules:
+    - currentLevel: WARN
+  escalations:
+    - endpointName: endpoint-0
+      repeatEvery: 1m
+`),
+					},
+				},
 			}
 
 			for _, tt := range tests {
@@ -3009,6 +3407,124 @@ spec:
 		})
 	})
 
+	t.Run("pkg with roles", func(t *testing.T) {
+		t.Run("with valid fields should produce summary", func(t *testing.T) {
+			testfileRunner(t, "testdata/role", func(t *testing.T, pkg *Pkg) {
+				sum := pkg.Summary()
+
+				require.Len(t, sum.Roles, 2)
+
+				expected := []SummaryRole{
+					{
+						PkgName:     "role-1",
+						Name:        "display name",
+						Description: "role 1 desc",
+						Permissions: rolePermissions{
+							{Action: "read", ResourceType: "buckets"},
+							{Action: "write", ResourceType: "dashboards", ResourceID: "020f755c3c082000"},
+						},
+					},
+					{
+						PkgName: "role-2",
+						Name:    "role-2",
+						Permissions: rolePermissions{
+							{Action: "read", ResourceType: "orgs"},
+						},
+					},
+				}
+				assert.Equal(t, expected, sum.Roles)
+			})
+		})
+
+		t.Run("handles bad config", func(t *testing.T) {
+			tests := []testPkgResourceError{
+				{
+					name:           "missing permissions",
+					validationErrs: 1,
+					valFields:      []string{fieldSpec, fieldRolePermissions},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Role
+metadata:
+  name:  role-1
+spec:
+`,
+				},
+				{
+					name:           "invalid action",
+					validationErrs: 1,
+					valFields:      []string{strings.Join([]string{fieldSpec, "permissions[0]", fieldRolePermissionAction}, ".")},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Role
+metadata:
+  name:  role-1
+spec:
+  permissions:
+    - action: execute
+      resourceType: buckets
+`,
+				},
+				{
+					name:           "unknown resource type",
+					validationErrs: 1,
+					valFields:      []string{strings.Join([]string{fieldSpec, "permissions[1]", fieldRolePermissionResourceType}, ".")},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Role
+metadata:
+  name:  role-1
+spec:
+  permissions:
+    - action: read
+      resourceType: buckets
+    - action: read
+      resourceType: rainbows
+`,
+				},
+				{
+					name:           "invalid resource id",
+					validationErrs: 1,
+					valFields:      []string{strings.Join([]string{fieldSpec, "permissions[0]", fieldRolePermissionResourceID}, ".")},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Role
+metadata:
+  name:  role-1
+spec:
+  permissions:
+    - action: read
+      resourceType: buckets
+      resourceID: not-an-id
+`,
+				},
+				{
+					name:           "duplicate meta names",
+					validationErrs: 1,
+					valFields:      []string{fieldMetadata, fieldName},
+					pkgStr: `apiVersion: influxdata.com/v2alpha1
+kind: Role
+metadata:
+  name:  role-1
+spec:
+  permissions:
+    - action: read
+      resourceType: buckets
+---
+apiVersion: influxdata.com/v2alpha1
+kind: Role
+metadata:
+  name:  role-1
+spec:
+  permissions:
+    - action: read
+      resourceType: buckets
+`,
+				},
+			}
+
+			for _, tt := range tests {
+				testPkgErrors(t, KindRole, tt)
+			}
+		})
+	})
+
 	t.Run("pkg with tasks", func(t *testing.T) {
 		t.Run("happy path", func(t *testing.T) {
 			testfileRunner(t, "testdata/tasks", func(t *testing.T, pkg *Pkg) {
@@ -87,6 +87,7 @@ type serviceOpt struct {
 	labelSVC    influxdb.LabelService
 	endpointSVC influxdb.NotificationEndpointService
 	orgSVC      influxdb.OrganizationService
+	roleSVC     influxdb.RoleService
 	ruleSVC     influxdb.NotificationRuleStore
 	secretSVC   influxdb.SecretService
 	taskSVC     influxdb.TaskService
@@ -174,6 +175,13 @@ func WithLabelSVC(labelSVC influxdb.LabelService) ServiceSetterFn {
 	}
 }
 
+// WithRoleSVC sets the role service.
+func WithRoleSVC(roleSVC influxdb.RoleService) ServiceSetterFn {
+	return func(opt *serviceOpt) {
+		opt.roleSVC = roleSVC
+	}
+}
+
 // WithSecretSVC sets the secret service.
 func WithSecretSVC(secretSVC influxdb.SecretService) ServiceSetterFn {
 	return func(opt *serviceOpt) {
@@ -229,6 +237,7 @@ type Service struct {
 	labelSVC    influxdb.LabelService
 	endpointSVC influxdb.NotificationEndpointService
 	orgSVC      influxdb.OrganizationService
+	roleSVC     influxdb.RoleService
 	ruleSVC     influxdb.NotificationRuleStore
 	secretSVC   influxdb.SecretService
 	taskSVC     influxdb.TaskService
@@ -264,6 +273,7 @@ func NewService(opts ...ServiceSetterFn) *Service {
 		dashSVC:     opt.dashSVC,
 		endpointSVC: opt.endpointSVC,
 		orgSVC:      opt.orgSVC,
+		roleSVC:     opt.roleSVC,
 		ruleSVC:     opt.ruleSVC,
 		secretSVC:   opt.secretSVC,
 		taskSVC:     opt.taskSVC,
@@ -346,6 +356,20 @@ func (s *Service) ExportStack(ctx context.Context, orgID, stackID influxdb.ID) (
 		return nil, err
 	}
 
+	endpointPkgNames := make(map[influxdb.ID]string)
+	for _, res := range stack.Resources {
+		if res.Kind.is(KindNotificationEndpoint,
+			KindNotificationEndpointHTTP,
+			KindNotificationEndpointOpsGenie,
+			KindNotificationEndpointPagerDuty,
+			KindNotificationEndpointSlack,
+			KindNotificationEndpointSMTP,
+			KindNotificationEndpointTeams,
+			KindNotificationEndpointTelegram) {
+			endpointPkgNames[res.ID] = res.PkgName
+		}
+	}
+
 	pkg := Pkg{Objects: labelObjs}
 	for _, res := range stack.Resources {
 		var (
@@ -363,7 +387,7 @@ func (s *Service) ExportStack(ctx context.Context, orgID, stackID influxdb.ID) (
 				return nil, ierrors.Wrap(err, fmt.Sprintf("failed to find bucket[%s]", res.ID.String()))
 			}
 			obj = BucketToObject("", *bkt)
-		case KindCheck, KindCheckDeadman, KindCheckThreshold:
+		case KindCheck, KindCheckAnomaly, KindCheckDeadman, KindCheckThreshold:
 			ch, err := s.checkSVC.FindCheckByID(ctx, res.ID)
 			if influxdb.ErrorCode(err) == influxdb.ENotFound {
 				continue
@@ -387,8 +411,12 @@ func (s *Service) ExportStack(ctx context.Context, orgID, stackID influxdb.ID) (
 			continue
 		case KindNotificationEndpoint,
 			KindNotificationEndpointHTTP,
+			KindNotificationEndpointOpsGenie,
 			KindNotificationEndpointPagerDuty,
-			KindNotificationEndpointSlack:
+			KindNotificationEndpointSlack,
+			KindNotificationEndpointSMTP,
+			KindNotificationEndpointTeams,
+			KindNotificationEndpointTelegram:
 			e, err := s.endpointSVC.FindNotificationEndpointByID(ctx, res.ID)
 			if influxdb.ErrorCode(err) == influxdb.ENotFound {
 				continue
@@ -416,7 +444,26 @@ func (s *Service) ExportStack(ctx context.Context, orgID, stackID influxdb.ID) (
 			if endpointName == "" {
 				continue
 			}
-			obj = NotificationRuleToObject("", endpointName, e)
+			var escalationNames []string
+			for _, id := range e.GetEscalationEndpointIDs() {
+				if name, ok := endpointPkgNames[id]; ok {
+					escalationNames = append(escalationNames, name)
+				}
+			}
+			// or if the endpoint of an escalation step is not in the stack
+			if len(escalationNames) != len(e.GetEscalationEndpointIDs()) {
+				continue
+			}
+			obj = NotificationRuleToObject("", endpointName, escalationNames, e)
+		case KindRole:
+			r, err := s.roleSVC.FindRoleByID(ctx, res.ID)
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				continue
+			}
+			if err != nil {
+				return nil, ierrors.Wrap(err, fmt.Sprintf("failed to find role[%s]", res.ID.String()))
+			}
+			obj = RoleToObject("", *r)
 		case KindTask:
 			t, err := s.taskSVC.FindTaskByID(ctx, res.ID)
 			if influxdb.ErrorCode(err) == influxdb.ENotFound {
@@ -731,6 +778,22 @@ func (s *Service) cloneOrgNotificationRules(ctx context.Context, orgID influxdb.
 	return resources, nil
 }
 
+func (s *Service) cloneOrgRoles(ctx context.Context, orgID influxdb.ID) ([]ResourceToClone, error) {
+	roles, _, err := s.roleSVC.FindRoles(ctx, influxdb.RoleFilter{OrgID: &orgID})
+	if err != nil {
+		return nil, err
+	}
+
+	resources := make([]ResourceToClone, 0, len(roles))
+	for _, r := range roles {
+		resources = append(resources, ResourceToClone{
+			Kind: KindRole,
+			ID:   r.ID,
+		})
+	}
+	return resources, nil
+}
+
 func (s *Service) cloneOrgTasks(ctx context.Context, orgID influxdb.ID) ([]ResourceToClone, error) {
 	tasks, _, err := s.taskSVC.FindTasks(ctx, influxdb.TaskFilter{OrganizationID: &orgID})
 	if err != nil {
@@ -828,6 +891,7 @@ func (s *Service) filterOrgResourceKinds(resourceKindFilters []Kind) []struct {
 		KindLabel:                s.cloneOrgLabels,
 		KindNotificationEndpoint: s.cloneOrgNotificationEndpoints,
 		KindNotificationRule:     s.cloneOrgNotificationRules,
+		KindRole:                 s.cloneOrgRoles,
 		KindTask:                 s.cloneOrgTasks,
 		KindTelegraf:             s.cloneOrgTelegrafs,
 		KindVariable:             s.cloneOrgVariables,
@@ -942,6 +1006,7 @@ func (s *Service) dryRun(ctx context.Context, orgID influxdb.ID, pkg *Pkg, opt A
 	s.dryRunChecks(ctx, orgID, state.mChecks)
 	s.dryRunDashboards(ctx, orgID, state.mDashboards)
 	s.dryRunLabels(ctx, orgID, state.mLabels)
+	s.dryRunRoles(ctx, orgID, state.mRoles)
 	s.dryRunTasks(ctx, orgID, state.mTasks)
 	s.dryRunTelegrafConfigs(ctx, orgID, state.mTelegrafs)
 	s.dryRunVariables(ctx, orgID, state.mVariables)
@@ -1090,9 +1155,45 @@ func (s *Service) dryRunNotificationRules(ctx context.Context, orgID influxdb.ID
 		r.associatedEndpoint = e
 	}
 
+	for _, r := range rules {
+		if IsRemoval(r.stateStatus) {
+			continue
+		}
+		if name, ok := r.associateEscalationEndpoints(endpoints); !ok {
+			err := fmt.Errorf("failed to find notification endpoint %q escalation dependency for notification rule %q", name, r.parserRule.PkgName())
+			return &influxdb.Error{
+				Code: influxdb.EUnprocessableEntity,
+				Err:  err,
+			}
+		}
+	}
+
 	return nil
 }
 
+func (s *Service) dryRunRoles(ctx context.Context, orgID influxdb.ID, roles map[string]*stateRole) {
+	for _, r := range roles {
+		r.orgID = orgID
+		var existing *influxdb.Role
+		if r.ID() != 0 {
+			existing, _ = s.roleSVC.FindRoleByID(ctx, r.ID())
+		} else {
+			name := r.parserRole.Name()
+			existingRoles, _, _ := s.roleSVC.FindRoles(ctx, influxdb.RoleFilter{
+				OrgID: &orgID,
+				Name:  &name,
+			})
+			if len(existingRoles) > 0 {
+				existing = existingRoles[0]
+			}
+		}
+		if IsNew(r.stateStatus) && existing != nil {
+			r.stateStatus = StateStatusExists
+		}
+		r.existing = existing
+	}
+}
+
 func (s *Service) dryRunSecrets(ctx context.Context, orgID influxdb.ID, pkg *Pkg) error {
 	pkgSecrets := pkg.mSecrets
 	if len(pkgSecrets) == 0 {
@@ -1466,6 +1567,7 @@ func (s *Service) applyState(ctx context.Context, coordinator *rollbackCoordinat
 			s.applyChecks(ctx, state.checks()),
 			s.applyDashboards(ctx, state.dashboards()),
 			endpointApp,
+			s.applyRoles(ctx, state.roles()),
 			s.applyTasks(ctx, state.tasks()),
 			s.applyTelegrafs(ctx, userID, state.telegrafConfigs()),
 		},
@@ -1486,6 +1588,7 @@ func (s *Service) applyState(ctx context.Context, coordinator *rollbackCoordinat
 	// secondary resources
 	// this last grouping relies on the above 2 steps having completely successfully
 	secondary := []applier{
+		s.applyBucketDownsampleRules(ctx, state.buckets()),
 		s.applyLabelMappings(ctx, state.labelMappings),
 		s.removeLabelMappings(ctx, state.labelMappingsToRemove),
 	}
@@ -1577,6 +1680,77 @@ func (s *Service) rollbackBuckets(ctx context.Context, buckets []*stateBucket) e
 	return nil
 }
 
+func (s *Service) applyBucketDownsampleRules(ctx context.Context, buckets []*stateBucket) applier {
+	const resource = "bucket_downsample_rules"
+
+	mutex := new(doMutex)
+	rollbackBuckets := make([]*stateBucket, 0, len(buckets))
+
+	createFn := func(ctx context.Context, i int, orgID, userID influxdb.ID) *applyErrBody {
+		var b *stateBucket
+		mutex.Do(func() {
+			b = buckets[i]
+		})
+		if !b.shouldApplyDownsampleRules() {
+			return nil
+		}
+
+		rules := b.parserBkt.DownsampleRules.toInfluxRules()
+		_, err := s.bucketSVC.UpdateBucket(ctx, b.ID(), influxdb.BucketUpdate{
+			DownsampleRules: &rules,
+		})
+		if err != nil {
+			return &applyErrBody{
+				name: b.parserBkt.PkgName(),
+				msg:  fmt.Sprintf("failed to update downsample rules of bucket[%q]: %s", b.ID(), err),
+			}
+		}
+
+		mutex.Do(func() {
+			rollbackBuckets = append(rollbackBuckets, b)
+		})
+
+		return nil
+	}
+
+	return applier{
+		creater: creater{
+			entries: len(buckets),
+			fn:      createFn,
+		},
+		rollbacker: rollbacker{
+			resource: resource,
+			fn:       func(_ influxdb.ID) error { return s.rollbackBucketDownsampleRules(ctx, rollbackBuckets) },
+		},
+	}
+}
+
+func (s *Service) rollbackBucketDownsampleRules(ctx context.Context, buckets []*stateBucket) error {
+	var errs []string
+	for _, b := range buckets {
+		// new buckets are removed along with their downsample rules
+		if b.existing == nil {
+			continue
+		}
+		rules := b.existing.DownsampleRules
+		if rules == nil {
+			rules = []influxdb.DownsampleRule{}
+		}
+		_, err := s.bucketSVC.UpdateBucket(ctx, b.ID(), influxdb.BucketUpdate{
+			DownsampleRules: &rules,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("error for bucket[%q]: %s", b.ID(), err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
 func (s *Service) applyBucket(ctx context.Context, b *stateBucket) (influxdb.Bucket, error) {
 	switch {
 	case IsRemoval(b.stateStatus):
@@ -2020,6 +2194,16 @@ func (s *Service) applyNotificationEndpoints(ctx context.Context, userID influxd
 							endpoints[i].parserEndpoint.password = new(references)
 						}
 						endpoints[i].parserEndpoint.password.Secret = secret.Key
+					case strings.HasSuffix(secret.Key, "-api-key"):
+						if endpoints[i].parserEndpoint.apiKey == nil {
+							endpoints[i].parserEndpoint.apiKey = new(references)
+						}
+						endpoints[i].parserEndpoint.apiKey.Secret = secret.Key
+					case strings.HasSuffix(secret.Key, "-url"):
+						if endpoints[i].parserEndpoint.secretURL == nil {
+							endpoints[i].parserEndpoint.secretURL = new(references)
+						}
+						endpoints[i].parserEndpoint.secretURL.Secret = secret.Key
 					}
 				}
 			}
@@ -2129,6 +2313,13 @@ func (s *Service) applyNotificationGenerator(ctx context.Context, userID influxd
 			continue
 		}
 		r.associatedEndpoint = v
+
+		if name, ok := r.associateEscalationEndpoints(mEndpoints); !ok {
+			errs = append(errs, &applyErrBody{
+				name: r.parserRule.PkgName(),
+				msg:  fmt.Sprintf("notification rule escalation endpoint dependency does not exist; endpointName=%q", name),
+			})
+		}
 	}
 
 	err = errs.toError("notification_rules", "failed to find dependency")
@@ -2246,6 +2437,14 @@ func (s *Service) rollbackNotificationRules(ctx context.Context, userID influxdb
 				rr.EndpointID = endpointID
 			case *rule.Slack:
 				rr.EndpointID = endpointID
+			case *rule.OpsGenie:
+				rr.EndpointID = endpointID
+			case *rule.Teams:
+				rr.EndpointID = endpointID
+			case *rule.Telegram:
+				rr.EndpointID = endpointID
+			case *rule.SMTP:
+				rr.EndpointID = endpointID
 			}
 			return r.existing
 		}
@@ -2341,6 +2540,125 @@ func (s *Service) applySecrets(secrets map[string]string) applier {
 	}
 }
 
+func (s *Service) applyRoles(ctx context.Context, roles []*stateRole) applier {
+	const resource = "role"
+
+	mutex := new(doMutex)
+	rollbackRoles := make([]*stateRole, 0, len(roles))
+
+	createFn := func(ctx context.Context, i int, orgID, userID influxdb.ID) *applyErrBody {
+		var r *stateRole
+		mutex.Do(func() {
+			roles[i].orgID = orgID
+			r = roles[i]
+		})
+		if !r.shouldApply() {
+			return nil
+		}
+
+		influxRole, err := s.applyRole(ctx, r)
+		if err != nil {
+			return &applyErrBody{
+				name: r.parserRole.PkgName(),
+				msg:  err.Error(),
+			}
+		}
+
+		mutex.Do(func() {
+			roles[i].id = influxRole.ID
+			rollbackRoles = append(rollbackRoles, roles[i])
+		})
+		return nil
+	}
+
+	return applier{
+		creater: creater{
+			entries: len(roles),
+			fn:      createFn,
+		},
+		rollbacker: rollbacker{
+			resource: resource,
+			fn:       func(_ influxdb.ID) error { return s.rollbackRoles(ctx, rollbackRoles) },
+		},
+	}
+}
+
+func (s *Service) rollbackRoles(ctx context.Context, roles []*stateRole) error {
+	rollbackFn := func(r *stateRole) error {
+		var err error
+		switch {
+		case IsRemoval(r.stateStatus):
+			if r.existing == nil {
+				return nil
+			}
+			err = ierrors.Wrap(s.roleSVC.CreateRole(ctx, r.existing), "rolling back removed role")
+		case IsExisting(r.stateStatus):
+			if r.existing == nil {
+				return nil
+			}
+			_, err = s.roleSVC.UpdateRole(ctx, r.ID(), influxdb.RoleUpdate{
+				Name:        &r.existing.Name,
+				Description: &r.existing.Description,
+				Permissions: r.existing.Permissions,
+			})
+			err = ierrors.Wrap(err, "rolling back updated role")
+		default:
+			err = ierrors.Wrap(s.roleSVC.DeleteRole(ctx, r.ID()), "rolling back created role")
+		}
+		return err
+	}
+
+	var errs []string
+	for _, r := range roles {
+		if err := rollbackFn(r); err != nil {
+			errs = append(errs, fmt.Sprintf("error for role[%q]: %s", r.ID(), err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return errors.New(strings.Join(errs, "; "))
+	}
+
+	return nil
+}
+
+func (s *Service) applyRole(ctx context.Context, r *stateRole) (influxdb.Role, error) {
+	switch {
+	case IsRemoval(r.stateStatus):
+		if err := s.roleSVC.DeleteRole(ctx, r.ID()); err != nil && influxdb.ErrorCode(err) != influxdb.ENotFound {
+			return influxdb.Role{}, ierrors.Wrap(err, "removing existing role")
+		}
+		if r.existing == nil {
+			return influxdb.Role{}, nil
+		}
+		return *r.existing, nil
+	case IsExisting(r.stateStatus) && r.existing != nil:
+		name, description := r.parserRole.Name(), r.parserRole.description
+		updatedRole, err := s.roleSVC.UpdateRole(ctx, r.ID(), influxdb.RoleUpdate{
+			Name:        &name,
+			Description: &description,
+			Permissions: r.parserRole.permissions.toInfluxPermissions(r.orgID),
+		})
+		if err != nil {
+			return influxdb.Role{}, ierrors.Wrap(err, "updating existing role")
+		}
+		return *updatedRole, nil
+	default:
+		// when an existing role (referenced in stack) has been deleted by a user
+		// then the resource is created anew to get it back to the expected state.
+		influxRole := influxdb.Role{
+			OrgID:       r.orgID,
+			Name:        r.parserRole.Name(),
+			Description: r.parserRole.description,
+			Permissions: r.parserRole.permissions.toInfluxPermissions(r.orgID),
+		}
+		if err := s.roleSVC.CreateRole(ctx, &influxRole); err != nil {
+			return influxdb.Role{}, ierrors.Wrap(err, "creating new role")
+		}
+		return influxRole, nil
+	}
+}
+
 func (s *Service) applyTasks(ctx context.Context, tasks []*stateTask) applier {
 	const resource = "tasks"
 
@@ -2987,6 +3305,17 @@ func (s *Service) updateStackAfterSuccess(ctx context.Context, stackID influxdb.
 			),
 		})
 	}
+	for _, r := range state.mRoles {
+		if IsRemoval(r.stateStatus) {
+			continue
+		}
+		stackResources = append(stackResources, StackResource{
+			APIVersion: APIVersion,
+			ID:         r.ID(),
+			Kind:       KindRole,
+			PkgName:    r.parserRole.PkgName(),
+		})
+	}
 	for _, t := range state.mTasks {
 		if IsRemoval(t.stateStatus) {
 			continue
@@ -3119,6 +3448,13 @@ func (s *Service) updateStackAfterRollback(ctx context.Context, stackID influxdb
 				res.Associations = newAss
 			}
 		}
+		for _, r := range state.mRoles {
+			res, ok := existingResources[newKey(KindRole, r.parserRole.PkgName())]
+			if ok && res.ID != r.ID() {
+				hasChanges = true
+				res.ID = r.existing.ID
+			}
+		}
 		for _, t := range state.mTasks {
 			res, ok := existingResources[newKey(KindTask, t.parserTask.PkgName())]
 			if ok && res.ID != t.ID() {
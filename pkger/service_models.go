@@ -5,6 +5,7 @@ import (
 	"sort"
 
 	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/notification"
 	"github.com/influxdata/influxdb/v2/notification/rule"
 )
 
@@ -14,6 +15,7 @@ type stateCoordinator struct {
 	mDashboards map[string]*stateDashboard
 	mEndpoints  map[string]*stateEndpoint
 	mLabels     map[string]*stateLabel
+	mRoles      map[string]*stateRole
 	mRules      map[string]*stateRule
 	mTasks      map[string]*stateTask
 	mTelegrafs  map[string]*stateTelegraf
@@ -30,6 +32,7 @@ func newStateCoordinator(pkg *Pkg) *stateCoordinator {
 		mDashboards: make(map[string]*stateDashboard),
 		mEndpoints:  make(map[string]*stateEndpoint),
 		mLabels:     make(map[string]*stateLabel),
+		mRoles:      make(map[string]*stateRole),
 		mRules:      make(map[string]*stateRule),
 		mTasks:      make(map[string]*stateTask),
 		mTelegrafs:  make(map[string]*stateTelegraf),
@@ -72,6 +75,12 @@ func newStateCoordinator(pkg *Pkg) *stateCoordinator {
 			stateStatus: StateStatusNew,
 		}
 	}
+	for _, pkgRole := range pkg.roles() {
+		state.mRoles[pkgRole.PkgName()] = &stateRole{
+			parserRole:  pkgRole,
+			stateStatus: StateStatusNew,
+		}
+	}
 	for _, pkgTask := range pkg.tasks() {
 		state.mTasks[pkgTask.PkgName()] = &stateTask{
 			parserTask:  pkgTask,
@@ -134,6 +143,14 @@ func (s *stateCoordinator) labels() []*stateLabel {
 	return out
 }
 
+func (s *stateCoordinator) roles() []*stateRole {
+	out := make([]*stateRole, 0, len(s.mRoles))
+	for _, r := range s.mRoles {
+		out = append(out, r)
+	}
+	return out
+}
+
 func (s *stateCoordinator) rules() []*stateRule {
 	out := make([]*stateRule, 0, len(s.mRules))
 	for _, r := range s.mRules {
@@ -210,6 +227,13 @@ func (s *stateCoordinator) diff() Diff {
 		return diff.NotificationRules[i].PkgName < diff.NotificationRules[j].PkgName
 	})
 
+	for _, r := range s.mRoles {
+		diff.Roles = append(diff.Roles, r.diffRole())
+	}
+	sort.Slice(diff.Roles, func(i, j int) bool {
+		return diff.Roles[i].PkgName < diff.Roles[j].PkgName
+	})
+
 	for _, t := range s.mTasks {
 		diff.Tasks = append(diff.Tasks, t.diffTask())
 	}
@@ -320,6 +344,16 @@ func (s *stateCoordinator) summary() Summary {
 		return sum.NotificationRules[i].PkgName < sum.NotificationRules[j].PkgName
 	})
 
+	for _, r := range s.mRoles {
+		if IsRemoval(r.stateStatus) {
+			continue
+		}
+		sum.Roles = append(sum.Roles, r.summarize())
+	}
+	sort.Slice(sum.Roles, func(i, j int) bool {
+		return sum.Roles[i].PkgName < sum.Roles[j].PkgName
+	})
+
 	for _, t := range s.mTasks {
 		if IsRemoval(t.stateStatus) {
 			continue
@@ -456,7 +490,7 @@ func (s *stateCoordinator) get(k Kind, pkgName string) (interface{}, bool) {
 	case KindBucket:
 		v, ok := s.mBuckets[pkgName]
 		return v, ok
-	case KindCheck, KindCheckDeadman, KindCheckThreshold:
+	case KindCheck, KindCheckAnomaly, KindCheckDeadman, KindCheckThreshold:
 		v, ok := s.mChecks[pkgName]
 		return v, ok
 	case KindDashboard:
@@ -467,13 +501,20 @@ func (s *stateCoordinator) get(k Kind, pkgName string) (interface{}, bool) {
 		return v, ok
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
+		KindNotificationEndpointOpsGenie,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointSMTP,
+		KindNotificationEndpointTeams,
+		KindNotificationEndpointTelegram:
 		v, ok := s.mEndpoints[pkgName]
 		return v, ok
 	case KindNotificationRule:
 		v, ok := s.mRules[pkgName]
 		return v, ok
+	case KindRole:
+		v, ok := s.mRoles[pkgName]
+		return v, ok
 	case KindTask:
 		v, ok := s.mTasks[pkgName]
 		return v, ok
@@ -536,7 +577,7 @@ func (s *stateCoordinator) addObjectForRemoval(k Kind, pkgName string, id influx
 			parserBkt:   &bucket{identity: newIdentity},
 			stateStatus: StateStatusRemove,
 		}
-	case KindCheck, KindCheckDeadman, KindCheckThreshold:
+	case KindCheck, KindCheckAnomaly, KindCheckDeadman, KindCheckThreshold:
 		s.mChecks[pkgName] = &stateCheck{
 			id:          id,
 			parserCheck: &check{identity: newIdentity},
@@ -556,8 +597,12 @@ func (s *stateCoordinator) addObjectForRemoval(k Kind, pkgName string, id influx
 		}
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
+		KindNotificationEndpointOpsGenie,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointSMTP,
+		KindNotificationEndpointTeams,
+		KindNotificationEndpointTelegram:
 		s.mEndpoints[pkgName] = &stateEndpoint{
 			id:             id,
 			parserEndpoint: &notificationEndpoint{identity: newIdentity},
@@ -569,6 +614,12 @@ func (s *stateCoordinator) addObjectForRemoval(k Kind, pkgName string, id influx
 			parserRule:  &notificationRule{identity: newIdentity},
 			stateStatus: StateStatusRemove,
 		}
+	case KindRole:
+		s.mRoles[pkgName] = &stateRole{
+			id:          id,
+			parserRole:  &role{identity: newIdentity},
+			stateStatus: StateStatusRemove,
+		}
 	case KindTask:
 		s.mTasks[pkgName] = &stateTask{
 			id:          id,
@@ -598,7 +649,7 @@ func (s *stateCoordinator) getObjectIDSetter(k Kind, pkgName string) (func(influ
 			r.id = id
 			r.stateStatus = StateStatusExists
 		}, ok
-	case KindCheck, KindCheckDeadman, KindCheckThreshold:
+	case KindCheck, KindCheckAnomaly, KindCheckDeadman, KindCheckThreshold:
 		r, ok := s.mChecks[pkgName]
 		return func(id influxdb.ID) {
 			r.id = id
@@ -618,8 +669,12 @@ func (s *stateCoordinator) getObjectIDSetter(k Kind, pkgName string) (func(influ
 		}, ok
 	case KindNotificationEndpoint,
 		KindNotificationEndpointHTTP,
+		KindNotificationEndpointOpsGenie,
 		KindNotificationEndpointPagerDuty,
-		KindNotificationEndpointSlack:
+		KindNotificationEndpointSlack,
+		KindNotificationEndpointSMTP,
+		KindNotificationEndpointTeams,
+		KindNotificationEndpointTelegram:
 		r, ok := s.mEndpoints[pkgName]
 		return func(id influxdb.ID) {
 			r.id = id
@@ -631,6 +686,12 @@ func (s *stateCoordinator) getObjectIDSetter(k Kind, pkgName string) (func(influ
 			r.id = id
 			r.stateStatus = StateStatusExists
 		}, ok
+	case KindRole:
+		r, ok := s.mRoles[pkgName]
+		return func(id influxdb.ID) {
+			r.id = id
+			r.stateStatus = StateStatusExists
+		}, ok
 	case KindTask:
 		r, ok := s.mTasks[pkgName]
 		return func(id influxdb.ID) {
@@ -683,9 +744,10 @@ func (b *stateBucket) diffBucket() DiffBucket {
 			PkgName:     b.parserBkt.PkgName(),
 		},
 		New: DiffBucketValues{
-			Name:           b.parserBkt.Name(),
-			Description:    b.parserBkt.Description,
-			RetentionRules: b.parserBkt.RetentionRules,
+			Name:            b.parserBkt.Name(),
+			Description:     b.parserBkt.Description,
+			RetentionRules:  b.parserBkt.RetentionRules,
+			DownsampleRules: b.parserBkt.DownsampleRules,
 		},
 	}
 	if e := b.existing; e != nil {
@@ -696,6 +758,7 @@ func (b *stateBucket) diffBucket() DiffBucket {
 		if e.RetentionPeriod > 0 {
 			diff.Old.RetentionRules = retentionRules{newRetentionRule(e.RetentionPeriod)}
 		}
+		diff.Old.DownsampleRules = newDownsampleRules(e.DownsampleRules)
 	}
 	return diff
 }
@@ -740,6 +803,19 @@ func (b *stateBucket) shouldApply() bool {
 		b.parserBkt.RetentionRules.RP() != b.existing.RetentionPeriod
 }
 
+// shouldApplyDownsampleRules returns true if the downsample rules of the bucket
+// differ from those of the existing bucket. The rules are applied once all
+// buckets exist, as they may write to buckets of the same pkg.
+func (b *stateBucket) shouldApplyDownsampleRules() bool {
+	if IsRemoval(b.stateStatus) {
+		return false
+	}
+	if b.existing == nil {
+		return len(b.parserBkt.DownsampleRules) > 0
+	}
+	return !reflect.DeepEqual(b.parserBkt.DownsampleRules, newDownsampleRules(b.existing.DownsampleRules))
+}
+
 type stateCheck struct {
 	id, orgID   influxdb.ID
 	stateStatus StateStatus
@@ -1104,7 +1180,8 @@ type stateRule struct {
 	id, orgID   influxdb.ID
 	stateStatus StateStatus
 
-	associatedEndpoint *stateEndpoint
+	associatedEndpoint  *stateEndpoint
+	escalationEndpoints []*stateEndpoint
 
 	parserRule *notificationRule
 	existing   influxdb.NotificationRule
@@ -1145,6 +1222,7 @@ func (r *stateRule) diffRule() DiffNotificationRule {
 			MessageTemplate: r.parserRule.msgTemplate,
 			StatusRules:     toSummaryStatusRules(r.parserRule.statusRules),
 			TagRules:        toSummaryTagRules(r.parserRule.tagRules),
+			Escalations:     r.parserRule.summarizeEscalations(r.escalationEndpointID),
 		},
 	}
 
@@ -1181,6 +1259,16 @@ func (r *stateRule) diffRule() DiffNotificationRule {
 			}
 			sum.Old.StatusRules = append(sum.Old.StatusRules, sRule)
 		}
+		for _, step := range b.Escalations {
+			sStep := SummaryEscalationStep{EndpointID: SafeID(step.EndpointID)}
+			if step.Delay != nil {
+				sStep.Delay = step.Delay.TimeDuration().String()
+			}
+			if step.RepeatEvery != nil {
+				sStep.RepeatEvery = step.RepeatEvery.TimeDuration().String()
+			}
+			sum.Old.Escalations = append(sum.Old.Escalations, sStep)
+		}
 	}
 
 	switch p := r.existing.(type) {
@@ -1192,6 +1280,18 @@ func (r *stateRule) diffRule() DiffNotificationRule {
 	case *rule.PagerDuty:
 		assignBase(p.Base)
 		sum.Old.MessageTemplate = p.MessageTemplate
+	case *rule.OpsGenie:
+		assignBase(p.Base)
+		sum.Old.MessageTemplate = p.MessageTemplate
+	case *rule.Teams:
+		assignBase(p.Base)
+		sum.Old.MessageTemplate = p.MessageTemplate
+	case *rule.Telegram:
+		assignBase(p.Base)
+		sum.Old.MessageTemplate = p.MessageTemplate
+	case *rule.SMTP:
+		assignBase(p.Base)
+		sum.Old.MessageTemplate = p.BodyTemplate
 	}
 
 	return sum
@@ -1204,6 +1304,35 @@ func (r *stateRule) endpointID() influxdb.ID {
 	return 0
 }
 
+// associateEscalationEndpoints associates the endpoints of the escalation
+// steps of the rule. It returns the name of the first endpoint that is not
+// found.
+func (r *stateRule) associateEscalationEndpoints(endpoints map[string]*stateEndpoint) (string, bool) {
+	r.escalationEndpoints = nil
+	for _, e := range r.parserRule.escalations {
+		v, ok := endpoints[e.endpointPkgName()]
+		if !ok {
+			return e.endpointName.String(), false
+		}
+		r.escalationEndpoints = append(r.escalationEndpoints, v)
+	}
+	return "", true
+}
+
+func (r *stateRule) escalationEndpointID(i int) influxdb.ID {
+	if i < len(r.escalationEndpoints) {
+		return r.escalationEndpoints[i].ID()
+	}
+	return 0
+}
+
+// setEscalationEndpointIDs sets the endpoint IDs of the escalation steps.
+func (r *stateRule) setEscalationEndpointIDs(steps []notification.EscalationStep) {
+	for i := range steps {
+		steps[i].EndpointID = r.escalationEndpointID(i)
+	}
+}
+
 func (r *stateRule) endpointPkgName() string {
 	if r.associatedEndpoint != nil && r.associatedEndpoint.parserEndpoint != nil {
 		return r.associatedEndpoint.parserEndpoint.PkgName()
@@ -1242,6 +1371,7 @@ func (r *stateRule) summarize() SummaryNotificationRule {
 	sum.EndpointID = SafeID(r.associatedEndpoint.ID())
 	sum.EndpointPkgName = r.associatedEndpoint.parserEndpoint.PkgName()
 	sum.EndpointType = r.associatedEndpoint.parserEndpoint.kind.String()
+	sum.Escalations = r.parserRule.summarizeEscalations(r.escalationEndpointID)
 	return sum
 }
 
@@ -1256,15 +1386,84 @@ func (r *stateRule) toInfluxRule() influxdb.NotificationRule {
 	switch e := influxRule.(type) {
 	case *rule.HTTP:
 		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
 	case *rule.PagerDuty:
 		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
 	case *rule.Slack:
 		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
+	case *rule.OpsGenie:
+		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
+	case *rule.Teams:
+		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
+	case *rule.Telegram:
+		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
+	case *rule.SMTP:
+		e.EndpointID = r.associatedEndpoint.ID()
+		r.setEscalationEndpointIDs(e.Escalations)
 	}
 
 	return influxRule
 }
 
+type stateRole struct {
+	id, orgID   influxdb.ID
+	stateStatus StateStatus
+
+	parserRole *role
+	existing   *influxdb.Role
+}
+
+func (r *stateRole) ID() influxdb.ID {
+	if !IsNew(r.stateStatus) && r.existing != nil {
+		return r.existing.ID
+	}
+	return r.id
+}
+
+func (r *stateRole) diffRole() DiffRole {
+	diff := DiffRole{
+		DiffIdentifier: DiffIdentifier{
+			ID:          SafeID(r.ID()),
+			Remove:      IsRemoval(r.stateStatus),
+			StateStatus: r.stateStatus,
+			PkgName:     r.parserRole.PkgName(),
+		},
+		New: DiffRoleValues{
+			Name:        r.parserRole.Name(),
+			Description: r.parserRole.description,
+			Permissions: r.parserRole.permissions,
+		},
+	}
+	if e := r.existing; e != nil {
+		diff.Old = &DiffRoleValues{
+			Name:        e.Name,
+			Description: e.Description,
+			Permissions: newRolePermissions(e.Permissions),
+		}
+	}
+	return diff
+}
+
+func (r *stateRole) shouldApply() bool {
+	return IsRemoval(r.stateStatus) ||
+		r.existing == nil ||
+		r.existing.Name != r.parserRole.Name() ||
+		r.existing.Description != r.parserRole.description ||
+		!reflect.DeepEqual(r.existing.Permissions, r.parserRole.permissions.toInfluxPermissions(r.orgID))
+}
+
+func (r *stateRole) summarize() SummaryRole {
+	sum := r.parserRole.summarize()
+	sum.ID = SafeID(r.ID())
+	sum.OrgID = SafeID(r.orgID)
+	return sum
+}
+
 type stateTask struct {
 	id, orgID   influxdb.ID
 	stateStatus StateStatus
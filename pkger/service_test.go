@@ -32,6 +32,7 @@ func TestService(t *testing.T) {
 			labelSVC:    mock.NewLabelService(),
 			endpointSVC: mock.NewNotificationEndpointService(),
 			orgSVC:      mock.NewOrganizationService(),
+			roleSVC:     mock.NewRoleService(),
 			ruleSVC:     mock.NewNotificationRuleStore(),
 			store: &fakeStore{
 				createFn: func(ctx context.Context, stack Stack) error {
@@ -61,6 +62,7 @@ func TestService(t *testing.T) {
 			WithNotificationEndpointSVC(opt.endpointSVC),
 			WithNotificationRuleSVC(opt.ruleSVC),
 			WithOrganizationService(opt.orgSVC),
+			WithRoleSVC(opt.roleSVC),
 			WithSecretSVC(opt.secretSVC),
 			WithTaskSVC(opt.taskSVC),
 			WithTelegrafSVC(opt.teleSVC),
@@ -175,7 +177,7 @@ func TestService(t *testing.T) {
 				require.NoError(t, err)
 
 				checks := impact.Diff.Checks
-				require.Len(t, checks, 2)
+				require.Len(t, checks, 3)
 				check0 := checks[0]
 				assert.True(t, check0.IsNew())
 				assert.Equal(t, "check-0", check0.PkgName)
@@ -188,6 +190,10 @@ func TestService(t *testing.T) {
 				assert.Equal(t, "display name", check1.New.GetName())
 				assert.NotZero(t, check1.ID)
 				assert.Equal(t, existing, check1.Old.Check)
+
+				check2 := checks[2]
+				assert.True(t, check2.IsNew())
+				assert.Equal(t, "check-2", check2.PkgName)
 			})
 		})
 
@@ -302,7 +308,7 @@ func TestService(t *testing.T) {
 				impact, err := svc.DryRun(context.TODO(), influxdb.ID(100), 0, pkg)
 				require.NoError(t, err)
 
-				require.Len(t, impact.Diff.NotificationEndpoints, 5)
+				require.Len(t, impact.Diff.NotificationEndpoints, 9)
 
 				var (
 					newEndpoints      []DiffNotificationEndpoint
@@ -315,7 +321,7 @@ func TestService(t *testing.T) {
 					}
 					newEndpoints = append(newEndpoints, e)
 				}
-				require.Len(t, newEndpoints, 4)
+				require.Len(t, newEndpoints, 8)
 				require.Len(t, existingEndpoints, 1)
 
 				expected := DiffNotificationEndpoint{
@@ -607,7 +613,7 @@ func TestService(t *testing.T) {
 					require.NoError(t, err)
 
 					sum := impact.Summary
-					require.Len(t, sum.Checks, 2)
+					require.Len(t, sum.Checks, 3)
 
 					containsWithID := func(t *testing.T, name string) {
 						t.Helper()
@@ -624,7 +630,7 @@ func TestService(t *testing.T) {
 						assert.Fail(t, "did not find notification by name: "+name)
 					}
 
-					for _, expectedName := range []string{"check-0", "display name"} {
+					for _, expectedName := range []string{"check-0", "display name", "check-2"} {
 						containsWithID(t, expectedName)
 					}
 				})
@@ -990,7 +996,7 @@ func TestService(t *testing.T) {
 				}
 
 				t.Run("applies successfully", func(t *testing.T) {
-					testLabelMappingApplyFn(t, "testdata/checks.yml", 2, opts)
+					testLabelMappingApplyFn(t, "testdata/checks.yml", 3, opts)
 				})
 
 				t.Run("deletes new label mappings on error", func(t *testing.T) {
@@ -1028,7 +1034,7 @@ func TestService(t *testing.T) {
 				}
 
 				t.Run("applies successfully", func(t *testing.T) {
-					testLabelMappingApplyFn(t, "testdata/notification_endpoint.yml", 5, opts)
+					testLabelMappingApplyFn(t, "testdata/notification_endpoint.yml", 9, opts)
 				})
 
 				t.Run("deletes new label mappings on error", func(t *testing.T) {
@@ -1145,7 +1151,7 @@ func TestService(t *testing.T) {
 					require.NoError(t, err)
 
 					sum := impact.Summary
-					require.Len(t, sum.NotificationEndpoints, 5)
+					require.Len(t, sum.NotificationEndpoints, 9)
 
 					containsWithID := func(t *testing.T, name string) {
 						var endpoints []string
@@ -1166,8 +1172,12 @@ func TestService(t *testing.T) {
 						"basic endpoint name",
 						"http-bearer-auth-notification-endpoint",
 						"http-none-auth-notification-endpoint",
+						"opsgenie name",
 						"pager duty name",
 						"slack name",
+						"smtp name",
+						"teams name",
+						"telegram name",
 					}
 					for _, expectedName := range expectedNames {
 						containsWithID(t, expectedName)
@@ -1233,6 +1243,47 @@ func TestService(t *testing.T) {
 				})
 			})
 
+			t.Run("successfully creates with escalations", func(t *testing.T) {
+				testfileRunner(t, "testdata/notification_rule_escalations.yml", func(t *testing.T, pkg *Pkg) {
+					endpointIDs := map[string]influxdb.ID{
+						"endpoint-0": 1,
+						"endpoint-1": 2,
+						"endpoint-2": 3,
+					}
+					fakeEndpointSVC := mock.NewNotificationEndpointService()
+					fakeEndpointSVC.CreateNotificationEndpointF = func(ctx context.Context, nr influxdb.NotificationEndpoint, userID influxdb.ID) error {
+						nr.SetID(endpointIDs[nr.GetName()])
+						return nil
+					}
+					var created influxdb.NotificationRule
+					fakeRuleStore := mock.NewNotificationRuleStore()
+					fakeRuleStore.CreateNotificationRuleF = func(ctx context.Context, nr influxdb.NotificationRuleCreate, userID influxdb.ID) error {
+						nr.SetID(influxdb.ID(fakeRuleStore.CreateNotificationRuleCalls.Count() + 1))
+						created = nr.NotificationRule
+						return nil
+					}
+
+					svc := newTestService(
+						WithNotificationEndpointSVC(fakeEndpointSVC),
+						WithNotificationRuleSVC(fakeRuleStore),
+					)
+
+					impact, err := svc.Apply(context.TODO(), influxdb.ID(9000), 0, pkg)
+					require.NoError(t, err)
+
+					require.NotNil(t, created)
+					assert.Equal(t, []influxdb.ID{2, 3}, created.GetEscalationEndpointIDs())
+
+					sum := impact.Summary
+					require.Len(t, sum.NotificationRules, 1)
+					expected := []SummaryEscalationStep{
+						{EndpointID: 2, EndpointPkgName: "endpoint-1", Delay: (15 * time.Minute).String()},
+						{EndpointID: 3, EndpointPkgName: "endpoint-2", Delay: time.Hour.String(), RepeatEvery: time.Hour.String()},
+					}
+					assert.Equal(t, expected, sum.NotificationRules[0].Escalations)
+				})
+			})
+
 			t.Run("rolls back all created notification rules on an error", func(t *testing.T) {
 				testfileRunner(t, "testdata/notification_rule.yml", func(t *testing.T, pkg *Pkg) {
 					fakeRuleStore := mock.NewNotificationRuleStore()
@@ -1270,6 +1321,79 @@ func TestService(t *testing.T) {
 			})
 		})
 
+		t.Run("roles", func(t *testing.T) {
+			t.Run("successfully creates", func(t *testing.T) {
+				testfileRunner(t, "testdata/role", func(t *testing.T, pkg *Pkg) {
+					orgID := influxdb.ID(9000)
+
+					fakeRoleSVC := mock.NewRoleService()
+					fakeRoleSVC.FindRolesFn = func(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+						return nil, 0, nil
+					}
+					created := make(map[string]influxdb.Role)
+					fakeRoleSVC.CreateRoleFn = func(ctx context.Context, r *influxdb.Role) error {
+						r.ID = influxdb.ID(len(created) + 1)
+						created[r.Name] = *r
+						return nil
+					}
+
+					svc := newTestService(WithRoleSVC(fakeRoleSVC))
+
+					impact, err := svc.Apply(context.TODO(), orgID, 0, pkg)
+					require.NoError(t, err)
+
+					sum := impact.Summary
+					require.Len(t, sum.Roles, 2)
+					for _, r := range sum.Roles {
+						assert.Containsf(t, []SafeID{1, 2}, r.ID, "actual role: %+v", r)
+						assert.Equal(t, SafeID(orgID), r.OrgID)
+					}
+
+					dashID := influxdb.ID(0x020f755c3c082000)
+					expected := []influxdb.Permission{
+						{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType, OrgID: &orgID}},
+						{Action: influxdb.WriteAction, Resource: influxdb.Resource{Type: influxdb.DashboardsResourceType, OrgID: &orgID, ID: &dashID}},
+					}
+					assert.Equal(t, expected, created["display name"].Permissions)
+
+					expected = []influxdb.Permission{
+						{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.OrgsResourceType, ID: &orgID}},
+					}
+					assert.Equal(t, expected, created["role-2"].Permissions)
+				})
+			})
+
+			t.Run("rolls back all created roles on an error", func(t *testing.T) {
+				testfileRunner(t, "testdata/role", func(t *testing.T, pkg *Pkg) {
+					fakeRoleSVC := mock.NewRoleService()
+					fakeRoleSVC.FindRolesFn = func(ctx context.Context, filter influxdb.RoleFilter, opt ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+						return nil, 0, nil
+					}
+					var creates int
+					fakeRoleSVC.CreateRoleFn = func(ctx context.Context, r *influxdb.Role) error {
+						creates++
+						if creates == 2 {
+							return errors.New("blowed up ")
+						}
+						r.ID = influxdb.ID(creates)
+						return nil
+					}
+					var deletes int
+					fakeRoleSVC.DeleteRoleFn = func(ctx context.Context, id influxdb.ID) error {
+						deletes++
+						return nil
+					}
+
+					svc := newTestService(WithRoleSVC(fakeRoleSVC))
+
+					_, err := svc.Apply(context.TODO(), influxdb.ID(9000), 0, pkg)
+					require.Error(t, err)
+
+					assert.Equal(t, 1, deletes)
+				})
+			})
+		})
+
 		t.Run("tasks", func(t *testing.T) {
 			t.Run("successfuly creates", func(t *testing.T) {
 				testfileRunner(t, "testdata/tasks.yml", func(t *testing.T, pkg *Pkg) {
@@ -1650,6 +1774,18 @@ func TestService(t *testing.T) {
 							Level:      notification.Critical,
 						},
 					},
+					{
+						name: "anomaly",
+						expected: &icheck.Anomaly{
+							Base:   newThresholdBase(2),
+							Method: icheck.AnomalyMAD,
+							Window: mustDuration(t, time.Hour),
+							Levels: []icheck.AnomalyLevel{
+								{Level: notification.Warn, Sensitivity: 2},
+								{Level: notification.Critical, Sensitivity: 3.5},
+							},
+						},
+					},
 				}
 
 				for _, tt := range tests {
@@ -1686,6 +1822,7 @@ func TestService(t *testing.T) {
 							expectedName = tt.newName
 						}
 						assert.Equal(t, expectedName, actual.GetName())
+						assert.Equal(t, tt.expected.Type(), actual.Type())
 					}
 					t.Run(tt.name, fn)
 				}
@@ -2227,6 +2364,40 @@ func TestService(t *testing.T) {
 							Token: influxdb.SecretField{Key: "tokne"},
 						},
 					},
+					{
+						name: "opsgenie",
+						expected: &endpoint.OpsGenie{
+							Base: endpoint.Base{
+								Name:        "og-endpoint",
+								Description: "desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							URL:    "http://example.com",
+							APIKey: influxdb.SecretField{Key: "-api-key"},
+						},
+					},
+					{
+						name: "teams",
+						expected: &endpoint.Teams{
+							Base: endpoint.Base{
+								Name:        "teams-endpoint",
+								Description: "desc",
+								Status:      influxdb.TaskStatusActive,
+							},
+							URL: influxdb.SecretField{Key: "-url"},
+						},
+					},
+					{
+						name: "telegram",
+						expected: &endpoint.Telegram{
+							Base: endpoint.Base{
+								Name:        "telegram-endpoint",
+								Description: "desc",
+								Status:      influxdb.TaskStatusInactive,
+							},
+							Token: influxdb.SecretField{Key: "-token"},
+						},
+					},
 					{
 						name: "http basic",
 						expected: &endpoint.HTTP{
@@ -2392,6 +2563,78 @@ func TestService(t *testing.T) {
 								Base: newRuleBase(13),
 							},
 						},
+						{
+							name: "opsgenie",
+							endpoint: &endpoint.OpsGenie{
+								Base: endpoint.Base{
+									ID:          newTestIDPtr(13),
+									Name:        "endpoint_0",
+									Description: "desc",
+									Status:      influxdb.TaskStatusActive,
+								},
+								APIKey: influxdb.SecretField{Key: "-api-key"},
+							},
+							rule: &rule.OpsGenie{
+								Base:            newRuleBase(13),
+								MessageTemplate: "Template",
+								Tags:            []string{"influxdb", "prod"},
+							},
+						},
+						{
+							name: "teams",
+							endpoint: &endpoint.Teams{
+								Base: endpoint.Base{
+									ID:          newTestIDPtr(13),
+									Name:        "endpoint_0",
+									Description: "desc",
+									Status:      influxdb.TaskStatusActive,
+								},
+								URL: influxdb.SecretField{Key: "-url"},
+							},
+							rule: &rule.Teams{
+								Base:            newRuleBase(13),
+								Title:           "Title",
+								MessageTemplate: "Template",
+							},
+						},
+						{
+							name: "telegram",
+							endpoint: &endpoint.Telegram{
+								Base: endpoint.Base{
+									ID:          newTestIDPtr(13),
+									Name:        "endpoint_0",
+									Description: "desc",
+									Status:      influxdb.TaskStatusActive,
+								},
+								Token: influxdb.SecretField{Key: "-token"},
+							},
+							rule: &rule.Telegram{
+								Base:                  newRuleBase(13),
+								Channel:               "-12345",
+								MessageTemplate:       "Template",
+								ParseMode:             "HTML",
+								DisableWebPagePreview: true,
+							},
+						},
+						{
+							name: "smtp",
+							endpoint: &endpoint.SMTP{
+								Base: endpoint.Base{
+									ID:          newTestIDPtr(13),
+									Name:        "endpoint_0",
+									Description: "desc",
+									Status:      influxdb.TaskStatusActive,
+								},
+								Host: "smtp.example.com:587",
+								From: "influxdb@example.com",
+							},
+							rule: &rule.SMTP{
+								Base:            newRuleBase(13),
+								SubjectTemplate: "Subject",
+								BodyTemplate:    "Template",
+								To:              "oncall@example.com",
+							},
+						},
 					}
 
 					for _, tt := range tests {
@@ -2469,6 +2712,29 @@ func TestService(t *testing.T) {
 							case *rule.Slack:
 								baseEqual(t, p.Base)
 								assert.Equal(t, p.MessageTemplate, actualRule.MessageTemplate)
+							case *rule.OpsGenie:
+								baseEqual(t, p.Base)
+								assert.Equal(t, p.MessageTemplate, actualRule.MessageTemplate)
+								actual := newPkg.notificationRules()[0].toInfluxRule().(*rule.OpsGenie)
+								assert.Equal(t, p.Tags, actual.Tags)
+							case *rule.Teams:
+								baseEqual(t, p.Base)
+								assert.Equal(t, p.MessageTemplate, actualRule.MessageTemplate)
+								actual := newPkg.notificationRules()[0].toInfluxRule().(*rule.Teams)
+								assert.Equal(t, p.Title, actual.Title)
+							case *rule.Telegram:
+								baseEqual(t, p.Base)
+								assert.Equal(t, p.MessageTemplate, actualRule.MessageTemplate)
+								actual := newPkg.notificationRules()[0].toInfluxRule().(*rule.Telegram)
+								assert.Equal(t, p.Channel, actual.Channel)
+								assert.Equal(t, p.ParseMode, actual.ParseMode)
+								assert.Equal(t, p.DisableWebPagePreview, actual.DisableWebPagePreview)
+							case *rule.SMTP:
+								baseEqual(t, p.Base)
+								assert.Equal(t, p.BodyTemplate, actualRule.MessageTemplate)
+								actual := newPkg.notificationRules()[0].toInfluxRule().(*rule.SMTP)
+								assert.Equal(t, p.SubjectTemplate, actual.SubjectTemplate)
+								assert.Equal(t, p.To, actual.To)
 							}
 
 							require.Len(t, pkg.Summary().NotificationEndpoints, 1)
@@ -3100,6 +3366,27 @@ func TestService(t *testing.T) {
 				return &influxdb.Variable{ID: 4, Name: "variable"}, nil
 			}
 
+			roleSVC := mock.NewRoleService()
+			roleSVC.FindRolesFn = func(_ context.Context, f influxdb.RoleFilter, _ ...influxdb.FindOptions) ([]*influxdb.Role, int, error) {
+				if f.OrgID == nil || *f.OrgID != orgID {
+					return nil, 0, errors.New("not suppose to get here")
+				}
+				return []*influxdb.Role{{ID: 5, Name: "role"}}, 1, nil
+			}
+			roleSVC.FindRoleByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Role, error) {
+				if id != 5 {
+					return nil, errors.New("wrong id")
+				}
+				return &influxdb.Role{
+					ID:    5,
+					OrgID: orgID,
+					Name:  "role",
+					Permissions: []influxdb.Permission{
+						{Action: influxdb.ReadAction, Resource: influxdb.Resource{Type: influxdb.BucketsResourceType, OrgID: &orgID}},
+					},
+				}, nil
+			}
+
 			svc := newTestService(
 				WithBucketSVC(bktSVC),
 				WithCheckSVC(checkSVC),
@@ -3107,6 +3394,7 @@ func TestService(t *testing.T) {
 				WithLabelSVC(labelSVC),
 				WithNotificationEndpointSVC(endpointSVC),
 				WithNotificationRuleSVC(ruleSVC),
+				WithRoleSVC(roleSVC),
 				WithTaskSVC(taskSVC),
 				WithVariableSVC(varSVC),
 			)
@@ -3145,6 +3433,11 @@ func TestService(t *testing.T) {
 			assert.Equal(t, expectedRule.Name, rules[0].Name)
 			assert.NotEmpty(t, rules[0].EndpointPkgName)
 
+			roles := summary.Roles
+			require.Len(t, roles, 1)
+			assert.Equal(t, "role", roles[0].Name)
+			assert.Equal(t, rolePermissions{{Action: "read", ResourceType: "buckets"}}, roles[0].Permissions)
+
 			require.Len(t, summary.Tasks, 1)
 			task1 := summary.Tasks[0]
 			assert.Equal(t, "task_0", task1.Name)
@@ -109,6 +109,20 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 	return s.AuthorizationService.DeleteAuthorization(ctx, id)
 }
 
+// RotateAuthorization issues a new token for an authorization, records function call latency, and counts function calls.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (a *platform.Authorization, err error) {
+	defer func(start time.Time) {
+		labels := prometheus.Labels{
+			"method": "RotateAuthorization",
+			"error":  fmt.Sprint(err != nil),
+		}
+		s.requestCount.With(labels).Add(1)
+		s.requestDuration.With(labels).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	return s.AuthorizationService.RotateAuthorization(ctx, id, grace)
+}
+
 // UpdateAuthorization updates the status and description.
 func (s *AuthorizationService) UpdateAuthorization(ctx context.Context, id platform.ID, upd *platform.AuthorizationUpdate) (a *platform.Authorization, err error) {
 	defer func(start time.Time) {
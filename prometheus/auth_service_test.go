@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	platform "github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/kit/prom"
@@ -43,6 +44,10 @@ func (a *authzSvc) UpdateAuthorization(context.Context, platform.ID, *platform.A
 	return nil, a.Err
 }
 
+func (a *authzSvc) RotateAuthorization(context.Context, platform.ID, time.Duration) (*platform.Authorization, error) {
+	return nil, a.Err
+}
+
 func TestAuthorizationService_Metrics(t *testing.T) {
 	a := new(authzSvc)
 
@@ -0,0 +1,3 @@
+package remote
+
+//go:generate protoc -I ../../internal -I . --plugin ../../scripts/protoc-gen-gogofaster --gogofaster_out=. remote.proto
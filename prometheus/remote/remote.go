@@ -0,0 +1,243 @@
+// Package remote implements the Prometheus remote storage protocol on top of
+// the storage engine.
+//
+// Samples of a Prometheus metric are written to the measurement named after
+// the metric, in the field named value. The other labels of the time series
+// are written as tags.
+package remote
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/v2/tsdb"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+)
+
+const (
+	// MetricNameLabel is the label holding the name of a Prometheus metric.
+	MetricNameLabel = "__name__"
+
+	// FieldName is the field the values of the samples are written to.
+	FieldName = "value"
+)
+
+// Points returns the points of the samples of the write request for the
+// bucket. Samples with a NaN or infinite value cannot be stored and are
+// skipped.
+func Points(req *WriteRequest, orgID, bucketID influxdb.ID) ([]models.Point, error) {
+	var points []models.Point
+	for _, ts := range req.Timeseries {
+		var (
+			name string
+			tags = make(models.Tags, 0, len(ts.Labels))
+		)
+		for _, l := range ts.Labels {
+			switch {
+			case l.Name == MetricNameLabel:
+				name = l.Value
+			case l.Value != "":
+				tags = append(tags, models.NewTag([]byte(l.Name), []byte(l.Value)))
+			}
+		}
+		if name == "" {
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  "time series is missing the " + MetricNameLabel + " label",
+			}
+		}
+		sort.Sort(tags)
+
+		for _, s := range ts.Samples {
+			if math.IsNaN(s.Value) || math.IsInf(s.Value, 0) {
+				continue
+			}
+			pt, err := models.NewPoint(name, tags, models.Fields{FieldName: s.Value}, time.Unix(0, s.Timestamp*int64(time.Millisecond)))
+			if err != nil {
+				return nil, &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "invalid sample of metric " + name,
+					Err:  err,
+				}
+			}
+			points = append(points, pt)
+		}
+	}
+	return tsdb.ExplodePoints(orgID, bucketID, points)
+}
+
+// ReadFilterRequest returns the storage request reading the series of the
+// bucket source that match the query.
+func ReadFilterRequest(q *Query, source *types.Any) (*datatypes.ReadFilterRequest, error) {
+	pred, err := predicate(q.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	return &datatypes.ReadFilterRequest{
+		ReadSource: source,
+		Range: datatypes.TimestampRange{
+			Start: q.StartTimestampMs * int64(time.Millisecond),
+			// The end of the query is inclusive, the end of the range is not.
+			End: q.EndTimestampMs*int64(time.Millisecond) + 1,
+		},
+		Predicate: pred,
+	}, nil
+}
+
+// predicate returns the storage predicate selecting the series matching all
+// of the label matchers.
+//
+// As in Prometheus, a series without a label matches as if the value of the
+// label was empty. The series index evaluates tag comparisons this way, so
+// label="" selects the series without the label and label!="" the series
+// with it, matching the PromQL transpiler.
+func predicate(matchers []*LabelMatcher) (*datatypes.Predicate, error) {
+	children := []*datatypes.Node{
+		comparisonNode(datatypes.ComparisonEqual, tagRefNode(models.FieldKeyTagKey), literalNode(FieldName, false)),
+	}
+	for _, m := range matchers {
+		key := m.Name
+		if key == MetricNameLabel {
+			key = models.MeasurementTagKey
+		}
+
+		var (
+			op    datatypes.Node_Comparison
+			value = m.Value
+			regex bool
+		)
+		switch m.Type {
+		case LabelMatcher_EQ:
+			op = datatypes.ComparisonEqual
+		case LabelMatcher_NEQ:
+			op = datatypes.ComparisonNotEqual
+		case LabelMatcher_RE:
+			op, regex = datatypes.ComparisonRegex, true
+		case LabelMatcher_NRE:
+			op, regex = datatypes.ComparisonNotRegex, true
+		default:
+			return nil, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("unsupported label matcher type %d", m.Type),
+			}
+		}
+		if regex {
+			// Prometheus regular expressions are fully anchored.
+			value = "^(?:" + value + ")$"
+		}
+		children = append(children, comparisonNode(op, tagRefNode(key), literalNode(value, regex)))
+	}
+
+	return &datatypes.Predicate{
+		Root: &datatypes.Node{
+			NodeType: datatypes.NodeTypeLogicalExpression,
+			Value:    &datatypes.Node_Logical_{Logical: datatypes.LogicalAnd},
+			Children: children,
+		},
+	}, nil
+}
+
+// NewQueryResult returns the time series of the result set of a storage read.
+// Integer values are returned as floats, other values are skipped.
+func NewQueryResult(rs reads.ResultSet) (*QueryResult, error) {
+	res := &QueryResult{}
+	if rs == nil {
+		return res, nil
+	}
+	defer rs.Close()
+
+	for rs.Next() {
+		samples, err := samples(rs.Cursor())
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) == 0 {
+			continue
+		}
+		res.Timeseries = append(res.Timeseries, &TimeSeries{
+			Labels:  labels(rs.Tags()),
+			Samples: samples,
+		})
+	}
+	return res, rs.Err()
+}
+
+func labels(tags models.Tags) []*Label {
+	labels := make([]*Label, 0, len(tags))
+	for _, t := range tags {
+		switch string(t.Key) {
+		case datatypes.FieldKey:
+		case datatypes.MeasurementKey:
+			labels = append(labels, &Label{Name: MetricNameLabel, Value: string(t.Value)})
+		default:
+			labels = append(labels, &Label{Name: string(t.Key), Value: string(t.Value)})
+		}
+	}
+	sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+	return labels
+}
+
+func samples(cur cursors.Cursor) ([]*Sample, error) {
+	if cur == nil {
+		return nil, nil
+	}
+	defer cur.Close()
+
+	var samples []*Sample
+	add := func(ts int64, v float64) {
+		samples = append(samples, &Sample{Value: v, Timestamp: ts / int64(time.Millisecond)})
+	}
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				add(ts, a.Values[i])
+			}
+		}
+	case cursors.IntegerArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				add(ts, float64(a.Values[i]))
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			for i, ts := range a.Timestamps {
+				add(ts, float64(a.Values[i]))
+			}
+		}
+	}
+	return samples, cur.Err()
+}
+
+func tagRefNode(k string) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeTagRef,
+		Value:    &datatypes.Node_TagRefValue{TagRefValue: k},
+	}
+}
+
+func literalNode(v string, regex bool) *datatypes.Node {
+	n := &datatypes.Node{NodeType: datatypes.NodeTypeLiteral}
+	if regex {
+		n.Value = &datatypes.Node_RegexValue{RegexValue: v}
+	} else {
+		n.Value = &datatypes.Node_StringValue{StringValue: v}
+	}
+	return n
+}
+
+func comparisonNode(op datatypes.Node_Comparison, lhs, rhs *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: op},
+		Children: []*datatypes.Node{lhs, rhs},
+	}
+}
@@ -0,0 +1,191 @@
+package remote_test
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/tsdb"
+)
+
+func TestPoints(t *testing.T) {
+	orgID, bucketID := influxdb.ID(0xff), influxdb.ID(0xee)
+	req := &remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			{
+				Labels: []*remote.Label{
+					{Name: "job", Value: "node"},
+					{Name: remote.MetricNameLabel, Value: "up"},
+					{Name: "instance", Value: "localhost:9100"},
+					{Name: "empty", Value: ""},
+				},
+				Samples: []*remote.Sample{
+					{Value: 1, Timestamp: 1000},
+					{Value: math.NaN(), Timestamp: 2000},
+					{Value: 0, Timestamp: 3000},
+				},
+			},
+		},
+	}
+
+	points, err := remote.Points(req, orgID, bucketID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := len(points), 2; got != exp {
+		t.Fatalf("points length mismatch: got %v, exp %v", got, exp)
+	}
+
+	encoded := tsdb.EncodeName(orgID, bucketID)
+	for i, exp := range []struct {
+		series string
+		value  float64
+		time   int64
+	}{
+		{series: ",\x00=up,instance=localhost:9100,job=node,\xff=value", value: 1, time: 1e9},
+		{series: ",\x00=up,instance=localhost:9100,job=node,\xff=value", value: 0, time: 3e9},
+	} {
+		p := points[i]
+		if got, exp := string(p.Key()), string(encoded[:])+exp.series; got != exp {
+			t.Errorf("point %d key mismatch: got %q, exp %q", i, got, exp)
+		}
+		if got := p.UnixNano(); got != exp.time {
+			t.Errorf("point %d time mismatch: got %v, exp %v", i, got, exp.time)
+		}
+		fields, err := p.Fields()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fields[remote.FieldName]; got != exp.value {
+			t.Errorf("point %d value mismatch: got %v, exp %v", i, got, exp.value)
+		}
+	}
+}
+
+func TestPoints_MissingName(t *testing.T) {
+	req := &remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			{
+				Labels:  []*remote.Label{{Name: "job", Value: "node"}},
+				Samples: []*remote.Sample{{Value: 1, Timestamp: 1000}},
+			},
+		},
+	}
+	_, err := remote.Points(req, 1, 2)
+	if got, exp := influxdb.ErrorCode(err), influxdb.EInvalid; got != exp {
+		t.Fatalf("error code mismatch: got %q, exp %q", got, exp)
+	}
+}
+
+func TestReadFilterRequest(t *testing.T) {
+	q := &remote.Query{
+		StartTimestampMs: 1000,
+		EndTimestampMs:   2000,
+		Matchers: []*remote.LabelMatcher{
+			{Type: remote.LabelMatcher_EQ, Name: remote.MetricNameLabel, Value: "up"},
+			{Type: remote.LabelMatcher_NEQ, Name: "job", Value: "node"},
+			{Type: remote.LabelMatcher_RE, Name: "instance", Value: "local.*"},
+			{Type: remote.LabelMatcher_NRE, Name: "env", Value: "dev|test"},
+		},
+	}
+
+	req, err := remote.ReadFilterRequest(q, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, exp := req.Range.Start, int64(1e9); got != exp {
+		t.Errorf("range start mismatch: got %v, exp %v", got, exp)
+	}
+	if got, exp := req.Range.End, int64(2e9+1); got != exp {
+		t.Errorf("range end mismatch: got %v, exp %v", got, exp)
+	}
+
+	exp := "'\xff' = \"value\" AND '\x00' = \"up\" AND 'job' != \"node\" AND " +
+		"'instance' =~ /^(?:local.*)$/ AND 'env' !~ /^(?:dev|test)$/"
+	if got := reads.PredicateToExprString(req.Predicate); got != exp {
+		t.Errorf("predicate mismatch:\ngot %s\nexp %s", got, exp)
+	}
+}
+
+func TestReadFilterRequest_UnsupportedMatcher(t *testing.T) {
+	q := &remote.Query{
+		Matchers: []*remote.LabelMatcher{{Type: 42, Name: "job", Value: "node"}},
+	}
+	_, err := remote.ReadFilterRequest(q, nil)
+	if got, exp := influxdb.ErrorCode(err), influxdb.EInvalid; got != exp {
+		t.Fatalf("error code mismatch: got %q, exp %q", got, exp)
+	}
+}
+
+func TestNewQueryResult(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus_remote_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	engine := storage.NewEngine(dir, storage.NewConfig())
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Close()
+
+	orgID, bucketID := influxdb.ID(0xff), influxdb.ID(0xee)
+	series := func(job string, samples ...*remote.Sample) *remote.TimeSeries {
+		return &remote.TimeSeries{
+			Labels: []*remote.Label{
+				{Name: remote.MetricNameLabel, Value: "up"},
+				{Name: "job", Value: job},
+			},
+			Samples: samples,
+		}
+	}
+	points, err := remote.Points(&remote.WriteRequest{
+		Timeseries: []*remote.TimeSeries{
+			series("node", &remote.Sample{Value: 1, Timestamp: 1000}, &remote.Sample{Value: 0, Timestamp: 3000}),
+			series("prometheus", &remote.Sample{Value: 1, Timestamp: 1000}),
+		},
+	}, orgID, bucketID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.WritePoints(context.Background(), points); err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := remote.ReadFilterRequest(&remote.Query{
+		StartTimestampMs: 0,
+		EndTimestampMs:   2000,
+		Matchers: []*remote.LabelMatcher{
+			{Type: remote.LabelMatcher_EQ, Name: remote.MetricNameLabel, Value: "up"},
+			{Type: remote.LabelMatcher_RE, Name: "job", Value: "no.*"},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cur, err := reads.NewIndexSeriesCursor(context.Background(), orgID, bucketID, req.Predicate, engine)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := remote.NewQueryResult(reads.NewFilteredResultSet(context.Background(), req, cur))
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &remote.QueryResult{
+		Timeseries: []*remote.TimeSeries{
+			series("node", &remote.Sample{Value: 1, Timestamp: 1000}),
+		},
+	}
+	if !reflect.DeepEqual(res, exp) {
+		t.Fatalf("query result mismatch:\ngot %v\nexp %v", res, exp)
+	}
+}
@@ -0,0 +1,240 @@
+// Package cache caches the encoded results of Flux queries.
+//
+// The Cache sits in front of a query.ProxyQueryService and serves the results
+// of queries that are identical to a query it ran before: the same normalized
+// program for the same organization, with now() truncated to the precision of
+// the cache so that queries relative to now share results for a while. Which
+// requests are served from and stored in the cache is chosen by each request
+// through a Control, such as one parsed from a Cache-Control header.
+//
+// Results are invalidated when points are written to, or deleted from, a bucket
+// the query reads at or before the end of the time range of the query. Writes
+// after the end of the range cannot change the result and keep it cached.
+// Restoring data into a bucket, deleting it or changing its retention period
+// invalidates all the results of the bucket.
+package cache
+
+import (
+	"container/list"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultNowPrecision is the precision now() is truncated to if the Config
+// does not set one.
+const DefaultNowPrecision = 10 * time.Second
+
+// Config configures a Cache.
+type Config struct {
+	// MaxBytes is the size of all cached results together, the least recently
+	// used results are evicted to make room for new ones.
+	MaxBytes int64
+
+	// MaxEntryBytes is the size of the largest result that is cached.
+	// If this is unset, then results up to MaxBytes are cached.
+	MaxEntryBytes int64
+
+	// NowPrecision is the precision now() is truncated to when a query is
+	// cached. Queries relative to now share cached results for this long.
+	// If this is unset, then DefaultNowPrecision is used.
+	NowPrecision time.Duration
+}
+
+// Cache holds the encoded results of queries.
+type Cache struct {
+	config Config
+
+	mu      sync.Mutex
+	size    int64
+	lru     *list.List
+	entries map[string]*list.Element
+	// buckets indexes the keys of the entries by the buckets they read.
+	buckets map[influxdb.ID]map[string]struct{}
+	// running are the queries whose results are to be stored once they
+	// finish. Their results are not stored if they were invalidated while
+	// the queries ran.
+	running map[*entry]struct{}
+
+	metrics *metrics
+}
+
+// entry is a cached result.
+type entry struct {
+	key     string
+	result  []byte
+	stats   flux.Statistics
+	created time.Time
+
+	// buckets are the buckets the query reads and stop the end of the time
+	// range it reads them in.
+	buckets []influxdb.ID
+	stop    time.Time
+
+	// invalidated is set if the entry was invalidated while its query ran.
+	invalidated bool
+}
+
+// reads returns true if the entry reads the bucket up to or after min.
+func (e *entry) reads(bucketID influxdb.ID, min time.Time) bool {
+	if e.stop.Before(min) {
+		return false
+	}
+	for _, id := range e.buckets {
+		if id == bucketID {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *entry) size() int64 {
+	return int64(len(e.key) + len(e.result))
+}
+
+// New returns a Cache with the config.
+func New(config Config) *Cache {
+	if config.MaxEntryBytes <= 0 || config.MaxEntryBytes > config.MaxBytes {
+		config.MaxEntryBytes = config.MaxBytes
+	}
+	if config.NowPrecision <= 0 {
+		config.NowPrecision = DefaultNowPrecision
+	}
+	return &Cache{
+		config:  config,
+		lru:     list.New(),
+		entries: make(map[string]*list.Element),
+		buckets: make(map[influxdb.ID]map[string]struct{}),
+		running: make(map[*entry]struct{}),
+		metrics: newMetrics(),
+	}
+}
+
+// get returns the entry of the key if it was created after min.
+func (c *Cache) get(key string, min time.Time) (*entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*entry)
+	if e.created.Before(min) {
+		return nil, false
+	}
+	c.lru.MoveToFront(el)
+	return e, true
+}
+
+// start registers the entry of a query that is about to run so that writes
+// while it runs invalidate it.
+func (c *Cache) start(e *entry) {
+	c.mu.Lock()
+	c.running[e] = struct{}{}
+	c.mu.Unlock()
+}
+
+// abort unregisters the entry of a query whose result is not to be stored.
+func (c *Cache) abort(e *entry) {
+	c.mu.Lock()
+	delete(c.running, e)
+	c.mu.Unlock()
+}
+
+// put stores the entry registered with start, unless it has been invalidated
+// while its query ran or is too large. It returns false if the entry was not
+// stored.
+func (c *Cache) put(e *entry) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.running, e)
+	size := e.size()
+	if e.invalidated || size > c.config.MaxEntryBytes {
+		return false
+	}
+
+	if el, ok := c.entries[e.key]; ok {
+		c.remove(el)
+	}
+	for c.size+size > c.config.MaxBytes {
+		c.remove(c.lru.Back())
+		c.metrics.evictions.WithLabelValues(evictedSize).Inc()
+	}
+
+	c.entries[e.key] = c.lru.PushFront(e)
+	for _, id := range e.buckets {
+		keys, ok := c.buckets[id]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.buckets[id] = keys
+		}
+		keys[e.key] = struct{}{}
+	}
+	c.size += size
+	c.updateSize()
+	return true
+}
+
+// remove removes the element from the cache. The lock must be held.
+func (c *Cache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*entry)
+	delete(c.entries, e.key)
+	for _, id := range e.buckets {
+		keys := c.buckets[id]
+		delete(keys, e.key)
+		if len(keys) == 0 {
+			delete(c.buckets, id)
+		}
+	}
+	c.size -= e.size()
+	c.updateSize()
+}
+
+func (c *Cache) updateSize() {
+	c.metrics.entries.Set(float64(len(c.entries)))
+	c.metrics.size.Set(float64(c.size))
+}
+
+// Invalidate removes the results of the queries that read the bucket in a
+// time range that does not end before min, as points written to the bucket
+// at min may change them.
+func (c *Cache) Invalidate(bucketID influxdb.ID, min time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for e := range c.running {
+		if e.reads(bucketID, min) {
+			e.invalidated = true
+		}
+	}
+	for key := range c.buckets[bucketID] {
+		el := c.entries[key]
+		if !el.Value.(*entry).reads(bucketID, min) {
+			continue
+		}
+		c.remove(el)
+		c.metrics.evictions.WithLabelValues(evictedInvalidated).Inc()
+	}
+}
+
+// InvalidateBucket removes the results of all the queries that read the bucket.
+func (c *Cache) InvalidateBucket(bucketID influxdb.ID) {
+	c.Invalidate(bucketID, beginning)
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (c *Cache) PrometheusCollectors() []prometheus.Collector {
+	return c.metrics.PrometheusCollectors()
+}
+
+// unbounded is the stop of the queries whose time range is not known.
+var unbounded = time.Unix(0, math.MaxInt64).UTC()
+
+// beginning is the earliest time points may be written at.
+var beginning = time.Unix(0, math.MinInt64).UTC()
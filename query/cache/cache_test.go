@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+func newTestEntry(key string, size int, stop time.Time, buckets ...influxdb.ID) *entry {
+	return &entry{
+		key:     key,
+		result:  []byte(strings.Repeat("x", size-len(key))),
+		created: time.Unix(100, 0),
+		buckets: buckets,
+		stop:    stop,
+	}
+}
+
+func TestCache_PutGet(t *testing.T) {
+	c := New(Config{MaxBytes: 100})
+
+	e := newTestEntry("a", 10, time.Unix(50, 0), 1)
+	c.start(e)
+	if !c.put(e) {
+		t.Fatal("expected the entry to be stored")
+	}
+
+	if got, ok := c.get("a", time.Unix(90, 0)); !ok || got != e {
+		t.Fatalf("expected a hit, got %v %v", got, ok)
+	}
+	if _, ok := c.get("a", time.Unix(110, 0)); ok {
+		t.Fatal("expected a miss for an entry created before the max age")
+	}
+	if _, ok := c.get("b", time.Time{}); ok {
+		t.Fatal("expected a miss for an unknown key")
+	}
+}
+
+func TestCache_EvictLeastRecentlyUsed(t *testing.T) {
+	c := New(Config{MaxBytes: 30})
+
+	for _, key := range []string{"a", "b", "c"} {
+		e := newTestEntry(key, 10, time.Unix(50, 0), 1)
+		c.start(e)
+		c.put(e)
+	}
+	// Use a so that b is the least recently used.
+	if _, ok := c.get("a", time.Time{}); !ok {
+		t.Fatal("expected a hit for a")
+	}
+
+	e := newTestEntry("d", 10, time.Unix(50, 0), 1)
+	c.start(e)
+	if !c.put(e) {
+		t.Fatal("expected the entry to be stored")
+	}
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		if _, ok := c.get(key, time.Time{}); ok != want {
+			t.Errorf("unexpected hit for %s -want/+got:\n\t- %v\n\t+ %v", key, want, ok)
+		}
+	}
+	if want, got := int64(30), c.size; want != got {
+		t.Errorf("unexpected size -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+	if want, got := 1, len(c.buckets); want != got {
+		t.Errorf("unexpected number of indexed buckets -want/+got:\n\t- %d\n\t+ %d", want, got)
+	}
+}
+
+func TestCache_MaxEntryBytes(t *testing.T) {
+	c := New(Config{MaxBytes: 100, MaxEntryBytes: 10})
+
+	e := newTestEntry("a", 11, time.Unix(50, 0), 1)
+	c.start(e)
+	if c.put(e) {
+		t.Fatal("expected the entry larger than the max entry bytes not to be stored")
+	}
+	if len(c.running) != 0 {
+		t.Fatal("expected the entry to no longer be running")
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	c := New(Config{MaxBytes: 100})
+
+	for _, e := range []*entry{
+		newTestEntry("a", 10, time.Unix(50, 0), 1),
+		newTestEntry("b", 10, time.Unix(80, 0), 1, 2),
+		newTestEntry("c", 10, time.Unix(80, 0), 2),
+	} {
+		c.start(e)
+		c.put(e)
+	}
+
+	// A write after the range of a does not change its result.
+	c.Invalidate(1, time.Unix(60, 0))
+
+	for key, want := range map[string]bool{"a": true, "b": false, "c": true} {
+		if _, ok := c.get(key, time.Time{}); ok != want {
+			t.Errorf("unexpected hit for %s -want/+got:\n\t- %v\n\t+ %v", key, want, ok)
+		}
+	}
+	if _, ok := c.buckets[2]["b"]; ok {
+		t.Error("expected the invalidated entry to be removed from the bucket index")
+	}
+}
+
+func TestCache_InvalidateRunning(t *testing.T) {
+	c := New(Config{MaxBytes: 100})
+
+	changed := newTestEntry("a", 10, time.Unix(50, 0), 1)
+	unchanged := newTestEntry("b", 10, time.Unix(50, 0), 1)
+	c.start(changed)
+	c.Invalidate(1, time.Unix(40, 0))
+	if c.put(changed) {
+		t.Fatal("expected the entry invalidated while its query ran not to be stored")
+	}
+
+	// A write after the range does not invalidate the running query.
+	c.start(unchanged)
+	c.Invalidate(1, time.Unix(60, 0))
+	if !c.put(unchanged) {
+		t.Fatal("expected the entry to be stored")
+	}
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Control is how a query request uses the cache. The zero value neither
+// serves nor stores the results of the request, so caching is opt-in.
+type Control struct {
+	// MaxAge is how long ago the result of a query may have been cached to be
+	// served. Results are stored in the cache if this is set.
+	MaxAge time.Duration
+	// NoCache requests that the query is run even if its result is cached.
+	// The new result is stored in the cache.
+	NoCache bool
+	// NoStore requests that the query is neither served from nor stored in the cache.
+	NoStore bool
+}
+
+// ParseControl parses the directives of a Cache-Control header value.
+// The max-age, no-cache and no-store directives are supported, others are
+// ignored.
+func ParseControl(header string) Control {
+	var c Control
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-cache":
+			c.NoCache = true
+		case directive == "no-store":
+			c.NoStore = true
+		case strings.HasPrefix(directive, "max-age="):
+			secs, err := strconv.ParseInt(strings.TrimPrefix(directive, "max-age="), 10, 64)
+			if err == nil && secs > 0 {
+				c.MaxAge = time.Duration(secs) * time.Second
+			}
+		}
+	}
+	return c
+}
+
+// serve returns true if the request may be served from the cache.
+func (c Control) serve() bool {
+	return !c.NoStore && !c.NoCache && c.MaxAge > 0
+}
+
+// store returns true if the result of the request may be stored in the cache.
+func (c Control) store() bool {
+	return !c.NoStore && (c.NoCache || c.MaxAge > 0)
+}
+
+type contextKey struct{}
+
+// ContextWithControl returns a new context with the cache control of the request.
+func ContextWithControl(ctx context.Context, c Control) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// ControlFromContext returns the cache control on the context, or the zero
+// Control if there is none.
+func ControlFromContext(ctx context.Context) Control {
+	c, _ := ctx.Value(contextKey{}).(Control)
+	return c
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseControl(t *testing.T) {
+	for _, tt := range []struct {
+		header string
+		want   Control
+		serve  bool
+		store  bool
+	}{
+		{
+			header: "",
+		},
+		{
+			header: "max-age=60",
+			want:   Control{MaxAge: time.Minute},
+			serve:  true,
+			store:  true,
+		},
+		{
+			header: "no-cache",
+			want:   Control{NoCache: true},
+			store:  true,
+		},
+		{
+			header: "Max-Age=30, no-store",
+			want:   Control{MaxAge: 30 * time.Second, NoStore: true},
+		},
+		{
+			header: "max-age=-1, private",
+		},
+		{
+			header: "max-age=ten",
+		},
+	} {
+		t.Run(tt.header, func(t *testing.T) {
+			got := ParseControl(tt.header)
+			if got != tt.want {
+				t.Errorf("unexpected control -want/+got:\n\t- %+v\n\t+ %+v", tt.want, got)
+			}
+			if got.serve() != tt.serve {
+				t.Errorf("unexpected serve -want/+got:\n\t- %v\n\t+ %v", tt.serve, got.serve())
+			}
+			if got.store() != tt.store {
+				t.Errorf("unexpected store -want/+got:\n\t- %v\n\t+ %v", tt.store, got.store())
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package cache
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// metrics holds metrics related to the query cache.
+type metrics struct {
+	requests  *prometheus.CounterVec
+	evictions *prometheus.CounterVec
+
+	entries prometheus.Gauge
+	size    prometheus.Gauge
+}
+
+const (
+	resultHit         = "hit"
+	resultMiss        = "miss"
+	resultBypass      = "bypass"
+	resultUncacheable = "uncacheable"
+
+	evictedSize        = "size"
+	evictedInvalidated = "invalidated"
+)
+
+func newMetrics() *metrics {
+	const (
+		namespace = "query"
+		subsystem = "cache"
+	)
+
+	return &metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "requests_total",
+			Help:      "Count of the query requests by whether they were served from the cache",
+		}, []string{"result"}),
+
+		evictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "evictions_total",
+			Help:      "Count of the results removed from the cache",
+		}, []string{"reason"}),
+
+		entries: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "entries",
+			Help:      "Number of cached results",
+		}),
+
+		size: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "size_bytes",
+			Help:      "Size of the cached results",
+		}),
+	}
+}
+
+// PrometheusCollectors satisfies the prom.PrometheusCollector interface.
+func (m *metrics) PrometheusCollectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		m.requests,
+		m.evictions,
+		m.entries,
+		m.size,
+	}
+}
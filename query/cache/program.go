@@ -0,0 +1,317 @@
+package cache
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/query"
+)
+
+// uncacheablePackages are the packages whose functions read data from outside
+// of the buckets of the organization or have side effects.
+var uncacheablePackages = map[string]bool{
+	"csv":                         true,
+	"experimental/csv":            true,
+	"experimental/http":           true,
+	"experimental/mqtt":           true,
+	"experimental/prometheus":     true,
+	"http":                        true,
+	"influxdata/influxdb/monitor": true,
+	"influxdata/influxdb/secrets": true,
+	"influxdata/influxdb/tasks":   true,
+	"influxdata/influxdb/v1":      true,
+	"pagerduty":                   true,
+	"slack":                       true,
+	"socket":                      true,
+	"sql":                         true,
+	"system":                      true,
+}
+
+// uncacheableFunctions are the functions that write data or read data other
+// than the points in buckets.
+var uncacheableFunctions = map[string]bool{
+	"buckets": true,
+	"to":      true,
+}
+
+// maxResolveDepth is how many identifiers are followed to find the stop of
+// a time range.
+const maxResolveDepth = 8
+
+// program is a Flux query analyzed for caching.
+type program struct {
+	// source is the formatted source of the query and its extern, so
+	// queries that only differ in formatting have the same source.
+	source string
+	// now is the time the query is run at.
+	now time.Time
+
+	// bucketNames and bucketIDs are the buckets the query reads.
+	bucketNames []string
+	bucketIDs   []influxdb.ID
+	// stop is the latest time the query reads.
+	stop time.Time
+
+	// compiler is the compiler of the query to run at now.
+	compiler flux.Compiler
+}
+
+// analyze analyzes the query of the compiler, with its now truncated to the
+// precision. It returns false if the results of the query cannot be cached.
+func analyze(fluxLang influxdb.FluxLanguageService, compiler flux.Compiler, precision time.Duration) (*program, bool) {
+	var (
+		pkg    *ast.Package
+		extern json.RawMessage
+		now    time.Time
+		err    error
+	)
+	switch c := compiler.(type) {
+	case lang.FluxCompiler:
+		if pkg, err = query.Parse(fluxLang, c.Query); err != nil {
+			return nil, false
+		}
+		extern = c.Extern
+		now = truncateNow(c.Now, precision)
+		c.Now = now
+		compiler = c
+	case lang.ASTCompiler:
+		pkg = new(ast.Package)
+		if err := json.Unmarshal(c.AST, pkg); err != nil {
+			return nil, false
+		}
+		extern = c.Extern
+		now = truncateNow(c.Now, precision)
+		c.Now = now
+		compiler = c
+	default:
+		return nil, false
+	}
+
+	var files []*ast.File
+	if lang.IsNonNullJSON(extern) {
+		f := new(ast.File)
+		if err := json.Unmarshal(extern, f); err != nil {
+			return nil, false
+		}
+		files = append(files, f)
+	}
+	files = append(files, pkg.Files...)
+
+	p := &program{
+		now:      now,
+		compiler: compiler,
+	}
+	a := &analyzer{
+		program: p,
+		scope:   make(map[string]ast.Expression),
+	}
+	var source strings.Builder
+	for _, f := range files {
+		if !a.analyzeFile(f) {
+			return nil, false
+		}
+		source.WriteString(ast.Format(f))
+		source.WriteByte('\n')
+	}
+	p.source = source.String()
+
+	if _, ok := a.scope["now"]; ok || len(a.stops) == 0 && (len(p.bucketNames) > 0 || len(p.bucketIDs) > 0) {
+		// The query reads buckets without a known time range, or
+		// overrides now so that the range cannot be resolved.
+		p.stop = unbounded
+		return p, true
+	}
+	for _, stop := range a.stops {
+		t, ok := a.resolveTime(stop, 0)
+		if !ok {
+			t = unbounded
+		}
+		if t.After(p.stop) {
+			p.stop = t
+		}
+	}
+	return p, true
+}
+
+// analyzer collects the buckets and time ranges a program reads.
+type analyzer struct {
+	*program
+
+	// scope holds the expressions assigned to variables and options.
+	scope map[string]ast.Expression
+	// stops are the stop arguments of the calls to range, nil if the
+	// argument is omitted.
+	stops []ast.Expression
+}
+
+func (a *analyzer) analyzeFile(f *ast.File) bool {
+	for _, imp := range f.Imports {
+		if imp.Path == nil || uncacheablePackages[imp.Path.Value] || strings.HasPrefix(imp.Path.Value, "contrib/") {
+			return false
+		}
+	}
+
+	for _, stmt := range f.Body {
+		switch s := stmt.(type) {
+		case *ast.VariableAssignment:
+			a.scope[s.ID.Name] = s.Init
+		case *ast.OptionStatement:
+			switch as := s.Assignment.(type) {
+			case *ast.VariableAssignment:
+				a.scope[as.ID.Name] = as.Init
+			case *ast.MemberAssignment:
+				if obj, ok := as.Member.Object.(*ast.Identifier); ok && as.Member.Property != nil {
+					a.scope[obj.Name+"."+as.Member.Property.Key()] = as.Init
+				}
+			}
+		}
+	}
+
+	ok := true
+	ast.Visit(f, func(n ast.Node) {
+		call, isCall := n.(*ast.CallExpression)
+		if !ok || !isCall {
+			return
+		}
+		ok = a.analyzeCall(call)
+	})
+	return ok
+}
+
+func (a *analyzer) analyzeCall(call *ast.CallExpression) bool {
+	var name string
+	switch callee := call.Callee.(type) {
+	case *ast.Identifier:
+		name = callee.Name
+	case *ast.MemberExpression:
+		if callee.Property != nil {
+			name = callee.Property.Key()
+		}
+	}
+	if uncacheableFunctions[name] {
+		return false
+	}
+
+	var params *ast.ObjectExpression
+	if len(call.Arguments) > 0 {
+		params, _ = call.Arguments[0].(*ast.ObjectExpression)
+	}
+	if name == "range" {
+		stop := property(params, "stop")
+		a.stops = append(a.stops, stop)
+	}
+	if params == nil {
+		return true
+	}
+
+	for _, prop := range params.Properties {
+		if prop.Key == nil {
+			continue
+		}
+		switch prop.Key.Key() {
+		case "bucket":
+			lit, ok := prop.Value.(*ast.StringLiteral)
+			if !ok {
+				return false
+			}
+			a.bucketNames = append(a.bucketNames, lit.Value)
+		case "bucketID":
+			lit, ok := prop.Value.(*ast.StringLiteral)
+			if !ok {
+				return false
+			}
+			id, err := influxdb.IDFromString(lit.Value)
+			if err != nil {
+				return false
+			}
+			a.bucketIDs = append(a.bucketIDs, *id)
+		}
+	}
+	return true
+}
+
+// resolveTime returns the time the expression evaluates to, as far as that can
+// be known without evaluating the program. A nil expression is now, like the
+// omitted stop of a range.
+func (a *analyzer) resolveTime(e ast.Expression, depth int) (time.Time, bool) {
+	if depth > maxResolveDepth {
+		return time.Time{}, false
+	}
+
+	switch e := e.(type) {
+	case nil:
+		return a.now, true
+	case *ast.DateTimeLiteral:
+		return e.Value, true
+	case *ast.DurationLiteral:
+		d, ok := duration(e)
+		return a.now.Add(d), ok
+	case *ast.UnaryExpression:
+		if lit, ok := e.Argument.(*ast.DurationLiteral); ok && e.Operator == ast.SubtractionOperator {
+			d, ok := duration(lit)
+			return a.now.Add(-d), ok
+		}
+	case *ast.CallExpression:
+		if id, ok := e.Callee.(*ast.Identifier); ok && id.Name == "now" && len(e.Arguments) == 0 {
+			return a.now, true
+		}
+	case *ast.Identifier:
+		if v, ok := a.scope[e.Name]; ok {
+			return a.resolveTime(v, depth+1)
+		}
+	case *ast.MemberExpression:
+		obj, ok := e.Object.(*ast.Identifier)
+		if !ok || e.Property == nil {
+			break
+		}
+		key := e.Property.Key()
+		if v, ok := a.scope[obj.Name+"."+key]; ok {
+			return a.resolveTime(v, depth+1)
+		}
+		if o, ok := a.scope[obj.Name].(*ast.ObjectExpression); ok {
+			if v := property(o, key); v != nil {
+				return a.resolveTime(v, depth+1)
+			}
+		}
+	}
+	return time.Time{}, false
+}
+
+// property returns the value of the property of the object, or nil if it has none.
+func property(o *ast.ObjectExpression, key string) ast.Expression {
+	if o == nil {
+		return nil
+	}
+	for _, prop := range o.Properties {
+		if prop.Key != nil && prop.Key.Key() == key {
+			return prop.Value
+		}
+	}
+	return nil
+}
+
+// duration returns the duration of the literal. Months and years are
+// rejected as their length depends on the time they are added to.
+func duration(lit *ast.DurationLiteral) (time.Duration, bool) {
+	for _, v := range lit.Values {
+		if v.Unit == ast.MonthUnit || v.Unit == ast.YearUnit {
+			return 0, false
+		}
+	}
+	d, err := ast.DurationFrom(lit, time.Time{})
+	return d, err == nil
+}
+
+// truncateNow truncates the now of a compiler to the precision, the compilers
+// run at the current time if their now is unset.
+func truncateNow(now time.Time, precision time.Duration) time.Time {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return now.Truncate(precision)
+}
@@ -0,0 +1,239 @@
+package cache
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb/v2"
+)
+
+var testNow = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testProperty(key string, value ast.Expression) *ast.Property {
+	return &ast.Property{Key: &ast.Identifier{Name: key}, Value: value}
+}
+
+func testCall(name string, props ...*ast.Property) *ast.CallExpression {
+	return &ast.CallExpression{
+		Callee:    &ast.Identifier{Name: name},
+		Arguments: []ast.Expression{&ast.ObjectExpression{Properties: props}},
+	}
+}
+
+// testPipe returns the expression that pipes the first call into the others.
+func testPipe(calls ...*ast.CallExpression) ast.Statement {
+	var e ast.Expression = calls[0]
+	for _, call := range calls[1:] {
+		e = &ast.PipeExpression{Argument: e, Call: call}
+	}
+	return &ast.ExpressionStatement{Expression: e}
+}
+
+func testDuration(magnitude int64, unit string) *ast.DurationLiteral {
+	return &ast.DurationLiteral{Values: []ast.Duration{{Magnitude: magnitude, Unit: unit}}}
+}
+
+func testAgo(magnitude int64, unit string) *ast.UnaryExpression {
+	return &ast.UnaryExpression{Operator: ast.SubtractionOperator, Argument: testDuration(magnitude, unit)}
+}
+
+func testCompiler(t *testing.T, imports []string, body ...ast.Statement) lang.ASTCompiler {
+	t.Helper()
+
+	f := &ast.File{
+		Name:    "query.flux",
+		Package: &ast.PackageClause{Name: &ast.Identifier{Name: "main"}},
+		Body:    body,
+	}
+	for _, path := range imports {
+		f.Imports = append(f.Imports, &ast.ImportDeclaration{Path: &ast.StringLiteral{Value: path}})
+	}
+	pkg, err := json.Marshal(&ast.Package{Package: "main", Files: []*ast.File{f}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lang.ASTCompiler{AST: pkg, Now: testNow.Add(3 * time.Second)}
+}
+
+func TestAnalyze(t *testing.T) {
+	from := testCall("from", testProperty("bucket", &ast.StringLiteral{Value: "telegraf"}))
+	stop := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range []struct {
+		name        string
+		imports     []string
+		body        []ast.Statement
+		uncacheable bool
+		bucketNames []string
+		bucketIDs   []influxdb.ID
+		stop        time.Time
+	}{
+		{
+			name: "range without stop",
+			body: []ast.Statement{
+				testPipe(from, testCall("range", testProperty("start", testAgo(1, "h")))),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        testNow,
+		},
+		{
+			name: "range with relative stop",
+			body: []ast.Statement{
+				testPipe(from, testCall("range",
+					testProperty("start", testAgo(1, "h")),
+					testProperty("stop", testAgo(5, "m")),
+				)),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        testNow.Add(-5 * time.Minute),
+		},
+		{
+			name: "range with stop variable",
+			body: []ast.Statement{
+				&ast.VariableAssignment{ID: &ast.Identifier{Name: "stop"}, Init: &ast.DateTimeLiteral{Value: stop}},
+				testPipe(
+					testCall("from", testProperty("bucketID", &ast.StringLiteral{Value: "000000000000000a"})),
+					testCall("range",
+						testProperty("start", testAgo(1, "h")),
+						testProperty("stop", &ast.Identifier{Name: "stop"}),
+					),
+				),
+			},
+			bucketIDs: []influxdb.ID{10},
+			stop:      stop,
+		},
+		{
+			name: "range with stop option",
+			body: []ast.Statement{
+				&ast.OptionStatement{Assignment: &ast.VariableAssignment{
+					ID: &ast.Identifier{Name: "v"},
+					Init: &ast.ObjectExpression{Properties: []*ast.Property{
+						testProperty("timeRangeStop", &ast.DateTimeLiteral{Value: stop}),
+					}},
+				}},
+				testPipe(from, testCall("range",
+					testProperty("start", testAgo(1, "h")),
+					testProperty("stop", &ast.MemberExpression{
+						Object:   &ast.Identifier{Name: "v"},
+						Property: &ast.Identifier{Name: "timeRangeStop"},
+					}),
+				)),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        stop,
+		},
+		{
+			name: "range with unknown stop",
+			body: []ast.Statement{
+				testPipe(from, testCall("range",
+					testProperty("start", testAgo(1, "h")),
+					testProperty("stop", testCall("stop")),
+				)),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        unbounded,
+		},
+		{
+			name: "range with stop in months",
+			body: []ast.Statement{
+				testPipe(from, testCall("range",
+					testProperty("start", testAgo(2, "mo")),
+					testProperty("stop", testAgo(1, "mo")),
+				)),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        unbounded,
+		},
+		{
+			name: "without range",
+			body: []ast.Statement{
+				testPipe(from),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        unbounded,
+		},
+		{
+			name: "now option",
+			body: []ast.Statement{
+				&ast.OptionStatement{Assignment: &ast.VariableAssignment{
+					ID:   &ast.Identifier{Name: "now"},
+					Init: &ast.FunctionExpression{Body: &ast.DateTimeLiteral{Value: stop}},
+				}},
+				testPipe(from, testCall("range", testProperty("start", testAgo(1, "h")))),
+			},
+			bucketNames: []string{"telegraf"},
+			stop:        unbounded,
+		},
+		{
+			name: "bucket variable",
+			body: []ast.Statement{
+				testPipe(testCall("from", testProperty("bucket", &ast.Identifier{Name: "bucket"}))),
+			},
+			uncacheable: true,
+		},
+		{
+			name: "to",
+			body: []ast.Statement{
+				testPipe(from, testCall("to", testProperty("bucket", &ast.StringLiteral{Value: "copy"}))),
+			},
+			uncacheable: true,
+		},
+		{
+			name:    "uncacheable import",
+			imports: []string{"sql"},
+			body: []ast.Statement{
+				testPipe(from),
+			},
+			uncacheable: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := analyze(nil, testCompiler(t, tt.imports, tt.body...), 10*time.Second)
+			if ok == tt.uncacheable {
+				t.Fatalf("unexpected cacheable -want/+got:\n\t- %v\n\t+ %v", !tt.uncacheable, ok)
+			}
+			if !ok {
+				return
+			}
+
+			if diff := cmp.Diff(tt.bucketNames, p.bucketNames); diff != "" {
+				t.Errorf("unexpected bucket names -want/+got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.bucketIDs, p.bucketIDs); diff != "" {
+				t.Errorf("unexpected bucket IDs -want/+got:\n%s", diff)
+			}
+			if !p.stop.Equal(tt.stop) {
+				t.Errorf("unexpected stop -want/+got:\n\t- %v\n\t+ %v", tt.stop, p.stop)
+			}
+			if !p.now.Equal(testNow) {
+				t.Errorf("unexpected now -want/+got:\n\t- %v\n\t+ %v", testNow, p.now)
+			}
+			if c := p.compiler.(lang.ASTCompiler); !c.Now.Equal(testNow) {
+				t.Errorf("unexpected compiler now -want/+got:\n\t- %v\n\t+ %v", testNow, c.Now)
+			}
+		})
+	}
+}
+
+func TestAnalyze_NormalizedSource(t *testing.T) {
+	query := func(magnitude int64) lang.ASTCompiler {
+		return testCompiler(t, nil, testPipe(
+			testCall("from", testProperty("bucket", &ast.StringLiteral{Value: "telegraf"})),
+			testCall("range", testProperty("start", testAgo(magnitude, "h"))),
+		))
+	}
+
+	a, _ := analyze(nil, query(1), time.Second)
+	b, _ := analyze(nil, query(1), time.Second)
+	c, _ := analyze(nil, query(2), time.Second)
+	if a.source != b.source {
+		t.Errorf("expected the same source for the same query, got:\n%s\n%s", a.source, b.source)
+	}
+	if a.source == c.source {
+		t.Errorf("expected a different source for a different query, got:\n%s", a.source)
+	}
+}
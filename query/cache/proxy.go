@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	"github.com/influxdata/influxdb/v2/kit/check"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"github.com/influxdata/influxdb/v2/query"
+)
+
+var _ query.ProxyQueryService = (*ProxyQueryService)(nil)
+
+// ProxyQueryService serves the results of queries from the cache and stores
+// the results of the queries it runs, as chosen by the Control on the context
+// of each query.
+type ProxyQueryService struct {
+	cache   *Cache
+	next    query.ProxyQueryService
+	lang    influxdb.FluxLanguageService
+	buckets influxdb.BucketService
+
+	now func() time.Time
+}
+
+// NewProxyQueryService returns a ProxyQueryService that runs the queries that
+// are not served from the cache with next. The bucket service is used to find
+// the buckets the queries read and must not require authorization.
+func NewProxyQueryService(c *Cache, next query.ProxyQueryService, lang influxdb.FluxLanguageService, buckets influxdb.BucketService) *ProxyQueryService {
+	return &ProxyQueryService{
+		cache:   c,
+		next:    next,
+		lang:    lang,
+		buckets: buckets,
+		now:     time.Now,
+	}
+}
+
+// Check runs the check of the proxied service.
+func (s *ProxyQueryService) Check(ctx context.Context) check.Response {
+	return s.next.Check(ctx)
+}
+
+// Query serves the query from the cache if its result is cached, otherwise it
+// runs the query and stores its result.
+func (s *ProxyQueryService) Query(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	control := ControlFromContext(ctx)
+	if !control.serve() && !control.store() {
+		s.cache.metrics.requests.WithLabelValues(resultBypass).Inc()
+		return s.next.Query(ctx, w, req)
+	}
+
+	e, compiler, ok := s.newEntry(ctx, req)
+	if !ok {
+		s.cache.metrics.requests.WithLabelValues(resultUncacheable).Inc()
+		return s.next.Query(ctx, w, req)
+	}
+
+	if control.serve() {
+		if cached, ok := s.cache.get(e.key, s.now().Add(-control.MaxAge)); ok {
+			s.cache.metrics.requests.WithLabelValues(resultHit).Inc()
+			if _, err := w.Write(cached.result); err != nil {
+				return flux.Statistics{}, err
+			}
+			return cached.stats, nil
+		}
+	}
+	s.cache.metrics.requests.WithLabelValues(resultMiss).Inc()
+
+	// The query runs at the truncated now, so the result is the one of any
+	// query with the same key.
+	r := *req
+	r.Request.Compiler = compiler
+
+	s.cache.start(e)
+	buf := &limitedBuffer{max: s.cache.config.MaxEntryBytes}
+	stats, err := s.next.Query(ctx, io.MultiWriter(w, buf), &r)
+	if err != nil || len(stats.RuntimeErrors) > 0 || buf.exceeded {
+		s.cache.abort(e)
+		return stats, err
+	}
+
+	e.result = buf.Bytes()
+	e.stats = stats
+	e.created = s.now()
+	s.cache.put(e)
+	return stats, nil
+}
+
+// newEntry returns the entry of the request without a result, and the compiler
+// to run the query of the request with. It returns false if the result of the
+// request cannot be cached.
+func (s *ProxyQueryService) newEntry(ctx context.Context, req *query.ProxyRequest) (*entry, flux.Compiler, bool) {
+	switch req.Dialect.(type) {
+	case *query.NoContentDialect, *query.NoContentWithErrorDialect:
+		return nil, nil, false
+	}
+
+	p, ok := analyze(s.lang, req.Request.Compiler, s.cache.config.NowPrecision)
+	if !ok {
+		return nil, nil, false
+	}
+
+	orgID := req.Request.OrganizationID
+	buckets := make([]influxdb.ID, 0, len(p.bucketIDs)+len(p.bucketNames))
+	buckets = append(buckets, p.bucketIDs...)
+	for _, name := range p.bucketNames {
+		b, err := s.buckets.FindBucketByName(ctx, orgID, name)
+		if err != nil {
+			return nil, nil, false
+		}
+		buckets = append(buckets, b.ID)
+	}
+
+	// The result may only be served to, or stored from, a request
+	// that can read all of the buckets, in full.
+	seen := make(map[influxdb.ID]bool, len(buckets))
+	ids := buckets[:0]
+	for _, id := range buckets {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if _, _, err := authorizer.AuthorizeRead(ctx, influxdb.BucketsResourceType, id, orgID); err != nil {
+			return nil, nil, false
+		}
+		if scopes, err := authorizer.BucketScopes(ctx, influxdb.ReadAction, orgID, id); err != nil || scopes != nil {
+			return nil, nil, false
+		}
+		ids = append(ids, id)
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%d\n%T %+v\n", orgID, p.now.UnixNano(), req.Dialect, req.Dialect)
+	io.WriteString(h, p.source)
+	return &entry{
+		key:     hex.EncodeToString(h.Sum(nil)),
+		buckets: ids,
+		stop:    p.stop,
+	}, p.compiler, true
+}
+
+// limitedBuffer buffers what is written to it up to max bytes. Writes never
+// fail, the buffer is marked as exceeded instead.
+type limitedBuffer struct {
+	bytes.Buffer
+	max      int64
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.exceeded {
+		return len(p), nil
+	}
+	if int64(b.Len()+len(p)) > b.max {
+		b.exceeded = true
+		b.Reset()
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/csv"
+	"github.com/influxdata/influxdb/v2"
+	pcontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/query"
+	qmock "github.com/influxdata/influxdb/v2/query/mock"
+	"github.com/influxdata/influxdb/v2/tsdb"
+)
+
+const (
+	testOrgID    influxdb.ID = 1
+	testBucketID influxdb.ID = 2
+)
+
+type testProxy struct {
+	*ProxyQueryService
+	runs   int
+	result string
+	err    error
+}
+
+func newTestProxy(c *Cache) *testProxy {
+	p := &testProxy{result: "result"}
+	next := &qmock.ProxyQueryService{
+		QueryF: func(ctx context.Context, w io.Writer, req *query.ProxyRequest) (flux.Statistics, error) {
+			p.runs++
+			if p.err != nil {
+				return flux.Statistics{}, p.err
+			}
+			_, err := io.WriteString(w, p.result)
+			return flux.Statistics{TotalDuration: time.Second}, err
+		},
+	}
+	buckets := mock.NewBucketService()
+	buckets.FindBucketByNameFn = func(ctx context.Context, orgID influxdb.ID, name string) (*influxdb.Bucket, error) {
+		return &influxdb.Bucket{ID: testBucketID, OrgID: orgID, Name: name}, nil
+	}
+	p.ProxyQueryService = NewProxyQueryService(c, next, nil, buckets)
+	return p
+}
+
+func (p *testProxy) query(t *testing.T, ctx context.Context) string {
+	t.Helper()
+
+	compiler := testCompiler(t, nil, testPipe(
+		testCall("from", testProperty("bucket", &ast.StringLiteral{Value: "telegraf"})),
+		testCall("range", testProperty("start", testAgo(1, "h"))),
+	))
+	compiler.Now = time.Now()
+	req := &query.ProxyRequest{
+		Request: query.Request{OrganizationID: testOrgID, Compiler: compiler},
+		Dialect: &csv.Dialect{},
+	}
+
+	var buf bytes.Buffer
+	if _, err := p.Query(ctx, &buf, req); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func testContext(t *testing.T, control Control, perms ...influxdb.Permission) context.Context {
+	if perms == nil {
+		p, err := influxdb.NewPermissionAtID(testBucketID, influxdb.ReadAction, influxdb.BucketsResourceType, testOrgID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		perms = append(perms, *p)
+	}
+	ctx := pcontext.SetAuthorizer(context.Background(), mock.NewMockAuthorizer(false, perms))
+	return ContextWithControl(ctx, control)
+}
+
+func TestProxyQueryService(t *testing.T) {
+	c := New(Config{MaxBytes: 1024})
+	p := newTestProxy(c)
+	ctx := testContext(t, Control{MaxAge: time.Hour})
+
+	if got := p.query(t, ctx); got != "result" {
+		t.Fatalf("unexpected result %q", got)
+	}
+	p.result = "changed"
+	if got := p.query(t, ctx); got != "result" {
+		t.Fatalf("expected the cached result, got %q", got)
+	}
+	if p.runs != 1 {
+		t.Fatalf("expected the query to run once, ran %d times", p.runs)
+	}
+
+	// Writing points to the bucket within the range invalidates the result.
+	w := NewPointsWriter(c, &nopPointsWriter{})
+	point := models.MustNewPoint(tsdb.EncodeNameString(testOrgID, testBucketID), nil, models.Fields{"v": 1.0}, time.Now().Add(-time.Minute))
+	if err := w.WritePoints(ctx, []models.Point{point}); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.query(t, ctx); got != "changed" {
+		t.Fatalf("expected the new result, got %q", got)
+	}
+	if p.runs != 2 {
+		t.Fatalf("expected the query to run twice, ran %d times", p.runs)
+	}
+}
+
+func TestProxyQueryService_Bypass(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		ctx  func(t *testing.T) context.Context
+	}{
+		{
+			name: "without control",
+			ctx: func(t *testing.T) context.Context {
+				return testContext(t, Control{})
+			},
+		},
+		{
+			name: "no store",
+			ctx: func(t *testing.T) context.Context {
+				return testContext(t, Control{MaxAge: time.Hour, NoStore: true})
+			},
+		},
+		{
+			name: "without bucket permission",
+			ctx: func(t *testing.T) context.Context {
+				p, err := influxdb.NewPermissionAtID(testBucketID+1, influxdb.ReadAction, influxdb.BucketsResourceType, testOrgID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return testContext(t, Control{MaxAge: time.Hour}, *p)
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{MaxBytes: 1024})
+			p := newTestProxy(c)
+			ctx := tt.ctx(t)
+
+			p.query(t, ctx)
+			p.query(t, ctx)
+			if p.runs != 2 {
+				t.Fatalf("expected the query to run twice, ran %d times", p.runs)
+			}
+			if len(c.entries) != 0 {
+				t.Fatalf("expected no cached results, got %d", len(c.entries))
+			}
+		})
+	}
+}
+
+func TestProxyQueryService_NoCache(t *testing.T) {
+	c := New(Config{MaxBytes: 1024})
+	p := newTestProxy(c)
+
+	p.query(t, testContext(t, Control{MaxAge: time.Hour}))
+	p.result = "changed"
+	if got := p.query(t, testContext(t, Control{NoCache: true})); got != "changed" {
+		t.Fatalf("expected the query to run, got %q", got)
+	}
+	if got := p.query(t, testContext(t, Control{MaxAge: time.Hour})); got != "changed" {
+		t.Fatalf("expected the result stored by the no-cache request, got %q", got)
+	}
+	if p.runs != 2 {
+		t.Fatalf("expected the query to run twice, ran %d times", p.runs)
+	}
+}
+
+func TestProxyQueryService_NotStored(t *testing.T) {
+	c := New(Config{MaxBytes: 1024, MaxEntryBytes: 100})
+	p := newTestProxy(c)
+	ctx := testContext(t, Control{MaxAge: time.Hour})
+
+	p.result = string(make([]byte, 200))
+	if got := p.query(t, ctx); got != p.result {
+		t.Fatal("expected the whole result to be written")
+	}
+
+	p.err = errors.New("query failed")
+	var buf bytes.Buffer
+	if _, err := p.Query(ctx, &buf, &query.ProxyRequest{
+		Request: query.Request{OrganizationID: testOrgID, Compiler: testCompiler(t, nil, testPipe(
+			testCall("from", testProperty("bucket", &ast.StringLiteral{Value: "other"})),
+		))},
+		Dialect: &csv.Dialect{},
+	}); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if len(c.entries) != 0 || len(c.running) != 0 {
+		t.Fatalf("expected no cached or running results, got %d and %d", len(c.entries), len(c.running))
+	}
+}
+
+type nopPointsWriter struct{}
+
+func (*nopPointsWriter) WritePoints(context.Context, []models.Point) error {
+	return nil
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/tsdb"
+)
+
+// PointsWriter invalidates the cached results of the buckets that points are
+// written to.
+type PointsWriter struct {
+	cache *Cache
+	next  storage.PointsWriter
+}
+
+// NewPointsWriter returns a PointsWriter that writes points with next.
+func NewPointsWriter(c *Cache, next storage.PointsWriter) *PointsWriter {
+	return &PointsWriter{cache: c, next: next}
+}
+
+// WritePoints writes the points and invalidates the results that read the
+// buckets at or after the earliest point written to each of them.
+func (w *PointsWriter) WritePoints(ctx context.Context, points []models.Point) error {
+	min := make(map[influxdb.ID]time.Time)
+	for _, p := range points {
+		_, bucketID := tsdb.DecodeNameSlice(p.Name())
+		if t, ok := min[bucketID]; !ok || p.Time().Before(t) {
+			min[bucketID] = p.Time()
+		}
+	}
+
+	// Results are invalidated even if the write fails, as part of the
+	// points may have been written.
+	defer func() {
+		for bucketID, t := range min {
+			w.cache.Invalidate(bucketID, t)
+		}
+	}()
+	return w.next.WritePoints(ctx, points)
+}
+
+// DeleteService invalidates the cached results of the buckets that points are
+// deleted from.
+type DeleteService struct {
+	cache *Cache
+	next  influxdb.DeleteService
+}
+
+// NewDeleteService returns a DeleteService that deletes points with next.
+func NewDeleteService(c *Cache, next influxdb.DeleteService) *DeleteService {
+	return &DeleteService{cache: c, next: next}
+}
+
+// DeleteBucketRangePredicate deletes the points and invalidates the results
+// that read the bucket at or after min.
+func (s *DeleteService) DeleteBucketRangePredicate(ctx context.Context, orgID, bucketID influxdb.ID, min, max int64, pred influxdb.Predicate) error {
+	defer s.cache.Invalidate(bucketID, time.Unix(0, min))
+	return s.next.DeleteBucketRangePredicate(ctx, orgID, bucketID, min, max, pred)
+}
+
+// RestoreService invalidates the cached results of the buckets that data is
+// restored into.
+type RestoreService struct {
+	cache *Cache
+	next  influxdb.RestoreService
+}
+
+// NewRestoreService returns a RestoreService that restores data with next.
+func NewRestoreService(c *Cache, next influxdb.RestoreService) *RestoreService {
+	return &RestoreService{cache: c, next: next}
+}
+
+// RestoreBucketFile restores the data and invalidates all the results that
+// read the target bucket.
+func (s *RestoreService) RestoreBucketFile(ctx context.Context, source, target influxdb.BackupBucket, path string) error {
+	defer s.cache.InvalidateBucket(target.BucketID)
+	return s.next.RestoreBucketFile(ctx, source, target, path)
+}
+
+// BucketService invalidates the cached results of the buckets that are
+// deleted or whose retention period changes.
+type BucketService struct {
+	influxdb.BucketService
+	cache *Cache
+}
+
+// NewBucketService returns a BucketService that manages buckets with next.
+func NewBucketService(c *Cache, next influxdb.BucketService) *BucketService {
+	return &BucketService{BucketService: next, cache: c}
+}
+
+// UpdateBucket updates the bucket and invalidates all the results that read
+// it if its retention period changes.
+func (s *BucketService) UpdateBucket(ctx context.Context, id influxdb.ID, upd influxdb.BucketUpdate) (*influxdb.Bucket, error) {
+	if upd.RetentionPeriod != nil {
+		defer s.cache.InvalidateBucket(id)
+	}
+	return s.BucketService.UpdateBucket(ctx, id, upd)
+}
+
+// DeleteBucket deletes the bucket and invalidates all the results that read it.
+func (s *BucketService) DeleteBucket(ctx context.Context, id influxdb.ID) error {
+	defer s.cache.InvalidateBucket(id)
+	return s.BucketService.DeleteBucket(ctx, id)
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+)
+
+type restoreService struct{}
+
+func (restoreService) RestoreBucketFile(ctx context.Context, source, target influxdb.BackupBucket, path string) error {
+	return nil
+}
+
+func TestRestoreService(t *testing.T) {
+	c := New(Config{MaxBytes: 100})
+	for _, e := range []*entry{
+		newTestEntry("a", 10, time.Unix(50, 0), 1),
+		newTestEntry("b", 10, time.Unix(50, 0), 2),
+	} {
+		c.start(e)
+		c.put(e)
+	}
+
+	s := NewRestoreService(c, restoreService{})
+	if err := s.RestoreBucketFile(context.Background(), influxdb.BackupBucket{OrgID: 3, BucketID: 2}, influxdb.BackupBucket{OrgID: 3, BucketID: 1}, "1.tsm"); err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range map[string]bool{"a": false, "b": true} {
+		if _, ok := c.get(key, time.Time{}); ok != want {
+			t.Errorf("unexpected hit for %s -want/+got:\n\t- %v\n\t+ %v", key, want, ok)
+		}
+	}
+}
+
+func TestBucketService(t *testing.T) {
+	retention := time.Hour
+	name := "renamed"
+	for _, tt := range []struct {
+		name string
+		fn   func(s *BucketService) error
+		want bool
+	}{
+		{
+			name: "delete",
+			fn: func(s *BucketService) error {
+				return s.DeleteBucket(context.Background(), 1)
+			},
+		},
+		{
+			name: "retention change",
+			fn: func(s *BucketService) error {
+				_, err := s.UpdateBucket(context.Background(), 1, influxdb.BucketUpdate{RetentionPeriod: &retention})
+				return err
+			},
+		},
+		{
+			name: "rename",
+			fn: func(s *BucketService) error {
+				_, err := s.UpdateBucket(context.Background(), 1, influxdb.BucketUpdate{Name: &name})
+				return err
+			},
+			want: true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(Config{MaxBytes: 100})
+			e := newTestEntry("a", 10, time.Unix(50, 0), 1)
+			c.start(e)
+			c.put(e)
+
+			if err := tt.fn(NewBucketService(c, mock.NewBucketService())); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := c.get("a", time.Time{}); ok != tt.want {
+				t.Errorf("unexpected hit -want/+got:\n\t- %v\n\t+ %v", tt.want, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package control
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/ast"
+	"github.com/influxdata/flux/lang"
+	"github.com/influxdata/influxdb/v2"
+	"go.uber.org/zap"
+)
+
+var _ influxdb.ActiveQueryService = (*Controller)(nil)
+
+// FindActiveQueryByID returns the active query with the ID.
+func (c *Controller) FindActiveQueryByID(ctx context.Context, id influxdb.ID) (*influxdb.ActiveQuery, error) {
+	q, err := c.findQuery(id)
+	if err != nil {
+		return nil, err
+	}
+	return q.active(), nil
+}
+
+// FindActiveQueries returns the active queries that match the filter,
+// the longest running first.
+func (c *Controller) FindActiveQueries(ctx context.Context, filter influxdb.ActiveQueryFilter) ([]*influxdb.ActiveQuery, error) {
+	var queries []*influxdb.ActiveQuery
+	for _, q := range c.Queries() {
+		if filter.OrgID != nil && q.orgID != *filter.OrgID {
+			continue
+		}
+		queries = append(queries, q.active())
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].ID < queries[j].ID
+	})
+	return queries, nil
+}
+
+// CancelActiveQuery cancels the active query with the ID.
+func (c *Controller) CancelActiveQuery(ctx context.Context, id influxdb.ID) error {
+	q, err := c.findQuery(id)
+	if err != nil {
+		return err
+	}
+	c.log.Info("Canceling query", zap.Stringer("query_id", id), zap.Stringer("org_id", q.orgID))
+	q.Cancel()
+	return nil
+}
+
+func (c *Controller) findQuery(id influxdb.ID) (*Query, error) {
+	c.queriesMu.RLock()
+	q, ok := c.queries[QueryID(id)]
+	c.queriesMu.RUnlock()
+	if !ok {
+		return nil, &influxdb.Error{
+			Code: influxdb.ENotFound,
+			Msg:  influxdb.ErrActiveQueryNotFound,
+		}
+	}
+	return q, nil
+}
+
+// active returns the state of the query as an active query.
+func (q *Query) active() *influxdb.ActiveQuery {
+	aq := &influxdb.ActiveQuery{
+		ID:              influxdb.ID(q.id),
+		OrgID:           q.orgID,
+		AuthorizationID: q.authorizationID,
+		UserID:          q.userID,
+		CompilerType:    string(q.compilerType),
+		State:           q.State().String(),
+		StartedAt:       q.createdAt,
+		Runtime:         time.Since(q.createdAt),
+		Query:           q.text,
+	}
+	q.stateMu.RLock()
+	if q.alloc != nil {
+		aq.MemoryAllocated = q.alloc.Allocated()
+	}
+	q.stateMu.RUnlock()
+	return aq
+}
+
+// queryText returns the source of the query of the compiler.
+func queryText(compiler flux.Compiler) string {
+	switch c := compiler.(type) {
+	case lang.FluxCompiler:
+		return c.Query
+	case lang.ASTCompiler:
+		pkg := new(ast.Package)
+		if err := json.Unmarshal(c.AST, pkg); err != nil {
+			return ""
+		}
+		return ast.Format(pkg)
+	default:
+		// The other compilers, such as those of InfluxQL and
+		// PromQL, encode their source in a query property.
+		var v struct {
+			Query string `json:"query"`
+		}
+		b, err := json.Marshal(compiler)
+		if err != nil {
+			return ""
+		}
+		_ = json.Unmarshal(b, &v)
+		return v.Query
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"runtime/debug"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/influxdata/flux"
 	"github.com/influxdata/flux/codes"
@@ -30,6 +31,7 @@ import (
 	"github.com/influxdata/flux/memory"
 	"github.com/influxdata/flux/runtime"
 	"github.com/influxdata/influxdb/v2"
+	icontext "github.com/influxdata/influxdb/v2/context"
 	"github.com/influxdata/influxdb/v2/kit/errors"
 	"github.com/influxdata/influxdb/v2/kit/prom"
 	"github.com/influxdata/influxdb/v2/kit/tracing"
@@ -50,7 +52,7 @@ type Controller struct {
 	lastID     uint64
 	queriesMu  sync.RWMutex
 	queries    map[QueryID]*Query
-	queryQueue chan *Query
+	queryQueue *fairQueue
 	wg         sync.WaitGroup
 	shutdown   bool
 	done       chan struct{}
@@ -99,6 +101,11 @@ type Config struct {
 	MetricLabelKeys []string
 
 	ExecutorDependencies []flux.Dependency
+
+	// OrganizationSettings looks up the query settings of the organization of
+	// each query, which limit its queries within the limits above. If this is
+	// unset, the queries of all organizations share the limits above equally.
+	OrganizationSettings influxdb.OrganizationSettingsService
 }
 
 // complete will fill in the defaults, validate the configuration, and
@@ -175,7 +182,7 @@ func New(config Config) (*Controller, error) {
 	ctrl := &Controller{
 		config:       c,
 		queries:      make(map[QueryID]*Query),
-		queryQueue:   make(chan *Query, c.QueueSize),
+		queryQueue:   newFairQueue(c.QueueSize),
 		done:         make(chan struct{}),
 		abort:        make(chan struct{}),
 		memory:       mm,
@@ -207,7 +214,7 @@ func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query,
 	for _, dep := range c.dependencies {
 		ctx = dep.Inject(ctx)
 	}
-	q, err := c.query(ctx, req.Compiler)
+	q, err := c.query(ctx, c.orgSettings(ctx, req.OrganizationID), req.Compiler)
 	if err != nil {
 		return q, err
 	}
@@ -215,13 +222,36 @@ func (c *Controller) Query(ctx context.Context, req *query.Request) (flux.Query,
 	return q, nil
 }
 
+// orgSettings returns the query settings of the organization. The
+// settings are left zero if they cannot be looked up.
+func (c *Controller) orgSettings(ctx context.Context, orgID influxdb.ID) influxdb.OrganizationSettings {
+	settings := influxdb.OrganizationSettings{OrgID: orgID}
+	if c.config.OrganizationSettings == nil || !orgID.Valid() {
+		return settings
+	}
+
+	s, err := c.config.OrganizationSettings.FindOrganizationSettings(ctx, orgID)
+	if err != nil {
+		c.log.Info("Failed to find organization settings, using defaults", zap.Stringer("org_id", orgID), zap.Error(err))
+		return settings
+	}
+	return *s
+}
+
 // query submits a query for execution returning immediately.
 // Done must be called on any returned Query objects.
-func (c *Controller) query(ctx context.Context, compiler flux.Compiler) (flux.Query, error) {
+func (c *Controller) query(ctx context.Context, settings influxdb.OrganizationSettings, compiler flux.Compiler) (flux.Query, error) {
 	q, err := c.createQuery(ctx, compiler.CompilerType())
 	if err != nil {
 		return nil, handleFluxError(err)
 	}
+	q.orgID = settings.OrgID
+	q.orgSettings = settings
+	q.text = queryText(compiler)
+	if a, err := icontext.GetAuthorizer(ctx); err == nil {
+		q.authorizationID = a.Identifier()
+		q.userID = a.GetUserID()
+	}
 
 	if err := c.compileQuery(q, compiler); err != nil {
 		q.setErr(err)
@@ -275,6 +305,8 @@ func (c *Controller) createQuery(ctx context.Context, ct flux.CompilerType) (*Qu
 		labelValues:        labelValues,
 		compileLabelValues: compileLabelValues,
 		state:              Created,
+		createdAt:          time.Now(),
+		compilerType:       ct,
 		c:                  c,
 		results:            make(chan flux.Result),
 		parentCtx:          parentCtx,
@@ -363,26 +395,17 @@ func (c *Controller) enqueueQuery(q *Query) error {
 		}
 	}
 
-	select {
-	case c.queryQueue <- q:
-	default:
-		return &flux.Error{
-			Code: codes.ResourceExhausted,
-			Msg:  "queue length exceeded",
-		}
-	}
-
-	return nil
+	return c.queryQueue.push(q)
 }
 
 func (c *Controller) processQueryQueue() {
 	for {
-		select {
-		case <-c.done:
+		q, ok := c.queryQueue.pop()
+		if !ok {
 			return
-		case q := <-c.queryQueue:
-			c.executeQuery(q)
 		}
+		c.executeQuery(q)
+		c.queryQueue.done(q)
 	}
 }
 
@@ -445,6 +468,7 @@ func (c *Controller) finish(q *Query) {
 	delete(c.queries, q.id)
 	if len(c.queries) == 0 && c.shutdown {
 		close(c.done)
+		c.queryQueue.close()
 	}
 	c.queriesMu.Unlock()
 }
@@ -545,7 +569,25 @@ type Query struct {
 	results chan flux.Result
 
 	memoryManager *queryMemoryManager
-	alloc         *memory.Allocator
+	// alloc is set while holding the stateMu, so that the memory of
+	// the query can be reported while it executes.
+	alloc *memory.Allocator
+
+	// createdAt is when the query was submitted, text is its source, and
+	// authorizationID and userID identify who submitted it.
+	createdAt       time.Time
+	compilerType    flux.CompilerType
+	text            string
+	authorizationID influxdb.ID
+	userID          influxdb.ID
+
+	// orgID is the organization the query is run for and orgSettings its
+	// query settings. The queueOrg and throttled fields are set by the
+	// query queue while holding its mutex.
+	orgID       influxdb.ID
+	orgSettings influxdb.OrganizationSettings
+	queueOrg    *queueOrg
+	throttled   bool
 }
 
 // ID reports an ephemeral unique ID for the query.
@@ -572,6 +614,16 @@ func (q *Query) Results() <-chan flux.Result {
 	return q.results
 }
 
+// throttledCounter returns the counter of the queries of the organization of
+// this query that were throttled for the reason.
+func (q *Query) throttledCounter(reason throttledLabel) prometheus.Counter {
+	l := len(q.labelValues)
+	lvs := make([]string, l+1)
+	copy(lvs, q.labelValues)
+	lvs[l] = string(reason)
+	return q.c.metrics.throttled.WithLabelValues(lvs...)
+}
+
 func (q *Query) recordUnusedMemory() {
 	unused := q.c.GetUnusedMemoryBytes()
 	q.c.metrics.memoryUnused.WithLabelValues(q.labelValues...).Set(float64(unused))
@@ -1015,6 +1067,8 @@ func handleFluxError(err error) error {
 		codes.OutOfRange,
 		codes.Unimplemented:
 		code = influxdb.EInvalid
+	case codes.Unavailable:
+		code = influxdb.EUnavailable
 	case codes.PermissionDenied:
 		code = influxdb.EForbidden
 	case codes.Unauthenticated:
@@ -20,6 +20,9 @@ import (
 	"github.com/influxdata/flux/plan"
 	"github.com/influxdata/flux/plan/plantest"
 	"github.com/influxdata/flux/stdlib/universe"
+	platform "github.com/influxdata/influxdb/v2"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	pmock "github.com/influxdata/influxdb/v2/mock"
 	"github.com/influxdata/influxdb/v2/query"
 	_ "github.com/influxdata/influxdb/v2/query/builtin"
 	"github.com/influxdata/influxdb/v2/query/control"
@@ -818,6 +821,178 @@ func TestController_QueueSize(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected an error about queue length exceeded")
 	}
+	if code := platform.ErrorCode(err); code != platform.EInvalid {
+		t.Fatalf("unexpected error code for queue length exceeded: %s", code)
+	}
+}
+
+func TestController_OrganizationQuotas(t *testing.T) {
+	var (
+		limitedOrg = platform.ID(1)
+		otherOrg   = platform.ID(2)
+	)
+
+	config := config
+	config.ConcurrencyQuota = 2
+	config.QueueSize = 5
+	config.OrganizationSettings = &pmock.OrganizationSettingsService{
+		FindOrganizationSettingsFn: func(ctx context.Context, orgID platform.ID) (*platform.OrganizationSettings, error) {
+			if orgID == limitedOrg {
+				return &platform.OrganizationSettings{OrgID: orgID, QueryConcurrencyQuota: 1, QueryQueueSize: 1}, nil
+			}
+			return &platform.OrganizationSettings{OrgID: orgID}, nil
+		},
+	}
+	ctrl, err := control.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(t, ctrl)
+
+	reg := setupPromRegistry(ctrl)
+
+	// This channel blocks program execution until we are done
+	// with running the test.
+	done := make(chan struct{})
+	defer close(done)
+
+	executing := make(chan struct{}, config.ConcurrencyQuota+config.QueueSize)
+	compiler := &mock.Compiler{
+		CompileFn: func(ctx context.Context) (flux.Program, error) {
+			return &mock.Program{
+				ExecuteFn: func(ctx context.Context, q *mock.Query, alloc *memory.Allocator) {
+					executing <- struct{}{}
+					// Block until test is finished
+					<-done
+				},
+			}, nil
+		},
+	}
+	runQuery := func(orgID platform.ID) error {
+		req := makeRequest(compiler)
+		req.OrganizationID = orgID
+		q, err := ctrl.Query(context.Background(), req)
+		if err != nil {
+			return err
+		}
+		go func() {
+			for range q.Results() {
+				// discard the results
+			}
+			q.Done()
+		}()
+		return nil
+	}
+
+	// The limited org may execute one query and queue one more.
+	if err := runQuery(limitedOrg); err != nil {
+		t.Fatal(err)
+	}
+	<-executing
+	if err := runQuery(limitedOrg); err != nil {
+		t.Fatal(err)
+	}
+	err = runQuery(limitedOrg)
+	if ierr, ok := err.(*platform.Error); !ok || ierr.Err != query.ErrOrganizationQueueFull {
+		t.Fatalf("expected organization queue length exceeded error, got: %v", err)
+	}
+
+	// The other org gets the remaining concurrency.
+	if err := runQuery(otherOrg); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-executing:
+	case <-time.After(time.Second):
+		t.Fatal("expected the query of the other org to execute")
+	}
+	select {
+	case <-executing:
+		t.Fatal("expected the queued query of the limited org to stay queued")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	metrics, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for reason, want := range map[string]float64{
+		"org_queue_full":    1,
+		"concurrency_quota": 1,
+	} {
+		m := FindMetric(metrics, "query_control_throttled_total", map[string]string{
+			"org":    limitedOrg.String(),
+			"reason": reason,
+		})
+		if m == nil || *m.Counter.Value != want {
+			t.Errorf("unexpected %s throttled total: got %v want %v", reason, m, want)
+		}
+	}
+}
+
+func TestController_ActiveQueries(t *testing.T) {
+	ctrl, err := control.New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shutdown(t, ctrl)
+
+	executing := make(chan struct{})
+	compiler := &mock.Compiler{
+		Type: "test",
+		CompileFn: func(ctx context.Context) (flux.Program, error) {
+			return &mock.Program{
+				ExecuteFn: func(ctx context.Context, q *mock.Query, alloc *memory.Allocator) {
+					close(executing)
+					<-ctx.Done()
+				},
+			}, nil
+		},
+	}
+
+	orgID := platform.ID(1)
+	ctx := icontext.SetAuthorizer(context.Background(), &platform.Authorization{ID: 3, UserID: 4})
+	req := makeRequest(compiler)
+	req.OrganizationID = orgID
+	q, err := ctrl.Query(ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer q.Done()
+	<-executing
+
+	active, err := ctrl.FindActiveQueries(ctx, platform.ActiveQueryFilter{OrgID: &orgID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(active) != 1 {
+		t.Fatalf("expected one active query, got %d", len(active))
+	}
+	got := active[0]
+	if got.OrgID != orgID || got.AuthorizationID != 3 || got.UserID != 4 || got.CompilerType != "test" || got.State != "executing" {
+		t.Fatalf("unexpected active query: %+v", got)
+	}
+
+	otherOrgID := platform.ID(2)
+	if active, err := ctrl.FindActiveQueries(ctx, platform.ActiveQueryFilter{OrgID: &otherOrgID}); err != nil || len(active) != 0 {
+		t.Fatalf("expected no active queries of the other org, got %v %v", active, err)
+	}
+
+	if err := ctrl.CancelActiveQuery(ctx, got.ID); err != nil {
+		t.Fatal(err)
+	}
+	if state := q.(*control.Query).State(); state != control.Canceled {
+		t.Fatalf("expected the query to be canceled, got: %v", state)
+	}
+	for range q.Results() {
+		// discard the results
+	}
+	q.Done()
+
+	err = ctrl.CancelActiveQuery(ctx, got.ID)
+	if code := platform.ErrorCode(err); code != platform.ENotFound {
+		t.Fatalf("expected the finished query not to be found, got: %v", err)
+	}
 }
 
 // Test that rapidly starting and canceling the query and then calling done will correctly
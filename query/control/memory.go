@@ -6,6 +6,7 @@ import (
 	"sync/atomic"
 
 	"github.com/influxdata/flux/memory"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 type memoryManager struct {
@@ -44,14 +45,23 @@ func (m *memoryManager) addUnusedMemoryBytes(amount int64) int64 {
 // for the given query.
 func (c *Controller) createAllocator(q *Query) {
 	q.memoryManager = &queryMemoryManager{
-		m:     c.memory,
-		limit: c.memory.initialBytesQuotaPerQuery,
+		m:         c.memory,
+		limit:     c.memory.initialBytesQuotaPerQuery,
+		org:       q.queueOrg,
+		orgQuota:  q.orgSettings.QueryMemoryBytesQuota,
+		throttled: q.throttledCounter(throttledMemoryQuota),
 	}
-	q.alloc = &memory.Allocator{
+	// The initial memory of a query is always given, but it counts
+	// against the memory quota of its organization.
+	q.memoryManager.org.addMemory(q.memoryManager.limit)
+	alloc := &memory.Allocator{
 		// Use an anonymous function to ensure the value is copied.
 		Limit:   func(v int64) *int64 { return &v }(q.memoryManager.limit),
 		Manager: q.memoryManager,
 	}
+	q.stateMu.Lock()
+	q.alloc = alloc
+	q.stateMu.Unlock()
 }
 
 // queryMemoryManager is a memory manager for a specific query.
@@ -59,6 +69,13 @@ type queryMemoryManager struct {
 	m     *memoryManager
 	limit int64
 	given int64
+
+	// org is the organization whose memory quota the query uses.
+	org      *queueOrg
+	orgQuota int64
+	// throttled counts the requests refused because of the memory quota
+	// of the organization.
+	throttled prometheus.Counter
 }
 
 // RequestMemory will determine if the query can be given more memory
@@ -94,11 +111,20 @@ func (q *queryMemoryManager) RequestMemory(want int64) (got int64, err error) {
 		// this method.
 		given := q.giveMemory(want, unused)
 
+		// Reserve the memory from the quota of the organization.
+		given, ok := q.org.reserveMemory(q.orgQuota, want, given)
+		if !ok {
+			q.throttled.Inc()
+			return 0, errors.New("organization hit memory quota")
+		}
+
 		// Reserve this memory for our own use.
 		if !q.m.unlimited {
 			if !q.m.trySetUnusedMemoryBytes(unused, unused-given) {
 				// The unused value has changed so someone may have taken
-				// the memory that we wanted. Retry.
+				// the memory that we wanted. Give the memory back to the
+				// organization and retry.
+				q.org.addMemory(-given)
 				continue
 			}
 		}
@@ -152,6 +178,7 @@ func (q *queryMemoryManager) Release() {
 	if !q.m.unlimited {
 		q.m.addUnusedMemoryBytes(q.given)
 	}
+	q.org.addMemory(-q.limit)
 	q.limit = q.m.initialBytesQuotaPerQuery
 	q.given = 0
 }
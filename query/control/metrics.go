@@ -6,6 +6,7 @@ import "github.com/prometheus/client_golang/prometheus"
 type controllerMetrics struct {
 	requests  *prometheus.CounterVec
 	functions *prometheus.CounterVec
+	throttled *prometheus.CounterVec
 
 	all          *prometheus.GaugeVec
 	compiling    *prometheus.GaugeVec
@@ -28,6 +29,15 @@ const (
 	labelQueueError   = requestsLabel("queue_error")
 )
 
+type throttledLabel string
+
+const (
+	throttledQueueFull        = throttledLabel("queue_full")
+	throttledOrgQueueFull     = throttledLabel("org_queue_full")
+	throttledConcurrencyQuota = throttledLabel("concurrency_quota")
+	throttledMemoryQuota      = throttledLabel("memory_quota")
+)
+
 func newControllerMetrics(labels []string) *controllerMetrics {
 	const (
 		namespace = "query"
@@ -49,6 +59,13 @@ func newControllerMetrics(labels []string) *controllerMetrics {
 			Help:      "Count of functions in queries",
 		}, append(labels, "function")),
 
+		throttled: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: subsystem,
+			Name:      "throttled_total",
+			Help:      "Count of the queries held back or rejected by a queue or quota",
+		}, append(labels, "reason")),
+
 		all: prometheus.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: subsystem,
@@ -123,6 +140,7 @@ func (cm *controllerMetrics) PrometheusCollectors() []prometheus.Collector {
 	return []prometheus.Collector{
 		cm.requests,
 		cm.functions,
+		cm.throttled,
 
 		cm.all,
 		cm.compiling,
@@ -0,0 +1,210 @@
+package control
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/query"
+)
+
+// fairQueue queues the queries of all organizations and dispatches them
+// in weighted fair order.
+//
+// Each organization has a virtual time that advances by the inverse of its
+// query weight whenever one of its queries is dispatched. The next query is
+// taken from the organization with the lowest virtual time that is within
+// its concurrency quota, so an organization with a weight of 2 gets twice
+// as many queries dispatched as an organization with a weight of 1 while
+// both have queries queued. Queries of an organization are dispatched in
+// the order they were queued.
+type fairQueue struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// size is the number of queries that may be queued over all organizations.
+	size int
+	len  int
+	orgs map[influxdb.ID]*queueOrg
+	// vclock is the virtual time of the last dispatched query. Organizations
+	// that start queueing queries start at this time so they cannot claim the
+	// share they did not use while idle.
+	vclock float64
+	closed bool
+}
+
+// queueOrg is the state of an organization with queued or executing queries.
+type queueOrg struct {
+	queries []*Query
+	running int
+	vtime   float64
+	limits  influxdb.OrganizationSettings
+
+	// memoryUsed is the memory allocated to the executing queries of the
+	// organization. It is updated atomically by the query memory managers.
+	memoryUsed int64
+}
+
+func newFairQueue(size int) *fairQueue {
+	fq := &fairQueue{
+		size: size,
+		orgs: make(map[influxdb.ID]*queueOrg),
+	}
+	fq.cond = sync.NewCond(&fq.mu)
+	return fq
+}
+
+// push queues the query or returns an error if the queue of the controller
+// or of the organization of the query is full.
+func (fq *fairQueue) push(q *Query) error {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	if fq.len >= fq.size {
+		q.throttledCounter(throttledQueueFull).Inc()
+		return &flux.Error{
+			Code: codes.ResourceExhausted,
+			Err:  query.ErrQueueFull,
+		}
+	}
+
+	o, ok := fq.orgs[q.orgID]
+	if !ok {
+		o = &queueOrg{vtime: fq.vclock}
+		fq.orgs[q.orgID] = o
+	}
+	o.limits = q.orgSettings
+	if o.limits.QueryQueueSize > 0 && len(o.queries) >= o.limits.QueryQueueSize {
+		fq.removeIfIdle(q.orgID, o)
+		q.throttledCounter(throttledOrgQueueFull).Inc()
+		return &flux.Error{
+			Code: codes.ResourceExhausted,
+			Err:  query.ErrOrganizationQueueFull,
+		}
+	}
+	if len(o.queries) == 0 && o.vtime < fq.vclock {
+		o.vtime = fq.vclock
+	}
+
+	o.queries = append(o.queries, q)
+	q.queueOrg = o
+	fq.len++
+	fq.cond.Signal()
+	return nil
+}
+
+// pop waits for the next query to dispatch. It returns false once the queue
+// has been closed.
+func (fq *fairQueue) pop() (*Query, bool) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	for {
+		if fq.closed {
+			return nil, false
+		}
+		if q := fq.next(); q != nil {
+			return q, true
+		}
+		fq.cond.Wait()
+	}
+}
+
+// next dequeues the next query to dispatch, or returns nil if no
+// organization with queued queries is within its concurrency quota.
+func (fq *fairQueue) next() *Query {
+	var (
+		nextID  influxdb.ID
+		nextOrg *queueOrg
+	)
+	for id, o := range fq.orgs {
+		if len(o.queries) == 0 {
+			continue
+		}
+		if quota := o.limits.QueryConcurrencyQuota; quota > 0 && o.running >= quota {
+			// Canceled queries do not execute, so they are dispatched
+			// regardless of the quota to report their cancellation.
+			if q := o.queries[0]; q.parentCtx.Err() == nil {
+				if !q.throttled {
+					q.throttled = true
+					q.throttledCounter(throttledConcurrencyQuota).Inc()
+				}
+				continue
+			}
+		}
+		if nextOrg == nil || o.vtime < nextOrg.vtime || (o.vtime == nextOrg.vtime && id < nextID) {
+			nextID, nextOrg = id, o
+		}
+	}
+	if nextOrg == nil {
+		return nil
+	}
+
+	q := nextOrg.queries[0]
+	nextOrg.queries[0] = nil
+	nextOrg.queries = nextOrg.queries[1:]
+	nextOrg.running++
+	fq.len--
+
+	fq.vclock = nextOrg.vtime
+	weight := nextOrg.limits.QueryWeight
+	if weight <= 0 {
+		weight = 1
+	}
+	nextOrg.vtime += 1 / float64(weight)
+	return q
+}
+
+// done marks a query returned by pop as no longer executing.
+func (fq *fairQueue) done(q *Query) {
+	fq.mu.Lock()
+	defer fq.mu.Unlock()
+
+	if o, ok := fq.orgs[q.orgID]; ok {
+		o.running--
+		fq.removeIfIdle(q.orgID, o)
+	}
+	fq.cond.Signal()
+}
+
+// close wakes up all goroutines waiting in pop and makes them return.
+func (fq *fairQueue) close() {
+	fq.mu.Lock()
+	fq.closed = true
+	fq.mu.Unlock()
+	fq.cond.Broadcast()
+}
+
+func (fq *fairQueue) removeIfIdle(id influxdb.ID, o *queueOrg) {
+	if o.running == 0 && len(o.queries) == 0 {
+		delete(fq.orgs, id)
+	}
+}
+
+// reserveMemory reserves the given bytes of the memory quota of the
+// organization. It reserves less if the quota cannot fit all of it, but
+// nothing if it cannot fit min. A quota of zero is unlimited.
+func (o *queueOrg) reserveMemory(quota, min, bytes int64) (int64, bool) {
+	for {
+		used := atomic.LoadInt64(&o.memoryUsed)
+		if quota > 0 {
+			if used+min > quota {
+				return 0, false
+			}
+			if used+bytes > quota {
+				bytes = quota - used
+			}
+		}
+		if atomic.CompareAndSwapInt64(&o.memoryUsed, used, used+bytes) {
+			return bytes, true
+		}
+	}
+}
+
+// addMemory adds the given bytes to the memory used by the organization
+// regardless of its quota.
+func (o *queueOrg) addMemory(bytes int64) {
+	atomic.AddInt64(&o.memoryUsed, bytes)
+}
@@ -0,0 +1,152 @@
+package control
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func newQueueTestQuery(c *Controller, settings influxdb.OrganizationSettings) *Query {
+	return &Query{
+		c:           c,
+		labelValues: []string{settings.OrgID.String()},
+		parentCtx:   context.Background(),
+		orgID:       settings.OrgID,
+		orgSettings: settings,
+	}
+}
+
+func TestFairQueue_Weight(t *testing.T) {
+	c := &Controller{metrics: newControllerMetrics([]string{orgLabel})}
+	fq := newFairQueue(100)
+
+	heavy := influxdb.OrganizationSettings{OrgID: 1, QueryWeight: 2}
+	light := influxdb.OrganizationSettings{OrgID: 2}
+	for i := 0; i < 6; i++ {
+		if err := fq.push(newQueueTestQuery(c, heavy)); err != nil {
+			t.Fatal(err)
+		}
+		if err := fq.push(newQueueTestQuery(c, light)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []influxdb.ID
+	for i := 0; i < 6; i++ {
+		q, ok := fq.pop()
+		if !ok {
+			t.Fatal("expected a query")
+		}
+		got = append(got, q.orgID)
+		fq.done(q)
+	}
+
+	exp := []influxdb.ID{1, 2, 1, 1, 2, 1}
+	for i := range exp {
+		if got[i] != exp[i] {
+			t.Fatalf("unexpected dispatch order: got %v want %v", got, exp)
+		}
+	}
+}
+
+func TestFairQueue_ConcurrencyQuota(t *testing.T) {
+	c := &Controller{metrics: newControllerMetrics([]string{orgLabel})}
+	fq := newFairQueue(100)
+
+	limited := influxdb.OrganizationSettings{OrgID: 1, QueryConcurrencyQuota: 1}
+	other := influxdb.OrganizationSettings{OrgID: 2}
+	for _, s := range []influxdb.OrganizationSettings{limited, limited, other} {
+		if err := fq.push(newQueueTestQuery(c, s)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	first, _ := fq.pop()
+	if first.orgID != 1 {
+		t.Fatalf("expected a query of org 1, got org %s", first.orgID)
+	}
+	if q, _ := fq.pop(); q.orgID != 2 {
+		t.Fatalf("expected the query of org 2 to skip the limited org, got org %s", q.orgID)
+	}
+	if q := fq.next(); q != nil {
+		t.Fatal("expected no query to be dispatched while org 1 is at its quota")
+	}
+	if got := counterValue(t, c, limited.OrgID.String(), throttledConcurrencyQuota); got != 1 {
+		t.Fatalf("unexpected concurrency quota throttled count: got %v want 1", got)
+	}
+
+	fq.done(first)
+	if q, _ := fq.pop(); q.orgID != 1 {
+		t.Fatalf("expected a query of org 1, got org %s", q.orgID)
+	}
+}
+
+func TestFairQueue_QueueSize(t *testing.T) {
+	c := &Controller{metrics: newControllerMetrics([]string{orgLabel})}
+	fq := newFairQueue(2)
+
+	limited := influxdb.OrganizationSettings{OrgID: 1, QueryQueueSize: 1}
+	if err := fq.push(newQueueTestQuery(c, limited)); err != nil {
+		t.Fatal(err)
+	}
+	if err := fq.push(newQueueTestQuery(c, limited)); err == nil || err.Error() != "organization queue length exceeded" {
+		t.Fatalf("expected organization queue length exceeded error, got %v", err)
+	}
+	if err := fq.push(newQueueTestQuery(c, influxdb.OrganizationSettings{OrgID: 2})); err != nil {
+		t.Fatal(err)
+	}
+	if err := fq.push(newQueueTestQuery(c, influxdb.OrganizationSettings{OrgID: 3})); err == nil || err.Error() != "queue length exceeded" {
+		t.Fatalf("expected queue length exceeded error, got %v", err)
+	}
+
+	if got := counterValue(t, c, limited.OrgID.String(), throttledOrgQueueFull); got != 1 {
+		t.Fatalf("unexpected org queue full throttled count: got %v want 1", got)
+	}
+	if got := counterValue(t, c, influxdb.ID(3).String(), throttledQueueFull); got != 1 {
+		t.Fatalf("unexpected queue full throttled count: got %v want 1", got)
+	}
+}
+
+func TestFairQueue_Close(t *testing.T) {
+	fq := newFairQueue(1)
+
+	done := make(chan bool)
+	go func() {
+		_, ok := fq.pop()
+		done <- ok
+	}()
+
+	fq.close()
+	if ok := <-done; ok {
+		t.Fatal("expected pop to return false after close")
+	}
+}
+
+func TestQueueOrg_ReserveMemory(t *testing.T) {
+	o := &queueOrg{}
+	o.addMemory(100)
+
+	if got, ok := o.reserveMemory(200, 10, 50); !ok || got != 50 {
+		t.Fatalf("unexpected reservation: got %d, %v want 50, true", got, ok)
+	}
+	if got, ok := o.reserveMemory(200, 10, 100); !ok || got != 50 {
+		t.Fatalf("unexpected capped reservation: got %d, %v want 50, true", got, ok)
+	}
+	if _, ok := o.reserveMemory(200, 10, 10); ok {
+		t.Fatal("expected reservation over the quota to be refused")
+	}
+	if got, ok := o.reserveMemory(0, 10, 1000); !ok || got != 1000 {
+		t.Fatalf("unexpected unlimited reservation: got %d, %v want 1000, true", got, ok)
+	}
+}
+
+func counterValue(t *testing.T, c *Controller, org string, reason throttledLabel) float64 {
+	t.Helper()
+	var m dto.Metric
+	if err := c.metrics.throttled.WithLabelValues(org, string(reason)).Write(&m); err != nil {
+		t.Fatal(err)
+	}
+	return m.GetCounter().GetValue()
+}
@@ -0,0 +1,82 @@
+// Package native implements an InfluxQL query engine that plans statements
+// directly against the storage engine instead of transpiling them to Flux.
+//
+// The engine preserves the InfluxQL semantics that do not survive the
+// translation to Flux, such as fill modes, SLIMIT and SOFFSET, subqueries and
+// the SHOW statements that are answered from the index.
+package native
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxql"
+)
+
+// CompilerType is the compiler type of the native InfluxQL engine.
+const CompilerType = "influxql_native"
+
+// AddCompilerMappings adds the native influxql compiler mappings.
+func AddCompilerMappings(mappings flux.CompilerMappings, dbrpMappingSvc platform.DBRPMappingService) error {
+	return mappings.Add(CompilerType, func() flux.Compiler {
+		return NewCompiler(dbrpMappingSvc)
+	})
+}
+
+// Compiler compiles an InfluxQL query into a program executed by the native engine.
+type Compiler struct {
+	Cluster string     `json:"cluster,omitempty"`
+	DB      string     `json:"db,omitempty"`
+	RP      string     `json:"rp,omitempty"`
+	Bucket  string     `json:"bucket,omitempty"`
+	Query   string     `json:"query"`
+	Now     *time.Time `json:"now,omitempty"`
+
+	dbrpMappingSvc platform.DBRPMappingService
+}
+
+var _ flux.Compiler = &Compiler{}
+
+// NewCompiler returns a new compiler that resolves databases and retention
+// policies using dbrpMappingSvc.
+func NewCompiler(dbrpMappingSvc platform.DBRPMappingService) *Compiler {
+	return &Compiler{
+		dbrpMappingSvc: dbrpMappingSvc,
+	}
+}
+
+// Compile parses the query into a program.
+func (c *Compiler) Compile(ctx context.Context, runtime flux.Runtime) (flux.Program, error) {
+	q, err := influxql.ParseQuery(c.Query)
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "failed to parse influxql query",
+			Err:  err,
+		}
+	}
+
+	now := time.Now()
+	if c.Now != nil {
+		now = *c.Now
+	}
+
+	return &program{
+		query: q,
+		executor: &statementExecutor{
+			cluster:        c.Cluster,
+			db:             c.DB,
+			rp:             c.RP,
+			bucket:         c.Bucket,
+			now:            now,
+			dbrpMappingSvc: c.dbrpMappingSvc,
+		},
+	}, nil
+}
+
+// CompilerType returns the native influxql compiler type.
+func (c *Compiler) CompilerType() flux.CompilerType {
+	return CompilerType
+}
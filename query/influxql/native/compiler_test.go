@@ -0,0 +1,477 @@
+package native_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/gogo/protobuf/types"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/query/influxql/native"
+	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/v2/tsdb"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+)
+
+const (
+	orgID    platform.ID = 0x3131313131313131
+	bucketID platform.ID = 0x3232323232323232
+)
+
+const testData = `
+cpu,host=a,region=west value=1 0
+cpu,host=a,region=west value=3 10000000000
+cpu,host=a,region=west value=5 30000000000
+cpu,host=b,region=east value=2 0
+cpu,host=b,region=east value=6 20000000000
+cpu,host=c,region=east value=4 10000000000
+mem,host=a free=10i,used=2i 0
+mem,host=a free=8i,used=4i 10000000000
+`
+
+func TestCompiler_Select(t *testing.T) {
+	deps := newTestDependencies(t, testData)
+
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "raw",
+			query: `SELECT value FROM cpu WHERE host = 'a' AND value > 1`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value"],"values":[["1970-01-01T00:00:10Z",3],["1970-01-01T00:00:30Z",5]]}]}]}`,
+		},
+		{
+			name:  "raw with tags and limit",
+			query: `SELECT value, host FROM cpu ORDER BY time DESC LIMIT 2`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","value","host"],"values":[["1970-01-01T00:00:30Z",5,"a"],["1970-01-01T00:00:20Z",6,"b"]]}]}]}`,
+		},
+		{
+			name:  "math",
+			query: `SELECT free / used FROM mem`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"mem","columns":["time","free_used"],"values":[["1970-01-01T00:00:00Z",5],["1970-01-01T00:00:10Z",2]]}]}]}`,
+		},
+		{
+			name:  "aggregate",
+			query: `SELECT mean(value), max(value), count(value) FROM cpu`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean","max","count"],"values":[["1970-01-01T00:00:00Z",3.5,6,6]]}]}]}`,
+		},
+		{
+			name:  "selector time",
+			query: `SELECT max(value) FROM cpu WHERE region = 'east'`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","max"],"values":[["1970-01-01T00:00:20Z",6]]}]}]}`,
+		},
+		{
+			name:  "fill null",
+			query: `SELECT mean(value) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:10Z",3],["1970-01-01T00:00:20Z",null],["1970-01-01T00:00:30Z",5]]}]}]}`,
+		},
+		{
+			name:  "fill previous",
+			query: `SELECT mean(value) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s) fill(previous)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:10Z",3],["1970-01-01T00:00:20Z",3],["1970-01-01T00:00:30Z",5]]}]}]}`,
+		},
+		{
+			name:  "fill linear",
+			query: `SELECT mean(value) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s) fill(linear)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:10Z",3],["1970-01-01T00:00:20Z",4],["1970-01-01T00:00:30Z",5]]}]}]}`,
+		},
+		{
+			name:  "fill none",
+			query: `SELECT mean(value) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s) fill(none)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:10Z",3],["1970-01-01T00:00:30Z",5]]}]}]}`,
+		},
+		{
+			name:  "fill number",
+			query: `SELECT mean(value) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s) fill(-1)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","mean"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:10Z",3],["1970-01-01T00:00:20Z",-1],["1970-01-01T00:00:30Z",5]]}]}]}`,
+		},
+		{
+			name:  "count",
+			query: `SELECT count(value) FROM cpu WHERE time >= 0s AND time < 40s GROUP BY time(10s)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","count"],"values":[["1970-01-01T00:00:00Z",2],["1970-01-01T00:00:10Z",2],["1970-01-01T00:00:20Z",1],["1970-01-01T00:00:30Z",1]]}]}]}`,
+		},
+		{
+			name:  "count fill null",
+			query: `SELECT count(value) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","count"],"values":[["1970-01-01T00:00:00Z",1],["1970-01-01T00:00:10Z",1],["1970-01-01T00:00:20Z",0],["1970-01-01T00:00:30Z",1]]}]}]}`,
+		},
+		{
+			name:  "derivative",
+			query: `SELECT derivative(mean(value), 10s) FROM cpu WHERE host = 'a' AND time >= 0s AND time < 40s GROUP BY time(10s)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","derivative"],"values":[["1970-01-01T00:00:10Z",2],["1970-01-01T00:00:30Z",1]]}]}]}`,
+		},
+		{
+			name:  "group by tag",
+			query: `SELECT sum(value) FROM cpu GROUP BY host`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"a"},"columns":["time","sum"],"values":[["1970-01-01T00:00:00Z",9]]},{"name":"cpu","tags":{"host":"b"},"columns":["time","sum"],"values":[["1970-01-01T00:00:00Z",8]]},{"name":"cpu","tags":{"host":"c"},"columns":["time","sum"],"values":[["1970-01-01T00:00:00Z",4]]}]}]}`,
+		},
+		{
+			name:  "slimit",
+			query: `SELECT sum(value) FROM cpu GROUP BY host SLIMIT 1 SOFFSET 1`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","tags":{"host":"b"},"columns":["time","sum"],"values":[["1970-01-01T00:00:00Z",8]]}]}]}`,
+		},
+		{
+			name:  "subquery",
+			query: `SELECT max(total) FROM (SELECT sum(value) AS total FROM cpu GROUP BY host)`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["time","max"],"values":[["1970-01-01T00:00:00Z",9]]}]}]}`,
+		},
+		{
+			name:  "regex source",
+			query: `SELECT last(*) FROM /^m/`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"mem","columns":["time","last_free","last_used"],"values":[["1970-01-01T00:00:00Z",8,4]]}]}]}`,
+		},
+		{
+			name:  "no data",
+			query: `SELECT value FROM cpu WHERE host = 'z'`,
+			want:  `{"results":[{"statement_id":0}]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runQuery(t, deps, tt.query); got != tt.want {
+				t.Errorf("unexpected response:\ngot:  %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompiler_Show(t *testing.T) {
+	deps := newTestDependencies(t, testData)
+
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "databases",
+			query: `SHOW DATABASES`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"databases","columns":["name"],"values":[["db0"]]}]}]}`,
+		},
+		{
+			name:  "measurements",
+			query: `SHOW MEASUREMENTS`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"measurements","columns":["name"],"values":[["cpu"],["mem"]]}]}]}`,
+		},
+		{
+			name:  "measurements where",
+			query: `SHOW MEASUREMENTS WHERE region = 'east'`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"measurements","columns":["name"],"values":[["cpu"]]}]}]}`,
+		},
+		{
+			name:  "tag keys",
+			query: `SHOW TAG KEYS FROM cpu`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["tagKey"],"values":[["host"],["region"]]}]}]}`,
+		},
+		{
+			name:  "tag values",
+			query: `SHOW TAG VALUES FROM cpu WITH KEY = host WHERE region = 'east'`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["key","value"],"values":[["host","b"],["host","c"]]}]}]}`,
+		},
+		{
+			name:  "field keys",
+			query: `SHOW FIELD KEYS`,
+			want:  `{"results":[{"statement_id":0,"series":[{"name":"cpu","columns":["fieldKey","fieldType"],"values":[["value","float"]]},{"name":"mem","columns":["fieldKey","fieldType"],"values":[["free","integer"],["used","integer"]]}]}]}`,
+		},
+		{
+			name:  "series",
+			query: `SHOW SERIES FROM cpu WHERE region = 'east'`,
+			want:  `{"results":[{"statement_id":0,"series":[{"columns":["key"],"values":[["cpu,host=b,region=east"],["cpu,host=c,region=east"]]}]}]}`,
+		},
+		{
+			name:  "retention policies",
+			query: `SHOW RETENTION POLICIES ON db0`,
+			want:  `{"results":[{"statement_id":0,"series":[{"columns":["name","duration","shardGroupDuration","replicaN","default"],"values":[["autogen","72h0m0s","24h0m0s",1,true]]}]}]}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runQuery(t, deps, tt.query); got != tt.want {
+				t.Errorf("unexpected response:\ngot:  %s\nwant: %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompiler_Errors(t *testing.T) {
+	deps := newTestDependencies(t, testData)
+
+	for _, tt := range []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "select into",
+			query: `SELECT value INTO other FROM cpu`,
+			want:  "SELECT INTO is not supported",
+		},
+		{
+			name:  "mixed aggregate",
+			query: `SELECT mean(value), value FROM cpu`,
+			want:  "mixing aggregate and non-aggregate queries is not supported",
+		},
+		{
+			name:  "unknown database",
+			query: `SELECT value FROM db1..cpu`,
+			want:  "database not found",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := runQuery(t, deps, tt.query); !strings.Contains(got, tt.want) {
+				t.Errorf("expected error containing %q, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+type testDependencies struct {
+	native.Dependencies
+	dbrpMappingSvc platform.DBRPMappingService
+}
+
+func TestCompiler_MemoryLimit(t *testing.T) {
+	deps := newTestDependencies(t, testData)
+
+	start := func(t *testing.T, alloc *memory.Allocator) flux.Query {
+		t.Helper()
+
+		now := time.Unix(60, 0)
+		c := native.NewCompiler(deps.dbrpMappingSvc)
+		c.Cluster = "cluster"
+		c.DB = "db0"
+		c.Query = `SELECT value FROM cpu`
+		c.Now = &now
+
+		ctx := query.ContextWithRequest(context.Background(), &query.Request{OrganizationID: orgID})
+		ctx = deps.Inject(ctx)
+		prog, err := c.Compile(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fq, err := prog.Start(ctx, alloc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for res := range fq.Results() {
+			_ = res.Tables().Do(func(flux.Table) error { return nil })
+		}
+		fq.Done()
+		return fq
+	}
+
+	t.Run("within limit", func(t *testing.T) {
+		alloc := &memory.Allocator{}
+		if fq := start(t, alloc); fq.Err() != nil {
+			t.Fatal(fq.Err())
+		}
+		if alloc.MaxAllocated() == 0 {
+			t.Fatal("expected buffered series to be accounted")
+		}
+		if got := alloc.Allocated(); got != 0 {
+			t.Fatalf("unexpected allocated memory after the query finished: %d", got)
+		}
+	})
+
+	t.Run("exceeds limit", func(t *testing.T) {
+		limit := int64(64)
+		alloc := &memory.Allocator{Limit: &limit}
+		if fq := start(t, alloc); fq.Err() == nil {
+			t.Fatal("expected the query to exceed its memory limit")
+		}
+		if got := alloc.Allocated(); got != 0 {
+			t.Fatalf("unexpected allocated memory after the query failed: %d", got)
+		}
+	})
+}
+
+func TestAddCompilerMappings(t *testing.T) {
+	mappings := make(flux.CompilerMappings)
+	if err := native.AddCompilerMappings(mappings, mock.NewDBRPMappingService()); err != nil {
+		t.Fatal(err)
+	}
+
+	var req query.Request
+	req.WithCompilerMappings(mappings)
+	data := `{"organization_id":"3131313131313131","compiler_type":"influxql_native","compiler":{"db":"db0","query":"SELECT value FROM cpu"}}`
+	if err := json.Unmarshal([]byte(data), &req); err != nil {
+		t.Fatal(err)
+	}
+
+	c, ok := req.Compiler.(*native.Compiler)
+	if !ok {
+		t.Fatalf("unexpected compiler type %T", req.Compiler)
+	}
+	if c.DB != "db0" || c.Query != "SELECT value FROM cpu" {
+		t.Fatalf("unexpected compiler: %+v", c)
+	}
+}
+
+func newTestDependencies(t *testing.T, data string) *testDependencies {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "influxql_native_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	engine := storage.NewEngine(dir, storage.NewConfig())
+	if err := engine.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { engine.Close() })
+
+	name := tsdb.EncodeName(orgID, bucketID)
+	points, err := models.ParsePointsString(strings.TrimSpace(data), string(models.EscapeMeasurement(name[:])))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.WritePoints(context.Background(), points); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := &platform.Bucket{
+		ID:              bucketID,
+		OrgID:           orgID,
+		Name:            "db0/autogen",
+		RetentionPeriod: 72 * time.Hour,
+	}
+	bucketSvc := mock.NewBucketService()
+	bucketSvc.FindBucketByIDFn = func(ctx context.Context, id platform.ID) (*platform.Bucket, error) {
+		if id != bucketID {
+			return nil, &platform.Error{Code: platform.ENotFound, Msg: "bucket not found"}
+		}
+		return bucket, nil
+	}
+
+	mapping := &platform.DBRPMapping{
+		Cluster:         "cluster",
+		Database:        "db0",
+		RetentionPolicy: "autogen",
+		Default:         true,
+		OrganizationID:  orgID,
+		BucketID:        bucketID,
+	}
+	dbrpMappingSvc := &mock.DBRPMappingService{
+		FindByFn: func(ctx context.Context, cluster, db, rp string) (*platform.DBRPMapping, error) {
+			if db != mapping.Database || (rp != "" && rp != mapping.RetentionPolicy) {
+				return nil, &platform.Error{Code: platform.ENotFound, Msg: "database not found"}
+			}
+			return mapping, nil
+		},
+		FindManyFn: func(ctx context.Context, filter platform.DBRPMappingFilter, opt ...platform.FindOptions) ([]*platform.DBRPMapping, int, error) {
+			if filter.Database != nil && *filter.Database != mapping.Database {
+				return nil, 0, nil
+			}
+			return []*platform.DBRPMapping{mapping}, 1, nil
+		},
+	}
+
+	return &testDependencies{
+		Dependencies: native.Dependencies{
+			Store:         &testStore{engine: engine},
+			Schema:        engine,
+			BucketService: bucketSvc,
+		},
+		dbrpMappingSvc: dbrpMappingSvc,
+	}
+}
+
+// testStore implements reads.Store and reads.WindowAggregateStore for the
+// single bucket of the test engine.
+type testStore struct {
+	engine *storage.Engine
+}
+
+func (s *testStore) ReadFilter(ctx context.Context, req *datatypes.ReadFilterRequest) (reads.ResultSet, error) {
+	cur, err := reads.NewIndexSeriesCursor(ctx, orgID, bucketID, req.Predicate, s.engine)
+	if err != nil || cur == nil {
+		return nil, err
+	}
+	return reads.NewFilteredResultSet(ctx, req, cur), nil
+}
+
+func (s *testStore) ReadGroup(ctx context.Context, req *datatypes.ReadGroupRequest) (reads.GroupResultSet, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *testStore) TagKeys(ctx context.Context, req *datatypes.TagKeysRequest) (cursors.StringIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *testStore) TagValues(ctx context.Context, req *datatypes.TagValuesRequest) (cursors.StringIterator, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *testStore) GetSource(orgID, bucketID uint64) proto.Message {
+	return &types.Empty{}
+}
+
+func (s *testStore) GetWindowAggregateCapability(ctx context.Context) reads.WindowAggregateCapability {
+	return countCapability{}
+}
+
+func (s *testStore) WindowAggregate(ctx context.Context, req *datatypes.ReadWindowAggregateRequest) (reads.ResultSet, error) {
+	cur, err := reads.NewIndexSeriesCursor(ctx, orgID, bucketID, req.Predicate, s.engine)
+	if err != nil || cur == nil {
+		return nil, err
+	}
+	return reads.NewWindowAggregateResultSet(ctx, req, cur)
+}
+
+type countCapability struct{}
+
+func (countCapability) HaveMin() bool   { return false }
+func (countCapability) HaveMax() bool   { return false }
+func (countCapability) HaveMean() bool  { return false }
+func (countCapability) HaveCount() bool { return true }
+func (countCapability) HaveSum() bool   { return false }
+
+// runQuery executes the query in the db0 database and returns the response
+// encoded in the 1.x format.
+func runQuery(t *testing.T, deps *testDependencies, q string) string {
+	t.Helper()
+
+	now := time.Unix(60, 0)
+	c := native.NewCompiler(deps.dbrpMappingSvc)
+	c.Cluster = "cluster"
+	c.DB = "db0"
+	c.Query = q
+	c.Now = &now
+
+	ctx := query.ContextWithRequest(context.Background(), &query.Request{OrganizationID: orgID})
+	ctx = deps.Inject(ctx)
+
+	var buf bytes.Buffer
+	enc := influxql.NewMultiResultEncoder()
+	prog, err := c.Compile(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fq, err := prog.Start(ctx, &memory.Allocator{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := flux.NewResultIteratorFromQuery(fq)
+	defer results.Release()
+	if _, err := enc.Encode(&buf, results); err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(buf.String())
+}
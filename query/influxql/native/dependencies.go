@@ -0,0 +1,73 @@
+package native
+
+import (
+	"context"
+	"errors"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/storage"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	"github.com/influxdata/influxql"
+)
+
+type key int
+
+const dependenciesKey key = iota
+
+// SchemaReader exposes the index backed schema of a bucket. It is used to
+// answer the SHOW statements and to resolve the field and tag keys of a
+// measurement when planning a SELECT statement.
+type SchemaReader interface {
+	MeasurementNames(ctx context.Context, orgID, bucketID influxdb.ID, start, end int64) (cursors.StringIterator, error)
+	MeasurementTagKeys(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	MeasurementTagValues(ctx context.Context, orgID, bucketID influxdb.ID, measurement, tagKey string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	MeasurementFields(ctx context.Context, orgID, bucketID influxdb.ID, measurement string, start, end int64, predicate influxql.Expr) (cursors.MeasurementFieldsIterator, error)
+	TagValues(ctx context.Context, orgID, bucketID influxdb.ID, tagKey string, start, end int64, predicate influxql.Expr) (cursors.StringIterator, error)
+	CreateSeriesCursor(ctx context.Context, orgID, bucketID influxdb.ID, cond influxql.Expr) (storage.SeriesCursor, error)
+}
+
+var _ SchemaReader = (*storage.Engine)(nil)
+
+// Dependencies are the services used by the native InfluxQL engine.
+type Dependencies struct {
+	// Store reads the series data. If it implements reads.WindowAggregateStore,
+	// supported aggregates are pushed down to storage.
+	Store reads.Store
+	// Schema reads the schema of a bucket from the index.
+	Schema SchemaReader
+	// BucketService resolves buckets. It is expected to enforce
+	// authorization of the caller on the context.
+	BucketService influxdb.BucketService
+}
+
+// Inject adds the dependencies to the context.
+func (d Dependencies) Inject(ctx context.Context) context.Context {
+	return context.WithValue(ctx, dependenciesKey, d)
+}
+
+// Validate returns an error if any of the dependencies are missing.
+func (d Dependencies) Validate() error {
+	if d.Store == nil {
+		return errors.New("missing store dependency")
+	}
+	if d.Schema == nil {
+		return errors.New("missing schema dependency")
+	}
+	if d.BucketService == nil {
+		return errors.New("missing bucket service dependency")
+	}
+	return nil
+}
+
+// GetDependencies retrieves the native engine dependencies from the context.
+func GetDependencies(ctx context.Context) (Dependencies, error) {
+	d, ok := ctx.Value(dependenciesKey).(Dependencies)
+	if !ok {
+		return Dependencies{}, &influxdb.Error{
+			Code: influxdb.EInternal,
+			Msg:  "native influxql engine dependencies are not configured",
+		}
+	}
+	return d, nil
+}
@@ -0,0 +1,257 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/flux/memory"
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	iql "github.com/influxdata/influxql"
+)
+
+// statementExecutor executes the statements of a single query.
+type statementExecutor struct {
+	cluster string
+	db      string
+	rp      string
+	bucket  string
+	now     time.Time
+
+	dbrpMappingSvc platform.DBRPMappingService
+	deps           Dependencies
+	orgID          platform.ID
+
+	// alloc accounts for the series buffered while a statement executes so
+	// the memory limits of the query controller apply. allocated is the
+	// number of bytes accounted for the current statement.
+	alloc     *memory.Allocator
+	allocated int
+
+	buckets map[string]*platform.Bucket
+	schemas map[string]*measurementSchema
+}
+
+// measurementSchema holds the field and tag keys of a measurement.
+type measurementSchema struct {
+	fields map[string]iql.DataType
+	tags   map[string]struct{}
+}
+
+func (e *statementExecutor) init(ctx context.Context) error {
+	req := query.RequestFromContext(ctx)
+	if req == nil {
+		return &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "missing request on context",
+		}
+	}
+	e.orgID = req.OrganizationID
+	e.buckets = make(map[string]*platform.Bucket)
+	e.schemas = make(map[string]*measurementSchema)
+	return nil
+}
+
+// account charges n bytes to the allocator of the query. It returns an error
+// when the query exceeds its memory limit.
+func (e *statementExecutor) account(n int) error {
+	if e.alloc == nil {
+		return nil
+	}
+	if err := e.alloc.Account(n); err != nil {
+		return err
+	}
+	e.allocated += n
+	return nil
+}
+
+// release returns the memory accounted for the current statement to the
+// allocator.
+func (e *statementExecutor) release() {
+	if e.alloc != nil && e.allocated > 0 {
+		_ = e.alloc.Account(-e.allocated)
+	}
+	e.allocated = 0
+}
+
+// executeStatement executes stmt and returns its result.
+func (e *statementExecutor) executeStatement(ctx context.Context, stmt iql.Statement, id int) (*influxql.Result, error) {
+	var (
+		rows []*influxql.Row
+		err  error
+	)
+	switch stmt := stmt.(type) {
+	case *iql.SelectStatement:
+		rows, err = e.executeSelect(ctx, stmt)
+	case *iql.ShowDatabasesStatement:
+		rows, err = e.executeShowDatabases(ctx, stmt)
+	case *iql.ShowRetentionPoliciesStatement:
+		rows, err = e.executeShowRetentionPolicies(ctx, stmt)
+	case *iql.ShowMeasurementsStatement:
+		rows, err = e.executeShowMeasurements(ctx, stmt)
+	case *iql.ShowTagKeysStatement:
+		rows, err = e.executeShowTagKeys(ctx, stmt)
+	case *iql.ShowTagValuesStatement:
+		rows, err = e.executeShowTagValues(ctx, stmt)
+	case *iql.ShowFieldKeysStatement:
+		rows, err = e.executeShowFieldKeys(ctx, stmt)
+	case *iql.ShowSeriesStatement:
+		rows, err = e.executeShowSeries(ctx, stmt)
+	default:
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("unsupported statement type %T", stmt),
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &influxql.Result{
+		StatementID: id,
+		Series:      rows,
+	}, nil
+}
+
+// findBucket resolves the bucket for the database and retention policy.
+// Empty values fall back to the defaults of the request.
+func (e *statementExecutor) findBucket(ctx context.Context, db, rp string) (*platform.Bucket, error) {
+	if db == "" && e.bucket != "" {
+		return e.findBucketByName(ctx, e.bucket)
+	}
+
+	if db == "" {
+		db = e.db
+	}
+	if rp == "" {
+		rp = e.rp
+	}
+	if db == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "database name required",
+		}
+	}
+
+	key := db + "\x00" + rp
+	if b, ok := e.buckets[key]; ok {
+		return b, nil
+	}
+
+	if e.dbrpMappingSvc == nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "unable to resolve database: no dbrp mapping service configured",
+		}
+	}
+	m, err := e.dbrpMappingSvc.FindBy(ctx, e.cluster, db, rp)
+	if err != nil {
+		return nil, err
+	}
+	b, err := e.deps.BucketService.FindBucketByID(ctx, m.BucketID)
+	if err != nil {
+		return nil, err
+	}
+	e.buckets[key] = b
+	return b, nil
+}
+
+func (e *statementExecutor) findBucketByName(ctx context.Context, name string) (*platform.Bucket, error) {
+	key := "\x00" + name
+	if b, ok := e.buckets[key]; ok {
+		return b, nil
+	}
+	b, err := e.deps.BucketService.FindBucketByName(ctx, e.orgID, name)
+	if err != nil {
+		return nil, err
+	}
+	e.buckets[key] = b
+	return b, nil
+}
+
+// measurementSchema returns the field and tag keys of the measurement m.
+func (e *statementExecutor) measurementSchema(ctx context.Context, m *iql.Measurement) (*measurementSchema, error) {
+	b, err := e.findBucket(ctx, m.Database, m.RetentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	key := b.ID.String() + "\x00" + m.Name
+	if s, ok := e.schemas[key]; ok {
+		return s, nil
+	}
+
+	s := &measurementSchema{
+		fields: make(map[string]iql.DataType),
+		tags:   make(map[string]struct{}),
+	}
+
+	fields, err := e.deps.Schema.MeasurementFields(ctx, b.OrgID, b.ID, m.Name, models.MinNanoTime, models.MaxNanoTime, nil)
+	if err != nil {
+		return nil, err
+	}
+	for fields.Next() {
+		for _, f := range fields.Value().Fields {
+			s.fields[f.Key] = cursors.FieldTypeToDataType(f.Type)
+		}
+	}
+
+	tags, err := e.deps.Schema.MeasurementTagKeys(ctx, b.OrgID, b.ID, m.Name, models.MinNanoTime, models.MaxNanoTime, nil)
+	if err != nil {
+		return nil, err
+	}
+	for tags.Next() {
+		if k := tags.Value(); !isInternalTagKey(k) {
+			s.tags[k] = struct{}{}
+		}
+	}
+
+	e.schemas[key] = s
+	return s, nil
+}
+
+// fieldMapper implements influxql.FieldMapper using the index of the buckets
+// referenced by a statement.
+type fieldMapper struct {
+	ctx context.Context
+	e   *statementExecutor
+}
+
+func (m *fieldMapper) FieldDimensions(meas *iql.Measurement) (map[string]iql.DataType, map[string]struct{}, error) {
+	s, err := m.e.measurementSchema(m.ctx, meas)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields := make(map[string]iql.DataType, len(s.fields))
+	for k, v := range s.fields {
+		fields[k] = v
+	}
+	dimensions := make(map[string]struct{}, len(s.tags))
+	for k := range s.tags {
+		dimensions[k] = struct{}{}
+	}
+	return fields, dimensions, nil
+}
+
+func (m *fieldMapper) MapType(meas *iql.Measurement, field string) iql.DataType {
+	s, err := m.e.measurementSchema(m.ctx, meas)
+	if err != nil {
+		return iql.Unknown
+	}
+	if typ, ok := s.fields[field]; ok {
+		return typ
+	}
+	if _, ok := s.tags[field]; ok {
+		return iql.Tag
+	}
+	return iql.Unknown
+}
+
+// isInternalTagKey reports whether k is one of the tag keys used by the
+// storage engine to encode the measurement and field of a series.
+func isInternalTagKey(k string) bool {
+	return k == models.MeasurementTagKey || k == models.FieldKeyTagKey
+}
@@ -0,0 +1,384 @@
+package native
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	iql "github.com/influxdata/influxql"
+)
+
+// valuePoint is a non-null value of a field.
+type valuePoint struct {
+	time  int64
+	value interface{}
+}
+
+// aggregateFunc reduces the values of a window to a single value. It returns
+// the time of the selected point for selectors and false if the type of the
+// values is not supported.
+type aggregateFunc func(points []valuePoint, args []iql.Expr) (interface{}, int64, bool)
+
+// transformFunc computes a value for each window from the aggregated values
+// of the windows. Windows that do not produce a row are set to absentValue.
+type transformFunc func(vals []interface{}, times []int64, args []iql.Expr, interval int64) ([]interface{}, error)
+
+// absentValue marks a window for which a transform does not produce a row.
+var absentValue = &struct{}{}
+
+var aggregateFuncs = map[string]aggregateFunc{
+	"count":      countFunc,
+	"sum":        sumFunc,
+	"mean":       meanFunc,
+	"median":     medianFunc,
+	"min":        minFunc,
+	"max":        maxFunc,
+	"first":      firstFunc,
+	"last":       lastFunc,
+	"spread":     spreadFunc,
+	"stddev":     stddevFunc,
+	"percentile": percentileFunc,
+}
+
+// selectorFuncs are the aggregates that select one of the values.
+var selectorFuncs = map[string]bool{
+	"min":        true,
+	"max":        true,
+	"first":      true,
+	"last":       true,
+	"percentile": true,
+}
+
+var transformFuncs = map[string]transformFunc{
+	"derivative":              derivativeFunc(false),
+	"non_negative_derivative": derivativeFunc(true),
+	"difference":              differenceFunc(false),
+	"non_negative_difference": differenceFunc(true),
+	"cumulative_sum":          cumulativeSumFunc,
+	"moving_average":          movingAverageFunc,
+}
+
+func countFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	return int64(len(points)), 0, true
+}
+
+func sumFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	switch points[0].value.(type) {
+	case float64:
+		var sum float64
+		for _, p := range points {
+			sum += p.value.(float64)
+		}
+		return sum, 0, true
+	case int64:
+		var sum int64
+		for _, p := range points {
+			sum += p.value.(int64)
+		}
+		return sum, 0, true
+	case uint64:
+		var sum uint64
+		for _, p := range points {
+			sum += p.value.(uint64)
+		}
+		return sum, 0, true
+	}
+	return nil, 0, false
+}
+
+func meanFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	var sum float64
+	for _, p := range points {
+		f, ok := toFloat(p.value)
+		if !ok {
+			return nil, 0, false
+		}
+		sum += f
+	}
+	return sum / float64(len(points)), 0, true
+}
+
+func medianFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	vals := make([]float64, len(points))
+	for i, p := range points {
+		f, ok := toFloat(p.value)
+		if !ok {
+			return nil, 0, false
+		}
+		vals[i] = f
+	}
+	sort.Float64s(vals)
+	n := len(vals)
+	if n%2 == 0 {
+		return vals[n/2-1] + (vals[n/2]-vals[n/2-1])/2, 0, true
+	}
+	return vals[n/2], 0, true
+}
+
+func minFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	sel := points[0]
+	for _, p := range points[1:] {
+		less, ok := lessValue(p.value, sel.value)
+		if !ok {
+			return nil, 0, false
+		}
+		if less {
+			sel = p
+		}
+	}
+	if _, ok := toFloat(sel.value); !ok {
+		return nil, 0, false
+	}
+	return sel.value, sel.time, true
+}
+
+func maxFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	sel := points[0]
+	for _, p := range points[1:] {
+		less, ok := lessValue(sel.value, p.value)
+		if !ok {
+			return nil, 0, false
+		}
+		if less {
+			sel = p
+		}
+	}
+	if _, ok := toFloat(sel.value); !ok {
+		return nil, 0, false
+	}
+	return sel.value, sel.time, true
+}
+
+func firstFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	return points[0].value, points[0].time, true
+}
+
+func lastFunc(points []valuePoint, _ []iql.Expr) (interface{}, int64, bool) {
+	p := points[len(points)-1]
+	return p.value, p.time, true
+}
+
+func spreadFunc(points []valuePoint, args []iql.Expr) (interface{}, int64, bool) {
+	min, _, ok := minFunc(points, args)
+	if !ok {
+		return nil, 0, false
+	}
+	max, _, _ := maxFunc(points, args)
+	switch min := min.(type) {
+	case float64:
+		return max.(float64) - min, 0, true
+	case int64:
+		return max.(int64) - min, 0, true
+	case uint64:
+		return max.(uint64) - min, 0, true
+	}
+	return nil, 0, false
+}
+
+func stddevFunc(points []valuePoint, args []iql.Expr) (interface{}, int64, bool) {
+	mean, _, ok := meanFunc(points, args)
+	if !ok {
+		return nil, 0, false
+	}
+	if len(points) < 2 {
+		return nil, 0, true
+	}
+	var variance float64
+	for _, p := range points {
+		f, _ := toFloat(p.value)
+		variance += math.Pow(f-mean.(float64), 2)
+	}
+	return math.Sqrt(variance / float64(len(points)-1)), 0, true
+}
+
+func percentileFunc(points []valuePoint, args []iql.Expr) (interface{}, int64, bool) {
+	for _, p := range points {
+		if _, ok := toFloat(p.value); !ok {
+			return nil, 0, false
+		}
+	}
+	pct, _ := numberArg(args[0])
+
+	sorted := make([]valuePoint, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		less, _ := lessValue(sorted[i].value, sorted[j].value)
+		return less
+	})
+
+	i := int(math.Floor(float64(len(sorted))*pct/100.0+0.5)) - 1
+	if i < 0 || i >= len(sorted) {
+		return nil, 0, true
+	}
+	return sorted[i].value, sorted[i].time, true
+}
+
+func derivativeFunc(nonNegative bool) transformFunc {
+	return func(vals []interface{}, times []int64, args []iql.Expr, _ int64) ([]interface{}, error) {
+		unit := int64(time.Second)
+		if len(args) > 0 {
+			d, ok := args[0].(*iql.DurationLiteral)
+			if !ok || d.Val <= 0 {
+				return nil, fmt.Errorf("second argument to derivative must be a positive duration, got %s", args[0])
+			}
+			unit = int64(d.Val)
+		}
+
+		out := make([]interface{}, len(vals))
+		var (
+			prev     float64
+			prevTime int64
+			havePrev bool
+		)
+		for i, v := range vals {
+			out[i] = absentValue
+			f, ok := toFloat(v)
+			if !ok {
+				continue
+			}
+			if havePrev {
+				d := (f - prev) / (float64(times[i]-prevTime) / float64(unit))
+				if !nonNegative || d >= 0 {
+					out[i] = d
+				}
+			}
+			prev, prevTime, havePrev = f, times[i], true
+		}
+		return out, nil
+	}
+}
+
+func differenceFunc(nonNegative bool) transformFunc {
+	return func(vals []interface{}, _ []int64, _ []iql.Expr, _ int64) ([]interface{}, error) {
+		out := make([]interface{}, len(vals))
+		var prev interface{}
+		for i, v := range vals {
+			out[i] = absentValue
+			if v == nil {
+				continue
+			}
+			if prev != nil {
+				var diff interface{}
+				negative := false
+				if a, ok := v.(int64); ok {
+					if b, ok := prev.(int64); ok {
+						diff, negative = a-b, a < b
+					}
+				}
+				if diff == nil {
+					a, _ := toFloat(v)
+					b, _ := toFloat(prev)
+					diff, negative = a-b, a < b
+				}
+				if !nonNegative || !negative {
+					out[i] = diff
+				}
+			}
+			prev = v
+		}
+		return out, nil
+	}
+}
+
+func cumulativeSumFunc(vals []interface{}, _ []int64, _ []iql.Expr, _ int64) ([]interface{}, error) {
+	out := make([]interface{}, len(vals))
+	var (
+		sum    float64
+		intSum int64
+	)
+	for i, v := range vals {
+		out[i] = absentValue
+		switch v := v.(type) {
+		case int64:
+			intSum += v
+			out[i] = intSum
+		case nil:
+		default:
+			f, ok := toFloat(v)
+			if !ok {
+				continue
+			}
+			sum += f
+			out[i] = sum
+		}
+	}
+	return out, nil
+}
+
+func movingAverageFunc(vals []interface{}, _ []int64, args []iql.Expr, _ int64) ([]interface{}, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("invalid number of arguments for moving_average, expected 2, got %d", len(args)+1)
+	}
+	n, ok := args[0].(*iql.IntegerLiteral)
+	if !ok || n.Val < 2 {
+		return nil, fmt.Errorf("moving_average window must be greater than 1, got %s", args[0])
+	}
+
+	out := make([]interface{}, len(vals))
+	buf := make([]float64, 0, n.Val)
+	for i, v := range vals {
+		out[i] = absentValue
+		f, ok := toFloat(v)
+		if !ok {
+			continue
+		}
+		if len(buf) == int(n.Val) {
+			buf = buf[1:]
+		}
+		buf = append(buf, f)
+		if len(buf) < int(n.Val) {
+			continue
+		}
+		var sum float64
+		for _, b := range buf {
+			sum += b
+		}
+		out[i] = sum / float64(len(buf))
+	}
+	return out, nil
+}
+
+// toFloat converts a numeric value to a float.
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// lessValue reports whether a is less than b. Both values must have the same
+// numeric type.
+func lessValue(a, b interface{}) (bool, bool) {
+	switch a := a.(type) {
+	case float64:
+		if b, ok := b.(float64); ok {
+			return a < b, true
+		}
+	case int64:
+		if b, ok := b.(int64); ok {
+			return a < b, true
+		}
+	case uint64:
+		if b, ok := b.(uint64); ok {
+			return a < b, true
+		}
+	}
+	return false, false
+}
+
+// numberArg returns the value of a numeric literal argument.
+func numberArg(expr iql.Expr) (float64, bool) {
+	switch expr := expr.(type) {
+	case *iql.NumberLiteral:
+		return expr.Val, true
+	case *iql.IntegerLiteral:
+		return float64(expr.Val), true
+	}
+	return 0, false
+}
@@ -0,0 +1,130 @@
+package native
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/memory"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	iql "github.com/influxdata/influxql"
+)
+
+// program executes the statements of an InfluxQL query in order.
+type program struct {
+	query    *iql.Query
+	executor *statementExecutor
+}
+
+// Start begins executing the statements. Each statement produces a single
+// result named after its statement id.
+func (p *program) Start(ctx context.Context, alloc *memory.Allocator) (flux.Query, error) {
+	deps, err := GetDependencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	q := &runningQuery{
+		results: make(chan flux.Result),
+		cancel:  cancel,
+		start:   time.Now(),
+	}
+
+	e := *p.executor
+	e.deps = deps
+	e.alloc = alloc
+	if err := e.init(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		defer close(q.results)
+		defer e.release()
+		for i, stmt := range p.query.Statements {
+			res, err := e.executeStatement(ctx, stmt, i)
+			if err != nil {
+				q.setErr(err)
+				return
+			}
+
+			select {
+			case q.results <- influxql.NewResult(res):
+			case <-ctx.Done():
+				q.setErr(ctx.Err())
+				return
+			}
+			// The result of the statement has been handed to the consumer.
+			e.release()
+		}
+	}()
+	return q, nil
+}
+
+// runningQuery implements flux.Query for a running program.
+type runningQuery struct {
+	results chan flux.Result
+	cancel  context.CancelFunc
+	start   time.Time
+	wg      sync.WaitGroup
+
+	mu    sync.Mutex
+	err   error
+	stats flux.Statistics
+	done  bool
+}
+
+func (q *runningQuery) Results() <-chan flux.Result {
+	return q.results
+}
+
+func (q *runningQuery) Done() {
+	q.cancel()
+
+	q.mu.Lock()
+	if q.done {
+		q.mu.Unlock()
+		return
+	}
+	q.done = true
+	q.mu.Unlock()
+
+	// Drain any results that were not consumed so the executing
+	// goroutine is able to exit.
+	for range q.results {
+	}
+	q.wg.Wait()
+
+	q.mu.Lock()
+	q.stats.TotalDuration = time.Since(q.start)
+	q.stats.ExecuteDuration = q.stats.TotalDuration
+	q.mu.Unlock()
+}
+
+func (q *runningQuery) Cancel() {
+	q.cancel()
+}
+
+func (q *runningQuery) Err() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.err
+}
+
+func (q *runningQuery) Statistics() flux.Statistics {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.stats
+}
+
+func (q *runningQuery) setErr(err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.err == nil {
+		q.err = err
+	}
+}
@@ -0,0 +1,427 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gogo/protobuf/types"
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	iql "github.com/influxdata/influxql"
+)
+
+// series holds the points of a single series, ordered by time.
+type series struct {
+	name   string
+	tags   map[string]string
+	points []*point
+}
+
+// point holds the field values of a series at a single timestamp.
+type point struct {
+	time   int64
+	fields map[string]interface{}
+}
+
+// seriesSet accumulates the cursors returned by storage into series. Storage
+// returns one cursor per field, which are merged by timestamp.
+type seriesSet struct {
+	keys   []string
+	series map[string]*seriesBuilder
+}
+
+type seriesBuilder struct {
+	series
+	points map[int64]*point
+}
+
+func newSeriesSet() *seriesSet {
+	return &seriesSet{series: make(map[string]*seriesBuilder)}
+}
+
+func (s *seriesSet) builder(tags models.Tags) *seriesBuilder {
+	var (
+		name string
+		key  strings.Builder
+		m    = make(map[string]string, len(tags))
+	)
+	for _, t := range tags {
+		k := string(t.Key)
+		switch k {
+		case datatypes.MeasurementKey:
+			name = string(t.Value)
+		case datatypes.FieldKey:
+		default:
+			m[k] = string(t.Value)
+			key.WriteByte(',')
+			key.Write(t.Key)
+			key.WriteByte('=')
+			key.Write(t.Value)
+		}
+	}
+
+	k := name + key.String()
+	b, ok := s.series[k]
+	if !ok {
+		b = &seriesBuilder{
+			series: series{name: name, tags: m},
+			points: make(map[int64]*point),
+		}
+		s.series[k] = b
+		s.keys = append(s.keys, k)
+	}
+	return b
+}
+
+func (b *seriesBuilder) add(ts int64, field string, v interface{}) {
+	p, ok := b.points[ts]
+	if !ok {
+		p = &point{time: ts, fields: make(map[string]interface{})}
+		b.points[ts] = p
+	}
+	p.fields[field] = v
+}
+
+// all returns the accumulated series in the order storage returned them.
+func (s *seriesSet) all() []*series {
+	out := make([]*series, 0, len(s.keys))
+	for _, k := range s.keys {
+		b := s.series[k]
+		b.series.points = make([]*point, 0, len(b.points))
+		for _, p := range b.points {
+			b.series.points = append(b.series.points, p)
+		}
+		sort.Slice(b.series.points, func(i, j int) bool {
+			return b.series.points[i].time < b.series.points[j].time
+		})
+		out = append(out, &b.series)
+	}
+	return out
+}
+
+// readSeries reads the fields of the measurement that match the tag condition
+// within the time range.
+func (e *statementExecutor) readSeries(ctx context.Context, m *iql.Measurement, fields []string, cond iql.Expr, tr iql.TimeRange) ([]*series, error) {
+	b, err := e.findBucket(ctx, m.Database, m.RetentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+	pred, err := seriesPredicate(m.Name, fields, cond)
+	if err != nil {
+		return nil, err
+	}
+	if pred, err = restrictPredicate(ctx, b, pred); err != nil {
+		return nil, err
+	}
+	src, err := types.MarshalAny(e.deps.Store.GetSource(uint64(b.OrgID), uint64(b.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := e.deps.Store.ReadFilter(ctx, &datatypes.ReadFilterRequest{
+		ReadSource: src,
+		Range:      timestampRange(tr),
+		Predicate:  pred,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return e.readResultSet(rs, nil)
+}
+
+// readWindowCount reads the number of values of field in each window using
+// the window aggregate capability of the store. The returned points hold the
+// count for the window starting at the point time.
+func (e *statementExecutor) readWindowCount(ctx context.Context, store reads.WindowAggregateStore, m *iql.Measurement, field string, cond iql.Expr, tr iql.TimeRange, every int64) ([]*series, error) {
+	b, err := e.findBucket(ctx, m.Database, m.RetentionPolicy)
+	if err != nil {
+		return nil, err
+	}
+	pred, err := seriesPredicate(m.Name, []string{field}, cond)
+	if err != nil {
+		return nil, err
+	}
+	if pred, err = restrictPredicate(ctx, b, pred); err != nil {
+		return nil, err
+	}
+	src, err := types.MarshalAny(e.deps.Store.GetSource(uint64(b.OrgID), uint64(b.ID)))
+	if err != nil {
+		return nil, err
+	}
+
+	rs, err := store.WindowAggregate(ctx, &datatypes.ReadWindowAggregateRequest{
+		ReadSource:  src,
+		Range:       timestampRange(tr),
+		Predicate:   pred,
+		WindowEvery: every,
+		Aggregate: []*datatypes.Aggregate{
+			{Type: datatypes.AggregateTypeCount},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// The store reports each count at the end of its window.
+	return e.readResultSet(rs, func(ts int64) int64 { return ts - every })
+}
+
+// readScopes returns the scopes that the user in the context is restricted to
+// when reading the bucket, or nil if the reads are not restricted.
+func readScopes(ctx context.Context, b *platform.Bucket) ([]platform.PermissionScope, error) {
+	if _, err := icontext.GetAuthorizer(ctx); err != nil {
+		return nil, nil
+	}
+	return authorizer.BucketScopes(ctx, platform.ReadAction, b.OrgID, b.ID)
+}
+
+// restrictPredicate restricts the predicate to the series that the user in
+// the context may read from the bucket.
+func restrictPredicate(ctx context.Context, b *platform.Bucket, pred *datatypes.Predicate) (*datatypes.Predicate, error) {
+	scopes, err := readScopes(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	return reads.RestrictPredicate(pred, scopes), nil
+}
+
+func (e *statementExecutor) readResultSet(rs reads.ResultSet, adjust func(int64) int64) ([]*series, error) {
+	if rs == nil {
+		return nil, nil
+	}
+	defer rs.Close()
+
+	set := newSeriesSet()
+	for rs.Next() {
+		tags := rs.Tags()
+		field := string(tags.Get([]byte(datatypes.FieldKey)))
+		b := set.builder(tags)
+		add := func(ts int64, v interface{}) {
+			if adjust != nil {
+				ts = adjust(ts)
+			}
+			b.add(ts, field, v)
+		}
+		if err := readCursor(rs.Cursor(), add, e.account); err != nil {
+			return nil, err
+		}
+	}
+	if err := rs.Err(); err != nil {
+		return nil, err
+	}
+	return set.all(), nil
+}
+
+// valueSize is the estimated number of bytes used to buffer a single value
+// read from storage, including its share of the point and group it is
+// added to.
+const valueSize = 96
+
+// readCursor calls fn with each value of the cursor. The memory used to
+// buffer each batch of values is charged to account before it is read.
+func readCursor(cur cursors.Cursor, fn func(ts int64, v interface{}), account func(n int) error) error {
+	if cur == nil {
+		return nil
+	}
+	defer cur.Close()
+
+	switch cur := cur.(type) {
+	case cursors.FloatArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			if err := account(a.Len() * valueSize); err != nil {
+				return err
+			}
+			for i, ts := range a.Timestamps {
+				fn(ts, a.Values[i])
+			}
+		}
+	case cursors.IntegerArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			if err := account(a.Len() * valueSize); err != nil {
+				return err
+			}
+			for i, ts := range a.Timestamps {
+				fn(ts, a.Values[i])
+			}
+		}
+	case cursors.UnsignedArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			if err := account(a.Len() * valueSize); err != nil {
+				return err
+			}
+			for i, ts := range a.Timestamps {
+				fn(ts, a.Values[i])
+			}
+		}
+	case cursors.StringArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			n := a.Len() * valueSize
+			for _, v := range a.Values {
+				n += len(v)
+			}
+			if err := account(n); err != nil {
+				return err
+			}
+			for i, ts := range a.Timestamps {
+				fn(ts, a.Values[i])
+			}
+		}
+	case cursors.BooleanArrayCursor:
+		for a := cur.Next(); a.Len() > 0; a = cur.Next() {
+			if err := account(a.Len() * valueSize); err != nil {
+				return err
+			}
+			for i, ts := range a.Timestamps {
+				fn(ts, a.Values[i])
+			}
+		}
+	default:
+		return &platform.Error{
+			Code: platform.EInternal,
+			Msg:  fmt.Sprintf("unsupported cursor type %T", cur),
+		}
+	}
+	return cur.Err()
+}
+
+// timestampRange converts the inclusive InfluxQL time range to the storage
+// range, which excludes the end time.
+func timestampRange(tr iql.TimeRange) datatypes.TimestampRange {
+	r := datatypes.TimestampRange{
+		Start: tr.MinTimeNano(),
+		End:   models.MaxNanoTime,
+	}
+	if !tr.Max.IsZero() && tr.Max.UnixNano() < models.MaxNanoTime {
+		r.End = tr.Max.UnixNano() + 1
+	}
+	return r
+}
+
+// seriesPredicate builds the storage predicate selecting the fields of the
+// measurement that match the tag condition.
+func seriesPredicate(name string, fields []string, cond iql.Expr) (*datatypes.Predicate, error) {
+	root := comparisonNode(datatypes.ComparisonEqual, tagRefNode(models.MeasurementTagKey), stringNode(name))
+
+	if len(fields) > 0 {
+		children := make([]*datatypes.Node, 0, len(fields))
+		for _, f := range fields {
+			children = append(children, comparisonNode(datatypes.ComparisonEqual, tagRefNode(models.FieldKeyTagKey), stringNode(f)))
+		}
+		root = logicalNode(datatypes.LogicalAnd, root, parenNode(logicalNode(datatypes.LogicalOr, children...)))
+	}
+
+	if cond != nil {
+		n, err := exprToNode(cond)
+		if err != nil {
+			return nil, err
+		}
+		root = logicalNode(datatypes.LogicalAnd, root, parenNode(n))
+	}
+	return &datatypes.Predicate{Root: root}, nil
+}
+
+// exprToNode converts a condition on tags to a storage predicate node.
+func exprToNode(expr iql.Expr) (*datatypes.Node, error) {
+	switch expr := expr.(type) {
+	case *iql.ParenExpr:
+		n, err := exprToNode(expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		return parenNode(n), nil
+	case *iql.BinaryExpr:
+		switch expr.Op {
+		case iql.AND, iql.OR:
+			lhs, err := exprToNode(expr.LHS)
+			if err != nil {
+				return nil, err
+			}
+			rhs, err := exprToNode(expr.RHS)
+			if err != nil {
+				return nil, err
+			}
+			op := datatypes.LogicalAnd
+			if expr.Op == iql.OR {
+				op = datatypes.LogicalOr
+			}
+			return logicalNode(op, lhs, rhs), nil
+		}
+
+		ref, lit := expr.LHS, expr.RHS
+		if _, ok := ref.(*iql.VarRef); !ok {
+			ref, lit = lit, ref
+		}
+		r, ok := ref.(*iql.VarRef)
+		if !ok {
+			break
+		}
+
+		var op datatypes.Node_Comparison
+		switch expr.Op {
+		case iql.EQ:
+			op = datatypes.ComparisonEqual
+		case iql.NEQ:
+			op = datatypes.ComparisonNotEqual
+		case iql.EQREGEX:
+			op = datatypes.ComparisonRegex
+		case iql.NEQREGEX:
+			op = datatypes.ComparisonNotRegex
+		default:
+			return nil, fmt.Errorf("unsupported tag comparison operator %s", expr.Op)
+		}
+
+		switch lit := lit.(type) {
+		case *iql.StringLiteral:
+			return comparisonNode(op, tagRefNode(r.Val), stringNode(lit.Val)), nil
+		case *iql.RegexLiteral:
+			return comparisonNode(op, tagRefNode(r.Val), &datatypes.Node{
+				NodeType: datatypes.NodeTypeLiteral,
+				Value:    &datatypes.Node_RegexValue{RegexValue: lit.Val.String()},
+			}), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported tag condition %s", expr)
+}
+
+func tagRefNode(k string) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeTagRef,
+		Value:    &datatypes.Node_TagRefValue{TagRefValue: k},
+	}
+}
+
+func stringNode(v string) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeLiteral,
+		Value:    &datatypes.Node_StringValue{StringValue: v},
+	}
+}
+
+func comparisonNode(op datatypes.Node_Comparison, lhs, rhs *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: op},
+		Children: []*datatypes.Node{lhs, rhs},
+	}
+}
+
+func logicalNode(op datatypes.Node_Logical, children ...*datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeLogicalExpression,
+		Value:    &datatypes.Node_Logical_{Logical: op},
+		Children: children,
+	}
+}
+
+func parenNode(n *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeParenExpression,
+		Children: []*datatypes.Node{n},
+	}
+}
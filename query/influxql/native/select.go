@@ -0,0 +1,944 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	iql "github.com/influxdata/influxql"
+)
+
+// selectPlan holds the state of a SELECT statement while it is executed.
+type selectPlan struct {
+	stmt     *iql.SelectStatement
+	columns  []string
+	tr       iql.TimeRange
+	interval int64
+	offset   int64
+	dims     []string
+
+	// cond is the condition without the time range. It is split into the
+	// tag conditions that are pushed down to storage and the remaining
+	// conditions evaluated against each point.
+	cond      iql.Expr
+	tagCond   iql.Expr
+	fieldCond iql.Expr
+
+	// fields are the fields read from storage.
+	fields []string
+
+	// calls are the distinct function calls of the statement and exprs the
+	// field expressions with each call replaced by a reference to its result.
+	calls []*iql.Call
+	exprs []iql.Expr
+
+	// countStore is set when the count is pushed down to storage.
+	countStore reads.WindowAggregateStore
+}
+
+// group is a set of series that are output as a single row.
+type group struct {
+	key    string
+	name   string
+	tags   map[string]string
+	points []groupPoint
+}
+
+type groupPoint struct {
+	*point
+	tags map[string]string
+}
+
+// window is the set of points aggregated into a single output row.
+type window struct {
+	start  int64
+	points []groupPoint
+}
+
+// pointValuer implements influxql.Valuer for a point of a series.
+type pointValuer struct {
+	fields map[string]interface{}
+	tags   map[string]string
+}
+
+func (v pointValuer) Value(key string) (interface{}, bool) {
+	if val, ok := v.fields[key]; ok {
+		return val, true
+	}
+	if val, ok := v.tags[key]; ok {
+		return val, true
+	}
+	return nil, false
+}
+
+// executeSelect executes a SELECT statement.
+func (e *statementExecutor) executeSelect(ctx context.Context, stmt *iql.SelectStatement) ([]*influxql.Row, error) {
+	if stmt.Target != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "SELECT INTO is not supported",
+		}
+	}
+	return e.selectRows(ctx, stmt, iql.TimeRange{})
+}
+
+// selectRows executes stmt restricted to the time range of an enclosing
+// statement, if any.
+func (e *statementExecutor) selectRows(ctx context.Context, stmt *iql.SelectStatement, outer iql.TimeRange) ([]*influxql.Row, error) {
+	p, err := e.planSelect(ctx, stmt, outer)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*series
+	for _, src := range p.stmt.Sources {
+		s, err := e.readSource(ctx, p, src)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, s...)
+	}
+
+	groups := p.group(data)
+	if p.stmt.SOffset > 0 {
+		if p.stmt.SOffset >= len(groups) {
+			return nil, nil
+		}
+		groups = groups[p.stmt.SOffset:]
+	}
+	if p.stmt.SLimit > 0 && p.stmt.SLimit < len(groups) {
+		groups = groups[:p.stmt.SLimit]
+	}
+
+	rows := make([]*influxql.Row, 0, len(groups))
+	for _, g := range groups {
+		var values [][]interface{}
+		if len(p.calls) > 0 {
+			values, err = p.aggregate(g)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			values = p.raw(g)
+		}
+
+		if !p.stmt.TimeAscending() {
+			for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+				values[i], values[j] = values[j], values[i]
+			}
+		}
+		if p.stmt.Offset > 0 {
+			if p.stmt.Offset >= len(values) {
+				continue
+			}
+			values = values[p.stmt.Offset:]
+		}
+		if p.stmt.Limit > 0 && p.stmt.Limit < len(values) {
+			values = values[:p.stmt.Limit]
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		rows = append(rows, &influxql.Row{
+			Name:    g.name,
+			Tags:    g.tags,
+			Columns: p.columns,
+			Values:  values,
+		})
+	}
+	return rows, nil
+}
+
+// planSelect validates the statement and determines how it is executed.
+func (e *statementExecutor) planSelect(ctx context.Context, stmt *iql.SelectStatement, outer iql.TimeRange) (*selectPlan, error) {
+	sources, err := e.expandSources(ctx, stmt.Sources)
+	if err != nil {
+		return nil, err
+	}
+	stmt = stmt.Clone()
+	stmt.Sources = sources
+
+	stmt, err = stmt.RewriteFields(&fieldMapper{ctx: ctx, e: e})
+	if err != nil {
+		return nil, err
+	}
+	stmt.RewriteTimeFields()
+	if stmt.Location != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "tz() is not supported",
+		}
+	}
+	if len(stmt.Fields) == 0 {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "at least 1 non-time field must be queried",
+		}
+	}
+
+	p := &selectPlan{stmt: stmt, columns: stmt.ColumnNames()}
+
+	p.cond, p.tr, err = iql.ConditionExpr(stmt.Condition, &iql.NowValuer{Now: e.now})
+	if err != nil {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	p.tr = p.tr.Intersect(outer)
+
+	interval, err := stmt.GroupByInterval()
+	if err != nil {
+		return nil, &platform.Error{Code: platform.EInvalid, Err: err}
+	}
+	offset, err := stmt.GroupByOffset()
+	if err != nil {
+		return nil, &platform.Error{Code: platform.EInvalid, Err: err}
+	}
+	p.interval, p.offset = int64(interval), int64(offset)
+	if p.interval > 0 && p.tr.Max.IsZero() {
+		p.tr.Max = e.now
+	}
+
+	for _, d := range stmt.Dimensions {
+		switch expr := d.Expr.(type) {
+		case *iql.VarRef:
+			p.dims = append(p.dims, expr.Val)
+		case *iql.Call:
+			if expr.Name != "time" {
+				return nil, &platform.Error{
+					Code: platform.EInvalid,
+					Msg:  fmt.Sprintf("unsupported dimension %s", expr),
+				}
+			}
+		default:
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("unsupported dimension %s", expr),
+			}
+		}
+	}
+	sort.Strings(p.dims)
+
+	if err := p.planFields(); err != nil {
+		return nil, err
+	}
+	if err := p.planCondition(); err != nil {
+		return nil, err
+	}
+	p.countStore = p.countPushdown(ctx, e.deps.Store)
+	return p, nil
+}
+
+// planFields collects the function calls and the fields referenced by the
+// field expressions.
+func (p *selectPlan) planFields() error {
+	fields := make(map[string]struct{})
+	var raw bool
+	for _, f := range p.stmt.Fields {
+		expr, err := p.replaceCalls(f.Expr, fields)
+		if err != nil {
+			return err
+		}
+		p.exprs = append(p.exprs, expr)
+
+		iql.WalkFunc(expr, func(n iql.Node) {
+			if ref, ok := n.(*iql.VarRef); ok && !isCallRef(ref) {
+				raw = true
+				if ref.Type != iql.Tag {
+					fields[ref.Val] = struct{}{}
+				}
+			}
+		})
+	}
+
+	if len(p.calls) > 0 && raw {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "mixing aggregate and non-aggregate queries is not supported",
+		}
+	}
+	if len(p.calls) == 0 && p.interval > 0 {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "GROUP BY requires at least one aggregate function",
+		}
+	}
+	if len(fields) == 0 {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "statement must have at least one field in select clause",
+		}
+	}
+
+	for k := range fields {
+		p.fields = append(p.fields, k)
+	}
+	sort.Strings(p.fields)
+	return nil
+}
+
+// callRefPrefix marks the references that replace function calls so they do
+// not collide with field names.
+const callRefPrefix = "\x00"
+
+func isCallRef(ref *iql.VarRef) bool {
+	return strings.HasPrefix(ref.Val, callRefPrefix)
+}
+
+// replaceCalls validates the calls in expr and replaces them with references
+// to their results.
+func (p *selectPlan) replaceCalls(expr iql.Expr, fields map[string]struct{}) (iql.Expr, error) {
+	switch expr := expr.(type) {
+	case *iql.BinaryExpr:
+		lhs, err := p.replaceCalls(expr.LHS, fields)
+		if err != nil {
+			return nil, err
+		}
+		rhs, err := p.replaceCalls(expr.RHS, fields)
+		if err != nil {
+			return nil, err
+		}
+		return &iql.BinaryExpr{Op: expr.Op, LHS: lhs, RHS: rhs}, nil
+	case *iql.ParenExpr:
+		e, err := p.replaceCalls(expr.Expr, fields)
+		if err != nil {
+			return nil, err
+		}
+		return &iql.ParenExpr{Expr: e}, nil
+	case *iql.Call:
+		if err := validateCall(expr, p.interval, fields); err != nil {
+			return nil, err
+		}
+		key := callRefPrefix + expr.String()
+		found := false
+		for _, c := range p.calls {
+			if callRefPrefix+c.String() == key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			p.calls = append(p.calls, expr)
+		}
+		return &iql.VarRef{Val: key}, nil
+	default:
+		return expr, nil
+	}
+}
+
+// validateCall checks that call is supported and adds the field it reads to
+// fields.
+func validateCall(call *iql.Call, interval int64, fields map[string]struct{}) error {
+	if _, ok := transformFuncs[call.Name]; ok {
+		if len(call.Args) == 0 {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid number of arguments for %s, expected at least 1, got 0", call.Name),
+			}
+		}
+		inner, ok := call.Args[0].(*iql.Call)
+		if !ok {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("%s() requires an aggregate function as its first argument", call.Name),
+			}
+		}
+		if _, ok := aggregateFuncs[inner.Name]; !ok {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("aggregate function required inside the call to %s", call.Name),
+			}
+		}
+		if interval == 0 {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("%s aggregate requires a GROUP BY interval", call.Name),
+			}
+		}
+		return validateCall(inner, interval, fields)
+	}
+
+	if _, ok := aggregateFuncs[call.Name]; !ok {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("unsupported function %s()", call.Name),
+		}
+	}
+	if len(call.Args) == 0 {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("invalid number of arguments for %s, expected at least 1, got 0", call.Name),
+		}
+	}
+	ref, ok := call.Args[0].(*iql.VarRef)
+	if !ok {
+		return &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("expected field argument in %s()", call.Name),
+		}
+	}
+	if call.Name == "percentile" {
+		if len(call.Args) != 2 {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("invalid number of arguments for percentile, expected 2, got %d", len(call.Args)),
+			}
+		}
+		if _, ok := numberArg(call.Args[1]); !ok {
+			return &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("expected float argument in percentile(), got %s", call.Args[1]),
+			}
+		}
+	}
+	fields[ref.Val] = struct{}{}
+	return nil
+}
+
+// planCondition splits the condition into the parts evaluated by storage and
+// the parts evaluated against each point.
+func (p *selectPlan) planCondition() error {
+	var tagConds, fieldConds []iql.Expr
+	for _, expr := range conjuncts(p.cond) {
+		if isTagCondition(expr) {
+			tagConds = append(tagConds, expr)
+			continue
+		}
+		fieldConds = append(fieldConds, expr)
+		iql.WalkFunc(expr, func(n iql.Node) {
+			if ref, ok := n.(*iql.VarRef); ok && ref.Type != iql.Tag && ref.Type != iql.Unknown {
+				p.fields = appendField(p.fields, ref.Val)
+			}
+		})
+	}
+	p.tagCond = conjunction(tagConds)
+	p.fieldCond = conjunction(fieldConds)
+	return nil
+}
+
+func appendField(fields []string, f string) []string {
+	for _, v := range fields {
+		if v == f {
+			return fields
+		}
+	}
+	return append(fields, f)
+}
+
+// conjuncts splits expr into the expressions joined by AND.
+func conjuncts(expr iql.Expr) []iql.Expr {
+	switch e := expr.(type) {
+	case nil:
+		return nil
+	case *iql.ParenExpr:
+		return conjuncts(e.Expr)
+	case *iql.BinaryExpr:
+		if e.Op == iql.AND {
+			return append(conjuncts(e.LHS), conjuncts(e.RHS)...)
+		}
+	}
+	return []iql.Expr{expr}
+}
+
+func conjunction(exprs []iql.Expr) iql.Expr {
+	var expr iql.Expr
+	for _, e := range exprs {
+		if expr == nil {
+			expr = e
+			continue
+		}
+		expr = &iql.BinaryExpr{Op: iql.AND, LHS: expr, RHS: e}
+	}
+	return expr
+}
+
+// isTagCondition reports whether expr only compares tags and is supported by
+// the storage predicates.
+func isTagCondition(expr iql.Expr) bool {
+	tagsOnly := true
+	iql.WalkFunc(expr, func(n iql.Node) {
+		if ref, ok := n.(*iql.VarRef); ok && ref.Type != iql.Tag && ref.Type != iql.Unknown {
+			tagsOnly = false
+		}
+	})
+	if !tagsOnly {
+		return false
+	}
+	_, err := exprToNode(expr)
+	return err == nil
+}
+
+// countPushdown returns the store if the statement is a single windowed
+// count that can be computed by storage.
+func (p *selectPlan) countPushdown(ctx context.Context, store reads.Store) reads.WindowAggregateStore {
+	if len(p.calls) != 1 || p.calls[0].Name != "count" {
+		return nil
+	}
+	if p.interval == 0 || p.offset != 0 || p.fieldCond != nil {
+		return nil
+	}
+	if p.tr.Min.IsZero() || p.tr.Min.UnixNano() < 0 {
+		return nil
+	}
+	for _, src := range p.stmt.Sources {
+		if _, ok := src.(*iql.Measurement); !ok {
+			return nil
+		}
+	}
+	ws, ok := store.(reads.WindowAggregateStore)
+	if !ok || !ws.GetWindowAggregateCapability(ctx).HaveCount() {
+		return nil
+	}
+	return ws
+}
+
+// expandSources replaces the regular expressions matching measurements
+// with the names of the matching measurements.
+func (e *statementExecutor) expandSources(ctx context.Context, sources iql.Sources) (iql.Sources, error) {
+	out := make(iql.Sources, 0, len(sources))
+	for _, src := range sources {
+		switch src := src.(type) {
+		case *iql.Measurement:
+			if src.Regex == nil {
+				out = append(out, src)
+				continue
+			}
+			names, err := e.measurementNames(ctx, src.Database, src.RetentionPolicy)
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range names {
+				if !src.Regex.Val.MatchString(name) {
+					continue
+				}
+				m := src.Clone()
+				m.Name, m.Regex = name, nil
+				out = append(out, m)
+			}
+		case *iql.SubQuery:
+			stmt := src.Statement.Clone()
+			s, err := e.expandSources(ctx, stmt.Sources)
+			if err != nil {
+				return nil, err
+			}
+			stmt.Sources = s
+			out = append(out, &iql.SubQuery{Statement: stmt})
+		default:
+			return nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("unsupported source %s", src),
+			}
+		}
+	}
+	return out, nil
+}
+
+// readSource reads the series of a measurement or subquery that match the
+// condition of the statement.
+func (e *statementExecutor) readSource(ctx context.Context, p *selectPlan, src iql.Source) ([]*series, error) {
+	switch src := src.(type) {
+	case *iql.Measurement:
+		if p.countStore != nil {
+			ref := p.calls[0].Args[0].(*iql.VarRef)
+			return e.readWindowCount(ctx, p.countStore, src, ref.Val, p.tagCond, p.tr, p.interval)
+		}
+		data, err := e.readSeries(ctx, src, p.fields, p.tagCond, p.tr)
+		if err != nil {
+			return nil, err
+		}
+		return filterSeries(data, p.fieldCond, iql.TimeRange{}), nil
+	case *iql.SubQuery:
+		rows, err := e.selectRows(ctx, src.Statement, p.tr)
+		if err != nil {
+			return nil, err
+		}
+		return filterSeries(rowsToSeries(rows), p.cond, p.tr), nil
+	default:
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  fmt.Sprintf("unsupported source %s", src),
+		}
+	}
+}
+
+// filterSeries removes the points that do not match cond or are outside of
+// the time range, and the series left without points.
+func filterSeries(data []*series, cond iql.Expr, tr iql.TimeRange) []*series {
+	if cond == nil && tr.IsZero() {
+		return data
+	}
+	min, max := tr.MinTimeNano(), tr.MaxTimeNano()
+
+	out := data[:0]
+	for _, s := range data {
+		points := s.points[:0]
+		for _, pt := range s.points {
+			if pt.time < min || pt.time > max {
+				continue
+			}
+			if cond != nil {
+				valuer := iql.ValuerEval{
+					Valuer:               pointValuer{fields: pt.fields, tags: s.tags},
+					IntegerFloatDivision: true,
+				}
+				if !valuer.EvalBool(cond) {
+					continue
+				}
+			}
+			points = append(points, pt)
+		}
+		if len(points) > 0 {
+			s.points = points
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// rowsToSeries converts the result of a subquery into series.
+func rowsToSeries(rows []*influxql.Row) []*series {
+	out := make([]*series, 0, len(rows))
+	for _, row := range rows {
+		s := &series{name: row.Name, tags: row.Tags}
+		for _, values := range row.Values {
+			ts, _ := values[0].(int64)
+			pt := &point{time: ts, fields: make(map[string]interface{}, len(values)-1)}
+			for i := 1; i < len(values) && i < len(row.Columns); i++ {
+				if values[i] != nil {
+					pt.fields[row.Columns[i]] = values[i]
+				}
+			}
+			s.points = append(s.points, pt)
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// group partitions the series by measurement and the GROUP BY tags. The
+// groups are ordered by name and tag values.
+func (p *selectPlan) group(data []*series) []*group {
+	groups := make(map[string]*group)
+	for _, s := range data {
+		var key strings.Builder
+		key.WriteString(s.name)
+		var tags map[string]string
+		if len(p.dims) > 0 {
+			tags = make(map[string]string, len(p.dims))
+			for _, d := range p.dims {
+				tags[d] = s.tags[d]
+				key.WriteByte(0)
+				key.WriteString(s.tags[d])
+			}
+		}
+
+		g, ok := groups[key.String()]
+		if !ok {
+			g = &group{key: key.String(), name: s.name, tags: tags}
+			groups[g.key] = g
+		}
+		for _, pt := range s.points {
+			g.points = append(g.points, groupPoint{point: pt, tags: s.tags})
+		}
+	}
+
+	out := make([]*group, 0, len(groups))
+	for _, g := range groups {
+		sort.SliceStable(g.points, func(i, j int) bool {
+			return g.points[i].time < g.points[j].time
+		})
+		out = append(out, g)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].key < out[j].key })
+	return out
+}
+
+// raw returns the rows of a query that selects raw field values.
+func (p *selectPlan) raw(g *group) [][]interface{} {
+	values := make([][]interface{}, 0, len(g.points))
+	for _, pt := range g.points {
+		valuer := iql.ValuerEval{
+			Valuer:               pointValuer{fields: pt.fields, tags: pt.tags},
+			IntegerFloatDivision: true,
+		}
+		row := make([]interface{}, len(p.columns))
+		row[0] = pt.time
+
+		hasValue := false
+		for i, expr := range p.exprs {
+			v := valuer.Eval(expr)
+			row[i+1] = v
+			if ref, ok := expr.(*iql.VarRef); v != nil && (!ok || ref.Type != iql.Tag) {
+				hasValue = true
+			}
+		}
+		if hasValue {
+			values = append(values, row)
+		}
+	}
+	return values
+}
+
+// aggregate returns the rows of a query that selects function calls.
+func (p *selectPlan) aggregate(g *group) ([][]interface{}, error) {
+	windows := p.windows(g.points)
+	if len(windows) == 0 {
+		return nil, nil
+	}
+
+	cols := make(map[string][]interface{}, len(p.calls))
+	var times []int64
+	for _, call := range p.calls {
+		vals, ts, err := p.evalCall(call, windows)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := transformFuncs[call.Name]; !ok {
+			vals = p.fill(call, vals, windows)
+		}
+		cols[callRefPrefix+call.String()] = vals
+		times = ts
+	}
+
+	// A single selector without a GROUP BY interval reports the time of the
+	// selected point.
+	selectorTime := p.interval == 0 && len(p.calls) == 1 && selectorFuncs[p.calls[0].Name]
+
+	values := make([][]interface{}, 0, len(windows))
+	for i, w := range windows {
+		m := make(iql.MapValuer, len(cols))
+		absent := false
+		for k, vals := range cols {
+			if vals[i] == absentValue {
+				absent = true
+				break
+			}
+			m[k] = vals[i]
+		}
+		if absent {
+			continue
+		}
+
+		row := make([]interface{}, len(p.columns))
+		row[0] = w.start
+		if selectorTime && len(w.points) > 0 {
+			row[0] = times[i]
+		}
+
+		valuer := iql.ValuerEval{Valuer: m, IntegerFloatDivision: true}
+		hasValue := false
+		for j, expr := range p.exprs {
+			row[j+1] = valuer.Eval(expr)
+			if row[j+1] != nil {
+				hasValue = true
+			}
+		}
+		if !hasValue && p.stmt.Fill == iql.NoFill {
+			continue
+		}
+		values = append(values, row)
+	}
+	return values, nil
+}
+
+// windows partitions the points of a group into the windows of the GROUP BY
+// interval.
+func (p *selectPlan) windows(points []groupPoint) []window {
+	if len(points) == 0 {
+		return nil
+	}
+	if p.interval == 0 {
+		var start int64
+		if !p.tr.Min.IsZero() {
+			start = p.tr.Min.UnixNano()
+		}
+		return []window{{start: start, points: points}}
+	}
+
+	start := points[0].time
+	if !p.tr.Min.IsZero() {
+		start = p.tr.Min.UnixNano()
+	}
+	start = p.windowStart(start)
+	end := p.tr.MaxTimeNano()
+
+	var windows []window
+	i := 0
+	for t := start; t <= end; t += p.interval {
+		w := window{start: t}
+		for ; i < len(points) && points[i].time-t < p.interval; i++ {
+			if points[i].time >= t {
+				w.points = append(w.points, points[i])
+			}
+		}
+		if len(w.points) > 0 || p.stmt.Fill != iql.NoFill {
+			windows = append(windows, w)
+		}
+		if t > math.MaxInt64-p.interval {
+			break
+		}
+	}
+	return windows
+}
+
+// windowStart returns the start of the window containing t.
+func (p *selectPlan) windowStart(t int64) int64 {
+	t -= p.offset
+	dt := t % p.interval
+	if dt < 0 {
+		dt += p.interval
+	}
+	return t - dt + p.offset
+}
+
+// evalCall computes the result of call for each window. It also returns the
+// time of the selected point for selectors.
+func (p *selectPlan) evalCall(call *iql.Call, windows []window) ([]interface{}, []int64, error) {
+	if fn, ok := transformFuncs[call.Name]; ok {
+		inner := call.Args[0].(*iql.Call)
+		vals, _, err := p.evalCall(inner, windows)
+		if err != nil {
+			return nil, nil, err
+		}
+		vals = p.fill(inner, vals, windows)
+
+		times := make([]int64, len(windows))
+		for i, w := range windows {
+			times[i] = w.start
+		}
+		out, err := fn(vals, times, call.Args[1:], p.interval)
+		if err != nil {
+			return nil, nil, &platform.Error{
+				Code: platform.EInvalid,
+				Err:  err,
+			}
+		}
+		return out, times, nil
+	}
+
+	fn := aggregateFuncs[call.Name]
+	if p.countStore != nil {
+		// Storage has already counted the values of each series.
+		fn = aggregateFuncs["sum"]
+	}
+	ref := call.Args[0].(*iql.VarRef)
+
+	vals := make([]interface{}, len(windows))
+	times := make([]int64, len(windows))
+	for i, w := range windows {
+		var in []valuePoint
+		for _, pt := range w.points {
+			if v, ok := pt.fields[ref.Val]; ok && v != nil {
+				in = append(in, valuePoint{time: pt.time, value: v})
+			}
+		}
+		if len(in) == 0 {
+			continue
+		}
+		v, ts, ok := fn(in, call.Args[1:])
+		if !ok {
+			return nil, nil, &platform.Error{
+				Code: platform.EInvalid,
+				Msg:  fmt.Sprintf("unsupported %s() argument type %T", call.Name, in[0].value),
+			}
+		}
+		vals[i], times[i] = v, ts
+	}
+	return vals, times, nil
+}
+
+// fill replaces the missing values of the aggregate call according to the
+// fill option of the statement.
+func (p *selectPlan) fill(call *iql.Call, vals []interface{}, windows []window) []interface{} {
+	switch p.stmt.Fill {
+	case iql.NullFill:
+		// Empty windows have a count of zero.
+		if call.Name == "count" {
+			for i, v := range vals {
+				if v == nil {
+					vals[i] = int64(0)
+				}
+			}
+		}
+	case iql.NumberFill:
+		fill := castFillValue(p.stmt.FillValue, vals)
+		for i, v := range vals {
+			if v == nil {
+				vals[i] = fill
+			}
+		}
+	case iql.PreviousFill:
+		var prev interface{}
+		for i, v := range vals {
+			if v == nil {
+				vals[i] = prev
+			} else {
+				prev = v
+			}
+		}
+	case iql.LinearFill:
+		prev := -1
+		for i, v := range vals {
+			if v == nil {
+				continue
+			}
+			if prev >= 0 && i-prev > 1 {
+				for j := prev + 1; j < i; j++ {
+					vals[j] = interpolate(vals[prev], v, windows[prev].start, windows[i].start, windows[j].start)
+				}
+			}
+			prev = i
+		}
+	}
+	return vals
+}
+
+// castFillValue converts the fill number to the type of the values.
+func castFillValue(fill interface{}, vals []interface{}) interface{} {
+	f, ok := toFloat(fill)
+	if !ok {
+		return fill
+	}
+	for _, v := range vals {
+		switch v.(type) {
+		case float64:
+			return f
+		case int64:
+			return int64(f)
+		case uint64:
+			return uint64(f)
+		}
+	}
+	return fill
+}
+
+// interpolate returns the linear interpolation at t between the values v0 at
+// t0 and v1 at t1.
+func interpolate(v0, v1 interface{}, t0, t1, t int64) interface{} {
+	switch v0 := v0.(type) {
+	case int64:
+		if v1, ok := v1.(int64); ok {
+			return v0 + (v1-v0)*(t-t0)/(t1-t0)
+		}
+	case uint64:
+		if v1, ok := v1.(uint64); ok {
+			if v1 >= v0 {
+				return v0 + (v1-v0)*uint64(t-t0)/uint64(t1-t0)
+			}
+			return v0 - (v0-v1)*uint64(t-t0)/uint64(t1-t0)
+		}
+	}
+	f0, ok0 := toFloat(v0)
+	f1, ok1 := toFloat(v1)
+	if !ok0 || !ok1 {
+		return nil
+	}
+	return f0 + (f1-f0)*float64(t-t0)/float64(t1-t0)
+}
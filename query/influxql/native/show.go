@@ -0,0 +1,531 @@
+package native
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/query/influxql"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
+	iql "github.com/influxdata/influxql"
+)
+
+// executeShowDatabases lists the databases mapped to buckets the caller is
+// allowed to read.
+func (e *statementExecutor) executeShowDatabases(ctx context.Context, stmt *iql.ShowDatabasesStatement) ([]*influxql.Row, error) {
+	if e.dbrpMappingSvc == nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "unable to list databases: no dbrp mapping service configured",
+		}
+	}
+
+	mappings, _, err := e.dbrpMappingSvc.FindMany(ctx, platform.DBRPMappingFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var names []string
+	for _, m := range mappings {
+		if _, ok := seen[m.Database]; ok {
+			continue
+		}
+		if _, err := e.deps.BucketService.FindBucketByID(ctx, m.BucketID); err != nil {
+			continue
+		}
+		seen[m.Database] = struct{}{}
+		names = append(names, m.Database)
+	}
+	sort.Strings(names)
+
+	return stringRows("databases", "name", names), nil
+}
+
+// executeShowRetentionPolicies lists the retention policies of a database.
+// Each retention policy is mapped to a bucket whose retention period is
+// reported as the duration of the policy.
+func (e *statementExecutor) executeShowRetentionPolicies(ctx context.Context, stmt *iql.ShowRetentionPoliciesStatement) ([]*influxql.Row, error) {
+	db := stmt.Database
+	if db == "" {
+		db = e.db
+	}
+	if db == "" {
+		return nil, &platform.Error{
+			Code: platform.EInvalid,
+			Msg:  "database name required",
+		}
+	}
+	if e.dbrpMappingSvc == nil {
+		return nil, &platform.Error{
+			Code: platform.EInternal,
+			Msg:  "unable to list retention policies: no dbrp mapping service configured",
+		}
+	}
+
+	mappings, _, err := e.dbrpMappingSvc.FindMany(ctx, platform.DBRPMappingFilter{Database: &db})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		return mappings[i].RetentionPolicy < mappings[j].RetentionPolicy
+	})
+
+	var values [][]interface{}
+	for _, m := range mappings {
+		b, err := e.deps.BucketService.FindBucketByID(ctx, m.BucketID)
+		if err != nil {
+			continue
+		}
+		values = append(values, []interface{}{
+			m.RetentionPolicy,
+			b.RetentionPeriod.String(),
+			shardGroupDuration(b.RetentionPeriod).String(),
+			int64(1),
+			m.Default,
+		})
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	return []*influxql.Row{{
+		Columns: []string{"name", "duration", "shardGroupDuration", "replicaN", "default"},
+		Values:  values,
+	}}, nil
+}
+
+// shardGroupDuration returns the default shard group duration of 1.x for a
+// retention policy with duration d.
+func shardGroupDuration(d time.Duration) time.Duration {
+	switch {
+	case d == 0 || d >= 180*24*time.Hour:
+		return 7 * 24 * time.Hour
+	case d >= 2*24*time.Hour:
+		return 24 * time.Hour
+	default:
+		return time.Hour
+	}
+}
+
+// executeShowMeasurements lists the measurements of a database.
+func (e *statementExecutor) executeShowMeasurements(ctx context.Context, stmt *iql.ShowMeasurementsStatement) ([]*influxql.Row, error) {
+	var sources iql.Sources
+	if stmt.Source != nil {
+		sources = iql.Sources{stmt.Source}
+	}
+	b, err := e.showBucket(ctx, stmt.Database, sources)
+	if err != nil {
+		return nil, err
+	}
+	cond, tr, err := e.showCondition(stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := e.showMeasurementNames(ctx, b, sources, cond, tr)
+	if err != nil {
+		return nil, err
+	}
+	names = limitStrings(names, stmt.Limit, stmt.Offset)
+	return stringRows("measurements", "name", names), nil
+}
+
+// executeShowTagKeys lists the tag keys of each measurement.
+func (e *statementExecutor) executeShowTagKeys(ctx context.Context, stmt *iql.ShowTagKeysStatement) ([]*influxql.Row, error) {
+	b, err := e.showBucket(ctx, stmt.Database, stmt.Sources)
+	if err != nil {
+		return nil, err
+	}
+	cond, tr, err := e.showCondition(stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	names, err := e.showMeasurementNames(ctx, b, stmt.Sources, cond, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*influxql.Row
+	for _, name := range names {
+		it, err := e.deps.Schema.MeasurementTagKeys(ctx, b.OrgID, b.ID, name, tr.MinTimeNano(), tr.MaxTimeNano(), cond)
+		if err != nil {
+			return nil, err
+		}
+		var keys []string
+		for _, k := range readStrings(it) {
+			if !isInternalTagKey(k) {
+				keys = append(keys, k)
+			}
+		}
+		keys = limitStrings(keys, stmt.Limit, stmt.Offset)
+		rows = append(rows, stringRows(name, "tagKey", keys)...)
+	}
+	return limitRows(rows, stmt.SLimit, stmt.SOffset), nil
+}
+
+// executeShowTagValues lists the values of the tag keys matching the WITH KEY
+// clause for each measurement.
+func (e *statementExecutor) executeShowTagValues(ctx context.Context, stmt *iql.ShowTagValuesStatement) ([]*influxql.Row, error) {
+	b, err := e.showBucket(ctx, stmt.Database, stmt.Sources)
+	if err != nil {
+		return nil, err
+	}
+	cond, tr, err := e.showCondition(stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+	names, err := e.showMeasurementNames(ctx, b, stmt.Sources, cond, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*influxql.Row
+	for _, name := range names {
+		it, err := e.deps.Schema.MeasurementTagKeys(ctx, b.OrgID, b.ID, name, tr.MinTimeNano(), tr.MaxTimeNano(), cond)
+		if err != nil {
+			return nil, err
+		}
+
+		var values [][]interface{}
+		for _, k := range readStrings(it) {
+			if isInternalTagKey(k) {
+				continue
+			}
+			ok, err := matchTagKey(stmt.Op, stmt.TagKeyExpr, k)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+
+			it, err := e.deps.Schema.MeasurementTagValues(ctx, b.OrgID, b.ID, name, k, tr.MinTimeNano(), tr.MaxTimeNano(), cond)
+			if err != nil {
+				return nil, err
+			}
+			for _, v := range readStrings(it) {
+				values = append(values, []interface{}{k, v})
+			}
+		}
+
+		values = limitValues(values, stmt.Limit, stmt.Offset)
+		if len(values) == 0 {
+			continue
+		}
+		rows = append(rows, &influxql.Row{
+			Name:    name,
+			Columns: []string{"key", "value"},
+			Values:  values,
+		})
+	}
+	return rows, nil
+}
+
+// matchTagKey reports whether the tag key k matches the WITH KEY clause.
+func matchTagKey(op iql.Token, expr iql.Literal, k string) (bool, error) {
+	switch op {
+	case iql.EQ, iql.NEQ:
+		lit, ok := expr.(*iql.StringLiteral)
+		if !ok {
+			break
+		}
+		return (lit.Val == k) == (op == iql.EQ), nil
+	case iql.EQREGEX, iql.NEQREGEX:
+		lit, ok := expr.(*iql.RegexLiteral)
+		if !ok {
+			break
+		}
+		return lit.Val.MatchString(k) == (op == iql.EQREGEX), nil
+	case iql.IN:
+		lit, ok := expr.(*iql.ListLiteral)
+		if !ok {
+			break
+		}
+		for _, v := range lit.Vals {
+			if v == k {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return false, &platform.Error{
+		Code: platform.EInvalid,
+		Msg:  fmt.Sprintf("unsupported WITH KEY clause %s %s", op, expr),
+	}
+}
+
+// executeShowFieldKeys lists the field keys and types of each measurement.
+func (e *statementExecutor) executeShowFieldKeys(ctx context.Context, stmt *iql.ShowFieldKeysStatement) ([]*influxql.Row, error) {
+	b, err := e.showBucket(ctx, stmt.Database, stmt.Sources)
+	if err != nil {
+		return nil, err
+	}
+	names, err := e.showMeasurementNames(ctx, b, stmt.Sources, nil, iql.TimeRange{})
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*influxql.Row
+	for _, name := range names {
+		it, err := e.deps.Schema.MeasurementFields(ctx, b.OrgID, b.ID, name, models.MinNanoTime, models.MaxNanoTime, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var fields []cursors.MeasurementField
+		for it.Next() {
+			fields = append(fields, it.Value().Fields...)
+		}
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Key < fields[j].Key })
+
+		values := make([][]interface{}, 0, len(fields))
+		for _, f := range fields {
+			values = append(values, []interface{}{f.Key, cursors.FieldTypeToDataType(f.Type).String()})
+		}
+		values = limitValues(values, stmt.Limit, stmt.Offset)
+		if len(values) == 0 {
+			continue
+		}
+		rows = append(rows, &influxql.Row{
+			Name:    name,
+			Columns: []string{"fieldKey", "fieldType"},
+			Values:  values,
+		})
+	}
+	return rows, nil
+}
+
+// executeShowSeries lists the keys of the series matching the condition.
+func (e *statementExecutor) executeShowSeries(ctx context.Context, stmt *iql.ShowSeriesStatement) ([]*influxql.Row, error) {
+	b, err := e.showBucket(ctx, stmt.Database, stmt.Sources)
+	if err != nil {
+		return nil, err
+	}
+	cond, _, err := e.showCondition(stmt.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(stmt.Sources) > 0 {
+		names, err := e.showMeasurementNames(ctx, b, stmt.Sources, nil, iql.TimeRange{})
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, nil
+		}
+		var expr iql.Expr
+		for _, name := range names {
+			eq := &iql.BinaryExpr{
+				Op:  iql.EQ,
+				LHS: &iql.VarRef{Val: models.MeasurementTagKey},
+				RHS: &iql.StringLiteral{Val: name},
+			}
+			if expr == nil {
+				expr = eq
+				continue
+			}
+			expr = &iql.BinaryExpr{Op: iql.OR, LHS: expr, RHS: eq}
+		}
+		if cond != nil {
+			expr = &iql.BinaryExpr{
+				Op:  iql.AND,
+				LHS: &iql.ParenExpr{Expr: expr},
+				RHS: &iql.ParenExpr{Expr: cond},
+			}
+		}
+		cond = expr
+	}
+
+	cur, err := e.deps.Schema.CreateSeriesCursor(ctx, b.OrgID, b.ID, cond)
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close()
+
+	seen := make(map[string]struct{})
+	var keys []string
+	for {
+		row, err := cur.Next()
+		if err != nil {
+			return nil, err
+		}
+		if row == nil {
+			break
+		}
+
+		tags := make(models.Tags, 0, len(row.Tags))
+		for _, t := range row.Tags {
+			if !isInternalTagKey(string(t.Key)) {
+				tags = append(tags, t)
+			}
+		}
+		key := string(models.MakeKey(row.Tags.Get(models.MeasurementTagKeyBytes), tags))
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	keys = limitStrings(keys, stmt.Limit, stmt.Offset)
+	return stringRows("", "key", keys), nil
+}
+
+// showBucket resolves the bucket queried by a SHOW statement. The retention
+// policy of the first source selects the bucket when it is specified.
+func (e *statementExecutor) showBucket(ctx context.Context, db string, sources iql.Sources) (*platform.Bucket, error) {
+	var rp string
+	for _, src := range sources {
+		if m, ok := src.(*iql.Measurement); ok && m.RetentionPolicy != "" {
+			if m.Database != "" {
+				db = m.Database
+			}
+			rp = m.RetentionPolicy
+			break
+		}
+	}
+	b, err := e.findBucket(ctx, db, rp)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := readScopes(ctx, b)
+	if err != nil {
+		return nil, err
+	}
+	if scopes != nil {
+		return nil, &platform.Error{
+			Code: platform.EForbidden,
+			Msg:  "SHOW statements are not supported with permissions restricted to measurements or tags",
+		}
+	}
+	return b, nil
+}
+
+// showCondition extracts the time range from the condition of a SHOW
+// statement.
+func (e *statementExecutor) showCondition(cond iql.Expr) (iql.Expr, iql.TimeRange, error) {
+	cond, tr, err := iql.ConditionExpr(cond, &iql.NowValuer{Now: e.now})
+	if err != nil {
+		return nil, iql.TimeRange{}, &platform.Error{
+			Code: platform.EInvalid,
+			Err:  err,
+		}
+	}
+	return cond, tr, nil
+}
+
+// showMeasurementNames returns the sorted names of the measurements of the
+// bucket that match the sources and have series matching cond.
+func (e *statementExecutor) showMeasurementNames(ctx context.Context, b *platform.Bucket, sources iql.Sources, cond iql.Expr, tr iql.TimeRange) ([]string, error) {
+	var (
+		it  cursors.StringIterator
+		err error
+	)
+	if cond == nil {
+		it, err = e.deps.Schema.MeasurementNames(ctx, b.OrgID, b.ID, tr.MinTimeNano(), tr.MaxTimeNano())
+	} else {
+		it, err = e.deps.Schema.TagValues(ctx, b.OrgID, b.ID, models.MeasurementTagKey, tr.MinTimeNano(), tr.MaxTimeNano(), cond)
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := readStrings(it)
+
+	if len(sources) > 0 {
+		filtered := names[:0]
+		for _, name := range names {
+			if matchSources(sources, name) {
+				filtered = append(filtered, name)
+			}
+		}
+		names = filtered
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// measurementNames returns the names of all measurements in the bucket of
+// the database and retention policy.
+func (e *statementExecutor) measurementNames(ctx context.Context, db, rp string) ([]string, error) {
+	b, err := e.findBucket(ctx, db, rp)
+	if err != nil {
+		return nil, err
+	}
+	return e.showMeasurementNames(ctx, b, nil, nil, iql.TimeRange{})
+}
+
+func matchSources(sources iql.Sources, name string) bool {
+	for _, src := range sources {
+		m, ok := src.(*iql.Measurement)
+		if !ok {
+			continue
+		}
+		if m.Regex != nil && m.Regex.Val.MatchString(name) || m.Regex == nil && m.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func readStrings(it cursors.StringIterator) []string {
+	var out []string
+	for it.Next() {
+		out = append(out, it.Value())
+	}
+	return out
+}
+
+// stringRows returns a single row listing vals in one column, or no rows if
+// vals is empty.
+func stringRows(name, column string, vals []string) []*influxql.Row {
+	if len(vals) == 0 {
+		return nil
+	}
+	values := make([][]interface{}, len(vals))
+	for i, v := range vals {
+		values[i] = []interface{}{v}
+	}
+	return []*influxql.Row{{
+		Name:    name,
+		Columns: []string{column},
+		Values:  values,
+	}}
+}
+
+func limitStrings(vals []string, limit, offset int) []string {
+	if offset >= len(vals) {
+		return nil
+	}
+	vals = vals[offset:]
+	if limit > 0 && limit < len(vals) {
+		vals = vals[:limit]
+	}
+	return vals
+}
+
+func limitValues(vals [][]interface{}, limit, offset int) [][]interface{} {
+	if offset >= len(vals) {
+		return nil
+	}
+	vals = vals[offset:]
+	if limit > 0 && limit < len(vals) {
+		vals = vals[:limit]
+	}
+	return vals
+}
+
+func limitRows(rows []*influxql.Row, limit, offset int) []*influxql.Row {
+	if offset >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
@@ -70,6 +70,11 @@ func newQueryResult(r *Result) *seriesIterator {
 	}
 }
 
+// NewResult constructs a flux.Result from a single statement Result.
+func NewResult(r *Result) flux.Result {
+	return newQueryResult(r)
+}
+
 // Name returns the results statement id.
 // It is used to implement flux.Result.
 func (r *seriesIterator) Name() string {
@@ -135,6 +140,10 @@ func (t *queryTable) translateRowsToColumns() error {
 			b := arrow.NewFloatBuilder(&memory.Allocator{})
 			b.Reserve(t.Len())
 			for _, row := range t.row.Values {
+				if row[i] == nil {
+					b.AppendNull()
+					continue
+				}
 				val, ok := row[i].(float64)
 				if !ok {
 					return fmt.Errorf("unsupported type %T found in column %s of type %s", val, col.Label, col.Type)
@@ -147,6 +156,10 @@ func (t *queryTable) translateRowsToColumns() error {
 			b := arrow.NewIntBuilder(&memory.Allocator{})
 			b.Reserve(t.Len())
 			for _, row := range t.row.Values {
+				if row[i] == nil {
+					b.AppendNull()
+					continue
+				}
 				val, ok := row[i].(int64)
 				if !ok {
 					return fmt.Errorf("unsupported type %T found in column %s of type %s", val, col.Label, col.Type)
@@ -159,6 +172,10 @@ func (t *queryTable) translateRowsToColumns() error {
 			b := arrow.NewUintBuilder(&memory.Allocator{})
 			b.Reserve(t.Len())
 			for _, row := range t.row.Values {
+				if row[i] == nil {
+					b.AppendNull()
+					continue
+				}
 				val, ok := row[i].(uint64)
 				if !ok {
 					return fmt.Errorf("unsupported type %T found in column %s of type %s", val, col.Label, col.Type)
@@ -171,6 +188,10 @@ func (t *queryTable) translateRowsToColumns() error {
 			b := arrow.NewStringBuilder(&memory.Allocator{})
 			b.Reserve(t.Len())
 			for _, row := range t.row.Values {
+				if row[i] == nil {
+					b.AppendNull()
+					continue
+				}
 				val, ok := row[i].(string)
 				if !ok {
 					return fmt.Errorf("unsupported type %T found in column %s of type %s", val, col.Label, col.Type)
@@ -183,6 +204,10 @@ func (t *queryTable) translateRowsToColumns() error {
 			b := arrow.NewBoolBuilder(&memory.Allocator{})
 			b.Reserve(t.Len())
 			for _, row := range t.row.Values {
+				if row[i] == nil {
+					b.AppendNull()
+					continue
+				}
 				val, ok := row[i].(bool)
 				if !ok {
 					return fmt.Errorf("unsupported type %T found in column %s of type %s", val, col.Label, col.Type)
@@ -196,6 +221,8 @@ func (t *queryTable) translateRowsToColumns() error {
 			b.Reserve(t.Len())
 			for _, row := range t.row.Values {
 				switch val := row[i].(type) {
+				case nil:
+					b.AppendNull()
 				case int64:
 					b.Append(val)
 				case float64:
@@ -253,7 +280,11 @@ func (r *queryTable) Key() flux.GroupKey {
 				panic(fmt.Errorf("table invalid: missing group column %q", label))
 			}
 			cols[j] = colMeta[idx]
-			kvs[j] = "string"
+			if label == "_measurement" {
+				kvs[j] = r.row.Name
+			} else {
+				kvs[j] = r.row.Tags[label]
+			}
 			v := values.New(kvs[j])
 			if v == values.InvalidValue {
 				panic(fmt.Sprintf("unsupported value kind %T", kvs[j]))
@@ -297,23 +328,29 @@ func (r *queryTable) Cols() []flux.ColMeta {
 		if len(r.row.Values) < 1 {
 			panic("must have at least one value")
 		}
-		data := r.row.Values[0]
 		for i := range r.row.Columns {
-			v := data[i]
 			if colMeta[i].Label == "_time" {
 				continue
 			}
-			switch v.(type) {
-			case float64:
-				colMeta[i].Type = flux.TFloat
-			case int64:
-				colMeta[i].Type = flux.TInt
-			case uint64:
-				colMeta[i].Type = flux.TUInt
-			case bool:
-				colMeta[i].Type = flux.TBool
-			case string:
-				colMeta[i].Type = flux.TString
+			// The type of a column is determined by its first non-null value.
+			// A column that only contains null values is typed as a float.
+			colMeta[i].Type = flux.TFloat
+			for _, data := range r.row.Values {
+				switch data[i].(type) {
+				case float64:
+					colMeta[i].Type = flux.TFloat
+				case int64:
+					colMeta[i].Type = flux.TInt
+				case uint64:
+					colMeta[i].Type = flux.TUInt
+				case bool:
+					colMeta[i].Type = flux.TBool
+				case string:
+					colMeta[i].Type = flux.TString
+				default:
+					continue
+				}
+				break
 			}
 		}
 
@@ -1,10 +1,13 @@
 package influxql
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/influxdata/flux"
@@ -13,7 +16,12 @@ import (
 )
 
 // MultiResultEncoder encodes results as InfluxQL JSON format.
-type MultiResultEncoder struct{}
+type MultiResultEncoder struct {
+	// TimeFormat is the format used for time values; defaults to RFC3339Nano.
+	TimeFormat TimeFormat
+	// Encoding is the format of the response; defaults to JSON.
+	Encoding EncodingFormat
+}
 
 // Encode writes a collection of results to the influxdb 1.X http response format.
 // Expectations/Assumptions:
@@ -154,7 +162,7 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 						vs := cr.Times(idx)
 						for i := 0; i < vs.Len(); i++ {
 							if vs.IsValid(i) {
-								values[i][j] = execute.Time(vs.Value(i)).Time().Format(time.RFC3339Nano)
+								values[i][j] = e.formatTime(execute.Time(vs.Value(i)))
 							}
 						}
 					default:
@@ -182,9 +190,143 @@ func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (i
 		resp.error(err)
 	}
 
-	err := json.NewEncoder(wc).Encode(resp)
+	var err error
+	switch e.Encoding {
+	case CSV:
+		err = encodeCSV(wc, &resp)
+	case JSONPretty:
+		enc := json.NewEncoder(wc)
+		enc.SetIndent("", "    ")
+		err = enc.Encode(resp)
+	default:
+		err = json.NewEncoder(wc).Encode(resp)
+	}
 	return wc.Count(), err
 }
+
+// formatTime formats t according to the configured TimeFormat.
+func (e *MultiResultEncoder) formatTime(t execute.Time) interface{} {
+	var unit time.Duration
+	switch e.TimeFormat {
+	case Hour:
+		unit = time.Hour
+	case Minute:
+		unit = time.Minute
+	case Second:
+		unit = time.Second
+	case Millisecond:
+		unit = time.Millisecond
+	case Microsecond:
+		unit = time.Microsecond
+	case Nanosecond:
+		unit = time.Nanosecond
+	default:
+		return t.Time().Format(time.RFC3339Nano)
+	}
+	return int64(t) / int64(unit)
+}
+
+// encodeCSV writes the response in the influxdb 1.X CSV format.
+// A header is written whenever the set of columns changes between series
+// and an empty line separates series with different headers.
+func encodeCSV(w io.Writer, resp *Response) error {
+	cw := csv.NewWriter(w)
+	if resp.Err != "" {
+		_ = cw.Write([]string{"error"})
+		_ = cw.Write([]string{resp.Err})
+		cw.Flush()
+		return cw.Error()
+	}
+
+	var columns []string
+	for _, result := range resp.Results {
+		if result.Err != "" {
+			_ = cw.Write([]string{"error"})
+			_ = cw.Write([]string{result.Err})
+			continue
+		}
+		for _, row := range result.Series {
+			header := append([]string{"name", "tags"}, row.Columns...)
+			if !stringsEqual(columns, header) {
+				if columns != nil {
+					cw.Flush()
+					if _, err := io.WriteString(w, "\n"); err != nil {
+						return err
+					}
+				}
+				columns = header
+				if err := cw.Write(columns); err != nil {
+					return err
+				}
+			}
+
+			tags := tagsString(row.Tags)
+			record := make([]string, len(header))
+			for _, values := range row.Values {
+				record[0], record[1] = row.Name, tags
+				for i, v := range values {
+					record[i+2] = csvValue(v)
+				}
+				if err := cw.Write(record); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func tagsString(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(tags[k])
+	}
+	return b.String()
+}
+
+func csvValue(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case uint64:
+		return strconv.FormatUint(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func NewMultiResultEncoder() *MultiResultEncoder {
 	return new(MultiResultEncoder)
 }
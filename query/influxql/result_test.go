@@ -106,6 +106,59 @@ func TestMultiResultEncoder_Encode(t *testing.T) {
 	}
 }
 
+func TestMultiResultEncoder_EncodeFormats(t *testing.T) {
+	newResults := func() flux.ResultIterator {
+		return flux.NewSliceResultIterator(
+			[]flux.Result{&executetest.Result{
+				Nm: "0",
+				Tbls: []*executetest.Table{{
+					KeyCols: []string{"_measurement", "host"},
+					ColMeta: []flux.ColMeta{
+						{Label: "_time", Type: flux.TTime},
+						{Label: "_measurement", Type: flux.TString},
+						{Label: "host", Type: flux.TString},
+						{Label: "value", Type: flux.TFloat},
+					},
+					Data: [][]interface{}{
+						{ts("2018-05-24T09:00:00Z"), "m0", "server01", float64(2)},
+						{ts("2018-05-24T09:00:10Z"), "m0", "server01", float64(2.5)},
+					},
+				}},
+			}},
+		)
+	}
+
+	for _, tt := range []struct {
+		name string
+		enc  *influxql.MultiResultEncoder
+		out  string
+	}{
+		{
+			name: "Epoch seconds",
+			enc:  &influxql.MultiResultEncoder{TimeFormat: influxql.Second},
+			out:  `{"results":[{"statement_id":0,"series":[{"name":"m0","tags":{"host":"server01"},"columns":["time","value"],"values":[[1527152400,2],[1527152410,2.5]]}]}]}` + "\n",
+		},
+		{
+			name: "CSV",
+			enc:  &influxql.MultiResultEncoder{Encoding: influxql.CSV, TimeFormat: influxql.Nanosecond},
+			out: "name,tags,time,value\n" +
+				"m0,host=server01,1527152400000000000,2\n" +
+				"m0,host=server01,1527152410000000000,2.5\n",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if _, err := tt.enc.Encode(&buf, newResults()); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+		})
+	}
+}
+
 type resultErrorIterator struct {
 	Error string
 }
@@ -0,0 +1,59 @@
+package promql
+
+import (
+	"context"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/flux/lang"
+)
+
+const CompilerType = "promql"
+
+// AddCompilerMappings adds the promql specific compiler mappings.
+func AddCompilerMappings(mappings flux.CompilerMappings) error {
+	return mappings.Add(CompilerType, func() flux.Compiler {
+		return new(Compiler)
+	})
+}
+
+// Compiler transpiles a PromQL range query to Flux.
+type Compiler struct {
+	Bucket string        `json:"bucket"`
+	Query  string        `json:"query"`
+	Start  time.Time     `json:"start"`
+	End    time.Time     `json:"end"`
+	Step   time.Duration `json:"step"`
+	Now    *time.Time    `json:"now,omitempty"`
+}
+
+var _ flux.Compiler = &Compiler{}
+
+// Compile transpiles the query into a Program.
+func (c *Compiler) Compile(ctx context.Context, runtime flux.Runtime) (flux.Program, error) {
+	var now time.Time
+	if c.Now != nil {
+		now = *c.Now
+	} else {
+		now = time.Now()
+	}
+	src, err := BuildFlux(c.Query, Config{
+		Bucket: c.Bucket,
+		Start:  c.Start,
+		End:    c.End,
+		Step:   c.Step,
+	})
+	if err != nil {
+		return nil, &flux.Error{
+			Code: codes.Invalid,
+			Msg:  "failed to transpile PromQL query",
+			Err:  err,
+		}
+	}
+	return lang.Compile(src, runtime, now)
+}
+
+func (c *Compiler) CompilerType() flux.CompilerType {
+	return CompilerType
+}
@@ -0,0 +1,32 @@
+package promql
+
+import (
+	"net/http"
+
+	"github.com/influxdata/flux"
+)
+
+const DialectType = "promql"
+
+// AddDialectMappings adds the promql specific dialect mappings.
+func AddDialectMappings(mappings flux.DialectMappings) error {
+	return mappings.Add(DialectType, func() flux.Dialect {
+		return new(Dialect)
+	})
+}
+
+// Dialect describes the output format of PromQL queries, the JSON response
+// of the Prometheus range query API.
+type Dialect struct{}
+
+func (d *Dialect) SetHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "application/json")
+}
+
+func (d *Dialect) Encoder() flux.MultiResultEncoder {
+	return new(MultiResultEncoder)
+}
+
+func (d *Dialect) DialectType() flux.DialectType {
+	return DialectType
+}
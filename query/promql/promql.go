@@ -48,58 +48,75 @@ var g = &grammar{
 									},
 									&ruleRefExpr{
 										pos:  position{line: 13, col: 32, offset: 331},
-										name: "AggregateExpression",
-									},
-									&ruleRefExpr{
-										pos:  position{line: 13, col: 54, offset: 353},
-										name: "VectorSelector",
+										name: "Expression",
 									},
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 13, col: 71, offset: 370},
+							pos:  position{line: 13, col: 45, offset: 344},
 							name: "EOF",
 						},
 					},
 				},
 			},
 		},
+		{
+			name: "Expression",
+			pos:  position{line: 17, col: 1, offset: 377},
+			expr: &choiceExpr{
+				pos: position{line: 17, col: 14, offset: 390},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 17, col: 14, offset: 390},
+						name: "AggregateExpression",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 17, col: 36, offset: 412},
+						name: "FunctionCall",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 17, col: 51, offset: 427},
+						name: "VectorSelector",
+					},
+				},
+			},
+		},
 		{
 			name: "SourceChar",
-			pos:  position{line: 17, col: 1, offset: 403},
+			pos:  position{line: 19, col: 1, offset: 443},
 			expr: &anyMatcher{
-				line: 17, col: 14, offset: 416,
+				line: 19, col: 14, offset: 456,
 			},
 		},
 		{
 			name: "Comment",
-			pos:  position{line: 19, col: 1, offset: 419},
+			pos:  position{line: 21, col: 1, offset: 459},
 			expr: &actionExpr{
-				pos: position{line: 19, col: 11, offset: 429},
+				pos: position{line: 21, col: 11, offset: 469},
 				run: (*parser).callonComment1,
 				expr: &seqExpr{
-					pos: position{line: 19, col: 11, offset: 429},
+					pos: position{line: 21, col: 11, offset: 469},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 19, col: 11, offset: 429},
+							pos:        position{line: 21, col: 11, offset: 469},
 							val:        "#",
 							ignoreCase: false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 19, col: 15, offset: 433},
+							pos: position{line: 21, col: 15, offset: 473},
 							expr: &seqExpr{
-								pos: position{line: 19, col: 17, offset: 435},
+								pos: position{line: 21, col: 17, offset: 475},
 								exprs: []interface{}{
 									&notExpr{
-										pos: position{line: 19, col: 17, offset: 435},
+										pos: position{line: 21, col: 17, offset: 475},
 										expr: &ruleRefExpr{
-											pos:  position{line: 19, col: 18, offset: 436},
+											pos:  position{line: 21, col: 18, offset: 476},
 											name: "EOL",
 										},
 									},
 									&ruleRefExpr{
-										pos:  position{line: 19, col: 22, offset: 440},
+										pos:  position{line: 21, col: 22, offset: 480},
 										name: "SourceChar",
 									},
 								},
@@ -111,15 +128,15 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 23, col: 1, offset: 500},
+			pos:  position{line: 25, col: 1, offset: 540},
 			expr: &actionExpr{
-				pos: position{line: 23, col: 14, offset: 513},
+				pos: position{line: 25, col: 14, offset: 553},
 				run: (*parser).callonIdentifier1,
 				expr: &labeledExpr{
-					pos:   position{line: 23, col: 14, offset: 513},
+					pos:   position{line: 25, col: 14, offset: 553},
 					label: "ident",
 					expr: &ruleRefExpr{
-						pos:  position{line: 23, col: 20, offset: 519},
+						pos:  position{line: 25, col: 20, offset: 559},
 						name: "IdentifierName",
 					},
 				},
@@ -127,21 +144,21 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierName",
-			pos:  position{line: 30, col: 1, offset: 692},
+			pos:  position{line: 32, col: 1, offset: 732},
 			expr: &actionExpr{
-				pos: position{line: 30, col: 18, offset: 709},
+				pos: position{line: 32, col: 18, offset: 749},
 				run: (*parser).callonIdentifierName1,
 				expr: &seqExpr{
-					pos: position{line: 30, col: 18, offset: 709},
+					pos: position{line: 32, col: 18, offset: 749},
 					exprs: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 30, col: 18, offset: 709},
+							pos:  position{line: 32, col: 18, offset: 749},
 							name: "IdentifierStart",
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 30, col: 34, offset: 725},
+							pos: position{line: 32, col: 34, offset: 765},
 							expr: &ruleRefExpr{
-								pos:  position{line: 30, col: 34, offset: 725},
+								pos:  position{line: 32, col: 34, offset: 765},
 								name: "IdentifierPart",
 							},
 						},
@@ -151,9 +168,9 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierStart",
-			pos:  position{line: 33, col: 1, offset: 776},
+			pos:  position{line: 35, col: 1, offset: 816},
 			expr: &charClassMatcher{
-				pos:        position{line: 33, col: 19, offset: 794},
+				pos:        position{line: 35, col: 19, offset: 834},
 				val:        "[\\pL_]",
 				chars:      []rune{'_'},
 				classes:    []*unicode.RangeTable{rangeTable("L")},
@@ -163,16 +180,16 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierPart",
-			pos:  position{line: 34, col: 1, offset: 801},
+			pos:  position{line: 36, col: 1, offset: 841},
 			expr: &choiceExpr{
-				pos: position{line: 34, col: 18, offset: 818},
+				pos: position{line: 36, col: 18, offset: 858},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 34, col: 18, offset: 818},
+						pos:  position{line: 36, col: 18, offset: 858},
 						name: "IdentifierStart",
 					},
 					&charClassMatcher{
-						pos:        position{line: 34, col: 36, offset: 836},
+						pos:        position{line: 36, col: 36, offset: 876},
 						val:        "[\\p{Nd}]",
 						classes:    []*unicode.RangeTable{rangeTable("Nd")},
 						ignoreCase: false,
@@ -183,74 +200,74 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 36, col: 1, offset: 846},
+			pos:  position{line: 38, col: 1, offset: 886},
 			expr: &choiceExpr{
-				pos: position{line: 36, col: 17, offset: 862},
+				pos: position{line: 38, col: 17, offset: 902},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 36, col: 17, offset: 862},
+						pos: position{line: 38, col: 17, offset: 902},
 						run: (*parser).callonStringLiteral2,
 						expr: &choiceExpr{
-							pos: position{line: 36, col: 19, offset: 864},
+							pos: position{line: 38, col: 19, offset: 904},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 36, col: 19, offset: 864},
+									pos: position{line: 38, col: 19, offset: 904},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 36, col: 19, offset: 864},
+											pos:        position{line: 38, col: 19, offset: 904},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 36, col: 23, offset: 868},
+											pos: position{line: 38, col: 23, offset: 908},
 											expr: &ruleRefExpr{
-												pos:  position{line: 36, col: 23, offset: 868},
+												pos:  position{line: 38, col: 23, offset: 908},
 												name: "DoubleStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 36, col: 41, offset: 886},
+											pos:        position{line: 38, col: 41, offset: 926},
 											val:        "\"",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 36, col: 47, offset: 892},
+									pos: position{line: 38, col: 47, offset: 932},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 36, col: 47, offset: 892},
+											pos:        position{line: 38, col: 47, offset: 932},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 36, col: 51, offset: 896},
+											pos:  position{line: 38, col: 51, offset: 936},
 											name: "SingleStringChar",
 										},
 										&litMatcher{
-											pos:        position{line: 36, col: 68, offset: 913},
+											pos:        position{line: 38, col: 68, offset: 953},
 											val:        "'",
 											ignoreCase: false,
 										},
 									},
 								},
 								&seqExpr{
-									pos: position{line: 36, col: 74, offset: 919},
+									pos: position{line: 38, col: 74, offset: 959},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 36, col: 74, offset: 919},
+											pos:        position{line: 38, col: 74, offset: 959},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 36, col: 78, offset: 923},
+											pos: position{line: 38, col: 78, offset: 963},
 											expr: &ruleRefExpr{
-												pos:  position{line: 36, col: 78, offset: 923},
+												pos:  position{line: 38, col: 78, offset: 963},
 												name: "RawStringChar",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 36, col: 93, offset: 938},
+											pos:        position{line: 38, col: 93, offset: 978},
 											val:        "`",
 											ignoreCase: false,
 										},
@@ -260,35 +277,35 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 42, col: 5, offset: 1084},
+						pos: position{line: 44, col: 5, offset: 1124},
 						run: (*parser).callonStringLiteral18,
 						expr: &choiceExpr{
-							pos: position{line: 42, col: 7, offset: 1086},
+							pos: position{line: 44, col: 7, offset: 1126},
 							alternatives: []interface{}{
 								&seqExpr{
-									pos: position{line: 42, col: 9, offset: 1088},
+									pos: position{line: 44, col: 9, offset: 1128},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 42, col: 9, offset: 1088},
+											pos:        position{line: 44, col: 9, offset: 1128},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 42, col: 13, offset: 1092},
+											pos: position{line: 44, col: 13, offset: 1132},
 											expr: &ruleRefExpr{
-												pos:  position{line: 42, col: 13, offset: 1092},
+												pos:  position{line: 44, col: 13, offset: 1132},
 												name: "DoubleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 42, col: 33, offset: 1112},
+											pos: position{line: 44, col: 33, offset: 1152},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 42, col: 33, offset: 1112},
+													pos:  position{line: 44, col: 33, offset: 1152},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 42, col: 39, offset: 1118},
+													pos:  position{line: 44, col: 39, offset: 1158},
 													name: "EOF",
 												},
 											},
@@ -296,29 +313,29 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 42, col: 51, offset: 1130},
+									pos: position{line: 44, col: 51, offset: 1170},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 42, col: 51, offset: 1130},
+											pos:        position{line: 44, col: 51, offset: 1170},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 42, col: 55, offset: 1134},
+											pos: position{line: 44, col: 55, offset: 1174},
 											expr: &ruleRefExpr{
-												pos:  position{line: 42, col: 55, offset: 1134},
+												pos:  position{line: 44, col: 55, offset: 1174},
 												name: "SingleStringChar",
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 42, col: 75, offset: 1154},
+											pos: position{line: 44, col: 75, offset: 1194},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 42, col: 75, offset: 1154},
+													pos:  position{line: 44, col: 75, offset: 1194},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 42, col: 81, offset: 1160},
+													pos:  position{line: 44, col: 81, offset: 1200},
 													name: "EOF",
 												},
 											},
@@ -326,22 +343,22 @@ var g = &grammar{
 									},
 								},
 								&seqExpr{
-									pos: position{line: 42, col: 91, offset: 1170},
+									pos: position{line: 44, col: 91, offset: 1210},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 42, col: 91, offset: 1170},
+											pos:        position{line: 44, col: 91, offset: 1210},
 											val:        "`",
 											ignoreCase: false,
 										},
 										&zeroOrMoreExpr{
-											pos: position{line: 42, col: 95, offset: 1174},
+											pos: position{line: 44, col: 95, offset: 1214},
 											expr: &ruleRefExpr{
-												pos:  position{line: 42, col: 95, offset: 1174},
+												pos:  position{line: 44, col: 95, offset: 1214},
 												name: "RawStringChar",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 42, col: 110, offset: 1189},
+											pos:  position{line: 44, col: 110, offset: 1229},
 											name: "EOF",
 										},
 									},
@@ -354,51 +371,51 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringChar",
-			pos:  position{line: 46, col: 1, offset: 1260},
+			pos:  position{line: 48, col: 1, offset: 1300},
 			expr: &choiceExpr{
-				pos: position{line: 46, col: 20, offset: 1279},
+				pos: position{line: 48, col: 20, offset: 1319},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 46, col: 20, offset: 1279},
+						pos: position{line: 48, col: 20, offset: 1319},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 46, col: 20, offset: 1279},
+								pos: position{line: 48, col: 20, offset: 1319},
 								expr: &choiceExpr{
-									pos: position{line: 46, col: 23, offset: 1282},
+									pos: position{line: 48, col: 23, offset: 1322},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 46, col: 23, offset: 1282},
+											pos:        position{line: 48, col: 23, offset: 1322},
 											val:        "\"",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 46, col: 29, offset: 1288},
+											pos:        position{line: 48, col: 29, offset: 1328},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 46, col: 36, offset: 1295},
+											pos:  position{line: 48, col: 36, offset: 1335},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 42, offset: 1301},
+								pos:  position{line: 48, col: 42, offset: 1341},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 46, col: 55, offset: 1314},
+						pos: position{line: 48, col: 55, offset: 1354},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 46, col: 55, offset: 1314},
+								pos:        position{line: 48, col: 55, offset: 1354},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 46, col: 60, offset: 1319},
+								pos:  position{line: 48, col: 60, offset: 1359},
 								name: "DoubleStringEscape",
 							},
 						},
@@ -408,51 +425,51 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringChar",
-			pos:  position{line: 47, col: 1, offset: 1338},
+			pos:  position{line: 49, col: 1, offset: 1378},
 			expr: &choiceExpr{
-				pos: position{line: 47, col: 20, offset: 1357},
+				pos: position{line: 49, col: 20, offset: 1397},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 47, col: 20, offset: 1357},
+						pos: position{line: 49, col: 20, offset: 1397},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 47, col: 20, offset: 1357},
+								pos: position{line: 49, col: 20, offset: 1397},
 								expr: &choiceExpr{
-									pos: position{line: 47, col: 23, offset: 1360},
+									pos: position{line: 49, col: 23, offset: 1400},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 47, col: 23, offset: 1360},
+											pos:        position{line: 49, col: 23, offset: 1400},
 											val:        "'",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 47, col: 29, offset: 1366},
+											pos:        position{line: 49, col: 29, offset: 1406},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 47, col: 36, offset: 1373},
+											pos:  position{line: 49, col: 36, offset: 1413},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 47, col: 42, offset: 1379},
+								pos:  position{line: 49, col: 42, offset: 1419},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 47, col: 55, offset: 1392},
+						pos: position{line: 49, col: 55, offset: 1432},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 47, col: 55, offset: 1392},
+								pos:        position{line: 49, col: 55, offset: 1432},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 47, col: 60, offset: 1397},
+								pos:  position{line: 49, col: 60, offset: 1437},
 								name: "SingleStringEscape",
 							},
 						},
@@ -462,20 +479,20 @@ var g = &grammar{
 		},
 		{
 			name: "RawStringChar",
-			pos:  position{line: 48, col: 1, offset: 1416},
+			pos:  position{line: 50, col: 1, offset: 1456},
 			expr: &seqExpr{
-				pos: position{line: 48, col: 17, offset: 1432},
+				pos: position{line: 50, col: 17, offset: 1472},
 				exprs: []interface{}{
 					&notExpr{
-						pos: position{line: 48, col: 17, offset: 1432},
+						pos: position{line: 50, col: 17, offset: 1472},
 						expr: &litMatcher{
-							pos:        position{line: 48, col: 18, offset: 1433},
+							pos:        position{line: 50, col: 18, offset: 1473},
 							val:        "`",
 							ignoreCase: false,
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 48, col: 22, offset: 1437},
+						pos:  position{line: 50, col: 22, offset: 1477},
 						name: "SourceChar",
 					},
 				},
@@ -483,40 +500,40 @@ var g = &grammar{
 		},
 		{
 			name: "DoubleStringEscape",
-			pos:  position{line: 50, col: 1, offset: 1449},
+			pos:  position{line: 52, col: 1, offset: 1489},
 			expr: &choiceExpr{
-				pos: position{line: 50, col: 22, offset: 1470},
+				pos: position{line: 52, col: 22, offset: 1510},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 50, col: 24, offset: 1472},
+						pos: position{line: 52, col: 24, offset: 1512},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 50, col: 24, offset: 1472},
+								pos:        position{line: 52, col: 24, offset: 1512},
 								val:        "\"",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 50, col: 30, offset: 1478},
+								pos:  position{line: 52, col: 30, offset: 1518},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 51, col: 7, offset: 1507},
+						pos: position{line: 53, col: 7, offset: 1547},
 						run: (*parser).callonDoubleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 51, col: 9, offset: 1509},
+							pos: position{line: 53, col: 9, offset: 1549},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 51, col: 9, offset: 1509},
+									pos:  position{line: 53, col: 9, offset: 1549},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 51, col: 22, offset: 1522},
+									pos:  position{line: 53, col: 22, offset: 1562},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 51, col: 28, offset: 1528},
+									pos:  position{line: 53, col: 28, offset: 1568},
 									name: "EOF",
 								},
 							},
@@ -527,40 +544,40 @@ var g = &grammar{
 		},
 		{
 			name: "SingleStringEscape",
-			pos:  position{line: 54, col: 1, offset: 1593},
+			pos:  position{line: 56, col: 1, offset: 1633},
 			expr: &choiceExpr{
-				pos: position{line: 54, col: 22, offset: 1614},
+				pos: position{line: 56, col: 22, offset: 1654},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 54, col: 24, offset: 1616},
+						pos: position{line: 56, col: 24, offset: 1656},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 54, col: 24, offset: 1616},
+								pos:        position{line: 56, col: 24, offset: 1656},
 								val:        "'",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 54, col: 30, offset: 1622},
+								pos:  position{line: 56, col: 30, offset: 1662},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 55, col: 7, offset: 1651},
+						pos: position{line: 57, col: 7, offset: 1691},
 						run: (*parser).callonSingleStringEscape5,
 						expr: &choiceExpr{
-							pos: position{line: 55, col: 9, offset: 1653},
+							pos: position{line: 57, col: 9, offset: 1693},
 							alternatives: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 55, col: 9, offset: 1653},
+									pos:  position{line: 57, col: 9, offset: 1693},
 									name: "SourceChar",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 55, col: 22, offset: 1666},
+									pos:  position{line: 57, col: 22, offset: 1706},
 									name: "EOL",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 55, col: 28, offset: 1672},
+									pos:  position{line: 57, col: 28, offset: 1712},
 									name: "EOF",
 								},
 							},
@@ -571,28 +588,28 @@ var g = &grammar{
 		},
 		{
 			name: "CommonEscapeSequence",
-			pos:  position{line: 59, col: 1, offset: 1738},
+			pos:  position{line: 61, col: 1, offset: 1778},
 			expr: &choiceExpr{
-				pos: position{line: 59, col: 24, offset: 1761},
+				pos: position{line: 61, col: 24, offset: 1801},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 59, col: 24, offset: 1761},
+						pos:  position{line: 61, col: 24, offset: 1801},
 						name: "SingleCharEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 59, col: 43, offset: 1780},
+						pos:  position{line: 61, col: 43, offset: 1820},
 						name: "OctalEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 59, col: 57, offset: 1794},
+						pos:  position{line: 61, col: 57, offset: 1834},
 						name: "HexEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 59, col: 69, offset: 1806},
+						pos:  position{line: 61, col: 69, offset: 1846},
 						name: "LongUnicodeEscape",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 59, col: 89, offset: 1826},
+						pos:  position{line: 61, col: 89, offset: 1866},
 						name: "ShortUnicodeEscape",
 					},
 				},
@@ -600,47 +617,47 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharEscape",
-			pos:  position{line: 60, col: 1, offset: 1845},
+			pos:  position{line: 62, col: 1, offset: 1885},
 			expr: &choiceExpr{
-				pos: position{line: 60, col: 20, offset: 1864},
+				pos: position{line: 62, col: 20, offset: 1904},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 60, col: 20, offset: 1864},
+						pos:        position{line: 62, col: 20, offset: 1904},
 						val:        "a",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 26, offset: 1870},
+						pos:        position{line: 62, col: 26, offset: 1910},
 						val:        "b",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 32, offset: 1876},
+						pos:        position{line: 62, col: 32, offset: 1916},
 						val:        "n",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 38, offset: 1882},
+						pos:        position{line: 62, col: 38, offset: 1922},
 						val:        "f",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 44, offset: 1888},
+						pos:        position{line: 62, col: 44, offset: 1928},
 						val:        "r",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 50, offset: 1894},
+						pos:        position{line: 62, col: 50, offset: 1934},
 						val:        "t",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 56, offset: 1900},
+						pos:        position{line: 62, col: 56, offset: 1940},
 						val:        "v",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 60, col: 62, offset: 1906},
+						pos:        position{line: 62, col: 62, offset: 1946},
 						val:        "\\",
 						ignoreCase: false,
 					},
@@ -649,50 +666,50 @@ var g = &grammar{
 		},
 		{
 			name: "OctalEscape",
-			pos:  position{line: 61, col: 1, offset: 1911},
+			pos:  position{line: 63, col: 1, offset: 1951},
 			expr: &choiceExpr{
-				pos: position{line: 61, col: 15, offset: 1925},
+				pos: position{line: 63, col: 15, offset: 1965},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 61, col: 15, offset: 1925},
+						pos: position{line: 63, col: 15, offset: 1965},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 61, col: 15, offset: 1925},
+								pos:  position{line: 63, col: 15, offset: 1965},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 61, col: 26, offset: 1936},
+								pos:  position{line: 63, col: 26, offset: 1976},
 								name: "OctalDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 61, col: 37, offset: 1947},
+								pos:  position{line: 63, col: 37, offset: 1987},
 								name: "OctalDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 62, col: 7, offset: 1964},
+						pos: position{line: 64, col: 7, offset: 2004},
 						run: (*parser).callonOctalEscape6,
 						expr: &seqExpr{
-							pos: position{line: 62, col: 7, offset: 1964},
+							pos: position{line: 64, col: 7, offset: 2004},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 62, col: 7, offset: 1964},
+									pos:  position{line: 64, col: 7, offset: 2004},
 									name: "OctalDigit",
 								},
 								&choiceExpr{
-									pos: position{line: 62, col: 20, offset: 1977},
+									pos: position{line: 64, col: 20, offset: 2017},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 62, col: 20, offset: 1977},
+											pos:  position{line: 64, col: 20, offset: 2017},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 62, col: 33, offset: 1990},
+											pos:  position{line: 64, col: 33, offset: 2030},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 62, col: 39, offset: 1996},
+											pos:  position{line: 64, col: 39, offset: 2036},
 											name: "EOF",
 										},
 									},
@@ -705,52 +722,52 @@ var g = &grammar{
 		},
 		{
 			name: "HexEscape",
-			pos:  position{line: 65, col: 1, offset: 2057},
+			pos:  position{line: 67, col: 1, offset: 2097},
 			expr: &choiceExpr{
-				pos: position{line: 65, col: 13, offset: 2069},
+				pos: position{line: 67, col: 13, offset: 2109},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 65, col: 13, offset: 2069},
+						pos: position{line: 67, col: 13, offset: 2109},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 65, col: 13, offset: 2069},
+								pos:        position{line: 67, col: 13, offset: 2109},
 								val:        "x",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 65, col: 17, offset: 2073},
+								pos:  position{line: 67, col: 17, offset: 2113},
 								name: "HexDigit",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 65, col: 26, offset: 2082},
+								pos:  position{line: 67, col: 26, offset: 2122},
 								name: "HexDigit",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 66, col: 7, offset: 2097},
+						pos: position{line: 68, col: 7, offset: 2137},
 						run: (*parser).callonHexEscape6,
 						expr: &seqExpr{
-							pos: position{line: 66, col: 7, offset: 2097},
+							pos: position{line: 68, col: 7, offset: 2137},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 66, col: 7, offset: 2097},
+									pos:        position{line: 68, col: 7, offset: 2137},
 									val:        "x",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 66, col: 13, offset: 2103},
+									pos: position{line: 68, col: 13, offset: 2143},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 66, col: 13, offset: 2103},
+											pos:  position{line: 68, col: 13, offset: 2143},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 66, col: 26, offset: 2116},
+											pos:  position{line: 68, col: 26, offset: 2156},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 66, col: 32, offset: 2122},
+											pos:  position{line: 68, col: 32, offset: 2162},
 											name: "EOF",
 										},
 									},
@@ -763,80 +780,80 @@ var g = &grammar{
 		},
 		{
 			name: "LongUnicodeEscape",
-			pos:  position{line: 69, col: 1, offset: 2189},
+			pos:  position{line: 71, col: 1, offset: 2229},
 			expr: &choiceExpr{
-				pos: position{line: 70, col: 5, offset: 2214},
+				pos: position{line: 72, col: 5, offset: 2254},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 70, col: 5, offset: 2214},
+						pos: position{line: 72, col: 5, offset: 2254},
 						run: (*parser).callonLongUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 70, col: 5, offset: 2214},
+							pos: position{line: 72, col: 5, offset: 2254},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 70, col: 5, offset: 2214},
+									pos:        position{line: 72, col: 5, offset: 2254},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 9, offset: 2218},
+									pos:  position{line: 72, col: 9, offset: 2258},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 18, offset: 2227},
+									pos:  position{line: 72, col: 18, offset: 2267},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 27, offset: 2236},
+									pos:  position{line: 72, col: 27, offset: 2276},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 36, offset: 2245},
+									pos:  position{line: 72, col: 36, offset: 2285},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 45, offset: 2254},
+									pos:  position{line: 72, col: 45, offset: 2294},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 54, offset: 2263},
+									pos:  position{line: 72, col: 54, offset: 2303},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 63, offset: 2272},
+									pos:  position{line: 72, col: 63, offset: 2312},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 70, col: 72, offset: 2281},
+									pos:  position{line: 72, col: 72, offset: 2321},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 73, col: 7, offset: 2383},
+						pos: position{line: 75, col: 7, offset: 2423},
 						run: (*parser).callonLongUnicodeEscape13,
 						expr: &seqExpr{
-							pos: position{line: 73, col: 7, offset: 2383},
+							pos: position{line: 75, col: 7, offset: 2423},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 73, col: 7, offset: 2383},
+									pos:        position{line: 75, col: 7, offset: 2423},
 									val:        "U",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 73, col: 13, offset: 2389},
+									pos: position{line: 75, col: 13, offset: 2429},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 73, col: 13, offset: 2389},
+											pos:  position{line: 75, col: 13, offset: 2429},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 73, col: 26, offset: 2402},
+											pos:  position{line: 75, col: 26, offset: 2442},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 73, col: 32, offset: 2408},
+											pos:  position{line: 75, col: 32, offset: 2448},
 											name: "EOF",
 										},
 									},
@@ -849,64 +866,64 @@ var g = &grammar{
 		},
 		{
 			name: "ShortUnicodeEscape",
-			pos:  position{line: 76, col: 1, offset: 2471},
+			pos:  position{line: 78, col: 1, offset: 2511},
 			expr: &choiceExpr{
-				pos: position{line: 77, col: 5, offset: 2497},
+				pos: position{line: 79, col: 5, offset: 2537},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 77, col: 5, offset: 2497},
+						pos: position{line: 79, col: 5, offset: 2537},
 						run: (*parser).callonShortUnicodeEscape2,
 						expr: &seqExpr{
-							pos: position{line: 77, col: 5, offset: 2497},
+							pos: position{line: 79, col: 5, offset: 2537},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 77, col: 5, offset: 2497},
+									pos:        position{line: 79, col: 5, offset: 2537},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 77, col: 9, offset: 2501},
+									pos:  position{line: 79, col: 9, offset: 2541},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 77, col: 18, offset: 2510},
+									pos:  position{line: 79, col: 18, offset: 2550},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 77, col: 27, offset: 2519},
+									pos:  position{line: 79, col: 27, offset: 2559},
 									name: "HexDigit",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 77, col: 36, offset: 2528},
+									pos:  position{line: 79, col: 36, offset: 2568},
 									name: "HexDigit",
 								},
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 80, col: 7, offset: 2630},
+						pos: position{line: 82, col: 7, offset: 2670},
 						run: (*parser).callonShortUnicodeEscape9,
 						expr: &seqExpr{
-							pos: position{line: 80, col: 7, offset: 2630},
+							pos: position{line: 82, col: 7, offset: 2670},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 80, col: 7, offset: 2630},
+									pos:        position{line: 82, col: 7, offset: 2670},
 									val:        "u",
 									ignoreCase: false,
 								},
 								&choiceExpr{
-									pos: position{line: 80, col: 13, offset: 2636},
+									pos: position{line: 82, col: 13, offset: 2676},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 80, col: 13, offset: 2636},
+											pos:  position{line: 82, col: 13, offset: 2676},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 80, col: 26, offset: 2649},
+											pos:  position{line: 82, col: 26, offset: 2689},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 80, col: 32, offset: 2655},
+											pos:  position{line: 82, col: 32, offset: 2695},
 											name: "EOF",
 										},
 									},
@@ -919,9 +936,9 @@ var g = &grammar{
 		},
 		{
 			name: "OctalDigit",
-			pos:  position{line: 84, col: 1, offset: 2719},
+			pos:  position{line: 86, col: 1, offset: 2759},
 			expr: &charClassMatcher{
-				pos:        position{line: 84, col: 14, offset: 2732},
+				pos:        position{line: 86, col: 14, offset: 2772},
 				val:        "[0-7]",
 				ranges:     []rune{'0', '7'},
 				ignoreCase: false,
@@ -930,9 +947,9 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigit",
-			pos:  position{line: 85, col: 1, offset: 2738},
+			pos:  position{line: 87, col: 1, offset: 2778},
 			expr: &charClassMatcher{
-				pos:        position{line: 85, col: 16, offset: 2753},
+				pos:        position{line: 87, col: 16, offset: 2793},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -941,9 +958,9 @@ var g = &grammar{
 		},
 		{
 			name: "HexDigit",
-			pos:  position{line: 86, col: 1, offset: 2759},
+			pos:  position{line: 88, col: 1, offset: 2799},
 			expr: &charClassMatcher{
-				pos:        position{line: 86, col: 12, offset: 2770},
+				pos:        position{line: 88, col: 12, offset: 2810},
 				val:        "[0-9a-f]i",
 				ranges:     []rune{'0', '9', 'a', 'f'},
 				ignoreCase: true,
@@ -952,44 +969,44 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassMatcher",
-			pos:  position{line: 88, col: 1, offset: 2781},
+			pos:  position{line: 90, col: 1, offset: 2821},
 			expr: &choiceExpr{
-				pos: position{line: 88, col: 20, offset: 2800},
+				pos: position{line: 90, col: 20, offset: 2840},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 88, col: 20, offset: 2800},
+						pos: position{line: 90, col: 20, offset: 2840},
 						run: (*parser).callonCharClassMatcher2,
 						expr: &seqExpr{
-							pos: position{line: 88, col: 20, offset: 2800},
+							pos: position{line: 90, col: 20, offset: 2840},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 88, col: 20, offset: 2800},
+									pos:        position{line: 90, col: 20, offset: 2840},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 88, col: 24, offset: 2804},
+									pos: position{line: 90, col: 24, offset: 2844},
 									expr: &choiceExpr{
-										pos: position{line: 88, col: 26, offset: 2806},
+										pos: position{line: 90, col: 26, offset: 2846},
 										alternatives: []interface{}{
 											&ruleRefExpr{
-												pos:  position{line: 88, col: 26, offset: 2806},
+												pos:  position{line: 90, col: 26, offset: 2846},
 												name: "ClassCharRange",
 											},
 											&ruleRefExpr{
-												pos:  position{line: 88, col: 43, offset: 2823},
+												pos:  position{line: 90, col: 43, offset: 2863},
 												name: "ClassChar",
 											},
 											&seqExpr{
-												pos: position{line: 88, col: 55, offset: 2835},
+												pos: position{line: 90, col: 55, offset: 2875},
 												exprs: []interface{}{
 													&litMatcher{
-														pos:        position{line: 88, col: 55, offset: 2835},
+														pos:        position{line: 90, col: 55, offset: 2875},
 														val:        "\\",
 														ignoreCase: false,
 													},
 													&ruleRefExpr{
-														pos:  position{line: 88, col: 60, offset: 2840},
+														pos:  position{line: 90, col: 60, offset: 2880},
 														name: "UnicodeClassEscape",
 													},
 												},
@@ -998,14 +1015,14 @@ var g = &grammar{
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 88, col: 82, offset: 2862},
+									pos:        position{line: 90, col: 82, offset: 2902},
 									val:        "]",
 									ignoreCase: false,
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 88, col: 86, offset: 2866},
+									pos: position{line: 90, col: 86, offset: 2906},
 									expr: &litMatcher{
-										pos:        position{line: 88, col: 86, offset: 2866},
+										pos:        position{line: 90, col: 86, offset: 2906},
 										val:        "i",
 										ignoreCase: false,
 									},
@@ -1014,44 +1031,44 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 90, col: 5, offset: 2908},
+						pos: position{line: 92, col: 5, offset: 2948},
 						run: (*parser).callonCharClassMatcher15,
 						expr: &seqExpr{
-							pos: position{line: 90, col: 5, offset: 2908},
+							pos: position{line: 92, col: 5, offset: 2948},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 90, col: 5, offset: 2908},
+									pos:        position{line: 92, col: 5, offset: 2948},
 									val:        "[",
 									ignoreCase: false,
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 90, col: 9, offset: 2912},
+									pos: position{line: 92, col: 9, offset: 2952},
 									expr: &seqExpr{
-										pos: position{line: 90, col: 11, offset: 2914},
+										pos: position{line: 92, col: 11, offset: 2954},
 										exprs: []interface{}{
 											&notExpr{
-												pos: position{line: 90, col: 11, offset: 2914},
+												pos: position{line: 92, col: 11, offset: 2954},
 												expr: &ruleRefExpr{
-													pos:  position{line: 90, col: 14, offset: 2917},
+													pos:  position{line: 92, col: 14, offset: 2957},
 													name: "EOL",
 												},
 											},
 											&ruleRefExpr{
-												pos:  position{line: 90, col: 20, offset: 2923},
+												pos:  position{line: 92, col: 20, offset: 2963},
 												name: "SourceChar",
 											},
 										},
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 90, col: 36, offset: 2939},
+									pos: position{line: 92, col: 36, offset: 2979},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 90, col: 36, offset: 2939},
+											pos:  position{line: 92, col: 36, offset: 2979},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 90, col: 42, offset: 2945},
+											pos:  position{line: 92, col: 42, offset: 2985},
 											name: "EOF",
 										},
 									},
@@ -1064,21 +1081,21 @@ var g = &grammar{
 		},
 		{
 			name: "ClassCharRange",
-			pos:  position{line: 94, col: 1, offset: 3017},
+			pos:  position{line: 96, col: 1, offset: 3057},
 			expr: &seqExpr{
-				pos: position{line: 94, col: 18, offset: 3034},
+				pos: position{line: 96, col: 18, offset: 3074},
 				exprs: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 94, col: 18, offset: 3034},
+						pos:  position{line: 96, col: 18, offset: 3074},
 						name: "ClassChar",
 					},
 					&litMatcher{
-						pos:        position{line: 94, col: 28, offset: 3044},
+						pos:        position{line: 96, col: 28, offset: 3084},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&ruleRefExpr{
-						pos:  position{line: 94, col: 32, offset: 3048},
+						pos:  position{line: 96, col: 32, offset: 3088},
 						name: "ClassChar",
 					},
 				},
@@ -1086,51 +1103,51 @@ var g = &grammar{
 		},
 		{
 			name: "ClassChar",
-			pos:  position{line: 95, col: 1, offset: 3058},
+			pos:  position{line: 97, col: 1, offset: 3098},
 			expr: &choiceExpr{
-				pos: position{line: 95, col: 13, offset: 3070},
+				pos: position{line: 97, col: 13, offset: 3110},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 95, col: 13, offset: 3070},
+						pos: position{line: 97, col: 13, offset: 3110},
 						exprs: []interface{}{
 							&notExpr{
-								pos: position{line: 95, col: 13, offset: 3070},
+								pos: position{line: 97, col: 13, offset: 3110},
 								expr: &choiceExpr{
-									pos: position{line: 95, col: 16, offset: 3073},
+									pos: position{line: 97, col: 16, offset: 3113},
 									alternatives: []interface{}{
 										&litMatcher{
-											pos:        position{line: 95, col: 16, offset: 3073},
+											pos:        position{line: 97, col: 16, offset: 3113},
 											val:        "]",
 											ignoreCase: false,
 										},
 										&litMatcher{
-											pos:        position{line: 95, col: 22, offset: 3079},
+											pos:        position{line: 97, col: 22, offset: 3119},
 											val:        "\\",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 95, col: 29, offset: 3086},
+											pos:  position{line: 97, col: 29, offset: 3126},
 											name: "EOL",
 										},
 									},
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 95, col: 35, offset: 3092},
+								pos:  position{line: 97, col: 35, offset: 3132},
 								name: "SourceChar",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 95, col: 48, offset: 3105},
+						pos: position{line: 97, col: 48, offset: 3145},
 						exprs: []interface{}{
 							&litMatcher{
-								pos:        position{line: 95, col: 48, offset: 3105},
+								pos:        position{line: 97, col: 48, offset: 3145},
 								val:        "\\",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 95, col: 53, offset: 3110},
+								pos:  position{line: 97, col: 53, offset: 3150},
 								name: "CharClassEscape",
 							},
 						},
@@ -1140,51 +1157,51 @@ var g = &grammar{
 		},
 		{
 			name: "CharClassEscape",
-			pos:  position{line: 96, col: 1, offset: 3126},
+			pos:  position{line: 98, col: 1, offset: 3166},
 			expr: &choiceExpr{
-				pos: position{line: 96, col: 19, offset: 3144},
+				pos: position{line: 98, col: 19, offset: 3184},
 				alternatives: []interface{}{
 					&choiceExpr{
-						pos: position{line: 96, col: 21, offset: 3146},
+						pos: position{line: 98, col: 21, offset: 3186},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 96, col: 21, offset: 3146},
+								pos:        position{line: 98, col: 21, offset: 3186},
 								val:        "]",
 								ignoreCase: false,
 							},
 							&ruleRefExpr{
-								pos:  position{line: 96, col: 27, offset: 3152},
+								pos:  position{line: 98, col: 27, offset: 3192},
 								name: "CommonEscapeSequence",
 							},
 						},
 					},
 					&actionExpr{
-						pos: position{line: 97, col: 7, offset: 3181},
+						pos: position{line: 99, col: 7, offset: 3221},
 						run: (*parser).callonCharClassEscape5,
 						expr: &seqExpr{
-							pos: position{line: 97, col: 7, offset: 3181},
+							pos: position{line: 99, col: 7, offset: 3221},
 							exprs: []interface{}{
 								&notExpr{
-									pos: position{line: 97, col: 7, offset: 3181},
+									pos: position{line: 99, col: 7, offset: 3221},
 									expr: &litMatcher{
-										pos:        position{line: 97, col: 8, offset: 3182},
+										pos:        position{line: 99, col: 8, offset: 3222},
 										val:        "p",
 										ignoreCase: false,
 									},
 								},
 								&choiceExpr{
-									pos: position{line: 97, col: 14, offset: 3188},
+									pos: position{line: 99, col: 14, offset: 3228},
 									alternatives: []interface{}{
 										&ruleRefExpr{
-											pos:  position{line: 97, col: 14, offset: 3188},
+											pos:  position{line: 99, col: 14, offset: 3228},
 											name: "SourceChar",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 97, col: 27, offset: 3201},
+											pos:  position{line: 99, col: 27, offset: 3241},
 											name: "EOL",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 97, col: 33, offset: 3207},
+											pos:  position{line: 99, col: 33, offset: 3247},
 											name: "EOF",
 										},
 									},
@@ -1197,49 +1214,49 @@ var g = &grammar{
 		},
 		{
 			name: "UnicodeClassEscape",
-			pos:  position{line: 101, col: 1, offset: 3273},
+			pos:  position{line: 103, col: 1, offset: 3313},
 			expr: &seqExpr{
-				pos: position{line: 101, col: 22, offset: 3294},
+				pos: position{line: 103, col: 22, offset: 3334},
 				exprs: []interface{}{
 					&litMatcher{
-						pos:        position{line: 101, col: 22, offset: 3294},
+						pos:        position{line: 103, col: 22, offset: 3334},
 						val:        "p",
 						ignoreCase: false,
 					},
 					&choiceExpr{
-						pos: position{line: 102, col: 7, offset: 3307},
+						pos: position{line: 104, col: 7, offset: 3347},
 						alternatives: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 102, col: 7, offset: 3307},
+								pos:  position{line: 104, col: 7, offset: 3347},
 								name: "SingleCharUnicodeClass",
 							},
 							&actionExpr{
-								pos: position{line: 103, col: 7, offset: 3336},
+								pos: position{line: 105, col: 7, offset: 3376},
 								run: (*parser).callonUnicodeClassEscape5,
 								expr: &seqExpr{
-									pos: position{line: 103, col: 7, offset: 3336},
+									pos: position{line: 105, col: 7, offset: 3376},
 									exprs: []interface{}{
 										&notExpr{
-											pos: position{line: 103, col: 7, offset: 3336},
+											pos: position{line: 105, col: 7, offset: 3376},
 											expr: &litMatcher{
-												pos:        position{line: 103, col: 8, offset: 3337},
+												pos:        position{line: 105, col: 8, offset: 3377},
 												val:        "{",
 												ignoreCase: false,
 											},
 										},
 										&choiceExpr{
-											pos: position{line: 103, col: 14, offset: 3343},
+											pos: position{line: 105, col: 14, offset: 3383},
 											alternatives: []interface{}{
 												&ruleRefExpr{
-													pos:  position{line: 103, col: 14, offset: 3343},
+													pos:  position{line: 105, col: 14, offset: 3383},
 													name: "SourceChar",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 103, col: 27, offset: 3356},
+													pos:  position{line: 105, col: 27, offset: 3396},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 103, col: 33, offset: 3362},
+													pos:  position{line: 105, col: 33, offset: 3402},
 													name: "EOF",
 												},
 											},
@@ -1248,26 +1265,26 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 104, col: 7, offset: 3433},
+								pos: position{line: 106, col: 7, offset: 3473},
 								run: (*parser).callonUnicodeClassEscape13,
 								expr: &seqExpr{
-									pos: position{line: 104, col: 7, offset: 3433},
+									pos: position{line: 106, col: 7, offset: 3473},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 104, col: 7, offset: 3433},
+											pos:        position{line: 106, col: 7, offset: 3473},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&labeledExpr{
-											pos:   position{line: 104, col: 11, offset: 3437},
+											pos:   position{line: 106, col: 11, offset: 3477},
 											label: "ident",
 											expr: &ruleRefExpr{
-												pos:  position{line: 104, col: 17, offset: 3443},
+												pos:  position{line: 106, col: 17, offset: 3483},
 												name: "IdentifierName",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 104, col: 32, offset: 3458},
+											pos:        position{line: 106, col: 32, offset: 3498},
 											val:        "}",
 											ignoreCase: false,
 										},
@@ -1275,34 +1292,34 @@ var g = &grammar{
 								},
 							},
 							&actionExpr{
-								pos: position{line: 110, col: 7, offset: 3622},
+								pos: position{line: 112, col: 7, offset: 3662},
 								run: (*parser).callonUnicodeClassEscape19,
 								expr: &seqExpr{
-									pos: position{line: 110, col: 7, offset: 3622},
+									pos: position{line: 112, col: 7, offset: 3662},
 									exprs: []interface{}{
 										&litMatcher{
-											pos:        position{line: 110, col: 7, offset: 3622},
+											pos:        position{line: 112, col: 7, offset: 3662},
 											val:        "{",
 											ignoreCase: false,
 										},
 										&ruleRefExpr{
-											pos:  position{line: 110, col: 11, offset: 3626},
+											pos:  position{line: 112, col: 11, offset: 3666},
 											name: "IdentifierName",
 										},
 										&choiceExpr{
-											pos: position{line: 110, col: 28, offset: 3643},
+											pos: position{line: 112, col: 28, offset: 3683},
 											alternatives: []interface{}{
 												&litMatcher{
-													pos:        position{line: 110, col: 28, offset: 3643},
+													pos:        position{line: 112, col: 28, offset: 3683},
 													val:        "]",
 													ignoreCase: false,
 												},
 												&ruleRefExpr{
-													pos:  position{line: 110, col: 34, offset: 3649},
+													pos:  position{line: 112, col: 34, offset: 3689},
 													name: "EOL",
 												},
 												&ruleRefExpr{
-													pos:  position{line: 110, col: 40, offset: 3655},
+													pos:  position{line: 112, col: 40, offset: 3695},
 													name: "EOF",
 												},
 											},
@@ -1317,9 +1334,9 @@ var g = &grammar{
 		},
 		{
 			name: "SingleCharUnicodeClass",
-			pos:  position{line: 115, col: 1, offset: 3735},
+			pos:  position{line: 117, col: 1, offset: 3775},
 			expr: &charClassMatcher{
-				pos:        position{line: 115, col: 26, offset: 3760},
+				pos:        position{line: 117, col: 26, offset: 3800},
 				val:        "[LMNCPZS]",
 				chars:      []rune{'L', 'M', 'N', 'C', 'P', 'Z', 'S'},
 				ignoreCase: false,
@@ -1328,39 +1345,39 @@ var g = &grammar{
 		},
 		{
 			name: "Number",
-			pos:  position{line: 118, col: 1, offset: 3772},
+			pos:  position{line: 120, col: 1, offset: 3812},
 			expr: &actionExpr{
-				pos: position{line: 118, col: 10, offset: 3781},
+				pos: position{line: 120, col: 10, offset: 3821},
 				run: (*parser).callonNumber1,
 				expr: &seqExpr{
-					pos: position{line: 118, col: 10, offset: 3781},
+					pos: position{line: 120, col: 10, offset: 3821},
 					exprs: []interface{}{
 						&zeroOrOneExpr{
-							pos: position{line: 118, col: 10, offset: 3781},
+							pos: position{line: 120, col: 10, offset: 3821},
 							expr: &litMatcher{
-								pos:        position{line: 118, col: 10, offset: 3781},
+								pos:        position{line: 120, col: 10, offset: 3821},
 								val:        "-",
 								ignoreCase: false,
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 118, col: 15, offset: 3786},
+							pos:  position{line: 120, col: 15, offset: 3826},
 							name: "Integer",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 118, col: 23, offset: 3794},
+							pos: position{line: 120, col: 23, offset: 3834},
 							expr: &seqExpr{
-								pos: position{line: 118, col: 25, offset: 3796},
+								pos: position{line: 120, col: 25, offset: 3836},
 								exprs: []interface{}{
 									&litMatcher{
-										pos:        position{line: 118, col: 25, offset: 3796},
+										pos:        position{line: 120, col: 25, offset: 3836},
 										val:        ".",
 										ignoreCase: false,
 									},
 									&oneOrMoreExpr{
-										pos: position{line: 118, col: 29, offset: 3800},
+										pos: position{line: 120, col: 29, offset: 3840},
 										expr: &ruleRefExpr{
-											pos:  position{line: 118, col: 29, offset: 3800},
+											pos:  position{line: 120, col: 29, offset: 3840},
 											name: "Digit",
 										},
 									},
@@ -1373,29 +1390,29 @@ var g = &grammar{
 		},
 		{
 			name: "Integer",
-			pos:  position{line: 122, col: 1, offset: 3852},
+			pos:  position{line: 124, col: 1, offset: 3892},
 			expr: &choiceExpr{
-				pos: position{line: 122, col: 11, offset: 3862},
+				pos: position{line: 124, col: 11, offset: 3902},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 122, col: 11, offset: 3862},
+						pos:        position{line: 124, col: 11, offset: 3902},
 						val:        "0",
 						ignoreCase: false,
 					},
 					&actionExpr{
-						pos: position{line: 122, col: 17, offset: 3868},
+						pos: position{line: 124, col: 17, offset: 3908},
 						run: (*parser).callonInteger3,
 						expr: &seqExpr{
-							pos: position{line: 122, col: 17, offset: 3868},
+							pos: position{line: 124, col: 17, offset: 3908},
 							exprs: []interface{}{
 								&ruleRefExpr{
-									pos:  position{line: 122, col: 17, offset: 3868},
+									pos:  position{line: 124, col: 17, offset: 3908},
 									name: "NonZeroDigit",
 								},
 								&zeroOrMoreExpr{
-									pos: position{line: 122, col: 30, offset: 3881},
+									pos: position{line: 124, col: 30, offset: 3921},
 									expr: &ruleRefExpr{
-										pos:  position{line: 122, col: 30, offset: 3881},
+										pos:  position{line: 124, col: 30, offset: 3921},
 										name: "Digit",
 									},
 								},
@@ -1407,9 +1424,9 @@ var g = &grammar{
 		},
 		{
 			name: "NonZeroDigit",
-			pos:  position{line: 126, col: 1, offset: 3945},
+			pos:  position{line: 128, col: 1, offset: 3985},
 			expr: &charClassMatcher{
-				pos:        position{line: 126, col: 16, offset: 3960},
+				pos:        position{line: 128, col: 16, offset: 4000},
 				val:        "[1-9]",
 				ranges:     []rune{'1', '9'},
 				ignoreCase: false,
@@ -1418,9 +1435,9 @@ var g = &grammar{
 		},
 		{
 			name: "Digit",
-			pos:  position{line: 127, col: 1, offset: 3966},
+			pos:  position{line: 129, col: 1, offset: 4006},
 			expr: &charClassMatcher{
-				pos:        position{line: 127, col: 9, offset: 3974},
+				pos:        position{line: 129, col: 9, offset: 4014},
 				val:        "[0-9]",
 				ranges:     []rune{'0', '9'},
 				ignoreCase: false,
@@ -1429,31 +1446,31 @@ var g = &grammar{
 		},
 		{
 			name: "LabelBlock",
-			pos:  position{line: 129, col: 1, offset: 3981},
+			pos:  position{line: 131, col: 1, offset: 4021},
 			expr: &choiceExpr{
-				pos: position{line: 129, col: 14, offset: 3994},
+				pos: position{line: 131, col: 14, offset: 4034},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 129, col: 14, offset: 3994},
+						pos: position{line: 131, col: 14, offset: 4034},
 						run: (*parser).callonLabelBlock2,
 						expr: &seqExpr{
-							pos: position{line: 129, col: 14, offset: 3994},
+							pos: position{line: 131, col: 14, offset: 4034},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 129, col: 14, offset: 3994},
+									pos:        position{line: 131, col: 14, offset: 4034},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&labeledExpr{
-									pos:   position{line: 129, col: 18, offset: 3998},
+									pos:   position{line: 131, col: 18, offset: 4038},
 									label: "block",
 									expr: &ruleRefExpr{
-										pos:  position{line: 129, col: 24, offset: 4004},
+										pos:  position{line: 131, col: 24, offset: 4044},
 										name: "LabelMatches",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 129, col: 37, offset: 4017},
+									pos:        position{line: 131, col: 37, offset: 4057},
 									val:        "}",
 									ignoreCase: false,
 								},
@@ -1461,22 +1478,22 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 131, col: 5, offset: 4049},
+						pos: position{line: 133, col: 5, offset: 4089},
 						run: (*parser).callonLabelBlock8,
 						expr: &seqExpr{
-							pos: position{line: 131, col: 5, offset: 4049},
+							pos: position{line: 133, col: 5, offset: 4089},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 131, col: 5, offset: 4049},
+									pos:        position{line: 133, col: 5, offset: 4089},
 									val:        "{",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 131, col: 9, offset: 4053},
+									pos:  position{line: 133, col: 9, offset: 4093},
 									name: "LabelMatches",
 								},
 								&ruleRefExpr{
-									pos:  position{line: 131, col: 22, offset: 4066},
+									pos:  position{line: 133, col: 22, offset: 4106},
 									name: "EOF",
 								},
 							},
@@ -1487,12 +1504,12 @@ var g = &grammar{
 		},
 		{
 			name: "NanoSecondUnits",
-			pos:  position{line: 135, col: 1, offset: 4131},
+			pos:  position{line: 137, col: 1, offset: 4171},
 			expr: &actionExpr{
-				pos: position{line: 135, col: 19, offset: 4149},
+				pos: position{line: 137, col: 19, offset: 4189},
 				run: (*parser).callonNanoSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 135, col: 19, offset: 4149},
+					pos:        position{line: 137, col: 19, offset: 4189},
 					val:        "ns",
 					ignoreCase: false,
 				},
@@ -1500,25 +1517,25 @@ var g = &grammar{
 		},
 		{
 			name: "MicroSecondUnits",
-			pos:  position{line: 140, col: 1, offset: 4254},
+			pos:  position{line: 142, col: 1, offset: 4294},
 			expr: &actionExpr{
-				pos: position{line: 140, col: 20, offset: 4273},
+				pos: position{line: 142, col: 20, offset: 4313},
 				run: (*parser).callonMicroSecondUnits1,
 				expr: &choiceExpr{
-					pos: position{line: 140, col: 21, offset: 4274},
+					pos: position{line: 142, col: 21, offset: 4314},
 					alternatives: []interface{}{
 						&litMatcher{
-							pos:        position{line: 140, col: 21, offset: 4274},
+							pos:        position{line: 142, col: 21, offset: 4314},
 							val:        "us",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 140, col: 28, offset: 4281},
+							pos:        position{line: 142, col: 28, offset: 4321},
 							val:        "µs",
 							ignoreCase: false,
 						},
 						&litMatcher{
-							pos:        position{line: 140, col: 35, offset: 4289},
+							pos:        position{line: 142, col: 35, offset: 4329},
 							val:        "μs",
 							ignoreCase: false,
 						},
@@ -1528,12 +1545,12 @@ var g = &grammar{
 		},
 		{
 			name: "MilliSecondUnits",
-			pos:  position{line: 145, col: 1, offset: 4398},
+			pos:  position{line: 147, col: 1, offset: 4438},
 			expr: &actionExpr{
-				pos: position{line: 145, col: 20, offset: 4417},
+				pos: position{line: 147, col: 20, offset: 4457},
 				run: (*parser).callonMilliSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 145, col: 20, offset: 4417},
+					pos:        position{line: 147, col: 20, offset: 4457},
 					val:        "ms",
 					ignoreCase: false,
 				},
@@ -1541,12 +1558,12 @@ var g = &grammar{
 		},
 		{
 			name: "SecondUnits",
-			pos:  position{line: 150, col: 1, offset: 4524},
+			pos:  position{line: 152, col: 1, offset: 4564},
 			expr: &actionExpr{
-				pos: position{line: 150, col: 15, offset: 4538},
+				pos: position{line: 152, col: 15, offset: 4578},
 				run: (*parser).callonSecondUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 150, col: 15, offset: 4538},
+					pos:        position{line: 152, col: 15, offset: 4578},
 					val:        "s",
 					ignoreCase: false,
 				},
@@ -1554,12 +1571,12 @@ var g = &grammar{
 		},
 		{
 			name: "MinuteUnits",
-			pos:  position{line: 154, col: 1, offset: 4575},
+			pos:  position{line: 156, col: 1, offset: 4615},
 			expr: &actionExpr{
-				pos: position{line: 154, col: 15, offset: 4589},
+				pos: position{line: 156, col: 15, offset: 4629},
 				run: (*parser).callonMinuteUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 154, col: 15, offset: 4589},
+					pos:        position{line: 156, col: 15, offset: 4629},
 					val:        "m",
 					ignoreCase: false,
 				},
@@ -1567,12 +1584,12 @@ var g = &grammar{
 		},
 		{
 			name: "HourUnits",
-			pos:  position{line: 158, col: 1, offset: 4626},
+			pos:  position{line: 160, col: 1, offset: 4666},
 			expr: &actionExpr{
-				pos: position{line: 158, col: 13, offset: 4638},
+				pos: position{line: 160, col: 13, offset: 4678},
 				run: (*parser).callonHourUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 158, col: 13, offset: 4638},
+					pos:        position{line: 160, col: 13, offset: 4678},
 					val:        "h",
 					ignoreCase: false,
 				},
@@ -1580,12 +1597,12 @@ var g = &grammar{
 		},
 		{
 			name: "DayUnits",
-			pos:  position{line: 162, col: 1, offset: 4673},
+			pos:  position{line: 164, col: 1, offset: 4713},
 			expr: &actionExpr{
-				pos: position{line: 162, col: 12, offset: 4684},
+				pos: position{line: 164, col: 12, offset: 4724},
 				run: (*parser).callonDayUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 162, col: 12, offset: 4684},
+					pos:        position{line: 164, col: 12, offset: 4724},
 					val:        "d",
 					ignoreCase: false,
 				},
@@ -1593,12 +1610,12 @@ var g = &grammar{
 		},
 		{
 			name: "WeekUnits",
-			pos:  position{line: 168, col: 1, offset: 4892},
+			pos:  position{line: 170, col: 1, offset: 4932},
 			expr: &actionExpr{
-				pos: position{line: 168, col: 13, offset: 4904},
+				pos: position{line: 170, col: 13, offset: 4944},
 				run: (*parser).callonWeekUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 168, col: 13, offset: 4904},
+					pos:        position{line: 170, col: 13, offset: 4944},
 					val:        "w",
 					ignoreCase: false,
 				},
@@ -1606,12 +1623,12 @@ var g = &grammar{
 		},
 		{
 			name: "YearUnits",
-			pos:  position{line: 174, col: 1, offset: 5115},
+			pos:  position{line: 176, col: 1, offset: 5155},
 			expr: &actionExpr{
-				pos: position{line: 174, col: 13, offset: 5127},
+				pos: position{line: 176, col: 13, offset: 5167},
 				run: (*parser).callonYearUnits1,
 				expr: &litMatcher{
-					pos:        position{line: 174, col: 13, offset: 5127},
+					pos:        position{line: 176, col: 13, offset: 5167},
 					val:        "y",
 					ignoreCase: false,
 				},
@@ -1619,44 +1636,44 @@ var g = &grammar{
 		},
 		{
 			name: "DurationUnits",
-			pos:  position{line: 180, col: 1, offset: 5324},
+			pos:  position{line: 182, col: 1, offset: 5364},
 			expr: &choiceExpr{
-				pos: position{line: 180, col: 18, offset: 5341},
+				pos: position{line: 182, col: 18, offset: 5381},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 18, offset: 5341},
+						pos:  position{line: 182, col: 18, offset: 5381},
 						name: "NanoSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 36, offset: 5359},
+						pos:  position{line: 182, col: 36, offset: 5399},
 						name: "MicroSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 55, offset: 5378},
+						pos:  position{line: 182, col: 55, offset: 5418},
 						name: "MilliSecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 74, offset: 5397},
+						pos:  position{line: 182, col: 74, offset: 5437},
 						name: "SecondUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 88, offset: 5411},
+						pos:  position{line: 182, col: 88, offset: 5451},
 						name: "MinuteUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 102, offset: 5425},
+						pos:  position{line: 182, col: 102, offset: 5465},
 						name: "HourUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 114, offset: 5437},
+						pos:  position{line: 182, col: 114, offset: 5477},
 						name: "DayUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 125, offset: 5448},
+						pos:  position{line: 182, col: 125, offset: 5488},
 						name: "WeekUnits",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 137, offset: 5460},
+						pos:  position{line: 182, col: 137, offset: 5500},
 						name: "YearUnits",
 					},
 				},
@@ -1664,26 +1681,26 @@ var g = &grammar{
 		},
 		{
 			name: "Duration",
-			pos:  position{line: 182, col: 1, offset: 5472},
+			pos:  position{line: 184, col: 1, offset: 5512},
 			expr: &actionExpr{
-				pos: position{line: 182, col: 12, offset: 5483},
+				pos: position{line: 184, col: 12, offset: 5523},
 				run: (*parser).callonDuration1,
 				expr: &seqExpr{
-					pos: position{line: 182, col: 12, offset: 5483},
+					pos: position{line: 184, col: 12, offset: 5523},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 182, col: 12, offset: 5483},
+							pos:   position{line: 184, col: 12, offset: 5523},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 182, col: 16, offset: 5487},
+								pos:  position{line: 184, col: 16, offset: 5527},
 								name: "Integer",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 182, col: 24, offset: 5495},
+							pos:   position{line: 184, col: 24, offset: 5535},
 							label: "units",
 							expr: &ruleRefExpr{
-								pos:  position{line: 182, col: 30, offset: 5501},
+								pos:  position{line: 184, col: 30, offset: 5541},
 								name: "DurationUnits",
 							},
 						},
@@ -1693,82 +1710,82 @@ var g = &grammar{
 		},
 		{
 			name: "Operators",
-			pos:  position{line: 188, col: 1, offset: 5650},
+			pos:  position{line: 190, col: 1, offset: 5690},
 			expr: &choiceExpr{
-				pos: position{line: 188, col: 13, offset: 5662},
+				pos: position{line: 190, col: 13, offset: 5702},
 				alternatives: []interface{}{
 					&litMatcher{
-						pos:        position{line: 188, col: 13, offset: 5662},
+						pos:        position{line: 190, col: 13, offset: 5702},
 						val:        "-",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 19, offset: 5668},
+						pos:        position{line: 190, col: 19, offset: 5708},
 						val:        "+",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 25, offset: 5674},
+						pos:        position{line: 190, col: 25, offset: 5714},
 						val:        "*",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 31, offset: 5680},
+						pos:        position{line: 190, col: 31, offset: 5720},
 						val:        "%",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 37, offset: 5686},
+						pos:        position{line: 190, col: 37, offset: 5726},
 						val:        "/",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 43, offset: 5692},
+						pos:        position{line: 190, col: 43, offset: 5732},
 						val:        "==",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 50, offset: 5699},
+						pos:        position{line: 190, col: 50, offset: 5739},
 						val:        "!=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 57, offset: 5706},
+						pos:        position{line: 190, col: 57, offset: 5746},
 						val:        "<=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 64, offset: 5713},
+						pos:        position{line: 190, col: 64, offset: 5753},
 						val:        "<",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 70, offset: 5719},
+						pos:        position{line: 190, col: 70, offset: 5759},
 						val:        ">=",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 77, offset: 5726},
+						pos:        position{line: 190, col: 77, offset: 5766},
 						val:        ">",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 83, offset: 5732},
+						pos:        position{line: 190, col: 83, offset: 5772},
 						val:        "=~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 90, offset: 5739},
+						pos:        position{line: 190, col: 90, offset: 5779},
 						val:        "!~",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 97, offset: 5746},
+						pos:        position{line: 190, col: 97, offset: 5786},
 						val:        "^",
 						ignoreCase: false,
 					},
 					&litMatcher{
-						pos:        position{line: 188, col: 103, offset: 5752},
+						pos:        position{line: 190, col: 103, offset: 5792},
 						val:        "=",
 						ignoreCase: false,
 					},
@@ -1777,42 +1794,42 @@ var g = &grammar{
 		},
 		{
 			name: "LabelOperators",
-			pos:  position{line: 190, col: 1, offset: 5757},
+			pos:  position{line: 192, col: 1, offset: 5797},
 			expr: &choiceExpr{
-				pos: position{line: 190, col: 19, offset: 5775},
+				pos: position{line: 192, col: 19, offset: 5815},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 190, col: 19, offset: 5775},
+						pos: position{line: 192, col: 19, offset: 5815},
 						run: (*parser).callonLabelOperators2,
 						expr: &litMatcher{
-							pos:        position{line: 190, col: 19, offset: 5775},
+							pos:        position{line: 192, col: 19, offset: 5815},
 							val:        "!=",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 192, col: 5, offset: 5811},
+						pos: position{line: 194, col: 5, offset: 5851},
 						run: (*parser).callonLabelOperators4,
 						expr: &litMatcher{
-							pos:        position{line: 192, col: 5, offset: 5811},
+							pos:        position{line: 194, col: 5, offset: 5851},
 							val:        "=~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 194, col: 5, offset: 5849},
+						pos: position{line: 196, col: 5, offset: 5889},
 						run: (*parser).callonLabelOperators6,
 						expr: &litMatcher{
-							pos:        position{line: 194, col: 5, offset: 5849},
+							pos:        position{line: 196, col: 5, offset: 5889},
 							val:        "!~",
 							ignoreCase: false,
 						},
 					},
 					&actionExpr{
-						pos: position{line: 196, col: 5, offset: 5889},
+						pos: position{line: 198, col: 5, offset: 5929},
 						run: (*parser).callonLabelOperators8,
 						expr: &litMatcher{
-							pos:        position{line: 196, col: 5, offset: 5889},
+							pos:        position{line: 198, col: 5, offset: 5929},
 							val:        "=",
 							ignoreCase: false,
 						},
@@ -1822,57 +1839,57 @@ var g = &grammar{
 		},
 		{
 			name: "Label",
-			pos:  position{line: 200, col: 1, offset: 5920},
+			pos:  position{line: 202, col: 1, offset: 5960},
 			expr: &ruleRefExpr{
-				pos:  position{line: 200, col: 9, offset: 5928},
+				pos:  position{line: 202, col: 9, offset: 5968},
 				name: "Identifier",
 			},
 		},
 		{
 			name: "LabelMatch",
-			pos:  position{line: 201, col: 1, offset: 5939},
+			pos:  position{line: 203, col: 1, offset: 5979},
 			expr: &actionExpr{
-				pos: position{line: 201, col: 14, offset: 5952},
+				pos: position{line: 203, col: 14, offset: 5992},
 				run: (*parser).callonLabelMatch1,
 				expr: &seqExpr{
-					pos: position{line: 201, col: 14, offset: 5952},
+					pos: position{line: 203, col: 14, offset: 5992},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 201, col: 14, offset: 5952},
+							pos:   position{line: 203, col: 14, offset: 5992},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 201, col: 20, offset: 5958},
+								pos:  position{line: 203, col: 20, offset: 5998},
 								name: "Label",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 201, col: 26, offset: 5964},
+							pos:  position{line: 203, col: 26, offset: 6004},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 201, col: 29, offset: 5967},
+							pos:   position{line: 203, col: 29, offset: 6007},
 							label: "op",
 							expr: &ruleRefExpr{
-								pos:  position{line: 201, col: 32, offset: 5970},
+								pos:  position{line: 203, col: 32, offset: 6010},
 								name: "LabelOperators",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 201, col: 47, offset: 5985},
+							pos:  position{line: 203, col: 47, offset: 6025},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 201, col: 50, offset: 5988},
+							pos:   position{line: 203, col: 50, offset: 6028},
 							label: "match",
 							expr: &choiceExpr{
-								pos: position{line: 201, col: 58, offset: 5996},
+								pos: position{line: 203, col: 58, offset: 6036},
 								alternatives: []interface{}{
 									&ruleRefExpr{
-										pos:  position{line: 201, col: 58, offset: 5996},
+										pos:  position{line: 203, col: 58, offset: 6036},
 										name: "StringLiteral",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 201, col: 74, offset: 6012},
+										pos:  position{line: 203, col: 74, offset: 6052},
 										name: "Number",
 									},
 								},
@@ -1884,32 +1901,32 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatches",
-			pos:  position{line: 204, col: 1, offset: 6102},
+			pos:  position{line: 206, col: 1, offset: 6142},
 			expr: &actionExpr{
-				pos: position{line: 204, col: 16, offset: 6117},
+				pos: position{line: 206, col: 16, offset: 6157},
 				run: (*parser).callonLabelMatches1,
 				expr: &seqExpr{
-					pos: position{line: 204, col: 16, offset: 6117},
+					pos: position{line: 206, col: 16, offset: 6157},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 204, col: 16, offset: 6117},
+							pos:   position{line: 206, col: 16, offset: 6157},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 204, col: 22, offset: 6123},
+								pos:  position{line: 206, col: 22, offset: 6163},
 								name: "LabelMatch",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 204, col: 33, offset: 6134},
+							pos:  position{line: 206, col: 33, offset: 6174},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 204, col: 36, offset: 6137},
+							pos:   position{line: 206, col: 36, offset: 6177},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 204, col: 41, offset: 6142},
+								pos: position{line: 206, col: 41, offset: 6182},
 								expr: &ruleRefExpr{
-									pos:  position{line: 204, col: 41, offset: 6142},
+									pos:  position{line: 206, col: 41, offset: 6182},
 									name: "LabelMatchesRest",
 								},
 							},
@@ -1920,27 +1937,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelMatchesRest",
-			pos:  position{line: 208, col: 1, offset: 6221},
+			pos:  position{line: 210, col: 1, offset: 6261},
 			expr: &actionExpr{
-				pos: position{line: 208, col: 21, offset: 6241},
+				pos: position{line: 210, col: 21, offset: 6281},
 				run: (*parser).callonLabelMatchesRest1,
 				expr: &seqExpr{
-					pos: position{line: 208, col: 21, offset: 6241},
+					pos: position{line: 210, col: 21, offset: 6281},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 208, col: 21, offset: 6241},
+							pos:        position{line: 210, col: 21, offset: 6281},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 208, col: 25, offset: 6245},
+							pos:  position{line: 210, col: 25, offset: 6285},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 208, col: 28, offset: 6248},
+							pos:   position{line: 210, col: 28, offset: 6288},
 							label: "match",
 							expr: &ruleRefExpr{
-								pos:  position{line: 208, col: 34, offset: 6254},
+								pos:  position{line: 210, col: 34, offset: 6294},
 								name: "LabelMatch",
 							},
 						},
@@ -1950,27 +1967,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelList",
-			pos:  position{line: 212, col: 1, offset: 6292},
+			pos:  position{line: 214, col: 1, offset: 6332},
 			expr: &choiceExpr{
-				pos: position{line: 212, col: 13, offset: 6304},
+				pos: position{line: 214, col: 13, offset: 6344},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 212, col: 13, offset: 6304},
+						pos: position{line: 214, col: 13, offset: 6344},
 						run: (*parser).callonLabelList2,
 						expr: &seqExpr{
-							pos: position{line: 212, col: 14, offset: 6305},
+							pos: position{line: 214, col: 14, offset: 6345},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 212, col: 14, offset: 6305},
+									pos:        position{line: 214, col: 14, offset: 6345},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 212, col: 18, offset: 6309},
+									pos:  position{line: 214, col: 18, offset: 6349},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 212, col: 21, offset: 6312},
+									pos:        position{line: 214, col: 21, offset: 6352},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -1978,49 +1995,49 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 214, col: 6, offset: 6344},
+						pos: position{line: 216, col: 6, offset: 6384},
 						run: (*parser).callonLabelList7,
 						expr: &seqExpr{
-							pos: position{line: 214, col: 6, offset: 6344},
+							pos: position{line: 216, col: 6, offset: 6384},
 							exprs: []interface{}{
 								&litMatcher{
-									pos:        position{line: 214, col: 6, offset: 6344},
+									pos:        position{line: 216, col: 6, offset: 6384},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 214, col: 10, offset: 6348},
+									pos:  position{line: 216, col: 10, offset: 6388},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 214, col: 13, offset: 6351},
+									pos:   position{line: 216, col: 13, offset: 6391},
 									label: "label",
 									expr: &ruleRefExpr{
-										pos:  position{line: 214, col: 19, offset: 6357},
+										pos:  position{line: 216, col: 19, offset: 6397},
 										name: "Label",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 214, col: 25, offset: 6363},
+									pos:  position{line: 216, col: 25, offset: 6403},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 214, col: 28, offset: 6366},
+									pos:   position{line: 216, col: 28, offset: 6406},
 									label: "rest",
 									expr: &zeroOrMoreExpr{
-										pos: position{line: 214, col: 33, offset: 6371},
+										pos: position{line: 216, col: 33, offset: 6411},
 										expr: &ruleRefExpr{
-											pos:  position{line: 214, col: 33, offset: 6371},
+											pos:  position{line: 216, col: 33, offset: 6411},
 											name: "LabelListRest",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 214, col: 48, offset: 6386},
+									pos:  position{line: 216, col: 48, offset: 6426},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 214, col: 51, offset: 6389},
+									pos:        position{line: 216, col: 51, offset: 6429},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2032,27 +2049,27 @@ var g = &grammar{
 		},
 		{
 			name: "LabelListRest",
-			pos:  position{line: 218, col: 1, offset: 6455},
+			pos:  position{line: 220, col: 1, offset: 6495},
 			expr: &actionExpr{
-				pos: position{line: 218, col: 18, offset: 6472},
+				pos: position{line: 220, col: 18, offset: 6512},
 				run: (*parser).callonLabelListRest1,
 				expr: &seqExpr{
-					pos: position{line: 218, col: 18, offset: 6472},
+					pos: position{line: 220, col: 18, offset: 6512},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 218, col: 18, offset: 6472},
+							pos:        position{line: 220, col: 18, offset: 6512},
 							val:        ",",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 218, col: 22, offset: 6476},
+							pos:  position{line: 220, col: 22, offset: 6516},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 218, col: 25, offset: 6479},
+							pos:   position{line: 220, col: 25, offset: 6519},
 							label: "label",
 							expr: &ruleRefExpr{
-								pos:  position{line: 218, col: 31, offset: 6485},
+								pos:  position{line: 220, col: 31, offset: 6525},
 								name: "Label",
 							},
 						},
@@ -2062,62 +2079,62 @@ var g = &grammar{
 		},
 		{
 			name: "VectorSelector",
-			pos:  position{line: 222, col: 1, offset: 6518},
+			pos:  position{line: 224, col: 1, offset: 6558},
 			expr: &actionExpr{
-				pos: position{line: 222, col: 18, offset: 6535},
+				pos: position{line: 224, col: 18, offset: 6575},
 				run: (*parser).callonVectorSelector1,
 				expr: &seqExpr{
-					pos: position{line: 222, col: 18, offset: 6535},
+					pos: position{line: 224, col: 18, offset: 6575},
 					exprs: []interface{}{
 						&labeledExpr{
-							pos:   position{line: 222, col: 18, offset: 6535},
+							pos:   position{line: 224, col: 18, offset: 6575},
 							label: "metric",
 							expr: &ruleRefExpr{
-								pos:  position{line: 222, col: 25, offset: 6542},
+								pos:  position{line: 224, col: 25, offset: 6582},
 								name: "Identifier",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 36, offset: 6553},
+							pos:  position{line: 224, col: 36, offset: 6593},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 40, offset: 6557},
+							pos:   position{line: 224, col: 40, offset: 6597},
 							label: "block",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 222, col: 46, offset: 6563},
+								pos: position{line: 224, col: 46, offset: 6603},
 								expr: &ruleRefExpr{
-									pos:  position{line: 222, col: 46, offset: 6563},
+									pos:  position{line: 224, col: 46, offset: 6603},
 									name: "LabelBlock",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 58, offset: 6575},
+							pos:  position{line: 224, col: 58, offset: 6615},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 61, offset: 6578},
+							pos:   position{line: 224, col: 61, offset: 6618},
 							label: "rng",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 222, col: 65, offset: 6582},
+								pos: position{line: 224, col: 65, offset: 6622},
 								expr: &ruleRefExpr{
-									pos:  position{line: 222, col: 65, offset: 6582},
+									pos:  position{line: 224, col: 65, offset: 6622},
 									name: "Range",
 								},
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 222, col: 72, offset: 6589},
+							pos:  position{line: 224, col: 72, offset: 6629},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 222, col: 75, offset: 6592},
+							pos:   position{line: 224, col: 75, offset: 6632},
 							label: "offset",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 222, col: 82, offset: 6599},
+								pos: position{line: 224, col: 82, offset: 6639},
 								expr: &ruleRefExpr{
-									pos:  position{line: 222, col: 82, offset: 6599},
+									pos:  position{line: 224, col: 82, offset: 6639},
 									name: "Offset",
 								},
 							},
@@ -2128,36 +2145,36 @@ var g = &grammar{
 		},
 		{
 			name: "Range",
-			pos:  position{line: 226, col: 1, offset: 6677},
+			pos:  position{line: 228, col: 1, offset: 6717},
 			expr: &actionExpr{
-				pos: position{line: 226, col: 9, offset: 6685},
+				pos: position{line: 228, col: 9, offset: 6725},
 				run: (*parser).callonRange1,
 				expr: &seqExpr{
-					pos: position{line: 226, col: 9, offset: 6685},
+					pos: position{line: 228, col: 9, offset: 6725},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 226, col: 9, offset: 6685},
+							pos:        position{line: 228, col: 9, offset: 6725},
 							val:        "[",
 							ignoreCase: false,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 226, col: 13, offset: 6689},
+							pos:  position{line: 228, col: 13, offset: 6729},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 226, col: 16, offset: 6692},
+							pos:   position{line: 228, col: 16, offset: 6732},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 226, col: 20, offset: 6696},
+								pos:  position{line: 228, col: 20, offset: 6736},
 								name: "Duration",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 226, col: 29, offset: 6705},
+							pos:  position{line: 228, col: 29, offset: 6745},
 							name: "__",
 						},
 						&litMatcher{
-							pos:        position{line: 226, col: 32, offset: 6708},
+							pos:        position{line: 228, col: 32, offset: 6748},
 							val:        "]",
 							ignoreCase: false,
 						},
@@ -2167,27 +2184,27 @@ var g = &grammar{
 		},
 		{
 			name: "Offset",
-			pos:  position{line: 230, col: 1, offset: 6737},
+			pos:  position{line: 232, col: 1, offset: 6777},
 			expr: &actionExpr{
-				pos: position{line: 230, col: 10, offset: 6746},
+				pos: position{line: 232, col: 10, offset: 6786},
 				run: (*parser).callonOffset1,
 				expr: &seqExpr{
-					pos: position{line: 230, col: 10, offset: 6746},
+					pos: position{line: 232, col: 10, offset: 6786},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 230, col: 10, offset: 6746},
+							pos:        position{line: 232, col: 10, offset: 6786},
 							val:        "offset",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 230, col: 20, offset: 6756},
+							pos:  position{line: 232, col: 20, offset: 6796},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 230, col: 23, offset: 6759},
+							pos:   position{line: 232, col: 23, offset: 6799},
 							label: "dur",
 							expr: &ruleRefExpr{
-								pos:  position{line: 230, col: 27, offset: 6763},
+								pos:  position{line: 232, col: 27, offset: 6803},
 								name: "Duration",
 							},
 						},
@@ -2197,12 +2214,12 @@ var g = &grammar{
 		},
 		{
 			name: "CountValueOperator",
-			pos:  position{line: 234, col: 1, offset: 6797},
+			pos:  position{line: 236, col: 1, offset: 6837},
 			expr: &actionExpr{
-				pos: position{line: 234, col: 22, offset: 6818},
+				pos: position{line: 236, col: 22, offset: 6858},
 				run: (*parser).callonCountValueOperator1,
 				expr: &litMatcher{
-					pos:        position{line: 234, col: 22, offset: 6818},
+					pos:        position{line: 236, col: 22, offset: 6858},
 					val:        "count_values",
 					ignoreCase: true,
 				},
@@ -2210,28 +2227,28 @@ var g = &grammar{
 		},
 		{
 			name: "BinaryAggregateOperators",
-			pos:  position{line: 240, col: 1, offset: 6903},
+			pos:  position{line: 242, col: 1, offset: 6943},
 			expr: &actionExpr{
-				pos: position{line: 240, col: 29, offset: 6931},
+				pos: position{line: 242, col: 29, offset: 6971},
 				run: (*parser).callonBinaryAggregateOperators1,
 				expr: &labeledExpr{
-					pos:   position{line: 240, col: 29, offset: 6931},
+					pos:   position{line: 242, col: 29, offset: 6971},
 					label: "op",
 					expr: &choiceExpr{
-						pos: position{line: 240, col: 33, offset: 6935},
+						pos: position{line: 242, col: 33, offset: 6975},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 240, col: 33, offset: 6935},
+								pos:        position{line: 242, col: 33, offset: 6975},
 								val:        "topk",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 240, col: 43, offset: 6945},
+								pos:        position{line: 242, col: 43, offset: 6985},
 								val:        "bottomk",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 240, col: 56, offset: 6958},
+								pos:        position{line: 242, col: 56, offset: 6998},
 								val:        "quantile",
 								ignoreCase: true,
 							},
@@ -2242,48 +2259,48 @@ var g = &grammar{
 		},
 		{
 			name: "UnaryAggregateOperators",
-			pos:  position{line: 246, col: 1, offset: 7060},
+			pos:  position{line: 248, col: 1, offset: 7100},
 			expr: &actionExpr{
-				pos: position{line: 246, col: 27, offset: 7086},
+				pos: position{line: 248, col: 27, offset: 7126},
 				run: (*parser).callonUnaryAggregateOperators1,
 				expr: &labeledExpr{
-					pos:   position{line: 246, col: 27, offset: 7086},
+					pos:   position{line: 248, col: 27, offset: 7126},
 					label: "op",
 					expr: &choiceExpr{
-						pos: position{line: 246, col: 31, offset: 7090},
+						pos: position{line: 248, col: 31, offset: 7130},
 						alternatives: []interface{}{
 							&litMatcher{
-								pos:        position{line: 246, col: 31, offset: 7090},
+								pos:        position{line: 248, col: 31, offset: 7130},
 								val:        "sum",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 246, col: 40, offset: 7099},
+								pos:        position{line: 248, col: 40, offset: 7139},
 								val:        "min",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 246, col: 49, offset: 7108},
+								pos:        position{line: 248, col: 49, offset: 7148},
 								val:        "max",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 246, col: 58, offset: 7117},
+								pos:        position{line: 248, col: 58, offset: 7157},
 								val:        "avg",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 246, col: 67, offset: 7126},
+								pos:        position{line: 248, col: 67, offset: 7166},
 								val:        "stddev",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 246, col: 79, offset: 7138},
+								pos:        position{line: 248, col: 79, offset: 7178},
 								val:        "stdvar",
 								ignoreCase: true,
 							},
 							&litMatcher{
-								pos:        position{line: 246, col: 91, offset: 7150},
+								pos:        position{line: 248, col: 91, offset: 7190},
 								val:        "count",
 								ignoreCase: true,
 							},
@@ -2294,20 +2311,20 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateOperators",
-			pos:  position{line: 252, col: 1, offset: 7249},
+			pos:  position{line: 254, col: 1, offset: 7289},
 			expr: &choiceExpr{
-				pos: position{line: 252, col: 22, offset: 7270},
+				pos: position{line: 254, col: 22, offset: 7310},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 252, col: 22, offset: 7270},
+						pos:  position{line: 254, col: 22, offset: 7310},
 						name: "CountValueOperator",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 252, col: 43, offset: 7291},
+						pos:  position{line: 254, col: 43, offset: 7331},
 						name: "BinaryAggregateOperators",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 252, col: 70, offset: 7318},
+						pos:  position{line: 254, col: 70, offset: 7358},
 						name: "UnaryAggregateOperators",
 					},
 				},
@@ -2315,41 +2332,41 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateBy",
-			pos:  position{line: 254, col: 1, offset: 7343},
+			pos:  position{line: 256, col: 1, offset: 7383},
 			expr: &actionExpr{
-				pos: position{line: 254, col: 15, offset: 7357},
+				pos: position{line: 256, col: 15, offset: 7397},
 				run: (*parser).callonAggregateBy1,
 				expr: &seqExpr{
-					pos: position{line: 254, col: 15, offset: 7357},
+					pos: position{line: 256, col: 15, offset: 7397},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 254, col: 15, offset: 7357},
+							pos:        position{line: 256, col: 15, offset: 7397},
 							val:        "by",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 254, col: 21, offset: 7363},
+							pos:  position{line: 256, col: 21, offset: 7403},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 254, col: 24, offset: 7366},
+							pos:   position{line: 256, col: 24, offset: 7406},
 							label: "labels",
 							expr: &ruleRefExpr{
-								pos:  position{line: 254, col: 31, offset: 7373},
+								pos:  position{line: 256, col: 31, offset: 7413},
 								name: "LabelList",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 254, col: 41, offset: 7383},
+							pos:  position{line: 256, col: 41, offset: 7423},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 254, col: 44, offset: 7386},
+							pos:   position{line: 256, col: 44, offset: 7426},
 							label: "keep",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 254, col: 49, offset: 7391},
+								pos: position{line: 256, col: 49, offset: 7431},
 								expr: &litMatcher{
-									pos:        position{line: 254, col: 49, offset: 7391},
+									pos:        position{line: 256, col: 49, offset: 7431},
 									val:        "keep_common",
 									ignoreCase: true,
 								},
@@ -2361,27 +2378,27 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateWithout",
-			pos:  position{line: 261, col: 1, offset: 7504},
+			pos:  position{line: 263, col: 1, offset: 7544},
 			expr: &actionExpr{
-				pos: position{line: 261, col: 20, offset: 7523},
+				pos: position{line: 263, col: 20, offset: 7563},
 				run: (*parser).callonAggregateWithout1,
 				expr: &seqExpr{
-					pos: position{line: 261, col: 20, offset: 7523},
+					pos: position{line: 263, col: 20, offset: 7563},
 					exprs: []interface{}{
 						&litMatcher{
-							pos:        position{line: 261, col: 20, offset: 7523},
+							pos:        position{line: 263, col: 20, offset: 7563},
 							val:        "without",
 							ignoreCase: true,
 						},
 						&ruleRefExpr{
-							pos:  position{line: 261, col: 31, offset: 7534},
+							pos:  position{line: 263, col: 31, offset: 7574},
 							name: "__",
 						},
 						&labeledExpr{
-							pos:   position{line: 261, col: 34, offset: 7537},
+							pos:   position{line: 263, col: 34, offset: 7577},
 							label: "labels",
 							expr: &ruleRefExpr{
-								pos:  position{line: 261, col: 41, offset: 7544},
+								pos:  position{line: 263, col: 41, offset: 7584},
 								name: "LabelList",
 							},
 						},
@@ -2391,16 +2408,16 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateGroup",
-			pos:  position{line: 268, col: 1, offset: 7656},
+			pos:  position{line: 270, col: 1, offset: 7696},
 			expr: &choiceExpr{
-				pos: position{line: 268, col: 18, offset: 7673},
+				pos: position{line: 270, col: 18, offset: 7713},
 				alternatives: []interface{}{
 					&ruleRefExpr{
-						pos:  position{line: 268, col: 18, offset: 7673},
+						pos:  position{line: 270, col: 18, offset: 7713},
 						name: "AggregateBy",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 268, col: 32, offset: 7687},
+						pos:  position{line: 270, col: 32, offset: 7727},
 						name: "AggregateWithout",
 					},
 				},
@@ -2408,86 +2425,86 @@ var g = &grammar{
 		},
 		{
 			name: "AggregateExpression",
-			pos:  position{line: 270, col: 1, offset: 7705},
+			pos:  position{line: 272, col: 1, offset: 7745},
 			expr: &choiceExpr{
-				pos: position{line: 271, col: 1, offset: 7727},
+				pos: position{line: 273, col: 1, offset: 7767},
 				alternatives: []interface{}{
 					&actionExpr{
-						pos: position{line: 271, col: 1, offset: 7727},
+						pos: position{line: 273, col: 1, offset: 7767},
 						run: (*parser).callonAggregateExpression2,
 						expr: &seqExpr{
-							pos: position{line: 271, col: 1, offset: 7727},
+							pos: position{line: 273, col: 1, offset: 7767},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 271, col: 1, offset: 7727},
+									pos:   position{line: 273, col: 1, offset: 7767},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 271, col: 4, offset: 7730},
+										pos:  position{line: 273, col: 4, offset: 7770},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 271, col: 24, offset: 7750},
+									pos:  position{line: 273, col: 24, offset: 7790},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 271, col: 27, offset: 7753},
+									pos:        position{line: 273, col: 27, offset: 7793},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 271, col: 31, offset: 7757},
+									pos:  position{line: 273, col: 31, offset: 7797},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 271, col: 34, offset: 7760},
+									pos:   position{line: 273, col: 34, offset: 7800},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 271, col: 40, offset: 7766},
+										pos:  position{line: 273, col: 40, offset: 7806},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 271, col: 54, offset: 7780},
+									pos:  position{line: 273, col: 54, offset: 7820},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 271, col: 57, offset: 7783},
+									pos:        position{line: 273, col: 57, offset: 7823},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 271, col: 61, offset: 7787},
+									pos:  position{line: 273, col: 61, offset: 7827},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 271, col: 64, offset: 7790},
+									pos:   position{line: 273, col: 64, offset: 7830},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 271, col: 71, offset: 7797},
-										name: "VectorSelector",
+										pos:  position{line: 273, col: 71, offset: 7837},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 271, col: 86, offset: 7812},
+									pos:  position{line: 273, col: 82, offset: 7848},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 271, col: 89, offset: 7815},
+									pos:        position{line: 273, col: 85, offset: 7851},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 271, col: 93, offset: 7819},
+									pos:  position{line: 273, col: 89, offset: 7855},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 271, col: 96, offset: 7822},
+									pos:   position{line: 273, col: 92, offset: 7858},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 271, col: 102, offset: 7828},
+										pos: position{line: 273, col: 98, offset: 7864},
 										expr: &ruleRefExpr{
-											pos:  position{line: 271, col: 102, offset: 7828},
+											pos:  position{line: 273, col: 98, offset: 7864},
 											name: "AggregateGroup",
 										},
 									},
@@ -2496,82 +2513,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 277, col: 1, offset: 7976},
+						pos: position{line: 279, col: 1, offset: 8000},
 						run: (*parser).callonAggregateExpression22,
 						expr: &seqExpr{
-							pos: position{line: 277, col: 1, offset: 7976},
+							pos: position{line: 279, col: 1, offset: 8000},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 277, col: 1, offset: 7976},
+									pos:   position{line: 279, col: 1, offset: 8000},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 277, col: 4, offset: 7979},
+										pos:  position{line: 279, col: 4, offset: 8003},
 										name: "CountValueOperator",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 277, col: 24, offset: 7999},
+									pos:  position{line: 279, col: 24, offset: 8023},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 277, col: 27, offset: 8002},
+									pos:   position{line: 279, col: 27, offset: 8026},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 277, col: 33, offset: 8008},
+										pos: position{line: 279, col: 33, offset: 8032},
 										expr: &ruleRefExpr{
-											pos:  position{line: 277, col: 33, offset: 8008},
+											pos:  position{line: 279, col: 33, offset: 8032},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 277, col: 49, offset: 8024},
+									pos:  position{line: 279, col: 49, offset: 8048},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 277, col: 52, offset: 8027},
+									pos:        position{line: 279, col: 52, offset: 8051},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 277, col: 56, offset: 8031},
+									pos:  position{line: 279, col: 56, offset: 8055},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 277, col: 59, offset: 8034},
+									pos:   position{line: 279, col: 59, offset: 8058},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 277, col: 65, offset: 8040},
+										pos:  position{line: 279, col: 65, offset: 8064},
 										name: "StringLiteral",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 277, col: 79, offset: 8054},
+									pos:  position{line: 279, col: 79, offset: 8078},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 277, col: 82, offset: 8057},
+									pos:        position{line: 279, col: 82, offset: 8081},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 277, col: 86, offset: 8061},
+									pos:  position{line: 279, col: 86, offset: 8085},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 277, col: 89, offset: 8064},
+									pos:   position{line: 279, col: 89, offset: 8088},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 277, col: 96, offset: 8071},
-										name: "VectorSelector",
+										pos:  position{line: 279, col: 96, offset: 8095},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 277, col: 111, offset: 8086},
+									pos:  position{line: 279, col: 107, offset: 8106},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 277, col: 114, offset: 8089},
+									pos:        position{line: 279, col: 110, offset: 8109},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2579,81 +2596,81 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 283, col: 1, offset: 8225},
+						pos: position{line: 285, col: 1, offset: 8233},
 						run: (*parser).callonAggregateExpression42,
 						expr: &seqExpr{
-							pos: position{line: 283, col: 1, offset: 8225},
+							pos: position{line: 285, col: 1, offset: 8233},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 283, col: 1, offset: 8225},
+									pos:   position{line: 285, col: 1, offset: 8233},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 283, col: 4, offset: 8228},
+										pos:  position{line: 285, col: 4, offset: 8236},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 283, col: 30, offset: 8254},
+									pos:  position{line: 285, col: 30, offset: 8262},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 283, col: 33, offset: 8257},
+									pos:        position{line: 285, col: 33, offset: 8265},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 283, col: 37, offset: 8261},
+									pos:  position{line: 285, col: 37, offset: 8269},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 283, col: 41, offset: 8265},
+									pos:   position{line: 285, col: 41, offset: 8273},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 283, col: 47, offset: 8271},
+										pos:  position{line: 285, col: 47, offset: 8279},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 283, col: 54, offset: 8278},
+									pos:  position{line: 285, col: 54, offset: 8286},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 283, col: 57, offset: 8281},
+									pos:        position{line: 285, col: 57, offset: 8289},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 283, col: 61, offset: 8285},
+									pos:  position{line: 285, col: 61, offset: 8293},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 283, col: 64, offset: 8288},
+									pos:   position{line: 285, col: 64, offset: 8296},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 283, col: 71, offset: 8295},
-										name: "VectorSelector",
+										pos:  position{line: 285, col: 71, offset: 8303},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 283, col: 86, offset: 8310},
+									pos:  position{line: 285, col: 82, offset: 8314},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 283, col: 89, offset: 8313},
+									pos:        position{line: 285, col: 85, offset: 8317},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 283, col: 93, offset: 8317},
+									pos:  position{line: 285, col: 89, offset: 8321},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 283, col: 96, offset: 8320},
+									pos:   position{line: 285, col: 92, offset: 8324},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 283, col: 102, offset: 8326},
+										pos: position{line: 285, col: 98, offset: 8330},
 										expr: &ruleRefExpr{
-											pos:  position{line: 283, col: 102, offset: 8326},
+											pos:  position{line: 285, col: 98, offset: 8330},
 											name: "AggregateGroup",
 										},
 									},
@@ -2662,82 +2679,82 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 289, col: 1, offset: 8467},
+						pos: position{line: 291, col: 1, offset: 8459},
 						run: (*parser).callonAggregateExpression62,
 						expr: &seqExpr{
-							pos: position{line: 289, col: 1, offset: 8467},
+							pos: position{line: 291, col: 1, offset: 8459},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 289, col: 1, offset: 8467},
+									pos:   position{line: 291, col: 1, offset: 8459},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 289, col: 4, offset: 8470},
+										pos:  position{line: 291, col: 4, offset: 8462},
 										name: "BinaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 289, col: 30, offset: 8496},
+									pos:  position{line: 291, col: 30, offset: 8488},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 289, col: 33, offset: 8499},
+									pos:   position{line: 291, col: 33, offset: 8491},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 289, col: 39, offset: 8505},
+										pos: position{line: 291, col: 39, offset: 8497},
 										expr: &ruleRefExpr{
-											pos:  position{line: 289, col: 39, offset: 8505},
+											pos:  position{line: 291, col: 39, offset: 8497},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 289, col: 55, offset: 8521},
+									pos:  position{line: 291, col: 55, offset: 8513},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 289, col: 58, offset: 8524},
+									pos:        position{line: 291, col: 58, offset: 8516},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 289, col: 62, offset: 8528},
+									pos:  position{line: 291, col: 62, offset: 8520},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 289, col: 66, offset: 8532},
+									pos:   position{line: 291, col: 66, offset: 8524},
 									label: "param",
 									expr: &ruleRefExpr{
-										pos:  position{line: 289, col: 72, offset: 8538},
+										pos:  position{line: 291, col: 72, offset: 8530},
 										name: "Number",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 289, col: 79, offset: 8545},
+									pos:  position{line: 291, col: 79, offset: 8537},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 289, col: 82, offset: 8548},
+									pos:        position{line: 291, col: 82, offset: 8540},
 									val:        ",",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 289, col: 86, offset: 8552},
+									pos:  position{line: 291, col: 86, offset: 8544},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 289, col: 89, offset: 8555},
+									pos:   position{line: 291, col: 89, offset: 8547},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 289, col: 96, offset: 8562},
-										name: "VectorSelector",
+										pos:  position{line: 291, col: 96, offset: 8554},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 289, col: 111, offset: 8577},
+									pos:  position{line: 291, col: 107, offset: 8565},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 289, col: 114, offset: 8580},
+									pos:        position{line: 291, col: 110, offset: 8568},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2745,60 +2762,60 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 295, col: 1, offset: 8709},
+						pos: position{line: 297, col: 1, offset: 8685},
 						run: (*parser).callonAggregateExpression82,
 						expr: &seqExpr{
-							pos: position{line: 295, col: 1, offset: 8709},
+							pos: position{line: 297, col: 1, offset: 8685},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 295, col: 1, offset: 8709},
+									pos:   position{line: 297, col: 1, offset: 8685},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 295, col: 4, offset: 8712},
+										pos:  position{line: 297, col: 4, offset: 8688},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 295, col: 29, offset: 8737},
+									pos:  position{line: 297, col: 29, offset: 8713},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 295, col: 32, offset: 8740},
+									pos:        position{line: 297, col: 32, offset: 8716},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 295, col: 36, offset: 8744},
+									pos:  position{line: 297, col: 36, offset: 8720},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 295, col: 39, offset: 8747},
+									pos:   position{line: 297, col: 39, offset: 8723},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 295, col: 46, offset: 8754},
-										name: "VectorSelector",
+										pos:  position{line: 297, col: 46, offset: 8730},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 295, col: 61, offset: 8769},
+									pos:  position{line: 297, col: 57, offset: 8741},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 295, col: 64, offset: 8772},
+									pos:        position{line: 297, col: 60, offset: 8744},
 									val:        ")",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 295, col: 68, offset: 8776},
+									pos:  position{line: 297, col: 64, offset: 8748},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 295, col: 71, offset: 8779},
+									pos:   position{line: 297, col: 67, offset: 8751},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 295, col: 77, offset: 8785},
+										pos: position{line: 297, col: 73, offset: 8757},
 										expr: &ruleRefExpr{
-											pos:  position{line: 295, col: 77, offset: 8785},
+											pos:  position{line: 297, col: 73, offset: 8757},
 											name: "AggregateGroup",
 										},
 									},
@@ -2807,61 +2824,61 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 299, col: 1, offset: 8878},
+						pos: position{line: 301, col: 1, offset: 8838},
 						run: (*parser).callonAggregateExpression97,
 						expr: &seqExpr{
-							pos: position{line: 299, col: 1, offset: 8878},
+							pos: position{line: 301, col: 1, offset: 8838},
 							exprs: []interface{}{
 								&labeledExpr{
-									pos:   position{line: 299, col: 1, offset: 8878},
+									pos:   position{line: 301, col: 1, offset: 8838},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 299, col: 4, offset: 8881},
+										pos:  position{line: 301, col: 4, offset: 8841},
 										name: "UnaryAggregateOperators",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 299, col: 29, offset: 8906},
+									pos:  position{line: 301, col: 29, offset: 8866},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 299, col: 32, offset: 8909},
+									pos:   position{line: 301, col: 32, offset: 8869},
 									label: "group",
 									expr: &zeroOrOneExpr{
-										pos: position{line: 299, col: 38, offset: 8915},
+										pos: position{line: 301, col: 38, offset: 8875},
 										expr: &ruleRefExpr{
-											pos:  position{line: 299, col: 38, offset: 8915},
+											pos:  position{line: 301, col: 38, offset: 8875},
 											name: "AggregateGroup",
 										},
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 299, col: 54, offset: 8931},
+									pos:  position{line: 301, col: 54, offset: 8891},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 299, col: 57, offset: 8934},
+									pos:        position{line: 301, col: 57, offset: 8894},
 									val:        "(",
 									ignoreCase: false,
 								},
 								&ruleRefExpr{
-									pos:  position{line: 299, col: 61, offset: 8938},
+									pos:  position{line: 301, col: 61, offset: 8898},
 									name: "__",
 								},
 								&labeledExpr{
-									pos:   position{line: 299, col: 64, offset: 8941},
+									pos:   position{line: 301, col: 64, offset: 8901},
 									label: "vector",
 									expr: &ruleRefExpr{
-										pos:  position{line: 299, col: 71, offset: 8948},
-										name: "VectorSelector",
+										pos:  position{line: 301, col: 71, offset: 8908},
+										name: "Expression",
 									},
 								},
 								&ruleRefExpr{
-									pos:  position{line: 299, col: 86, offset: 8963},
+									pos:  position{line: 301, col: 82, offset: 8919},
 									name: "__",
 								},
 								&litMatcher{
-									pos:        position{line: 299, col: 89, offset: 8966},
+									pos:        position{line: 301, col: 85, offset: 8922},
 									val:        ")",
 									ignoreCase: false,
 								},
@@ -2871,24 +2888,165 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "FunctionCall",
+			pos:  position{line: 305, col: 1, offset: 8990},
+			expr: &actionExpr{
+				pos: position{line: 305, col: 16, offset: 9005},
+				run: (*parser).callonFunctionCall1,
+				expr: &seqExpr{
+					pos: position{line: 305, col: 16, offset: 9005},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 305, col: 16, offset: 9005},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 305, col: 21, offset: 9010},
+								name: "IdentifierName",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 305, col: 36, offset: 9025},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 305, col: 39, offset: 9028},
+							val:        "(",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 305, col: 43, offset: 9032},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 305, col: 46, offset: 9035},
+							label: "args",
+							expr: &zeroOrOneExpr{
+								pos: position{line: 305, col: 51, offset: 9040},
+								expr: &ruleRefExpr{
+									pos:  position{line: 305, col: 51, offset: 9040},
+									name: "FunctionArgs",
+								},
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 305, col: 65, offset: 9054},
+							name: "__",
+						},
+						&litMatcher{
+							pos:        position{line: 305, col: 68, offset: 9057},
+							val:        ")",
+							ignoreCase: false,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionArgs",
+			pos:  position{line: 309, col: 1, offset: 9106},
+			expr: &actionExpr{
+				pos: position{line: 309, col: 16, offset: 9121},
+				run: (*parser).callonFunctionArgs1,
+				expr: &seqExpr{
+					pos: position{line: 309, col: 16, offset: 9121},
+					exprs: []interface{}{
+						&labeledExpr{
+							pos:   position{line: 309, col: 16, offset: 9121},
+							label: "first",
+							expr: &ruleRefExpr{
+								pos:  position{line: 309, col: 22, offset: 9127},
+								name: "FunctionArg",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 309, col: 34, offset: 9139},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 309, col: 37, offset: 9142},
+							label: "rest",
+							expr: &zeroOrMoreExpr{
+								pos: position{line: 309, col: 42, offset: 9147},
+								expr: &ruleRefExpr{
+									pos:  position{line: 309, col: 42, offset: 9147},
+									name: "FunctionArgsRest",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionArgsRest",
+			pos:  position{line: 313, col: 1, offset: 9208},
+			expr: &actionExpr{
+				pos: position{line: 313, col: 20, offset: 9227},
+				run: (*parser).callonFunctionArgsRest1,
+				expr: &seqExpr{
+					pos: position{line: 313, col: 20, offset: 9227},
+					exprs: []interface{}{
+						&litMatcher{
+							pos:        position{line: 313, col: 20, offset: 9227},
+							val:        ",",
+							ignoreCase: false,
+						},
+						&ruleRefExpr{
+							pos:  position{line: 313, col: 24, offset: 9231},
+							name: "__",
+						},
+						&labeledExpr{
+							pos:   position{line: 313, col: 27, offset: 9234},
+							label: "arg",
+							expr: &ruleRefExpr{
+								pos:  position{line: 313, col: 31, offset: 9238},
+								name: "FunctionArg",
+							},
+						},
+						&ruleRefExpr{
+							pos:  position{line: 313, col: 43, offset: 9250},
+							name: "__",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "FunctionArg",
+			pos:  position{line: 317, col: 1, offset: 9278},
+			expr: &choiceExpr{
+				pos: position{line: 317, col: 15, offset: 9292},
+				alternatives: []interface{}{
+					&ruleRefExpr{
+						pos:  position{line: 317, col: 15, offset: 9292},
+						name: "Number",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 317, col: 24, offset: 9301},
+						name: "Expression",
+					},
+				},
+			},
+		},
 		{
 			name: "__",
-			pos:  position{line: 303, col: 1, offset: 9046},
+			pos:  position{line: 319, col: 1, offset: 9313},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 303, col: 6, offset: 9051},
+				pos: position{line: 319, col: 6, offset: 9318},
 				expr: &choiceExpr{
-					pos: position{line: 303, col: 8, offset: 9053},
+					pos: position{line: 319, col: 8, offset: 9320},
 					alternatives: []interface{}{
 						&ruleRefExpr{
-							pos:  position{line: 303, col: 8, offset: 9053},
+							pos:  position{line: 319, col: 8, offset: 9320},
 							name: "Whitespace",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 303, col: 21, offset: 9066},
+							pos:  position{line: 319, col: 21, offset: 9333},
 							name: "EOL",
 						},
 						&ruleRefExpr{
-							pos:  position{line: 303, col: 27, offset: 9072},
+							pos:  position{line: 319, col: 27, offset: 9339},
 							name: "Comment",
 						},
 					},
@@ -2897,20 +3055,20 @@ var g = &grammar{
 		},
 		{
 			name: "_",
-			pos:  position{line: 304, col: 1, offset: 9083},
+			pos:  position{line: 320, col: 1, offset: 9350},
 			expr: &zeroOrMoreExpr{
-				pos: position{line: 304, col: 5, offset: 9087},
+				pos: position{line: 320, col: 5, offset: 9354},
 				expr: &ruleRefExpr{
-					pos:  position{line: 304, col: 5, offset: 9087},
+					pos:  position{line: 320, col: 5, offset: 9354},
 					name: "Whitespace",
 				},
 			},
 		},
 		{
 			name: "Whitespace",
-			pos:  position{line: 306, col: 1, offset: 9100},
+			pos:  position{line: 322, col: 1, offset: 9367},
 			expr: &charClassMatcher{
-				pos:        position{line: 306, col: 14, offset: 9113},
+				pos:        position{line: 322, col: 14, offset: 9380},
 				val:        "[ \\t\\r]",
 				chars:      []rune{' ', '\t', '\r'},
 				ignoreCase: false,
@@ -2919,62 +3077,62 @@ var g = &grammar{
 		},
 		{
 			name: "EOL",
-			pos:  position{line: 307, col: 1, offset: 9121},
+			pos:  position{line: 323, col: 1, offset: 9388},
 			expr: &litMatcher{
-				pos:        position{line: 307, col: 7, offset: 9127},
+				pos:        position{line: 323, col: 7, offset: 9394},
 				val:        "\n",
 				ignoreCase: false,
 			},
 		},
 		{
 			name: "EOS",
-			pos:  position{line: 308, col: 1, offset: 9132},
+			pos:  position{line: 324, col: 1, offset: 9399},
 			expr: &choiceExpr{
-				pos: position{line: 308, col: 7, offset: 9138},
+				pos: position{line: 324, col: 7, offset: 9405},
 				alternatives: []interface{}{
 					&seqExpr{
-						pos: position{line: 308, col: 7, offset: 9138},
+						pos: position{line: 324, col: 7, offset: 9405},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 308, col: 7, offset: 9138},
+								pos:  position{line: 324, col: 7, offset: 9405},
 								name: "__",
 							},
 							&litMatcher{
-								pos:        position{line: 308, col: 10, offset: 9141},
+								pos:        position{line: 324, col: 10, offset: 9408},
 								val:        ";",
 								ignoreCase: false,
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 308, col: 16, offset: 9147},
+						pos: position{line: 324, col: 16, offset: 9414},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 308, col: 16, offset: 9147},
+								pos:  position{line: 324, col: 16, offset: 9414},
 								name: "_",
 							},
 							&zeroOrOneExpr{
-								pos: position{line: 308, col: 18, offset: 9149},
+								pos: position{line: 324, col: 18, offset: 9416},
 								expr: &ruleRefExpr{
-									pos:  position{line: 308, col: 18, offset: 9149},
+									pos:  position{line: 324, col: 18, offset: 9416},
 									name: "SingleLineComment",
 								},
 							},
 							&ruleRefExpr{
-								pos:  position{line: 308, col: 37, offset: 9168},
+								pos:  position{line: 324, col: 37, offset: 9435},
 								name: "EOL",
 							},
 						},
 					},
 					&seqExpr{
-						pos: position{line: 308, col: 43, offset: 9174},
+						pos: position{line: 324, col: 43, offset: 9441},
 						exprs: []interface{}{
 							&ruleRefExpr{
-								pos:  position{line: 308, col: 43, offset: 9174},
+								pos:  position{line: 324, col: 43, offset: 9441},
 								name: "__",
 							},
 							&ruleRefExpr{
-								pos:  position{line: 308, col: 46, offset: 9177},
+								pos:  position{line: 324, col: 46, offset: 9444},
 								name: "EOF",
 							},
 						},
@@ -2984,11 +3142,11 @@ var g = &grammar{
 		},
 		{
 			name: "EOF",
-			pos:  position{line: 310, col: 1, offset: 9182},
+			pos:  position{line: 326, col: 1, offset: 9449},
 			expr: &notExpr{
-				pos: position{line: 310, col: 7, offset: 9188},
+				pos: position{line: 326, col: 7, offset: 9455},
 				expr: &anyMatcher{
-					line: 310, col: 8, offset: 9189,
+					line: 326, col: 8, offset: 9456,
 				},
 			},
 		},
@@ -3555,7 +3713,7 @@ func (p *parser) callonAggregateWithout1() (interface{}, error) {
 func (c *current) onAggregateExpression2(op, param, vector, group interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*StringLiteral)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression2() (interface{}, error) {
@@ -3567,7 +3725,7 @@ func (p *parser) callonAggregateExpression2() (interface{}, error) {
 func (c *current) onAggregateExpression22(op, group, param, vector interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*StringLiteral)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression22() (interface{}, error) {
@@ -3579,7 +3737,7 @@ func (p *parser) callonAggregateExpression22() (interface{}, error) {
 func (c *current) onAggregateExpression42(op, param, vector, group interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*Number)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression42() (interface{}, error) {
@@ -3591,7 +3749,7 @@ func (p *parser) callonAggregateExpression42() (interface{}, error) {
 func (c *current) onAggregateExpression62(op, group, param, vector interface{}) (interface{}, error) {
 	oper := op.(*Operator)
 	oper.Arg = param.(*Number)
-	return NewAggregateExpr(oper, vector.(*Selector), group)
+	return NewAggregateExpr(oper, vector, group)
 }
 
 func (p *parser) callonAggregateExpression62() (interface{}, error) {
@@ -3601,7 +3759,7 @@ func (p *parser) callonAggregateExpression62() (interface{}, error) {
 }
 
 func (c *current) onAggregateExpression82(op, vector, group interface{}) (interface{}, error) {
-	return NewAggregateExpr(op.(*Operator), vector.(*Selector), group)
+	return NewAggregateExpr(op.(*Operator), vector, group)
 }
 
 func (p *parser) callonAggregateExpression82() (interface{}, error) {
@@ -3611,7 +3769,7 @@ func (p *parser) callonAggregateExpression82() (interface{}, error) {
 }
 
 func (c *current) onAggregateExpression97(op, group, vector interface{}) (interface{}, error) {
-	return NewAggregateExpr(op.(*Operator), vector.(*Selector), group)
+	return NewAggregateExpr(op.(*Operator), vector, group)
 }
 
 func (p *parser) callonAggregateExpression97() (interface{}, error) {
@@ -3620,6 +3778,36 @@ func (p *parser) callonAggregateExpression97() (interface{}, error) {
 	return p.cur.onAggregateExpression97(stack["op"], stack["group"], stack["vector"])
 }
 
+func (c *current) onFunctionCall1(name, args interface{}) (interface{}, error) {
+	return NewCall(name.(string), args)
+}
+
+func (p *parser) callonFunctionCall1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunctionCall1(stack["name"], stack["args"])
+}
+
+func (c *current) onFunctionArgs1(first, rest interface{}) (interface{}, error) {
+	return NewArgs(first.(Arg), rest)
+}
+
+func (p *parser) callonFunctionArgs1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunctionArgs1(stack["first"], stack["rest"])
+}
+
+func (c *current) onFunctionArgsRest1(arg interface{}) (interface{}, error) {
+	return arg, nil
+}
+
+func (p *parser) callonFunctionArgsRest1() (interface{}, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onFunctionArgsRest1(stack["arg"])
+}
+
 var (
 	// errNoRule is returned when the grammar to parse has no rule.
 	errNoRule = errors.New("grammar has no rule")
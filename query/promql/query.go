@@ -26,3 +26,59 @@ func Build(promql string, opts ...Option) (*flux.Spec, error) {
 	}
 	return builder.QuerySpec()
 }
+
+// BuildFlux returns the Flux query evaluating the PromQL query at every step
+// between the start and end of the configuration.
+//
+// The samples of a metric are read from the measurement named after the
+// metric. Every table of the result holds the samples of a series at the
+// evaluation times, its group key holding the labels of the series.
+func BuildFlux(promql string, config Config, opts ...Option) (string, error) {
+	if err := config.validate(); err != nil {
+		return "", err
+	}
+	parsed, err := ParsePromQL(promql, opts...)
+	if err != nil {
+		return "", err
+	}
+	expr, ok := parsed.(Arg)
+	if !ok {
+		return "", fmt.Errorf("unable to build flux as %T is not an expression", parsed)
+	}
+	return newTranspiler(config).transpile(expr)
+}
+
+// ParseError is a syntax error at a position of a PromQL query.
+type ParseError struct {
+	Line   int
+	Column int
+	Offset int
+	Msg    string
+}
+
+// ParseErrors returns the syntax errors of a PromQL query.
+func ParseErrors(promql string) []ParseError {
+	_, err := ParsePromQL(promql)
+	if err == nil {
+		return nil
+	}
+	list, ok := err.(errList)
+	if !ok {
+		return []ParseError{{Msg: err.Error()}}
+	}
+	errs := make([]ParseError, 0, len(list))
+	for _, err := range list {
+		pe, ok := err.(*parserError)
+		if !ok {
+			errs = append(errs, ParseError{Msg: err.Error()})
+			continue
+		}
+		errs = append(errs, ParseError{
+			Line:   pe.pos.line,
+			Column: pe.pos.col,
+			Offset: pe.pos.offset,
+			Msg:    pe.Inner.Error(),
+		})
+	}
+	return errs
+}
@@ -335,6 +335,69 @@ func TestParsePromQL(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:   "function call over a range",
+			promql: `rate(http_requests_total{job="api"}[5m] offset 1h)`,
+			want: &Call{
+				Func: "rate",
+				Args: []Arg{
+					&Selector{
+						Name:   "http_requests_total",
+						Range:  5 * time.Minute,
+						Offset: time.Hour,
+						LabelMatchers: []*LabelMatcher{
+							{
+								Name: "job",
+								Kind: Equal,
+								Value: &StringLiteral{
+									String: "api",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "function call with nested aggregation",
+			promql: `histogram_quantile(0.9, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`,
+			want: &Call{
+				Func: "histogram_quantile",
+				Args: []Arg{
+					&Number{
+						Val: 0.9,
+					},
+					&AggregateExpr{
+						Op: &Operator{
+							Kind: SumKind,
+						},
+						Expr: &Call{
+							Func: "rate",
+							Args: []Arg{
+								&Selector{
+									Name:  "http_request_duration_seconds_bucket",
+									Range: 5 * time.Minute,
+								},
+							},
+						},
+						Aggregate: &Aggregate{
+							By: true,
+							Labels: []*Identifier{
+								{
+									Name: "le",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "function call missing closing parenthesis",
+			promql:  `rate(http_requests_total[5m]`,
+			wantErr: true,
+			want:    "",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,189 @@
+package promql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/iocounter"
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+)
+
+// Response is the JSON response of the Prometheus range query API.
+type Response struct {
+	Status    string `json:"status"`
+	Data      *Data  `json:"data,omitempty"`
+	ErrorType string `json:"errorType,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Data is the result of a successful range query.
+type Data struct {
+	ResultType string    `json:"resultType"`
+	Result     []*Series `json:"result"`
+}
+
+// Series is a time series of the result, identified by its labels.
+type Series struct {
+	Metric map[string]string `json:"metric"`
+	Values []Sample          `json:"values"`
+}
+
+// Sample is the value of a series at an evaluation time, encoded as a pair
+// of the unix time in seconds and the value formatted as a string.
+type Sample struct {
+	Time  int64 // Time is the unix time in nanoseconds.
+	Value float64
+}
+
+func (s Sample) MarshalJSON() ([]byte, error) {
+	// Prometheus timestamps have a millisecond precision.
+	ts := strconv.FormatFloat(float64(s.Time/1e6)/1e3, 'f', -1, 64)
+	return []byte("[" + ts + `,"` + formatValue(s.Value) + `"]`), nil
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// MultiResultEncoder encodes results as the JSON response of the Prometheus
+// range query API.
+type MultiResultEncoder struct{}
+
+// Encode writes the results of a transpiled PromQL query as a matrix.
+//
+// The string columns of the group key of a table are the labels of a series,
+// the _measurement column holding the metric name, and the _time and _value
+// columns hold its samples. The _field column is ignored. Tables with the
+// same labels are merged into a single series.
+func (e *MultiResultEncoder) Encode(w io.Writer, results flux.ResultIterator) (int64, error) {
+	wc := &iocounter.Writer{Writer: w}
+
+	series := make(map[string]*Series)
+	err := e.decodeResults(results, series)
+	if err == nil {
+		err = results.Err()
+	}
+	if err != nil {
+		resp := Response{
+			Status:    "error",
+			ErrorType: "execution",
+			Error:     err.Error(),
+		}
+		if err := json.NewEncoder(wc).Encode(resp); err != nil {
+			return wc.Count(), err
+		}
+		return wc.Count(), nil
+	}
+
+	keys := make([]string, 0, len(series))
+	for k := range series {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	data := &Data{
+		ResultType: "matrix",
+		Result:     make([]*Series, 0, len(keys)),
+	}
+	for _, k := range keys {
+		s := series[k]
+		if len(s.Values) == 0 {
+			continue
+		}
+		sort.SliceStable(s.Values, func(i, j int) bool { return s.Values[i].Time < s.Values[j].Time })
+		data.Result = append(data.Result, s)
+	}
+	err = json.NewEncoder(wc).Encode(Response{Status: "success", Data: data})
+	return wc.Count(), err
+}
+
+func (e *MultiResultEncoder) decodeResults(results flux.ResultIterator, series map[string]*Series) error {
+	defer results.Release()
+	for results.More() {
+		if err := results.Next().Tables().Do(func(tbl flux.Table) error {
+			metric := make(map[string]string)
+			for j, c := range tbl.Key().Cols() {
+				if c.Type != flux.TString || c.Label == "_field" {
+					continue
+				}
+				label := c.Label
+				if label == "_measurement" {
+					label = remote.MetricNameLabel
+				}
+				metric[label] = tbl.Key().Value(j).Str()
+			}
+
+			key := seriesKey(metric)
+			s, ok := series[key]
+			if !ok {
+				s = &Series{Metric: metric, Values: []Sample{}}
+				series[key] = s
+			}
+
+			timeIdx := execute.ColIdx(execute.DefaultTimeColLabel, tbl.Cols())
+			valueIdx := execute.ColIdx(execute.DefaultValueColLabel, tbl.Cols())
+			if timeIdx < 0 || valueIdx < 0 {
+				tbl.Done()
+				return nil
+			}
+			return tbl.Do(func(cr flux.ColReader) error {
+				times := cr.Times(timeIdx)
+				for i := 0; i < cr.Len(); i++ {
+					if !times.IsValid(i) {
+						continue
+					}
+					v, ok, err := floatValue(cr, valueIdx, i)
+					if err != nil {
+						return err
+					}
+					if ok {
+						s.Values = append(s.Values, Sample{Time: times.Value(i), Value: v})
+					}
+				}
+				return nil
+			})
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// floatValue returns the value of the numeric column j at row i.
+func floatValue(cr flux.ColReader, j, i int) (float64, bool, error) {
+	switch typ := cr.Cols()[j].Type; typ {
+	case flux.TFloat:
+		vs := cr.Floats(j)
+		return vs.Value(i), vs.IsValid(i), nil
+	case flux.TInt:
+		vs := cr.Ints(j)
+		return float64(vs.Value(i)), vs.IsValid(i), nil
+	case flux.TUInt:
+		vs := cr.UInts(j)
+		return float64(vs.Value(i)), vs.IsValid(i), nil
+	default:
+		return 0, false, fmt.Errorf("unsupported value column type: %s", typ)
+	}
+}
+
+func seriesKey(metric map[string]string) string {
+	labels := make([]string, 0, len(metric))
+	for k, v := range metric {
+		labels = append(labels, strconv.Quote(k)+"="+strconv.Quote(v))
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ",")
+}
@@ -0,0 +1,141 @@
+package promql_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/v2/query/promql"
+)
+
+func TestMultiResultEncoder_Encode(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		in   flux.ResultIterator
+		out  string
+	}{
+		{
+			name: "Merged series",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{
+						{
+							KeyCols: []string{"_measurement", "_field", "job"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "_field", Type: flux.TString},
+								{Label: "job", Type: flux.TString},
+								{Label: "_value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2020-01-01T00:00:10Z"), "up", "value", "a", 2.5},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "job"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "job", Type: flux.TString},
+								{Label: "_value", Type: flux.TFloat},
+							},
+							Data: [][]interface{}{
+								{ts("2020-01-01T00:00:05.5Z"), "up", "a", 1.0},
+							},
+						},
+						{
+							KeyCols: []string{"_measurement", "job"},
+							ColMeta: []flux.ColMeta{
+								{Label: "_time", Type: flux.TTime},
+								{Label: "_measurement", Type: flux.TString},
+								{Label: "job", Type: flux.TString},
+								{Label: "_value", Type: flux.TInt},
+							},
+							Data: [][]interface{}{
+								{ts("2020-01-01T00:00:05Z"), "up", "b", int64(3)},
+							},
+						},
+					},
+				}},
+			),
+			out: `{"status":"success","data":{"resultType":"matrix","result":[` +
+				`{"metric":{"__name__":"up","job":"a"},"values":[[1577836805.5,"1"],[1577836810,"2.5"]]},` +
+				`{"metric":{"__name__":"up","job":"b"},"values":[[1577836805,"3"]]}]}}`,
+		},
+		{
+			name: "Empty",
+			in: flux.NewSliceResultIterator(
+				[]flux.Result{&executetest.Result{
+					Nm: "_result",
+					Tbls: []*executetest.Table{{
+						KeyCols:   []string{"job"},
+						KeyValues: []interface{}{"a"},
+						ColMeta: []flux.ColMeta{
+							{Label: "_time", Type: flux.TTime},
+							{Label: "job", Type: flux.TString},
+							{Label: "_value", Type: flux.TFloat},
+						},
+					}},
+				}},
+			),
+			out: `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+		},
+		{
+			name: "Error",
+			in:   &resultErrorIterator{Error: "expected"},
+			out:  `{"status":"error","errorType":"execution","error":"expected"}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// Add expected newline to end of output
+			tt.out += "\n"
+
+			var buf bytes.Buffer
+			enc := new(promql.MultiResultEncoder)
+			n, err := enc.Encode(&buf, tt.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got, exp := buf.String(), tt.out; got != exp {
+				t.Fatalf("unexpected output:\nexp=%s\ngot=%s", exp, got)
+			}
+			if g, w := n, int64(len(tt.out)); g != w {
+				t.Errorf("unexpected encoding count -want/+got:\n%s", cmp.Diff(w, g))
+			}
+		})
+	}
+}
+
+type resultErrorIterator struct {
+	Error string
+}
+
+func (*resultErrorIterator) Statistics() flux.Statistics {
+	return flux.Statistics{}
+}
+
+func (*resultErrorIterator) Release()          {}
+func (*resultErrorIterator) More() bool        { return false }
+func (*resultErrorIterator) Next() flux.Result { panic("no results") }
+
+func (ri *resultErrorIterator) Err() error {
+	return errors.New(ri.Error)
+}
+
+// ts takes an RFC3339 time string and returns an execute.Time from it using the unix timestamp.
+func ts(s string) execute.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		panic(err)
+	}
+	return execute.Time(t.UnixNano())
+}
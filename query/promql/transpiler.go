@@ -0,0 +1,401 @@
+package promql
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/influxdata/influxdb/v2/prometheus/remote"
+)
+
+const (
+	// DefaultLookbackDelta is how far back an instant vector selector looks
+	// for the latest sample of a series.
+	DefaultLookbackDelta = 5 * time.Minute
+
+	// MaxPoints is the maximum number of evaluation times of a range query.
+	MaxPoints = 11000
+)
+
+// Config configures the translation of a PromQL query to Flux.
+type Config struct {
+	// Bucket holds the samples written by the Prometheus remote_write endpoint.
+	Bucket string
+
+	// Start and End are the first and last evaluation times of the query,
+	// and Step the duration between evaluation times.
+	Start time.Time
+	End   time.Time
+	Step  time.Duration
+
+	// LookbackDelta defaults to DefaultLookbackDelta.
+	LookbackDelta time.Duration
+}
+
+// validate returns an error if the configuration does not describe a valid
+// range query, and sets the defaults of the configuration.
+func (c *Config) validate() error {
+	if c.Bucket == "" {
+		return fmt.Errorf("bucket is required")
+	}
+	if c.End.Before(c.Start) {
+		return fmt.Errorf("end time must not be before start time")
+	}
+	if c.Step <= 0 {
+		return fmt.Errorf("zero or negative query resolution step widths are not accepted")
+	}
+	if c.End.Sub(c.Start)/c.Step >= MaxPoints {
+		return fmt.Errorf("exceeded maximum resolution of %d points per timeseries, try increasing the step", MaxPoints)
+	}
+	if c.LookbackDelta <= 0 {
+		c.LookbackDelta = DefaultLookbackDelta
+	}
+	return nil
+}
+
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"μs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// ParseDuration parses a duration of the Prometheus HTTP API, either a
+// number of seconds such as 15 or 0.5, or a PromQL duration such as 1m.
+func ParseDuration(s string) (time.Duration, error) {
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		d := secs * float64(time.Second)
+		if math.IsNaN(d) || math.IsInf(d, 0) || d > math.MaxInt64 || d < math.MinInt64 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(d), nil
+	}
+
+	i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	n, err := strconv.ParseInt(s[:i], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	unit, ok := durationUnits[s[i:]]
+	if !ok {
+		return 0, fmt.Errorf("invalid unit of duration %q", s)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// transpiler translates the parsed PromQL expressions of a range query to Flux.
+type transpiler struct {
+	Config
+	end time.Time
+}
+
+// newTranspiler returns the transpiler of the range query of the valid
+// configuration.
+func newTranspiler(config Config) *transpiler {
+	return &transpiler{
+		Config: config,
+		// The last evaluation time is aligned to the steps from the start.
+		end: config.Start.Add(config.End.Sub(config.Start) / config.Step * config.Step),
+	}
+}
+
+// transpile returns the Flux query of the expression.
+func (t *transpiler) transpile(expr Arg) (string, error) {
+	src, err := t.expr(expr)
+	if err != nil {
+		return "", err
+	}
+	return "import \"internal/promql\"\n\n" + src, nil
+}
+
+func (t *transpiler) expr(expr Arg) (string, error) {
+	switch expr := expr.(type) {
+	case *Selector:
+		if expr.Range != 0 {
+			return "", fmt.Errorf("range vector selector %s must be the argument of a function", expr.Name)
+		}
+		return t.selector(expr, t.LookbackDelta, []string{"last()"}, false)
+	case *Call:
+		return t.call(expr)
+	case *AggregateExpr:
+		return t.aggregate(expr)
+	default:
+		return "", fmt.Errorf("unable to transpile %T expression", expr)
+	}
+}
+
+// selector returns the Flux query applying the functions fns to the samples
+// of each series within the window of each evaluation time. The window ends
+// at the evaluation time, inclusive.
+func (t *transpiler) selector(s *Selector, window time.Duration, fns []string, dropName bool) (string, error) {
+	pred, err := t.predicate(s)
+	if err != nil {
+		return "", err
+	}
+
+	// Windows end at the nanosecond after the evaluation time, and the
+	// evaluation times are shifted back by the offset of the selector.
+	start := s.Offset.Nanoseconds() * -1
+	first := t.Start.Add(time.Duration(start))
+	last := t.end.Add(time.Duration(start))
+	offset := first.Add(1).UnixNano() % t.Step.Nanoseconds()
+	if offset < 0 {
+		offset += t.Step.Nanoseconds()
+	}
+
+	drop := []string{"_start", "_stop", "_field"}
+	if dropName {
+		drop = append(drop, "_measurement")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "from(bucket: %s)\n", fluxString(t.Bucket))
+	pipe(&b, "range(start: %s, stop: %s)", fluxTime(first.Add(1-window)), fluxTime(last.Add(1)))
+	pipe(&b, "filter(fn: (r) => %s)", pred)
+	pipe(&b, "window(every: %s, period: %s, offset: %s, createEmpty: false)",
+		fluxDuration(t.Step), fluxDuration(window), fluxDuration(time.Duration(offset)))
+	// Windows clipped by the range do not end after an evaluation time.
+	pipe(&b, "filter(fn: (r) => int(v: r._stop) - int(v: r._start) == %d)", window.Nanoseconds())
+	for _, fn := range fns {
+		pipe(&b, "%s", fn)
+	}
+	pipe(&b, `duplicate(column: "_stop", as: "_time")`)
+	pipe(&b, `timeShift(duration: %s, columns: ["_time"])`, fluxDuration(s.Offset-1))
+	pipe(&b, "drop(columns: %s)", fluxStrings(drop))
+	return b.String(), nil
+}
+
+func (t *transpiler) predicate(s *Selector) (string, error) {
+	exprs := []string{
+		"r._measurement == " + fluxString(s.Name),
+		"r._field == " + fluxString(remote.FieldName),
+	}
+	for _, m := range s.LabelMatchers {
+		var value string
+		switch v := m.Value.Value().(type) {
+		case string:
+			value = v
+		case float64:
+			value = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return "", fmt.Errorf("unsupported value of label matcher %s", m.Name)
+		}
+
+		column := fluxMember(m.Name)
+		if m.Name == remote.MetricNameLabel {
+			column = "r._measurement"
+		}
+
+		var (
+			expr         string
+			matchesEmpty bool
+		)
+		switch m.Kind {
+		case Equal, NotEqual:
+			op := "=="
+			if m.Kind == NotEqual {
+				op = "!="
+			}
+			expr = column + " " + op + " " + fluxString(value)
+			matchesEmpty = (value == "") == (m.Kind == Equal)
+		case RegexMatch, RegexNoMatch:
+			// Prometheus regular expressions are fully anchored.
+			value = "^(?:" + value + ")$"
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return "", fmt.Errorf("invalid regular expression of label matcher %s: %v", m.Name, err)
+			}
+			op := "=~"
+			if m.Kind == RegexNoMatch {
+				op = "!~"
+			}
+			expr = column + " " + op + " " + fluxRegex(value)
+			matchesEmpty = re.MatchString("") == (m.Kind == RegexMatch)
+		default:
+			return "", fmt.Errorf("unknown label match kind %d", m.Kind)
+		}
+
+		// A series without the label matches as if its value was empty.
+		if matchesEmpty {
+			expr = "(not exists " + column + " or " + expr + ")"
+		} else {
+			expr = "(exists " + column + " and " + expr + ")"
+		}
+		exprs = append(exprs, expr)
+	}
+	return strings.Join(exprs, " and "), nil
+}
+
+func (t *transpiler) call(c *Call) (string, error) {
+	switch c.Func {
+	case "rate", "increase", "irate":
+		if len(c.Args) != 1 {
+			return "", fmt.Errorf("expected 1 argument in call to %s, got %d", c.Func, len(c.Args))
+		}
+		s, ok := c.Args[0].(*Selector)
+		if !ok || s.Range == 0 {
+			return "", fmt.Errorf("expected a range vector selector in call to %s", c.Func)
+		}
+
+		// The builtins compute the rates over the window of each evaluation
+		// time the way Prometheus does, extrapolating to the window bounds.
+		fn := fmt.Sprintf("promql.extrapolatedRate(isCounter: true, isRate: %t)", c.Func == "rate")
+		if c.Func == "irate" {
+			fn = "promql.instantRate(isRate: true)"
+		}
+		return t.selector(s, s.Range, []string{fn}, true)
+
+	case "histogram_quantile":
+		if len(c.Args) != 2 {
+			return "", fmt.Errorf("expected 2 arguments in call to %s, got %d", c.Func, len(c.Args))
+		}
+		q, ok := c.Args[0].(*Number)
+		if !ok {
+			return "", fmt.Errorf("expected a number as first argument in call to %s", c.Func)
+		}
+		v, err := t.expr(c.Args[1])
+		if err != nil {
+			return "", err
+		}
+
+		var b strings.Builder
+		b.WriteString(v)
+		// Every table holds the buckets of a histogram at an evaluation time.
+		pipe(&b, `group(columns: ["_measurement", "le", "_value"], mode: "except")`)
+		pipe(&b, "promql.promHistogramQuantile(quantile: %s)", fluxFloat(q.Val))
+		pipe(&b, `group(columns: ["_time", "_value"], mode: "except")`)
+		return b.String(), nil
+
+	default:
+		return "", fmt.Errorf("unknown function %s", c.Func)
+	}
+}
+
+var aggregateFuncs = map[OperatorKind]string{
+	SumKind:   "sum()",
+	MinKind:   "min()",
+	MaxKind:   "max()",
+	AvgKind:   "mean()",
+	StdevKind: `stddev(mode: "population")`,
+	CountKind: "count()",
+}
+
+func (t *transpiler) aggregate(a *AggregateExpr) (string, error) {
+	fn, ok := aggregateFuncs[a.Op.Kind]
+	if !ok {
+		return "", fmt.Errorf("unsupported aggregation operator, expected one of sum, min, max, avg, stddev or count")
+	}
+
+	var inner Arg = a.Selector
+	if a.Selector == nil {
+		inner = a.Expr
+	}
+	v, err := t.expr(inner)
+	if err != nil {
+		return "", err
+	}
+
+	var labels []string
+	if a.Aggregate != nil {
+		for _, l := range a.Aggregate.Labels {
+			name := l.Name
+			if name == remote.MetricNameLabel {
+				name = "_measurement"
+			}
+			labels = append(labels, name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(v)
+	if a.Aggregate != nil && a.Aggregate.Without {
+		pipe(&b, "drop(columns: %s)", fluxStrings(append([]string{"_measurement"}, labels...)))
+		pipe(&b, `group(columns: ["_value"], mode: "except")`)
+		pipe(&b, "%s", fn)
+		pipe(&b, `group(columns: ["_time", "_value"], mode: "except")`)
+	} else {
+		pipe(&b, "keep(columns: %s)", fluxStrings(append([]string{"_time", "_value"}, labels...)))
+		pipe(&b, "group(columns: %s)", fluxStrings(append([]string{"_time"}, labels...)))
+		pipe(&b, "%s", fn)
+		pipe(&b, "group(columns: %s)", fluxStrings(labels))
+	}
+	return b.String(), nil
+}
+
+// pipe writes a call of a Flux function to the pipeline in b.
+func pipe(b *strings.Builder, format string, args ...interface{}) {
+	b.WriteString("    |> ")
+	fmt.Fprintf(b, format, args...)
+	b.WriteByte('\n')
+}
+
+func fluxString(s string) string {
+	s = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "${", `\${`).Replace(s)
+	return `"` + s + `"`
+}
+
+func fluxStrings(ss []string) string {
+	quoted := make([]string, len(ss))
+	for i, s := range ss {
+		quoted[i] = fluxString(s)
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+func fluxRegex(re string) string {
+	re = strings.NewReplacer("/", `\/`, "\n", `\n`).Replace(re)
+	return "/" + re + "/"
+}
+
+func fluxMember(label string) string {
+	return "r[" + fluxString(label) + "]"
+}
+
+func fluxFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+	return s
+}
+
+func fluxTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+var fluxDurationUnits = []struct {
+	unit time.Duration
+	name string
+}{
+	{time.Hour, "h"},
+	{time.Minute, "m"},
+	{time.Second, "s"},
+	{time.Millisecond, "ms"},
+	{time.Microsecond, "us"},
+}
+
+// fluxDuration returns the Flux duration literal of d in its largest exact unit.
+func fluxDuration(d time.Duration) string {
+	if d == 0 {
+		return "0s"
+	}
+	for _, u := range fluxDurationUnits {
+		if d%u.unit == 0 {
+			return strconv.FormatInt(int64(d/u.unit), 10) + u.name
+		}
+	}
+	return strconv.FormatInt(int64(d), 10) + "ns"
+}
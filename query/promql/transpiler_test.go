@@ -0,0 +1,272 @@
+package promql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBuildFlux(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := Config{
+		Bucket: "prometheus",
+		Start:  start,
+		End:    start.Add(time.Hour),
+		Step:   15 * time.Second,
+	}
+
+	tests := []struct {
+		name   string
+		promql string
+		want   string
+	}{
+		{
+			name:   "instant vector selector",
+			promql: `up{job="node", instance=~"local.*", env!=""}`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:55:00.000000001Z, stop: 2020-01-01T01:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "up" and r._field == "value" and (exists r["job"] and r["job"] == "node") and (exists r["instance"] and r["instance"] =~ /^(?:local.*)$/) and (exists r["env"] and r["env"] != ""))
+    |> window(every: 15s, period: 5m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> last()
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field"])
+`,
+		},
+		{
+			name:   "matchers matching a missing label",
+			promql: `up{env="", dc!~"us-.*"}`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:55:00.000000001Z, stop: 2020-01-01T01:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "up" and r._field == "value" and (not exists r["env"] or r["env"] == "") and (not exists r["dc"] or r["dc"] !~ /^(?:us-.*)$/))
+    |> window(every: 15s, period: 5m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> last()
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field"])
+`,
+		},
+		{
+			name:   "selector with offset",
+			promql: `up offset 5m`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:50:00.000000001Z, stop: 2020-01-01T00:55:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "up" and r._field == "value")
+    |> window(every: 15s, period: 5m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> last()
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: 299999999999ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field"])
+`,
+		},
+		{
+			name:   "rate",
+			promql: `rate(http_requests_total[5m])`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:55:00.000000001Z, stop: 2020-01-01T01:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "http_requests_total" and r._field == "value")
+    |> window(every: 15s, period: 5m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> promql.extrapolatedRate(isCounter: true, isRate: true)
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field", "_measurement"])
+`,
+		},
+		{
+			name:   "increase",
+			promql: `increase(http_requests_total[10m])`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:50:00.000000001Z, stop: 2020-01-01T01:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "http_requests_total" and r._field == "value")
+    |> window(every: 15s, period: 10m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 600000000000)
+    |> promql.extrapolatedRate(isCounter: true, isRate: false)
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field", "_measurement"])
+`,
+		},
+		{
+			name:   "irate with offset",
+			promql: `irate(http_requests_total{job="api"}[1m] offset 1h)`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T22:59:00.000000001Z, stop: 2020-01-01T00:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "http_requests_total" and r._field == "value" and (exists r["job"] and r["job"] == "api"))
+    |> window(every: 15s, period: 1m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 60000000000)
+    |> promql.instantRate(isRate: true)
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: 3599999999999ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field", "_measurement"])
+`,
+		},
+		{
+			name:   "histogram_quantile of an aggregation",
+			promql: `histogram_quantile(0.9, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:55:00.000000001Z, stop: 2020-01-01T01:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "http_request_duration_seconds_bucket" and r._field == "value")
+    |> window(every: 15s, period: 5m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> promql.extrapolatedRate(isCounter: true, isRate: true)
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field", "_measurement"])
+    |> keep(columns: ["_time", "_value", "le"])
+    |> group(columns: ["_time", "le"])
+    |> sum()
+    |> group(columns: ["le"])
+    |> group(columns: ["_measurement", "le", "_value"], mode: "except")
+    |> promql.promHistogramQuantile(quantile: 0.9)
+    |> group(columns: ["_time", "_value"], mode: "except")
+`,
+		},
+		{
+			name:   "aggregation without labels",
+			promql: `avg without (instance) (up)`,
+			want: `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:55:00.000000001Z, stop: 2020-01-01T01:00:00.000000001Z)
+    |> filter(fn: (r) => r._measurement == "up" and r._field == "value")
+    |> window(every: 15s, period: 5m, offset: 1ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> last()
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field"])
+    |> drop(columns: ["_measurement", "instance"])
+    |> group(columns: ["_value"], mode: "except")
+    |> mean()
+    |> group(columns: ["_time", "_value"], mode: "except")
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BuildFlux(tt.promql, config)
+			if err != nil {
+				t.Fatalf("BuildFlux() %s error = %v", tt.promql, err)
+			}
+			if got != tt.want {
+				t.Errorf("BuildFlux() %s -want/+got\n%s", tt.promql, cmp.Diff(tt.want, got))
+			}
+		})
+	}
+}
+
+func TestBuildFlux_UnalignedStart(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 7, 0, time.UTC)
+	got, err := BuildFlux(`up`, Config{
+		Bucket: "prometheus",
+		Start:  start,
+		End:    start.Add(time.Minute + 5*time.Second),
+		Step:   15 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The last evaluation time is the last step before the end.
+	want := `import "internal/promql"
+
+from(bucket: "prometheus")
+    |> range(start: 2019-12-31T23:55:07.000000001Z, stop: 2020-01-01T00:01:07.000000001Z)
+    |> filter(fn: (r) => r._measurement == "up" and r._field == "value")
+    |> window(every: 15s, period: 5m, offset: 7000000001ns, createEmpty: false)
+    |> filter(fn: (r) => int(v: r._stop) - int(v: r._start) == 300000000000)
+    |> last()
+    |> duplicate(column: "_stop", as: "_time")
+    |> timeShift(duration: -1ns, columns: ["_time"])
+    |> drop(columns: ["_start", "_stop", "_field"])
+`
+	if got != want {
+		t.Errorf("BuildFlux() -want/+got\n%s", cmp.Diff(want, got))
+	}
+}
+
+func TestBuildFlux_Errors(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	config := Config{
+		Bucket: "prometheus",
+		Start:  start,
+		End:    start.Add(time.Hour),
+		Step:   15 * time.Second,
+	}
+
+	tests := []struct {
+		name   string
+		promql string
+		config func(c *Config)
+	}{
+		{name: "syntax error", promql: `up{`},
+		{name: "range vector selector", promql: `up[5m]`},
+		{name: "rate of an instant vector", promql: `rate(up)`},
+		{name: "unknown function", promql: `foo(up)`},
+		{name: "unsupported aggregation", promql: `topk(5, up)`},
+		{name: "invalid regular expression", promql: `up{job=~"("}`},
+		{name: "missing bucket", promql: `up`, config: func(c *Config) { c.Bucket = "" }},
+		{name: "zero step", promql: `up`, config: func(c *Config) { c.Step = 0 }},
+		{name: "end before start", promql: `up`, config: func(c *Config) { c.End = start.Add(-time.Hour) }},
+		{name: "too many points", promql: `up`, config: func(c *Config) { c.Step = time.Millisecond }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := config
+			if tt.config != nil {
+				tt.config(&c)
+			}
+			if _, err := BuildFlux(tt.promql, c); err == nil {
+				t.Errorf("BuildFlux() %s expected error", tt.promql)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{s: "15", want: 15 * time.Second},
+		{s: "0.5", want: 500 * time.Millisecond},
+		{s: "30s", want: 30 * time.Second},
+		{s: "5m", want: 5 * time.Minute},
+		{s: "1d", want: 24 * time.Hour},
+		{s: "100ms", want: 100 * time.Millisecond},
+		{s: "", wantErr: true},
+		{s: "m", wantErr: true},
+		{s: "5x", wantErr: true},
+		{s: "1m30s", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseDuration() %q error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() %q = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
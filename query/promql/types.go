@@ -423,13 +423,26 @@ func (o *Operator) QuerySpec() (*flux.Operation, error) {
 	}
 }
 
+// AggregateExpr aggregates a vector selector, or the nested expression Expr.
 type AggregateExpr struct {
 	Op        *Operator  `json:"op,omitempty"`
 	Selector  *Selector  `json:"selector,omitempty"`
+	Expr      Arg        `json:"expr,omitempty"`
 	Aggregate *Aggregate `json:"aggregate,omitempty"`
 }
 
+func (a *AggregateExpr) Type() ArgKind {
+	return ExprKind
+}
+
+func (a *AggregateExpr) Value() interface{} {
+	return a.Op.Kind
+}
+
 func (a *AggregateExpr) QuerySpec() (*flux.Spec, error) {
+	if a.Selector == nil {
+		return nil, fmt.Errorf("unable to build a query spec of nested expressions")
+	}
 	spec, err := a.Selector.QuerySpec()
 	if err != nil {
 		return nil, err
@@ -472,10 +485,14 @@ func (a *AggregateExpr) QuerySpec() (*flux.Spec, error) {
 	return spec, nil
 }
 
-func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*AggregateExpr, error) {
+func NewAggregateExpr(op *Operator, vector interface{}, group interface{}) (*AggregateExpr, error) {
 	expr := &AggregateExpr{
-		Op:       op,
-		Selector: selector,
+		Op: op,
+	}
+	if selector, ok := vector.(*Selector); ok {
+		expr.Selector = selector
+	} else {
+		expr.Expr = vector.(Arg)
 	}
 	if group != nil {
 		expr.Aggregate = group.(*Aggregate)
@@ -483,6 +500,40 @@ func NewAggregateExpr(op *Operator, selector *Selector, group interface{}) (*Agg
 	return expr, nil
 }
 
+// Call is a call of a PromQL function such as rate or histogram_quantile.
+type Call struct {
+	Func string `json:"func,omitempty"`
+	Args []Arg  `json:"args,omitempty"`
+}
+
+func (c *Call) Type() ArgKind {
+	return ExprKind
+}
+
+func (c *Call) Value() interface{} {
+	return c.Func
+}
+
+func NewCall(fn string, args interface{}) (*Call, error) {
+	call := &Call{
+		Func: fn,
+	}
+	if args != nil {
+		call.Args = args.([]Arg)
+	}
+	return call, nil
+}
+
+func NewArgs(first Arg, rest interface{}) ([]Arg, error) {
+	args := []Arg{first}
+	for _, a := range toIfaceSlice(rest) {
+		if arg, ok := a.(Arg); ok {
+			args = append(args, arg)
+		}
+	}
+	return args, nil
+}
+
 type Comment struct {
 	Source string `json:"source,omitempty"`
 }
@@ -12,6 +12,21 @@ import (
 	"github.com/influxdata/influxdb/v2/kit/check"
 )
 
+var (
+	// ErrQueueFull is returned when the query queue has no room for the query.
+	ErrQueueFull = &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "queue length exceeded",
+	}
+
+	// ErrOrganizationQueueFull is returned when the queue of the organization
+	// of the query has no room for the query.
+	ErrOrganizationQueueFull = &influxdb.Error{
+		Code: influxdb.EInvalid,
+		Msg:  "organization queue length exceeded",
+	}
+)
+
 // QueryService represents a type capable of performing queries.
 type QueryService interface {
 	check.Checker
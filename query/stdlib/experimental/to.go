@@ -459,6 +459,9 @@ func (t *ToTransformation) writeTable(ctx context.Context, tbl flux.Table) error
 			}
 		}
 
+		if err := influxdb.AuthorizeWritePoints(ctx, points); err != nil {
+			return err
+		}
 		return t.buf.WritePoints(ctx, points)
 	})
 }
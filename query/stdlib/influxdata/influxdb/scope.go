@@ -0,0 +1,35 @@
+package influxdb
+
+import (
+	"context"
+
+	platform "github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/authorizer"
+	icontext "github.com/influxdata/influxdb/v2/context"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+)
+
+// restrictPredicate restricts the predicate of a read of the bucket to the
+// series that the authorizer of the query may read. Reads of queries without
+// an authorizer are not restricted.
+func restrictPredicate(ctx context.Context, orgID, bucketID platform.ID, pred *datatypes.Predicate) (*datatypes.Predicate, error) {
+	if _, err := icontext.GetAuthorizer(ctx); err != nil {
+		return pred, nil
+	}
+	scopes, err := authorizer.BucketScopes(ctx, platform.ReadAction, orgID, bucketID)
+	if err != nil {
+		return nil, err
+	}
+	return reads.RestrictPredicate(pred, scopes), nil
+}
+
+// AuthorizeWritePoints authorizes the authorizer of the query to write the
+// points. Points written by queries without an authorizer are not restricted.
+func AuthorizeWritePoints(ctx context.Context, points []models.Point) error {
+	if _, err := icontext.GetAuthorizer(ctx); err != nil {
+		return nil
+	}
+	return authorizer.AuthorizeWritePoints(ctx, points)
+}
@@ -180,6 +180,10 @@ func createReadFilterSource(s plan.ProcedureSpec, id execute.DatasetID, a execut
 	if err != nil {
 		return nil, err
 	}
+	filter, err := restrictPredicate(ctx, orgID, bucketID, spec.Filter)
+	if err != nil {
+		return nil, err
+	}
 
 	return ReadFilterSource(
 		id,
@@ -188,7 +192,7 @@ func createReadFilterSource(s plan.ProcedureSpec, id execute.DatasetID, a execut
 			OrganizationID: orgID,
 			BucketID:       bucketID,
 			Bounds:         *bounds,
-			Predicate:      spec.Filter,
+			Predicate:      filter,
 		},
 		a,
 	), nil
@@ -253,6 +257,10 @@ func createReadGroupSource(s plan.ProcedureSpec, id execute.DatasetID, a execute
 	if err != nil {
 		return nil, err
 	}
+	filter, err := restrictPredicate(ctx, orgID, bucketID, spec.Filter)
+	if err != nil {
+		return nil, err
+	}
 
 	return ReadGroupSource(
 		id,
@@ -262,7 +270,7 @@ func createReadGroupSource(s plan.ProcedureSpec, id execute.DatasetID, a execute
 				OrganizationID: orgID,
 				BucketID:       bucketID,
 				Bounds:         *bounds,
-				Predicate:      spec.Filter,
+				Predicate:      filter,
 			},
 			GroupMode:       query.ToGroupMode(spec.GroupMode),
 			GroupKeys:       spec.GroupKeys,
@@ -338,6 +346,10 @@ func createReadWindowAggregateSource(s plan.ProcedureSpec, id execute.DatasetID,
 	if err != nil {
 		return nil, err
 	}
+	filter, err := restrictPredicate(ctx, orgID, bucketID, spec.Filter)
+	if err != nil {
+		return nil, err
+	}
 
 	return ReadWindowAggregateSource(
 		id,
@@ -347,7 +359,7 @@ func createReadWindowAggregateSource(s plan.ProcedureSpec, id execute.DatasetID,
 				OrganizationID: orgID,
 				BucketID:       bucketID,
 				Bounds:         *bounds,
-				Predicate:      spec.Filter,
+				Predicate:      filter,
 			},
 			WindowEvery: spec.WindowEvery,
 			Aggregates:  spec.Aggregates,
@@ -373,6 +385,10 @@ func createReadTagKeysSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID,
 	if err != nil {
 		return nil, err
 	}
+	filter, err := restrictPredicate(ctx, orgID, bucketID, spec.Filter)
+	if err != nil {
+		return nil, err
+	}
 
 	bounds := a.StreamContext().Bounds()
 	return ReadTagKeysSource(
@@ -383,7 +399,7 @@ func createReadTagKeysSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID,
 				OrganizationID: orgID,
 				BucketID:       bucketID,
 				Bounds:         *bounds,
-				Predicate:      spec.Filter,
+				Predicate:      filter,
 			},
 		},
 		a,
@@ -437,6 +453,10 @@ func createReadTagValuesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID
 	if err != nil {
 		return nil, err
 	}
+	filter, err := restrictPredicate(ctx, orgID, bucketID, spec.Filter)
+	if err != nil {
+		return nil, err
+	}
 
 	bounds := a.StreamContext().Bounds()
 	return ReadTagValuesSource(
@@ -447,7 +467,7 @@ func createReadTagValuesSource(prSpec plan.ProcedureSpec, dsid execute.DatasetID
 				OrganizationID: orgID,
 				BucketID:       bucketID,
 				Bounds:         *bounds,
-				Predicate:      spec.Filter,
+				Predicate:      filter,
 			},
 			TagKey: spec.TagKey,
 		},
@@ -655,6 +655,9 @@ func writeTable(ctx context.Context, t *ToTransformation, tbl flux.Table) (err e
 			}
 		}
 
+		if err := AuthorizeWritePoints(ctx, points); err != nil {
+			return err
+		}
 		return t.buf.WritePoints(ctx, points)
 	})
 }
@@ -0,0 +1,124 @@
+package influxdb
+
+import (
+	"context"
+	"fmt"
+)
+
+// ErrRoleNotFound is the error msg for a missing role.
+const ErrRoleNotFound = "role not found"
+
+// ops for role error.
+const (
+	OpFindRoleByID = "FindRoleByID"
+	OpFindRoles    = "FindRoles"
+	OpCreateRole   = "CreateRole"
+	OpUpdateRole   = "UpdateRole"
+	OpDeleteRole   = "DeleteRole"
+)
+
+// RoleService represents a service for managing roles.
+type RoleService interface {
+	// FindRoleByID returns a single role by ID.
+	FindRoleByID(ctx context.Context, id ID) (*Role, error)
+
+	// FindRoles returns a list of roles that match filter and the total count of matching roles.
+	// Additional options provide pagination & sorting.
+	FindRoles(ctx context.Context, filter RoleFilter, opt ...FindOptions) ([]*Role, int, error)
+
+	// CreateRole creates a new role and sets r.ID with the new identifier.
+	CreateRole(ctx context.Context, r *Role) error
+
+	// UpdateRole updates a single role with a changeset.
+	// Returns the new role state after update.
+	UpdateRole(ctx context.Context, id ID, upd RoleUpdate) (*Role, error)
+
+	// DeleteRole removes a role by ID, and unassigns it from its members.
+	DeleteRole(ctx context.Context, id ID) error
+}
+
+// Role is a named set of permissions within an organization. A role is
+// granted to users by making them members of it, and its permissions can be
+// granted to an authorization when the authorization is created.
+type Role struct {
+	ID          ID           `json:"id,omitempty"`
+	OrgID       ID           `json:"orgID,omitempty"`
+	Name        string       `json:"name"`
+	Description string       `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions"`
+	CRUDLog
+}
+
+// Valid returns an error if the role is invalid.
+func (r *Role) Valid() error {
+	if !r.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "role orgID is invalid",
+		}
+	}
+	if r.Name == "" {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "role name is required",
+		}
+	}
+	if len(r.Permissions) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "role must have at least one permission",
+		}
+	}
+	for _, p := range r.Permissions {
+		if err := p.Valid(); err != nil {
+			return &Error{
+				Code: EInvalid,
+				Err:  err,
+			}
+		}
+		if !r.withinOrg(p) {
+			return &Error{
+				Code: EInvalid,
+				Msg:  fmt.Sprintf("role permission %s is not within the role's organization", p),
+			}
+		}
+	}
+	return nil
+}
+
+// withinOrg returns true if the permission only gives access to resources of
+// the role's organization.
+func (r *Role) withinOrg(p Permission) bool {
+	if p.Resource.Type == OrgsResourceType {
+		return p.Resource.ID != nil && *p.Resource.ID == r.OrgID
+	}
+	return p.Resource.OrgID != nil && *p.Resource.OrgID == r.OrgID
+}
+
+// RoleFilter represents a set of filter that restrict the returned roles.
+type RoleFilter struct {
+	ID           *ID
+	OrgID        *ID
+	Organization *string
+	Name         *string
+}
+
+// RoleUpdate is the changeset of a role. Nil fields are left unchanged.
+type RoleUpdate struct {
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Permissions []Permission `json:"permissions,omitempty"`
+}
+
+// Apply applies the changeset to the role.
+func (u RoleUpdate) Apply(r *Role) {
+	if u.Name != nil {
+		r.Name = *u.Name
+	}
+	if u.Description != nil {
+		r.Description = *u.Description
+	}
+	if u.Permissions != nil {
+		r.Permissions = u.Permissions
+	}
+}
@@ -0,0 +1,140 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/influxdata/influxdb/v2"
+	"go.uber.org/zap"
+)
+
+// GroupMapping grants the members of a group of an identity provider or
+// directory a role in an organization.
+type GroupMapping struct {
+	Group string
+	Org   string
+	Role  influxdb.UserType
+}
+
+// ParseGroupMapping parses a group mapping of the form group=org or
+// group=org:role, where the role is member or owner and defaults to member.
+func ParseGroupMapping(s string) (GroupMapping, error) {
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return GroupMapping{}, &influxdb.Error{
+			Code: influxdb.EInvalid,
+			Msg:  fmt.Sprintf("invalid group mapping %q: expected group=org[:role]", s),
+		}
+	}
+
+	m := GroupMapping{
+		Group: parts[0],
+		Org:   parts[1],
+		Role:  influxdb.Member,
+	}
+	if i := strings.LastIndex(m.Org, ":"); i >= 0 {
+		m.Org, m.Role = m.Org[:i], influxdb.UserType(m.Org[i+1:])
+		if err := m.Role.Valid(); err != nil || m.Org == "" {
+			return GroupMapping{}, &influxdb.Error{
+				Code: influxdb.EInvalid,
+				Msg:  fmt.Sprintf("invalid group mapping %q: role must be member or owner", s),
+			}
+		}
+	}
+	return m, nil
+}
+
+// ParseGroupMappings parses group mappings with ParseGroupMapping.
+func ParseGroupMappings(ss []string) ([]GroupMapping, error) {
+	mappings := make([]GroupMapping, 0, len(ss))
+	for _, s := range ss {
+		m, err := ParseGroupMapping(s)
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, m)
+	}
+	return mappings, nil
+}
+
+// groupMapper updates the organization memberships of users from the
+// groups they have in an identity provider or directory.
+type groupMapper struct {
+	log      *zap.Logger
+	mappings []GroupMapping
+
+	orgSvc influxdb.OrganizationService
+	urmSvc influxdb.UserResourceMappingService
+}
+
+func newGroupMapper(log *zap.Logger, mappings []GroupMapping, orgSvc influxdb.OrganizationService, urmSvc influxdb.UserResourceMappingService) *groupMapper {
+	return &groupMapper{
+		log:      log,
+		mappings: mappings,
+		orgSvc:   orgSvc,
+		urmSvc:   urmSvc,
+	}
+}
+
+// sync makes the user a member or owner of the mapped
+// organizations of its groups, and removes it from the mapped organizations
+// of the groups it is not in. Memberships of organizations that are not
+// mapped are left as they are.
+func (m *groupMapper) sync(ctx context.Context, userID influxdb.ID, groups map[string]bool) error {
+	// roles holds the role the user should have in each mapped organization,
+	// the empty role if it should not be in the organization.
+	roles := make(map[string]influxdb.UserType)
+	for _, gm := range m.mappings {
+		role := roles[gm.Org]
+		if groups[gm.Group] && role != influxdb.Owner {
+			role = gm.Role
+		}
+		roles[gm.Org] = role
+	}
+
+	for name, role := range roles {
+		org, err := m.orgSvc.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &name})
+		if err != nil {
+			if influxdb.ErrorCode(err) == influxdb.ENotFound {
+				m.log.Warn("Mapped organization does not exist", zap.String("org", name))
+				continue
+			}
+			return err
+		}
+
+		urms, _, err := m.urmSvc.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+			ResourceType: influxdb.OrgsResourceType,
+			ResourceID:   org.ID,
+			UserID:       userID,
+		})
+		if err != nil {
+			return err
+		}
+
+		var current influxdb.UserType
+		if len(urms) > 0 {
+			current = urms[0].UserType
+		}
+		if current == role {
+			continue
+		}
+		if current != "" {
+			if err := m.urmSvc.DeleteUserResourceMapping(ctx, org.ID, userID); err != nil {
+				return err
+			}
+		}
+		if role != "" {
+			err := m.urmSvc.CreateUserResourceMapping(ctx, &influxdb.UserResourceMapping{
+				ResourceType: influxdb.OrgsResourceType,
+				ResourceID:   org.ID,
+				UserID:       userID,
+				UserType:     role,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
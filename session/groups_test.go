@@ -0,0 +1,33 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+)
+
+func TestParseGroupMapping(t *testing.T) {
+	for _, tc := range []struct {
+		s    string
+		want GroupMapping
+		err  bool
+	}{
+		{s: "admins=acme", want: GroupMapping{Group: "admins", Org: "acme", Role: influxdb.Member}},
+		{s: "admins=acme:owner", want: GroupMapping{Group: "admins", Org: "acme", Role: influxdb.Owner}},
+		{s: "admins=my:org:member", want: GroupMapping{Group: "admins", Org: "my:org", Role: influxdb.Member}},
+		{s: "admins=acme:admin", err: true},
+		{s: "admins=:owner", err: true},
+		{s: "admins", err: true},
+		{s: "=acme", err: true},
+	} {
+		t.Run(tc.s, func(t *testing.T) {
+			got, err := ParseGroupMapping(tc.s)
+			if (err != nil) != tc.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("unexpected mapping: got %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
@@ -88,12 +88,14 @@ func (h *SessionHandler) handleSignin(w http.ResponseWriter, r *http.Request) {
 	u, err := h.userSvc.FindUser(ctx, influxdb.UserFilter{
 		Name: &req.Username,
 	})
-	if err != nil {
-		h.api.Err(w, r, ErrUnauthorized)
-		return
+	if err == nil {
+		err = h.passSvc.ComparePassword(ctx, u.ID, req.Password)
+	} else if p, ok := h.passSvc.(influxdb.UserProvisioner); ok && influxdb.ErrorCode(err) == influxdb.ENotFound {
+		// The user may exist in the directory the passwords service
+		// authenticates against, without having signed in before.
+		_, err = p.ProvisionUser(ctx, req.Username, req.Password)
 	}
-
-	if err := h.passSvc.ComparePassword(ctx, u.ID, req.Password); err != nil {
+	if err != nil {
 		h.api.Err(w, r, ErrUnauthorized)
 		return
 	}
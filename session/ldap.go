@@ -0,0 +1,252 @@
+package session
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/influxdata/influxdb/v2"
+	"go.uber.org/zap"
+)
+
+var _ influxdb.PasswordsService = (*LDAPPasswordsService)(nil)
+var _ influxdb.UserProvisioner = (*LDAPPasswordsService)(nil)
+
+// errNotInDirectory is returned when a user is not in the LDAP directory.
+var errNotInDirectory = errors.New("user is not in the directory")
+
+// LDAPConfig configures authentication against an LDAP directory.
+type LDAPConfig struct {
+	// URL is the ldap:// or ldaps:// URL of the directory.
+	URL string
+	// StartTLS upgrades ldap:// connections to TLS.
+	StartTLS bool
+	// InsecureSkipVerify disables the verification of the certificate of the directory.
+	InsecureSkipVerify bool
+	// Timeout is the timeout of connections and requests to the directory.
+	Timeout time.Duration
+
+	// BindDN and BindPassword are the credentials users and groups are
+	// searched with. The search is anonymous if BindDN is unset.
+	BindDN       string
+	BindPassword string
+
+	// UserBaseDN is where users are searched.
+	UserBaseDN string
+	// UserFilter finds the user with the name, which replaces the %s in
+	// the filter. "(uid=%s)" if unset.
+	UserFilter string
+
+	// GroupBaseDN is where the groups of users are searched. Groups are not
+	// searched if it is unset.
+	GroupBaseDN string
+	// GroupFilter finds the groups of the user with the DN, which replaces
+	// the %s in the filter. "(member=%s)" if unset.
+	GroupFilter string
+	// GroupAttribute is the attribute with the name of a group, "cn" if unset.
+	GroupAttribute string
+	// GroupMappings grant the members of groups access to organizations.
+	GroupMappings []GroupMapping
+}
+
+// Enabled returns true if LDAP authentication is configured.
+func (c LDAPConfig) Enabled() bool {
+	return c.URL != ""
+}
+
+// ldapConn is a connection to an LDAP directory.
+type ldapConn interface {
+	Bind(username, password string) error
+	Search(req *ldap.SearchRequest) (*ldap.SearchResult, error)
+	Close()
+}
+
+// LDAPPasswordsService authenticates users against an LDAP directory.
+// Users of the directory are created when they sign in for the first time,
+// and their organization memberships are updated from the group mappings
+// of their groups at every sign in.
+//
+// Users that are not in the directory, such as break-glass accounts, are
+// authenticated with their local passwords. So are all users while the
+// directory cannot be reached.
+type LDAPPasswordsService struct {
+	influxdb.PasswordsService
+
+	log     *zap.Logger
+	config  LDAPConfig
+	userSvc influxdb.UserService
+	groups  *groupMapper
+
+	dial func() (ldapConn, error)
+}
+
+// NewLDAPPasswordsService returns a PasswordsService that authenticates
+// users against the directory, and against the local PasswordsService if
+// they are not in the directory.
+func NewLDAPPasswordsService(log *zap.Logger, config LDAPConfig, passwordsSvc influxdb.PasswordsService, userSvc influxdb.UserService, orgSvc influxdb.OrganizationService, urmSvc influxdb.UserResourceMappingService) *LDAPPasswordsService {
+	if config.UserFilter == "" {
+		config.UserFilter = "(uid=%s)"
+	}
+	if config.GroupFilter == "" {
+		config.GroupFilter = "(member=%s)"
+	}
+	if config.GroupAttribute == "" {
+		config.GroupAttribute = "cn"
+	}
+
+	s := &LDAPPasswordsService{
+		PasswordsService: passwordsSvc,
+
+		log:     log,
+		config:  config,
+		userSvc: userSvc,
+		groups:  newGroupMapper(log, config.GroupMappings, orgSvc, urmSvc),
+	}
+	s.dial = s.dialDirectory
+	return s
+}
+
+func (s *LDAPPasswordsService) dialDirectory() (ldapConn, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: s.config.InsecureSkipVerify}
+	opts := []ldap.DialOpt{ldap.DialWithTLSConfig(tlsConfig)}
+	if s.config.Timeout > 0 {
+		opts = append(opts, ldap.DialWithDialer(&net.Dialer{Timeout: s.config.Timeout}))
+	}
+
+	conn, err := ldap.DialURL(s.config.URL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if s.config.Timeout > 0 {
+		conn.SetTimeout(s.config.Timeout)
+	}
+	if s.config.StartTLS {
+		if err := conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// ComparePassword authenticates the user against the directory, or against
+// its local password if it is not in the directory or the directory cannot
+// be reached.
+func (s *LDAPPasswordsService) ComparePassword(ctx context.Context, userID influxdb.ID, password string) error {
+	u, err := s.userSvc.FindUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	groups, err := s.authenticate(u.Name, password)
+	switch {
+	case err == nil:
+		return s.groups.sync(ctx, u.ID, groups)
+	case err == errNotInDirectory:
+		return s.PasswordsService.ComparePassword(ctx, userID, password)
+	case ldap.IsErrorWithCode(err, ldap.ErrorNetwork):
+		s.log.Warn("LDAP directory is unavailable, using the local password", zap.String("user", u.Name), zap.Error(err))
+		return s.PasswordsService.ComparePassword(ctx, userID, password)
+	default:
+		return ldapUnauthorized(err)
+	}
+}
+
+// ProvisionUser authenticates a user that does not exist yet against the
+// directory and creates it.
+func (s *LDAPPasswordsService) ProvisionUser(ctx context.Context, name, password string) (*influxdb.User, error) {
+	groups, err := s.authenticate(name, password)
+	if err != nil {
+		return nil, ldapUnauthorized(err)
+	}
+
+	u := &influxdb.User{
+		Name:   name,
+		Status: influxdb.Active,
+	}
+	if err := s.userSvc.CreateUser(ctx, u); err != nil {
+		return nil, err
+	}
+	s.log.Info("Provisioned user from LDAP", zap.String("user", name), zap.Stringer("userID", u.ID))
+	return u, s.groups.sync(ctx, u.ID, groups)
+}
+
+// authenticate binds to the directory as the user with the name and returns
+// its groups.
+func (s *LDAPPasswordsService) authenticate(name, password string) (map[string]bool, error) {
+	conn, err := s.dial()
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if err := s.bindSearch(conn); err != nil {
+		return nil, err
+	}
+	res, err := conn.Search(ldap.NewSearchRequest(
+		s.config.UserBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		fmt.Sprintf(s.config.UserFilter, ldap.EscapeFilter(name)),
+		[]string{"dn"}, nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	switch len(res.Entries) {
+	case 0:
+		return nil, errNotInDirectory
+	case 1:
+	default:
+		return nil, fmt.Errorf("user %q matches %d entries in the directory", name, len(res.Entries))
+	}
+
+	// An empty password is an unauthenticated bind that always succeeds.
+	if password == "" {
+		return nil, fmt.Errorf("empty password")
+	}
+	dn := res.Entries[0].DN
+	if err := conn.Bind(dn, password); err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]bool)
+	if s.config.GroupBaseDN == "" {
+		return groups, nil
+	}
+	if err := s.bindSearch(conn); err != nil {
+		return nil, err
+	}
+	res, err = conn.Search(ldap.NewSearchRequest(
+		s.config.GroupBaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
+		fmt.Sprintf(s.config.GroupFilter, ldap.EscapeFilter(dn)),
+		[]string{s.config.GroupAttribute}, nil,
+	))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range res.Entries {
+		for _, g := range e.GetAttributeValues(s.config.GroupAttribute) {
+			groups[g] = true
+		}
+	}
+	return groups, nil
+}
+
+// bindSearch binds as the account that searches the directory.
+func (s *LDAPPasswordsService) bindSearch(conn ldapConn) error {
+	if s.config.BindDN == "" {
+		return nil
+	}
+	return conn.Bind(s.config.BindDN, s.config.BindPassword)
+}
+
+func ldapUnauthorized(err error) error {
+	return &influxdb.Error{
+		Code: influxdb.EUnauthorized,
+		Msg:  "unauthorized access",
+		Err:  err,
+	}
+}
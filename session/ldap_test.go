@@ -0,0 +1,269 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/inmem"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/tenant"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	testBindDN       = "cn=search,dc=example,dc=com"
+	testBindPassword = "search-secret"
+	testUserBaseDN   = "ou=users,dc=example,dc=com"
+	testGroupBaseDN  = "ou=groups,dc=example,dc=com"
+)
+
+// fakeDirectory is an LDAP directory of users by uid and of groups by cn.
+type fakeDirectory struct {
+	passwords map[string]string
+	groups    map[string][]string
+	down      bool
+}
+
+func userDN(uid string) string {
+	return "uid=" + uid + "," + testUserBaseDN
+}
+
+func (d *fakeDirectory) dial() (ldapConn, error) {
+	if d.down {
+		return nil, ldap.NewError(ldap.ErrorNetwork, errors.New("connection refused"))
+	}
+	return &fakeConn{dir: d}, nil
+}
+
+type fakeConn struct {
+	dir   *fakeDirectory
+	bound string
+}
+
+func (c *fakeConn) Bind(dn, password string) error {
+	if dn == testBindDN && password == testBindPassword {
+		c.bound = dn
+		return nil
+	}
+	for uid, p := range c.dir.passwords {
+		if userDN(uid) == dn && p == password {
+			c.bound = dn
+			return nil
+		}
+	}
+	return ldap.NewError(ldap.LDAPResultInvalidCredentials, errors.New("invalid credentials"))
+}
+
+func (c *fakeConn) Search(req *ldap.SearchRequest) (*ldap.SearchResult, error) {
+	if c.bound != testBindDN {
+		return nil, ldap.NewError(ldap.LDAPResultInsufficientAccessRights, errors.New("insufficient access"))
+	}
+
+	res := &ldap.SearchResult{}
+	switch req.BaseDN {
+	case testUserBaseDN:
+		uid := strings.TrimSuffix(strings.TrimPrefix(req.Filter, "(uid="), ")")
+		if _, ok := c.dir.passwords[uid]; ok {
+			res.Entries = append(res.Entries, ldap.NewEntry(userDN(uid), nil))
+		}
+	case testGroupBaseDN:
+		member := strings.TrimSuffix(strings.TrimPrefix(req.Filter, "(member="), ")")
+		for cn, members := range c.dir.groups {
+			for _, m := range members {
+				if m == member {
+					res.Entries = append(res.Entries, ldap.NewEntry("cn="+cn+","+testGroupBaseDN, map[string][]string{"cn": {cn}}))
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+func (c *fakeConn) Close() {}
+
+func newLDAPTest(t *testing.T, dir *fakeDirectory) (*LDAPPasswordsService, influxdb.TenantService) {
+	ts, _ := tenant.NewStore(inmem.NewKVStore())
+	ten := tenant.NewService(ts)
+
+	ctx := context.Background()
+	for _, name := range []string{"acme", "beta"} {
+		if err := ten.CreateOrganization(ctx, &influxdb.Organization{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewLDAPPasswordsService(zaptest.NewLogger(t), LDAPConfig{
+		URL:          "ldap://localhost",
+		BindDN:       testBindDN,
+		BindPassword: testBindPassword,
+		UserBaseDN:   testUserBaseDN,
+		GroupBaseDN:  testGroupBaseDN,
+		GroupMappings: []GroupMapping{
+			{Group: "admins", Org: "acme", Role: influxdb.Owner},
+			{Group: "engineers", Org: "beta", Role: influxdb.Member},
+		},
+	}, ten, ten, ten, ten)
+	svc.dial = dir.dial
+	return svc, ten
+}
+
+func orgRole(t *testing.T, ten influxdb.TenantService, userID influxdb.ID, org string) influxdb.UserType {
+	t.Helper()
+
+	ctx := context.Background()
+	o, err := ten.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+	if err != nil {
+		t.Fatal(err)
+	}
+	urms, _, err := ten.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+		ResourceType: influxdb.OrgsResourceType,
+		ResourceID:   o.ID,
+		UserID:       userID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urms) == 0 {
+		return ""
+	}
+	return urms[0].UserType
+}
+
+func TestLDAPPasswordsService_Directory(t *testing.T) {
+	dir := &fakeDirectory{
+		passwords: map[string]string{"jane": "jane-secret"},
+		groups: map[string][]string{
+			"admins":    {userDN("jane")},
+			"engineers": {userDN("jane")},
+		},
+	}
+	svc, ten := newLDAPTest(t, dir)
+	ctx := context.Background()
+
+	if _, err := svc.ProvisionUser(ctx, "jane", "wrong"); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Fatalf("expected unauthorized for a wrong password, got %v", err)
+	}
+	if _, err := svc.ProvisionUser(ctx, "john", "john-secret"); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Fatalf("expected unauthorized for a user that is not in the directory, got %v", err)
+	}
+
+	u, err := svc.ProvisionUser(ctx, "jane", "jane-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := orgRole(t, ten, u.ID, "acme"), influxdb.Owner; got != want {
+		t.Errorf("unexpected role in acme: got %q, want %q", got, want)
+	}
+	if got, want := orgRole(t, ten, u.ID, "beta"), influxdb.Member; got != want {
+		t.Errorf("unexpected role in beta: got %q, want %q", got, want)
+	}
+
+	if err := svc.ComparePassword(ctx, u.ID, "wrong"); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Fatalf("expected unauthorized for a wrong password, got %v", err)
+	}
+	if err := svc.ComparePassword(ctx, u.ID, ""); influxdb.ErrorCode(err) != influxdb.EUnauthorized {
+		t.Fatalf("expected unauthorized for an empty password, got %v", err)
+	}
+
+	// Memberships follow the groups of the directory at every sign in.
+	dir.groups["admins"] = nil
+	if err := svc.ComparePassword(ctx, u.ID, "jane-secret"); err != nil {
+		t.Fatal(err)
+	}
+	if got := orgRole(t, ten, u.ID, "acme"); got != "" {
+		t.Errorf("expected no role in acme, got %q", got)
+	}
+	if got, want := orgRole(t, ten, u.ID, "beta"), influxdb.Member; got != want {
+		t.Errorf("unexpected role in beta: got %q, want %q", got, want)
+	}
+}
+
+func TestLDAPPasswordsService_LocalFallback(t *testing.T) {
+	dir := &fakeDirectory{
+		passwords: map[string]string{"jane": "jane-secret"},
+	}
+	svc, ten := newLDAPTest(t, dir)
+	ctx := context.Background()
+
+	// The break-glass account is not in the directory and has a local password.
+	admin := &influxdb.User{Name: "admin", Status: influxdb.Active}
+	if err := ten.CreateUser(ctx, admin); err != nil {
+		t.Fatal(err)
+	}
+	if err := ten.SetPassword(ctx, admin.ID, "admin-secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ComparePassword(ctx, admin.ID, "admin-secret"); err != nil {
+		t.Fatalf("expected the local password to be accepted, got %v", err)
+	}
+	if err := svc.ComparePassword(ctx, admin.ID, "wrong"); err == nil {
+		t.Fatal("expected a wrong local password to be rejected")
+	}
+
+	// The local password of a user of the directory is only accepted while
+	// the directory is down.
+	jane := &influxdb.User{Name: "jane", Status: influxdb.Active}
+	if err := ten.CreateUser(ctx, jane); err != nil {
+		t.Fatal(err)
+	}
+	if err := ten.SetPassword(ctx, jane.ID, "local-secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.ComparePassword(ctx, jane.ID, "local-secret"); err == nil {
+		t.Fatal("expected the local password to be rejected while the directory is up")
+	}
+	dir.down = true
+	if err := svc.ComparePassword(ctx, jane.ID, "local-secret"); err != nil {
+		t.Fatalf("expected the local password to be accepted while the directory is down, got %v", err)
+	}
+	if _, err := svc.ProvisionUser(ctx, "john", "john-secret"); err == nil {
+		t.Fatal("expected users not to be provisioned while the directory is down")
+	}
+}
+
+func TestSessionHandler_handleSignin_LDAP(t *testing.T) {
+	dir := &fakeDirectory{
+		passwords: map[string]string{"jane": "jane-secret"},
+	}
+	svc, ten := newLDAPTest(t, dir)
+	sessionSvc := NewService(NewStorage(inmem.NewSessionStore()), ten, ten, &mock.AuthorizationService{
+		FindAuthorizationsFn: func(context.Context, influxdb.AuthorizationFilter, ...influxdb.FindOptions) ([]*influxdb.Authorization, int, error) {
+			return []*influxdb.Authorization{}, 0, nil
+		},
+	}, time.Minute)
+
+	h := NewSessionHandler(zaptest.NewLogger(t), sessionSvc, ten, svc)
+	server := httptest.NewServer(h.SignInResourceHandler())
+	defer server.Close()
+
+	for _, tc := range []struct {
+		password string
+		code     int
+	}{
+		{password: "wrong", code: http.StatusUnauthorized},
+		// The first sign in creates the user, the second finds it.
+		{password: "jane-secret", code: http.StatusNoContent},
+		{password: "jane-secret", code: http.StatusNoContent},
+	} {
+		r, err := http.NewRequest("POST", server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r.SetBasicAuth("jane", tc.password)
+		resp, err := server.Client().Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.code {
+			t.Errorf("unexpected status signing in with %q: got %d, want %d", tc.password, resp.StatusCode, tc.code)
+		}
+	}
+}
@@ -0,0 +1,427 @@
+package session
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gojwt "github.com/dgrijalva/jwt-go"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/influxdata/influxdb/v2"
+	chronoauth "github.com/influxdata/influxdb/v2/chronograf/oauth2"
+	kithttp "github.com/influxdata/influxdb/v2/kit/transport/http"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+const (
+	prefixOAuth = "/api/v2/oauth"
+
+	cookieOAuthStateName = "oauth_state"
+	// oauthStateLifespan is how long a user has to log in at the identity provider.
+	oauthStateLifespan = 10 * time.Minute
+)
+
+// DefaultOAuthScopes are the scopes requested if the OAuthConfig does not set any.
+var DefaultOAuthScopes = []string{"openid", "email", "profile"}
+
+// OAuthConfig configures single sign-on with an OAuth2 or OpenID Connect
+// identity provider.
+type OAuthConfig struct {
+	// ClientID and ClientSecret are the credentials influxd is registered with
+	// at the identity provider.
+	ClientID     string
+	ClientSecret string
+
+	// AuthURL and TokenURL are the authorization and token endpoints of the provider.
+	AuthURL  string
+	TokenURL string
+	// UserInfoURL is the userinfo endpoint of the provider. The claims it
+	// returns add to those of the id_token, or replace them if the provider
+	// does not return an id_token.
+	UserInfoURL string
+	// JWKSURL is the key set that RS256 signed id_tokens are verified with.
+	// HS256 signed id_tokens are verified with the client secret.
+	JWKSURL string
+	// Issuer is the iss claim id_tokens must be issued with. It is not
+	// checked if unset.
+	Issuer string
+	// RedirectURL is the URL of the callback of influxd as registered at the provider.
+	RedirectURL string
+	// Scopes are the scopes requested, DefaultOAuthScopes if unset.
+	Scopes []string
+
+	// UsernameClaim is the claim whose value is the name of the user,
+	// "email" if unset. An email is only accepted if the email_verified
+	// claim is true.
+	UsernameClaim string
+	// GroupsClaim is the claim that lists the groups of the user,
+	// "groups" if unset. Its value is a list of strings or a comma
+	// separated string.
+	GroupsClaim string
+	// GroupMappings grant the members of groups access to organizations.
+	GroupMappings []GroupMapping
+	// AutoProvision creates the users that log in for the first time.
+	// Otherwise only existing users may log in.
+	AutoProvision bool
+	// LinkExistingUsers lets users log in as the existing user of the same
+	// name that was not created by a login with the identity provider, such
+	// as a user with a password. Otherwise logins only map to users created
+	// by logins of the same subject at the identity provider.
+	LinkExistingUsers bool
+
+	// StateSecret signs the state of logins. It must be the same on all
+	// instances behind a load balancer. A random secret is used if unset.
+	StateSecret string
+	// SuccessURL is where users are redirected to after they logged in, "/" if unset.
+	SuccessURL string
+}
+
+// Enabled returns true if single sign-on is configured.
+func (c OAuthConfig) Enabled() bool {
+	return c.ClientID != ""
+}
+
+// OAuthHandler logs users in with an OAuth2 or OpenID Connect identity
+// provider and creates sessions for them.
+type OAuthHandler struct {
+	chi.Router
+	api *kithttp.API
+	log *zap.Logger
+
+	config   OAuthConfig
+	oauth    *oauth2.Config
+	states   *chronoauth.JWT
+	idTokens *chronoauth.JWT
+
+	sessionSvc influxdb.SessionService
+	userSvc    influxdb.UserService
+	groups     *groupMapper
+}
+
+// NewOAuthHandler returns a new instance of OAuthHandler.
+func NewOAuthHandler(log *zap.Logger, config OAuthConfig, sessionSvc influxdb.SessionService, userSvc influxdb.UserService, orgSvc influxdb.OrganizationService, urmSvc influxdb.UserResourceMappingService) (*OAuthHandler, error) {
+	if len(config.Scopes) == 0 {
+		config.Scopes = DefaultOAuthScopes
+	}
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = "email"
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = "groups"
+	}
+	if config.SuccessURL == "" {
+		config.SuccessURL = "/"
+	}
+	if config.StateSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		config.StateSecret = hex.EncodeToString(secret)
+	}
+
+	h := &OAuthHandler{
+		Router: chi.NewRouter(),
+		api:    kithttp.NewAPI(kithttp.WithLog(log)),
+		log:    log,
+
+		config: config,
+		oauth: &oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			Scopes:       config.Scopes,
+			RedirectURL:  config.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  config.AuthURL,
+				TokenURL: config.TokenURL,
+			},
+		},
+		states:   chronoauth.NewJWT(config.StateSecret, ""),
+		idTokens: chronoauth.NewJWT(config.ClientSecret, config.JWKSURL),
+
+		sessionSvc: sessionSvc,
+		userSvc:    userSvc,
+		groups:     newGroupMapper(log, config.GroupMappings, orgSvc, urmSvc),
+	}
+
+	h.Router.Use(
+		middleware.Recoverer,
+		middleware.RequestID,
+		middleware.RealIP,
+	)
+	h.Router.Get("/login", h.handleLogin)
+	h.Router.Get("/callback", h.handleCallback)
+	return h, nil
+}
+
+// Prefix is necessary to mount the router as a resource handler
+func (h *OAuthHandler) Prefix() string { return prefixOAuth }
+
+// handleLogin is the HTTP handler for the GET /oauth/login route. It
+// redirects to the identity provider with a signed state that is also
+// stored in a cookie, so that the callback can only complete logins the
+// browser started.
+func (h *OAuthHandler) handleLogin(w http.ResponseWriter, r *http.Request) {
+	nonce := make([]byte, 32)
+	if _, err := rand.Read(nonce); err != nil {
+		h.api.Err(w, r, err)
+		return
+	}
+
+	now := time.Now()
+	state, err := h.states.Create(r.Context(), chronoauth.Principal{
+		Subject:   hex.EncodeToString(nonce),
+		IssuedAt:  now,
+		ExpiresAt: now.Add(oauthStateLifespan),
+	})
+	if err != nil {
+		h.api.Err(w, r, err)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieOAuthStateName,
+		Value:    string(state),
+		Path:     prefixOAuth,
+		MaxAge:   int(oauthStateLifespan / time.Second),
+		HttpOnly: true,
+	})
+	http.Redirect(w, r, h.oauth.AuthCodeURL(string(state), oauth2.AccessTypeOnline), http.StatusFound)
+}
+
+// handleCallback is the HTTP handler for the GET /oauth/callback route.
+func (h *OAuthHandler) handleCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	// The state is only needed once, so the cookie is removed regardless
+	// of the outcome of the login.
+	http.SetCookie(w, &http.Cookie{
+		Name:   cookieOAuthStateName,
+		Path:   prefixOAuth,
+		MaxAge: -1,
+	})
+
+	if err := h.validState(ctx, r); err != nil {
+		h.log.Info("Invalid OAuth state", zap.Error(err))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+	if e := r.FormValue("error"); e != "" {
+		h.log.Info("Identity provider denied the login", zap.String("error", e), zap.String("description", r.FormValue("error_description")))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+
+	token, err := h.oauth.Exchange(ctx, r.FormValue("code"))
+	if err != nil {
+		h.log.Info("Failed to exchange the OAuth code for a token", zap.Error(err))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+
+	claims, err := h.claims(ctx, token)
+	if err != nil {
+		h.log.Info("Failed to get the claims of the user", zap.Error(err))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		h.log.Info("Claims of the user have no subject")
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+	name, _ := claims[h.config.UsernameClaim].(string)
+	if name == "" {
+		h.log.Info("Claims of the user have no username", zap.String("claim", h.config.UsernameClaim))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+	if h.config.UsernameClaim == "email" && !isTrue(claims["email_verified"]) {
+		h.log.Info("Email of the user is not verified", zap.String("user", name))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+
+	u, err := h.findOrCreateUser(ctx, name, subject)
+	if err != nil {
+		h.log.Info("Failed to find the user", zap.String("user", name), zap.Error(err))
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+	if err := h.groups.sync(ctx, u.ID, groupsFromClaim(claims[h.config.GroupsClaim])); err != nil {
+		h.log.Error("Failed to update the organizations of the user", zap.String("user", name), zap.Error(err))
+		h.api.Err(w, r, err)
+		return
+	}
+
+	s, err := h.sessionSvc.CreateSession(ctx, name)
+	if err != nil {
+		h.api.Err(w, r, ErrUnauthorized)
+		return
+	}
+
+	// Unlike the cookie of signin, the cookie is set explicitly for all
+	// paths as it would default to the path of the callback otherwise.
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieSessionName,
+		Value:    s.Key,
+		Path:     "/",
+		HttpOnly: true,
+	})
+	http.Redirect(w, r, h.config.SuccessURL, http.StatusFound)
+}
+
+// validState checks that the state of the callback was signed by the login
+// handler and matches the state cookie of the browser.
+func (h *OAuthHandler) validState(ctx context.Context, r *http.Request) error {
+	state := r.FormValue("state")
+	c, err := r.Cookie(cookieOAuthStateName)
+	if err != nil {
+		return err
+	}
+	if state == "" || c.Value != state {
+		return fmt.Errorf("state does not match the state cookie")
+	}
+	_, err = h.states.ValidPrincipal(ctx, chronoauth.Token(state), oauthStateLifespan)
+	return err
+}
+
+// claims returns the claims of the id_token of the token, merged with the
+// claims returned by the userinfo endpoint if it is configured.
+func (h *OAuthHandler) claims(ctx context.Context, token *oauth2.Token) (map[string]interface{}, error) {
+	claims := make(map[string]interface{})
+	if idToken, ok := token.Extra("id_token").(string); ok && idToken != "" {
+		c, err := h.idTokens.GetClaims(idToken)
+		if err != nil {
+			return nil, err
+		}
+		if !hasAudience(c, h.config.ClientID) {
+			return nil, fmt.Errorf("id_token is not issued for client %q", h.config.ClientID)
+		}
+		if h.config.Issuer != "" && c["iss"] != h.config.Issuer {
+			return nil, fmt.Errorf("id_token is not issued by %q", h.config.Issuer)
+		}
+		claims = c
+	} else if h.config.UserInfoURL == "" {
+		return nil, fmt.Errorf("provider returned no id_token and no userinfo endpoint is configured")
+	}
+
+	if h.config.UserInfoURL == "" {
+		return claims, nil
+	}
+
+	res, err := h.oauth.Client(ctx, token).Get(h.config.UserInfoURL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("userinfo endpoint returned %s", res.Status)
+	}
+
+	var info map[string]interface{}
+	if err := json.NewDecoder(res.Body).Decode(&info); err != nil {
+		return nil, err
+	}
+	// The userinfo response must be about the subject of the id_token,
+	// whose claims take precedence.
+	if sub, ok := claims["sub"]; ok && info["sub"] != sub {
+		return nil, fmt.Errorf("userinfo subject does not match the id_token")
+	}
+	for k, v := range info {
+		if _, ok := claims[k]; !ok {
+			claims[k] = v
+		}
+	}
+	return claims, nil
+}
+
+// hasAudience returns true if the aud claim, a string or a list of strings,
+// contains the client ID.
+func hasAudience(claims gojwt.MapClaims, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		for _, a := range aud {
+			if a == clientID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isTrue returns true if the claim is the boolean true or the string "true",
+// as some providers return booleans as strings.
+func isTrue(claim interface{}) bool {
+	switch c := claim.(type) {
+	case bool:
+		return c
+	case string:
+		return c == "true"
+	}
+	return false
+}
+
+// groupsFromClaim returns the groups of a claim that is a list of strings
+// or a comma separated string.
+func groupsFromClaim(claim interface{}) map[string]bool {
+	groups := make(map[string]bool)
+	switch c := claim.(type) {
+	case string:
+		for _, g := range strings.Split(c, ",") {
+			if g = strings.TrimSpace(g); g != "" {
+				groups[g] = true
+			}
+		}
+	case []interface{}:
+		for _, g := range c {
+			if s, ok := g.(string); ok && s != "" {
+				groups[s] = true
+			}
+		}
+	}
+	return groups
+}
+
+// findOrCreateUser finds the user with the name, creating it if it does not
+// exist and users are provisioned automatically. Users created by logins are
+// only found for the subject that created them; other users only if linking
+// existing users is enabled.
+func (h *OAuthHandler) findOrCreateUser(ctx context.Context, name, subject string) (*influxdb.User, error) {
+	u, err := h.userSvc.FindUser(ctx, influxdb.UserFilter{Name: &name})
+	if err == nil {
+		switch {
+		case u.OAuthID == subject:
+			return u, nil
+		case u.OAuthID != "":
+			return nil, fmt.Errorf("user was created by the login of another subject")
+		case !h.config.LinkExistingUsers:
+			return nil, fmt.Errorf("user was not created by a login and linking existing users is disabled")
+		}
+		return u, nil
+	}
+	if influxdb.ErrorCode(err) != influxdb.ENotFound || !h.config.AutoProvision {
+		return nil, err
+	}
+
+	u = &influxdb.User{
+		Name:    name,
+		OAuthID: subject,
+		Status:  influxdb.Active,
+	}
+	if err := h.userSvc.CreateUser(ctx, u); err != nil {
+		return nil, err
+	}
+	h.log.Info("Provisioned user", zap.String("user", name), zap.Stringer("userID", u.ID))
+	return u, nil
+}
@@ -0,0 +1,369 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	gojwt "github.com/dgrijalva/jwt-go"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/inmem"
+	"github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/tenant"
+	"go.uber.org/zap/zaptest"
+)
+
+const (
+	testClientID     = "influxd"
+	testClientSecret = "client-secret"
+)
+
+// mockIdP is an identity provider that issues tokens for a single user.
+type mockIdP struct {
+	*httptest.Server
+
+	// claims are the claims of the user, returned in the id_token if
+	// idToken is set and by the userinfo endpoint otherwise.
+	claims  gojwt.MapClaims
+	idToken bool
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	idp := &mockIdP{idToken: true}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "good-code" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		res := map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "bearer",
+		}
+		if idp.idToken {
+			claims := gojwt.MapClaims{"aud": testClientID, "exp": time.Now().Add(time.Hour).Unix()}
+			for k, v := range idp.claims {
+				claims[k] = v
+			}
+			token, err := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims).SignedString([]byte(testClientSecret))
+			if err != nil {
+				t.Fatal(err)
+			}
+			res["id_token"] = token
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(res)
+	})
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(idp.claims)
+	})
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+type oauthTest struct {
+	tenant  influxdb.TenantService
+	handler *OAuthHandler
+	server  *httptest.Server
+	client  *http.Client
+}
+
+func newOAuthTest(t *testing.T, idp *mockIdP, config OAuthConfig) *oauthTest {
+	ts, _ := tenant.NewStore(inmem.NewKVStore())
+	ten := tenant.NewService(ts)
+	sessionSvc := NewService(NewStorage(inmem.NewSessionStore()), ten, ten, &mock.AuthorizationService{
+		FindAuthorizationsFn: func(context.Context, influxdb.AuthorizationFilter, ...influxdb.FindOptions) ([]*influxdb.Authorization, int, error) {
+			return []*influxdb.Authorization{}, 0, nil
+		},
+	}, time.Minute)
+
+	config.ClientID = testClientID
+	config.ClientSecret = testClientSecret
+	config.AuthURL = idp.URL + "/authorize"
+	config.TokenURL = idp.URL + "/token"
+	h, err := NewOAuthHandler(zaptest.NewLogger(t), config, sessionSvc, ten, ten, ten)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(h)
+	client := server.Client()
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &oauthTest{tenant: ten, handler: h, server: server, client: client}
+}
+
+func (tt *oauthTest) Close() {
+	tt.server.Close()
+}
+
+// login starts a login and returns the state the provider redirects back with.
+func (tt *oauthTest) login(t *testing.T) string {
+	t.Helper()
+
+	res, err := tt.client.Get(tt.server.URL + "/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		t.Fatalf("unexpected login status: %d", res.StatusCode)
+	}
+	loc, err := url.Parse(res.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loc.Query().Get("client_id"); got != testClientID {
+		t.Fatalf("unexpected client id: %q", got)
+	}
+	state := loc.Query().Get("state")
+	var cookie string
+	for _, c := range res.Cookies() {
+		if c.Name == cookieOAuthStateName {
+			cookie = c.Value
+		}
+	}
+	if state == "" || cookie != state {
+		t.Fatalf("state %q does not match state cookie %q", state, cookie)
+	}
+	return state
+}
+
+// callback completes the login with the state and the code and returns the
+// session cookie, or the status code if there is none.
+func (tt *oauthTest) callback(t *testing.T, state, cookie, code string) (string, int) {
+	t.Helper()
+
+	q := url.Values{"state": {state}, "code": {code}}
+	req, err := http.NewRequest("GET", tt.server.URL+"/callback?"+q.Encode(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.AddCookie(&http.Cookie{Name: cookieOAuthStateName, Value: cookie})
+	res, err := tt.client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	for _, c := range res.Cookies() {
+		if c.Name == cookieSessionName {
+			return c.Value, res.StatusCode
+		}
+	}
+	return "", res.StatusCode
+}
+
+func (tt *oauthTest) role(t *testing.T, user, org string) influxdb.UserType {
+	t.Helper()
+
+	ctx := context.Background()
+	u, err := tt.tenant.FindUser(ctx, influxdb.UserFilter{Name: &user})
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := tt.tenant.FindOrganization(ctx, influxdb.OrganizationFilter{Name: &org})
+	if err != nil {
+		t.Fatal(err)
+	}
+	urms, _, err := tt.tenant.FindUserResourceMappings(ctx, influxdb.UserResourceMappingFilter{
+		ResourceType: influxdb.OrgsResourceType,
+		ResourceID:   o.ID,
+		UserID:       u.ID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(urms) == 0 {
+		return ""
+	}
+	return urms[0].UserType
+}
+
+func TestOAuthHandler_IDToken(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.Close()
+	idp.claims = gojwt.MapClaims{
+		"sub":            "jane-subject",
+		"email":          "jane@example.com",
+		"email_verified": true,
+		"groups":         []interface{}{"admins", "devs"},
+	}
+
+	tt := newOAuthTest(t, idp, OAuthConfig{
+		AutoProvision: true,
+		GroupMappings: []GroupMapping{
+			{Group: "admins", Org: "acme", Role: influxdb.Owner},
+			{Group: "devs", Org: "acme", Role: influxdb.Member},
+			{Group: "devs", Org: "beta", Role: influxdb.Member},
+			{Group: "ops", Org: "missing", Role: influxdb.Member},
+		},
+	})
+	defer tt.Close()
+
+	ctx := context.Background()
+	for _, name := range []string{"acme", "beta"} {
+		if err := tt.tenant.CreateOrganization(ctx, &influxdb.Organization{Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	state := tt.login(t)
+	key, code := tt.callback(t, state, state, "good-code")
+	if code != http.StatusFound || key == "" {
+		t.Fatalf("expected a session and a redirect, got session %q and status %d", key, code)
+	}
+	if got, want := tt.role(t, "jane@example.com", "acme"), influxdb.Owner; got != want {
+		t.Errorf("unexpected role in acme: got %q, want %q", got, want)
+	}
+	if got, want := tt.role(t, "jane@example.com", "beta"), influxdb.Member; got != want {
+		t.Errorf("unexpected role in beta: got %q, want %q", got, want)
+	}
+
+	// Leaving groups at the identity provider removes the memberships of
+	// their organizations at the next login.
+	idp.claims["groups"] = "devs"
+	state = tt.login(t)
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusFound || key == "" {
+		t.Fatalf("expected a session and a redirect, got session %q and status %d", key, code)
+	}
+	if got, want := tt.role(t, "jane@example.com", "acme"), influxdb.Member; got != want {
+		t.Errorf("unexpected role in acme: got %q, want %q", got, want)
+	}
+
+	idp.claims["groups"] = []interface{}{}
+	state = tt.login(t)
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusFound || key == "" {
+		t.Fatalf("expected a session and a redirect, got session %q and status %d", key, code)
+	}
+	for _, org := range []string{"acme", "beta"} {
+		if got := tt.role(t, "jane@example.com", org); got != "" {
+			t.Errorf("expected no role in %s, got %q", org, got)
+		}
+	}
+}
+
+func TestOAuthHandler_UserInfo(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.Close()
+	idp.idToken = false
+	idp.claims = gojwt.MapClaims{"sub": "jane-subject", "preferred_username": "jane"}
+
+	tt := newOAuthTest(t, idp, OAuthConfig{
+		UserInfoURL:   idp.URL + "/userinfo",
+		UsernameClaim: "preferred_username",
+		AutoProvision: true,
+	})
+	defer tt.Close()
+
+	state := tt.login(t)
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusFound || key == "" {
+		t.Fatalf("expected a session and a redirect, got session %q and status %d", key, code)
+	}
+
+	name := "jane"
+	u, err := tt.tenant.FindUser(context.Background(), influxdb.UserFilter{Name: &name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Status != influxdb.Active {
+		t.Errorf("expected the provisioned user to be active, got %q", u.Status)
+	}
+	if u.OAuthID != "jane-subject" {
+		t.Errorf("expected the provisioned user to have the subject of the login, got %q", u.OAuthID)
+	}
+}
+
+func TestOAuthHandler_Unauthorized(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.Close()
+	idp.claims = gojwt.MapClaims{"sub": "jane-subject", "email": "jane@example.com", "email_verified": true}
+
+	tt := newOAuthTest(t, idp, OAuthConfig{})
+	defer tt.Close()
+
+	state := tt.login(t)
+	for _, tc := range []struct {
+		name          string
+		state, cookie string
+		code          string
+	}{
+		{name: "missing state cookie", state: state, code: "good-code"},
+		{name: "state does not match cookie", state: state, cookie: state + "x", code: "good-code"},
+		{name: "forged state", state: "forged", cookie: "forged", code: "good-code"},
+		{name: "bad code", state: state, cookie: state, code: "bad-code"},
+		{name: "user does not exist", state: state, cookie: state, code: "good-code"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			key, code := tt.callback(t, tc.state, tc.cookie, tc.code)
+			if code != http.StatusUnauthorized || key != "" {
+				t.Errorf("expected unauthorized, got session %q and status %d", key, code)
+			}
+		})
+	}
+
+	// Existing users not created by a login are only used if linking them
+	// is enabled, and then only for verified emails.
+	if err := tt.tenant.CreateUser(context.Background(), &influxdb.User{Name: "jane@example.com", Status: influxdb.Active}); err != nil {
+		t.Fatal(err)
+	}
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusUnauthorized || key != "" {
+		t.Errorf("expected unauthorized for an unlinked user, got session %q and status %d", key, code)
+	}
+
+	tt.handler.config.LinkExistingUsers = true
+	idp.claims["email_verified"] = false
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusUnauthorized || key != "" {
+		t.Errorf("expected unauthorized for an unverified email, got session %q and status %d", key, code)
+	}
+
+	idp.claims["email_verified"] = true
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusFound || key == "" {
+		t.Fatalf("expected a session and a redirect, got session %q and status %d", key, code)
+	}
+}
+
+func TestOAuthHandler_ProvisionedUser(t *testing.T) {
+	idp := newMockIdP(t)
+	defer idp.Close()
+	idp.claims = gojwt.MapClaims{
+		"iss":            "https://idp.example.com",
+		"sub":            "jane-subject",
+		"email":          "jane@example.com",
+		"email_verified": true,
+	}
+
+	tt := newOAuthTest(t, idp, OAuthConfig{
+		Issuer:            "https://idp.example.com",
+		AutoProvision:     true,
+		LinkExistingUsers: true,
+	})
+	defer tt.Close()
+
+	state := tt.login(t)
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusFound || key == "" {
+		t.Fatalf("expected a session and a redirect, got session %q and status %d", key, code)
+	}
+
+	// Users created by a login are never used by the logins of other
+	// subjects, even if linking existing users is enabled.
+	idp.claims["sub"] = "mallory-subject"
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusUnauthorized || key != "" {
+		t.Errorf("expected unauthorized for another subject, got session %q and status %d", key, code)
+	}
+
+	idp.claims["sub"] = "jane-subject"
+	idp.claims["iss"] = "https://other.example.com"
+	if key, code := tt.callback(t, state, state, "good-code"); code != http.StatusUnauthorized || key != "" {
+		t.Errorf("expected unauthorized for another issuer, got session %q and status %d", key, code)
+	}
+}
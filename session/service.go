@@ -16,6 +16,7 @@ type Service struct {
 	userService   influxdb.UserService
 	urmService    influxdb.UserResourceMappingService
 	authService   influxdb.AuthorizationService
+	roleService   influxdb.RoleService
 	sessionLength time.Duration
 
 	idGen    influxdb.IDGenerator
@@ -50,6 +51,13 @@ func (s *Service) WithMaxPermissionFunc(fn func(context.Context) bool) {
 	s.disableAuthorizationsForMaxPermissions = fn
 }
 
+// WithRoleService sets the role service used to look up the permissions of
+// the roles a user is a member of. Without it, roles grant no permissions
+// to sessions.
+func (s *Service) WithRoleService(rs influxdb.RoleService) {
+	s.roleService = rs
+}
+
 // FindSession finds a session based on the session key
 func (s *Service) FindSession(ctx context.Context, key string) (*influxdb.Session, error) {
 	session, err := s.store.FindSessionByKey(ctx, key)
@@ -123,7 +131,7 @@ func (s *Service) getPermissionSet(ctx context.Context, uid influxdb.ID) ([]infl
 		return nil, err
 	}
 
-	permissions, err := permissionFromMapping(mappings)
+	permissions, err := s.permissionFromMapping(ctx, mappings)
 	if err != nil {
 		return nil, err
 	}
@@ -136,7 +144,7 @@ func (s *Service) getPermissionSet(ctx context.Context, uid influxdb.ID) ([]infl
 			if err != nil {
 				return nil, err
 			}
-			pms, err := permissionFromMapping(mappings)
+			pms, err := s.permissionFromMapping(ctx, mappings)
 			if err != nil {
 				return nil, err
 			}
@@ -150,6 +158,9 @@ func (s *Service) getPermissionSet(ctx context.Context, uid influxdb.ID) ([]infl
 			return nil, err
 		}
 		for _, a := range as {
+			if a.Expired() != nil {
+				continue
+			}
 			permissions = append(permissions, a.Permissions...)
 		}
 	}
@@ -158,9 +169,18 @@ func (s *Service) getPermissionSet(ctx context.Context, uid influxdb.ID) ([]infl
 	return permissions, nil
 }
 
-func permissionFromMapping(mappings []*influxdb.UserResourceMapping) ([]influxdb.Permission, error) {
+func (s *Service) permissionFromMapping(ctx context.Context, mappings []*influxdb.UserResourceMapping) ([]influxdb.Permission, error) {
 	ps := make([]influxdb.Permission, 0, len(mappings))
 	for _, m := range mappings {
+		if m.ResourceType == influxdb.RolesResourceType {
+			p, err := s.rolePermissions(ctx, m.ResourceID)
+			if err != nil {
+				return nil, err
+			}
+			ps = append(ps, p...)
+			continue
+		}
+
 		p, err := m.ToPermissions()
 		if err != nil {
 			return nil, &influxdb.Error{
@@ -173,3 +193,19 @@ func permissionFromMapping(mappings []*influxdb.UserResourceMapping) ([]influxdb
 
 	return ps, nil
 }
+
+// rolePermissions returns the permissions of the role with the id. A role
+// that no longer exists grants no permissions.
+func (s *Service) rolePermissions(ctx context.Context, id influxdb.ID) ([]influxdb.Permission, error) {
+	if s.roleService == nil {
+		return nil, nil
+	}
+	r, err := s.roleService.FindRoleByID(ctx, id)
+	if err != nil {
+		if influxdb.ErrorCode(err) == influxdb.ENotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return r.Permissions, nil
+}
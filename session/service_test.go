@@ -47,3 +47,64 @@ func initSessionService(f influxdbtesting.SessionFields, t *testing.T) (influxdb
 	}
 	return svc, "session", func() {}
 }
+
+func TestService_RolePermissions(t *testing.T) {
+	ctx := context.Background()
+	ts, _ := tenant.NewStore(inmem.NewKVStore())
+	ten := tenant.NewService(ts)
+
+	u := &influxdb.User{Name: "analyst"}
+	if err := ten.CreateUser(ctx, u); err != nil {
+		t.Fatal(err)
+	}
+
+	readBuckets := influxdb.Permission{
+		Action: influxdb.ReadAction,
+		Resource: influxdb.Resource{
+			Type:  influxdb.BucketsResourceType,
+			OrgID: influxdbtesting.IDPtr(1),
+		},
+	}
+	roles := mock.NewRoleService()
+	roles.FindRoleByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Role, error) {
+		if id != 10 {
+			return nil, &influxdb.Error{Code: influxdb.ENotFound, Msg: influxdb.ErrRoleNotFound}
+		}
+		return &influxdb.Role{ID: 10, OrgID: 1, Permissions: []influxdb.Permission{readBuckets}}, nil
+	}
+
+	for _, id := range []influxdb.ID{10, 20} {
+		err := ten.CreateUserResourceMapping(ctx, &influxdb.UserResourceMapping{
+			UserID:       u.ID,
+			UserType:     influxdb.Member,
+			ResourceType: influxdb.RolesResourceType,
+			ResourceID:   id,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	svc := NewService(NewStorage(inmem.NewSessionStore()), ten, ten, &mock.AuthorizationService{
+		FindAuthorizationsFn: func(context.Context, influxdb.AuthorizationFilter, ...influxdb.FindOptions) ([]*influxdb.Authorization, int, error) {
+			return []*influxdb.Authorization{}, 0, nil
+		},
+	}, time.Minute)
+
+	ps, err := svc.getPermissionSet(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if influxdb.PermissionAllowed(readBuckets, ps) {
+		t.Fatalf("expected role permissions to be ignored without a role service")
+	}
+
+	svc.WithRoleService(roles)
+	ps, err = svc.getPermissionSet(ctx, u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !influxdb.PermissionAllowed(readBuckets, ps) {
+		t.Fatalf("expected the permissions of role 10 to be granted, got %v", ps)
+	}
+}
@@ -0,0 +1,142 @@
+package influxdb
+
+import (
+	"context"
+	"time"
+)
+
+// ErrSilenceNotFound is the error msg for a missing silence.
+const ErrSilenceNotFound = "silence not found"
+
+// ops for silence error.
+const (
+	OpFindSilenceByID = "FindSilenceByID"
+	OpFindSilences    = "FindSilences"
+	OpCreateSilence   = "CreateSilence"
+	OpUpdateSilence   = "UpdateSilence"
+	OpDeleteSilence   = "DeleteSilence"
+)
+
+// SilenceService represents a service for managing silences.
+type SilenceService interface {
+	// FindSilenceByID returns a single silence by ID.
+	FindSilenceByID(ctx context.Context, id ID) (*Silence, error)
+
+	// FindSilences returns a list of silences that match filter and the total count of matching silences.
+	// Additional options provide pagination & sorting.
+	FindSilences(ctx context.Context, filter SilenceFilter, opt ...FindOptions) ([]*Silence, int, error)
+
+	// CreateSilence creates a new silence and sets s.ID with the new identifier.
+	CreateSilence(ctx context.Context, s *Silence) error
+
+	// UpdateSilence updates a single silence with a changeset.
+	// Returns the new silence state after update.
+	UpdateSilence(ctx context.Context, id ID, upd SilenceUpdate) (*Silence, error)
+
+	// DeleteSilence removes a silence by ID.
+	DeleteSilence(ctx context.Context, id ID) error
+}
+
+// Silence mutes the notification rules of an organization during a
+// maintenance window. A status is silenced when its time is within
+// [StartsAt, EndsAt), it was written by one of CheckIDs (if any) and it
+// has all the Matchers tags. Silenced notifications are logged but not sent.
+type Silence struct {
+	ID        ID        `json:"id,omitempty"`
+	OrgID     ID        `json:"orgID,omitempty"`
+	Matchers  []Tag     `json:"matchers,omitempty"`
+	CheckIDs  []ID      `json:"checkIDs,omitempty"`
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	CreatedBy ID        `json:"createdBy,omitempty"`
+	Comment   string    `json:"comment,omitempty"`
+	CRUDLog
+}
+
+// Valid returns an error if the silence is invalid.
+func (s *Silence) Valid() error {
+	if !s.OrgID.Valid() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence orgID is invalid",
+		}
+	}
+	if len(s.Matchers) == 0 && len(s.CheckIDs) == 0 {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence must have at least one matcher or check ID",
+		}
+	}
+	for _, m := range s.Matchers {
+		if err := m.Valid(); err != nil {
+			return err
+		}
+	}
+	for _, id := range s.CheckIDs {
+		if !id.Valid() {
+			return &Error{
+				Code: EInvalid,
+				Msg:  "silence check ID is invalid",
+			}
+		}
+	}
+	if s.StartsAt.IsZero() || s.EndsAt.IsZero() {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence startsAt and endsAt are required",
+		}
+	}
+	if !s.EndsAt.After(s.StartsAt) {
+		return &Error{
+			Code: EInvalid,
+			Msg:  "silence endsAt must be after startsAt",
+		}
+	}
+	return nil
+}
+
+// Active returns true if the silence mutes notifications at t.
+func (s *Silence) Active(t time.Time) bool {
+	return !t.Before(s.StartsAt) && t.Before(s.EndsAt)
+}
+
+// Expired returns true if the silence no longer mutes notifications at t.
+func (s *Silence) Expired(t time.Time) bool {
+	return !t.Before(s.EndsAt)
+}
+
+// SilenceFilter represents a set of filter that restrict the returned silences.
+type SilenceFilter struct {
+	OrgID        *ID
+	Organization *string
+	// Active returns only the silences active at the given time.
+	Active *time.Time
+}
+
+// SilenceUpdate is the changeset of a silence. Nil fields are left unchanged.
+type SilenceUpdate struct {
+	Matchers []Tag      `json:"matchers,omitempty"`
+	CheckIDs []ID       `json:"checkIDs,omitempty"`
+	StartsAt *time.Time `json:"startsAt,omitempty"`
+	EndsAt   *time.Time `json:"endsAt,omitempty"`
+	Comment  *string    `json:"comment,omitempty"`
+}
+
+// Apply applies the changeset to the silence.
+func (u SilenceUpdate) Apply(s *Silence) {
+	if u.Matchers != nil {
+		s.Matchers = u.Matchers
+	}
+	if u.CheckIDs != nil {
+		s.CheckIDs = u.CheckIDs
+	}
+	if u.StartsAt != nil {
+		s.StartsAt = *u.StartsAt
+	}
+	if u.EndsAt != nil {
+		s.EndsAt = *u.EndsAt
+	}
+	if u.Comment != nil {
+		s.Comment = *u.Comment
+	}
+}
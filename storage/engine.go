@@ -5,7 +5,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"math"
 	"os"
 	"path/filepath"
@@ -682,37 +681,41 @@ func (e *Engine) deleteBucketRangeLocked(ctx context.Context, orgID, bucketID in
 	return e.engine.DeletePrefixRange(ctx, name, min, max, pred)
 }
 
-// CreateBackup creates a "snapshot" of all TSM data in the Engine.
+// CreateBackup creates a "snapshot" of the TSM data in the Engine matching the filter.
 //   1) Snapshot the cache to ensure the backup includes all data written before now.
 //   2) Create hard links to all TSM files, in a new directory within the engine root directory.
-//   3) Return a unique backup ID (invalid after the process terminates) and list of files.
-func (e *Engine) CreateBackup(ctx context.Context) (int, []string, error) {
+//   3) Rewrite the TSM files to the buckets selected by the filter.
+//   4) Drop the files that are unchanged since the base backup of the filter.
+//   5) Return a manifest with a unique backup ID (invalid after the process terminates) and the files.
+func (e *Engine) CreateBackup(ctx context.Context, filter influxdb.BackupFilter) (*influxdb.BackupManifest, error) {
 	span, ctx := tracing.StartSpanFromContext(ctx)
 	defer span.Finish()
 
 	if e.closing == nil {
-		return 0, nil, ErrEngineClosed
+		return nil, ErrEngineClosed
 	}
 
 	if err := e.engine.WriteSnapshot(ctx, tsm1.CacheStatusBackup); err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
 	id, snapshotPath, err := e.engine.FileStore.CreateSnapshot(ctx)
 	if err != nil {
-		return 0, nil, err
+		return nil, err
 	}
 
-	fileInfos, err := ioutil.ReadDir(snapshotPath)
+	files, err := newBackupFiles(snapshotPath, filter)
 	if err != nil {
-		return 0, nil, err
-	}
-	filenames := make([]string, len(fileInfos))
-	for i, fi := range fileInfos {
-		filenames[i] = fi.Name()
+		return nil, multierr.Append(err, os.RemoveAll(snapshotPath))
 	}
 
-	return id, filenames, nil
+	return &influxdb.BackupManifest{
+		ID:        id,
+		CreatedAt: time.Now().UTC(),
+		OrgID:     filter.OrgID,
+		BucketID:  filter.BucketID,
+		Files:     files,
+	}, nil
 }
 
 // FetchBackupFile writes a given backup file to the provided writer.
@@ -0,0 +1,295 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/internal/fs"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/tsdb"
+	"github.com/influxdata/influxdb/v2/tsdb/tsm1"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+)
+
+// newBackupFiles prepares the files of the snapshot in dir for the backup
+// described by filter and returns their manifest entries.
+//
+// If the filter selects buckets, the TSM files are rewritten to contain only
+// the data of the selected buckets with tombstones applied, and the tombstone
+// files are dropped. Files matching a file of the base backup are removed from
+// dir and marked as such in the manifest.
+//
+// The files are only checksummed to be compared with the files of the base
+// backup, the client checksums the files it fetches otherwise.
+func newBackupFiles(dir string, filter influxdb.BackupFilter) ([]influxdb.BackupFile, error) {
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := filter.OrgID != nil || filter.BucketID != nil
+	base := make(map[string]string, len(filter.Since))
+	for _, f := range filter.Since {
+		base[f.FileName] = f.Checksum
+	}
+
+	files := make([]influxdb.BackupFile, 0, len(fileInfos))
+	for _, fi := range fileInfos {
+		path := filepath.Join(dir, fi.Name())
+
+		var buckets []influxdb.BackupBucket
+		if strings.HasSuffix(fi.Name(), "."+tsm1.TSMFileExtension) {
+			if filtered {
+				buckets, err = filterTSMFile(path, filter)
+			} else {
+				buckets, err = tsmFileBuckets(path)
+			}
+			if err != nil {
+				return nil, errors.WithMessagef(err, "failed to read TSM file %s", fi.Name())
+			}
+			if len(buckets) == 0 {
+				if err := os.Remove(path); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		files = append(files, influxdb.BackupFile{
+			FileName: fi.Name(),
+			Buckets:  buckets,
+		})
+	}
+
+	// Tombstones are applied to the rewritten TSM files of a filtered backup.
+	if filtered {
+		keep := files[:0]
+		for _, f := range files {
+			if strings.HasSuffix(f.FileName, "."+tsm1.TSMFileExtension) {
+				keep = append(keep, f)
+				continue
+			}
+			if err := os.Remove(filepath.Join(dir, f.FileName)); err != nil {
+				return nil, err
+			}
+		}
+		files = keep
+	}
+
+	if len(base) == 0 {
+		return files, nil
+	}
+	for i := range files {
+		f := &files[i]
+		path := filepath.Join(dir, f.FileName)
+		if f.Checksum, f.Size, err = fs.Checksum(path); err != nil {
+			return nil, err
+		}
+		if sum, ok := base[f.FileName]; ok && sum == f.Checksum {
+			if err := os.Remove(path); err != nil {
+				return nil, err
+			}
+			f.Base = true
+		}
+	}
+	return files, nil
+}
+
+// tsmFileBuckets returns the buckets with data in the TSM file at path. Only
+// the first key of each bucket is read, the others are skipped by starting a
+// new iterator after them.
+func tsmFileBuckets(path string) ([]influxdb.BackupBucket, error) {
+	r, err := openTSMFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var buckets backupBuckets
+	iter := r.Iterator(nil)
+	for iter.Next() {
+		key := iter.Key()
+		orgID, bucketID := tsmKeyBucket(key)
+		buckets.add(orgID, bucketID)
+		if end := tsmBucketKeysEnd(key, orgID, bucketID); end != nil {
+			if err := iter.Err(); err != nil {
+				return nil, err
+			}
+			iter = r.Iterator(end)
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return buckets.list(), nil
+}
+
+// filterTSMFile rewrites the TSM file at path to contain only the blocks of
+// the buckets matching filter, and returns those buckets. The file is
+// replaced rather than modified, leaving the hard linked source untouched.
+func filterTSMFile(path string, filter influxdb.BackupFilter) (_ []influxdb.BackupBucket, err error) {
+	r, err := openTSMFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	tmpPath := path + "." + tsm1.TmpTSMFileExtension
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return nil, err
+	}
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		return nil, multierr.Append(err, f.Close())
+	}
+	written := false
+	defer func() {
+		if !written {
+			err = multierr.Append(err, w.Remove())
+		}
+	}()
+
+	var buckets backupBuckets
+	err = copyTSMBlocks(r, w, func(key []byte) []byte {
+		if !filter.Matches(tsmKeyBucket(key)) {
+			return nil
+		}
+		return key
+	}, func(key []byte, _ byte) {
+		buckets.add(tsmKeyBucket(key))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(buckets) == 0 {
+		return nil, nil
+	}
+	if err := w.WriteIndex(); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	written = true
+	if err := os.Remove(tsm1.StatsFilename(tmpPath)); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return nil, err
+	}
+	return buckets.list(), nil
+}
+
+// copyTSMBlocks writes the blocks of r to w with the tombstones of r applied.
+// The blocks are written with the key returned by mapKey, or skipped if it
+// returns nil. The keys returned by mapKey must preserve the order of the keys
+// of r. written is called with the new key and block type of each written block.
+func copyTSMBlocks(r *tsm1.TSMReader, w tsm1.TSMWriter, mapKey func(key []byte) []byte, written func(key []byte, typ byte)) error {
+	iter := r.BlockIterator()
+	for iter.Next() {
+		key, minTime, maxTime, typ, _, block, err := iter.Read()
+		if err != nil {
+			return err
+		}
+		newKey := mapKey(key)
+		if newKey == nil {
+			continue
+		}
+
+		tombstones := r.TombstoneRange(key, nil)
+		if len(tombstones) == 0 {
+			if err := w.WriteBlock(newKey, minTime, maxTime, block); err != nil {
+				return err
+			}
+			written(newKey, typ)
+			continue
+		}
+
+		values, err := tsm1.DecodeBlock(block, nil)
+		if err != nil {
+			return err
+		}
+		for _, tr := range tombstones {
+			values = tsm1.Values(values).Exclude(tr.Min, tr.Max)
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if err := w.Write(newKey, values); err != nil {
+			return err
+		}
+		written(newKey, typ)
+	}
+	return iter.Err()
+}
+
+func openTSMFile(path string) (*tsm1.TSMReader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	r, err := tsm1.NewTSMReader(f)
+	if err != nil {
+		return nil, multierr.Append(err, f.Close())
+	}
+	return r, nil
+}
+
+// tsmKeyBucket returns the organization and bucket of a TSM key.
+func tsmKeyBucket(key []byte) (orgID, bucketID influxdb.ID) {
+	seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+	name := models.ParseName(seriesKey)
+	if len(name) != len(tsdb.EncodeName(0, 0)) {
+		return 0, 0
+	}
+	return tsdb.DecodeNameSlice(name)
+}
+
+// tsmBucketKeysEnd returns the smallest key greater than the keys with the
+// escaped org and bucket prefix of key, or nil if there is none.
+func tsmBucketKeysEnd(key []byte, orgID, bucketID influxdb.ID) []byte {
+	name := tsdb.EncodeName(orgID, bucketID)
+	prefix := models.EscapeMeasurement(name[:])
+	if !bytes.HasPrefix(key, prefix) {
+		return nil
+	}
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i]++; end[i] != 0 {
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// backupBuckets is the set of buckets in a backup file.
+type backupBuckets map[influxdb.BackupBucket]struct{}
+
+func (b *backupBuckets) add(orgID, bucketID influxdb.ID) {
+	if *b == nil {
+		*b = make(backupBuckets)
+	}
+	(*b)[influxdb.BackupBucket{OrgID: orgID, BucketID: bucketID}] = struct{}{}
+}
+
+func (b backupBuckets) list() []influxdb.BackupBucket {
+	buckets := make([]influxdb.BackupBucket, 0, len(b))
+	for bucket := range b {
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].OrgID != buckets[j].OrgID {
+			return buckets[i].OrgID < buckets[j].OrgID
+		}
+		return buckets[i].BucketID < buckets[j].BucketID
+	})
+	return buckets
+}
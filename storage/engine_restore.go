@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/kit/tracing"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/tsdb"
+	"github.com/influxdata/influxdb/v2/tsdb/tsm1"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// restoreSeriesBatchSize is the number of series added to the index at once
+// when restoring a bucket.
+const restoreSeriesBatchSize = 10000
+
+// RestoreBucketFile imports the data of the source bucket in the backup TSM
+// file at path into the target bucket of the running engine.
+//   1) Rewrite the blocks of the source bucket into a new TSM file of the engine,
+//      remapping the keys to the target bucket and applying the tombstones of the file.
+//   2) Add the series of the target bucket to the series file and index.
+//   3) Add the new TSM file to the file store, split by shard group.
+func (e *Engine) RestoreBucketFile(ctx context.Context, source, target influxdb.BackupBucket, path string) (err error) {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closing == nil {
+		return ErrEngineClosed
+	}
+
+	r, err := openTSMFile(path)
+	if err != nil {
+		return errors.WithMessage(err, "failed to open backup TSM file")
+	}
+	defer r.Close()
+
+	tmpPath := e.engine.NextTSMFilePath()
+	f, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return err
+	}
+	w, err := tsm1.NewTSMWriter(f)
+	if err != nil {
+		return multierr.Append(err, f.Close())
+	}
+	written := false
+	defer func() {
+		if !written {
+			err = multierr.Append(err, w.Remove())
+		}
+	}()
+
+	targetName := tsdb.EncodeName(target.OrgID, target.BucketID)
+	collection := &tsdb.SeriesCollection{
+		Keys:  make([][]byte, 0, restoreSeriesBatchSize),
+		Names: make([][]byte, 0, restoreSeriesBatchSize),
+		Tags:  make([]models.Tags, 0, restoreSeriesBatchSize),
+		Types: make([]models.FieldType, 0, restoreSeriesBatchSize),
+	}
+	var (
+		lastKey []byte
+		n       int
+	)
+	err = copyTSMBlocks(r, w, func(key []byte) []byte {
+		if orgID, bucketID := tsmKeyBucket(key); orgID != source.OrgID || bucketID != source.BucketID {
+			return nil
+		}
+		seriesKey, field := tsm1.SeriesAndFieldFromCompositeKey(key)
+		_, tags := models.ParseKeyBytes(seriesKey)
+		return tsm1.AppendSeriesFieldKeyBytes(nil, models.MakeKey(targetName[:], tags), field)
+	}, func(key []byte, typ byte) {
+		n++
+		if bytes.Equal(key, lastKey) {
+			return
+		}
+		lastKey = key
+
+		seriesKey, _ := tsm1.SeriesAndFieldFromCompositeKey(key)
+		name, tags := models.ParseKeyBytes(seriesKey)
+		collection.Keys = append(collection.Keys, seriesKey)
+		collection.Names = append(collection.Names, name)
+		collection.Tags = append(collection.Tags, tags)
+		collection.Types = append(collection.Types, blockTypeFieldType(typ))
+	})
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return nil
+	}
+	if err := w.WriteIndex(); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	written = true
+
+	for i := 0; i < collection.Length(); i += restoreSeriesBatchSize {
+		end := i + restoreSeriesBatchSize
+		if end > collection.Length() {
+			end = collection.Length()
+		}
+		batch := &tsdb.SeriesCollection{
+			Keys:  collection.Keys[i:end],
+			Names: collection.Names[i:end],
+			Tags:  collection.Tags[i:end],
+			Types: collection.Types[i:end],
+		}
+		if err := e.index.CreateSeriesListIfNotExists(batch); err != nil {
+			return multierr.Append(err, removeTSMFile(tmpPath))
+		}
+	}
+
+	if err := e.engine.FileStore.Replace(nil, []string{tmpPath}); err != nil {
+		return multierr.Append(err, removeTSMFile(tmpPath))
+	}
+	if err := e.engine.SplitShardGroups(ctx); err != nil {
+		return errors.WithMessage(err, "failed to split restored TSM file by shard group")
+	}
+
+	e.logger.Info("Restored bucket data",
+		zap.String("source_bucket_id", source.BucketID.String()),
+		zap.String("target_bucket_id", target.BucketID.String()),
+		zap.Int("series", collection.Length()),
+		zap.Int("blocks", n))
+	return nil
+}
+
+// removeTSMFile removes a TSM file that was not added to the file store.
+func removeTSMFile(path string) error {
+	if err := os.Remove(tsm1.StatsFilename(path)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return os.Remove(path)
+}
+
+func blockTypeFieldType(typ byte) models.FieldType {
+	switch typ {
+	case tsm1.BlockFloat64:
+		return models.Float
+	case tsm1.BlockInteger:
+		return models.Integer
+	case tsm1.BlockBoolean:
+		return models.Boolean
+	case tsm1.BlockString:
+		return models.String
+	case tsm1.BlockUnsigned:
+		return models.Unsigned
+	default:
+		return models.Empty
+	}
+}
@@ -2,11 +2,15 @@ package storage_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io/ioutil"
 	"math"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -16,6 +20,7 @@ import (
 	"github.com/influxdata/influxdb/v2/storage"
 	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
 	"github.com/influxdata/influxdb/v2/tsdb"
+	"github.com/influxdata/influxdb/v2/tsdb/cursors"
 	"github.com/influxdata/influxdb/v2/tsdb/tsm1"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -352,6 +357,195 @@ func TestEngine_DeleteBucket_Predicate(t *testing.T) {
 
 }
 
+func TestEngine_CreateBackup(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	otherBucket := influxdb.ID(0x3333333333333333)
+	writePoint := func(bucketID influxdb.ID, ts int64) {
+		t.Helper()
+		err := engine.Engine.WritePoints(context.TODO(), []models.Point{models.MustNewPoint(
+			tsdb.EncodeNameString(engine.org, bucketID),
+			models.NewTags(map[string]string{models.FieldKeyTagKey: "value", models.MeasurementTagKey: "cpu", "host": "server"}),
+			map[string]interface{}{"value": 1.0},
+			time.Unix(0, ts),
+		)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	// fetchAll fetches the files of the backup and checksums them like the
+	// client does when the server did not.
+	fetchAll := func(m *influxdb.BackupManifest) {
+		t.Helper()
+		for i := range m.Files {
+			f := &m.Files[i]
+			if f.Base {
+				continue
+			}
+			h := sha256.New()
+			if err := engine.FetchBackupFile(context.Background(), m.ID, f.FileName, h); err != nil {
+				t.Fatal(err)
+			}
+			if f.Checksum == "" {
+				f.Checksum = hex.EncodeToString(h.Sum(nil))
+			}
+		}
+	}
+
+	writePoint(engine.bucket, 1)
+	writePoint(otherBucket, 1)
+
+	full, err := engine.CreateBackup(context.Background(), influxdb.BackupFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetchAll(full)
+	// The data of each bucket is written to its own TSM file.
+	if got, exp := len(full.Files), 2; got != exp {
+		t.Fatalf("got %d files, exp %d", got, exp)
+	}
+	for _, f := range full.Files {
+		if got, exp := len(f.Buckets), 1; got != exp {
+			t.Fatalf("got %d buckets, exp %d", got, exp)
+		}
+	}
+	if full.Files[0].Buckets[0] == full.Files[1].Buckets[0] {
+		t.Fatalf("got bucket %v in both files", full.Files[0].Buckets[0])
+	}
+
+	filtered, err := engine.CreateBackup(context.Background(), influxdb.BackupFilter{BucketID: &engine.bucket})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetchAll(filtered)
+	if got, exp := len(filtered.Files), 1; got != exp {
+		t.Fatalf("got %d files, exp %d", got, exp)
+	}
+	f := filtered.Files[0]
+	if exp := []influxdb.BackupBucket{{OrgID: engine.org, BucketID: engine.bucket}}; len(f.Buckets) != 1 || f.Buckets[0] != exp[0] {
+		t.Fatalf("got buckets %v, exp %v", f.Buckets, exp)
+	}
+
+	writePoint(engine.bucket, 2)
+
+	incremental, err := engine.CreateBackup(context.Background(), influxdb.BackupFilter{Since: full.Files})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fetchAll(incremental)
+	if got, exp := len(incremental.Files), 3; got != exp {
+		t.Fatalf("got %d files, exp %d", got, exp)
+	}
+	checksums := make(map[string]string)
+	for _, f := range full.Files {
+		checksums[f.FileName] = f.Checksum
+	}
+	var base int
+	for _, f := range incremental.Files {
+		if f.Base {
+			base++
+			if checksum, ok := checksums[f.FileName]; !ok || f.Checksum != checksum {
+				t.Fatalf("unexpected base file %+v", f)
+			}
+		}
+	}
+	if base != 2 {
+		t.Fatalf("got %d base files, exp 2", base)
+	}
+}
+
+func TestEngine_RestoreBucketFile(t *testing.T) {
+	engine := NewDefaultEngine()
+	defer engine.Close()
+	engine.MustOpen()
+
+	tags := models.NewTags(map[string]string{models.FieldKeyTagKey: "value", models.MeasurementTagKey: "cpu", "host": "server"})
+	for _, ts := range []int64{1, 2, 3} {
+		err := engine.Engine.WritePoints(context.TODO(), []models.Point{models.MustNewPoint(
+			tsdb.EncodeNameString(engine.org, engine.bucket),
+			tags,
+			map[string]interface{}{"value": float64(ts)},
+			time.Unix(0, ts),
+		)})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Snapshot the cache so the delete below creates a tombstone.
+	if _, err := engine.CreateBackup(context.Background(), influxdb.BackupFilter{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.DeleteBucketRange(context.Background(), engine.org, engine.bucket, 2, 2); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest, err := engine.CreateBackup(context.Background(), influxdb.BackupFilter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "storage_restore_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var tsmPath string
+	for _, f := range manifest.Files {
+		path := filepath.Join(dir, f.FileName)
+		w, err := os.Create(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := engine.FetchBackupFile(context.Background(), manifest.ID, f.FileName, w); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+		if strings.HasSuffix(path, ".tsm") {
+			tsmPath = path
+		}
+	}
+
+	source := influxdb.BackupBucket{OrgID: engine.org, BucketID: engine.bucket}
+	target := influxdb.BackupBucket{OrgID: 0x4141414141414141, BucketID: 0x4242424242424242}
+	if err := engine.RestoreBucketFile(context.Background(), source, target, tsmPath); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, exp := engine.SeriesCardinality(), int64(2); got != exp {
+		t.Fatalf("got %d series, exp %d series in index", got, exp)
+	}
+
+	itr, err := engine.CreateCursorIterator(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := tsdb.EncodeName(target.OrgID, target.BucketID)
+	cur, err := itr.Next(context.Background(), &cursors.CursorRequest{
+		Name:      name[:],
+		Tags:      tags,
+		Field:     "value",
+		Ascending: true,
+		StartTime: 0,
+		EndTime:   10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fcur, ok := cur.(cursors.FloatArrayCursor)
+	if !ok {
+		t.Fatalf("unexpected cursor type %T", cur)
+	}
+	defer fcur.Close()
+
+	a := fcur.Next()
+	if got, exp := a.Values, []float64{1, 3}; len(got) != len(exp) || got[0] != exp[0] || got[1] != exp[1] {
+		t.Fatalf("got values %v, exp %v", got, exp)
+	}
+}
+
 func TestEngine_OpenClose(t *testing.T) {
 	engine := NewDefaultEngine()
 	engine.MustOpen()
@@ -0,0 +1,72 @@
+package reads
+
+import (
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/models"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+)
+
+// RestrictPredicate returns a predicate that matches the series that match p and
+// are within one of the scopes. p may be nil to match all series. p is returned
+// unchanged if scopes is nil, as the access to the bucket is not restricted.
+func RestrictPredicate(p *datatypes.Predicate, scopes []influxdb.PermissionScope) *datatypes.Predicate {
+	if scopes == nil {
+		return p
+	}
+
+	nodes := make([]*datatypes.Node, 0, len(scopes))
+	for _, s := range scopes {
+		nodes = append(nodes, scopeNode(s))
+	}
+	root := logicalNode(datatypes.LogicalOr, nodes...)
+	if p != nil && p.Root != nil {
+		root = logicalNode(datatypes.LogicalAnd, parenNode(p.Root), parenNode(root))
+	}
+	return &datatypes.Predicate{Root: root}
+}
+
+// scopeNode returns the node matching the series within the scope.
+func scopeNode(s influxdb.PermissionScope) *datatypes.Node {
+	var nodes []*datatypes.Node
+	if len(s.Measurements) > 0 {
+		measurements := make([]*datatypes.Node, 0, len(s.Measurements))
+		for _, m := range s.Measurements {
+			measurements = append(measurements, tagEqualNode(models.MeasurementTagKey, m))
+		}
+		nodes = append(nodes, parenNode(logicalNode(datatypes.LogicalOr, measurements...)))
+	}
+	for _, t := range s.Tags {
+		nodes = append(nodes, tagEqualNode(t.Key, t.Value))
+	}
+	return parenNode(logicalNode(datatypes.LogicalAnd, nodes...))
+}
+
+func tagEqualNode(key, value string) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeComparisonExpression,
+		Value:    &datatypes.Node_Comparison_{Comparison: datatypes.ComparisonEqual},
+		Children: []*datatypes.Node{
+			{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: key}},
+			{NodeType: datatypes.NodeTypeLiteral, Value: &datatypes.Node_StringValue{StringValue: value}},
+		},
+	}
+}
+
+// logicalNode combines the nodes with op. A single node is returned as is.
+func logicalNode(op datatypes.Node_Logical, nodes ...*datatypes.Node) *datatypes.Node {
+	if len(nodes) == 1 {
+		return nodes[0]
+	}
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeLogicalExpression,
+		Value:    &datatypes.Node_Logical_{Logical: op},
+		Children: nodes,
+	}
+}
+
+func parenNode(n *datatypes.Node) *datatypes.Node {
+	return &datatypes.Node{
+		NodeType: datatypes.NodeTypeParenExpression,
+		Children: []*datatypes.Node{n},
+	}
+}
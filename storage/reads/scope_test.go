@@ -0,0 +1,62 @@
+package reads_test
+
+import (
+	"testing"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/storage/reads"
+	"github.com/influxdata/influxdb/v2/storage/reads/datatypes"
+)
+
+func TestRestrictPredicate(t *testing.T) {
+	hostPredicate := &datatypes.Predicate{
+		Root: &datatypes.Node{
+			NodeType: datatypes.NodeTypeComparisonExpression,
+			Value:    &datatypes.Node_Comparison_{Comparison: datatypes.ComparisonEqual},
+			Children: []*datatypes.Node{
+				{NodeType: datatypes.NodeTypeTagRef, Value: &datatypes.Node_TagRefValue{TagRefValue: "host"}},
+				{NodeType: datatypes.NodeTypeLiteral, Value: &datatypes.Node_StringValue{StringValue: "host1"}},
+			},
+		},
+	}
+	payments := influxdb.PermissionScope{
+		Measurements: []string{"cpu", "mem"},
+		Tags:         []influxdb.Tag{{Key: "team", Value: "payments"}},
+	}
+	billing := influxdb.PermissionScope{
+		Tags: []influxdb.Tag{{Key: "team", Value: "billing"}},
+	}
+
+	cases := []struct {
+		n      string
+		p      *datatypes.Predicate
+		scopes []influxdb.PermissionScope
+		e      string
+	}{
+		{
+			n: "unrestricted",
+			p: hostPredicate,
+			e: `'host' = "host1"`,
+		},
+		{
+			n:      "restricted without predicate",
+			scopes: []influxdb.PermissionScope{billing},
+			e:      `( 'team' = "billing" )`,
+		},
+		{
+			n:      "restricted predicate",
+			p:      hostPredicate,
+			scopes: []influxdb.PermissionScope{payments, billing},
+			e:      "( 'host' = \"host1\" ) AND ( ( ( '\x00' = \"cpu\" OR '\x00' = \"mem\" ) AND 'team' = \"payments\" ) OR ( 'team' = \"billing\" ) )",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.n, func(t *testing.T) {
+			got := reads.PredicateToExprString(reads.RestrictPredicate(tc.p, tc.scopes))
+			if got != tc.e {
+				t.Fatal("got:", got, "wanted:", tc.e)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"time"
 
@@ -17,6 +18,10 @@ const (
 	TaskDefaultPageSize = 100
 	TaskMaxPageSize     = 500
 
+	// TaskDefaultMaxBackfillRuns is the default maximum number of runs a single
+	// backfill may enqueue, enough for a year of hourly runs.
+	TaskDefaultMaxBackfillRuns = 10000
+
 	// TODO(jsteenb2): make these constants of type Status
 
 	TaskStatusActive   = "active"
@@ -56,6 +61,7 @@ type Task struct {
 	Every           string                 `json:"every,omitempty"`
 	Cron            string                 `json:"cron,omitempty"`
 	Offset          time.Duration          `json:"offset,omitempty"`
+	DependsOn       []ID                   `json:"dependsOn,omitempty"`
 	LatestCompleted time.Time              `json:"latestCompleted,omitempty"`
 	LatestScheduled time.Time              `json:"latestScheduled,omitempty"`
 	LastRunStatus   string                 `json:"lastRunStatus,omitempty"`
@@ -80,17 +86,105 @@ func (t *Task) EffectiveCron() string {
 	return ""
 }
 
+// TaskGraph is the dependency graph of a task. It holds the task together with
+// the tasks it transitively depends on and the tasks that transitively depend on it.
+type TaskGraph struct {
+	Tasks []TaskGraphNode `json:"tasks"`
+	Edges []TaskGraphEdge `json:"edges"`
+}
+
+// TaskGraphNode is a task in a TaskGraph.
+type TaskGraphNode struct {
+	ID            ID     `json:"id"`
+	Name          string `json:"name"`
+	Status        string `json:"status"`
+	LastRunStatus string `json:"lastRunStatus,omitempty"`
+}
+
+// TaskGraphEdge is a dependency in a TaskGraph, the run of the To task waits on
+// the run of the From task that is scheduled for the same time.
+type TaskGraphEdge struct {
+	From ID `json:"from"`
+	To   ID `json:"to"`
+}
+
+// NewTaskGraph returns the dependency graph of the task with the given id,
+// the graph is made of the provided tasks.
+func NewTaskGraph(id ID, tasks []*Task) (*TaskGraph, error) {
+	byID := make(map[ID]*Task, len(tasks))
+	downstream := make(map[ID][]ID)
+	for _, t := range tasks {
+		byID[t.ID] = t
+		for _, upID := range t.DependsOn {
+			downstream[upID] = append(downstream[upID], t.ID)
+		}
+	}
+	if _, ok := byID[id]; !ok {
+		return nil, ErrTaskNotFound
+	}
+
+	inGraph := map[ID]bool{id: true}
+	walk := func(next func(id ID) []ID) {
+		queue := []ID{id}
+		for len(queue) > 0 {
+			current := queue[0]
+			queue = queue[1:]
+			for _, nextID := range next(current) {
+				if _, ok := byID[nextID]; !ok || inGraph[nextID] {
+					continue
+				}
+				inGraph[nextID] = true
+				queue = append(queue, nextID)
+			}
+		}
+	}
+	walk(func(id ID) []ID { return byID[id].DependsOn })
+	walk(func(id ID) []ID { return downstream[id] })
+
+	g := &TaskGraph{
+		Tasks: []TaskGraphNode{},
+		Edges: []TaskGraphEdge{},
+	}
+	for _, t := range tasks {
+		if !inGraph[t.ID] {
+			continue
+		}
+		g.Tasks = append(g.Tasks, TaskGraphNode{
+			ID:            t.ID,
+			Name:          t.Name,
+			Status:        t.Status,
+			LastRunStatus: t.LastRunStatus,
+		})
+		for _, upID := range t.DependsOn {
+			if inGraph[upID] {
+				g.Edges = append(g.Edges, TaskGraphEdge{From: upID, To: t.ID})
+			}
+		}
+	}
+	sort.Slice(g.Tasks, func(i, j int) bool {
+		return g.Tasks[i].ID < g.Tasks[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	return g, nil
+}
+
 // Run is a record createId when a run of a task is scheduled.
 type Run struct {
-	ID           ID        `json:"id,omitempty"`
-	TaskID       ID        `json:"taskID"`
-	Status       string    `json:"status"`
-	ScheduledFor time.Time `json:"scheduledFor"`          // ScheduledFor is the Now time used in the task's query
-	RunAt        time.Time `json:"runAt"`                 // RunAt is the time the task is scheduled to be run, which is ScheduledFor + Offset
-	StartedAt    time.Time `json:"startedAt,omitempty"`   // StartedAt is the time the executor begins running the task
-	FinishedAt   time.Time `json:"finishedAt,omitempty"`  // FinishedAt is the time the executor finishes running the task
-	RequestedAt  time.Time `json:"requestedAt,omitempty"` // RequestedAt is the time the coordinator told the scheduler to schedule the task
-	Log          []Log     `json:"log,omitempty"`
+	ID           ID          `json:"id,omitempty"`
+	TaskID       ID          `json:"taskID"`
+	Status       string      `json:"status"`
+	ScheduledFor time.Time   `json:"scheduledFor"`          // ScheduledFor is the Now time used in the task's query
+	RunAt        time.Time   `json:"runAt"`                 // RunAt is the time the task is scheduled to be run, which is ScheduledFor + Offset
+	StartedAt    time.Time   `json:"startedAt,omitempty"`   // StartedAt is the time the executor begins running the task
+	FinishedAt   time.Time   `json:"finishedAt,omitempty"`  // FinishedAt is the time the executor finishes running the task
+	RequestedAt  time.Time   `json:"requestedAt,omitempty"` // RequestedAt is the time the coordinator told the scheduler to schedule the task
+	Log          []Log       `json:"log,omitempty"`
+	Results      []RunResult `json:"results,omitempty"` // Results are the first rows of the results yielded by the run, if the task captures them
 }
 
 // Log represents a link to a log resource
@@ -104,6 +198,19 @@ func (l Log) String() string {
 	return l.Time + ": " + l.Message
 }
 
+// RunResult holds the first rows of a result yielded by a run.
+type RunResult struct {
+	Name      string           `json:"name"`
+	Tables    []RunResultTable `json:"tables"`
+	Truncated bool             `json:"truncated,omitempty"` // Truncated is true if the result had more rows than were captured
+}
+
+// RunResultTable holds the captured rows of a table of a run result.
+type RunResultTable struct {
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
 // TaskService represents a service for managing one-off and recurring tasks.
 type TaskService interface {
 	// FindTaskByID returns a single task
@@ -141,6 +248,14 @@ type TaskService interface {
 	// ForceRun forces a run to occur with unix timestamp scheduledFor, to be executed as soon as possible.
 	// The value of scheduledFor may or may not align with the task's schedule.
 	ForceRun(ctx context.Context, taskID ID, scheduledFor int64) (*Run, error)
+
+	// BackfillTask enqueues a run for every time the task is scheduled for within [start, stop),
+	// to be executed as soon as possible. The progress of the backfill is reported by FindRuns.
+	BackfillTask(ctx context.Context, taskID ID, start, stop time.Time) ([]*Run, error)
+
+	// CancelBackfill removes the enqueued runs of the task that have not started yet,
+	// and cancels the runs of its backfill that are running.
+	CancelBackfill(ctx context.Context, taskID ID) error
 }
 
 // TaskCreate is the set of values to create a task.
@@ -484,6 +599,7 @@ const (
 	RunFail
 	RunCanceled
 	RunScheduled
+	RunSkipped
 )
 
 func (r RunStatus) String() string {
@@ -498,6 +614,8 @@ func (r RunStatus) String() string {
 		return "canceled"
 	case RunScheduled:
 		return "scheduled"
+	case RunSkipped:
+		return "skipped"
 	}
 	panic(fmt.Sprintf("unknown RunStatus: %d", r))
 }
@@ -22,6 +22,7 @@ const (
 	finishedAtField   = "finishedAt"
 	requestedAtField  = "requestedAt"
 	logField          = "logs"
+	resultsField      = "results"
 
 	taskIDTag = "taskID"
 	statusTag = "status"
@@ -152,6 +153,21 @@ func (as *AnalyticalStorage) FindRuns(ctx context.Context, filter influxdb.RunFi
 		filterPart = fmt.Sprintf(`|> filter(fn: (r) => r.runID > %q)`, filter.After.String())
 	}
 
+	// scheduledFor is a field, the time range can only be filtered once the fields are pivoted
+	timePart := ""
+	if filter.AfterTime != "" {
+		if _, err := time.Parse(time.RFC3339, filter.AfterTime); err != nil {
+			return nil, 0, &influxdb.Error{Code: influxdb.EInvalid, Msg: "afterTime is not an RFC3339 time", Err: err}
+		}
+		timePart += fmt.Sprintf(`|> filter(fn: (r) => time(v: r.scheduledFor) > time(v: %q))`, filter.AfterTime)
+	}
+	if filter.BeforeTime != "" {
+		if _, err := time.Parse(time.RFC3339, filter.BeforeTime); err != nil {
+			return nil, 0, &influxdb.Error{Code: influxdb.EInvalid, Msg: "beforeTime is not an RFC3339 time", Err: err}
+		}
+		timePart += fmt.Sprintf(`|> filter(fn: (r) => time(v: r.scheduledFor) < time(v: %q))`, filter.BeforeTime)
+	}
+
 	// the data will be stored for 7 days in the system bucket so pulling 14d's is sufficient.
 	runsScript := fmt.Sprintf(`from(bucketID: %q)
 	  |> range(start: -14d)
@@ -159,11 +175,12 @@ func (as *AnalyticalStorage) FindRuns(ctx context.Context, filter influxdb.RunFi
 	  |> filter(fn: (r) => r._measurement == "runs" and r.taskID == %q)
 	  %s
 	  |> pivot(rowKey:["_time"], columnKey: ["_field"], valueColumn: "_value")
+	  %s
 	  |> group(columns: ["taskID"])
 	  |> sort(columns:["scheduledFor"], desc: true)
 	  |> limit(n:%d)
 
-	  `, sb.ID.String(), filter.Task.String(), filterPart, filter.Limit-len(runs))
+	  `, sb.ID.String(), filter.Task.String(), filterPart, timePart, filter.Limit-len(runs))
 
 	// At this point we are behind authorization
 	// so we are faking a read only permission to the org's system bucket
@@ -407,6 +424,14 @@ func (re *runReader) readRuns(cr flux.ColReader) error {
 						re.log.Info("Failed to parse log data", zap.Error(err), zap.ByteString("log_bytes", logBytes))
 					}
 				}
+			case resultsField:
+				resultsBytes := bytes.TrimSpace(cr.Strings(j).Value(i))
+				if len(resultsBytes) != 0 {
+					err := json.Unmarshal(resultsBytes, &r.Results)
+					if err != nil {
+						re.log.Info("Failed to parse results data", zap.Error(err), zap.ByteString("results_bytes", resultsBytes))
+					}
+				}
 			}
 		}
 
@@ -113,3 +113,47 @@ func TaskNotifyCoordinatorOfExisting(ctx context.Context, ts TaskService, tcs Ta
 
 	return nil
 }
+
+// BackfillRunFinder is a type on which the enqueued runs of the backfills of a
+// task can be found.
+type BackfillRunFinder interface {
+	FindBackfillRuns(ctx context.Context, taskID influxdb.ID) ([]*influxdb.Run, error)
+}
+
+// Backfiller is a type with a single method which is called when runs of a
+// task have been backfilled.
+type Backfiller interface {
+	TaskBackfilled(ctx context.Context, task *influxdb.Task, runs []*influxdb.Run) error
+}
+
+// NotifyBackfillerOfExisting lists all tasks by the provided task service and for
+// each task with backfilled runs that are still enqueued it calls the provided
+// backfillers task backfilled method, so that backfills carry on after a restart.
+func NotifyBackfillerOfExisting(ctx context.Context, log *zap.Logger, ts TaskService, rf BackfillRunFinder, b Backfiller) error {
+	tasks, _, err := ts.FindTasks(ctx, influxdb.TaskFilter{})
+	if err != nil {
+		return err
+	}
+
+	for len(tasks) > 0 {
+		for _, task := range tasks {
+			runs, err := rf.FindBackfillRuns(ctx, task.ID)
+			if err != nil {
+				log.Error("Failed to find backfilled runs", zap.String("taskID", task.ID.String()), zap.Error(err))
+				continue
+			}
+			if err := b.TaskBackfilled(ctx, task, runs); err != nil {
+				return err
+			}
+		}
+
+		tasks, _, err = ts.FindTasks(ctx, influxdb.TaskFilter{
+			After: &tasks[len(tasks)-1].ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
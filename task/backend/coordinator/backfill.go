@@ -0,0 +1,138 @@
+package coordinator
+
+import (
+	"context"
+	"sync"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/task/options"
+	"go.uber.org/zap"
+)
+
+// backfill is the queue of the enqueued runs of a task that are being executed
+// in the background.
+type backfill struct {
+	runs   []*influxdb.Run
+	cancel context.CancelFunc
+}
+
+// WithFluxLanguageService sets the language service used to read the
+// concurrency option of backfilled tasks.
+func WithFluxLanguageService(lang influxdb.FluxLanguageService) CoordinatorOption {
+	return func(c *Coordinator) {
+		c.lang = lang
+	}
+}
+
+// TaskBackfilled executes the runs of the backfill in the background, in order and
+// with at most the concurrency of the task running at once. If the task is already
+// being backfilled the runs are added to its queue.
+func (c *Coordinator) TaskBackfilled(ctx context.Context, task *influxdb.Task, runs []*influxdb.Run) error {
+	if len(runs) == 0 {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if b, ok := c.backfills[task.ID]; ok {
+		b.runs = append(b.runs, runs...)
+		return nil
+	}
+
+	// the backfill outlives the request that started it
+	bctx, cancel := context.WithCancel(context.Background())
+	b := &backfill{runs: runs, cancel: cancel}
+	c.backfills[task.ID] = b
+
+	go c.runBackfill(bctx, task, b)
+	return nil
+}
+
+// BackfillCancelled stops the backfill of the task and cancels its running runs.
+func (c *Coordinator) BackfillCancelled(ctx context.Context, taskID influxdb.ID) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if b, ok := c.backfills[taskID]; ok {
+		b.runs = nil
+		b.cancel()
+		delete(c.backfills, taskID)
+	}
+	return nil
+}
+
+func (c *Coordinator) runBackfill(ctx context.Context, task *influxdb.Task, b *backfill) {
+	log := c.log.With(zap.String("taskID", task.ID.String()))
+	slots := make(chan struct{}, c.backfillConcurrency(task))
+	var wg sync.WaitGroup
+
+	for {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+
+		run, ok := c.nextBackfillRun(task.ID, b, &wg)
+		if !ok {
+			return
+		}
+
+		// canceling ctx cancels the promises of the backfill
+		promise, err := c.ex.ManualRun(ctx, task.ID, run.ID)
+		if err != nil {
+			log.Info("Failed to start backfilled run", zap.String("runID", run.ID.String()), zap.Error(err))
+			<-slots
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-promise.Done()
+			<-slots
+		}()
+	}
+}
+
+// nextBackfillRun pops the next run of the backfill. Once the queue is empty it
+// waits for the running runs to finish and removes the backfill, unless more
+// runs were added in the meantime.
+func (c *Coordinator) nextBackfillRun(taskID influxdb.ID, b *backfill, running *sync.WaitGroup) (*influxdb.Run, bool) {
+	for {
+		c.mu.Lock()
+		if len(b.runs) > 0 {
+			run := b.runs[0]
+			b.runs = b.runs[1:]
+			c.mu.Unlock()
+			return run, true
+		}
+		c.mu.Unlock()
+
+		running.Wait()
+
+		c.mu.Lock()
+		if len(b.runs) == 0 {
+			if c.backfills[taskID] == b {
+				delete(c.backfills, taskID)
+			}
+			b.cancel()
+			c.mu.Unlock()
+			return nil, false
+		}
+		c.mu.Unlock()
+	}
+}
+
+// backfillConcurrency returns the number of runs of the task a backfill may run at once.
+func (c *Coordinator) backfillConcurrency(task *influxdb.Task) int {
+	if c.lang == nil {
+		return 1
+	}
+	opts, err := options.FromScript(c.lang, task.Flux)
+	if err != nil || opts.Concurrency == nil {
+		return 1
+	}
+	return int(*opts.Concurrency)
+}
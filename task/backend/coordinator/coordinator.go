@@ -3,6 +3,7 @@ package coordinator
 import (
 	"context"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/influxdata/influxdb/v2"
@@ -31,6 +32,10 @@ type Coordinator struct {
 	ex  Executor
 
 	limit int
+	lang  influxdb.FluxLanguageService
+
+	mu        sync.Mutex
+	backfills map[influxdb.ID]*backfill
 }
 
 type CoordinatorOption func(*Coordinator)
@@ -96,6 +101,8 @@ func NewCoordinator(log *zap.Logger, scheduler scheduler.Scheduler, executor Exe
 		sch:   scheduler,
 		ex:    executor,
 		limit: DefaultLimit,
+
+		backfills: make(map[influxdb.ID]*backfill),
 	}
 
 	for _, opt := range opts {
@@ -140,17 +147,27 @@ func (c *Coordinator) TaskUpdated(ctx context.Context, from, to *influxdb.Task)
 		}
 	}
 
+	c.taskChanged(to.ID)
 	return nil
 }
 
-//TaskDeleted asks the Scheduler to release the deleted task
+// TaskDeleted asks the Scheduler to release the deleted task and stops its backfill
 func (c *Coordinator) TaskDeleted(ctx context.Context, id influxdb.ID) error {
 	tid := scheduler.ID(id)
 	if err := c.sch.Release(tid); err != nil && err != influxdb.ErrTaskNotClaimed {
 		return err
 	}
 
-	return nil
+	c.taskChanged(id)
+	return c.BackfillCancelled(ctx, id)
+}
+
+// taskChanged lets the runs held on the runs of the task know it changed, when
+// the executor holds runs until their dependencies finish.
+func (c *Coordinator) taskChanged(id influxdb.ID) {
+	if g, ok := c.ex.(*DependencyGate); ok {
+		g.TaskChanged(id)
+	}
 }
 
 // RunCancelled speaks directly to the executor to cancel a task run
@@ -94,6 +94,72 @@ func Test_Coordinator_Executor_Methods(t *testing.T) {
 	}
 }
 
+func Test_Coordinator_Backfill(t *testing.T) {
+	var (
+		taskOne = &influxdb.Task{ID: influxdb.ID(1)}
+		runs    = []*influxdb.Run{{ID: 1, TaskID: 1}, {ID: 2, TaskID: 1}, {ID: 3, TaskID: 1}}
+	)
+
+	waitFor := func(t *testing.T, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatal("timed out waiting for backfill")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	t.Run("runs in order one at a time", func(t *testing.T) {
+		ex := &backfillExecutor{runFor: 10 * time.Millisecond}
+		coord := NewCoordinator(zaptest.NewLogger(t), &schedulerC{}, ex)
+
+		if err := coord.TaskBackfilled(context.Background(), taskOne, runs); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(t, func() bool {
+			coord.mu.Lock()
+			defer coord.mu.Unlock()
+			return len(coord.backfills) == 0
+		})
+
+		started, running, maxRunning := ex.state()
+		if exp := []influxdb.ID{1, 2, 3}; !cmp.Equal(started, exp) {
+			t.Errorf("unexpected started runs -got/+exp\n%s", cmp.Diff(started, exp))
+		}
+		if running != 0 || maxRunning != 1 {
+			t.Errorf("expected runs to execute one at a time, got %d running and at most %d", running, maxRunning)
+		}
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		ex := &backfillExecutor{runFor: time.Hour}
+		coord := NewCoordinator(zaptest.NewLogger(t), &schedulerC{}, ex)
+
+		if err := coord.TaskBackfilled(context.Background(), taskOne, runs); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(t, func() bool {
+			started, _, _ := ex.state()
+			return len(started) == 1
+		})
+
+		if err := coord.BackfillCancelled(context.Background(), taskOne.ID); err != nil {
+			t.Fatal(err)
+		}
+		waitFor(t, func() bool {
+			_, running, _ := ex.state()
+			return running == 0
+		})
+
+		started, _, _ := ex.state()
+		if exp := []influxdb.ID{1}; !cmp.Equal(started, exp) {
+			t.Errorf("unexpected started runs -got/+exp\n%s", cmp.Diff(started, exp))
+		}
+	})
+}
+
 func TestNewSchedulableTask(t *testing.T) {
 	now := time.Now().UTC()
 	one := influxdb.ID(1)
@@ -251,7 +317,7 @@ func Test_Coordinator_Scheduler_Methods(t *testing.T) {
 			if diff := cmp.Diff(
 				test.scheduler.calls,
 				sch.calls,
-				cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{}, *coord),
+				cmp.AllowUnexported(executorE{}, schedulerC{}, SchedulableTask{}, Coordinator{}),
 				cmpopts.IgnoreUnexported(scheduler.Schedule{}),
 			); diff != "" {
 				t.Errorf("unexpected scheduler contents %s", diff)
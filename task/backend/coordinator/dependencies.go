@@ -0,0 +1,416 @@
+package coordinator
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/task/backend"
+	"github.com/influxdata/influxdb/v2/task/backend/executor"
+	"github.com/influxdata/influxdb/v2/task/backend/scheduler"
+	"go.uber.org/zap"
+)
+
+var _ scheduler.Executor = (*DependencyGate)(nil)
+var _ Executor = (*DependencyGate)(nil)
+var _ GatedExecutor = (*executor.Executor)(nil)
+
+// dependencyRetryInterval is how long a held run waits to check its dependencies
+// again after failing to look them up.
+var dependencyRetryInterval = time.Second
+
+// GatedExecutor is an abstraction of the task executor with the functions
+// needed by the DependencyGate.
+type GatedExecutor interface {
+	PromisedExecute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) (executor.Promise, error)
+	ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error)
+	ResumeCurrentRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error)
+	ExecuteRun(ctx context.Context, run *influxdb.Run) (executor.Promise, error)
+	SkipRun(ctx context.Context, task *influxdb.Task, run *influxdb.Run, reason string) error
+	Cancel(ctx context.Context, runID influxdb.ID) error
+}
+
+// DependencyGate sits in front of the executor, for both the scheduler and the
+// coordinator. It holds the runs of tasks with dependencies until the runs of
+// their upstream tasks scheduled for the same time have finished, so that held
+// runs do not take up the workers of the executor.
+//
+// Held runs are checked again whenever a run of one of their upstream tasks
+// finishes, or one of their upstream tasks is updated or deleted.
+type DependencyGate struct {
+	log *zap.Logger
+	ex  GatedExecutor
+	ts  influxdb.TaskService
+	tcs backend.TaskControlService
+
+	mu   sync.Mutex
+	held map[influxdb.ID]*heldRun
+}
+
+// NewDependencyGate returns a DependencyGate handing the runs to ex once their
+// dependencies have finished.
+func NewDependencyGate(log *zap.Logger, ex GatedExecutor, ts influxdb.TaskService, tcs backend.TaskControlService) *DependencyGate {
+	return &DependencyGate{
+		log:  log,
+		ex:   ex,
+		ts:   ts,
+		tcs:  tcs,
+		held: make(map[influxdb.ID]*heldRun),
+	}
+}
+
+// Execute creates the run of the task scheduled for scheduledFor, and executes
+// it once its dependencies have finished.
+func (g *DependencyGate) Execute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) error {
+	t, err := g.ts.FindTaskByID(ctx, influxdb.ID(id))
+	if err != nil {
+		return err
+	}
+	if len(t.DependsOn) == 0 {
+		p, err := g.ex.PromisedExecute(ctx, id, scheduledFor, runAt)
+		if err != nil {
+			return err
+		}
+		g.watch(t.ID, p)
+		return nil
+	}
+
+	r, err := g.tcs.CreateRun(ctx, t.ID, scheduledFor.UTC(), runAt.UTC())
+	if err != nil {
+		return err
+	}
+	g.hold(ctx, t, r)
+	return nil
+}
+
+// ManualRun starts the manual run of the task once its dependencies have finished.
+func (g *DependencyGate) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
+	t, err := g.ts.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.DependsOn) == 0 {
+		p, err := g.ex.ManualRun(ctx, id, runID)
+		if err != nil {
+			return nil, err
+		}
+		g.watch(t.ID, p)
+		return p, nil
+	}
+
+	r, err := g.tcs.StartManualRun(ctx, id, runID)
+	if err != nil {
+		return nil, err
+	}
+	return g.hold(ctx, t, r), nil
+}
+
+// ResumeCurrentRun resumes the currently running run of the task, holding it
+// again if it was held when it was stopped.
+func (g *DependencyGate) ResumeCurrentRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
+	t, err := g.ts.FindTaskByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.DependsOn) == 0 {
+		p, err := g.ex.ResumeCurrentRun(ctx, id, runID)
+		if err != nil {
+			return nil, err
+		}
+		g.watch(t.ID, p)
+		return p, nil
+	}
+
+	g.mu.Lock()
+	h, ok := g.held[runID]
+	g.mu.Unlock()
+	if ok {
+		return h, nil
+	}
+
+	runs, err := g.tcs.CurrentlyRunning(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range runs {
+		if r.ID == runID {
+			return g.hold(ctx, t, r), nil
+		}
+	}
+	return nil, influxdb.ErrRunNotFound
+}
+
+// Cancel cancels the run, whether it is held or already executing.
+func (g *DependencyGate) Cancel(ctx context.Context, runID influxdb.ID) error {
+	g.mu.Lock()
+	h, ok := g.held[runID]
+	if ok {
+		delete(g.held, runID)
+	}
+	g.mu.Unlock()
+
+	if !ok {
+		return g.ex.Cancel(ctx, runID)
+	}
+
+	now := time.Now().UTC()
+	g.tcs.AddRunLog(h.ctx, h.task.ID, h.run.ID, now, "Run canceled")
+	g.tcs.UpdateRunState(h.ctx, h.task.ID, h.run.ID, now, influxdb.RunCanceled)
+	if _, err := g.tcs.FinishRun(h.ctx, h.task.ID, h.run.ID); err != nil {
+		g.log.Error("Failed to finish run", zap.String("taskID", h.task.ID.String()), zap.String("runID", h.run.ID.String()), zap.Error(err))
+	}
+	h.finish(influxdb.ErrRunCanceled)
+	g.upstreamFinished(h.task.ID)
+	return nil
+}
+
+// TaskChanged checks the held runs of the tasks depending on the task again,
+// after it was updated or deleted.
+func (g *DependencyGate) TaskChanged(id influxdb.ID) {
+	g.upstreamFinished(id)
+}
+
+// hold holds the run of the task until its dependencies have finished.
+func (g *DependencyGate) hold(ctx context.Context, t *influxdb.Task, r *influxdb.Run) *heldRun {
+	h := &heldRun{
+		g:        g,
+		task:     t,
+		run:      r,
+		ctx:      ctx,
+		released: make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	g.mu.Lock()
+	g.held[r.ID] = h
+	g.mu.Unlock()
+
+	// canceling the context of a manual run cancels it while it is held
+	go func() {
+		select {
+		case <-ctx.Done():
+			g.Cancel(context.Background(), r.ID)
+		case <-h.released:
+		case <-h.done:
+		}
+	}()
+
+	g.check(h)
+	return h
+}
+
+// check hands the held run to the executor if its dependencies succeeded, or
+// skips it if one of them did not.
+func (g *DependencyGate) check(h *heldRun) {
+	state, msg, err := g.checkDependencies(h.ctx, h.task, h.run.ScheduledFor)
+	if err != nil {
+		g.log.Info("Error checking task dependencies", zap.Error(err), zap.String("taskID", h.task.ID.String()))
+		time.AfterFunc(dependencyRetryInterval, func() { g.check(h) })
+		return
+	}
+
+	g.mu.Lock()
+	if g.held[h.run.ID] != h {
+		// released or canceled in the meantime
+		g.mu.Unlock()
+		return
+	}
+	if state == dependenciesPending {
+		logged := msg == h.waitingOn
+		h.waitingOn = msg
+		g.mu.Unlock()
+		if !logged {
+			// add to the run log once for every upstream run we wait on
+			g.tcs.AddRunLog(h.ctx, h.task.ID, h.run.ID, time.Now().UTC(), msg)
+		}
+		return
+	}
+	delete(g.held, h.run.ID)
+	close(h.released)
+	g.mu.Unlock()
+
+	if state == dependenciesFailed {
+		if err := g.ex.SkipRun(h.ctx, h.task, h.run, msg); err != nil {
+			g.log.Error("Failed to skip run", zap.String("taskID", h.task.ID.String()), zap.String("runID", h.run.ID.String()), zap.Error(err))
+		}
+		h.finish(influxdb.ErrRunSkipped)
+		g.upstreamFinished(h.task.ID)
+		return
+	}
+
+	p, err := g.ex.ExecuteRun(h.ctx, h.run)
+	if err != nil {
+		g.log.Error("Failed to execute run", zap.String("taskID", h.task.ID.String()), zap.String("runID", h.run.ID.String()), zap.Error(err))
+		h.finish(err)
+		return
+	}
+	go func() {
+		<-p.Done()
+		h.finish(p.Error())
+		g.upstreamFinished(h.task.ID)
+	}()
+}
+
+// watch checks the held runs depending on the task again once the run of the
+// promise has finished.
+func (g *DependencyGate) watch(taskID influxdb.ID, p executor.Promise) {
+	go func() {
+		<-p.Done()
+		g.upstreamFinished(taskID)
+	}()
+}
+
+// upstreamFinished checks the held runs of the tasks depending on the task again.
+func (g *DependencyGate) upstreamFinished(taskID influxdb.ID) {
+	g.mu.Lock()
+	var dependents []*heldRun
+	for _, h := range g.held {
+		for _, id := range h.task.DependsOn {
+			if id == taskID {
+				dependents = append(dependents, h)
+				break
+			}
+		}
+	}
+	g.mu.Unlock()
+
+	for _, h := range dependents {
+		g.check(h)
+	}
+}
+
+// dependencyState is the state of the runs of the upstream tasks of a run.
+type dependencyState int
+
+const (
+	// dependenciesSucceeded means the runs of all upstream tasks succeeded.
+	dependenciesSucceeded dependencyState = iota
+	// dependenciesPending means a run of an upstream task has not finished yet.
+	dependenciesPending
+	// dependenciesFailed means a run of an upstream task did not succeed, or never will.
+	dependenciesFailed
+)
+
+// checkDependencies returns the state of the runs of the upstream tasks of the
+// task that are scheduled for scheduledFor, along with a message describing it.
+func (g *DependencyGate) checkDependencies(ctx context.Context, t *influxdb.Task, scheduledFor time.Time) (dependencyState, string, error) {
+	for _, upstreamID := range t.DependsOn {
+		state, msg, err := g.checkDependency(ctx, upstreamID, scheduledFor)
+		if err != nil || state != dependenciesSucceeded {
+			return state, msg, err
+		}
+	}
+	return dependenciesSucceeded, "", nil
+}
+
+func (g *DependencyGate) checkDependency(ctx context.Context, upstreamID influxdb.ID, scheduledFor time.Time) (dependencyState, string, error) {
+	upstream, err := g.ts.FindTaskByID(ctx, upstreamID)
+	if influxdb.ErrorCode(err) == influxdb.ENotFound {
+		return dependenciesFailed, fmt.Sprintf("upstream task %s does not exist", upstreamID), nil
+	}
+	if err != nil {
+		return dependenciesPending, "", err
+	}
+
+	// the run of the upstream task may still be held or running
+	running, err := g.tcs.CurrentlyRunning(ctx, upstreamID)
+	if err != nil {
+		return dependenciesPending, "", err
+	}
+	manual, err := g.tcs.ManualRuns(ctx, upstreamID)
+	if err != nil {
+		return dependenciesPending, "", err
+	}
+	for _, run := range append(running, manual...) {
+		if run.ScheduledFor.Equal(scheduledFor) {
+			return dependenciesPending, fmt.Sprintf("Waiting on run %s of upstream task %s", run.ID, upstreamID), nil
+		}
+	}
+
+	if upstream.LatestCompleted.Before(scheduledFor) {
+		if upstream.Status != string(influxdb.TaskActive) {
+			return dependenciesFailed, fmt.Sprintf("upstream task %s is inactive", upstreamID), nil
+		}
+		return dependenciesPending, fmt.Sprintf("Waiting on upstream task %s", upstreamID), nil
+	}
+
+	status := upstream.LastRunStatus
+	if !upstream.LatestCompleted.Equal(scheduledFor) {
+		// the upstream task already completed later runs, look the run up in its history
+		status = ""
+		runs, _, err := g.ts.FindRuns(ctx, influxdb.RunFilter{
+			Task:       upstreamID,
+			AfterTime:  scheduledFor.Add(-time.Second).UTC().Format(time.RFC3339),
+			BeforeTime: scheduledFor.Add(time.Second).UTC().Format(time.RFC3339),
+			Limit:      1,
+		})
+		if err != nil {
+			return dependenciesPending, "", err
+		}
+		for _, run := range runs {
+			if run.ScheduledFor.Equal(scheduledFor) {
+				status = run.Status
+				break
+			}
+		}
+		if status == "" {
+			return dependenciesFailed, fmt.Sprintf("upstream task %s has no run scheduled for %s", upstreamID, scheduledFor.UTC().Format(time.RFC3339)), nil
+		}
+	}
+
+	if status != influxdb.RunSuccess.String() {
+		return dependenciesFailed, fmt.Sprintf("run of upstream task %s did not succeed: %s", upstreamID, status), nil
+	}
+	return dependenciesSucceeded, "", nil
+}
+
+// heldRun is the promise of a run held by the DependencyGate.
+type heldRun struct {
+	g    *DependencyGate
+	task *influxdb.Task
+	run  *influxdb.Run
+	ctx  context.Context
+
+	// waitingOn is the message of the upstream run last logged as waited on.
+	waitingOn string
+
+	released chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	err      error
+}
+
+// ID is the id of the held run.
+func (h *heldRun) ID() influxdb.ID {
+	return h.run.ID
+}
+
+// Cancel cancels the run, waiting for it to finish unless ctx is done first.
+func (h *heldRun) Cancel(ctx context.Context) {
+	h.g.Cancel(ctx, h.run.ID)
+
+	select {
+	case <-h.Done():
+	case <-ctx.Done():
+	}
+}
+
+// Done provides a channel that closes once the run has finished.
+func (h *heldRun) Done() <-chan struct{} {
+	return h.done
+}
+
+// Error returns the error resulting from the run, waiting for it to finish.
+func (h *heldRun) Error() error {
+	<-h.done
+	return h.err
+}
+
+func (h *heldRun) finish(err error) {
+	h.once.Do(func() {
+		h.err = err
+		close(h.done)
+	})
+}
@@ -0,0 +1,187 @@
+package coordinator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	pmock "github.com/influxdata/influxdb/v2/mock"
+	"github.com/influxdata/influxdb/v2/task/backend/scheduler"
+	"github.com/influxdata/influxdb/v2/task/mock"
+	"go.uber.org/zap/zaptest"
+)
+
+func Test_DependencyGate(t *testing.T) {
+	var (
+		upstreamID   = influxdb.ID(1)
+		downstreamID = influxdb.ID(2)
+		scheduledFor = time.Unix(3600, 0).UTC()
+	)
+
+	// newGate returns a gate over an upstream task and a task depending on it.
+	newGate := func(t *testing.T) (*DependencyGate, *gateExecutor, *mock.TaskControlService, func(influxdb.RunStatus)) {
+		var mu sync.Mutex
+		tasks := map[influxdb.ID]*influxdb.Task{
+			upstreamID:   {ID: upstreamID, Status: string(influxdb.TaskActive)},
+			downstreamID: {ID: downstreamID, Status: string(influxdb.TaskActive), DependsOn: []influxdb.ID{upstreamID}},
+		}
+		ts := pmock.NewTaskService()
+		ts.FindTaskByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Task, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			task, ok := tasks[id]
+			if !ok {
+				return nil, influxdb.ErrTaskNotFound
+			}
+			cp := *task
+			return &cp, nil
+		}
+
+		tcs := mock.NewTaskControlService()
+		for _, task := range tasks {
+			cp := *task
+			tcs.SetTask(&cp)
+		}
+		ex := newGateExecutor(tcs)
+
+		// finishUpstream finishes the scheduled run of the upstream task.
+		finishUpstream := func(rs influxdb.RunStatus) {
+			mu.Lock()
+			tasks[upstreamID].LatestCompleted = scheduledFor
+			tasks[upstreamID].LastRunStatus = rs.String()
+			mu.Unlock()
+			ex.finish(upstreamID, rs)
+		}
+		return NewDependencyGate(zaptest.NewLogger(t), ex, ts, tcs), ex, tcs, finishUpstream
+	}
+
+	// heldRunID returns the ID of the run of the downstream task held by the gate.
+	heldRunID := func(t *testing.T, g *DependencyGate) influxdb.ID {
+		t.Helper()
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		for id, h := range g.held {
+			if h.task.ID == downstreamID {
+				return id
+			}
+		}
+		t.Fatal("downstream run is not held")
+		return 0
+	}
+
+	waitFor := func(t *testing.T, cond func() bool) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatal("timed out waiting for the gate")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	for _, tt := range []struct {
+		name         string
+		upstream     influxdb.RunStatus
+		wantExecuted bool
+	}{
+		{name: "upstream succeeds", upstream: influxdb.RunSuccess, wantExecuted: true},
+		{name: "upstream fails", upstream: influxdb.RunFail},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			g, ex, _, finishUpstream := newGate(t)
+
+			if err := g.Execute(context.Background(), scheduler.ID(downstreamID), scheduledFor, scheduledFor); err != nil {
+				t.Fatal(err)
+			}
+			if err := g.Execute(context.Background(), scheduler.ID(upstreamID), scheduledFor, scheduledFor); err != nil {
+				t.Fatal(err)
+			}
+			runID := heldRunID(t, g)
+			if executed, skipped := ex.state(); len(executed)+len(skipped) > 0 {
+				t.Fatal("downstream run was released before the upstream run finished")
+			}
+
+			finishUpstream(tt.upstream)
+			waitFor(t, func() bool {
+				executed, skipped := ex.state()
+				return len(executed)+len(skipped) > 0
+			})
+
+			executed, skipped := ex.state()
+			want := []influxdb.ID{runID}
+			if !tt.wantExecuted {
+				executed, skipped = skipped, executed
+			}
+			if !cmp.Equal(executed, want) || len(skipped) > 0 {
+				t.Errorf("unexpected runs, executed %v and skipped %v", executed, skipped)
+			}
+		})
+	}
+
+	t.Run("cancel held run", func(t *testing.T) {
+		g, ex, tcs, finishUpstream := newGate(t)
+
+		if err := g.Execute(context.Background(), scheduler.ID(downstreamID), scheduledFor, scheduledFor); err != nil {
+			t.Fatal(err)
+		}
+		if err := g.Execute(context.Background(), scheduler.ID(upstreamID), scheduledFor, scheduledFor); err != nil {
+			t.Fatal(err)
+		}
+		runID := heldRunID(t, g)
+		if err := g.Cancel(context.Background(), runID); err != nil {
+			t.Fatal(err)
+		}
+		if run := tcs.FinishedRun(runID); run == nil || run.Status != influxdb.RunCanceled.String() {
+			t.Fatalf("expected canceled run, got %+v", run)
+		}
+
+		finishUpstream(influxdb.RunSuccess)
+		time.Sleep(10 * time.Millisecond)
+		if executed, skipped := ex.state(); len(executed)+len(skipped) > 0 {
+			t.Errorf("canceled run was released, executed %v and skipped %v", executed, skipped)
+		}
+	})
+	t.Run("upstream completed later runs", func(t *testing.T) {
+		upstream := &influxdb.Task{
+			ID:              upstreamID,
+			Status:          string(influxdb.TaskActive),
+			LatestCompleted: scheduledFor.Add(time.Hour),
+			LastRunStatus:   influxdb.RunFail.String(),
+		}
+		ts := pmock.NewTaskService()
+		ts.FindTaskByIDFn = func(_ context.Context, id influxdb.ID) (*influxdb.Task, error) {
+			return upstream, nil
+		}
+		var gotFilter influxdb.RunFilter
+		ts.FindRunsFn = func(_ context.Context, f influxdb.RunFilter) ([]*influxdb.Run, int, error) {
+			gotFilter = f
+			run := &influxdb.Run{ID: 3, TaskID: upstreamID, ScheduledFor: scheduledFor, Status: influxdb.RunSuccess.String()}
+			return []*influxdb.Run{run}, 1, nil
+		}
+		tcs := mock.NewTaskControlService()
+		tcs.SetTask(upstream)
+		g := NewDependencyGate(zaptest.NewLogger(t), newGateExecutor(tcs), ts, tcs)
+
+		state, msg, err := g.checkDependency(context.Background(), upstreamID, scheduledFor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if state != dependenciesSucceeded {
+			t.Errorf("expected the dependency to succeed, got %v: %s", state, msg)
+		}
+
+		wantFilter := influxdb.RunFilter{
+			Task:       upstreamID,
+			AfterTime:  "1970-01-01T00:59:59Z",
+			BeforeTime: "1970-01-01T01:00:01Z",
+			Limit:      1,
+		}
+		if diff := cmp.Diff(wantFilter, gotFilter); diff != "" {
+			t.Errorf("unexpected run filter -want/+got:\n%s", diff)
+		}
+	})
+}
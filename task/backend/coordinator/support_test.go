@@ -2,13 +2,19 @@ package coordinator
 
 import (
 	"context"
+	"errors"
+	"sync"
+	"time"
 
 	"github.com/influxdata/influxdb/v2"
 	"github.com/influxdata/influxdb/v2/task/backend/executor"
 	"github.com/influxdata/influxdb/v2/task/backend/scheduler"
+	"github.com/influxdata/influxdb/v2/task/mock"
 )
 
 var _ Executor = (*executorE)(nil)
+var _ Executor = (*backfillExecutor)(nil)
+var _ GatedExecutor = (*gateExecutor)(nil)
 
 type (
 	executorE struct {
@@ -112,3 +118,133 @@ func (e *executorE) Cancel(ctx context.Context, runID influxdb.ID) error {
 	e.calls = append(e.calls, cancelCallC{runID})
 	return nil
 }
+
+// backfillExecutor executes each run for runFor, or until it is canceled.
+type backfillExecutor struct {
+	runFor time.Duration
+
+	mu         sync.Mutex
+	started    []influxdb.ID
+	running    int
+	maxRunning int
+}
+
+func (e *backfillExecutor) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
+	e.mu.Lock()
+	e.started = append(e.started, runID)
+	e.running++
+	if e.running > e.maxRunning {
+		e.maxRunning = e.running
+	}
+	e.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	p := &promise{
+		run:        &influxdb.Run{ID: runID, TaskID: id},
+		done:       make(chan struct{}),
+		ctx:        ctx,
+		cancelFunc: cancel,
+	}
+	go func() {
+		select {
+		case <-time.After(e.runFor):
+		case <-ctx.Done():
+			p.err = influxdb.ErrRunCanceled
+		}
+		e.mu.Lock()
+		e.running--
+		e.mu.Unlock()
+		close(p.done)
+	}()
+	return p, nil
+}
+
+func (e *backfillExecutor) Cancel(ctx context.Context, runID influxdb.ID) error {
+	return nil
+}
+
+func (e *backfillExecutor) state() (started []influxdb.ID, running, maxRunning int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]influxdb.ID(nil), e.started...), e.running, e.maxRunning
+}
+
+// gateExecutor records the runs handed to it by a DependencyGate. The runs of
+// scheduled tasks without dependencies run until they are finished by the test.
+type gateExecutor struct {
+	tcs *mock.TaskControlService
+
+	mu       sync.Mutex
+	promises map[influxdb.ID]*promise
+	executed []influxdb.ID
+	skipped  []influxdb.ID
+}
+
+func newGateExecutor(tcs *mock.TaskControlService) *gateExecutor {
+	return &gateExecutor{tcs: tcs, promises: make(map[influxdb.ID]*promise)}
+}
+
+func (e *gateExecutor) PromisedExecute(ctx context.Context, id scheduler.ID, scheduledFor time.Time, runAt time.Time) (executor.Promise, error) {
+	run, err := e.tcs.CreateRun(ctx, influxdb.ID(id), scheduledFor, runAt)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	p := &promise{
+		run:        run,
+		done:       make(chan struct{}),
+		ctx:        ctx,
+		cancelFunc: cancel,
+	}
+
+	e.mu.Lock()
+	e.promises[influxdb.ID(id)] = p
+	e.mu.Unlock()
+	return p, nil
+}
+
+// finish finishes the run of the task with the status.
+func (e *gateExecutor) finish(taskID influxdb.ID, rs influxdb.RunStatus) {
+	e.mu.Lock()
+	p := e.promises[taskID]
+	e.mu.Unlock()
+
+	e.tcs.UpdateRunState(context.Background(), taskID, p.run.ID, time.Now(), rs)
+	e.tcs.FinishRun(context.Background(), taskID, p.run.ID)
+	close(p.done)
+}
+
+func (e *gateExecutor) ManualRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
+	return nil, errors.New("unexpected manual run")
+}
+
+func (e *gateExecutor) ResumeCurrentRun(ctx context.Context, id influxdb.ID, runID influxdb.ID) (executor.Promise, error) {
+	return nil, errors.New("unexpected resumed run")
+}
+
+func (e *gateExecutor) ExecuteRun(ctx context.Context, run *influxdb.Run) (executor.Promise, error) {
+	e.mu.Lock()
+	e.executed = append(e.executed, run.ID)
+	e.mu.Unlock()
+
+	p := &promise{run: run, done: make(chan struct{})}
+	close(p.done)
+	return p, nil
+}
+
+func (e *gateExecutor) SkipRun(ctx context.Context, task *influxdb.Task, run *influxdb.Run, reason string) error {
+	e.mu.Lock()
+	e.skipped = append(e.skipped, run.ID)
+	e.mu.Unlock()
+	return nil
+}
+
+func (e *gateExecutor) Cancel(ctx context.Context, runID influxdb.ID) error {
+	return nil
+}
+
+func (e *gateExecutor) state() (executed, skipped []influxdb.ID) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]influxdb.ID(nil), e.executed...), append([]influxdb.ID(nil), e.skipped...)
+}
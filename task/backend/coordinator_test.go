@@ -67,8 +67,38 @@ func Test_NotifyCoordinatorOfCreated(t *testing.T) {
 	}
 }
 
+func Test_NotifyBackfillerOfExisting(t *testing.T) {
+	var (
+		coordinator = &coordinator{}
+		tasks       = &taskService{
+			// paginated reponses
+			pageOne: []*influxdb.Task{taskOne},
+			otherPages: map[influxdb.ID][]*influxdb.Task{
+				one:   []*influxdb.Task{taskTwo, taskThree},
+				three: []*influxdb.Task{taskFour},
+			},
+		}
+		runs = backfillRunFinder{
+			two:   []*influxdb.Run{{ID: 10, TaskID: two}, {ID: 11, TaskID: two}},
+			three: []*influxdb.Run{{ID: 12, TaskID: three}},
+		}
+	)
+
+	if err := NotifyBackfillerOfExisting(context.Background(), zaptest.NewLogger(t), tasks, runs, coordinator); err != nil {
+		t.Errorf("expected nil, found %q", err)
+	}
+
+	if diff := cmp.Diff(map[influxdb.ID][]*influxdb.Run{
+		two:   runs[two],
+		three: runs[three],
+	}, coordinator.backfilled); diff != "" {
+		t.Errorf("unexpected runs sent to coordinator %v", diff)
+	}
+}
+
 type coordinator struct {
-	tasks []*influxdb.Task
+	tasks      []*influxdb.Task
+	backfilled map[influxdb.ID][]*influxdb.Run
 }
 
 func (c *coordinator) TaskCreated(_ context.Context, task *influxdb.Task) error {
@@ -77,6 +107,25 @@ func (c *coordinator) TaskCreated(_ context.Context, task *influxdb.Task) error
 	return nil
 }
 
+func (c *coordinator) TaskBackfilled(_ context.Context, task *influxdb.Task, runs []*influxdb.Run) error {
+	if len(runs) == 0 {
+		return nil
+	}
+	if c.backfilled == nil {
+		c.backfilled = make(map[influxdb.ID][]*influxdb.Run)
+	}
+	c.backfilled[task.ID] = append(c.backfilled[task.ID], runs...)
+
+	return nil
+}
+
+// backfillRunFinder maps task IDs to their enqueued backfilled runs.
+type backfillRunFinder map[influxdb.ID][]*influxdb.Run
+
+func (f backfillRunFinder) FindBackfillRuns(_ context.Context, taskID influxdb.ID) ([]*influxdb.Run, error) {
+	return f[taskID], nil
+}
+
 // TasksService mocking
 type taskService struct {
 	// paginated tasks
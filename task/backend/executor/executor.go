@@ -50,6 +50,7 @@ type LimitFunc func(*influxdb.Task, *influxdb.Run) error
 type executorConfig struct {
 	maxWorkers    int
 	buildCompiler CompilerBuilderFunc
+	lang          influxdb.FluxLanguageService
 }
 
 type executorOption func(*executorConfig)
@@ -73,6 +74,14 @@ func WithCompilerBuilder(builder CompilerBuilderFunc) executorOption {
 	}
 }
 
+// WithFluxLanguageService is an Executor option that configures the language
+// service used to read the retry option of tasks. Without it runs are attempted once.
+func WithFluxLanguageService(lang influxdb.FluxLanguageService) executorOption {
+	return func(o *executorConfig) {
+		o.lang = lang
+	}
+}
+
 // NewExecutor creates a new task executor
 func NewExecutor(log *zap.Logger, qs query.QueryService, as influxdb.AuthorizationService, ts influxdb.TaskService, tcs backend.TaskControlService, opts ...executorOption) (*Executor, *ExecutorMetrics) {
 	cfg := &executorConfig{
@@ -84,11 +93,12 @@ func NewExecutor(log *zap.Logger, qs query.QueryService, as influxdb.Authorizati
 	}
 
 	e := &Executor{
-		log: log,
-		ts:  ts,
-		tcs: tcs,
-		qs:  qs,
-		as:  as,
+		log:  log,
+		ts:   ts,
+		tcs:  tcs,
+		qs:   qs,
+		as:   as,
+		lang: cfg.lang,
 
 		currentPromises: sync.Map{},
 		promiseQueue:    make(chan *promise, maxPromises),
@@ -113,8 +123,9 @@ type Executor struct {
 	ts  influxdb.TaskService
 	tcs backend.TaskControlService
 
-	qs query.QueryService
-	as influxdb.AuthorizationService
+	qs   query.QueryService
+	as   influxdb.AuthorizationService
+	lang influxdb.FluxLanguageService
 
 	metrics *ExecutorMetrics
 
@@ -196,6 +207,31 @@ func (e *Executor) ResumeCurrentRun(ctx context.Context, id influxdb.ID, runID i
 	return nil, influxdb.ErrRunNotFound
 }
 
+// ExecuteRun begins execution of a run that was already created, such as a run
+// held until the runs of the tasks it depends on finished.
+func (e *Executor) ExecuteRun(ctx context.Context, run *influxdb.Run) (Promise, error) {
+	p, err := e.createPromise(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+
+	e.startWorker()
+	return p, nil
+}
+
+// SkipRun finishes a run that was already created as skipped, without executing it.
+func (e *Executor) SkipRun(ctx context.Context, t *influxdb.Task, run *influxdb.Run, reason string) error {
+	e.tcs.AddRunLog(ctx, t.ID, run.ID, time.Now().UTC(), fmt.Sprintf("Skipped: %s", reason))
+	if err := e.tcs.UpdateRunState(ctx, t.ID, run.ID, time.Now().UTC(), influxdb.RunSkipped); err != nil {
+		return err
+	}
+	e.metrics.FinishRun(t, influxdb.RunSkipped, 0)
+	e.log.Debug("Run skipped", zap.String("taskID", t.ID.String()), zap.String("reason", reason))
+
+	_, err := e.tcs.FinishRun(ctx, t.ID, run.ID)
+	return err
+}
+
 func (e *Executor) createRun(ctx context.Context, id influxdb.ID, scheduledFor time.Time, runAt time.Time) (*promise, error) {
 	r, err := e.tcs.CreateRun(ctx, id, scheduledFor.UTC(), runAt.UTC())
 	if err != nil {
@@ -415,11 +451,41 @@ func (w *worker) executeQuery(p *promise) {
 	// start
 	w.start(p)
 
+	maxAttempts := w.e.maxAttempts(p.task)
+	for attempt := 1; ; attempt++ {
+		err := w.attempt(ctx, p)
+		if err == nil {
+			w.finish(p, influxdb.RunSuccess, nil)
+			return
+		}
+		if attempt >= maxAttempts || !retryable(err) {
+			w.finish(p, influxdb.RunFail, err)
+			return
+		}
+
+		wait := backoff(attempt)
+		w.e.tcs.AddRunLog(p.ctx, p.task.ID, p.run.ID, time.Now().UTC(), fmt.Sprintf("Attempt %d of %d failed, retrying in %s: %v", attempt, maxAttempts, wait, err))
+		w.e.metrics.LogRetry(p.task)
+
+		select {
+		// If done the promise was canceled
+		case <-p.ctx.Done():
+			w.finish(p, influxdb.RunCanceled, influxdb.ErrRunCanceled)
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// attempt executes the query of the run once.
+func (w *worker) attempt(ctx context.Context, p *promise) error {
+	span, ctx := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
 	ctx = icontext.SetAuthorizer(ctx, p.task.Authorization)
 	compiler, err := w.buildCompiler(ctx, p.task.Flux, p.run.ScheduledFor)
 	if err != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrFluxParseError(err))
-		return
+		return influxdb.ErrFluxParseError(err)
 	}
 
 	req := &query.Request{
@@ -427,26 +493,45 @@ func (w *worker) executeQuery(p *promise) {
 		OrganizationID: p.task.OrganizationID,
 		Compiler:       compiler,
 	}
-	req.WithReturnNoContent(true)
+	captureRows := w.e.captureRows(p.task)
+	if captureRows == 0 {
+		req.WithReturnNoContent(true)
+	}
 	it, err := w.e.qs.Query(ctx, req)
 	if err != nil {
 		// Assume the error should not be part of the runResult.
-		w.finish(p, influxdb.RunFail, influxdb.ErrQueryError(err))
-		return
+		return influxdb.ErrQueryError(err)
 	}
 
-	var runErr error
+	var (
+		runErr  error
+		results []influxdb.RunResult
+	)
 	// Drain the result iterator.
 	for it.More() {
 		// Consume the full iterator so that we don't leak outstanding iterators.
 		res := it.Next()
-		if runErr = w.exhaustResultIterators(res); runErr != nil {
+		if captureRows > 0 {
+			var result influxdb.RunResult
+			if result, runErr = captureResult(res, captureRows); runErr == nil {
+				results = append(results, result)
+			}
+		} else {
+			runErr = w.exhaustResultIterators(res)
+		}
+		if runErr != nil {
 			w.e.log.Info("Error exhausting result iterator", zap.Error(runErr), zap.String("name", res.Name()))
 		}
 	}
 
 	it.Release()
 
+	if len(results) > 0 {
+		if err := w.e.tcs.SetRunResults(p.ctx, p.task.ID, p.run.ID, results); err != nil {
+			w.e.log.Info("Failed to save run results", zap.Error(err), zap.String("taskID", p.task.ID.String()), zap.String("runID", p.run.ID.String()))
+		}
+	}
+
 	// log the trace id and whether or not it was sampled into the run log
 	if traceID, isSampled, ok := tracing.InfoFromSpan(span); ok {
 		msg := fmt.Sprintf("trace_id=%s is_sampled=%t", traceID, isSampled)
@@ -454,16 +539,14 @@ func (w *worker) executeQuery(p *promise) {
 	}
 
 	if runErr != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrRunExecutionError(runErr))
-		return
+		return influxdb.ErrRunExecutionError(runErr)
 	}
 
 	if it.Err() != nil {
-		w.finish(p, influxdb.RunFail, influxdb.ErrResultIteratorError(it.Err()))
-		return
+		return influxdb.ErrResultIteratorError(it.Err())
 	}
 
-	w.finish(p, influxdb.RunSuccess, nil)
+	return nil
 }
 
 // RunsActive returns the current number of workers, which is equivalent to
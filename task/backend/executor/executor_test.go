@@ -76,6 +76,8 @@ func TestTaskExecutor(t *testing.T) {
 	t.Run("ResumeRun", testResumingRun)
 	t.Run("WorkerLimit", testWorkerLimit)
 	t.Run("LimitFunc", testLimitFunc)
+	t.Run("HeldRun", testHeldRun)
+	t.Run("Retry", testRetry)
 	t.Run("Metrics", testMetrics)
 	t.Run("IteratorFailure", testIteratorFailure)
 	t.Run("ErrorHandling", testErrorHandling)
@@ -344,6 +346,108 @@ func testLimitFunc(t *testing.T) {
 	}
 }
 
+func testHeldRun(t *testing.T) {
+	t.Parallel()
+	tes := taskExecutorSystem(t)
+
+	script := fmt.Sprintf(fmtTestScript, t.Name())
+	ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+	task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	skippedRun, err := tes.i.CreateRun(ctx, task.ID, time.Unix(63, 0), time.Unix(66, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tes.ex.SkipRun(ctx, task, skippedRun, "upstream failed"); err != nil {
+		t.Fatal(err)
+	}
+	run, err := tes.i.FindRunByID(context.Background(), task.ID, skippedRun.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if run.Status != influxdb.RunSkipped.String() {
+		t.Fatalf("expected skipped run, got %s", run.Status)
+	}
+
+	heldRun, err := tes.i.CreateRun(ctx, task.ID, time.Unix(123, 0), time.Unix(126, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	promise, err := tes.ex.ExecuteRun(ctx, heldRun)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if promise.ID() != heldRun.ID {
+		t.Fatal("promise and held run dont match")
+	}
+
+	tes.svc.WaitForQueryLive(t, script)
+	tes.svc.SucceedQuery(script)
+
+	if got := promise.Error(); got != nil {
+		t.Fatal(got)
+	}
+}
+
+func testRetry(t *testing.T) {
+	// not parallel, the backoff is shortened for the duration of the test
+	defer func(d time.Duration) { retryBackoff = d }(retryBackoff)
+	retryBackoff = 10 * time.Millisecond
+
+	for _, tt := range []struct {
+		name      string
+		err       error
+		attempts  int
+		expectErr bool
+	}{
+		{name: "retryable", err: &influxdb.Error{Code: influxdb.EInvalid, Err: query.ErrQueueFull}, attempts: 2},
+		{name: "not retryable", err: &influxdb.Error{Code: influxdb.EInvalid, Msg: "compilation failed"}, attempts: 1, expectErr: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			tes := taskExecutorSystem(t)
+			ex, metrics := NewExecutor(zaptest.NewLogger(t), query.QueryServiceBridge{AsyncQueryService: tes.svc}, tes.i, tes.i, tes.tcs, WithFluxLanguageService(fluxlang.DefaultService))
+			reg := prom.NewRegistry(zaptest.NewLogger(t))
+			reg.MustRegister(metrics.PrometheusCollectors()...)
+
+			script := fmt.Sprintf(fmtRetryTestScript, t.Name())
+			ctx := icontext.SetAuthorizer(context.Background(), tes.tc.Auth)
+			task, err := tes.i.CreateTask(ctx, influxdb.TaskCreate{OrganizationID: tes.tc.OrgID, OwnerID: tes.tc.Auth.GetUserID(), Flux: script})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// the first attempt fails
+			tes.svc.FailNextQuery(tt.err)
+			promise, err := ex.PromisedExecute(ctx, scheduler.ID(task.ID), time.Unix(123, 0), time.Unix(126, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.attempts > 1 {
+				tes.svc.WaitForQueryLive(t, script)
+				tes.svc.SucceedQuery(script)
+			}
+			<-promise.Done()
+
+			if got := promise.Error(); (got != nil) != tt.expectErr {
+				t.Fatalf("unexpected run error: %v", got)
+			}
+
+			mg := promtest.MustGather(t, reg)
+			retries := promtest.FindMetric(mg, "task_executor_retries_counter", map[string]string{"taskID": task.ID.String()})
+			switch {
+			case tt.attempts == 1 && retries != nil:
+				t.Fatalf("expected no retries, got %v", retries.Counter.GetValue())
+			case tt.attempts > 1 && (retries == nil || retries.Counter.GetValue() != float64(tt.attempts-1)):
+				t.Fatalf("expected %d retries, got %v", tt.attempts-1, retries)
+			}
+		})
+	}
+}
+
 func testMetrics(t *testing.T) {
 	t.Parallel()
 	tes := taskExecutorSystem(t)
@@ -0,0 +1,98 @@
+package executor
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/task/options"
+)
+
+// captureRows returns how many rows of each result of a run of the task are
+// kept with the run, as set by the captureRows option of the task. No rows are
+// kept if the executor has no language service to read the option with.
+func (e *Executor) captureRows(t *influxdb.Task) int {
+	if e.lang == nil {
+		return 0
+	}
+	o, err := options.FromScript(e.lang, t.Flux)
+	if err != nil || o.CaptureRows == nil || *o.CaptureRows < 1 {
+		return 0
+	}
+	return int(*o.CaptureRows)
+}
+
+// captureResult consumes all tables of res and returns its first n rows.
+func captureResult(res flux.Result, n int) (influxdb.RunResult, error) {
+	result := influxdb.RunResult{Name: res.Name()}
+	err := res.Tables().Do(func(tbl flux.Table) error {
+		var table *influxdb.RunResultTable
+		return tbl.Do(func(cr flux.ColReader) error {
+			if cr.Len() == 0 {
+				return nil
+			}
+			if n == 0 {
+				result.Truncated = true
+				return nil
+			}
+
+			if table == nil {
+				cols := cr.Cols()
+				columns := make([]string, len(cols))
+				for j, col := range cols {
+					columns[j] = col.Label
+				}
+				result.Tables = append(result.Tables, influxdb.RunResultTable{Columns: columns})
+				table = &result.Tables[len(result.Tables)-1]
+			}
+
+			l := cr.Len()
+			if l > n {
+				l = n
+				result.Truncated = true
+			}
+			for i := 0; i < l; i++ {
+				row := make([]interface{}, len(cr.Cols()))
+				for j := range row {
+					row[j] = rowValue(cr, i, j)
+				}
+				table.Rows = append(table.Rows, row)
+			}
+			n -= l
+			return nil
+		})
+	})
+	return result, err
+}
+
+// rowValue returns the value of column j of row i in a form that can be
+// encoded as JSON, times are formatted as RFC3339. JSON has no NaN or infinity,
+// these floats are formatted as "NaN", "+Inf" and "-Inf".
+func rowValue(cr flux.ColReader, i, j int) interface{} {
+	v := execute.ValueForRow(cr, i, j)
+	if v.IsNull() {
+		return nil
+	}
+	switch cr.Cols()[j].Type {
+	case flux.TString:
+		return v.Str()
+	case flux.TInt:
+		return v.Int()
+	case flux.TUInt:
+		return v.UInt()
+	case flux.TFloat:
+		f := v.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return strconv.FormatFloat(f, 'f', -1, 64)
+		}
+		return f
+	case flux.TBool:
+		return v.Bool()
+	case flux.TTime:
+		return v.Time().Time().UTC().Format(time.RFC3339Nano)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package executor
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/execute"
+	"github.com/influxdata/flux/execute/executetest"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/query/fluxlang"
+)
+
+func TestCaptureResult(t *testing.T) {
+	cols := []flux.ColMeta{
+		{Label: "_time", Type: flux.TTime},
+		{Label: "_value", Type: flux.TFloat},
+		{Label: "host", Type: flux.TString},
+	}
+	newResult := func() flux.Result {
+		return &executetest.Result{
+			Nm: "_result",
+			Tbls: []*executetest.Table{
+				{
+					KeyCols: []string{"host"},
+					ColMeta: cols,
+					Data: [][]interface{}{
+						{execute.Time(0), 1.0, "a"},
+						{execute.Time(10e9), nil, "a"},
+					},
+				},
+				{
+					KeyCols: []string{"host"},
+					ColMeta: cols,
+					Data: [][]interface{}{
+						{execute.Time(0), 3.0, "b"},
+					},
+				},
+			},
+		}
+	}
+	columns := []string{"_time", "_value", "host"}
+
+	for _, tt := range []struct {
+		name string
+		n    int
+		exp  influxdb.RunResult
+	}{
+		{
+			name: "all rows",
+			n:    3,
+			exp: influxdb.RunResult{
+				Name: "_result",
+				Tables: []influxdb.RunResultTable{
+					{Columns: columns, Rows: [][]interface{}{
+						{"1970-01-01T00:00:00Z", 1.0, "a"},
+						{"1970-01-01T00:00:10Z", nil, "a"},
+					}},
+					{Columns: columns, Rows: [][]interface{}{
+						{"1970-01-01T00:00:00Z", 3.0, "b"},
+					}},
+				},
+			},
+		},
+		{
+			name: "truncated within table",
+			n:    1,
+			exp: influxdb.RunResult{
+				Name: "_result",
+				Tables: []influxdb.RunResultTable{
+					{Columns: columns, Rows: [][]interface{}{
+						{"1970-01-01T00:00:00Z", 1.0, "a"},
+					}},
+				},
+				Truncated: true,
+			},
+		},
+		{
+			name: "truncated between tables",
+			n:    2,
+			exp: influxdb.RunResult{
+				Name: "_result",
+				Tables: []influxdb.RunResultTable{
+					{Columns: columns, Rows: [][]interface{}{
+						{"1970-01-01T00:00:00Z", 1.0, "a"},
+						{"1970-01-01T00:00:10Z", nil, "a"},
+					}},
+				},
+				Truncated: true,
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := captureResult(newResult(), tt.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.exp, got); diff != "" {
+				t.Errorf("unexpected result: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCaptureResult_NonFiniteFloats(t *testing.T) {
+	res := &executetest.Result{
+		Nm: "_result",
+		Tbls: []*executetest.Table{
+			{
+				ColMeta: []flux.ColMeta{{Label: "_value", Type: flux.TFloat}},
+				Data: [][]interface{}{
+					{math.NaN()},
+					{math.Inf(1)},
+					{math.Inf(-1)},
+				},
+			},
+		},
+	}
+
+	got, err := captureResult(res, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := influxdb.RunResult{
+		Name: "_result",
+		Tables: []influxdb.RunResultTable{
+			{Columns: []string{"_value"}, Rows: [][]interface{}{{"NaN"}, {"+Inf"}, {"-Inf"}}},
+		},
+	}
+	if diff := cmp.Diff(exp, got); diff != "" {
+		t.Errorf("unexpected result: %s", diff)
+	}
+	if _, err := json.Marshal(got); err != nil {
+		t.Errorf("failed to encode the result as JSON: %v", err)
+	}
+}
+
+func TestCaptureRows(t *testing.T) {
+	e := &Executor{lang: fluxlang.DefaultService}
+	for _, tt := range []struct {
+		name string
+		flux string
+		exp  int
+	}{
+		{name: "unset", flux: `option task = {name: "a", every: 1m} from(bucket: "b") |> range(start: -1m)`, exp: 0},
+		{name: "set", flux: `option task = {name: "a", every: 1m, captureRows: 20} from(bucket: "b") |> range(start: -1m)`, exp: 20},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := e.captureRows(&influxdb.Task{Flux: tt.flux}); got != tt.exp {
+				t.Errorf("captureRows() = %d, want %d", got, tt.exp)
+			}
+		})
+	}
+
+	if got := (&Executor{}).captureRows(&influxdb.Task{Flux: `option task = {name: "a", every: 1m, captureRows: 20}`}); got != 0 {
+		t.Errorf("captureRows() without language service = %d, want 0", got)
+	}
+}
@@ -0,0 +1,79 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/task/options"
+)
+
+var (
+	// retryBackoff is how long a run waits before its second attempt, the wait
+	// doubles for every further attempt.
+	retryBackoff = time.Second
+	// maxRetryBackoff is the longest a run waits between two attempts.
+	maxRetryBackoff = time.Minute
+)
+
+// maxAttempts returns how many times a run of the task is attempted, the first
+// attempt and as many retries as the retry option of the task allows. Runs are
+// attempted once if the executor has no language service to read the option with.
+func (e *Executor) maxAttempts(t *influxdb.Task) int {
+	if e.lang == nil {
+		return 1
+	}
+	o, err := options.FromScript(e.lang, t.Flux)
+	if err != nil || o.Retry == nil || *o.Retry < 1 {
+		return 1
+	}
+	return 1 + int(*o.Retry)
+}
+
+// backoff returns how long to wait after the failed attempt before the next one.
+func backoff(attempt int) time.Duration {
+	d := retryBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return d
+}
+
+// retryable returns true if err is a transient failure that a later attempt of
+// the run may not run into, such as a full query queue or a storage timeout.
+func retryable(err error) bool {
+	for err != nil {
+		if errors.Is(err, context.DeadlineExceeded) ||
+			errors.Is(err, query.ErrQueueFull) ||
+			errors.Is(err, query.ErrOrganizationQueueFull) {
+			return true
+		}
+
+		switch e := err.(type) {
+		case *influxdb.Error:
+			switch e.Code {
+			case influxdb.ETooManyRequests, influxdb.EUnavailable:
+				return true
+			}
+			err = e.Err
+		case *flux.Error:
+			switch e.Code {
+			case codes.ResourceExhausted, codes.Unavailable, codes.DeadlineExceeded:
+				return true
+			}
+			err = e.Err
+		case influxdb.RequestStillQueuedError, *influxdb.RequestStillQueuedError:
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/flux"
+	"github.com/influxdata/flux/codes"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/query"
+	"github.com/influxdata/influxdb/v2/query/fluxlang"
+)
+
+func TestRetryable(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		err  error
+		exp  bool
+	}{
+		{name: "queue full", err: influxdb.ErrQueryError(&influxdb.Error{Code: influxdb.EInvalid, Err: query.ErrQueueFull}), exp: true},
+		{name: "organization queue full", err: influxdb.ErrQueryError(&influxdb.Error{Code: influxdb.EInvalid, Err: query.ErrOrganizationQueueFull}), exp: true},
+		{name: "unavailable", err: influxdb.ErrQueryError(&influxdb.Error{Code: influxdb.EUnavailable, Msg: "query controller shutdown"}), exp: true},
+		{name: "request still queued", err: influxdb.ErrQueryError(influxdb.RequestStillQueuedError{Start: 1, End: 2}), exp: true},
+		{name: "storage timeout", err: influxdb.ErrResultIteratorError(&flux.Error{Code: codes.DeadlineExceeded, Msg: "read timed out"}), exp: true},
+		{name: "deadline exceeded", err: influxdb.ErrRunExecutionError(fmt.Errorf("read: %w", context.DeadlineExceeded)), exp: true},
+		{name: "compile error", err: influxdb.ErrFluxParseError(errors.New("undefined identifier")), exp: false},
+		{name: "invalid query", err: influxdb.ErrQueryError(&influxdb.Error{Code: influxdb.EInvalid, Msg: "bucket not found"}), exp: false},
+		{name: "canceled", err: influxdb.ErrQueryError(context.Canceled), exp: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.exp {
+				t.Errorf("retryable(%v) = %t, want %t", tt.err, got, tt.exp)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt, exp := range map[int]time.Duration{
+		1:  retryBackoff,
+		2:  2 * retryBackoff,
+		3:  4 * retryBackoff,
+		20: maxRetryBackoff,
+	} {
+		if got := backoff(attempt); got != exp {
+			t.Errorf("backoff(%d) = %s, want %s", attempt, got, exp)
+		}
+	}
+}
+
+func TestMaxAttempts(t *testing.T) {
+	task := &influxdb.Task{Flux: `option task = {retry: 3, name:"x", every:1m} from(bucket:"b-src") |> range(start:-1m) |> to(bucket:"b-dst", org:"o")`}
+
+	tes := taskExecutorSystem(t)
+	if got := tes.ex.maxAttempts(task); got != 1 {
+		t.Errorf("expected a single attempt without a language service, got %d", got)
+	}
+
+	ex, _ := NewExecutor(tes.ex.log, tes.ex.qs, tes.ex.as, tes.i, tes.tcs, WithFluxLanguageService(fluxlang.DefaultService))
+	if got := ex.maxAttempts(task); got != 4 {
+		t.Errorf("expected 4 attempts, got %d", got)
+	}
+	if got := ex.maxAttempts(taskWith1Concurrency); got != 1 {
+		t.Errorf("expected the default of a single attempt, got %d", got)
+	}
+}
@@ -296,3 +296,11 @@ option task = {
 			every: 1m,
 }
 from(bucket: "one") |> to(bucket: "two", orgID: "0000000000000000")`
+
+const fmtRetryTestScript = `
+option task = {
+			name: %q,
+			every: 1m,
+			retry: 3,
+}
+from(bucket: "one") |> to(bucket: "two", orgID: "0000000000000000")`
@@ -17,6 +17,8 @@ type Coordinator interface {
 	RunCancelled(ctx context.Context, runID influxdb.ID) error
 	RunRetried(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error
 	RunForced(ctx context.Context, task *influxdb.Task, run *influxdb.Run) error
+	TaskBackfilled(ctx context.Context, task *influxdb.Task, runs []*influxdb.Run) error
+	BackfillCancelled(ctx context.Context, taskID influxdb.ID) error
 }
 
 // CoordinatingTaskService acts as a TaskService decorator that handles coordinating the api request
@@ -125,3 +127,27 @@ func (s *CoordinatingTaskService) ForceRun(ctx context.Context, taskID influxdb.
 
 	return r, s.coordinator.RunForced(ctx, t, r)
 }
+
+// BackfillTask enqueues the runs of the backfill in the task system and publishes them to be executed.
+func (s *CoordinatingTaskService) BackfillTask(ctx context.Context, taskID influxdb.ID, start, stop time.Time) ([]*influxdb.Run, error) {
+	t, err := s.TaskService.FindTaskByID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	runs, err := s.TaskService.BackfillTask(ctx, taskID, start, stop)
+	if err != nil {
+		return runs, err
+	}
+
+	return runs, s.coordinator.TaskBackfilled(ctx, t, runs)
+}
+
+// CancelBackfill removes the enqueued runs of the task and publishes the cancelation.
+func (s *CoordinatingTaskService) CancelBackfill(ctx context.Context, taskID influxdb.ID) error {
+	if err := s.TaskService.CancelBackfill(ctx, taskID); err != nil {
+		return err
+	}
+
+	return s.coordinator.BackfillCancelled(ctx, taskID)
+}
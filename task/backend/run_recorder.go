@@ -61,6 +61,14 @@ func (s *StoragePointsWriterRecorder) Record(ctx context.Context, orgID influxdb
 	}
 	fields[logField] = string(logBytes)
 
+	if len(run.Results) > 0 {
+		resultsBytes, err := json.Marshal(run.Results)
+		if err != nil {
+			return err
+		}
+		fields[resultsField] = string(resultsBytes)
+	}
+
 	point, err := models.NewPoint("runs", tags, fields, startedAt)
 	if err != nil {
 		return err
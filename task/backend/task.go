@@ -28,4 +28,7 @@ type TaskControlService interface {
 
 	// AddRunLog adds a log line to the run.
 	AddRunLog(ctx context.Context, taskID, runID influxdb.ID, when time.Time, log string) error
+
+	// SetRunResults sets the rows captured from the results of the run.
+	SetRunResults(ctx context.Context, taskID, runID influxdb.ID, results []influxdb.RunResult) error
 }
@@ -141,7 +141,7 @@ func (d *TaskControlService) UpdateRunState(ctx context.Context, taskID, runID i
 	switch state {
 	case influxdb.RunStarted:
 		run.StartedAt = when
-	case influxdb.RunSuccess, influxdb.RunFail, influxdb.RunCanceled:
+	case influxdb.RunSuccess, influxdb.RunFail, influxdb.RunCanceled, influxdb.RunSkipped:
 		run.FinishedAt = when
 	case influxdb.RunScheduled:
 		// nothing
@@ -165,6 +165,19 @@ func (d *TaskControlService) AddRunLog(ctx context.Context, taskID, runID influx
 	return nil
 }
 
+// SetRunResults sets the rows captured from the results of the run.
+func (d *TaskControlService) SetRunResults(ctx context.Context, taskID, runID influxdb.ID, results []influxdb.RunResult) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	run := d.runs[taskID][runID]
+	if run == nil {
+		panic("cannot set the results of a non existent run")
+	}
+	run.Results = results
+	return nil
+}
+
 func (d *TaskControlService) CreatedFor(taskID influxdb.ID) []*influxdb.Run {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -19,6 +19,7 @@ import (
 
 const maxConcurrency = 100
 const maxRetry = 10
+const maxCaptureRows = 100
 
 // Options are the task-related options that can be specified in a Flux script.
 type Options struct {
@@ -39,6 +40,14 @@ type Options struct {
 	Concurrency *int64 `json:"concurrency,omitempty"`
 
 	Retry *int64 `json:"retry,omitempty"`
+
+	// DependsOn are the IDs of the tasks whose runs have to succeed before
+	// the run of this task for the same scheduled time is executed.
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// CaptureRows is the number of rows of each result yielded by a run
+	// that are kept with the run for debugging, none are kept if unset.
+	CaptureRows *int64 `json:"captureRows,omitempty"`
 }
 
 // Duration is a time span that supports the same units as the flux parser's time duration, as well as negative length time spans.
@@ -117,6 +126,8 @@ func (o *Options) Clear() {
 	o.Offset = nil
 	o.Concurrency = nil
 	o.Retry = nil
+	o.DependsOn = nil
+	o.CaptureRows = nil
 }
 
 // IsZero tells us if the options has been zeroed out.
@@ -126,7 +137,9 @@ func (o *Options) IsZero() bool {
 		o.Every.IsZero() &&
 		(o.Offset == nil || o.Offset.IsZero()) &&
 		o.Concurrency == nil &&
-		o.Retry == nil
+		o.Retry == nil &&
+		len(o.DependsOn) == 0 &&
+		o.CaptureRows == nil
 }
 
 // All the task option names we accept.
@@ -137,6 +150,8 @@ const (
 	optOffset      = "offset"
 	optConcurrency = "concurrency"
 	optRetry       = "retry"
+	optDependsOn   = "dependsOn"
+	optCaptureRows = "captureRows"
 )
 
 // contains is a helper function to see if an array of strings contains a string
@@ -315,6 +330,30 @@ func FromScript(lang FluxLanguageService, script string) (Options, error) {
 		opt.Retry = pointer.Int64(retryVal.Int())
 	}
 
+	if dependsOnVal, ok := optObject.Get(optDependsOn); ok {
+		if err := checkNature(dependsOnVal.Type().Nature(), semantic.Array); err != nil {
+			return opt, err
+		}
+		var natureErr error
+		dependsOnVal.Array().Range(func(i int, v values.Value) {
+			if err := checkNature(v.Type().Nature(), semantic.String); err != nil {
+				natureErr = err
+				return
+			}
+			opt.DependsOn = append(opt.DependsOn, v.Str())
+		})
+		if natureErr != nil {
+			return opt, natureErr
+		}
+	}
+
+	if captureRowsVal, ok := optObject.Get(optCaptureRows); ok {
+		if err := checkNature(captureRowsVal.Type().Nature(), semantic.Int); err != nil {
+			return opt, err
+		}
+		opt.CaptureRows = pointer.Int64(captureRowsVal.Int())
+	}
+
 	if err := opt.Validate(); err != nil {
 		return opt, err
 	}
@@ -375,6 +414,20 @@ func (o *Options) Validate() error {
 			errs = append(errs, fmt.Sprintf("retry exceeded max of %d", maxRetry))
 		}
 	}
+	if o.CaptureRows != nil {
+		if *o.CaptureRows < 0 {
+			errs = append(errs, "captureRows must not be negative")
+		} else if *o.CaptureRows > maxCaptureRows {
+			errs = append(errs, fmt.Sprintf("captureRows exceeded max of %d", maxCaptureRows))
+		}
+	}
+	for i, id := range o.DependsOn {
+		if id == "" {
+			errs = append(errs, "dependsOn must not contain empty task IDs")
+		} else if contains(o.DependsOn[:i], id) {
+			errs = append(errs, fmt.Sprintf("dependsOn contains task %s more than once", id))
+		}
+	}
 
 	if len(errs) == 0 {
 		return nil
@@ -416,7 +469,7 @@ func validateOptionNames(o values.Object) error {
 	var unexpected []string
 	o.Range(func(name string, _ values.Value) {
 		switch name {
-		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry:
+		case optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optDependsOn, optCaptureRows:
 			// Known option. Nothing to do.
 		default:
 			unexpected = append(unexpected, name)
@@ -425,7 +478,7 @@ func validateOptionNames(o values.Object) error {
 
 	if len(unexpected) > 0 {
 		u := strings.Join(unexpected, ", ")
-		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry}, ", ")
+		v := strings.Join([]string{optName, optCron, optEvery, optOffset, optConcurrency, optRetry, optDependsOn, optCaptureRows}, ", ")
 		return fmt.Errorf("unknown task option(s): %s. valid options are %s", u, v)
 	}
 
@@ -34,6 +34,13 @@ func scriptGenerator(opt options.Options, body string) string {
 	if opt.Retry != nil && *opt.Retry != 0 {
 		taskData = fmt.Sprintf("%s  retry: %d,\n", taskData, *opt.Retry)
 	}
+	if len(opt.DependsOn) > 0 {
+		dependsOn := make([]string, 0, len(opt.DependsOn))
+		for _, id := range opt.DependsOn {
+			dependsOn = append(dependsOn, fmt.Sprintf("%q", id))
+		}
+		taskData = fmt.Sprintf("%s  dependsOn: [%s],\n", taskData, strings.Join(dependsOn, ", "))
+	}
 	if body == "" {
 		body = `from(bucket: "test")
     |> range(start:-1h)`
@@ -102,7 +109,13 @@ func TestFromScript(t *testing.T) {
 		},
 		{script: "option task = {name:\"test_task_smoke_name\", every:30s} from(bucket:\"test_tasks_smoke_bucket_source\") |> range(start: -1h) |> map(fn: (r) => ({r with _time: r._time, _value:r._value, t : \"quality_rocks\"}))|> to(bucket:\"test_tasks_smoke_bucket_dest\", orgID:\"3e73e749495d37d5\")",
 			exp: options.Options{Name: "test_task_smoke_name", Every: *(options.MustParseDuration("30s")), Retry: pointer.Int64(1), Concurrency: pointer.Int64(1)}, shouldErr: false}, // TODO(docmerlin): remove this once tasks fully supports all flux duration units.
-
+		{script: scriptGenerator(options.Options{Name: "name12", Every: *(options.MustParseDuration("1h")), DependsOn: []string{"020f755c3c082000", "020f755c3c082001"}}, ""),
+			exp: options.Options{Name: "name12", Every: *(options.MustParseDuration("1h")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1), DependsOn: []string{"020f755c3c082000", "020f755c3c082001"}}},
+		{script: scriptGenerator(options.Options{Name: "name13", Every: *(options.MustParseDuration("1h")), DependsOn: []string{"020f755c3c082000", "020f755c3c082000"}}, ""), shouldErr: true},
+		{script: "option task = {\n  name: \"name14\",\n  every: 1m0s,\n  dependsOn: \"020f755c3c082000\",\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
+		{script: "option task = {\n  name: \"name15\",\n  every: 1m0s,\n  captureRows: 10,\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)",
+			exp: options.Options{Name: "name15", Every: *(options.MustParseDuration("1m")), Concurrency: pointer.Int64(1), Retry: pointer.Int64(1), CaptureRows: pointer.Int64(10)}},
+		{script: "option task = {\n  name: \"name16\",\n  every: 1m0s,\n  captureRows: \"10\",\n\n}\n\nfrom(bucket: \"test\")\n    |> range(start:-1h)", shouldErr: true},
 	} {
 		o, err := options.FromScript(fluxlang.DefaultService, c.script)
 		if c.shouldErr && err == nil {
@@ -147,7 +160,7 @@ func TestFromScriptWithUnknownOptions(t *testing.T) {
 		t.Errorf("expected error to mention unrecognized options, but it said: %v", err)
 	}
 
-	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry"}
+	validOpts := []string{"name", "cron", "every", "offset", "concurrency", "retry", "dependsOn"}
 	for _, o := range validOpts {
 		if !strings.Contains(msg, o) {
 			t.Errorf("expected error to mention valid option %q but it said: %v", o, err)
@@ -223,6 +236,30 @@ func TestValidate(t *testing.T) {
 		t.Error("expected error for retry too large")
 	}
 
+	*bad = good
+	bad.DependsOn = []string{""}
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for empty dependency")
+	}
+
+	*bad = good
+	bad.DependsOn = []string{"020f755c3c082000", "020f755c3c082000"}
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for duplicate dependencies")
+	}
+
+	*bad = good
+	bad.CaptureRows = pointer.Int64(-1)
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for negative captureRows")
+	}
+
+	*bad = good
+	bad.CaptureRows = pointer.Int64(math.MaxInt64)
+	if err := bad.Validate(); err == nil {
+		t.Error("expected error for captureRows too large")
+	}
+
 	notbad := new(options.Options)
 	*notbad = good
 	notbad.Cron = ""
@@ -113,6 +113,10 @@ func TestTaskService(t *testing.T, fn BackendComponentFactory, testCategory ...s
 					t.Parallel()
 					testLogsAcrossStorage(t, sys)
 				})
+				t.Run("task Results Storage", func(t *testing.T) {
+					t.Parallel()
+					testResultsAcrossStorage(t, sys)
+				})
 			})
 		}
 	}
@@ -1674,6 +1678,74 @@ func testLogsAcrossStorage(t *testing.T, sys *System) {
 
 }
 
+func testResultsAcrossStorage(t *testing.T, sys *System) {
+	cr := creds(t, sys)
+
+	ct := influxdb.TaskCreate{
+		OrganizationID: cr.OrgID,
+		Flux:           fmt.Sprintf(scriptFmt, 0),
+		OwnerID:        cr.UserID,
+	}
+	task, err := sys.TaskService.CreateTask(icontext.SetAuthorizer(sys.Ctx, cr.Authorizer()), ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestedAt := time.Now().Add(5 * time.Minute).UTC()
+	rc, err := sys.TaskControlService.CreateRun(sys.Ctx, task.ID, requestedAt, requestedAt.Add(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	startedAt := time.Now().UTC()
+	if err := sys.TaskControlService.UpdateRunState(sys.Ctx, task.ID, rc.ID, startedAt, influxdb.RunStarted); err != nil {
+		t.Fatal(err)
+	}
+
+	// values that survive a JSON round trip unchanged
+	results := []influxdb.RunResult{
+		{
+			Name: "_result",
+			Tables: []influxdb.RunResultTable{
+				{
+					Columns: []string{"_time", "_value", "host"},
+					Rows: [][]interface{}{
+						{"2020-01-01T00:00:00Z", 1.5, "a"},
+						{"2020-01-01T00:00:10Z", 2.5, "a"},
+					},
+				},
+			},
+			Truncated: true,
+		},
+	}
+	if err := sys.TaskControlService.SetRunResults(sys.Ctx, task.ID, rc.ID, results); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := sys.TaskService.FindRunByID(sys.Ctx, task.ID, rc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(results, run.Results); diff != "" {
+		t.Fatalf("unexpected results of running run: %s", diff)
+	}
+
+	if err := sys.TaskControlService.UpdateRunState(sys.Ctx, task.ID, rc.ID, startedAt.Add(time.Second), influxdb.RunSuccess); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sys.TaskControlService.FinishRun(sys.Ctx, task.ID, rc.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	run, err = sys.TaskService.FindRunByID(sys.Ctx, task.ID, rc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(results, run.Results); diff != "" {
+		t.Fatalf("unexpected results of finished run: %s", diff)
+	}
+}
+
 func creds(t *testing.T, s *System) TestCreds {
 	t.Helper()
 
@@ -11,6 +11,13 @@ var (
 		Msg:  "run canceled",
 	}
 
+	// ErrRunSkipped is returned from the RunResult when a Run is skipped because
+	// a run of an upstream task did not succeed.
+	ErrRunSkipped = &Error{
+		Code: EInternal,
+		Msg:  "run skipped",
+	}
+
 	// ErrTaskNotClaimed is returned when attempting to operate against a task that must be claimed but is not.
 	ErrTaskNotClaimed = &Error{
 		Code: EConflict,
@@ -178,3 +185,22 @@ func ErrTaskConcurrencyLimitReached(runsInFront int) *Error {
 		Op:   "taskExecutor",
 	}
 }
+
+// ErrTaskDependencyInvalid is returned when a task depends on a task it cannot depend on.
+func ErrTaskDependencyInvalid(err error) *Error {
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("invalid task dependencies; Err: %v", err),
+		Op:   "taskOptions",
+		Err:  err,
+	}
+}
+
+// ErrTaskBackfillInvalid is returned when the time range of a backfill is invalid.
+func ErrTaskBackfillInvalid(msg string) *Error {
+	return &Error{
+		Code: EInvalid,
+		Msg:  fmt.Sprintf("invalid backfill; Err: %s", msg),
+		Op:   "taskBackfill",
+	}
+}
@@ -166,3 +166,39 @@ func TestParseRequestStillQueuedError(t *testing.T) {
 		t.Fatalf("%q should have parsed to %v, but got %v", validMsg, e, err)
 	}
 }
+
+func TestNewTaskGraph(t *testing.T) {
+	tasks := []*platform.Task{
+		{ID: 1, Name: "raw", Status: "active", LastRunStatus: "success"},
+		{ID: 2, Name: "downsample 1m", Status: "active", DependsOn: []platform.ID{1}},
+		{ID: 3, Name: "downsample 1h", Status: "active", DependsOn: []platform.ID{2}},
+		{ID: 4, Name: "report", Status: "inactive", DependsOn: []platform.ID{3, 5}},
+		{ID: 5, Name: "other", Status: "active"},
+		{ID: 6, Name: "unrelated", Status: "active"},
+	}
+
+	g, err := platform.NewTaskGraph(2, tasks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := &platform.TaskGraph{
+		Tasks: []platform.TaskGraphNode{
+			{ID: 1, Name: "raw", Status: "active", LastRunStatus: "success"},
+			{ID: 2, Name: "downsample 1m", Status: "active"},
+			{ID: 3, Name: "downsample 1h", Status: "active"},
+			{ID: 4, Name: "report", Status: "inactive"},
+		},
+		Edges: []platform.TaskGraphEdge{
+			{From: 1, To: 2},
+			{From: 2, To: 3},
+			{From: 3, To: 4},
+		},
+	}
+	if !cmp.Equal(g, exp) {
+		t.Fatalf("unexpected graph -got/+exp\n%s", cmp.Diff(g, exp))
+	}
+
+	if _, err := platform.NewTaskGraph(7, tasks); err != platform.ErrTaskNotFound {
+		t.Fatalf("expected task not found error but got %v", err)
+	}
+}
@@ -0,0 +1,394 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+)
+
+var alertAcknowledgementCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*influxdb.AlertAcknowledgement) []*influxdb.AlertAcknowledgement {
+		out := append([]*influxdb.AlertAcknowledgement(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID.String() < out[j].ID.String()
+		})
+		return out
+	}),
+}
+
+// AlertAcknowledgementFields defines fields for an alert acknowledgement test.
+// The acknowledgements are created with their own IDs at TimeGenerator's time.
+type AlertAcknowledgementFields struct {
+	AlertAcknowledgements []*influxdb.AlertAcknowledgement
+	IDGenerator           influxdb.IDGenerator
+	TimeGenerator         influxdb.TimeGenerator
+}
+
+var alertStart = fakeDate.Add(-time.Hour)
+
+func newTestAlertAcknowledgement(id string, orgID, checkID influxdb.ID, tags ...influxdb.Tag) *influxdb.AlertAcknowledgement {
+	return &influxdb.AlertAcknowledgement{
+		ID:        MustIDBase16(id),
+		OrgID:     orgID,
+		CheckID:   checkID,
+		Tags:      tags,
+		Level:     "CRIT",
+		StartedAt: alertStart,
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: fakeDate,
+			UpdatedAt: fakeDate,
+		},
+	}
+}
+
+// AlertAcknowledgementService tests all the service functions.
+func AlertAcknowledgementService(
+	init func(AlertAcknowledgementFields, *testing.T) (influxdb.AlertAcknowledgementService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(AlertAcknowledgementFields, *testing.T) (influxdb.AlertAcknowledgementService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateAlertAcknowledgement",
+			fn:   CreateAlertAcknowledgement,
+		},
+		{
+			name: "FindAlertAcknowledgementByID",
+			fn:   FindAlertAcknowledgementByID,
+		},
+		{
+			name: "FindAlertAcknowledgements",
+			fn:   FindAlertAcknowledgements,
+		},
+		{
+			name: "DeleteAlertAcknowledgement",
+			fn:   DeleteAlertAcknowledgement,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CreateAlertAcknowledgement tests influxdb.AlertAcknowledgementService CreateAlertAcknowledgement interface method
+func CreateAlertAcknowledgement(init func(AlertAcknowledgementFields, *testing.T) (influxdb.AlertAcknowledgementService, func()), t *testing.T) {
+	type wants struct {
+		err  error
+		acks []*influxdb.AlertAcknowledgement
+	}
+
+	tests := []struct {
+		name   string
+		fields AlertAcknowledgementFields
+		ack    *influxdb.AlertAcknowledgement
+		wants  wants
+	}{
+		{
+			name: "create alert acknowledgement",
+			fields: AlertAcknowledgementFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+				AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			ack: &influxdb.AlertAcknowledgement{
+				OrgID:     1,
+				CheckID:   3,
+				Tags:      []influxdb.Tag{{Key: "host", Value: "db02"}},
+				Level:     "WARN",
+				StartedAt: alertStart,
+				CreatedBy: 5,
+				Comment:   "looking into it",
+			},
+			wants: wants{
+				acks: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+					{
+						ID:        MustIDBase16(idB),
+						OrgID:     1,
+						CheckID:   3,
+						Tags:      []influxdb.Tag{{Key: "host", Value: "db02"}},
+						Level:     "WARN",
+						StartedAt: alertStart,
+						CreatedBy: 5,
+						Comment:   "looking into it",
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: fakeDate,
+							UpdatedAt: fakeDate,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "create alert acknowledgement replaces the one of the same series",
+			fields: AlertAcknowledgementFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+				AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			ack: &influxdb.AlertAcknowledgement{
+				OrgID:     1,
+				CheckID:   3,
+				Tags:      []influxdb.Tag{{Key: "host", Value: "db01"}},
+				Level:     "CRIT",
+				StartedAt: alertStart,
+				Resolved:  true,
+			},
+			wants: wants{
+				acks: []*influxdb.AlertAcknowledgement{
+					{
+						ID:        MustIDBase16(idB),
+						OrgID:     1,
+						CheckID:   3,
+						Tags:      []influxdb.Tag{{Key: "host", Value: "db01"}},
+						Level:     "CRIT",
+						StartedAt: alertStart,
+						Resolved:  true,
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: fakeDate,
+							UpdatedAt: fakeDate,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "create alert acknowledgement with invalid level",
+			fields: AlertAcknowledgementFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+			},
+			ack: &influxdb.AlertAcknowledgement{
+				OrgID:     1,
+				CheckID:   3,
+				Level:     "OK",
+				StartedAt: alertStart,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "alert acknowledgement level must be one of CRIT, WARN or INFO",
+				},
+				acks: []*influxdb.AlertAcknowledgement{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateAlertAcknowledgement(ctx, tt.ack)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			acks, _, err := s.FindAlertAcknowledgements(ctx, influxdb.AlertAcknowledgementFilter{OrgID: idPtr(1)})
+			if err != nil {
+				t.Fatalf("failed to retrieve alert acknowledgements: %v", err)
+			}
+			if diff := cmp.Diff(acks, tt.wants.acks, alertAcknowledgementCmpOptions...); diff != "" {
+				t.Errorf("alert acknowledgements are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindAlertAcknowledgementByID tests influxdb.AlertAcknowledgementService FindAlertAcknowledgementByID interface method
+func FindAlertAcknowledgementByID(init func(AlertAcknowledgementFields, *testing.T) (influxdb.AlertAcknowledgementService, func()), t *testing.T) {
+	type wants struct {
+		err error
+		ack *influxdb.AlertAcknowledgement
+	}
+
+	tests := []struct {
+		name   string
+		fields AlertAcknowledgementFields
+		id     influxdb.ID
+		wants  wants
+	}{
+		{
+			name: "find alert acknowledgement by id",
+			fields: AlertAcknowledgementFields{
+				TimeGenerator: fakeGenerator,
+				AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+					newTestAlertAcknowledgement(idB, 1, 3, influxdb.Tag{Key: "host", Value: "db02"}),
+				},
+			},
+			id: MustIDBase16(idB),
+			wants: wants{
+				ack: newTestAlertAcknowledgement(idB, 1, 3, influxdb.Tag{Key: "host", Value: "db02"}),
+			},
+		},
+		{
+			name: "find alert acknowledgement by id not found",
+			fields: AlertAcknowledgementFields{
+				TimeGenerator: fakeGenerator,
+				AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			id: MustIDBase16(idC),
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrAlertAcknowledgementNotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+
+			ack, err := s.FindAlertAcknowledgementByID(context.Background(), tt.id)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(ack, tt.wants.ack); diff != "" {
+				t.Errorf("alert acknowledgement is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindAlertAcknowledgements tests influxdb.AlertAcknowledgementService FindAlertAcknowledgements interface method
+func FindAlertAcknowledgements(init func(AlertAcknowledgementFields, *testing.T) (influxdb.AlertAcknowledgementService, func()), t *testing.T) {
+	fields := AlertAcknowledgementFields{
+		TimeGenerator: fakeGenerator,
+		AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+			newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+			newTestAlertAcknowledgement(idB, 1, 4, influxdb.Tag{Key: "host", Value: "db01"}),
+			newTestAlertAcknowledgement(idC, 2, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter influxdb.AlertAcknowledgementFilter
+		acks   []*influxdb.AlertAcknowledgement
+	}{
+		{
+			name:   "find alert acknowledgements by org",
+			filter: influxdb.AlertAcknowledgementFilter{OrgID: idPtr(1)},
+			acks: []*influxdb.AlertAcknowledgement{
+				newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+				newTestAlertAcknowledgement(idB, 1, 4, influxdb.Tag{Key: "host", Value: "db01"}),
+			},
+		},
+		{
+			name:   "find alert acknowledgements by org and check",
+			filter: influxdb.AlertAcknowledgementFilter{OrgID: idPtr(1), CheckID: idPtr(4)},
+			acks: []*influxdb.AlertAcknowledgement{
+				newTestAlertAcknowledgement(idB, 1, 4, influxdb.Tag{Key: "host", Value: "db01"}),
+			},
+		},
+		{
+			name:   "find alert acknowledgements of a check without any",
+			filter: influxdb.AlertAcknowledgementFilter{OrgID: idPtr(2), CheckID: idPtr(4)},
+			acks:   []*influxdb.AlertAcknowledgement{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+
+			acks, n, err := s.FindAlertAcknowledgements(context.Background(), tt.filter)
+			if err != nil {
+				t.Fatalf("failed to retrieve alert acknowledgements: %v", err)
+			}
+			if n != len(tt.acks) {
+				t.Errorf("expected %d alert acknowledgements, got %d", len(tt.acks), n)
+			}
+			if diff := cmp.Diff(acks, tt.acks, alertAcknowledgementCmpOptions...); diff != "" {
+				t.Errorf("alert acknowledgements are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteAlertAcknowledgement tests influxdb.AlertAcknowledgementService DeleteAlertAcknowledgement interface method
+func DeleteAlertAcknowledgement(init func(AlertAcknowledgementFields, *testing.T) (influxdb.AlertAcknowledgementService, func()), t *testing.T) {
+	type wants struct {
+		err  error
+		acks []*influxdb.AlertAcknowledgement
+	}
+
+	tests := []struct {
+		name   string
+		fields AlertAcknowledgementFields
+		id     influxdb.ID
+		wants  wants
+	}{
+		{
+			name: "delete alert acknowledgement",
+			fields: AlertAcknowledgementFields{
+				TimeGenerator: fakeGenerator,
+				AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+					newTestAlertAcknowledgement(idB, 1, 3, influxdb.Tag{Key: "host", Value: "db02"}),
+				},
+			},
+			id: MustIDBase16(idA),
+			wants: wants{
+				acks: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idB, 1, 3, influxdb.Tag{Key: "host", Value: "db02"}),
+				},
+			},
+		},
+		{
+			name: "delete alert acknowledgement not found",
+			fields: AlertAcknowledgementFields{
+				TimeGenerator: fakeGenerator,
+				AlertAcknowledgements: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			id: MustIDBase16(idC),
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrAlertAcknowledgementNotFound,
+				},
+				acks: []*influxdb.AlertAcknowledgement{
+					newTestAlertAcknowledgement(idA, 1, 3, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteAlertAcknowledgement(ctx, tt.id)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			acks, _, err := s.FindAlertAcknowledgements(ctx, influxdb.AlertAcknowledgementFilter{OrgID: idPtr(1)})
+			if err != nil {
+				t.Fatalf("failed to retrieve alert acknowledgements: %v", err)
+			}
+			if diff := cmp.Diff(acks, tt.wants.acks, alertAcknowledgementCmpOptions...); diff != "" {
+				t.Errorf("alert acknowledgements are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
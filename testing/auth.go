@@ -92,9 +92,13 @@ func AuthorizationService(
 			name: "DeleteAuthorization",
 			fn:   DeleteAuthorization,
 		},
+		{
+			name: "RotateAuthorization",
+			fn:   RotateAuthorization,
+		},
 	}
 	for _, tt := range tests {
-		if tt.name == "FindAuthorizationByToken" && len(opts) > 0 && opts[0].WithoutFindByToken {
+		if (tt.name == "FindAuthorizationByToken" || tt.name == "RotateAuthorization") && len(opts) > 0 && opts[0].WithoutFindByToken {
 			continue
 		}
 		t.Run(tt.name, func(t *testing.T) {
@@ -1361,3 +1365,122 @@ func deleteUsersPermission(orgID platform.ID) []platform.Permission {
 		{Action: platform.WriteAction, Resource: platform.Resource{Type: platform.UsersResourceType, OrgID: &orgID}},
 	}
 }
+
+// RotateAuthorization testing
+func RotateAuthorization(
+	init func(AuthorizationFields, *testing.T) (platform.AuthorizationService, string, func()),
+	t *testing.T,
+) {
+	type args struct {
+		id    platform.ID
+		grace time.Duration
+	}
+	type wants struct {
+		err error
+		// previousToken is whether the token before the rotation is still accepted.
+		previousToken bool
+	}
+
+	fields := AuthorizationFields{
+		TokenGenerator: mock.NewTokenGenerator("rand9", nil),
+		TimeGenerator:  platform.RealTimeGenerator{},
+		Users: []*platform.User{
+			{
+				Name: "cooluser",
+				ID:   MustIDBase16(userOneID),
+			},
+		},
+		Orgs: []*platform.Organization{
+			{
+				Name: "o1",
+				ID:   MustIDBase16(orgOneID),
+			},
+		},
+		Authorizations: []*platform.Authorization{
+			{
+				ID:          MustIDBase16(authOneID),
+				UserID:      MustIDBase16(userOneID),
+				OrgID:       MustIDBase16(orgOneID),
+				Token:       "rand1",
+				Permissions: allUsersPermission(MustIDBase16(orgOneID)),
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		fields AuthorizationFields
+		args   args
+		wants  wants
+	}{
+		{
+			name:   "rotate with grace period",
+			fields: fields,
+			args: args{
+				id:    MustIDBase16(authOneID),
+				grace: time.Hour,
+			},
+			wants: wants{
+				previousToken: true,
+			},
+		},
+		{
+			name:   "rotate without grace period",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(authOneID),
+			},
+		},
+		{
+			name:   "rotate authorization that does not exist",
+			fields: fields,
+			args: args{
+				id: MustIDBase16(authThreeID),
+			},
+			wants: wants{
+				err: &platform.Error{
+					Code: platform.ENotFound,
+					Msg:  "authorization not found",
+					Op:   platform.OpRotateAuthorization,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, opPrefix, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			a, err := s.RotateAuthorization(ctx, tt.args.id, tt.args.grace)
+			diffPlatformErrors(tt.name, err, tt.wants.err, opPrefix, t)
+			if err != nil {
+				return
+			}
+
+			if a.Token == "" || a.Token == "rand1" {
+				t.Fatalf("expected a new token, got %q", a.Token)
+			}
+			if diff := cmp.Diff(a.Permissions, allUsersPermission(MustIDBase16(orgOneID))); diff != "" {
+				t.Errorf("permissions are different -got/+want\ndiff %s", diff)
+			}
+
+			got, err := s.FindAuthorizationByToken(ctx, a.Token)
+			if err != nil {
+				t.Fatalf("failed to find authorization by the new token: %v", err)
+			}
+			if got.ID != tt.args.id {
+				t.Errorf("expected authorization %s for the new token, got %s", tt.args.id, got.ID)
+			}
+
+			_, err = s.FindAuthorizationByToken(ctx, "rand1")
+			if tt.wants.previousToken && err != nil {
+				t.Errorf("expected the previous token to be accepted, got %v", err)
+			}
+			if !tt.wants.previousToken && platform.ErrorCode(err) != platform.ENotFound {
+				t.Errorf("expected the previous token to be rejected, got %v", err)
+			}
+		})
+	}
+}
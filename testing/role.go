@@ -0,0 +1,519 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+)
+
+var roleCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*influxdb.Role) []*influxdb.Role {
+		out := append([]*influxdb.Role(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID.String() < out[j].ID.String()
+		})
+		return out
+	}),
+}
+
+// RoleFields defines fields for a role test. The roles are created with
+// their own IDs at TimeGenerator's time.
+type RoleFields struct {
+	Roles         []*influxdb.Role
+	IDGenerator   influxdb.IDGenerator
+	TimeGenerator influxdb.TimeGenerator
+}
+
+func roleReadPermission(rt influxdb.ResourceType, orgID influxdb.ID) influxdb.Permission {
+	return influxdb.Permission{
+		Action:   influxdb.ReadAction,
+		Resource: influxdb.Resource{Type: rt, OrgID: &orgID},
+	}
+}
+
+func newTestRole(id string, orgID influxdb.ID, name string) *influxdb.Role {
+	return &influxdb.Role{
+		ID:          MustIDBase16(id),
+		OrgID:       orgID,
+		Name:        name,
+		Permissions: []influxdb.Permission{roleReadPermission(influxdb.BucketsResourceType, orgID)},
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: fakeDate,
+			UpdatedAt: fakeDate,
+		},
+	}
+}
+
+// RoleService tests all the service functions.
+func RoleService(
+	init func(RoleFields, *testing.T) (influxdb.RoleService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(RoleFields, *testing.T) (influxdb.RoleService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateRole",
+			fn:   CreateRole,
+		},
+		{
+			name: "FindRoleByID",
+			fn:   FindRoleByID,
+		},
+		{
+			name: "FindRoles",
+			fn:   FindRoles,
+		},
+		{
+			name: "UpdateRole",
+			fn:   UpdateRole,
+		},
+		{
+			name: "DeleteRole",
+			fn:   DeleteRole,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CreateRole tests influxdb.RoleService CreateRole interface method
+func CreateRole(init func(RoleFields, *testing.T) (influxdb.RoleService, func()), t *testing.T) {
+	type wants struct {
+		err   error
+		roles []*influxdb.Role
+	}
+
+	tests := []struct {
+		name   string
+		fields RoleFields
+		role   *influxdb.Role
+		wants  wants
+	}{
+		{
+			name: "create role",
+			fields: RoleFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+			role: &influxdb.Role{
+				OrgID:       1,
+				Name:        "alert manager",
+				Description: "manages checks and notification rules",
+				Permissions: []influxdb.Permission{
+					roleReadPermission(influxdb.ChecksResourceType, 1),
+					roleReadPermission(influxdb.NotificationRuleResourceType, 1),
+				},
+			},
+			wants: wants{
+				roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+					{
+						ID:          MustIDBase16(idB),
+						OrgID:       1,
+						Name:        "alert manager",
+						Description: "manages checks and notification rules",
+						Permissions: []influxdb.Permission{
+							roleReadPermission(influxdb.ChecksResourceType, 1),
+							roleReadPermission(influxdb.NotificationRuleResourceType, 1),
+						},
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: fakeDate,
+							UpdatedAt: fakeDate,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "create role with the name of a role of the org",
+			fields: RoleFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+			role: &influxdb.Role{
+				OrgID:       1,
+				Name:        "analyst",
+				Permissions: []influxdb.Permission{roleReadPermission(influxdb.DashboardsResourceType, 1)},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "role with name analyst already exists",
+				},
+				roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+		},
+		{
+			name: "create role without permissions",
+			fields: RoleFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+			},
+			role: &influxdb.Role{
+				OrgID: 1,
+				Name:  "analyst",
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "role must have at least one permission",
+				},
+				roles: []*influxdb.Role{},
+			},
+		},
+		{
+			name: "create role with permission outside of its org",
+			fields: RoleFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+			},
+			role: &influxdb.Role{
+				OrgID:       1,
+				Name:        "analyst",
+				Permissions: []influxdb.Permission{roleReadPermission(influxdb.BucketsResourceType, 2)},
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "role permission read:orgs/0000000000000002/buckets is not within the role's organization",
+				},
+				roles: []*influxdb.Role{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateRole(ctx, tt.role)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			roles, _, err := s.FindRoles(ctx, influxdb.RoleFilter{OrgID: idPtr(1)})
+			if err != nil {
+				t.Fatalf("failed to retrieve roles: %v", err)
+			}
+			if diff := cmp.Diff(roles, tt.wants.roles, roleCmpOptions...); diff != "" {
+				t.Errorf("roles are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindRoleByID tests influxdb.RoleService FindRoleByID interface method
+func FindRoleByID(init func(RoleFields, *testing.T) (influxdb.RoleService, func()), t *testing.T) {
+	type wants struct {
+		err  error
+		role *influxdb.Role
+	}
+
+	tests := []struct {
+		name   string
+		fields RoleFields
+		id     influxdb.ID
+		wants  wants
+	}{
+		{
+			name: "find role by id",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+					newTestRole(idB, 1, "alert manager"),
+				},
+			},
+			id: MustIDBase16(idB),
+			wants: wants{
+				role: newTestRole(idB, 1, "alert manager"),
+			},
+		},
+		{
+			name: "find role by id not found",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+			id: MustIDBase16(idC),
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrRoleNotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+
+			role, err := s.FindRoleByID(context.Background(), tt.id)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(role, tt.wants.role); diff != "" {
+				t.Errorf("role is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindRoles tests influxdb.RoleService FindRoles interface method
+func FindRoles(init func(RoleFields, *testing.T) (influxdb.RoleService, func()), t *testing.T) {
+	fields := RoleFields{
+		TimeGenerator: fakeGenerator,
+		Roles: []*influxdb.Role{
+			newTestRole(idA, 1, "analyst"),
+			newTestRole(idB, 1, "alert manager"),
+			newTestRole(idC, 2, "analyst"),
+		},
+	}
+
+	analyst := "analyst"
+
+	tests := []struct {
+		name   string
+		filter influxdb.RoleFilter
+		opts   []influxdb.FindOptions
+		roles  []*influxdb.Role
+	}{
+		{
+			name:   "find roles by org",
+			filter: influxdb.RoleFilter{OrgID: idPtr(1)},
+			roles: []*influxdb.Role{
+				newTestRole(idA, 1, "analyst"),
+				newTestRole(idB, 1, "alert manager"),
+			},
+		},
+		{
+			name:   "find roles by name",
+			filter: influxdb.RoleFilter{Name: &analyst},
+			roles: []*influxdb.Role{
+				newTestRole(idA, 1, "analyst"),
+				newTestRole(idC, 2, "analyst"),
+			},
+		},
+		{
+			name:   "find roles by id",
+			filter: influxdb.RoleFilter{ID: idPtr(MustIDBase16(idC))},
+			roles: []*influxdb.Role{
+				newTestRole(idC, 2, "analyst"),
+			},
+		},
+		{
+			name:   "find roles by id of another org",
+			filter: influxdb.RoleFilter{ID: idPtr(MustIDBase16(idC)), OrgID: idPtr(1)},
+			roles:  []*influxdb.Role{},
+		},
+		{
+			name:   "find roles with limit",
+			filter: influxdb.RoleFilter{OrgID: idPtr(1)},
+			opts:   []influxdb.FindOptions{{Limit: 1}},
+			roles: []*influxdb.Role{
+				newTestRole(idA, 1, "analyst"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+
+			roles, n, err := s.FindRoles(context.Background(), tt.filter, tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to retrieve roles: %v", err)
+			}
+			if n != len(tt.roles) {
+				t.Errorf("expected %d roles, got %d", len(tt.roles), n)
+			}
+			if diff := cmp.Diff(roles, tt.roles, roleCmpOptions...); diff != "" {
+				t.Errorf("roles are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateRole tests influxdb.RoleService UpdateRole interface method
+func UpdateRole(init func(RoleFields, *testing.T) (influxdb.RoleService, func()), t *testing.T) {
+	type wants struct {
+		err  error
+		role *influxdb.Role
+	}
+
+	name := "read-only analyst"
+	taken := "alert manager"
+	description := "reads everything"
+
+	tests := []struct {
+		name   string
+		fields RoleFields
+		id     influxdb.ID
+		upd    influxdb.RoleUpdate
+		wants  wants
+	}{
+		{
+			name: "update role name, description and permissions",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+			id: MustIDBase16(idA),
+			upd: influxdb.RoleUpdate{
+				Name:        &name,
+				Description: &description,
+				Permissions: influxdb.MemberPermissions(1),
+			},
+			wants: wants{
+				role: &influxdb.Role{
+					ID:          MustIDBase16(idA),
+					OrgID:       1,
+					Name:        name,
+					Description: description,
+					Permissions: influxdb.MemberPermissions(1),
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: fakeDate,
+						UpdatedAt: fakeDate,
+					},
+				},
+			},
+		},
+		{
+			name: "update role name to the name of a role of the org",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+					newTestRole(idB, 1, "alert manager"),
+				},
+			},
+			id:  MustIDBase16(idA),
+			upd: influxdb.RoleUpdate{Name: &taken},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EConflict,
+					Msg:  "role with name alert manager already exists",
+				},
+			},
+		},
+		{
+			name: "update role not found",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+			},
+			id:  MustIDBase16(idA),
+			upd: influxdb.RoleUpdate{Description: &description},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrRoleNotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+
+			role, err := s.UpdateRole(context.Background(), tt.id, tt.upd)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(role, tt.wants.role); diff != "" {
+				t.Errorf("role is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteRole tests influxdb.RoleService DeleteRole interface method
+func DeleteRole(init func(RoleFields, *testing.T) (influxdb.RoleService, func()), t *testing.T) {
+	type wants struct {
+		err   error
+		roles []*influxdb.Role
+	}
+
+	tests := []struct {
+		name   string
+		fields RoleFields
+		id     influxdb.ID
+		wants  wants
+	}{
+		{
+			name: "delete role",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+					newTestRole(idB, 1, "alert manager"),
+				},
+			},
+			id: MustIDBase16(idA),
+			wants: wants{
+				roles: []*influxdb.Role{
+					newTestRole(idB, 1, "alert manager"),
+				},
+			},
+		},
+		{
+			name: "delete role not found",
+			fields: RoleFields{
+				TimeGenerator: fakeGenerator,
+				Roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+			id: MustIDBase16(idC),
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrRoleNotFound,
+				},
+				roles: []*influxdb.Role{
+					newTestRole(idA, 1, "analyst"),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteRole(ctx, tt.id)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			roles, _, err := s.FindRoles(ctx, influxdb.RoleFilter{OrgID: idPtr(1)})
+			if err != nil {
+				t.Fatalf("failed to retrieve roles: %v", err)
+			}
+			if diff := cmp.Diff(roles, tt.wants.roles, roleCmpOptions...); diff != "" {
+				t.Errorf("roles are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
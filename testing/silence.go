@@ -0,0 +1,486 @@
+package testing
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/influxdata/influxdb/v2"
+	"github.com/influxdata/influxdb/v2/mock"
+)
+
+var silenceCmpOptions = cmp.Options{
+	cmp.Transformer("Sort", func(in []*influxdb.Silence) []*influxdb.Silence {
+		out := append([]*influxdb.Silence(nil), in...)
+		sort.Slice(out, func(i, j int) bool {
+			return out[i].ID.String() < out[j].ID.String()
+		})
+		return out
+	}),
+}
+
+// SilenceFields defines fields for a silence test. The silences are
+// created with their own IDs at TimeGenerator's time.
+type SilenceFields struct {
+	Silences      []*influxdb.Silence
+	IDGenerator   influxdb.IDGenerator
+	TimeGenerator influxdb.TimeGenerator
+}
+
+var (
+	silenceStart = fakeDate
+	silenceEnd   = fakeDate.Add(2 * time.Hour)
+)
+
+func newTestSilence(id string, orgID influxdb.ID, matchers ...influxdb.Tag) *influxdb.Silence {
+	return &influxdb.Silence{
+		ID:       MustIDBase16(id),
+		OrgID:    orgID,
+		Matchers: matchers,
+		StartsAt: silenceStart,
+		EndsAt:   silenceEnd,
+		CRUDLog: influxdb.CRUDLog{
+			CreatedAt: fakeDate,
+			UpdatedAt: fakeDate,
+		},
+	}
+}
+
+// SilenceService tests all the service functions.
+func SilenceService(
+	init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()), t *testing.T,
+) {
+	tests := []struct {
+		name string
+		fn   func(init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()),
+			t *testing.T)
+	}{
+		{
+			name: "CreateSilence",
+			fn:   CreateSilence,
+		},
+		{
+			name: "FindSilenceByID",
+			fn:   FindSilenceByID,
+		},
+		{
+			name: "FindSilences",
+			fn:   FindSilences,
+		},
+		{
+			name: "UpdateSilence",
+			fn:   UpdateSilence,
+		},
+		{
+			name: "DeleteSilence",
+			fn:   DeleteSilence,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(init, t)
+		})
+	}
+}
+
+// CreateSilence tests influxdb.SilenceService CreateSilence interface method
+func CreateSilence(init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()), t *testing.T) {
+	type wants struct {
+		err      error
+		silences []*influxdb.Silence
+	}
+
+	tests := []struct {
+		name    string
+		fields  SilenceFields
+		silence *influxdb.Silence
+		wants   wants
+	}{
+		{
+			name: "create silence with matchers",
+			fields: SilenceFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			silence: &influxdb.Silence{
+				OrgID:     1,
+				Matchers:  []influxdb.Tag{{Key: "region", Value: "east"}},
+				CheckIDs:  []influxdb.ID{MustIDBase16(idC)},
+				StartsAt:  silenceStart,
+				EndsAt:    silenceEnd,
+				CreatedBy: 5,
+				Comment:   "maintenance",
+			},
+			wants: wants{
+				silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+					{
+						ID:        MustIDBase16(idB),
+						OrgID:     1,
+						Matchers:  []influxdb.Tag{{Key: "region", Value: "east"}},
+						CheckIDs:  []influxdb.ID{MustIDBase16(idC)},
+						StartsAt:  silenceStart,
+						EndsAt:    silenceEnd,
+						CreatedBy: 5,
+						Comment:   "maintenance",
+						CRUDLog: influxdb.CRUDLog{
+							CreatedAt: fakeDate,
+							UpdatedAt: fakeDate,
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "create silence without matchers or check IDs",
+			fields: SilenceFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+			},
+			silence: &influxdb.Silence{
+				OrgID:    1,
+				StartsAt: silenceStart,
+				EndsAt:   silenceEnd,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "silence must have at least one matcher or check ID",
+				},
+				silences: []*influxdb.Silence{},
+			},
+		},
+		{
+			name: "create silence ending before it starts",
+			fields: SilenceFields{
+				IDGenerator:   mock.NewIDGenerator(idB, t),
+				TimeGenerator: fakeGenerator,
+			},
+			silence: &influxdb.Silence{
+				OrgID:    1,
+				CheckIDs: []influxdb.ID{MustIDBase16(idC)},
+				StartsAt: silenceEnd,
+				EndsAt:   silenceStart,
+			},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "silence endsAt must be after startsAt",
+				},
+				silences: []*influxdb.Silence{},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.CreateSilence(ctx, tt.silence)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			silences, _, err := s.FindSilences(ctx, influxdb.SilenceFilter{OrgID: idPtr(1)})
+			if err != nil {
+				t.Fatalf("failed to retrieve silences: %v", err)
+			}
+			if diff := cmp.Diff(silences, tt.wants.silences, silenceCmpOptions...); diff != "" {
+				t.Errorf("silences are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindSilenceByID tests influxdb.SilenceService FindSilenceByID interface method
+func FindSilenceByID(init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()), t *testing.T) {
+	type wants struct {
+		err     error
+		silence *influxdb.Silence
+	}
+
+	tests := []struct {
+		name   string
+		fields SilenceFields
+		id     influxdb.ID
+		wants  wants
+	}{
+		{
+			name: "find silence by id",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+					newTestSilence(idB, 1, influxdb.Tag{Key: "host", Value: "db02"}),
+				},
+			},
+			id: MustIDBase16(idB),
+			wants: wants{
+				silence: newTestSilence(idB, 1, influxdb.Tag{Key: "host", Value: "db02"}),
+			},
+		},
+		{
+			name: "find silence by id not found",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			id: MustIDBase16(idC),
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrSilenceNotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+
+			silence, err := s.FindSilenceByID(context.Background(), tt.id)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(silence, tt.wants.silence); diff != "" {
+				t.Errorf("silence is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// FindSilences tests influxdb.SilenceService FindSilences interface method
+func FindSilences(init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()), t *testing.T) {
+	fields := SilenceFields{
+		TimeGenerator: fakeGenerator,
+		Silences: []*influxdb.Silence{
+			newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+			newTestSilence(idB, 1, influxdb.Tag{Key: "host", Value: "db02"}),
+			newTestSilence(idC, 2, influxdb.Tag{Key: "host", Value: "db03"}),
+		},
+	}
+
+	before := silenceStart.Add(-time.Minute)
+	during := silenceStart.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		filter   influxdb.SilenceFilter
+		opts     []influxdb.FindOptions
+		silences []*influxdb.Silence
+	}{
+		{
+			name:   "find silences by org",
+			filter: influxdb.SilenceFilter{OrgID: idPtr(1)},
+			silences: []*influxdb.Silence{
+				newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				newTestSilence(idB, 1, influxdb.Tag{Key: "host", Value: "db02"}),
+			},
+		},
+		{
+			name:   "find active silences",
+			filter: influxdb.SilenceFilter{OrgID: idPtr(2), Active: &during},
+			silences: []*influxdb.Silence{
+				newTestSilence(idC, 2, influxdb.Tag{Key: "host", Value: "db03"}),
+			},
+		},
+		{
+			name:     "find active silences before they start",
+			filter:   influxdb.SilenceFilter{OrgID: idPtr(2), Active: &before},
+			silences: []*influxdb.Silence{},
+		},
+		{
+			name:   "find silences with limit",
+			filter: influxdb.SilenceFilter{OrgID: idPtr(1)},
+			opts:   []influxdb.FindOptions{{Limit: 1}},
+			silences: []*influxdb.Silence{
+				newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(fields, t)
+			defer done()
+
+			silences, n, err := s.FindSilences(context.Background(), tt.filter, tt.opts...)
+			if err != nil {
+				t.Fatalf("failed to retrieve silences: %v", err)
+			}
+			if n != len(tt.silences) {
+				t.Errorf("expected %d silences, got %d", len(tt.silences), n)
+			}
+			if diff := cmp.Diff(silences, tt.silences, silenceCmpOptions...); diff != "" {
+				t.Errorf("silences are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// UpdateSilence tests influxdb.SilenceService UpdateSilence interface method
+func UpdateSilence(init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()), t *testing.T) {
+	type wants struct {
+		err     error
+		silence *influxdb.Silence
+	}
+
+	newEnd := silenceEnd.Add(time.Hour)
+	badEnd := silenceStart.Add(-time.Hour)
+	comment := "extended"
+
+	tests := []struct {
+		name   string
+		fields SilenceFields
+		id     influxdb.ID
+		upd    influxdb.SilenceUpdate
+		wants  wants
+	}{
+		{
+			name: "update silence end and comment",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			id:  MustIDBase16(idA),
+			upd: influxdb.SilenceUpdate{EndsAt: &newEnd, Comment: &comment},
+			wants: wants{
+				silence: &influxdb.Silence{
+					ID:       MustIDBase16(idA),
+					OrgID:    1,
+					Matchers: []influxdb.Tag{{Key: "host", Value: "db01"}},
+					StartsAt: silenceStart,
+					EndsAt:   newEnd,
+					Comment:  comment,
+					CRUDLog: influxdb.CRUDLog{
+						CreatedAt: fakeDate,
+						UpdatedAt: fakeDate,
+					},
+				},
+			},
+		},
+		{
+			name: "update silence with end before start",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			id:  MustIDBase16(idA),
+			upd: influxdb.SilenceUpdate{EndsAt: &badEnd},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.EInvalid,
+					Msg:  "silence endsAt must be after startsAt",
+				},
+			},
+		},
+		{
+			name: "update silence not found",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+			},
+			id:  MustIDBase16(idA),
+			upd: influxdb.SilenceUpdate{Comment: &comment},
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrSilenceNotFound,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+
+			silence, err := s.UpdateSilence(context.Background(), tt.id, tt.upd)
+			ErrorsEqual(t, err, tt.wants.err)
+			if diff := cmp.Diff(silence, tt.wants.silence); diff != "" {
+				t.Errorf("silence is different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
+
+// DeleteSilence tests influxdb.SilenceService DeleteSilence interface method
+func DeleteSilence(init func(SilenceFields, *testing.T) (influxdb.SilenceService, func()), t *testing.T) {
+	type wants struct {
+		err      error
+		silences []*influxdb.Silence
+	}
+
+	tests := []struct {
+		name   string
+		fields SilenceFields
+		id     influxdb.ID
+		wants  wants
+	}{
+		{
+			name: "delete silence",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+					newTestSilence(idB, 1, influxdb.Tag{Key: "host", Value: "db02"}),
+				},
+			},
+			id: MustIDBase16(idA),
+			wants: wants{
+				silences: []*influxdb.Silence{
+					newTestSilence(idB, 1, influxdb.Tag{Key: "host", Value: "db02"}),
+				},
+			},
+		},
+		{
+			name: "delete silence not found",
+			fields: SilenceFields{
+				TimeGenerator: fakeGenerator,
+				Silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+			id: MustIDBase16(idC),
+			wants: wants{
+				err: &influxdb.Error{
+					Code: influxdb.ENotFound,
+					Msg:  influxdb.ErrSilenceNotFound,
+				},
+				silences: []*influxdb.Silence{
+					newTestSilence(idA, 1, influxdb.Tag{Key: "host", Value: "db01"}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, done := init(tt.fields, t)
+			defer done()
+			ctx := context.Background()
+
+			err := s.DeleteSilence(ctx, tt.id)
+			ErrorsEqual(t, err, tt.wants.err)
+
+			silences, _, err := s.FindSilences(ctx, influxdb.SilenceFilter{OrgID: idPtr(1)})
+			if err != nil {
+				t.Fatalf("failed to retrieve silences: %v", err)
+			}
+			if diff := cmp.Diff(silences, tt.wants.silences, silenceCmpOptions...); diff != "" {
+				t.Errorf("silences are different -got/+want\ndiff %s", diff)
+			}
+		})
+	}
+}
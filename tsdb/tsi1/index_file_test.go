@@ -2,7 +2,6 @@ package tsi1_test
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"reflect"
 	"testing"
@@ -107,15 +106,13 @@ func TestGenerateIndexFile(t *testing.T) {
 
 // Ensure index file generated with uvarint encoding can be loaded.
 func TestGenerateIndexFile_Uvarint(t *testing.T) {
-	// Load previously generated series file.
-	sfile := seriesfile.NewSeriesFile("testdata/uvarint/_series")
-	if err := sfile.Open(context.Background()); err != nil {
-		t.Fatal(err)
-	}
+	// Open the series file in a temporary directory so the test does not
+	// write into testdata.
+	sfile := MustOpenSeriesFile()
 	defer sfile.Close()
 
 	// Load legacy index file from buffer.
-	f := tsi1.NewIndexFile(sfile)
+	f := tsi1.NewIndexFile(sfile.SeriesFile)
 	f.SetPath("testdata/uvarint/index")
 	if err := f.Open(); err != nil {
 		t.Fatal(err)
@@ -82,6 +82,12 @@ type CompactionPlanner interface {
 	Plan(lastWrite time.Time) []CompactionGroup
 	PlanLevel(level int) []CompactionGroup
 	PlanOptimize() []CompactionGroup
+
+	// PlanShardGroupSplit returns the TSM files holding the data of more
+	// than one shard group, each in its own compaction group. The other
+	// plans skip these files until they have been split.
+	PlanShardGroupSplit() []CompactionGroup
+
 	Release(group []CompactionGroup)
 	FullyCompacted() bool
 
@@ -123,6 +129,10 @@ type DefaultPlanner struct {
 	// filesInUse is the set of files that have been returned as part of a plan and might
 	// be being compacted.  Two plans should not return the same file at any given time.
 	filesInUse map[string]struct{}
+
+	// ShardGroupDuration is the duration of the time window of the shard groups.
+	// Files of different shard groups are never planned together.
+	ShardGroupDuration time.Duration
 }
 
 type fileStore interface {
@@ -194,6 +204,17 @@ func (t *tsmGeneration) hasTombstones() bool {
 	return false
 }
 
+// spansShardGroups returns true if any of the files hold the data of more
+// than one shard group.
+func (t *tsmGeneration) spansShardGroups(d time.Duration) bool {
+	for _, f := range t.files {
+		if _, ok := fileShardGroup(f, d); !ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *DefaultPlanner) SetFileStore(fs *FileStore) {
 	c.FileStore = fs
 }
@@ -204,8 +225,17 @@ func (c *DefaultPlanner) ParseFileName(path string) (int, int, error) {
 
 // FullyCompacted returns true if the shard is fully compacted.
 func (c *DefaultPlanner) FullyCompacted() bool {
-	gens := c.findGenerations(false)
-	return len(gens) <= 1 && !gens.hasTombstones()
+	for _, gen := range c.findGenerations(false) {
+		if gen.spansShardGroups(c.ShardGroupDuration) {
+			return false
+		}
+	}
+	for _, gens := range c.shardGroups(c.findGenerations(false)) {
+		if len(gens) > 1 || gens.hasTombstones() {
+			return false
+		}
+	}
+	return true
 }
 
 // ForceFull causes the planner to return a full compaction plan the next time
@@ -231,8 +261,21 @@ func (c *DefaultPlanner) PlanLevel(level int) []CompactionGroup {
 	// Determine the generations from all files on disk.  We need to treat
 	// a generation conceptually as a single file even though it may be
 	// split across several files in sequence.
-	generations := c.findGenerations(true)
+	var cGroups []CompactionGroup
+	for _, generations := range c.shardGroups(c.findGenerations(true)) {
+		cGroups = append(cGroups, c.planLevel(generations, level)...)
+	}
 
+	if !c.acquire(cGroups) {
+		return nil
+	}
+
+	return cGroups
+}
+
+// planLevel returns the groups of TSM files to rewrite for a specific level
+// within the generations of a shard group.
+func (c *DefaultPlanner) planLevel(generations tsmGenerations, level int) []CompactionGroup {
 	// If there is only one generation and no tombstones, then there's nothing to
 	// do.
 	if len(generations) <= 1 && !generations.hasTombstones() {
@@ -304,10 +347,6 @@ func (c *DefaultPlanner) PlanLevel(level int) []CompactionGroup {
 		}
 	}
 
-	if !c.acquire(cGroups) {
-		return nil
-	}
-
 	return cGroups
 }
 
@@ -327,8 +366,21 @@ func (c *DefaultPlanner) PlanOptimize() []CompactionGroup {
 	// Determine the generations from all files on disk.  We need to treat
 	// a generation conceptually as a single file even though it may be
 	// split across several files in sequence.
-	generations := c.findGenerations(true)
+	var cGroups []CompactionGroup
+	for _, generations := range c.shardGroups(c.findGenerations(true)) {
+		cGroups = append(cGroups, c.planOptimize(generations)...)
+	}
+
+	if !c.acquire(cGroups) {
+		return nil
+	}
+
+	return cGroups
+}
 
+// planOptimize returns the groups of TSM files to optimize within the
+// generations of a shard group.
+func (c *DefaultPlanner) planOptimize(generations tsmGenerations) []CompactionGroup {
 	// If there is only one generation and no tombstones, then there's nothing to
 	// do.
 	if len(generations) <= 1 && !generations.hasTombstones() {
@@ -396,10 +448,6 @@ func (c *DefaultPlanner) PlanOptimize() []CompactionGroup {
 		cGroups = append(cGroups, cGroup)
 	}
 
-	if !c.acquire(cGroups) {
-		return nil
-	}
-
 	return cGroups
 }
 
@@ -407,6 +455,7 @@ func (c *DefaultPlanner) PlanOptimize() []CompactionGroup {
 // multiple groups if possible to allow compactions to run concurrently.
 func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
 	generations := c.findGenerations(true)
+	shardGroups := c.shardGroups(generations)
 
 	c.mu.RLock()
 	forceFull := c.forceFull
@@ -422,47 +471,20 @@ func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
 			c.mu.Unlock()
 		}
 
-		var tsmFiles []string
-		var genCount int
-		for i, group := range generations {
-			var skip bool
-
-			// Skip the file if it's over the max size and contains a full block and it does not have any tombstones
-			if len(generations) > 2 && group.size() > uint64(maxTSMFileSize) && c.FileStore.BlockCount(group.files[0].Path, 1) == MaxPointsPerBlock && !group.hasTombstones() {
-				skip = true
-			}
-
-			// We need to look at the level of the next file because it may need to be combined with this generation
-			// but won't get picked up on it's own if this generation is skipped.  This allows the most recently
-			// created files to get picked up by the full compaction planner and avoids having a few less optimally
-			// compressed files.
-			if i < len(generations)-1 {
-				if generations[i+1].level() <= 3 {
-					skip = false
-				}
-			}
-
-			if skip {
-				continue
-			}
-
-			for _, f := range group.files {
-				tsmFiles = append(tsmFiles, f.Path)
+		var groups []CompactionGroup
+		for _, gens := range shardGroups {
+			if group := c.planFull(gens); group != nil {
+				groups = append(groups, group)
 			}
-			genCount += 1
 		}
-		sort.Strings(tsmFiles)
-
-		// Make sure we have more than 1 file and more than 1 generation
-		if len(tsmFiles) <= 1 || genCount <= 1 {
+		if len(groups) == 0 {
 			return nil
 		}
 
-		group := []CompactionGroup{tsmFiles}
-		if !c.acquire(group) {
+		if !c.acquire(groups) {
 			return nil
 		}
-		return group
+		return groups
 	}
 
 	// don't plan if nothing has changed in the filestore
@@ -472,6 +494,61 @@ func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
 
 	c.lastPlanCheck = time.Now()
 
+	var tsmFiles []CompactionGroup
+	for _, gens := range shardGroups {
+		tsmFiles = append(tsmFiles, c.plan(gens)...)
+	}
+
+	if !c.acquire(tsmFiles) {
+		return nil
+	}
+	return tsmFiles
+}
+
+// planFull returns the TSM files of a full compaction of the generations of a
+// shard group.
+func (c *DefaultPlanner) planFull(generations tsmGenerations) CompactionGroup {
+	var tsmFiles []string
+	var genCount int
+	for i, group := range generations {
+		var skip bool
+
+		// Skip the file if it's over the max size and contains a full block and it does not have any tombstones
+		if len(generations) > 2 && group.size() > uint64(maxTSMFileSize) && c.FileStore.BlockCount(group.files[0].Path, 1) == MaxPointsPerBlock && !group.hasTombstones() {
+			skip = true
+		}
+
+		// We need to look at the level of the next file because it may need to be combined with this generation
+		// but won't get picked up on it's own if this generation is skipped.  This allows the most recently
+		// created files to get picked up by the full compaction planner and avoids having a few less optimally
+		// compressed files.
+		if i < len(generations)-1 {
+			if generations[i+1].level() <= 3 {
+				skip = false
+			}
+		}
+
+		if skip {
+			continue
+		}
+
+		for _, f := range group.files {
+			tsmFiles = append(tsmFiles, f.Path)
+		}
+		genCount += 1
+	}
+	sort.Strings(tsmFiles)
+
+	// Make sure we have more than 1 file and more than 1 generation
+	if len(tsmFiles) <= 1 || genCount <= 1 {
+		return nil
+	}
+	return tsmFiles
+}
+
+// plan returns the groups of level 4 or higher TSM files to rewrite within the
+// generations of a shard group.
+func (c *DefaultPlanner) plan(generations tsmGenerations) []CompactionGroup {
 	// If there is only one generation, return early to avoid re-compacting the same file
 	// over and over again.
 	if len(generations) <= 1 && !generations.hasTombstones() {
@@ -594,11 +671,48 @@ func (c *DefaultPlanner) Plan(lastWrite time.Time) []CompactionGroup {
 		sort.Strings(cGroup)
 		tsmFiles = append(tsmFiles, cGroup)
 	}
+	return tsmFiles
+}
 
-	if !c.acquire(tsmFiles) {
+// PlanShardGroupSplit returns the TSM files holding the data of more than one
+// shard group, each in its own compaction group.
+func (c *DefaultPlanner) PlanShardGroupSplit() []CompactionGroup {
+	var cGroups []CompactionGroup
+	for _, gen := range c.findGenerations(true) {
+		for _, f := range gen.files {
+			if _, ok := fileShardGroup(f, c.ShardGroupDuration); !ok {
+				cGroups = append(cGroups, CompactionGroup{f.Path})
+			}
+		}
+	}
+
+	if !c.acquire(cGroups) {
 		return nil
 	}
-	return tsmFiles
+
+	return cGroups
+}
+
+// shardGroups splits the generations by shard group, keeping their order.
+// Generations spanning more than one shard group are left out until they
+// have been split.
+func (c *DefaultPlanner) shardGroups(generations tsmGenerations) []tsmGenerations {
+	var groups []tsmGenerations
+	index := make(map[shardGroup]int)
+	for _, gen := range generations {
+		if gen.spansShardGroups(c.ShardGroupDuration) {
+			continue
+		}
+		g, _ := fileShardGroup(gen.files[0], c.ShardGroupDuration)
+		i, ok := index[g]
+		if !ok {
+			i = len(groups)
+			index[g] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], gen)
+	}
+	return groups
 }
 
 // findGenerations groups all the TSM files by generation based
@@ -689,6 +803,10 @@ type Compactor struct {
 	Dir  string
 	Size int
 
+	// ShardGroupDuration is the duration of the time window of the shard
+	// groups the written TSM files are split by.
+	ShardGroupDuration time.Duration
+
 	FileStore interface {
 		SetCurrentGenerationFunc(func() int)
 		NextGeneration() int
@@ -842,16 +960,24 @@ func (c *Compactor) WriteSnapshot(ctx context.Context, cache *Cache) ([]string,
 		throttle = false
 	}
 
-	splits := cache.Split(concurrency)
+	// Each shard group is written to its own generation, so that compactions
+	// never mix shard groups. A single shard group is split for concurrency.
+	splits := cache.splitShardGroups(c.ShardGroupDuration)
+	if len(splits) == 1 {
+		splits = splits[0].Split(concurrency)
+	}
 
 	type res struct {
 		files []string
 		err   error
 	}
 
-	resC := make(chan res, concurrency)
-	for i := 0; i < concurrency; i++ {
+	limit := limiter.NewFixed(concurrency)
+	resC := make(chan res, len(splits))
+	for i := range splits {
+		limit.Take()
 		go func(sp *Cache) {
+			defer limit.Release()
 			iter := NewCacheKeyIterator(sp, MaxPointsPerBlock, intC)
 			files, err := c.writeNewFiles(c.FileStore.NextGeneration(), 0, nil, iter, throttle)
 			resC <- res{files: files, err: err}
@@ -860,8 +986,8 @@ func (c *Compactor) WriteSnapshot(ctx context.Context, cache *Cache) ([]string,
 	}
 
 	var err error
-	files := make([]string, 0, concurrency)
-	for i := 0; i < concurrency; i++ {
+	files := make([]string, 0, len(splits))
+	for i := 0; i < len(splits); i++ {
 		result := <-resC
 		if result.err != nil {
 			err = result.err
@@ -1014,6 +1140,96 @@ func (c *Compactor) CompactFast(tsmFiles []string) ([]string, error) {
 
 }
 
+// WriteShardGroups rewrites a TSM file holding the data of more than one
+// shard group into new TSM files, each holding the data of a single shard
+// group. The file is read once, writing each block to the files of its shard
+// group as it goes. The new files keep the compaction level of the file.
+func (c *Compactor) WriteShardGroups(tsmFile string) (files []string, err error) {
+	_, seq, err := c.parseFileName(tsmFile)
+	if err != nil {
+		return nil, err
+	}
+
+	tr := c.FileStore.TSMReader(tsmFile)
+	if tr == nil {
+		return nil, fmt.Errorf("TSM file not found: %s", tsmFile)
+	}
+	defer tr.Unref()
+
+	iter, err := NewTSMBatchKeyIterator(MaxPointsPerBlock, false, nil, tr)
+	if err != nil {
+		return nil, err
+	}
+
+	writers := make(map[shardGroup]*shardGroupWriter)
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, w := range writers {
+			if rerr := w.remove(); rerr != nil {
+				err = rerr
+			}
+		}
+	}()
+
+	var (
+		key    []byte
+		values []Value
+	)
+	write := func(g shardGroup, minTime, maxTime int64, block []byte) error {
+		w := writers[g]
+		if w == nil {
+			w = &shardGroupWriter{
+				dir:        c.Dir,
+				generation: c.FileStore.NextGeneration(),
+				sequence:   seq - 1,
+				format:     c.formatFileName,
+			}
+			writers[g] = w
+		}
+		return w.WriteBlock(key, minTime, maxTime, block)
+	}
+
+	for iter.Next() {
+		c.mu.RLock()
+		enabled := c.snapshotsEnabled || c.compactionsEnabled
+		c.mu.RUnlock()
+
+		if !enabled {
+			return nil, errCompactionAborted{}
+		}
+
+		var minTime, maxTime int64
+		var block []byte
+		key, minTime, maxTime, block, err = iter.Read()
+		if err != nil {
+			return nil, err
+		}
+		if values, err = splitShardGroupBlock(key, minTime, maxTime, block, c.ShardGroupDuration, values, write); err != nil {
+			return nil, err
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	groups := make([]shardGroup, 0, len(writers))
+	for g := range writers {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].less(groups[j]) })
+
+	for _, g := range groups {
+		w := writers[g]
+		if err := w.finish(); err != nil {
+			return nil, err
+		}
+		files = append(files, w.files...)
+	}
+	return files, nil
+}
+
 // removeTmpFiles is responsible for cleaning up a compaction that
 // was started, but then abandoned before the temporary files were dealt with.
 func (c *Compactor) removeTmpFiles(files []string) error {
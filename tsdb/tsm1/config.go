@@ -15,6 +15,10 @@ const (
 	// DefaultLargeSeriesWriteThreshold is the number of series per write
 	// that requires the series index be pregrown before insert.
 	DefaultLargeSeriesWriteThreshold = 10000
+
+	// DefaultShardGroupDuration is the default duration of the time window
+	// TSM files are partitioned by.
+	DefaultShardGroupDuration = 24 * time.Hour
 )
 
 // Config contains all of the configuration necessary to run a tsm1 engine.
@@ -33,6 +37,11 @@ type Config struct {
 	// preallocation to improve throughput. Currently used in the series file.
 	LargeSeriesWriteThreshold int `toml:"large-series-write-threshold"`
 
+	// ShardGroupDuration is the duration of the time window the TSM files of
+	// each bucket are partitioned by. Data expired by retention is removed a
+	// whole shard group at a time.
+	ShardGroupDuration toml.Duration `toml:"shard-group-duration"`
+
 	Compaction CompactionConfig `toml:"compaction"`
 	Cache      CacheConfig      `toml:"cache"`
 }
@@ -43,6 +52,7 @@ func NewConfig() Config {
 		MaxConcurrentOpens:        DefaultMaxConcurrentOpens,
 		MADVWillNeed:              DefaultMADVWillNeed,
 		LargeSeriesWriteThreshold: DefaultLargeSeriesWriteThreshold,
+		ShardGroupDuration:        toml.Duration(DefaultShardGroupDuration),
 
 		Cache: NewCacheConfig(),
 		Compaction: CompactionConfig{
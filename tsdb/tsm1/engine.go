@@ -187,6 +187,7 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 	c := NewCompactor()
 	c.Dir = path
 	c.FileStore = fs
+	c.ShardGroupDuration = time.Duration(config.ShardGroupDuration)
 	c.RateLimit = limiter.NewRate(
 		int(config.Compaction.Throughput),
 		int(config.Compaction.ThroughputBurst))
@@ -211,6 +212,9 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 		maxCompactions = runtime.GOMAXPROCS(0)
 	}
 
+	planner := NewDefaultPlanner(fs, time.Duration(config.Compaction.FullWriteColdDuration))
+	planner.ShardGroupDuration = c.ShardGroupDuration
+
 	logger := zap.NewNop()
 	e := &Engine{
 		path:   path,
@@ -222,8 +226,7 @@ func NewEngine(path string, idx *tsi1.Index, config Config, options ...EngineOpt
 
 		FileStore: fs,
 		Compactor: c,
-		CompactionPlan: NewDefaultPlanner(fs,
-			time.Duration(config.Compaction.FullWriteColdDuration)),
+		CompactionPlan: planner,
 
 		CacheFlushMemorySizeThreshold:  uint64(config.Cache.SnapshotMemorySize),
 		CacheFlushWriteColdDuration:    time.Duration(config.Cache.SnapshotWriteColdDuration),
@@ -472,6 +475,12 @@ func (e *Engine) ScheduleFullCompaction(ctx context.Context) error {
 // Path returns the path the engine was opened with.
 func (e *Engine) Path() string { return e.path }
 
+// NextTSMFilePath returns the temporary path of a new TSM file of the next
+// generation. Once written, the file is added to the engine with FileStore.Replace.
+func (e *Engine) NextTSMFilePath() string {
+	return filepath.Join(e.path, e.formatFileName(e.FileStore.NextGeneration(), 1)+"."+TSMFileExtension+"."+TmpTSMFileExtension)
+}
+
 func (e *Engine) SetFieldName(measurement []byte, name string) {
 	e.index.SetFieldName(measurement, name)
 }
@@ -568,6 +577,50 @@ func (e *Engine) Open(ctx context.Context) (err error) {
 	return nil
 }
 
+// SplitShardGroups rewrites the TSM files holding the data of more than one
+// shard group, such as files added to the file store from outside the engine,
+// into files holding the data of a single shard group.
+func (e *Engine) SplitShardGroups(ctx context.Context) error {
+	e.disableLevelCompactions(true)
+	defer e.enableLevelCompactions(true)
+	return e.splitShardGroups(ctx)
+}
+
+// splitShardGroups rewrites the TSM files holding the data of more than one
+// shard group.
+func (e *Engine) splitShardGroups(ctx context.Context) error {
+	span, _ := tracing.StartSpanFromContext(ctx)
+	defer span.Finish()
+
+	for _, f := range e.FileStore.Stats() {
+		if _, ok := fileShardGroup(f, e.Compactor.ShardGroupDuration); ok {
+			continue
+		}
+		if err := e.splitShardGroupFile(f.Path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitShardGroupFile replaces the TSM file with files each holding the data
+// of a single shard group.
+func (e *Engine) splitShardGroupFile(path string) error {
+	files, err := e.Compactor.WriteShardGroups(path)
+	if _, ok := err.(errCompactionAborted); ok {
+		return err
+	} else if err != nil {
+		return fmt.Errorf("error splitting TSM file %s by shard group: %v", path, err)
+	}
+	if err := e.FileStore.Replace([]string{path}, files); err != nil {
+		return fmt.Errorf("error replacing TSM file %s: %v", path, err)
+	}
+	e.logger.Info("Split TSM file by shard group",
+		zap.String("path", path),
+		zap.Int("files", len(files)))
+	return nil
+}
+
 // Close closes the engine. Subsequent calls to Close are a nop.
 func (e *Engine) Close() error {
 	e.SetCompactionsEnabled(false)
@@ -1000,6 +1053,15 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 
 			span, ctx := tracing.StartSpanFromContext(context.Background())
 
+			// Split any files holding the data of more than one shard group,
+			// such as data directories written before TSM files were
+			// partitioned by shard group.
+			splitGroups := e.CompactionPlan.PlanShardGroupSplit()
+			if len(splitGroups) > 0 && e.splitShardGroupsInBackground(splitGroups[0], wg) {
+				splitGroups = splitGroups[1:]
+			}
+			e.CompactionPlan.Release(splitGroups)
+
 			// Find our compaction plans
 			level1Groups := e.CompactionPlan.PlanLevel(1)
 			level2Groups := e.CompactionPlan.PlanLevel(2)
@@ -1061,6 +1123,32 @@ func (e *Engine) compact(wg *sync.WaitGroup) {
 	}
 }
 
+// splitShardGroupsInBackground kicks off the split of the file of the group by
+// shard group using the compaction limiter. It returns true if the split was
+// started.
+func (e *Engine) splitShardGroupsInBackground(grp CompactionGroup, wg *sync.WaitGroup) bool {
+	if !e.compactionLimiter.TryTake() {
+		return false
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer e.compactionLimiter.Release()
+		defer e.CompactionPlan.Release([]CompactionGroup{grp})
+
+		for _, path := range grp {
+			if err := e.splitShardGroupFile(path); err != nil {
+				if _, ok := err.(errCompactionAborted); !ok {
+					e.logger.Warn("Error splitting TSM file by shard group", zap.Error(err))
+				}
+				return
+			}
+		}
+	}()
+	return true
+}
+
 // compactHiPriorityLevel kicks off compactions using the high priority policy. It returns
 // true if the compaction was started
 func (e *Engine) compactHiPriorityLevel(ctx context.Context, grp CompactionGroup, level compactionLevel, fast bool, wg *sync.WaitGroup) bool {
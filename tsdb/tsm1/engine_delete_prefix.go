@@ -14,6 +14,7 @@ import (
 	"github.com/influxdata/influxdb/v2/tsdb"
 	"github.com/influxdata/influxdb/v2/tsdb/tsi1"
 	"github.com/influxdata/influxql"
+	"go.uber.org/zap"
 )
 
 // DeletePrefixRange removes all TSM data belonging to a bucket, and removes all index
@@ -80,6 +81,20 @@ func (e *Engine) DeletePrefixRange(rootCtx context.Context, name []byte, min, ma
 	}
 	possiblyDead.keys = make(map[string]struct{})
 
+	// TSM files only holding data of the prefix within the time range are removed
+	// rather than tombstoned. As TSM files are partitioned by shard group, this
+	// leaves only the shard groups overlapping the bounds of the range to tombstone.
+	if pred == nil {
+		span, _ = tracing.StartSpanFromContextWithOperationName(rootCtx, "drop TSM files")
+		err := e.dropPrefixFiles(name, min, max, func(key []byte) {
+			possiblyDead.keys[string(key)] = struct{}{}
+		})
+		span.Finish()
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := e.FileStore.Apply(func(r TSMFile) error {
 		var predClone Predicate // Apply executes concurrently across files.
 		if pred != nil {
@@ -301,3 +316,44 @@ func (e *Engine) DeletePrefixRange(rootCtx context.Context, name []byte, min, ma
 
 	return nil
 }
+
+// dropPrefixFiles removes the TSM files whose keys all have the prefix name and
+// whose data lies within min and max. fn is called with each key of the files.
+func (e *Engine) dropPrefixFiles(name []byte, min, max int64, fn func(key []byte)) error {
+	var paths []string
+	for _, f := range e.FileStore.Stats() {
+		if f.MinTime < min || f.MaxTime > max || !bytes.HasPrefix(f.MinKey, name) || !bytes.HasPrefix(f.MaxKey, name) {
+			continue
+		}
+
+		r := e.FileStore.TSMReader(f.Path)
+		if r == nil {
+			continue
+		}
+		iter := r.Iterator(name)
+		for iter.Next() {
+			key := iter.Key()
+			if !bytes.HasPrefix(key, name) {
+				break
+			}
+			fn(key)
+		}
+		err := iter.Err()
+		r.Unref()
+		if err != nil {
+			return err
+		}
+		paths = append(paths, f.Path)
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+	if err := e.FileStore.Replace(paths, nil); err != nil {
+		return err
+	}
+	e.logger.Info("Dropped TSM files of deleted range",
+		zap.String("name_prefix", fmt.Sprintf("%x", name)),
+		zap.Int("files", len(paths)))
+	return nil
+}
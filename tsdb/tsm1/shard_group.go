@@ -0,0 +1,225 @@
+package tsm1
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/influxdata/influxdb/v2/models"
+	"go.uber.org/multierr"
+)
+
+// Shard groups partition the TSM files of the engine by bucket and time window.
+// Every TSM file holds the data of a single bucket within a single window of
+// ShardGroupDuration, and files are only ever compacted with files of the same
+// shard group. Expiring the data of a bucket is therefore a matter of removing
+// the files of its expired shard groups rather than writing tombstones.
+
+// shardGroup identifies the bucket and time window of a TSM file.
+type shardGroup struct {
+	name  string // measurement name of the bucket
+	start int64  // start of the time window, inclusive
+}
+
+// newShardGroup returns the shard group of the value of key at time t.
+func newShardGroup(key []byte, t int64, d time.Duration) shardGroup {
+	return shardGroup{name: shardGroupName(key), start: shardGroupStart(t, d)}
+}
+
+// end returns the end of the time window of g, exclusive.
+func (g shardGroup) end(d time.Duration) int64 {
+	if d <= 0 || g.start > math.MaxInt64-int64(d) {
+		return math.MaxInt64
+	}
+	return g.start + int64(d)
+}
+
+func (g shardGroup) less(o shardGroup) bool {
+	if g.name != o.name {
+		return g.name < o.name
+	}
+	return g.start < o.start
+}
+
+// shardGroupName returns the measurement name of the composite key.
+func shardGroupName(key []byte) string {
+	seriesKey, _ := SeriesAndFieldFromCompositeKey(key)
+	return string(models.ParseName(seriesKey))
+}
+
+// shardGroupStart returns the start of the time window of duration d holding t.
+// A non-positive d places all times in the same window.
+func shardGroupStart(t int64, d time.Duration) int64 {
+	if d <= 0 {
+		return math.MinInt64
+	}
+	start := t - t%int64(d)
+	if t%int64(d) < 0 {
+		if start < math.MinInt64+int64(d) {
+			return math.MinInt64
+		}
+		start -= int64(d)
+	}
+	return start
+}
+
+// fileShardGroup returns the shard group of the TSM file, and whether all of
+// the data of the file belongs to that shard group.
+func fileShardGroup(f FileStat, d time.Duration) (shardGroup, bool) {
+	g := newShardGroup(f.MinKey, f.MinTime, d)
+	return g, g == newShardGroup(f.MaxKey, f.MaxTime, d)
+}
+
+// splitShardGroups returns the contents of the cache split by shard group, in
+// shard group order. The values of the cache must be deduplicated.
+func (c *Cache) splitShardGroups(d time.Duration) []*Cache {
+	c.mu.RLock()
+	store := c.store
+	c.mu.RUnlock()
+
+	stores := make(map[shardGroup]*ring)
+	// applySerial cannot return an error in this invocation.
+	_ = store.applySerial(func(k string, e *entry) error {
+		e.mu.RLock()
+		values := e.values
+		e.mu.RUnlock()
+
+		key := []byte(k)
+		name := shardGroupName(key)
+		for len(values) > 0 {
+			g := shardGroup{name: name, start: shardGroupStart(values[0].UnixNano(), d)}
+			end := g.end(d)
+			n := sort.Search(len(values), func(i int) bool { return values[i].UnixNano() >= end })
+
+			r := stores[g]
+			if r == nil {
+				r = newRing()
+				stores[g] = r
+			}
+			r.add(key, &entry{values: values[:n], n: int64(n), vtype: e.vtype})
+			values = values[n:]
+		}
+		return nil
+	})
+
+	groups := make([]shardGroup, 0, len(stores))
+	for g := range stores {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].less(groups[j]) })
+
+	caches := make([]*Cache, len(groups))
+	for i, g := range groups {
+		caches[i] = &Cache{store: stores[g]}
+	}
+	return caches
+}
+
+// splitShardGroupBlock calls fn with the part of the block of key in each
+// shard group it holds data for, in time order. Blocks within a single shard
+// group are passed through, others are decoded and re-encoded per shard group.
+func splitShardGroupBlock(key []byte, minTime, maxTime int64, block []byte, d time.Duration, buf []Value, fn func(g shardGroup, minTime, maxTime int64, block []byte) error) ([]Value, error) {
+	g := newShardGroup(key, minTime, d)
+	if maxTime < g.end(d) {
+		return buf, fn(g, minTime, maxTime, block)
+	}
+
+	values, err := DecodeBlock(block, buf[:0])
+	if err != nil {
+		return buf, err
+	}
+	for vs := values; len(vs) > 0; {
+		g.start = shardGroupStart(vs[0].UnixNano(), d)
+		end := g.end(d)
+		n := sort.Search(len(vs), func(i int) bool { return vs[i].UnixNano() >= end })
+
+		b, err := Values(vs[:n]).Encode(nil)
+		if err != nil {
+			return values, err
+		}
+		if err := fn(g, vs[0].UnixNano(), vs[n-1].UnixNano(), b); err != nil {
+			return values, err
+		}
+		vs = vs[n:]
+	}
+	return values, nil
+}
+
+// shardGroupWriter writes the blocks of a single shard group into new TSM
+// files of one generation, rotating to a new file once the current one has
+// reached the max TSM file size.
+type shardGroupWriter struct {
+	dir        string
+	generation int
+	sequence   int
+	format     FormatFileNameFunc
+
+	w     TSMWriter
+	files []string
+}
+
+// WriteBlock writes the block to the current file, creating it if needed.
+func (w *shardGroupWriter) WriteBlock(key []byte, minTime, maxTime int64, block []byte) error {
+	if w.w == nil {
+		if err := w.create(); err != nil {
+			return err
+		}
+	}
+
+	err := w.w.WriteBlock(key, minTime, maxTime, block)
+	if err == ErrMaxBlocksExceeded || (err == nil && w.w.Size() > maxTSMFileSize) {
+		// The block was written; the next one goes to a new file.
+		return w.finish()
+	}
+	return err
+}
+
+func (w *shardGroupWriter) create() error {
+	w.sequence++
+	path := filepath.Join(w.dir, w.format(w.generation, w.sequence)+"."+TSMFileExtension+"."+TmpTSMFileExtension)
+	fd, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_EXCL, 0666)
+	if err != nil {
+		return errCompactionInProgress{err: err}
+	}
+	w.w, err = NewTSMWriter(fd)
+	if err != nil {
+		fd.Close()
+		return multierr.Append(err, os.Remove(path))
+	}
+	w.files = append(w.files, path)
+	return nil
+}
+
+// finish writes the index of the current file and closes it.
+func (w *shardGroupWriter) finish() error {
+	if w.w == nil {
+		return nil
+	}
+	tw := w.w
+	w.w = nil
+	if err := tw.WriteIndex(); err != nil {
+		return multierr.Append(err, tw.Remove())
+	}
+	return tw.Close()
+}
+
+// remove removes all the files written, including the current one.
+func (w *shardGroupWriter) remove() error {
+	var err error
+	if w.w != nil {
+		err = w.w.Remove()
+		w.w = nil
+		w.files = w.files[:len(w.files)-1]
+	}
+	for _, f := range w.files {
+		if rerr := os.Remove(f); rerr != nil && !os.IsNotExist(rerr) {
+			err = multierr.Append(err, rerr)
+		} else if rerr := os.Remove(StatsFilename(f)); rerr != nil && !os.IsNotExist(rerr) {
+			err = multierr.Append(err, rerr)
+		}
+	}
+	w.files = nil
+	return err
+}
@@ -0,0 +1,272 @@
+package tsm1_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb/v2/toml"
+	"github.com/influxdata/influxdb/v2/tsdb/tsm1"
+)
+
+const day = int64(24 * time.Hour)
+
+func TestCompactor_WriteSnapshot_ShardGroups(t *testing.T) {
+	dir := MustTempDir()
+	defer os.RemoveAll(dir)
+
+	c := tsm1.NewCache(0)
+	for k, v := range map[string][]tsm1.Value{
+		"mm0,host=A#!~#value": {tsm1.NewValue(1, 1.0), tsm1.NewValue(day+1, 2.0)},
+		"mm0,host=B#!~#value": {tsm1.NewValue(2, 3.0)},
+		"mm1,host=A#!~#value": {tsm1.NewValue(3, 4.0)},
+	} {
+		if err := c.Write([]byte(k), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compactor := tsm1.NewCompactor()
+	compactor.Dir = dir
+	compactor.FileStore = &generationFileStore{}
+	compactor.ShardGroupDuration = 24 * time.Hour
+	compactor.Open()
+
+	files, err := compactor.WriteSnapshot(context.Background(), c)
+	if err != nil {
+		t.Fatalf("unexpected error writing snapshot: %v", err)
+	}
+	if got, exp := len(files), 3; got != exp {
+		t.Fatalf("files length mismatch: got %v, exp %v", got, exp)
+	}
+
+	var keys int
+	for _, f := range files {
+		r := MustOpenTSMReader(f)
+		assertSingleShardGroup(t, r.Stats(), day)
+		keys += r.KeyCount()
+		r.Close()
+	}
+	if got, exp := keys, 4; got != exp {
+		t.Fatalf("keys mismatch: got %v, exp %v", got, exp)
+	}
+}
+
+func TestDefaultPlanner_PlanLevel_ShardGroups(t *testing.T) {
+	var data []tsm1.FileStat
+	for i := 0; i < 16; i++ {
+		name := []byte("mm0,host=A#!~#value")
+		if i%2 == 1 {
+			name = []byte("mm1,host=A#!~#value")
+		}
+		data = append(data, tsm1.FileStat{
+			Path:   tsm1.DefaultFormatFileName(i+1, 1) + ".tsm",
+			Size:   1024 * 1024,
+			MinKey: name,
+			MaxKey: name,
+		})
+	}
+
+	cp := tsm1.NewDefaultPlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsm1.DefaultCompactFullWriteColdDuration,
+	)
+	cp.ShardGroupDuration = 24 * time.Hour
+
+	tsm := cp.PlanLevel(1)
+	if exp, got := 2, len(tsm); got != exp {
+		t.Fatalf("tsm file length mismatch: got %v, exp %v", got, exp)
+	}
+	for i, group := range tsm {
+		if exp, got := 8, len(group); got != exp {
+			t.Fatalf("group %d length mismatch: got %v, exp %v", i, got, exp)
+		}
+		for j, path := range group {
+			if exp := data[2*j+i].Path; path != exp {
+				t.Fatalf("group %d file mismatch: got %v, exp %v", i, path, exp)
+			}
+		}
+	}
+}
+
+func TestDefaultPlanner_PlanShardGroupSplit(t *testing.T) {
+	var data []tsm1.FileStat
+	for i := 0; i < 8; i++ {
+		name := []byte("mm0,host=A#!~#value")
+		data = append(data, tsm1.FileStat{
+			Path:   tsm1.DefaultFormatFileName(i+1, 1) + ".tsm",
+			Size:   1024 * 1024,
+			MinKey: name,
+			MaxKey: name,
+		})
+	}
+	// A file holding the data of two buckets.
+	data[3].MaxKey = []byte("mm1,host=A#!~#value")
+
+	cp := tsm1.NewDefaultPlanner(
+		&fakeFileStore{
+			PathsFn: func() []tsm1.FileStat {
+				return data
+			},
+		}, tsm1.DefaultCompactFullWriteColdDuration,
+	)
+	cp.ShardGroupDuration = 24 * time.Hour
+
+	if cp.FullyCompacted() {
+		t.Fatal("expected a file spanning shard groups to not be fully compacted")
+	}
+	if tsm := cp.PlanLevel(1); len(tsm) != 0 {
+		t.Fatalf("unexpected level plan including a file spanning shard groups: %v", tsm)
+	}
+
+	tsm := cp.PlanShardGroupSplit()
+	if exp, got := 1, len(tsm); got != exp {
+		t.Fatalf("tsm file length mismatch: got %v, exp %v", got, exp)
+	}
+	if exp, got := (tsm1.CompactionGroup{data[3].Path}), tsm[0]; len(got) != 1 || got[0] != exp[0] {
+		t.Fatalf("split plan mismatch: got %v, exp %v", got, exp)
+	}
+	if tsm := cp.PlanShardGroupSplit(); len(tsm) != 0 {
+		t.Fatalf("unexpected split plan for a file in use: %v", tsm)
+	}
+}
+
+func TestEngine_DeletePrefixRange_DropsShardGroups(t *testing.T) {
+	config := tsm1.NewConfig()
+	config.ShardGroupDuration = toml.Duration(24 * time.Hour)
+	e, err := NewEngine(config, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	if err := e.writePoints(
+		MustParsePointString("cpu,host=A value=1.1 1", "mm0"),
+		MustParsePointString("cpu,host=A value=1.2 2", "mm0"),
+		MustParsePointString("cpu,host=B value=1.3 86400000000001", "mm0"),
+		MustParsePointString("cpu,host=A value=1.4 1", "mm1"),
+	); err != nil {
+		t.Fatalf("failed to write points: %s", err.Error())
+	}
+	if err := e.WriteSnapshot(context.Background(), tsm1.CacheStatusColdNoWrites); err != nil {
+		t.Fatalf("failed to snapshot: %s", err.Error())
+	}
+	if exp, got := 3, len(e.FileStore.Stats()); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+
+	if err := e.DeletePrefixRange(context.Background(), []byte("mm0"), 0, day-1, nil); err != nil {
+		t.Fatalf("failed to delete range: %v", err)
+	}
+
+	stats := e.FileStore.Stats()
+	if exp, got := 2, len(stats); exp != got {
+		t.Fatalf("file count mismatch: exp %v, got %v", exp, got)
+	}
+	for _, f := range stats {
+		if f.HasTombstone {
+			t.Fatalf("unexpected tombstone for file %s", f.Path)
+		}
+		if bytes.HasPrefix(f.MinKey, []byte("mm0")) && f.MinTime < day {
+			t.Fatalf("unexpected file of deleted shard group: %s", f.Path)
+		}
+	}
+
+	keys := e.FileStore.Keys()
+	if _, ok := keys["mm0,\x00=cpu,host=A,\xff=value#!~#value"]; ok {
+		t.Fatalf("unexpected deleted series in file store: %v", keys)
+	}
+	if exp, got := 2, len(keys); exp != got {
+		t.Fatalf("series count mismatch: exp %v, got %v", exp, got)
+	}
+}
+
+func TestEngine_SplitsShardGroupsInBackground(t *testing.T) {
+	config := tsm1.NewConfig()
+	config.ShardGroupDuration = toml.Duration(24 * time.Hour)
+	e, err := NewEngine(config, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer e.Close()
+
+	planner := tsm1.NewDefaultPlanner(e.FileStore, tsm1.DefaultCompactFullWriteColdDuration)
+	planner.ShardGroupDuration = 24 * time.Hour
+	e.CompactionPlan = planner
+
+	// A file written before TSM files were partitioned by shard group.
+	if err := os.MkdirAll(e.Path(), 0777); err != nil {
+		t.Fatal(err)
+	}
+	values := map[string][]tsm1.Value{
+		"mm0,host=A#!~#value": {tsm1.NewValue(1, 1.0), tsm1.NewValue(2, 2.0), tsm1.NewValue(day+1, 3.0)},
+		"mm1,host=A#!~#value": {tsm1.NewValue(day+2, 4.0)},
+	}
+	MustWriteTSM(e.Path(), 1, values)
+
+	if err := e.Open(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The file is split by the compaction loop rather than by Open.
+	var stats []tsm1.FileStat
+	for deadline := time.Now().Add(10 * time.Second); ; time.Sleep(100 * time.Millisecond) {
+		stats = e.FileStore.Stats()
+		if len(stats) == 3 {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatalf("file count mismatch: exp %v, got %v", 3, len(stats))
+		}
+	}
+
+	read := make(map[string]int)
+	for _, f := range stats {
+		assertSingleShardGroup(t, f, day)
+
+		r := MustOpenTSMReader(f.Path)
+		for key := range values {
+			vals, err := r.ReadAll([]byte(key))
+			if err != nil {
+				t.Fatal(err)
+			}
+			read[key] += len(vals)
+		}
+		r.Close()
+	}
+	for key, vals := range values {
+		if exp, got := len(vals), read[key]; exp != got {
+			t.Fatalf("value count mismatch for %s: exp %v, got %v", key, exp, got)
+		}
+	}
+}
+
+func assertSingleShardGroup(t *testing.T, f tsm1.FileStat, d int64) {
+	t.Helper()
+	minName := bytes.SplitN(f.MinKey, []byte(","), 2)[0]
+	maxName := bytes.SplitN(f.MaxKey, []byte(","), 2)[0]
+	if !bytes.Equal(minName, maxName) {
+		t.Fatalf("file %s holds more than one bucket: %s, %s", f.Path, minName, maxName)
+	}
+	if f.MinTime/d != f.MaxTime/d {
+		t.Fatalf("file %s spans more than one shard group: %d, %d", f.Path, f.MinTime, f.MaxTime)
+	}
+}
+
+// generationFileStore is a fakeFileStore returning increasing generations.
+type generationFileStore struct {
+	fakeFileStore
+	gen int64
+}
+
+func (w *generationFileStore) NextGeneration() int {
+	return int(atomic.AddInt64(&w.gen, 1))
+}
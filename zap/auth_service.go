@@ -2,6 +2,7 @@ package zap
 
 import (
 	"context"
+	"time"
 
 	platform "github.com/influxdata/influxdb/v2"
 	"go.uber.org/zap"
@@ -70,6 +71,17 @@ func (s *AuthorizationService) DeleteAuthorization(ctx context.Context, id platf
 	return s.AuthorizationService.DeleteAuthorization(ctx, id)
 }
 
+// RotateAuthorization issues a new token for an authorization and logs any errors.
+func (s *AuthorizationService) RotateAuthorization(ctx context.Context, id platform.ID, grace time.Duration) (a *platform.Authorization, err error) {
+	defer func() {
+		if err != nil {
+			s.log.Info("Error rotating authorization", zap.Error(err))
+		}
+	}()
+
+	return s.AuthorizationService.RotateAuthorization(ctx, id, grace)
+}
+
 // UpdateAuthorization updates an authorization's status, description and logs any errors.
 func (s *AuthorizationService) UpdateAuthorization(ctx context.Context, id platform.ID, upd *platform.AuthorizationUpdate) (a *platform.Authorization, err error) {
 	defer func() {